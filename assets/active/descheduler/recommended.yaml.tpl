@@ -18,6 +18,12 @@
 {{- else if objectExists "PrometheusRule" "openshift-kube-descheduler-operator" "descheduler-rules" -}}
   {{- $devActualUtilizationProfile = "PrometheusCPUCombined" -}}
 {{- end -}}
+{{- $hints := .Descheduler -}}
+{{- $relieveAndMigrate := hasSuffix "KubeVirtRelieveAndMigrate" $preferredProfile -}}
+{{- $interval := 60 -}}
+{{- if $hints.Tuned -}}
+  {{- $interval = $hints.IntervalSeconds -}}
+{{- end -}}
 apiVersion: operator.openshift.io/v1
 kind: KubeDescheduler
 metadata:
@@ -26,10 +32,10 @@ metadata:
 spec:
   managementState: Managed
   mode: Automatic
-  deschedulingIntervalSeconds: 60
+  deschedulingIntervalSeconds: {{ $interval }}
   profiles:
     - {{ $preferredProfile }}
-  {{- if or $needsEvictionsInBackground $devActualUtilizationProfile }}
+  {{- if or $needsEvictionsInBackground $devActualUtilizationProfile $hints.Tuned }}
   profileCustomizations:
     {{- if $needsEvictionsInBackground }}
     devEnableEvictionsInBackground: true
@@ -37,9 +43,20 @@ spec:
     {{- if $devActualUtilizationProfile }}
     devActualUtilizationProfile: {{ $devActualUtilizationProfile }}
     {{- end }}
+    {{- if $hints.Tuned }}
+    {{- if $relieveAndMigrate }}
+    devDeviationThresholds: {{ $hints.DeviationThresholds }}
+    {{- else }}
+    devLowNodeUtilizationThresholds: {{ $hints.LowNodeUtilizationThresholds }}
+    {{- end }}
+    {{- end }}
   {{- end }}
   evictionLimits:
     {{- $migTotal := dig "spec" "virtualization" "liveMigrationConfig" "parallelMigrationsPerCluster" 5 .HCO.Object }}
-    total: {{ $migTotal }}
     {{- $migNode := dig "spec" "virtualization" "liveMigrationConfig" "parallelOutboundMigrationsPerNode" 2 .HCO.Object }}
+    {{- with .LiveMigration }}
+    {{- $migTotal = .ParallelMigrationsPerCluster | default $migTotal }}
+    {{- $migNode = .ParallelOutboundMigrationsPerNode | default $migNode }}
+    {{- end }}
+    total: {{ $migTotal }}
     node: {{ $migNode }}
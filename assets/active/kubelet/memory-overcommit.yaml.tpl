@@ -0,0 +1,24 @@
+{{- $percent := dig "spec" "higherWorkloadDensity" "memoryOvercommitPercentage" 100 .HCO.Object }}
+{{- if le $percent 100 }}
+# autopilot:skip reason=higherWorkloadDensity.memoryOvercommitPercentage is not above 100
+{{- else }}
+# Memory overcommit lets virt-launcher request less than the guest memory, so nodes run
+# closer to the limit: start soft eviction earlier, scaled with the overcommit ratio
+# (5% at 110, capped at 10%), so the kubelet reclaims before hard eviction kills VMs.
+# evictionHard is left alone: setting any of its keys drops the kubelet defaults.
+{{- $threshold := min 10 (add 4 (div (sub $percent 100) 10)) }}
+apiVersion: machineconfiguration.openshift.io/v1
+kind: KubeletConfig
+metadata:
+  name: virt-memory-overcommit
+spec:
+  kubeletConfig:
+    evictionSoft:
+      memory.available: {{ printf "%d%%" $threshold | quote }}
+    evictionSoftGracePeriod:
+      memory.available: 1m30s
+    evictionPressureTransitionPeriod: 2m
+  machineConfigPoolSelector:
+    matchLabels:
+      pools.operator.machineconfiguration.openshift.io/worker: ""
+{{- end }}
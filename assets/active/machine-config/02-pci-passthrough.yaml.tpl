@@ -1,13 +1,19 @@
 {{- if or .Hardware.PCIDevicesPresent .Hardware.GPUPresent }}
+{{- /* intel_iommu is an x86 kernel argument: on mixed-arch clusters target only amd64 nodes */}}
+{{- $role := .Architecture.RoleFor "amd64" }}
+{{- if not $role }}
+# autopilot:skip reason=no MachineConfig role reaches only amd64 nodes
+{{- else }}
 apiVersion: machineconfiguration.openshift.io/v1
 kind: MachineConfig
 metadata:
   name: 50-virt-pci-passthrough
   labels:
-    machineconfiguration.openshift.io/role: worker
+    machineconfiguration.openshift.io/role: {{ $role }}
 spec:
   kernelArguments:
   # according to https://docs.redhat.com/en/documentation/openshift_container_platform/4.20/html/hardware_networks/configuring-sriov-device#nw-sriov-configuring-device_configuring-sriov-device
     - intel_iommu=on
     - iommu=pt
 {{- end }}
+{{- end }}
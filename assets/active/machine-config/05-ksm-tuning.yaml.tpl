@@ -0,0 +1,35 @@
+{{- $ksm := dig "spec" "ksmConfiguration" "" .HCO.Object }}
+{{- if not $ksm }}
+# autopilot:skip reason=KSM is not enabled on the HCO (spec.ksmConfiguration unset)
+{{- else }}
+# virt-handler starts and stops KSM on the nodes selected by spec.ksmConfiguration, but
+# only toggles run and the scan rate. Pages merged across NUMA nodes make guests pay
+# remote-memory latency, and merge_across_nodes can only be changed while nothing is
+# merged, so set it at boot before the kubelet (and thus virt-handler) comes up.
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  labels:
+    machineconfiguration.openshift.io/role: worker
+  name: 90-worker-ksm-tuning
+spec:
+  config:
+    ignition:
+      version: 3.5.0
+    systemd:
+      units:
+      - contents: |
+          [Unit]
+          Description=KubeVirt KSM tuning
+          ConditionPathExists=/sys/kernel/mm/ksm/merge_across_nodes
+
+          [Service]
+          Type=oneshot
+          ExecStart=/bin/sh -c "echo 0 > /sys/kernel/mm/ksm/merge_across_nodes && echo 1 > /sys/kernel/mm/ksm/use_zero_pages"
+          RemainAfterExit=true
+
+          [Install]
+          RequiredBy=kubelet-dependencies.target
+        enabled: true
+        name: kubevirt-ksm-tuning.service
+{{- end }}
@@ -0,0 +1,47 @@
+{{- $hugepages := .HugePages }}
+{{- range $hugepages.Pools }}
+{{- if not .Ready }}
+# autopilot:warning message=no hugepages for pool {{ .Name }}: {{ .Reason }}
+{{- end }}
+{{- end }}
+{{- if not $hugepages.Ready }}
+# autopilot:skip reason={{ $hugepages.Reason | default "no MachineConfigPool can hold the requested hugepages" }}
+{{- else }}
+# Sized from the HCO density hints (pkg/hugepages): {{ $hugepages.VMsPerNode }} VMs of {{ $hugepages.PagesPerVM }}x{{ $hugepages.PageSize }} per node.
+# The script splits them over the NUMA nodes it finds at boot, as the Node Tuning Operator
+# does, so a VM fits on one; it runs before the kubelet, which then reports the capacity.
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  labels:
+    machineconfiguration.openshift.io/role: {{ $hugepages.Role }}
+  name: 50-{{ $hugepages.Role }}-kubevirt-hugepages
+spec:
+  config:
+    ignition:
+      version: 3.5.0
+    storage:
+      files:
+      - contents:
+          source: data:text/plain;charset=utf-8;base64,{{ readAsset "machine-config/06-hugepages/kubevirt-hugepages.sh" | b64enc }}
+        mode: 493
+        overwrite: true
+        path: /usr/local/bin/kubevirt-hugepages.sh
+    systemd:
+      units:
+      - contents: |
+          [Unit]
+          Description=KubeVirt hugepages for {{ $hugepages.VMsPerNode }} VMs of {{ $hugepages.PagesPerVM }}x{{ $hugepages.PageSize }}
+          ConditionPathExists=/sys/devices/system/node/node0/hugepages/hugepages-{{ $hugepages.PageSizeKB }}kB
+          Before=kubelet-dependencies.target
+
+          [Service]
+          Type=oneshot
+          ExecStart=/usr/local/bin/kubevirt-hugepages.sh {{ $hugepages.VMsPerNode }} {{ $hugepages.PagesPerVM }} {{ $hugepages.PageSizeKB }} {{ $hugepages.MaxPercent }}
+          RemainAfterExit=true
+
+          [Install]
+          RequiredBy=kubelet-dependencies.target
+        enabled: true
+        name: kubevirt-hugepages.service
+{{- end }}
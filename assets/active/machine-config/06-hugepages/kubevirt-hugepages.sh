@@ -0,0 +1,34 @@
+#!/bin/sh
+# Allocate hugepages for VMs, spread evenly over the NUMA nodes of this host so that
+# none of the VMs has to span two of them.
+#
+# Usage: kubevirt-hugepages.sh VMS PAGES_PER_VM PAGE_SIZE_KB MAX_PERCENT
+#
+# Each NUMA node gets the pages of ceil(VMS / NUMA nodes) VMs, unless that exceeds
+# MAX_PERCENT of its memory: that node is then left alone rather than starved.
+set -eu
+
+vms=$1
+pages_per_vm=$2
+page_size_kb=$3
+max_percent=$4
+
+numa_nodes=$(ls -d /sys/devices/system/node/node[0-9]* | wc -l)
+per_node=$(( (vms + numa_nodes - 1) / numa_nodes * pages_per_vm ))
+
+for node in /sys/devices/system/node/node[0-9]*; do
+    name=${node##*/}
+    # "Node 0 MemTotal:  131596288 kB"; includes pages allocated on an earlier boot
+    total_kb=$(awk '/MemTotal/ { print $4 }' "$node/meminfo")
+    if [ $(( per_node * page_size_kb )) -gt $(( total_kb * max_percent / 100 )) ]; then
+        echo "$name: $per_node pages of ${page_size_kb}kB exceed ${max_percent}% of ${total_kb}kB, not allocating" >&2
+        continue
+    fi
+
+    nr_hugepages="$node/hugepages/hugepages-${page_size_kb}kB/nr_hugepages"
+    echo "$per_node" > "$nr_hugepages"
+    allocated=$(cat "$nr_hugepages")
+    if [ "$allocated" -lt "$per_node" ]; then
+        echo "$name: only $allocated of $per_node pages of ${page_size_kb}kB could be allocated" >&2
+    fi
+done
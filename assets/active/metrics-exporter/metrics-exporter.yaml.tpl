@@ -18,15 +18,25 @@ spec:
       labels:
         app: kubevirt-metrics-exporter
     spec:
-      serviceAccountName: kubevirt-metrics-exporter
+      serviceAccountName: {{ index .Outputs "metrics-exporter-serviceaccount" "name" }}
       hostPID: true
+      {{- /* The exporter reads VM processes, so it runs wherever the HCO places workloads */}}
+      {{- $workloads := "" }}
+      {{- with .Placement }}{{ $workloads = .Workloads }}{{ end }}
+      {{- if and $workloads $workloads.NodeSelector }}
+      nodeSelector: {{ toJson $workloads.NodeSelector }}
+      {{- else }}
       nodeSelector:
         node-role.kubernetes.io/worker: ""
+      {{- end }}
+      {{- if and $workloads $workloads.Affinity }}
+      affinity: {{ toJson $workloads.Affinity }}
+      {{- end }}
       tolerations:
         - operator: Exists
       containers:
         - name: exporter
-          image: {{ index .Images "kubevirt-metrics-exporter" }}
+          image: {{ .Mirrors.Rewrite (index .Images "kubevirt-metrics-exporter") }}
           env:
             - name: NODE_NAME
               valueFrom:
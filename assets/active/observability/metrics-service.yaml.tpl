@@ -8,6 +8,14 @@ metadata:
     app.kubernetes.io/name: virt-platform-autopilot
     app.kubernetes.io/component: autopilot
 spec:
+  {{- if .Network.IsDualStack }}
+  # Serve metrics on both families so scrapers on either stack can reach them
+  ipFamilyPolicy: PreferDualStack
+  ipFamilies:
+    {{- range .Network.IPFamilies }}
+    - {{ . }}
+    {{- end }}
+  {{- end }}
   selector:
     app: virt-platform-autopilot
     control-plane: controller-manager
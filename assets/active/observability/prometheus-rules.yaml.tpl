@@ -39,6 +39,30 @@ spec:
               (0 = Drifted/Sync Failed, 1 = Synced)
             runbook_url: "https://github.com/kubevirt/virt-platform-autopilot/blob/main/docs/runbooks/VirtPlatformSyncFailed.md"
 
+        - alert: VirtPlatformBlastRadiusExceeded
+          # Safety guard indicator: a single reconcile wanted to reboot or delete too much
+          # Expr: kubevirt_autopilot_blast_radius_held > 0
+          # The whole batch is held back until acknowledged on the HCO, so platform
+          # changes stop flowing; likely a catalog bug, needs a human decision now
+          expr: |
+            kubevirt_autopilot_blast_radius_held > 0
+          labels:
+            severity: critical
+            operator: virt-platform-autopilot
+            kubernetes_operator_part_of: kubevirt
+            kubernetes_operator_component: autopilot
+            operator_health_impact: critical
+          annotations:
+            summary: "virt-platform-autopilot is holding back {{`{{ $value }}`}} changes ({{`{{ $labels.operation }}`}})"
+            description: |-
+              A single reconcile would have made {{`{{ $value }}`}} {{`{{ $labels.operation }}`}}
+              changes, more than the configured blast radius limit. Nothing in the batch
+              was applied.
+
+              Check the BlastRadiusExceeded event on the HyperConverged for the affected
+              resources and the acknowledgement fingerprint.
+            runbook_url: "https://github.com/kubevirt/virt-platform-autopilot/blob/main/docs/runbooks/VirtPlatformBlastRadiusExceeded.md"
+
     - name: virt-platform-autopilot.warning
       interval: 30s
       rules:
@@ -132,3 +156,32 @@ spec:
 
               Manual intervention may be required to remove this resource.
             runbook_url: "https://github.com/kubevirt/virt-platform-autopilot/blob/main/docs/runbooks/VirtPlatformTombstoneStuck.md"
+
+        - alert: VirtPlatformCacheMemoryHigh
+          # Cache growth indicator: one watched type holds >256MiB in the informer cache
+          # Expr: kubevirt_autopilot_cache_estimated_bytes > 256MiB (for > 30m)
+          # Label-filtered types stay small; an unfiltered exemption (HCO, CRDs) growing
+          # with the cluster puts the operator pod at risk of being OOM-killed
+          expr: |
+            kubevirt_autopilot_cache_estimated_bytes > 268435456
+          for: 30m
+          labels:
+            severity: warning
+            operator: virt-platform-autopilot
+            kubernetes_operator_part_of: kubevirt
+            kubernetes_operator_component: autopilot
+            operator_health_impact: warning
+          annotations:
+            summary: "virt-platform-autopilot cache for {{`{{ $labels.kind }}`}} is unexpectedly large"
+            description: |-
+              The informer cache of virt-platform-autopilot holds an estimated
+              {{`{{ $value | humanize1024 }}`}}B of {{`{{ $labels.kind }}`}} ({{`{{ $labels.group }}`}}/{{`{{ $labels.version }}`}})
+              objects for 30 minutes.
+
+              Managed types are cached only when they carry the
+              platform.kubevirt.io/managed-by label, so they should stay small.
+              Growth usually comes from a type cached without that filter
+              (HyperConverged, CustomResourceDefinition) or from very large objects.
+
+              The operator pod may be OOM-killed if the cache keeps growing.
+            runbook_url: "https://github.com/kubevirt/virt-platform-autopilot/blob/main/docs/runbooks/VirtPlatformCacheMemoryHigh.md"
@@ -0,0 +1,19 @@
+{{- with .MetalLB }}
+{{- range .Conflicts }}
+# autopilot:warning message={{ . }}
+{{- end }}
+{{- end }}
+{{- if not (and .MetalLB .MetalLB.AddressPools) }}
+# autopilot:skip reason=no address pools: set the metallb-address-pools annotation or add BareMetalHosts
+{{- else }}
+# Pools derived from the host inventory sit at the end of each machine network, clear
+# of host NICs, node addresses and the API and ingress VIPs.
+apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: virt-platform-default
+  namespace: metallb-system
+spec:
+  addresses: {{ toJson .MetalLB.AddressPools }}
+  autoAssign: true
+{{- end }}
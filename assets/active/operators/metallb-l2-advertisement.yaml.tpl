@@ -0,0 +1,12 @@
+{{- if not (and .MetalLB .MetalLB.AddressPools) }}
+# autopilot:skip reason=no default MetalLB address pool to advertise
+{{- else }}
+apiVersion: metallb.io/v1beta1
+kind: L2Advertisement
+metadata:
+  name: virt-platform-default
+  namespace: metallb-system
+spec:
+  ipAddressPools:
+    - virt-platform-default
+{{- end }}
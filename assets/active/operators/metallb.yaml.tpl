@@ -1,6 +1,41 @@
+{{- /* Speakers announce service IPs from the VM nodes, so they follow workloads placement */ -}}
+{{- $infra := "" -}}
+{{- $workloads := "" -}}
+{{- with .Placement -}}
+  {{- $infra = .Infra -}}
+  {{- $workloads = .Workloads -}}
+{{- end -}}
 apiVersion: metallb.io/v1beta1
 kind: MetalLB
 metadata:
   name: metallb
   namespace: metallb-system
+{{- if .Placement.IsEmpty }}
 spec: {}
+{{- else }}
+spec:
+  {{- with $workloads }}
+  {{- if .NodeSelector }}
+  nodeSelector: {{ toJson .NodeSelector }}
+  {{- end }}
+  {{- if .Tolerations }}
+  speakerTolerations: {{ toJson .Tolerations }}
+  {{- end }}
+  {{- if .Affinity }}
+  speakerConfig:
+    affinity: {{ toJson .Affinity }}
+  {{- end }}
+  {{- end }}
+  {{- with $infra }}
+  {{- if .NodeSelector }}
+  controllerNodeSelector: {{ toJson .NodeSelector }}
+  {{- end }}
+  {{- if .Tolerations }}
+  controllerTolerations: {{ toJson .Tolerations }}
+  {{- end }}
+  {{- if .Affinity }}
+  controllerConfig:
+    affinity: {{ toJson .Affinity }}
+  {{- end }}
+  {{- end }}
+{{- end }}
@@ -0,0 +1,35 @@
+{{- $profile := .PerformanceProfile }}
+{{- if not $profile.Ready }}
+# autopilot:skip reason={{ $profile.Reason | default "worker nodes not suitable for a PerformanceProfile" }}
+{{- else }}
+# Parameters are computed from the worker nodes at reconcile time (pkg/perfprofile):
+# {{ $profile.Workers }} workers, {{ $profile.CPUs }} CPUs, {{ $profile.NUMANodes }} NUMA node(s).
+# The Node Tuning Operator turns this into a MachineConfig: changes reboot the worker pool.
+apiVersion: performance.openshift.io/v2
+kind: PerformanceProfile
+metadata:
+  name: virt-performance
+spec:
+  cpu:
+    reserved: {{ $profile.ReservedCPUs | quote }}
+    isolated: {{ $profile.IsolatedCPUs | quote }}
+  {{- if $profile.HugePages }}
+  hugepages:
+    defaultHugepagesSize: {{ $profile.HugePageSize }}
+    pages:
+      - size: {{ $profile.HugePageSize }}
+        count: {{ $profile.HugePages }}
+  {{- end }}
+  numa:
+    topologyPolicy: {{ $profile.TopologyPolicy }}
+  realTimeKernel:
+    enabled: false
+  workloadHints:
+    realTime: false
+    highPowerConsumption: false
+    perPodPowerManagement: false
+  nodeSelector:
+    node-role.kubernetes.io/worker: ""
+  machineConfigPoolSelector:
+    pools.operator.machineconfiguration.openshift.io/worker: ""
+{{- end }}
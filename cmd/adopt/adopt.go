@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+var (
+	kubeconfig string
+	namespace  string
+	asset      string
+	dryRun     bool
+)
+
+// Options select the asset to adopt
+type Options struct {
+	Asset  string
+	DryRun bool
+}
+
+// NewAdoptCommand creates the adopt subcommand
+func NewAdoptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "adopt",
+		Short: "Keep the live customizations of an asset when the autopilot takes it over",
+		Long: `Compare the live object of an asset with what the autopilot renders for it and
+write the differences to the object as a platform.kubevirt.io/patch annotation,
+so enabling the autopilot on a cluster that was configured by hand keeps every
+customized value instead of overwriting it.
+
+Only fields the template renders are compared: fields set on the live object
+alone are left in place by server-side apply anyway. Fields masked with
+platform.kubevirt.io/ignore-fields are already kept and are skipped. Sensitive
+kinds such as MachineConfig do not accept patches; use ignore-fields or
+platform.kubevirt.io/mode: unmanaged for them.
+
+The baseline is the plain rendering. A patch annotation takes precedence over the
+asset's entry in the overrides ConfigMap, so the generated patch includes that
+entry's effect, and any patch annotation already on the object is replaced.
+
+Examples:
+  virt-platform-autopilot adopt --asset=swap-enable --dry-run
+  virt-platform-autopilot adopt --asset=swap-enable
+`,
+		Args: cobra.NoArgs,
+		RunE: runAdopt,
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the HyperConverged CR (required when there are several)")
+	cmd.Flags().StringVar(&asset, "asset", "", "Asset to adopt (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the patch that would be written instead of writing it")
+	_ = cmd.MarkFlagRequired("asset")
+	_ = cmd.RegisterFlagCompletionFunc("asset", completion.AssetNames)
+
+	return cmd
+}
+
+// runAdopt executes the adopt command
+func runAdopt(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	c, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	ctx := context.Background()
+	hco, err := cluster.FindHCO(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+	desired, err := Baseline(ctx, c, registry, hco, asset)
+	if err != nil {
+		return err
+	}
+	return Adopt(ctx, c, hco, desired, Options{Asset: asset, DryRun: dryRun}, cmd.OutOrStdout())
+}
+
+// Baseline renders asset for hco the way the controller does, before mutators and patches
+func Baseline(ctx context.Context, c client.Client, registry *assets.Registry, hco *unstructured.Unstructured, assetName string) (*unstructured.Unstructured, error) {
+	assetMeta, err := registry.GetAsset(assetName)
+	if err != nil {
+		return nil, err
+	}
+	renderCtx, err := controller.NewRenderContextBuilder(c).Build(ctx, hco)
+	if err != nil {
+		return nil, err
+	}
+	renderer := engine.NewRenderer(assets.NewLoader())
+	renderer.SetClient(c)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		if reason, skipped := engine.SkipReason(err); skipped {
+			return nil, fmt.Errorf("asset %s renders nothing for this cluster: %s", assetName, reason)
+		}
+		return nil, fmt.Errorf("failed to render asset %s: %w", assetName, err)
+	}
+	if desired == nil {
+		return nil, fmt.Errorf("asset %s renders nothing for this cluster", assetName)
+	}
+	return desired, nil
+}
+
+// Adopt writes the patch that keeps the live values of desired's object as its patch annotation
+func Adopt(ctx context.Context, c client.Client, hco, desired *unstructured.Unstructured, opts Options, w io.Writer) error {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		return fmt.Errorf("failed to get %s %s: %w", desired.GetKind(), objectName(desired.GetNamespace(), desired.GetName()), err)
+	}
+	name := fmt.Sprintf("%s %s", live.GetKind(), objectName(live.GetNamespace(), live.GetName()))
+
+	patch, paths, err := overrides.AdoptionPatch(desired, live)
+	if err != nil {
+		return fmt.Errorf("cannot adopt %s: %w", name, err)
+	}
+	if patch == "" {
+		fmt.Fprintf(w, "%s already matches the rendering of asset %s, nothing to adopt\n", name, opts.Asset)
+		return nil
+	}
+
+	// Validate the patch as the controller will before relying on it
+	annotated := desired.DeepCopy()
+	annotated.SetAnnotations(map[string]string{overrides.PatchAnnotation: patch})
+	if err := overrides.ValidateAnnotations(annotated); err != nil {
+		return fmt.Errorf("generated patch for %s is not valid: %w", name, err)
+	}
+
+	if opts.DryRun {
+		var ops []any
+		if err := json.Unmarshal([]byte(patch), &ops); err != nil {
+			return fmt.Errorf("failed to parse generated patch: %w", err)
+		}
+		data, err := json.MarshalIndent(ops, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal patch: %w", err)
+		}
+		fmt.Fprintf(w, "%s\n", data)
+		return nil
+	}
+
+	previous := live.GetAnnotations()[overrides.PatchAnnotation]
+	original := live.DeepCopy()
+	annotations := live.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[overrides.PatchAnnotation] = patch
+	// The generated patch is a JSON Patch, the default format
+	delete(annotations, overrides.PatchTypeAnnotation)
+	live.SetAnnotations(annotations)
+	if err := c.Patch(ctx, live, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to annotate %s: %w", name, err)
+	}
+
+	fmt.Fprintf(w, "Adopted %s: %d customized fields kept through %s\n", name, len(paths), overrides.PatchAnnotation)
+	for _, path := range paths {
+		fmt.Fprintf(w, "  %s\n", path)
+	}
+	if previous != "" {
+		fmt.Fprintf(w, "The previous patch annotation was replaced; its effect is included in the new patch\n")
+	}
+	if patches, err := overrides.LoadOverridePatches(ctx, c, hco); err == nil {
+		if _, found := patches[opts.Asset]; found {
+			fmt.Fprintf(w, "The overrides ConfigMap entry for %s no longer applies while the annotation is set and can be removed\n", opts.Asset)
+		}
+	}
+	return nil
+}
+
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+func newHCO() *unstructured.Unstructured {
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	hco.SetNamespace("openshift-cnv")
+	hco.SetName("kubevirt-hyperconverged")
+	return hco
+}
+
+func newConfigMapObject(data map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName("virt-settings")
+	_ = unstructured.SetNestedField(obj.Object, data, "data")
+	return obj
+}
+
+func TestAdoptWritesPatchAnnotation(t *testing.T) {
+	ctx := context.Background()
+	live := newConfigMapObject(map[string]any{"mode": "custom", "extra": "kept"})
+	live.SetAnnotations(map[string]string{overrides.PatchTypeAnnotation: overrides.PatchTypeMerge})
+	c := fake.NewClientBuilder().WithObjects(live).Build()
+	desired := newConfigMapObject(map[string]any{"mode": "default", "level": "2"})
+
+	var out bytes.Buffer
+	require.NoError(t, Adopt(ctx, c, newHCO(), desired, Options{Asset: "virt-settings"}, &out))
+	assert.Contains(t, out.String(), "2 customized fields")
+	assert.Contains(t, out.String(), "/data/level")
+
+	adopted := newConfigMapObject(nil)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(adopted), adopted))
+	annotations := adopted.GetAnnotations()
+	require.Contains(t, annotations, overrides.PatchAnnotation)
+	assert.NotContains(t, annotations, overrides.PatchTypeAnnotation, "the generated patch is a JSON Patch")
+
+	// The controller applying the annotation to the rendering must reproduce the live values
+	desired.SetAnnotations(map[string]string{overrides.PatchAnnotation: annotations[overrides.PatchAnnotation]})
+	applied, err := overrides.ApplyPatch(desired)
+	require.NoError(t, err)
+	require.True(t, applied)
+	data, _, _ := unstructured.NestedStringMap(desired.Object, "data")
+	assert.Equal(t, map[string]string{"mode": "custom"}, data)
+}
+
+func TestAdoptDryRun(t *testing.T) {
+	ctx := context.Background()
+	live := newConfigMapObject(map[string]any{"mode": "custom"})
+	c := fake.NewClientBuilder().WithObjects(live).Build()
+
+	var out bytes.Buffer
+	require.NoError(t, Adopt(ctx, c, newHCO(), newConfigMapObject(map[string]any{"mode": "default"}),
+		Options{Asset: "virt-settings", DryRun: true}, &out))
+	assert.Contains(t, out.String(), `"path": "/data/mode"`)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(live), live))
+	assert.NotContains(t, live.GetAnnotations(), overrides.PatchAnnotation, "dry run must not write the annotation")
+}
+
+func TestAdoptNothingToAdopt(t *testing.T) {
+	ctx := context.Background()
+	live := newConfigMapObject(map[string]any{"mode": "default"})
+	c := fake.NewClientBuilder().WithObjects(live).Build()
+
+	var out bytes.Buffer
+	require.NoError(t, Adopt(ctx, c, newHCO(), newConfigMapObject(map[string]any{"mode": "default"}),
+		Options{Asset: "virt-settings"}, &out))
+	assert.Contains(t, out.String(), "nothing to adopt")
+}
+
+func TestAdoptMissingObject(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	err := Adopt(context.Background(), c, newHCO(), newConfigMapObject(nil), Options{Asset: "virt-settings"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to get ConfigMap openshift-cnv/virt-settings")
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+
+	// allAssets is the --assets value selecting the whole catalog
+	allAssets = "all"
+)
+
+var (
+	outputFormat  string
+	assetSelector string
+	iterations    int
+	scenarioFile  string
+	fakeNodes     int
+)
+
+// Environment records what a result was measured on, so results of different
+// releases are only compared when they ran on comparable machines
+type Environment struct {
+	GoVersion      string    `json:"goVersion"`
+	OS             string    `json:"os"`
+	Arch           string    `json:"arch"`
+	CPUs           int       `json:"cpus"`
+	CatalogVersion string    `json:"catalogVersion,omitempty"`
+	CatalogDigest  string    `json:"catalogDigest"`
+	StartedAt      time.Time `json:"startedAt"`
+}
+
+// Timing summarises a series of latency samples
+type Timing struct {
+	Samples    int     `json:"samples"`
+	MeanMillis float64 `json:"meanMillis"`
+	P50Millis  float64 `json:"p50Millis"`
+	P95Millis  float64 `json:"p95Millis"`
+	MaxMillis  float64 `json:"maxMillis"`
+}
+
+// NewBenchCommand creates the bench subcommand
+func NewBenchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure render and reconcile performance against in-memory clusters",
+		Long: `Run the renderer and the reconciler against fake clients and report latency
+and write counts. Nothing touches a real cluster.
+
+The JSON output is stable and carries the catalog digest and machine details,
+so CI can store one result per release and compare them to catch regressions.
+`,
+	}
+	cmd.AddCommand(newRenderCommand(), newReconcileCommand())
+	return cmd
+}
+
+// addCommonFlags registers the flags shared by every bench subcommand
+func addCommonFlags(cmd *cobra.Command, defaultIterations int) {
+	cmd.Flags().StringVar(&assetSelector, "assets", allAssets, "Assets to benchmark: all, or a comma-separated list of asset names")
+	cmd.Flags().IntVar(&iterations, "iterations", defaultIterations, "Number of measured passes")
+	cmd.Flags().StringVar(&outputFormat, "output", outputTable, "Output format: table or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values(outputTable, outputJSON))
+}
+
+// validateCommonFlags checks the shared flags before any work starts
+func validateCommonFlags() error {
+	if iterations < 1 {
+		return fmt.Errorf("--iterations must be at least 1, got %d", iterations)
+	}
+	if outputFormat != outputTable && outputFormat != outputJSON {
+		return fmt.Errorf("unsupported output format %q (use table or json)", outputFormat)
+	}
+	return nil
+}
+
+// selectAssets resolves an --assets value to catalog assets in reconcile order.
+// Unknown names are an error, so a typo does not shrink the benchmark unnoticed.
+func selectAssets(registry *assets.Registry, selector string) ([]assets.AssetMetadata, error) {
+	all := registry.ListAssetsByReconcileOrder()
+	if selector == "" || selector == allAssets {
+		return all, nil
+	}
+
+	wanted := make(map[string]bool)
+	for name := range strings.SplitSeq(selector, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, err := registry.GetAsset(name); err != nil {
+			return nil, fmt.Errorf("unknown asset %q in --assets", name)
+		}
+		wanted[name] = true
+	}
+	if len(wanted) == 0 {
+		return nil, fmt.Errorf("--assets selects no assets")
+	}
+	return slices.DeleteFunc(all, func(a assets.AssetMetadata) bool { return !wanted[a.Name] }), nil
+}
+
+// newEnvironment describes the current process and catalog
+func newEnvironment(registry *assets.Registry) Environment {
+	return Environment{
+		GoVersion:      runtime.Version(),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		CPUs:           runtime.NumCPU(),
+		CatalogVersion: registry.CatalogVersion(),
+		CatalogDigest:  registry.CatalogDigest(),
+		StartedAt:      time.Now().UTC(),
+	}
+}
+
+// newTiming summarises samples; the percentiles are nearest-rank
+func newTiming(samples []time.Duration) Timing {
+	if len(samples) == 0 {
+		return Timing{}
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return Timing{
+		Samples:    len(sorted),
+		MeanMillis: millis(total / time.Duration(len(sorted))),
+		P50Millis:  millis(percentile(sorted, 50)),
+		P95Millis:  millis(percentile(sorted, 95)),
+		MaxMillis:  millis(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// millis converts d to fractional milliseconds
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// silenceLogs discards controller logs, which would otherwise drown the report
+func silenceLogs() {
+	log.SetLogger(logr.Discard())
+}
+
+// writeJSON writes result as indented JSON
+func writeJSON(w io.Writer, result any) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// newTable returns a tabwriter for the table output
+func newTable(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+}
+
+// writeTiming writes one table row for timing
+func writeTiming(w io.Writer, name string, timing Timing) {
+	fmt.Fprintf(w, "%s\t%d\t%.3f\t%.3f\t%.3f\t%.3f\n",
+		name, timing.Samples, timing.MeanMillis, timing.P50Millis, timing.P95Millis, timing.MaxMillis)
+}
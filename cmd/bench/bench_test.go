@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func TestNewTiming(t *testing.T) {
+	samples := make([]time.Duration, 0, 20)
+	for i := 20; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	timing := newTiming(samples)
+	assert.Equal(t, 20, timing.Samples)
+	assert.InDelta(t, 10.5, timing.MeanMillis, 1e-9)
+	assert.InDelta(t, 10, timing.P50Millis, 1e-9)
+	assert.InDelta(t, 19, timing.P95Millis, 1e-9)
+	assert.InDelta(t, 20, timing.MaxMillis, 1e-9)
+
+	assert.Equal(t, Timing{}, newTiming(nil))
+}
+
+func TestSelectAssets(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+
+	all, err := selectAssets(registry, allAssets)
+	require.NoError(t, err)
+	assert.Len(t, all, len(registry.ListAssets(nil)))
+
+	selected, err := selectAssets(registry, "swap-enable, hco-golden-config")
+	require.NoError(t, err)
+	names := []string{selected[0].Name, selected[1].Name}
+	assert.ElementsMatch(t, []string{"swap-enable", "hco-golden-config"}, names)
+
+	_, err = selectAssets(registry, "swap-enable,no-such-asset")
+	assert.ErrorContains(t, err, `unknown asset "no-such-asset"`)
+}
+
+func TestRender(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	result := Render(engine.NewRenderer(loader), RenderOptions{Assets: registry.ListAssetsByReconcileOrder(), Iterations: 2})
+
+	assert.Equal(t, "render", result.Benchmark)
+	assert.Zero(t, result.Errors, "every shipped template should render: %+v", result.PerAsset)
+	assert.Equal(t, 2*result.Assets, result.Renders+result.Skipped)
+	assert.Equal(t, 2*result.Assets, result.Render.Samples)
+	require.Len(t, result.PerAsset, result.Assets)
+	for i := 1; i < len(result.PerAsset); i++ {
+		assert.GreaterOrEqual(t, result.PerAsset[i-1].Render.MeanMillis, result.PerAsset[i].Render.MeanMillis)
+	}
+}
+
+func TestReconcileSteadyStateHasNoChurn(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+
+	result, err := Reconcile(context.Background(), registry, ReconcileOptions{FakeNodes: 20, Iterations: 3})
+	require.NoError(t, err)
+
+	assert.Equal(t, "synthetic", result.Scenario)
+	assert.Equal(t, 23, result.Nodes)
+	assert.Empty(t, result.Errors)
+	assert.Positive(t, result.Included)
+	require.Len(t, result.Writes, 3)
+	assert.Positive(t, result.Writes[0], "the first reconcile should create the managed objects")
+	assert.Zero(t, result.ChurnWrites, "an unchanged cluster should not be written to: %v", result.Churn)
+	assert.Equal(t, 3, result.Detection.Samples)
+	assert.Equal(t, 3, result.ConditionEvaluation.Samples)
+	assert.Equal(t, 3, result.Reconcile.Samples)
+}
+
+func TestReconcileAllowlist(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+
+	result, err := Reconcile(context.Background(), registry, ReconcileOptions{
+		Assets:     []string{"swap-enable"},
+		Iterations: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Included)
+}
+
+func TestBenchCommandJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	cmd := NewBenchCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"reconcile", "--fake-nodes=5", "--iterations=1", "--assets=swap-enable", "--output=json"})
+	require.NoError(t, cmd.Execute())
+
+	var result ReconcileResult
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, "reconcile", result.Benchmark)
+	assert.NotEmpty(t, result.Environment.CatalogDigest)
+	assert.Equal(t, 8, result.Nodes)
+}
+
+func TestBenchCommandValidation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"render", "--iterations=0"}, "--iterations must be at least 1"},
+		{[]string{"render", "--output=yaml"}, "unsupported output format"},
+		{[]string{"render", "--assets=nope"}, `unknown asset "nope"`},
+		{[]string{"reconcile", "--fake-nodes=-1"}, "--fake-nodes must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := NewBenchCommand()
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			assert.ErrorContains(t, cmd.Execute(), tt.want)
+		})
+	}
+}
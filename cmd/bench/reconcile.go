@@ -0,0 +1,505 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/scenario"
+)
+
+// ReconcileOptions configures a reconcile benchmark
+type ReconcileOptions struct {
+	// Scenario is the cluster to start from; nil uses a synthetic cluster with every
+	// CRD the catalog needs installed
+	Scenario *scenario.Scenario
+	// Assets restricts the autopilot to these assets through the opt-in annotation;
+	// empty manages the whole catalog
+	Assets     []string
+	FakeNodes  int
+	Iterations int
+}
+
+// ReconcileResult is the outcome of a reconcile benchmark
+type ReconcileResult struct {
+	Benchmark   string      `json:"benchmark"`
+	Environment Environment `json:"environment"`
+	Scenario    string      `json:"scenario"`
+	Iterations  int         `json:"iterations"`
+	Nodes       int         `json:"nodes"`
+	// Assets is the size of the catalog; Included how many of them the conditions select
+	Assets   int `json:"assets"`
+	Included int `json:"included"`
+	// Detection is building the render context: hardware, topology, storage and
+	// network detection over every node
+	Detection Timing `json:"detection"`
+	// ConditionEvaluation is deciding the inclusion of every asset from a built context
+	ConditionEvaluation Timing `json:"conditionEvaluation"`
+	// Reconcile is one full Reconcile call, detection and apply included
+	Reconcile Timing `json:"reconcile"`
+	// Writes is the number of writes each reconcile made. The first pass creates the
+	// managed objects; any write after it is churn, broken down by kind in Churn.
+	Writes      []int          `json:"writes"`
+	ChurnWrites int            `json:"churnWrites"`
+	Churn       map[string]int `json:"churn,omitempty"`
+	// Errors are the distinct errors Reconcile returned
+	Errors []string `json:"errors,omitempty"`
+}
+
+// newReconcileCommand creates the bench reconcile subcommand
+func newReconcileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Measure detection, condition evaluation and apply churn",
+		Long: `Reconcile an in-memory cluster with the given number of fake worker nodes
+and report detection latency, condition evaluation latency, full reconcile
+latency and the writes every reconcile made.
+
+The first reconcile creates the managed objects. Every later one runs against
+an unchanged cluster, so any write it makes is churn: a template that does not
+render deterministically, or an apply that never converges. Churn is broken
+down by kind.
+
+Without --scenario the cluster has every CRD the catalog needs installed. With
+a scenario, the fake nodes are added to its own.
+
+Examples:
+  virt-platform-autopilot bench reconcile --fake-nodes=1000 --assets=all
+  virt-platform-autopilot bench reconcile --scenario=scenarios/gpu-cluster.yaml --output=json
+`,
+		Args: cobra.NoArgs,
+		RunE: runReconcileBench,
+	}
+	addCommonFlags(cmd, 10)
+	cmd.Flags().IntVar(&fakeNodes, "fake-nodes", 1000, "Number of synthetic worker nodes to add to the cluster")
+	cmd.Flags().StringVar(&scenarioFile, "scenario", "", "Scenario YAML file to start from instead of the synthetic cluster")
+	return cmd
+}
+
+// runReconcileBench executes the bench reconcile command
+func runReconcileBench(cmd *cobra.Command, _ []string) error {
+	if err := validateCommonFlags(); err != nil {
+		return err
+	}
+	if fakeNodes < 0 {
+		return fmt.Errorf("--fake-nodes must not be negative, got %d", fakeNodes)
+	}
+	cmd.SilenceUsage = true
+	silenceLogs()
+
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+	opts := ReconcileOptions{FakeNodes: fakeNodes, Iterations: iterations}
+	if assetSelector != allAssets {
+		selected, err := selectAssets(registry, assetSelector)
+		if err != nil {
+			return err
+		}
+		for _, asset := range selected {
+			opts.Assets = append(opts.Assets, asset.Name)
+		}
+	}
+	if scenarioFile != "" {
+		if opts.Scenario, err = scenario.Load(scenarioFile); err != nil {
+			return err
+		}
+	}
+
+	result, err := Reconcile(context.Background(), registry, opts)
+	if err != nil {
+		return err
+	}
+	result.Environment = newEnvironment(registry)
+	if outputFormat == outputJSON {
+		return writeJSON(cmd.OutOrStdout(), result)
+	}
+	writeReconcileTable(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// Reconcile builds the benchmark cluster from opts and measures opts.Iterations
+// passes of detection, condition evaluation and Reconcile against it
+func Reconcile(ctx context.Context, registry *assets.Registry, opts ReconcileOptions) (*ReconcileResult, error) {
+	s := opts.Scenario
+	if s == nil {
+		s = syntheticScenario(registry)
+	}
+	s = withFakeNodes(s, opts.FakeNodes)
+	s.HCO.SetAnnotations(withScope(s.HCO.GetAnnotations(), opts.Assets))
+	// The fake client does not convert between versions, and the reconciler reads v1
+	s.HCO.SetGroupVersionKind(pkgcontext.HCOGVK)
+
+	builder, err := s.ClientBuilder()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the benchmark cluster: %w", err)
+	}
+	counter := &writeCounter{}
+	c := builder.WithObjects(missingNamespaces(s, registry)...).
+		WithInterceptorFuncs(counter.funcs()).
+		Build()
+
+	reconciler, err := controller.NewPlatformReconciler(c, c, s.HCO.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconcileResult{
+		Benchmark:  "reconcile",
+		Scenario:   s.Name,
+		Iterations: opts.Iterations,
+		Nodes:      len(s.Nodes),
+		Assets:     len(registry.ListAssets(nil)),
+		Churn:      make(map[string]int),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: s.HCO.GetNamespace(), Name: s.HCO.GetName()}}
+	errs := make(map[string]bool)
+	var detection, evaluation, reconcile []time.Duration
+
+	for i := range opts.Iterations {
+		hco := &unstructured.Unstructured{}
+		hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := c.Get(ctx, req.NamespacedName, hco); err != nil {
+			return nil, fmt.Errorf("failed to get HyperConverged: %w", err)
+		}
+
+		start := time.Now()
+		renderCtx, err := controller.NewRenderContextBuilder(c).Build(ctx, hco)
+		detection = append(detection, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build render context: %w", err)
+		}
+
+		start = time.Now()
+		inclusions, err := controller.EvaluateConditions(ctx, c, registry, hco, renderCtx)
+		evaluation = append(evaluation, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate conditions: %w", err)
+		}
+		result.Included = 0
+		for _, inclusion := range inclusions {
+			if inclusion.Included {
+				result.Included++
+			}
+		}
+
+		counter.reset()
+		start = time.Now()
+		_, err = reconciler.Reconcile(ctx, req)
+		reconcile = append(reconcile, time.Since(start))
+		if err != nil {
+			errs[err.Error()] = true
+		}
+
+		writes, byKind := counter.snapshot()
+		result.Writes = append(result.Writes, writes)
+		if i > 0 {
+			result.ChurnWrites += writes
+			for kind, n := range byKind {
+				result.Churn[kind] += n
+			}
+		}
+	}
+
+	result.Detection = newTiming(detection)
+	result.ConditionEvaluation = newTiming(evaluation)
+	result.Reconcile = newTiming(reconcile)
+	result.Errors = slices.Sorted(maps.Keys(errs))
+	return result, nil
+}
+
+// syntheticScenario is an opted-in OpenShift cluster with three control-plane nodes
+// and every CRD an asset requires, gates on or checks for, so that conditions rather
+// than missing APIs decide what is applied
+func syntheticScenario(registry *assets.Registry) *scenario.Scenario {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	hco.SetAnnotations(map[string]string{
+		overrides.AnnotationAutopilotEnabled: "true",
+		"platform.kubevirt.io/openshift":     "true",
+	})
+
+	crds := map[string]bool{pkgcontext.HCOCRDName: true}
+	for _, asset := range registry.ListAssets(nil) {
+		for _, crd := range []string{asset.RequiredCRD, asset.GateCRD} {
+			if crd != "" {
+				crds[crd] = true
+			}
+		}
+		for _, condition := range asset.Conditions {
+			if condition.Type == assets.ConditionTypeCRD {
+				crds[condition.Value] = true
+			}
+		}
+	}
+
+	s := &scenario.Scenario{
+		Name: "synthetic",
+		HCO:  hco,
+		CRDs: slices.Sorted(maps.Keys(crds)),
+	}
+	for i := range 3 {
+		s.Nodes = append(s.Nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("master-%d", i),
+			Labels: map[string]string{"node-role.kubernetes.io/master": ""},
+		}})
+	}
+	infrastructure := unstructured.Unstructured{}
+	infrastructure.SetAPIVersion("config.openshift.io/v1")
+	infrastructure.SetKind("Infrastructure")
+	infrastructure.SetName("cluster")
+	_ = unstructured.SetNestedField(infrastructure.Object, "HighlyAvailable", "status", "controlPlaneTopology")
+	_ = unstructured.SetNestedField(infrastructure.Object, "BareMetal", "status", "platformStatus", "type")
+	s.Objects = append(s.Objects, infrastructure)
+	return s
+}
+
+// withFakeNodes returns a copy of s with n identical bare-metal workers added.
+// Every tenth one carries GPUs behind an IOMMU, so the detectors see a mixed fleet.
+func withFakeNodes(s *scenario.Scenario, n int) *scenario.Scenario {
+	out := *s
+	out.HCO = s.HCO.DeepCopy()
+	out.Nodes = slices.Clone(s.Nodes)
+	for i := range n {
+		node := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("bench-worker-%04d", i),
+			Labels: map[string]string{"node-role.kubernetes.io/worker": ""},
+		}}
+		node.Status.Capacity = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("64"),
+			corev1.ResourceMemory: resource.MustParse("512Gi"),
+			corev1.ResourcePods:   resource.MustParse("250"),
+		}
+		if i%10 == 0 {
+			node.Labels["feature.node.kubernetes.io/iommu-enabled"] = "true"
+			node.Status.Capacity["nvidia.com/gpu"] = resource.MustParse("4")
+		}
+		out.Nodes = append(out.Nodes, node)
+	}
+	return &out
+}
+
+// withScope sets the opt-in annotation to the asset allowlist, or "true" for the
+// whole catalog
+func withScope(annotations map[string]string, names []string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[overrides.AnnotationAutopilotEnabled] = "true"
+	if len(names) > 0 {
+		annotations[overrides.AnnotationAutopilotEnabled] = strings.Join(names, ",")
+	}
+	return annotations
+}
+
+// missingNamespaces returns the namespaces assets render into that s does not
+// already contain, since namespaced objects cannot be created without them
+func missingNamespaces(s *scenario.Scenario, registry *assets.Registry) []client.Object {
+	present := make(map[string]bool)
+	for _, obj := range s.Objects {
+		if obj.GetKind() == "Namespace" && obj.GetAPIVersion() == "v1" {
+			present[obj.GetName()] = true
+		}
+	}
+
+	wanted := map[string]bool{s.HCO.GetNamespace(): true}
+	for _, namespaces := range registry.AssetNamespaces() {
+		for _, ns := range namespaces {
+			wanted[ns] = true
+		}
+	}
+
+	var objs []client.Object
+	for _, ns := range slices.Sorted(maps.Keys(wanted)) {
+		if !present[ns] {
+			objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+		}
+	}
+	return objs
+}
+
+// writeCounter counts the writes that reach the fake cluster. Dry-run applies are
+// emulated rather than counted, since the fake client would persist them.
+type writeCounter struct {
+	mu     sync.Mutex
+	total  int
+	byKind map[string]int
+}
+
+// record counts one write of kind
+func (w *writeCounter) record(kind string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.byKind == nil {
+		w.byKind = make(map[string]int)
+	}
+	if kind == "" {
+		kind = "unknown"
+	}
+	w.total++
+	w.byKind[kind]++
+}
+
+// reset clears the counts before a reconcile
+func (w *writeCounter) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.total = 0
+	w.byKind = nil
+}
+
+// snapshot returns the counts since the last reset
+func (w *writeCounter) snapshot() (int, map[string]int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.total, maps.Clone(w.byKind)
+}
+
+// funcs returns interceptors recording every write by the kind it targets
+func (w *writeCounter) funcs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			w.record(kindOf(obj))
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			w.record(kindOf(obj))
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			w.record(kindOf(obj))
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			w.record(kindOf(obj))
+			return c.Delete(ctx, obj, opts...)
+		},
+		Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			applied, ok := obj.(unstructuredContent)
+			if !ok {
+				w.record("unknown")
+				return c.Apply(ctx, obj, opts...)
+			}
+			if slices.Contains(opts, client.ApplyOption(client.DryRunAll)) {
+				return dryRunApply(ctx, c, applied)
+			}
+			w.record(applied.GetKind())
+			return c.Apply(ctx, obj, opts...)
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			w.record(kindOf(obj) + "/" + subResource)
+			return c.SubResource(subResource).Update(ctx, obj, opts...)
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			w.record(kindOf(obj) + "/" + subResource)
+			return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+		},
+	}
+}
+
+// unstructuredContent is the part of the apply configuration built from an
+// unstructured object that dry-run emulation reads and fills in
+type unstructuredContent interface {
+	GetKind() string
+	UnstructuredContent() map[string]any
+	SetUnstructuredContent(map[string]any)
+}
+
+// dryRunApply answers a dry-run apply, which the fake client would persist, with the
+// applied fields merged over the live object. That is what the API server returns
+// when no other manager owns the fields, so unchanged objects show no drift.
+func dryRunApply(ctx context.Context, c client.Reader, applied unstructuredContent) error {
+	desired := &unstructured.Unstructured{Object: applied.UnstructuredContent()}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	applied.SetUnstructuredContent(mergeFields(live.Object, desired.Object))
+	return nil
+}
+
+// mergeFields overlays applied on live: maps merge key by key, anything else replaces
+func mergeFields(live, applied map[string]any) map[string]any {
+	merged := runtime.DeepCopyJSON(live)
+	for key, value := range applied {
+		liveMap, liveIsMap := merged[key].(map[string]any)
+		appliedMap, appliedIsMap := value.(map[string]any)
+		if liveIsMap && appliedIsMap {
+			merged[key] = mergeFields(liveMap, appliedMap)
+			continue
+		}
+		merged[key] = runtime.DeepCopyJSONValue(value)
+	}
+	return merged
+}
+
+// kindOf returns the kind of obj, from its type meta or, for typed objects, its Go type
+func kindOf(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", obj), "*v1.")
+}
+
+// writeReconcileTable prints a reconcile result for humans
+func writeReconcileTable(w io.Writer, result *ReconcileResult) {
+	fmt.Fprintf(w, "Scenario %s: %d nodes, %d of %d assets included, %d iterations\n\n",
+		result.Scenario, result.Nodes, result.Included, result.Assets, result.Iterations)
+
+	tw := newTable(w)
+	fmt.Fprintln(tw, "PHASE\tSAMPLES\tMEAN(ms)\tP50(ms)\tP95(ms)\tMAX(ms)")
+	writeTiming(tw, "detection", result.Detection)
+	writeTiming(tw, "condition-evaluation", result.ConditionEvaluation)
+	writeTiming(tw, "reconcile", result.Reconcile)
+	_ = tw.Flush()
+
+	writes := make([]string, len(result.Writes))
+	for i, n := range result.Writes {
+		writes[i] = fmt.Sprint(n)
+	}
+	fmt.Fprintf(w, "\nWrites per reconcile: %s\n", strings.Join(writes, " "))
+	fmt.Fprintf(w, "Churn after the first reconcile: %d writes\n", result.ChurnWrites)
+	for _, kind := range slices.Sorted(maps.Keys(result.Churn)) {
+		fmt.Fprintf(w, "  %s: %d\n", kind, result.Churn[kind])
+	}
+	for _, err := range result.Errors {
+		fmt.Fprintf(w, "Reconcile error: %s\n", err)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// RenderOptions configures a render benchmark
+type RenderOptions struct {
+	// Assets are rendered in this order on every pass
+	Assets     []assets.AssetMetadata
+	Iterations int
+}
+
+// RenderResult is the outcome of a render benchmark
+type RenderResult struct {
+	Benchmark   string      `json:"benchmark"`
+	Environment Environment `json:"environment"`
+	Iterations  int         `json:"iterations"`
+	Assets      int         `json:"assets"`
+	// Renders counts the renders that produced objects; Skipped those that rendered
+	// empty or the skip sentinel
+	Renders          int     `json:"renders"`
+	Skipped          int     `json:"skipped"`
+	Errors           int     `json:"errors"`
+	ElapsedSeconds   float64 `json:"elapsedSeconds"`
+	RendersPerSecond float64 `json:"rendersPerSecond"`
+	// Render summarises every single render; PerAsset breaks it down, slowest first
+	Render   Timing        `json:"render"`
+	PerAsset []AssetTiming `json:"perAsset"`
+}
+
+// AssetTiming is the render latency of one asset
+type AssetTiming struct {
+	Asset  string `json:"asset"`
+	Render Timing `json:"render"`
+	Errors int    `json:"errors,omitempty"`
+	// Error is the first render error, so a failing asset can be told from a slow one
+	Error string `json:"error,omitempty"`
+}
+
+// newRenderCommand creates the bench render subcommand
+func newRenderCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Measure template render throughput",
+		Long: `Render every selected asset against an opted-in HyperConverged, once per
+iteration, and report renders per second and per-asset latency.
+
+Conditions are ignored so every template is exercised; hardware, storage and
+network detection results are empty, as in offline rendering.
+
+Examples:
+  virt-platform-autopilot bench render
+  virt-platform-autopilot bench render --iterations=500 --output=json > render.json
+`,
+		Args: cobra.NoArgs,
+		RunE: runRenderBench,
+	}
+	addCommonFlags(cmd, 100)
+	return cmd
+}
+
+// runRenderBench executes the bench render command
+func runRenderBench(cmd *cobra.Command, _ []string) error {
+	if err := validateCommonFlags(); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+	silenceLogs()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+	selected, err := selectAssets(registry, assetSelector)
+	if err != nil {
+		return err
+	}
+
+	result := Render(engine.NewRenderer(loader), RenderOptions{Assets: selected, Iterations: iterations})
+	result.Environment = newEnvironment(registry)
+	if outputFormat == outputJSON {
+		return writeJSON(cmd.OutOrStdout(), result)
+	}
+	writeRenderTable(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// Render renders opts.Assets opts.Iterations times with renderer. Each pass starts
+// from a fresh render context, so asset outputs are recomputed as in a reconcile.
+func Render(renderer *engine.Renderer, opts RenderOptions) *RenderResult {
+	hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+	hco.SetAnnotations(map[string]string{overrides.AnnotationAutopilotEnabled: "true"})
+
+	result := &RenderResult{Benchmark: "render", Iterations: opts.Iterations, Assets: len(opts.Assets)}
+	perAsset := make([][]time.Duration, len(opts.Assets))
+	timings := make([]AssetTiming, len(opts.Assets))
+	var all []time.Duration
+
+	start := time.Now()
+	for range opts.Iterations {
+		renderCtx := pkgcontext.NewRenderContext(hco)
+		for i := range opts.Assets {
+			asset := &opts.Assets[i]
+			renderStart := time.Now()
+			objs, err := renderer.RenderMultiAsset(asset, renderCtx)
+			elapsed := time.Since(renderStart)
+
+			perAsset[i] = append(perAsset[i], elapsed)
+			all = append(all, elapsed)
+			switch _, skipped := engine.SkipReason(err); {
+			case skipped:
+				result.Skipped++
+			case err != nil:
+				result.Errors++
+				timings[i].Errors++
+				if timings[i].Error == "" {
+					timings[i].Error = err.Error()
+				}
+			case len(objs) == 0:
+				result.Skipped++
+			default:
+				result.Renders++
+			}
+		}
+	}
+	elapsed := time.Since(start)
+
+	result.ElapsedSeconds = elapsed.Seconds()
+	if elapsed > 0 {
+		result.RendersPerSecond = float64(len(all)) / elapsed.Seconds()
+	}
+	result.Render = newTiming(all)
+	for i := range opts.Assets {
+		timings[i].Asset = opts.Assets[i].Name
+		timings[i].Render = newTiming(perAsset[i])
+	}
+	slices.SortStableFunc(timings, func(a, b AssetTiming) int {
+		switch {
+		case a.Render.MeanMillis > b.Render.MeanMillis:
+			return -1
+		case a.Render.MeanMillis < b.Render.MeanMillis:
+			return 1
+		}
+		return 0
+	})
+	result.PerAsset = timings
+	return result
+}
+
+// writeRenderTable prints a render result for humans
+func writeRenderTable(w io.Writer, result *RenderResult) {
+	fmt.Fprintf(w, "%d assets x %d iterations in %.2fs: %.0f renders/s (%d rendered, %d skipped, %d errors)\n\n",
+		result.Assets, result.Iterations, result.ElapsedSeconds, result.RendersPerSecond,
+		result.Renders, result.Skipped, result.Errors)
+
+	tw := newTable(w)
+	fmt.Fprintln(tw, "ASSET\tSAMPLES\tMEAN(ms)\tP50(ms)\tP95(ms)\tMAX(ms)")
+	writeTiming(tw, "(all)", result.Render)
+	for _, asset := range result.PerAsset {
+		writeTiming(tw, asset.Asset, asset.Render)
+	}
+	_ = tw.Flush()
+
+	for _, asset := range result.PerAsset {
+		if asset.Error != "" {
+			fmt.Fprintf(w, "\n%s: %d errors, first: %s", asset.Asset, asset.Errors, asset.Error)
+		}
+	}
+	if result.Errors > 0 {
+		fmt.Fprintln(w)
+	}
+}
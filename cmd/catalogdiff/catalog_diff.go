@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalogdiff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+const (
+	// catalogPath is the embedded catalog location
+	catalogPath = "active/metadata.yaml"
+
+	// imageEntrypoint is the operator binary inside the published image
+	imageEntrypoint = "/manager"
+
+	// imageTimeout bounds pulling and running an image to print its catalog
+	imageTimeout = 5 * time.Minute
+)
+
+var (
+	fromFile      string
+	toFile        string
+	fromImage     string
+	toImage       string
+	containerTool string
+	outputFormat  string
+	printCatalog  bool
+)
+
+// runContainer runs an image and returns its stdout; replaced in tests
+var runContainer = func(ctx context.Context, tool string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, tool, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", tool, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// NewCatalogDiffCommand creates the catalog-diff subcommand
+func NewCatalogDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog-diff",
+		Short: "Compare the asset catalogs of two operator versions",
+		Long: `Report which assets are added, removed or changed between two asset catalogs.
+
+Use this before upgrading to review what the new operator version will start
+(or stop) managing. Each side is either a metadata.yaml file or an operator image;
+a side that is not given defaults to the catalog embedded in this binary.
+
+Images are read by running their operator binary with a container tool, so the
+image must ship a version of virt-platform-autopilot that has this command.
+
+Examples:
+  # What does this binary manage that the installed version does not?
+  virt-platform-autopilot catalog-diff --from-image=quay.io/kubevirt/virt-platform-autopilot:v1.2.0
+
+  # Compare two releases
+  virt-platform-autopilot catalog-diff \
+    --from-image=quay.io/kubevirt/virt-platform-autopilot:v1.2.0 \
+    --to-image=quay.io/kubevirt/virt-platform-autopilot:v1.3.0
+
+  # Compare two metadata.yaml files (e.g. from two git tags)
+  virt-platform-autopilot catalog-diff --from=old/metadata.yaml --to=assets/active/metadata.yaml
+
+  # Print this binary's catalog
+  virt-platform-autopilot catalog-diff --print-catalog
+`,
+		Args: cobra.NoArgs,
+		RunE: runCatalogDiff,
+	}
+
+	cmd.Flags().StringVar(&fromFile, "from", "", "Path to the metadata.yaml of the old version")
+	cmd.Flags().StringVar(&toFile, "to", "", "Path to the metadata.yaml of the new version (default: this binary's catalog)")
+	cmd.Flags().StringVar(&fromImage, "from-image", "", "Operator image of the old version")
+	cmd.Flags().StringVar(&toImage, "to-image", "", "Operator image of the new version (default: this binary's catalog)")
+	cmd.Flags().StringVar(&containerTool, "container-tool", "podman", "Container CLI used to run --from-image/--to-image")
+	cmd.Flags().StringVar(&outputFormat, "output", "text", "Output format: text, yaml, or json")
+	cmd.Flags().BoolVar(&printCatalog, "print-catalog", false, "Print this binary's metadata.yaml and exit")
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values("text", "yaml", "json"))
+
+	return cmd
+}
+
+// runCatalogDiff executes the catalog-diff command
+func runCatalogDiff(cmd *cobra.Command, _ []string) error {
+	loader := assets.NewLoader()
+
+	if printCatalog {
+		data, err := loader.LoadAsset(catalogPath)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if fromFile == "" && fromImage == "" {
+		return fmt.Errorf("either --from or --from-image must be specified")
+	}
+	if fromFile != "" && fromImage != "" {
+		return fmt.Errorf("--from and --from-image are mutually exclusive")
+	}
+	if toFile != "" && toImage != "" {
+		return fmt.Errorf("--to and --to-image are mutually exclusive")
+	}
+	if outputFormat != "text" && outputFormat != "yaml" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	cmd.SilenceUsage = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageTimeout)
+	defer cancel()
+
+	from, fromLabel, err := loadCatalog(ctx, loader, fromFile, fromImage, containerTool)
+	if err != nil {
+		return fmt.Errorf("failed to load old catalog: %w", err)
+	}
+	to, toLabel, err := loadCatalog(ctx, loader, toFile, toImage, containerTool)
+	if err != nil {
+		return fmt.Errorf("failed to load new catalog: %w", err)
+	}
+
+	diff := assets.DiffCatalogs(from, to)
+	return writeDiff(cmd.OutOrStdout(), diff, fromLabel, toLabel, outputFormat)
+}
+
+// loadCatalog reads a catalog from a file, an image (run with tool), or this binary,
+// and returns a label describing where it came from
+func loadCatalog(ctx context.Context, loader *assets.Loader, file, image, tool string) (*assets.AssetCatalog, string, error) {
+	var data []byte
+	var label string
+	var err error
+
+	switch {
+	case file != "":
+		label = file
+		data, err = os.ReadFile(file)
+	case image != "":
+		label = image
+		data, err = runContainer(ctx, tool,
+			"run", "--rm", "--entrypoint", imageEntrypoint, image, "catalog-diff", "--print-catalog")
+	default:
+		label = "this binary"
+		data, err = loader.LoadAsset(catalogPath)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	catalog, err := assets.ParseCatalog(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", label, err)
+	}
+	if len(catalog.Assets) == 0 {
+		return nil, "", fmt.Errorf("%s: catalog has no assets", label)
+	}
+	return catalog, label, nil
+}
+
+// writeDiff prints the diff in the requested format
+func writeDiff(w io.Writer, diff assets.CatalogDiff, fromLabel, toLabel, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	case "yaml":
+		data, err := yaml.Marshal(diff)
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Catalog diff: %s -> %s\n", fromLabel, toLabel)
+	if diff.FromVersion != "" || diff.ToVersion != "" {
+		_, _ = fmt.Fprintf(w, "Catalog version: %s -> %s\n", display(diff.FromVersion), display(diff.ToVersion))
+	}
+	if diff.Empty() {
+		_, _ = fmt.Fprintf(w, "\nNo changes (%d assets)\n", diff.Unchanged)
+		return nil
+	}
+
+	if len(diff.Added) > 0 {
+		_, _ = fmt.Fprintf(w, "\nAdded (%d):\n", len(diff.Added))
+		for _, asset := range diff.Added {
+			_, _ = fmt.Fprintf(w, "  + %s (component %s, install %s, conditions %s)\n",
+				asset.Name, asset.Component, asset.Install, assets.FormatConditions(asset.Conditions))
+		}
+	}
+	if len(diff.Removed) > 0 {
+		_, _ = fmt.Fprintf(w, "\nRemoved (%d):\n", len(diff.Removed))
+		for _, asset := range diff.Removed {
+			_, _ = fmt.Fprintf(w, "  - %s (component %s)\n", asset.Name, asset.Component)
+		}
+	}
+	if len(diff.Changed) > 0 {
+		_, _ = fmt.Fprintf(w, "\nChanged (%d):\n", len(diff.Changed))
+		for _, change := range diff.Changed {
+			_, _ = fmt.Fprintf(w, "  ~ %s\n", change.Name)
+			for _, field := range change.Fields {
+				_, _ = fmt.Fprintf(w, "      %s: %s -> %s\n", field.Field, display(field.From), display(field.To))
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\nUnchanged: %d\n", diff.Unchanged)
+	return nil
+}
+
+// display shows unset string fields explicitly
+func display(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalogdiff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+const oldCatalog = `assets:
+  - name: hco-golden-config
+    path: active/hco/golden-config.yaml.tpl
+    phase: 0
+    install: always
+    component: HyperConverged
+  - name: retired
+    path: active/retired.yaml
+    install: always
+    component: ConfigMap
+`
+
+func TestLoadCatalog(t *testing.T) {
+	loader := assets.NewLoader()
+	path := filepath.Join(t.TempDir(), "metadata.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(oldCatalog), 0644))
+
+	t.Run("file", func(t *testing.T) {
+		catalog, label, err := loadCatalog(context.Background(), loader, path, "", "podman")
+		require.NoError(t, err)
+		assert.Equal(t, path, label)
+		assert.Len(t, catalog.Assets, 2)
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		catalog, label, err := loadCatalog(context.Background(), loader, "", "", "podman")
+		require.NoError(t, err)
+		assert.Equal(t, "this binary", label)
+		assert.NotEmpty(t, catalog.Assets)
+	})
+
+	t.Run("image", func(t *testing.T) {
+		original := runContainer
+		defer func() { runContainer = original }()
+
+		var gotArgs []string
+		runContainer = func(_ context.Context, tool string, args ...string) ([]byte, error) {
+			gotArgs = append([]string{tool}, args...)
+			return []byte(oldCatalog), nil
+		}
+
+		catalog, label, err := loadCatalog(context.Background(), loader, "", "quay.io/example/autopilot:v1", "podman")
+		require.NoError(t, err)
+		assert.Equal(t, "quay.io/example/autopilot:v1", label)
+		assert.Len(t, catalog.Assets, 2)
+		assert.Equal(t, []string{
+			"podman", "run", "--rm", "--entrypoint", "/manager",
+			"quay.io/example/autopilot:v1", "catalog-diff", "--print-catalog",
+		}, gotArgs)
+	})
+
+	t.Run("empty catalog", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.yaml")
+		require.NoError(t, os.WriteFile(empty, []byte("assets: []\n"), 0644))
+		_, _, err := loadCatalog(context.Background(), loader, empty, "", "podman")
+		assert.ErrorContains(t, err, "catalog has no assets")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, err := loadCatalog(context.Background(), loader, filepath.Join(t.TempDir(), "missing.yaml"), "", "podman")
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteDiff(t *testing.T) {
+	diff := assets.CatalogDiff{
+		Added: []assets.AssetMetadata{{
+			Name: "new", Component: "ConfigMap", Install: assets.InstallModeOptIn,
+			Conditions: []assets.AssetCondition{{Type: assets.ConditionTypeAnnotation, Key: "k", Value: "v"}},
+		}},
+		Removed: []assets.AssetMetadata{{Name: "retired", Component: "ConfigMap"}},
+		Changed: []assets.AssetChange{{
+			Name:   "promoted",
+			Fields: []assets.FieldChange{{Field: "group", From: "", To: "g"}},
+		}},
+		Unchanged: 3,
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeDiff(&out, diff, "old.yaml", "this binary", "text"))
+	assert.Equal(t, `Catalog diff: old.yaml -> this binary
+
+Added (1):
+  + new (component ConfigMap, install opt-in, conditions annotation(k=v))
+
+Removed (1):
+  - retired (component ConfigMap)
+
+Changed (1):
+  ~ promoted
+      group: "" -> g
+
+Unchanged: 3
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeDiff(&out, assets.CatalogDiff{Unchanged: 5}, "a", "b", "text"))
+	assert.Contains(t, out.String(), "No changes (5 assets)")
+	assert.NotContains(t, out.String(), "Catalog version")
+
+	out.Reset()
+	require.NoError(t, writeDiff(&out, assets.CatalogDiff{ToVersion: "1.0.0"}, "a", "b", "text"))
+	assert.Contains(t, out.String(), `Catalog version: "" -> 1.0.0`)
+
+	out.Reset()
+	require.NoError(t, writeDiff(&out, diff, "a", "b", "json"))
+	var decoded assets.CatalogDiff
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, diff, decoded)
+}
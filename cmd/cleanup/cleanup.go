@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// Mode selects what cleanup does with the managed resources
+type Mode string
+
+const (
+	// ModeAbandon removes the managed-by label and the autopilot's field ownership,
+	// leaving the resources in place
+	ModeAbandon Mode = "abandon"
+	// ModeDelete deletes the managed resources
+	ModeDelete Mode = "delete"
+)
+
+// Validate rejects unknown modes
+func (m Mode) Validate() error {
+	switch m {
+	case ModeAbandon, ModeDelete:
+		return nil
+	}
+	return fmt.Errorf("unknown cleanup mode %q, expected %s or %s", m, ModeAbandon, ModeDelete)
+}
+
+var (
+	kubeconfig string
+	mode       string
+	dryRun     bool
+)
+
+// Options select what Cleanup does
+type Options struct {
+	Mode   Mode
+	DryRun bool
+}
+
+// NewCleanupCommand creates the cleanup subcommand
+func NewCleanupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Release or delete every resource the autopilot manages, before uninstalling it",
+		Long: `Find every resource carrying the platform.kubevirt.io/managed-by label among the
+kinds the embedded catalog and its tombstones manage, and release or delete them.
+
+  --mode=abandon  removes the managed-by label and the autopilot's managedFields
+                  entries: the resources keep their current content and are left
+                  for manual management, and the next server-side apply by anyone
+                  takes their fields over without conflicts
+  --mode=delete   deletes the resources
+
+The HyperConverged resource and Namespaces are only ever abandoned: deleting them
+would uninstall OpenShift Virtualization or everything running in the namespace.
+
+Stop the operator first (remove its Subscription and CSV, or scale it to zero),
+otherwise it re-applies what was cleaned up on its next reconcile.
+
+Examples:
+  virt-platform-autopilot cleanup --mode=abandon --dry-run
+  virt-platform-autopilot cleanup --mode=delete
+`,
+		Args: cobra.NoArgs,
+		RunE: runCleanup,
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&mode, "mode", string(ModeAbandon), "What to do with the managed resources: abandon or delete")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print what would be done instead of doing it")
+	_ = cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions(
+		[]string{string(ModeAbandon), string(ModeDelete)}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// runCleanup executes the cleanup command
+func runCleanup(cmd *cobra.Command, _ []string) error {
+	opts := Options{Mode: Mode(mode), DryRun: dryRun}
+	if err := opts.Mode.Validate(); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		return fmt.Errorf("failed to load asset catalog: %w", err)
+	}
+	kinds := registry.Kinds()
+	tombstones, err := assets.NewLoader().LoadTombstones()
+	if err != nil {
+		return fmt.Errorf("failed to load tombstones: %w", err)
+	}
+	for _, ts := range tombstones {
+		kinds = append(kinds, ts.GVK)
+	}
+
+	c, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	ctx := context.Background()
+	objs, err := ManagedObjects(ctx, c, kinds)
+	if err != nil {
+		return err
+	}
+	return Cleanup(ctx, c, objs, opts, cmd.OutOrStdout())
+}
+
+// ManagedObjects lists the objects of kinds that carry the managed-by label, sorted by
+// kind, namespace and name. Kinds the cluster does not serve are skipped, and a kind
+// listed under several versions is listed once.
+func ManagedObjects(ctx context.Context, c client.Reader, kinds []schema.GroupVersionKind) ([]*unstructured.Unstructured, error) {
+	listed := make(map[schema.GroupKind]bool)
+	var objs []*unstructured.Unstructured
+	for _, gvk := range kinds {
+		if listed[gvk.GroupKind()] {
+			continue
+		}
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := c.List(ctx, list, client.MatchingLabels{engine.ManagedByLabel: engine.ManagedByValue})
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+		listed[gvk.GroupKind()] = true
+		for i := range list.Items {
+			obj := &list.Items[i]
+			obj.SetGroupVersionKind(gvk)
+			objs = append(objs, obj)
+		}
+	}
+
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if a.GetKind() != b.GetKind() {
+			return a.GetKind() < b.GetKind()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return objs, nil
+}
+
+// Cleanup abandons or deletes objs according to opts and reports every object to w.
+// It goes on after a failure and returns all of them.
+func Cleanup(ctx context.Context, c client.Client, objs []*unstructured.Unstructured, opts Options, w io.Writer) error {
+	var errs []error
+	abandoned, deleted := 0, 0
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		name := fmt.Sprintf("%s %s", gvk.Kind, objectName(obj))
+
+		if gvk.GroupKind() == pkgcontext.HCOGVK.GroupKind() && obj.GetAnnotations()[overrides.AnnotationAutopilotEnabled] != "" {
+			fmt.Fprintf(w, "Warning: %s still carries %s; a running autopilot reconciles it again\n",
+				name, overrides.AnnotationAutopilotEnabled)
+		}
+
+		if opts.Mode == ModeDelete && !neverDeleted(gvk) {
+			if opts.DryRun {
+				fmt.Fprintf(w, "Would delete %s\n", name)
+				deleted++
+				continue
+			}
+			err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to delete %s: %w", name, err))
+				continue
+			}
+			fmt.Fprintf(w, "Deleted %s\n", name)
+			deleted++
+			continue
+		}
+
+		if opts.DryRun {
+			fmt.Fprintf(w, "Would abandon %s\n", name)
+			abandoned++
+			continue
+		}
+		if _, err := engine.AbandonOwnership(ctx, c, obj); err != nil {
+			errs = append(errs, fmt.Errorf("failed to abandon %s: %w", name, err))
+			continue
+		}
+		fmt.Fprintf(w, "Abandoned %s\n", name)
+		abandoned++
+	}
+
+	fmt.Fprintf(w, "%d deleted, %d abandoned, %d failed\n", deleted, abandoned, len(errs))
+	return errors.Join(errs...)
+}
+
+// neverDeleted reports kinds that are abandoned even in delete mode: deleting the HCO
+// uninstalls OpenShift Virtualization, and deleting a Namespace everything in it
+func neverDeleted(gvk schema.GroupVersionKind) bool {
+	return gvk.GroupKind() == pkgcontext.HCOGVK.GroupKind() ||
+		gvk.GroupKind() == (schema.GroupKind{Kind: "Namespace"})
+}
+
+func objectName(obj client.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
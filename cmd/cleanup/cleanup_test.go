@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+var (
+	configMapGVK = corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	namespaceGVK = corev1.SchemeGroupVersion.WithKind("Namespace")
+	managedBy    = map[string]string{engine.ManagedByLabel: engine.ManagedByValue}
+)
+
+func newClusterObjects() []client.Object {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetLabels(managedBy)
+	hco.SetAnnotations(map[string]string{overrides.AnnotationAutopilotEnabled: "true"})
+	return []client.Object{
+		hco,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-mtv", Labels: managedBy}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b-settings", Namespace: "openshift-cnv", Labels: managedBy}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a-settings", Namespace: "openshift-cnv", Labels: managedBy}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "user-settings", Namespace: "openshift-cnv"}},
+	}
+}
+
+func TestModeValidate(t *testing.T) {
+	assert.NoError(t, ModeAbandon.Validate())
+	assert.NoError(t, ModeDelete.Validate())
+	assert.ErrorContains(t, Mode("purge").Validate(), "unknown cleanup mode")
+}
+
+func TestManagedObjects(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(newClusterObjects()...).Build()
+	kinds := []schema.GroupVersionKind{
+		configMapGVK,
+		namespaceGVK,
+		pkgcontext.HCOGVK,
+		// Listed once, under its first version
+		{Group: "hco.kubevirt.io", Version: "v1beta1", Kind: "HyperConverged"},
+		// Not served by the cluster
+		{Group: "example.io", Version: "v1", Kind: "Widget"},
+	}
+
+	objs, err := ManagedObjects(context.Background(), c, kinds)
+	require.NoError(t, err)
+
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetObjectKind().GroupVersionKind().Kind+" "+objectName(obj))
+	}
+	assert.Equal(t, []string{
+		"ConfigMap openshift-cnv/a-settings",
+		"ConfigMap openshift-cnv/b-settings",
+		"HyperConverged openshift-cnv/kubevirt-hyperconverged",
+		"Namespace openshift-mtv",
+	}, names)
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	kinds := []schema.GroupVersionKind{configMapGVK, namespaceGVK, pkgcontext.HCOGVK}
+
+	tests := []struct {
+		name          string
+		opts          Options
+		wantDeleted   []string
+		wantAbandoned []string
+		wantOutput    string
+	}{
+		{
+			name:          "abandon",
+			opts:          Options{Mode: ModeAbandon},
+			wantAbandoned: []string{"a-settings", "b-settings", "kubevirt-hyperconverged", "openshift-mtv"},
+			wantOutput:    "0 deleted, 4 abandoned, 0 failed",
+		},
+		{
+			name:          "delete keeps the HCO and namespaces",
+			opts:          Options{Mode: ModeDelete},
+			wantDeleted:   []string{"a-settings", "b-settings"},
+			wantAbandoned: []string{"kubevirt-hyperconverged", "openshift-mtv"},
+			wantOutput:    "2 deleted, 2 abandoned, 0 failed",
+		},
+		{
+			name:       "dry run",
+			opts:       Options{Mode: ModeDelete, DryRun: true},
+			wantOutput: "Would delete ConfigMap openshift-cnv/a-settings",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(newClusterObjects()...).Build()
+			objs, err := ManagedObjects(ctx, c, kinds)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, Cleanup(ctx, c, objs, tt.opts, &out))
+			assert.Contains(t, out.String(), tt.wantOutput)
+			assert.Contains(t, out.String(), "Warning: HyperConverged openshift-cnv/kubevirt-hyperconverged still carries")
+
+			remaining, err := ManagedObjects(ctx, c, kinds)
+			require.NoError(t, err)
+			if tt.opts.DryRun {
+				assert.Len(t, remaining, len(objs), "dry run changed objects")
+				return
+			}
+			assert.Empty(t, remaining, "objects still labeled")
+
+			for _, obj := range objs {
+				live := &unstructured.Unstructured{}
+				live.SetGroupVersionKind(obj.GroupVersionKind())
+				err := c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+				switch {
+				case contains(tt.wantDeleted, obj.GetName()):
+					assert.True(t, apierrors.IsNotFound(err), "%s not deleted", obj.GetName())
+				case contains(tt.wantAbandoned, obj.GetName()):
+					require.NoError(t, err, "%s deleted", obj.GetName())
+				}
+			}
+
+			// Unmanaged objects are never touched
+			user := &corev1.ConfigMap{}
+			assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: "user-settings"}, user))
+		})
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package completion provides the completion command and the flag value completions
+// the other commands register, so admins can discover assets and output formats with <TAB>.
+package completion
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+// NewCompletionCommand creates the completion command. It replaces cobra's default one
+// so the supported shells and their setup are documented here.
+func NewCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion bash|zsh|fish",
+		Short: "Generate a shell completion script",
+		Long: `Print a completion script for bash, zsh or fish. Commands, flags, asset names
+and output formats then complete with <TAB>.
+
+Load it into the current shell, or install it once:
+  bash: source <(virt-platform-autopilot completion bash)
+        virt-platform-autopilot completion bash > /etc/bash_completion.d/virt-platform-autopilot
+  zsh:  virt-platform-autopilot completion zsh > "${fpath[1]}/_virt-platform-autopilot"
+  fish: virt-platform-autopilot completion fish > ~/.config/fish/completions/virt-platform-autopilot.fish
+
+The bash script requires the bash-completion package.
+`,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			}
+			return fmt.Errorf("unsupported shell %q, expected bash, zsh or fish", args[0])
+		},
+	}
+	return cmd
+}
+
+// Values completes a flag with a fixed set of values, such as output formats
+func Values(values ...string) cobra.CompletionFunc {
+	return cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+}
+
+// AssetNames completes a flag with the names of the assets in the embedded catalog
+func AssetNames(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []cobra.Completion
+	for _, asset := range registry.ListAssetsByReconcileOrder() {
+		names = append(names, cobra.CompletionWithDesc(asset.Name, asset.Component))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRoot() *cobra.Command {
+	root := &cobra.Command{Use: "virt-platform-autopilot"}
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.AddCommand(NewCompletionCommand())
+
+	render := &cobra.Command{Use: "render", Run: func(*cobra.Command, []string) {}}
+	render.Flags().String("asset", "", "")
+	render.Flags().String("output", "yaml", "")
+	_ = render.RegisterFlagCompletionFunc("asset", AssetNames)
+	_ = render.RegisterFlagCompletionFunc("output", Values("yaml", "json"))
+	root.AddCommand(render)
+	return root
+}
+
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	root := newRoot()
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestCompletionScripts(t *testing.T) {
+	for shell, marker := range map[string]string{
+		"bash": "# bash completion V2 for virt-platform-autopilot",
+		"zsh":  "#compdef virt-platform-autopilot",
+		"fish": "# fish completion for virt-platform-autopilot",
+	} {
+		t.Run(shell, func(t *testing.T) {
+			out, err := run(t, "completion", shell)
+			require.NoError(t, err)
+			assert.Contains(t, out, marker)
+		})
+	}
+
+	_, err := run(t, "completion", "tcsh")
+	assert.ErrorContains(t, err, `unsupported shell "tcsh"`)
+}
+
+func TestFlagCompletions(t *testing.T) {
+	out, err := run(t, cobra.ShellCompNoDescRequestCmd, "render", "--asset", "swap")
+	require.NoError(t, err)
+	assert.Contains(t, out, "swap-enable\n")
+
+	out, err = run(t, cobra.ShellCompRequestCmd, "render", "--asset", "swap-")
+	require.NoError(t, err)
+	assert.Contains(t, out, "swap-enable\tMachineConfig\n")
+
+	out, err = run(t, cobra.ShellCompNoDescRequestCmd, "render", "--output", "")
+	require.NoError(t, err)
+	assert.Contains(t, out, "yaml\njson\n")
+	assert.Contains(t, out, "ShellCompDirectiveNoFileComp")
+}
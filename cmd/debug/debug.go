@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgdebug "github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+)
+
+const (
+	// stdoutDir is the --output-dir value that streams the dump to stdout as a tar archive
+	stdoutDir = "-"
+
+	// dumpTimeout bounds collecting a dump
+	dumpTimeout = 2 * time.Minute
+)
+
+var (
+	kubeconfig string
+	outputDir  string
+)
+
+// NewDebugCommand creates the debug subcommand
+func NewDebugCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect the catalog and collect troubleshooting data from a cluster",
+	}
+	cmd.AddCommand(newDumpCommand(), newInventoryCommand(), newExclusionsCommand(), newCatalogCommand())
+	return cmd
+}
+
+// newDumpCommand creates the debug dump subcommand
+func newDumpCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Write catalog, render, exclusion, inventory, status and event data to a directory",
+		Long: `Collect everything needed to analyse the autopilot on a cluster into a directory:
+
+  catalog.yaml                 asset catalog of this binary
+  render.yaml                  every asset rendered against the live HCO, excluded ones included
+  exclusions.yaml              excluded and filtered assets with reasons
+  tombstones.yaml              resources scheduled for deletion
+  inventory.yaml               live state of every included asset (present, managed label, resourceVersion)
+  inventory/<asset>.yaml       the live object of each included asset
+  status/hyperconverged.yaml   the HyperConverged CR, including status
+  events.yaml                  recent events recorded by the autopilot (EventList)
+  errors.txt                   sections that could not be collected, if any
+
+Collection is best effort: missing permissions or resources are recorded in
+errors.txt and the remaining sections are still written. The must-gather script
+(hack/must-gather/gather_autopilot) runs this inside the operator pod.
+
+Examples:
+  # Dump from the current kubeconfig context
+  virt-platform-autopilot debug dump --kubeconfig=$KUBECONFIG --output-dir=./autopilot-dump
+
+  # Dump from inside the operator pod, streamed out as a tar archive
+  oc exec -n openshift-cnv deploy/virt-platform-autopilot -- /manager debug dump --output-dir=- | tar -x -C ./autopilot-dump
+`,
+		RunE: runDump,
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory to write the dump to, or - for a tar archive on stdout")
+	_ = cmd.MarkFlagRequired("output-dir")
+
+	return cmd
+}
+
+// runDump executes the debug dump command
+func runDump(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	k8sClient, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dumpTimeout)
+	defer cancel()
+
+	return dump(ctx, pkgdebug.NewServer(k8sClient, loader, registry), outputDir, cmd.OutOrStdout())
+}
+
+// dump writes the dump of server to dir, or as a tar archive to stdout when dir is "-"
+func dump(ctx context.Context, server *pkgdebug.Server, dir string, stdout io.Writer) error {
+	if dir != stdoutDir {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		return server.Dump(ctx, pkgdebug.DirWriter(dir))
+	}
+
+	archive := pkgdebug.NewTarWriter(stdout)
+	if err := server.Dump(ctx, archive); err != nil {
+		return err
+	}
+	return archive.Close()
+}
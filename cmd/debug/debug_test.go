@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	pkgdebug "github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+)
+
+func newTestServer(t *testing.T) *pkgdebug.Server {
+	t.Helper()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	return pkgdebug.NewServer(fake.NewClientBuilder().WithObjects(hco).Build(), loader, registry)
+}
+
+func TestDumpToDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "dump")
+
+	var stdout bytes.Buffer
+	require.NoError(t, dump(context.Background(), newTestServer(t), dir, &stdout))
+
+	assert.Empty(t, stdout.String())
+	assert.FileExists(t, filepath.Join(dir, pkgdebug.DumpRenderFile))
+	assert.FileExists(t, filepath.Join(dir, pkgdebug.DumpHCOFile))
+}
+
+func TestDumpToStdout(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, dump(context.Background(), newTestServer(t), stdoutDir, &stdout))
+
+	names := map[string]bool{}
+	tr := tar.NewReader(&stdout)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names[header.Name] = true
+	}
+
+	assert.True(t, names[pkgdebug.DumpCatalogFile])
+	assert.True(t, names[pkgdebug.DumpRenderFile])
+	assert.True(t, names[pkgdebug.DumpHCOFile])
+}
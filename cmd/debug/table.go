@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgdebug "github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+)
+
+// Output formats of the inventory, exclusions and catalog commands
+const (
+	outputTable = "table"
+	outputWide  = "wide"
+	outputYAML  = "yaml"
+	outputJSON  = "json"
+)
+
+// queryTimeout bounds the cluster queries of the inventory and exclusions commands
+const queryTimeout = 30 * time.Second
+
+var output string
+
+// newInventoryCommand creates the debug inventory subcommand
+func newInventoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "List the live objects of every included asset",
+		Long: `Render every asset against the live HCO and show whether its object exists on the
+cluster and carries the managed-by label.
+
+STATUS is Managed, Unmanaged (present without the managed-by label), Missing, or
+Error. -o wide adds the namespace, the source asset and the resourceVersion.
+
+Examples:
+  virt-platform-autopilot debug inventory --kubeconfig=$KUBECONFIG
+  virt-platform-autopilot debug inventory -o wide
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			server, err := newServer()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+			defer cancel()
+			items, err := server.Inventory(ctx)
+			if err != nil {
+				return err
+			}
+			return writeInventory(cmd.OutOrStdout(), items, output, time.Now())
+		},
+	}
+	addQueryFlags(cmd)
+	return cmd
+}
+
+// newExclusionsCommand creates the debug exclusions subcommand
+func newExclusionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exclusions",
+		Short: "List the assets excluded or filtered for the live HCO",
+		Long: `Show every asset that is not applied to the cluster and why: unmet conditions,
+an empty or failed render, or a root exclusion on the HCO.
+-o wide adds the source asset path and the details of the reason.
+
+Examples:
+  virt-platform-autopilot debug exclusions --kubeconfig=$KUBECONFIG
+  virt-platform-autopilot debug exclusions -o wide
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			server, err := newServer()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+			defer cancel()
+			exclusions, err := server.Exclusions(ctx)
+			if err != nil {
+				return err
+			}
+			return writeExclusions(cmd.OutOrStdout(), exclusions, output)
+		},
+	}
+	addQueryFlags(cmd)
+	return cmd
+}
+
+// newCatalogCommand creates the debug catalog subcommand
+func newCatalogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "List the assets of the embedded catalog",
+		Long: `Show the asset catalog of this binary; no cluster access is needed.
+-o wide adds the scope, reconcile order, conditions and source asset path.
+
+Examples:
+  virt-platform-autopilot debug catalog
+  virt-platform-autopilot debug catalog -o wide
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			registry, err := assets.NewRegistry(assets.NewLoader())
+			if err != nil {
+				return fmt.Errorf("failed to load asset registry: %w", err)
+			}
+			return writeCatalog(cmd.OutOrStdout(), registry.ListAssetsByReconcileOrder(), output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "Output format: table, wide, yaml or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values(outputTable, outputWide, outputYAML, outputJSON))
+	return cmd
+}
+
+// addQueryFlags registers the flags of the commands that read the cluster
+func addQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "Output format: table, wide, yaml or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values(outputTable, outputWide, outputYAML, outputJSON))
+}
+
+// newServer connects to the cluster and loads the embedded catalog
+func newServer() (*pkgdebug.Server, error) {
+	k8sClient, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load asset registry: %w", err)
+	}
+	return pkgdebug.NewServer(k8sClient, loader, registry), nil
+}
+
+// writeInventory prints items in format; now is the reference time of the AGE column
+func writeInventory(w io.Writer, items []pkgdebug.InventoryItem, format string, now time.Time) error {
+	if format != outputTable && format != outputWide {
+		return writeStructured(w, items, format)
+	}
+
+	wide := format == outputWide
+	header := []string{"NAME", "KIND", "STATUS", "AGE"}
+	if wide {
+		header = []string{"NAME", "NAMESPACE", "KIND", "STATUS", "AGE", "SOURCE ASSET", "RESOURCE VERSION"}
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		age := "<none>"
+		if item.Created != nil {
+			age = humanAge(now.Sub(item.Created.Time))
+		}
+		if !wide {
+			rows = append(rows, []string{item.Name, item.Kind, inventoryStatus(item), age})
+			continue
+		}
+		rows = append(rows, []string{item.Name, orNone(item.Namespace), item.Kind, inventoryStatus(item), age,
+			item.Asset, orNone(item.ResourceVersion)})
+	}
+	return writeTable(w, header, rows)
+}
+
+// inventoryStatus summarises the live state of an inventory item
+func inventoryStatus(item pkgdebug.InventoryItem) string {
+	switch {
+	case item.Error != "":
+		return "Error"
+	case !item.Present:
+		return "Missing"
+	case !item.Managed:
+		return "Unmanaged"
+	default:
+		return "Managed"
+	}
+}
+
+// writeExclusions prints exclusions in format
+func writeExclusions(w io.Writer, exclusions []pkgdebug.ExclusionInfo, format string) error {
+	if format != outputTable && format != outputWide {
+		return writeStructured(w, exclusions, format)
+	}
+
+	wide := format == outputWide
+	header := []string{"NAME", "COMPONENT", "REASON"}
+	if wide {
+		header = append(header, "MESSAGE", "SOURCE ASSET", "DETAILS")
+	}
+	rows := make([][]string, 0, len(exclusions))
+	for _, e := range exclusions {
+		row := []string{e.Asset, e.Component, string(e.Reason)}
+		if wide {
+			message := e.Message
+			if message == "" {
+				message = "<none>"
+			}
+			row = append(row, message, e.Path, formatDetails(e.Details))
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(w, header, rows)
+}
+
+// writeCatalog prints the catalog assets in format
+func writeCatalog(w io.Writer, catalog []assets.AssetMetadata, format string) error {
+	if format != outputTable && format != outputWide {
+		return writeStructured(w, catalog, format)
+	}
+
+	wide := format == outputWide
+	header := []string{"NAME", "COMPONENT", "INSTALL", "PHASE"}
+	if wide {
+		header = append(header, "SCOPE", "ORDER", "CONDITIONS", "SOURCE ASSET")
+	}
+	rows := make([][]string, 0, len(catalog))
+	for _, asset := range catalog {
+		row := []string{asset.Name, asset.Component, string(asset.Install), strconv.Itoa(asset.Phase)}
+		if wide {
+			row = append(row, string(asset.Scope), strconv.Itoa(asset.ReconcileOrder),
+				assets.FormatConditions(asset.Conditions), asset.Path)
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(w, header, rows)
+}
+
+// writeStructured prints v as YAML or JSON
+func writeStructured(w io.Writer, v any, format string) error {
+	switch format {
+	case outputYAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	default:
+		return fmt.Errorf("unsupported output format %q (table, wide, yaml or json)", format)
+	}
+}
+
+// writeTable prints header and rows as kubectl-style aligned columns
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(w, "No resources found.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatDetails joins details as sorted key=value pairs
+func formatDetails(details map[string]string) string {
+	if len(details) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(details))
+	for k, v := range details {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// orNone shows unset values the way kubectl does
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+// humanAge formats d like kubectl's AGE column: the largest whole unit, with minutes added below 8h
+func humanAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "<invalid>"
+	case d < 2*time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		if m := int(d.Minutes()) % 60; m > 0 && d < 8*time.Hour {
+			return fmt.Sprintf("%dh%dm", int(d.Hours()), m)
+		}
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+}
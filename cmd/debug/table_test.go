@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgdebug "github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func TestWriteInventory(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	created := metav1.NewTime(now.Add(-50 * time.Hour))
+	items := []pkgdebug.InventoryItem{
+		{Asset: "swap-enable", Kind: "MachineConfig", Name: "90-worker-swap", Present: true, Managed: true,
+			ResourceVersion: "42", Created: &created},
+		{Asset: "descheduler", Kind: "KubeDescheduler", Namespace: "openshift-kube-descheduler-operator", Name: "cluster"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeInventory(&out, items, outputTable, now))
+	assert.Equal(t, `NAME             KIND              STATUS    AGE
+90-worker-swap   MachineConfig     Managed   2d
+cluster          KubeDescheduler   Missing   <none>
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeInventory(&out, items, outputWide, now))
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, []string{"NAME", "NAMESPACE", "KIND", "STATUS", "AGE", "SOURCE", "ASSET", "RESOURCE", "VERSION"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"90-worker-swap", "<none>", "MachineConfig", "Managed", "2d", "swap-enable", "42"}, strings.Fields(lines[1]))
+
+	out.Reset()
+	require.NoError(t, writeInventory(&out, items, outputJSON, now))
+	var decoded []pkgdebug.InventoryItem
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Len(t, decoded, 2)
+
+	assert.Error(t, writeInventory(&out, items, "xml", now))
+}
+
+func TestInventoryStatus(t *testing.T) {
+	assert.Equal(t, "Managed", inventoryStatus(pkgdebug.InventoryItem{Present: true, Managed: true}))
+	assert.Equal(t, "Unmanaged", inventoryStatus(pkgdebug.InventoryItem{Present: true}))
+	assert.Equal(t, "Missing", inventoryStatus(pkgdebug.InventoryItem{}))
+	assert.Equal(t, "Error", inventoryStatus(pkgdebug.InventoryItem{Error: "forbidden"}))
+}
+
+func TestWriteExclusions(t *testing.T) {
+	exclusions := []pkgdebug.ExclusionInfo{{
+		Asset: "mtv-operator", Path: "active/operators/mtv.yaml.tpl", Component: "ForkliftController",
+		Reason: engine.ReasonTemplateSkipped, Message: "MTV is not installed",
+		Details: map[string]string{"b": "2", "a": "1"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeExclusions(&out, exclusions, outputWide))
+	assert.Contains(t, out.String(), "active/operators/mtv.yaml.tpl")
+	assert.Contains(t, out.String(), "a=1, b=2")
+	assert.Contains(t, out.String(), "TemplateSkipped")
+	assert.Contains(t, out.String(), "MTV is not installed")
+
+	out.Reset()
+	require.NoError(t, writeExclusions(&out, nil, outputTable))
+	assert.Equal(t, "No resources found.\n", out.String())
+}
+
+func TestWriteCatalog(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+	catalog := registry.ListAssetsByReconcileOrder()
+
+	var out bytes.Buffer
+	require.NoError(t, writeCatalog(&out, catalog, outputTable))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, len(catalog)+1)
+	assert.Equal(t, []string{"NAME", "COMPONENT", "INSTALL", "PHASE"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"hco-golden-config", "HyperConverged", "always", "0"}, strings.Fields(lines[1]))
+
+	out.Reset()
+	require.NoError(t, writeCatalog(&out, catalog, outputWide))
+	assert.Contains(t, strings.Split(out.String(), "\n")[1], "active/hco/golden-config.yaml.tpl")
+}
+
+func TestInventoryAndExclusionsFromServer(t *testing.T) {
+	server := newTestServer(t)
+
+	items, err := server.Inventory(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, items)
+
+	exclusions, err := server.Exclusions(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, exclusions)
+}
+
+func TestHumanAge(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:             "30s",
+		5 * time.Minute:              "5m",
+		3*time.Hour + 20*time.Minute: "3h20m",
+		10 * time.Hour:               "10h",
+		50 * time.Hour:               "2d",
+		2 * 365 * 24 * time.Hour:     "2y",
+		-time.Second:                 "<invalid>",
+	}
+	for d, want := range tests {
+		assert.Equal(t, want, humanAge(d), d.String())
+	}
+}
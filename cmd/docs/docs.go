@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+var contextOutputFormat string
+
+// NewDocsCommand creates the docs command, whose subcommands generate reference
+// documentation from the code it describes
+func NewDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate reference documentation",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newContextCommand())
+	cmd.AddCommand(newManCommand())
+
+	return cmd
+}
+
+// newContextCommand creates the docs context subcommand
+func newContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Print the template context reference",
+		Long: `Print every field and method asset templates can read from the render context,
+with its type, description, what it is detected from and an example value.
+
+The reference is derived from the RenderContext Go types: field names and types
+by reflection, descriptions from their doc comments, detectors and examples from
+the detector and example struct tags. docs/template-context.md is its Markdown
+output; regenerate it with "make docs-context" after changing the context.
+
+Examples:
+  virt-platform-autopilot docs context > docs/template-context.md
+  virt-platform-autopilot docs context --output=json | jq '.fields[] | select(.path | startswith(".Network"))'
+`,
+		Args: cobra.NoArgs,
+		RunE: runContext,
+	}
+
+	cmd.Flags().StringVar(&contextOutputFormat, "output", "markdown", "Output format: markdown or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values("markdown", "json"))
+
+	return cmd
+}
+
+// runContext executes the docs context command
+func runContext(cmd *cobra.Command, _ []string) error {
+	if contextOutputFormat != "markdown" && contextOutputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s", contextOutputFormat)
+	}
+
+	schema, err := pkgcontext.Schema()
+	if err != nil {
+		return fmt.Errorf("failed to derive the context schema: %w", err)
+	}
+
+	if contextOutputFormat == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(schema)
+	}
+	WriteContextMarkdown(cmd.OutOrStdout(), schema)
+	return nil
+}
+
+// WriteContextMarkdown writes schema as a Markdown reference with one section per
+// top-level context field
+func WriteContextMarkdown(w io.Writer, schema *pkgcontext.ContextSchema) {
+	fmt.Fprintln(w, "<!-- Code generated by \"virt-platform-autopilot docs context\". DO NOT EDIT. -->")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "# Template Context Reference")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Asset templates are rendered with the render context as their root (`.`).")
+	fmt.Fprintln(w, "This reference lists what each part of it holds and what it is detected from.")
+	fmt.Fprintln(w, "See [Adding Assets](adding-assets.md) for usage examples.")
+
+	for _, top := range schema.Fields {
+		if strings.Count(top.Path, ".") != 1 {
+			continue
+		}
+		fmt.Fprintf(w, "\n## `%s`\n\n", top.Path)
+		if top.Description != "" {
+			fmt.Fprintf(w, "%s.\n\n", strings.TrimSuffix(top.Description, "."))
+		}
+		fmt.Fprintf(w, "- Type: `%s`\n", top.Type)
+		if top.Detector != "" {
+			fmt.Fprintf(w, "- Detected from: %s\n", top.Detector)
+		}
+		if top.Example != "" {
+			fmt.Fprintf(w, "- Example: `%s`\n", top.Example)
+		}
+
+		prefix := top.Path + "."
+		var fields []pkgcontext.ContextField
+		for _, field := range schema.Fields {
+			if strings.HasPrefix(field.Path, prefix) || strings.HasPrefix(field.Path, top.Path+"[]") {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "| Field | Type | Description | Example |")
+			fmt.Fprintln(w, "|---|---|---|---|")
+			for _, field := range fields {
+				fmt.Fprintf(w, "| `%s` | `%s` | %s | %s |\n",
+					field.Path, field.Type, tableCell(field.Description), codeCell(field.Example))
+			}
+		}
+
+		var methods []pkgcontext.ContextMethod
+		for _, method := range schema.Methods {
+			if strings.HasPrefix(method.Path, prefix) {
+				methods = append(methods, method)
+			}
+		}
+		if len(methods) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "| Method | Signature | Description |")
+			fmt.Fprintln(w, "|---|---|---|")
+			for _, method := range methods {
+				fmt.Fprintf(w, "| `%s` | `%s` | %s |\n", method.Path, method.Signature, tableCell(method.Description))
+			}
+		}
+	}
+}
+
+// tableCell escapes s for a Markdown table cell
+func tableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// codeCell formats a non-empty s as code in a Markdown table cell
+func codeCell(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + tableCell(s) + "`"
+}
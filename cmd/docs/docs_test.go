@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func runDocs(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := NewDocsCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+// TestTemplateContextReferenceUpToDate keeps docs/template-context.md in sync with the
+// RenderContext; run "make docs-context" when it fails
+func TestTemplateContextReferenceUpToDate(t *testing.T) {
+	out, err := runDocs(t, "context")
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile("../../docs/template-context.md")
+	require.NoError(t, err)
+	assert.Equal(t, string(committed), out, "docs/template-context.md is stale, run make docs-context")
+}
+
+func TestContextMarkdown(t *testing.T) {
+	out, err := runDocs(t, "context")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "## `.Topology`")
+	assert.Contains(t, out, "- Detected from: node role labels and the cluster Infrastructure")
+	assert.Contains(t, out, "| `.Topology.IsCompact` | `bool` |")
+	assert.Contains(t, out, "| `.Mirrors.Mirrors[].Source` | `string` |")
+	assert.Contains(t, out, "| `.Mirrors.Rewrite` | `Rewrite(string) string` |")
+	// Methods mutating the context are not listed
+	assert.NotContains(t, out, "AddMirror")
+}
+
+func TestContextJSON(t *testing.T) {
+	out, err := runDocs(t, "context", "--output=json")
+	require.NoError(t, err)
+
+	schema := &pkgcontext.ContextSchema{}
+	require.NoError(t, json.Unmarshal([]byte(out), schema))
+	assert.NotEmpty(t, schema.Fields)
+	assert.NotEmpty(t, schema.Methods)
+}
+
+func TestContextRejectsUnknownFormat(t *testing.T) {
+	_, err := runDocs(t, "context", "--output=html")
+	assert.ErrorContains(t, err, "unsupported output format: html")
+	contextOutputFormat = "markdown"
+}
+
+func TestManPages(t *testing.T) {
+	root := &cobra.Command{Use: "virt-platform-autopilot", Short: "Root"}
+	root.CompletionOptions.DisableDefaultCmd = true
+	docs := NewDocsCommand()
+	root.AddCommand(docs)
+	root.AddCommand(&cobra.Command{Use: "secret", Hidden: true, Run: func(*cobra.Command, []string) {}})
+	root.InitDefaultHelpCmd()
+
+	dir := t.TempDir()
+	root.SetArgs([]string{"docs", "man", "--dir=" + dir})
+	require.NoError(t, root.Execute())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{
+		"virt-platform-autopilot.1",
+		"virt-platform-autopilot-docs.1",
+		"virt-platform-autopilot-docs-context.1",
+		"virt-platform-autopilot-docs-man.1",
+	}, names)
+
+	page, err := os.ReadFile(filepath.Join(dir, "virt-platform-autopilot-docs-context.1"))
+	require.NoError(t, err)
+	out := string(page)
+	assert.Contains(t, out, `.TH "VIRT-PLATFORM-AUTOPILOT-DOCS-CONTEXT" 1 ""`)
+	assert.Contains(t, out, "virt\\-platform\\-autopilot\\-docs\\-context \\- Print the template context reference\n")
+	assert.Contains(t, out, "\\fB\\-\\-output=string\\fP\nOutput format: markdown or json (default markdown)\n")
+	assert.Contains(t, out, ".SH SEE ALSO\n\\fBvirt\\-platform\\-autopilot\\-docs\\fP(1)\n")
+}
+
+func TestRoffText(t *testing.T) {
+	assert.Equal(t, "\\&.TH not a request\n\\&'quoted\nC:\\ePath \\-\\-flag\n", roffText(".TH not a request\n'quoted\nC:\\Path --flag"))
+}
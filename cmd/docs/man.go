@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var manDir string
+
+// newManCommand creates the docs man subcommand
+func newManCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages for every command",
+		Long: `Write a section 1 man page for the root command and each subcommand to --dir,
+named after the command path, e.g. virt-platform-autopilot-render.1. Pages are
+built from the same help text and flags as --help, so they never go stale.
+
+Examples:
+  virt-platform-autopilot docs man --dir=/usr/local/share/man/man1
+  man ./_output/man/virt-platform-autopilot-render.1
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			return WriteManPages(cmd.Root(), manDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&manDir, "dir", "", "Directory to write the man pages to (required)")
+	_ = cmd.MarkFlagRequired("dir")
+	_ = cmd.MarkFlagDirname("dir")
+
+	return cmd
+}
+
+// WriteManPages writes one man page per visible command of the tree rooted at root
+func WriteManPages(root *cobra.Command, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return writeManTree(root, dir)
+}
+
+func writeManTree(cmd *cobra.Command, dir string) error {
+	if !manVisible(cmd) {
+		return nil
+	}
+	path := filepath.Join(dir, manPageName(cmd))
+	if err := os.WriteFile(path, ManPage(cmd), 0o644); err != nil {
+		return fmt.Errorf("failed to write man page %s: %w", path, err)
+	}
+	for _, sub := range cmd.Commands() {
+		if err := writeManTree(sub, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manVisible skips hidden and deprecated commands and the generated help command
+func manVisible(cmd *cobra.Command) bool {
+	return cmd.IsAvailableCommand()
+}
+
+func manPageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-") + ".1"
+}
+
+// ManPage renders the roff source of cmd's man page. No date is written, so
+// regenerating unchanged commands produces identical files.
+func ManPage(cmd *cobra.Command) []byte {
+	var b bytes.Buffer
+	name := strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+	root := cmd.Root().Name()
+
+	fmt.Fprintf(&b, ".TH %q 1 \"\" %q %q\n", strings.ToUpper(name), root, "User Commands")
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, "\\fB%s\\fP\n", roffEscape(cmd.UseLine()))
+
+	b.WriteString(".SH DESCRIPTION\n")
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	b.WriteString(".nf\n")
+	b.WriteString(roffText(strings.TrimRight(description, "\n")))
+	b.WriteString(".fi\n")
+
+	writeManFlags(&b, "OPTIONS", cmd.NonInheritedFlags())
+	writeManFlags(&b, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	var related []string
+	if cmd.HasParent() {
+		related = append(related, manReference(cmd.Parent()))
+	}
+	for _, sub := range cmd.Commands() {
+		if manVisible(sub) {
+			related = append(related, manReference(sub))
+		}
+	}
+	if len(related) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		b.WriteString(strings.Join(related, ", ") + "\n")
+	}
+	return b.Bytes()
+}
+
+func writeManFlags(b *bytes.Buffer, section string, flags *pflag.FlagSet) {
+	if !flags.HasAvailableFlags() {
+		return
+	}
+	fmt.Fprintf(b, ".SH %s\n", section)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		b.WriteString(".TP\n")
+		name := "--" + flag.Name
+		if flag.Shorthand != "" {
+			name = "-" + flag.Shorthand + ", " + name
+		}
+		if flag.Value.Type() != "bool" {
+			name += "=" + flag.Value.Type()
+		}
+		fmt.Fprintf(b, "\\fB%s\\fP\n", roffEscape(name))
+		usage := flag.Usage
+		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		b.WriteString(roffText(usage))
+	})
+}
+
+func manReference(cmd *cobra.Command) string {
+	return fmt.Sprintf("\\fB%s\\fP(1)", roffEscape(strings.ReplaceAll(cmd.CommandPath(), " ", "-")))
+}
+
+// roffText escapes every line of s and protects lines that roff would read as requests
+func roffText(s string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(s, "\n") {
+		line = roffEscape(line)
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			line = "\\&" + line
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	return strings.ReplaceAll(s, "-", `\-`)
+}
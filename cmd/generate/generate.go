@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewGenerateCommand creates the generate command, whose subcommands derive
+// packaging artifacts from the embedded asset catalog and catalog files from the cluster
+func NewGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate packaging artifacts and catalog files",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newOLMBundleCommand())
+	cmd.AddCommand(newTombstoneCommand())
+
+	return cmd
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/assets"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/csv-generator/csv"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/csv-generator/parser"
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/rbac"
+)
+
+const (
+	// bundlePackage is the OLM package name of the standalone bundle
+	bundlePackage = "virt-platform-autopilot"
+
+	// csvFile is the CSV file name inside the bundle's manifests directory
+	csvFile = bundlePackage + ".clusterserviceversion.yaml"
+
+	// managedResourceCRDFile is the ManagedResource CRD file inside the manifests directory
+	managedResourceCRDFile = controller.ManagedResourceCRDName + ".crd.yaml"
+
+	// exclusionCRDFile is the AutopilotExclusion CRD file inside the manifests directory
+	exclusionCRDFile = overrides.AutopilotExclusionCRDName + ".crd.yaml"
+
+	// statusCRDFile is the AutopilotStatus CRD file inside the manifests directory
+	statusCRDFile = controller.AutopilotStatusCRDName + ".crd.yaml"
+
+	// deploymentCRDFile is the AutopilotDeployment CRD file inside the manifests directory
+	deploymentCRDFile = controller.AutopilotDeploymentCRDName + ".crd.yaml"
+)
+
+var (
+	bundleOutputDir        string
+	bundleChannel          string
+	bundleCSVVersion       string
+	bundleNamespace        string
+	bundleOperatorImage    string
+	bundleOperatorVersion  string
+	bundlePullPolicy       string
+	bundleAdditionalImages string
+)
+
+// newOLMBundleCommand creates the generate olm-bundle subcommand
+func newOLMBundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "olm-bundle",
+		Short: "Generate an OLM bundle whose CSV is derived from the asset catalog",
+		Long: `Write a registry+v1 OLM bundle (manifests/ and metadata/) for virt-platform-autopilot.
+
+Everything the catalog determines is derived from the embedded assets rather than
+maintained by hand, so the packaging cannot drift from what the operator manages:
+
+  - cluster permissions: the rules of config/rbac/role.yaml, computed from the
+    active assets and the tombstones (which need delete)
+  - required CRDs: the HyperConverged CRD, the only hard dependency
+  - the platform.kubevirt.io/managed-crds annotation: the CRDs of the active and
+    tombstoned resources; they are soft dependencies and stay out of the required
+    list, which would block installation on clusters without them
+  - alm-examples: a HyperConverged with the autopilot activation annotation
+  - owned CRDs: ManagedResource, the read-only inventory of applied objects,
+    AutopilotExclusion, the structured exclusions, AutopilotStatus, the
+    ClusterOperator-style aggregate status, and AutopilotDeployment, the owner
+    of the applied objects
+
+The CSV is the one csv-generator produces for the unified HCO bundle, plus the
+catalog-derived annotations above.
+
+Examples:
+  virt-platform-autopilot generate olm-bundle --csv-version=0.2.0 --output-dir=bundle
+  virt-platform-autopilot generate olm-bundle --csv-version=0.2.0 --output-dir=bundle \
+    --operator-image=quay.io/openshift-virtualization/virt-platform-autopilot@sha256:...
+`,
+		Args: cobra.NoArgs,
+		RunE: runOLMBundle,
+	}
+
+	cmd.Flags().StringVar(&bundleOutputDir, "output-dir", "", "Directory the bundle is written to (required)")
+	cmd.Flags().StringVar(&bundleCSVVersion, "csv-version", "", "Version of the ClusterServiceVersion (required)")
+	cmd.Flags().StringVar(&bundleChannel, "channel", "alpha", "OLM channel of the bundle, also its default channel")
+	cmd.Flags().StringVar(&bundleNamespace, "namespace", pkgcontext.DefaultHCONamespace, "Namespace the operator is installed in")
+	cmd.Flags().StringVar(&bundleOperatorImage, "operator-image", "quay.io/openshift-virtualization/virt-platform-autopilot:latest",
+		"Operator container image reference")
+	cmd.Flags().StringVar(&bundleOperatorVersion, "operator-version", "", "Operator version (defaults to --csv-version)")
+	cmd.Flags().StringVar(&bundlePullPolicy, "pull-policy", "IfNotPresent", "Image pull policy")
+	cmd.Flags().StringVar(&bundleAdditionalImages, "additional-images", "",
+		"Comma-separated ENVKEY:image pairs injected as environment variables and listed as related images")
+	_ = cmd.MarkFlagRequired("output-dir")
+	_ = cmd.MarkFlagRequired("csv-version")
+
+	return cmd
+}
+
+// runOLMBundle executes the generate olm-bundle command
+func runOLMBundle(cmd *cobra.Command, _ []string) error {
+	if bundleOutputDir == "" {
+		return fmt.Errorf("--output-dir must not be empty")
+	}
+	cmd.SilenceUsage = true
+
+	opts, err := bundleOptions()
+	if err != nil {
+		return err
+	}
+	files, err := writeBundle(bundleOutputDir, csv.BuildCSV(opts), bundleChannel)
+	if err != nil {
+		return err
+	}
+	printBundleSummary(cmd.OutOrStdout(), files, opts)
+	return nil
+}
+
+// bundleOptions collects the CSV inputs from the flags and the embedded catalog
+func bundleOptions() (csv.Options, error) {
+	rules, err := rbac.AllRules(assets.EmbeddedFS)
+	if err != nil {
+		return csv.Options{}, fmt.Errorf("failed to generate RBAC rules: %w", err)
+	}
+
+	loader := pkgassets.NewLoader()
+	registry, err := pkgassets.NewRegistry(loader)
+	if err != nil {
+		return csv.Options{}, fmt.Errorf("failed to load asset registry: %w", err)
+	}
+	tombstones, err := loader.LoadTombstones()
+	if err != nil {
+		return csv.Options{}, fmt.Errorf("failed to load tombstones: %w", err)
+	}
+
+	return csv.Options{
+		CSVVersion:       bundleCSVVersion,
+		Namespace:        bundleNamespace,
+		OperatorImage:    bundleOperatorImage,
+		OperatorVersion:  bundleOperatorVersion,
+		PullPolicy:       bundlePullPolicy,
+		AdditionalImages: parser.ParseAdditionalImages(bundleAdditionalImages),
+		Rules:            rules,
+		ManagedCRDs:      managedCRDs(registry.ListAssets(nil), tombstones),
+		Examples:         []map[string]any{exampleHCO(bundleNamespace)},
+		OwnedCRDs: []csv.CRDDescription{{
+			Name:        controller.ManagedResourceCRDName,
+			Version:     controller.ManagedResourceGVK.Version,
+			Kind:        controller.ManagedResourceGVK.Kind,
+			DisplayName: "Managed Resource",
+			Description: "State of an object applied by the autopilot, one per applied object.",
+		}, {
+			Name:        overrides.AutopilotExclusionCRDName,
+			Version:     overrides.AutopilotExclusionGVK.Version,
+			Kind:        overrides.AutopilotExclusionGVK.Kind,
+			DisplayName: "Autopilot Exclusion",
+			Description: "Objects the autopilot must not apply, with an optional expiry; the status lists what it matched.",
+		}, {
+			Name:        controller.AutopilotStatusCRDName,
+			Version:     controller.AutopilotStatusGVK.Version,
+			Kind:        controller.AutopilotStatusGVK.Kind,
+			DisplayName: "Autopilot Status",
+			Description: "Available, Progressing and Degraded conditions aggregated from the state of every asset.",
+		}, {
+			Name:        controller.AutopilotDeploymentCRDName,
+			Version:     controller.AutopilotDeploymentGVK.Version,
+			Kind:        controller.AutopilotDeploymentGVK.Kind,
+			DisplayName: "Autopilot Deployment",
+			Description: "Owner of the objects applied in the namespace of a HyperConverged, for garbage collection.",
+		}},
+	}, nil
+}
+
+// managedCRDs returns the sorted CRDs of the catalog's assets and tombstones
+func managedCRDs(catalog []pkgassets.AssetMetadata, tombstones []pkgassets.TombstoneMetadata) []string {
+	seen := make(map[string]bool)
+	for _, asset := range catalog {
+		seen[asset.RequiredCRD] = true
+		seen[asset.GateCRD] = true
+	}
+	for _, ts := range tombstones {
+		seen[ts.CRDName()] = true
+	}
+	delete(seen, "")
+
+	crds := make([]string, 0, len(seen))
+	for crd := range seen {
+		crds = append(crds, crd)
+	}
+	sort.Strings(crds)
+	return crds
+}
+
+// exampleHCO is the alm-examples entry: a HyperConverged with the autopilot enabled
+func exampleHCO(namespace string) map[string]any {
+	return map[string]any{
+		"apiVersion": pkgcontext.HCOGVK.GroupVersion().String(),
+		"kind":       pkgcontext.HCOKind,
+		"metadata": map[string]any{
+			"name":      pkgcontext.HCOName,
+			"namespace": namespace,
+			"annotations": map[string]any{
+				overrides.AnnotationAutopilotEnabled: "true",
+			},
+		},
+		"spec": map[string]any{},
+	}
+}
+
+// writeBundle writes the CSV, the owned CRDs and the bundle annotations below dir and
+// returns the written paths relative to dir
+func writeBundle(dir string, clusterServiceVersion csv.ClusterServiceVersion, channel string) ([]string, error) {
+	annotations := map[string]any{
+		"annotations": map[string]string{
+			"operators.operatorframework.io.bundle.mediatype.v1":       "registry+v1",
+			"operators.operatorframework.io.bundle.manifests.v1":       "manifests/",
+			"operators.operatorframework.io.bundle.metadata.v1":        "metadata/",
+			"operators.operatorframework.io.bundle.package.v1":         bundlePackage,
+			"operators.operatorframework.io.bundle.channels.v1":        channel,
+			"operators.operatorframework.io.bundle.channel.default.v1": channel,
+		},
+	}
+
+	files := []struct {
+		path   string
+		object any
+	}{
+		{filepath.Join("manifests", csvFile), clusterServiceVersion},
+		{filepath.Join("manifests", managedResourceCRDFile), controller.ManagedResourceCRD()},
+		{filepath.Join("manifests", exclusionCRDFile), controller.AutopilotExclusionCRD()},
+		{filepath.Join("manifests", statusCRDFile), controller.AutopilotStatusCRD()},
+		{filepath.Join("manifests", deploymentCRDFile), controller.AutopilotDeploymentCRD()},
+		{filepath.Join("metadata", "annotations.yaml"), annotations},
+	}
+
+	written := make([]string, 0, len(files))
+	for _, file := range files {
+		data, err := yaml.Marshal(file.object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", file.path, err)
+		}
+		path := filepath.Join(dir, file.path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, file.path)
+	}
+	return written, nil
+}
+
+// printBundleSummary lists the written files and what was derived from the catalog
+func printBundleSummary(w io.Writer, files []string, opts csv.Options) {
+	for _, file := range files {
+		fmt.Fprintf(w, "wrote %s\n", file)
+	}
+	fmt.Fprintf(w, "%d permission rules, %d managed CRDs\n", len(opts.Rules), len(opts.ManagedCRDs))
+}
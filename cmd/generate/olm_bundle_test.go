@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/assets"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/csv-generator/csv"
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/rbac"
+)
+
+func runGenerate(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := NewGenerateCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestOLMBundleDerivedFromCatalog(t *testing.T) {
+	dir := t.TempDir()
+	out, err := runGenerate(t, "olm-bundle", "--csv-version=1.2.3", "--output-dir="+dir, "--channel=stable")
+	require.NoError(t, err)
+	assert.Contains(t, out, "wrote manifests/"+csvFile)
+	assert.Contains(t, out, "wrote metadata/annotations.yaml")
+
+	data, err := os.ReadFile(filepath.Join(dir, "manifests", csvFile))
+	require.NoError(t, err)
+	generated := csv.ClusterServiceVersion{}
+	require.NoError(t, yaml.Unmarshal(data, &generated))
+	assert.Equal(t, "virt-platform-autopilot.v1.2.3", generated.Metadata.Name)
+
+	t.Run("permissions match the generated RBAC", func(t *testing.T) {
+		rules, err := rbac.AllRules(assets.EmbeddedFS)
+		require.NoError(t, err)
+		require.Len(t, generated.Spec.Install.Spec.ClusterPermissions, 1)
+		permissions := generated.Spec.Install.Spec.ClusterPermissions[0].Rules
+		require.Len(t, permissions, len(rules))
+		for i, rule := range rules {
+			assert.Equal(t, rule.APIGroups, permissions[i].APIGroups)
+			assert.Equal(t, rule.Resources, permissions[i].Resources)
+			assert.Equal(t, rule.Verbs, permissions[i].Verbs)
+		}
+	})
+
+	t.Run("only the HCO is a required CRD", func(t *testing.T) {
+		require.Len(t, generated.Spec.CustomResourceDefinitions.Required, 1)
+		assert.Equal(t, "hyperconvergeds.hco.kubevirt.io", generated.Spec.CustomResourceDefinitions.Required[0].Name)
+	})
+
+	t.Run("the ManagedResource, AutopilotExclusion, AutopilotStatus and AutopilotDeployment CRDs are owned and shipped", func(t *testing.T) {
+		require.Len(t, generated.Spec.CustomResourceDefinitions.Owned, 4)
+		assert.Equal(t, controller.ManagedResourceCRDName, generated.Spec.CustomResourceDefinitions.Owned[0].Name)
+		assert.Equal(t, overrides.AutopilotExclusionCRDName, generated.Spec.CustomResourceDefinitions.Owned[1].Name)
+		assert.Equal(t, controller.AutopilotStatusCRDName, generated.Spec.CustomResourceDefinitions.Owned[2].Name)
+		assert.Equal(t, controller.AutopilotDeploymentCRDName, generated.Spec.CustomResourceDefinitions.Owned[3].Name)
+
+		for _, file := range []string{managedResourceCRDFile, exclusionCRDFile, statusCRDFile, deploymentCRDFile} {
+			assert.Contains(t, out, "wrote manifests/"+file)
+			data, err := os.ReadFile(filepath.Join(dir, "manifests", file))
+			require.NoError(t, err)
+			crd := map[string]any{}
+			require.NoError(t, yaml.Unmarshal(data, &crd))
+			assert.Equal(t, "CustomResourceDefinition", crd["kind"])
+		}
+	})
+
+	t.Run("managed CRDs cover the catalog", func(t *testing.T) {
+		managed := strings.Split(generated.Metadata.Annotations[csv.ManagedCRDsAnnotation], ",")
+		assert.Contains(t, managed, "machineconfigs.machineconfiguration.openshift.io")
+		assert.IsIncreasing(t, managed)
+
+		registry, err := pkgassets.NewRegistry(pkgassets.NewLoader())
+		require.NoError(t, err)
+		for _, asset := range registry.ListAssets(nil) {
+			if asset.RequiredCRD != "" {
+				assert.Contains(t, managed, asset.RequiredCRD, "asset %s", asset.Name)
+			}
+		}
+	})
+
+	t.Run("alm-examples holds an activated HyperConverged", func(t *testing.T) {
+		var examples []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(generated.Metadata.Annotations["alm-examples"]), &examples))
+		require.Len(t, examples, 1)
+		assert.Equal(t, "HyperConverged", examples[0]["kind"])
+		metadata := examples[0]["metadata"].(map[string]any)
+		assert.Equal(t, "openshift-cnv", metadata["namespace"])
+		assert.Equal(t, map[string]any{"platform.kubevirt.io/autopilot": "true"}, metadata["annotations"])
+	})
+
+	t.Run("bundle annotations", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(dir, "metadata", "annotations.yaml"))
+		require.NoError(t, err)
+		var doc struct {
+			Annotations map[string]string `json:"annotations"`
+		}
+		require.NoError(t, yaml.Unmarshal(data, &doc))
+		assert.Equal(t, "registry+v1", doc.Annotations["operators.operatorframework.io.bundle.mediatype.v1"])
+		assert.Equal(t, "virt-platform-autopilot", doc.Annotations["operators.operatorframework.io.bundle.package.v1"])
+		assert.Equal(t, "stable", doc.Annotations["operators.operatorframework.io.bundle.channels.v1"])
+		assert.Equal(t, "stable", doc.Annotations["operators.operatorframework.io.bundle.channel.default.v1"])
+	})
+}
+
+func TestOLMBundleRequiresOutputDir(t *testing.T) {
+	_, err := runGenerate(t, "olm-bundle", "--csv-version=1.2.3", "--output-dir=")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--output-dir must not be empty")
+}
+
+func TestManagedCRDs(t *testing.T) {
+	catalog := []pkgassets.AssetMetadata{
+		{Name: "a", RequiredCRD: "b.example.io", GateCRD: "a.example.io"},
+		{Name: "b", RequiredCRD: "b.example.io"},
+		{Name: "c"},
+	}
+	tombstones := []pkgassets.TombstoneMetadata{{}}
+	tombstones[0].GVK.Group = "example.io"
+	tombstones[0].GVK.Kind = "Widget"
+
+	assert.Equal(t, []string{"a.example.io", "b.example.io", "widgets.example.io"}, managedCRDs(catalog, tombstones))
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+var (
+	tombstoneKind       string
+	tombstoneName       string
+	tombstoneNamespace  string
+	tombstoneAPIVersion string
+	tombstoneKubeconfig string
+	tombstoneOutputDir  string
+)
+
+// newTombstoneCommand creates the generate tombstone subcommand
+func newTombstoneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tombstone",
+		Short: "Generate a tombstone for a live object the autopilot manages",
+		Long: `Fetch an object from the cluster and write the tombstone that deletes it.
+
+The object must carry the platform.kubevirt.io/managed-by=virt-platform-autopilot
+label, which the reconciler checks before deleting a tombstoned object: a tombstone
+for an object without it would never take effect. The tombstone holds only the
+apiVersion, kind, name, namespace and that label.
+
+The kind is resolved through API discovery; --api-version picks the group (and
+version) when several groups serve the same kind. Without --output-dir the tombstone
+is printed; with it, it is written to <kind>-<name>.yaml in that directory, typically
+a release directory below assets/tombstones.
+
+Examples:
+  virt-platform-autopilot generate tombstone --kind=MachineConfig --name=50-old-thing \
+    --kubeconfig=$KUBECONFIG --output-dir=assets/tombstones/v1.2-cleanup
+  virt-platform-autopilot generate tombstone --kind=ConfigMap --namespace=openshift-cnv \
+    --name=old-settings --api-version=v1
+`,
+		Args: cobra.NoArgs,
+		RunE: runTombstone,
+	}
+
+	cmd.Flags().StringVar(&tombstoneKind, "kind", "", "Kind of the object (required)")
+	cmd.Flags().StringVar(&tombstoneName, "name", "", "Name of the object (required)")
+	cmd.Flags().StringVar(&tombstoneNamespace, "namespace", "", "Namespace of the object, for namespaced kinds")
+	cmd.Flags().StringVar(&tombstoneAPIVersion, "api-version", "", "API group/version of the kind (default: resolved through discovery)")
+	cmd.Flags().StringVar(&tombstoneKubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&tombstoneOutputDir, "output-dir", "", "Tombstones directory the file is written to (default: print to stdout)")
+	_ = cmd.MarkFlagRequired("kind")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// runTombstone executes the generate tombstone command
+func runTombstone(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+	cmd.SilenceUsage = true
+
+	config, err := cluster.RestConfig(tombstoneKubeconfig)
+	if err != nil {
+		return err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	resources, err := discoveryClient.ServerPreferredResources()
+	if err != nil && (len(resources) == 0 || !discovery.IsGroupDiscoveryFailedError(err)) {
+		return fmt.Errorf("failed to discover API resources: %w", err)
+	}
+	gvk, namespaced, err := resolveKind(resources, tombstoneKind, tombstoneAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(config, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	tombstone, err := buildTombstone(ctx, c, gvk, namespaced, tombstoneNamespace, tombstoneName)
+	if err != nil {
+		return err
+	}
+	return writeTombstone(cmd.OutOrStdout(), tombstoneOutputDir, tombstone)
+}
+
+// resolveKind finds the GVK serving kind among the discovered resources and whether it
+// is namespaced. apiVersion, when set, restricts the match to its group and replaces
+// the preferred version. A kind served by several groups is an error naming them.
+func resolveKind(resources []*metav1.APIResourceList, kind, apiVersion string) (schema.GroupVersionKind, bool, error) {
+	var want *schema.GroupVersion
+	if apiVersion != "" {
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return schema.GroupVersionKind{}, false, fmt.Errorf("invalid --api-version: %w", err)
+		}
+		want = &gv
+	}
+
+	type match struct {
+		gvk        schema.GroupVersionKind
+		namespaced bool
+	}
+	var matches []match
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil || (want != nil && gv.Group != want.Group) {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			// Subresources such as machineconfigs/status share the kind
+			if resource.Kind != kind || strings.Contains(resource.Name, "/") {
+				continue
+			}
+			if want != nil {
+				gv = *want
+			}
+			matches = append(matches, match{gvk: gv.WithKind(kind), namespaced: resource.Namespaced})
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if want != nil {
+			return schema.GroupVersionKind{}, false, fmt.Errorf("kind %s is not served in group %q", kind, want.Group)
+		}
+		return schema.GroupVersionKind{}, false, fmt.Errorf("kind %s is not served by the cluster", kind)
+	case 1:
+		return matches[0].gvk, matches[0].namespaced, nil
+	}
+	groups := make([]string, 0, len(matches))
+	for _, m := range matches {
+		groups = append(groups, m.gvk.GroupVersion().String())
+	}
+	sort.Strings(groups)
+	return schema.GroupVersionKind{}, false, fmt.Errorf("kind %s is served by %s, pick one with --api-version",
+		kind, strings.Join(groups, ", "))
+}
+
+// buildTombstone fetches the object and returns its tombstone. The object must exist
+// and carry the managed-by label, or the tombstone would never delete anything.
+func buildTombstone(ctx context.Context, reader client.Reader, gvk schema.GroupVersionKind,
+	namespaced bool, namespace, name string) (*unstructured.Unstructured, error) {
+	switch {
+	case namespaced && namespace == "":
+		return nil, fmt.Errorf("%s is namespaced, --namespace is required", gvk.Kind)
+	case !namespaced && namespace != "":
+		return nil, fmt.Errorf("%s is cluster-scoped, --namespace must not be set", gvk.Kind)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(gvk)
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, live); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%s %s not found: there is nothing to tombstone", gvk.Kind, objectRef(namespace, name))
+		}
+		return nil, fmt.Errorf("failed to fetch %s %s: %w", gvk.Kind, objectRef(namespace, name), err)
+	}
+	if value := live.GetLabels()[pkgassets.TombstoneLabel]; value != pkgassets.TombstoneLabelValue {
+		return nil, fmt.Errorf("%s %s lacks the %s=%s label (got %q): the autopilot does not manage it and would refuse to delete it",
+			gvk.Kind, objectRef(namespace, name), pkgassets.TombstoneLabel, pkgassets.TombstoneLabelValue, value)
+	}
+
+	tombstone := &unstructured.Unstructured{}
+	tombstone.SetGroupVersionKind(gvk)
+	tombstone.SetName(name)
+	tombstone.SetNamespace(namespace)
+	tombstone.SetLabels(map[string]string{pkgassets.TombstoneLabel: pkgassets.TombstoneLabelValue})
+	return tombstone, nil
+}
+
+// writeTombstone prints the tombstone, or writes it below dir without overwriting an
+// existing file
+func writeTombstone(stdout io.Writer, dir string, tombstone *unstructured.Unstructured) error {
+	data, err := yaml.Marshal(tombstone.Object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tombstone: %w", err)
+	}
+	if dir == "" {
+		_, err := stdout.Write(data)
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, strings.ToLower(tombstone.GetKind())+"-"+tombstone.GetName()+".yaml")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create tombstone: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Fprintf(stdout, "wrote %s\n", path)
+	return nil
+}
+
+func objectRef(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
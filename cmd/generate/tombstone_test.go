@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+var machineConfigGVK = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfig"}
+
+func TestResolveKind(t *testing.T) {
+	resources := []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+		}},
+		{GroupVersion: "machineconfiguration.openshift.io/v1", APIResources: []metav1.APIResource{
+			{Name: "machineconfigs", Kind: "MachineConfig"},
+			{Name: "machineconfigs/status", Kind: "MachineConfig"},
+		}},
+		{GroupVersion: "config.openshift.io/v1", APIResources: []metav1.APIResource{
+			{Name: "networks", Kind: "Network"},
+		}},
+		{GroupVersion: "operator.openshift.io/v1", APIResources: []metav1.APIResource{
+			{Name: "networks", Kind: "Network"},
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		kind           string
+		apiVersion     string
+		wantGVK        schema.GroupVersionKind
+		wantNamespaced bool
+		wantErr        string
+	}{
+		{name: "cluster-scoped kind", kind: "MachineConfig", wantGVK: machineConfigGVK},
+		{name: "core namespaced kind", kind: "ConfigMap",
+			wantGVK: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, wantNamespaced: true},
+		{name: "ambiguous kind", kind: "Network",
+			wantErr: "kind Network is served by config.openshift.io/v1, operator.openshift.io/v1, pick one with --api-version"},
+		{name: "api version picks the group", kind: "Network", apiVersion: "operator.openshift.io/v1",
+			wantGVK: schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: "Network"}},
+		{name: "api version overrides the preferred version", kind: "MachineConfig", apiVersion: "machineconfiguration.openshift.io/v1beta1",
+			wantGVK: machineConfigGVK.GroupKind().WithVersion("v1beta1")},
+		{name: "unknown kind", kind: "Widget", wantErr: "kind Widget is not served by the cluster"},
+		{name: "kind outside the group", kind: "ConfigMap", apiVersion: "apps/v1",
+			wantErr: `kind ConfigMap is not served in group "apps"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvk, namespaced, err := resolveKind(resources, tt.kind, tt.apiVersion)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGVK, gvk)
+			assert.Equal(t, tt.wantNamespaced, namespaced)
+		})
+	}
+}
+
+func liveMachineConfig(name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"config": map[string]any{"ignition": map[string]any{"version": "3.2.0"}}},
+	}}
+	obj.SetGroupVersionKind(machineConfigGVK)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestBuildTombstone(t *testing.T) {
+	managed := liveMachineConfig("50-old-thing", map[string]string{
+		pkgassets.TombstoneLabel:                 pkgassets.TombstoneLabelValue,
+		"machineconfiguration.openshift.io/role": "worker",
+	})
+	unmanaged := liveMachineConfig("99-user", map[string]string{"machineconfiguration.openshift.io/role": "worker"})
+	reader := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(managed, unmanaged).Build()
+	ctx := context.Background()
+
+	t.Run("keeps only identity and the managed-by label", func(t *testing.T) {
+		tombstone, err := buildTombstone(ctx, reader, machineConfigGVK, false, "", "50-old-thing")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"apiVersion": "machineconfiguration.openshift.io/v1",
+			"kind":       "MachineConfig",
+			"metadata": map[string]any{
+				"name":   "50-old-thing",
+				"labels": map[string]any{pkgassets.TombstoneLabel: pkgassets.TombstoneLabelValue},
+			},
+		}, tombstone.Object)
+	})
+
+	t.Run("refuses an object without the managed-by label", func(t *testing.T) {
+		_, err := buildTombstone(ctx, reader, machineConfigGVK, false, "", "99-user")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "lacks the "+pkgassets.TombstoneLabel)
+	})
+
+	t.Run("refuses a missing object", func(t *testing.T) {
+		_, err := buildTombstone(ctx, reader, machineConfigGVK, false, "", "50-gone")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("checks the namespace against the scope", func(t *testing.T) {
+		_, err := buildTombstone(ctx, reader, machineConfigGVK, false, "openshift-cnv", "50-old-thing")
+		assert.EqualError(t, err, "MachineConfig is cluster-scoped, --namespace must not be set")
+		configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+		_, err = buildTombstone(ctx, reader, configMap, true, "", "settings")
+		assert.EqualError(t, err, "ConfigMap is namespaced, --namespace is required")
+	})
+}
+
+func TestWriteTombstone(t *testing.T) {
+	tombstone := liveMachineConfig("50-old-thing", map[string]string{pkgassets.TombstoneLabel: pkgassets.TombstoneLabelValue})
+	unstructured.RemoveNestedField(tombstone.Object, "spec")
+
+	t.Run("prints without a directory", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeTombstone(&out, "", tombstone))
+		assert.Contains(t, out.String(), "kind: MachineConfig")
+	})
+
+	t.Run("writes a file the loader accepts and never overwrites it", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "tombstones", "v1.2-cleanup")
+		var out bytes.Buffer
+		require.NoError(t, writeTombstone(&out, dir, tombstone))
+		path := filepath.Join(dir, "machineconfig-50-old-thing.yaml")
+		assert.Equal(t, "wrote "+path+"\n", out.String())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		objects, err := pkgassets.ParseMultiYAML(data)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		assert.Equal(t, machineConfigGVK, objects[0].GroupVersionKind())
+		assert.Equal(t, pkgassets.TombstoneLabelValue, objects[0].GetLabels()[pkgassets.TombstoneLabel])
+
+		assert.Error(t, writeTombstone(&out, dir, tombstone))
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster holds the cluster access shared by the CLI commands.
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// RestConfig loads kubeconfigPath, or the in-cluster config when empty
+func RestConfig(kubeconfigPath string) (*rest.Config, error) {
+	var config *rest.Config
+	var err error
+	if kubeconfigPath != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build config: %w", err)
+	}
+	return config, nil
+}
+
+// NewClient creates a client from kubeconfigPath, or the in-cluster config when empty
+func NewClient(kubeconfigPath string) (client.Client, error) {
+	config, err := RestConfig(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(config, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return c, nil
+}
+
+// FindHCO returns the only HyperConverged CR in namespace, or in the cluster when
+// namespace is empty; none or several are an error
+func FindHCO(ctx context.Context, c client.Client, namespace string) (*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(pkgcontext.HCOGVK.GroupVersion().WithKind(pkgcontext.HCOKind + "List"))
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list HyperConverged resources: %w", err)
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, fmt.Errorf("no HyperConverged resources found")
+	case 1:
+		return &list.Items[0], nil
+	default:
+		return nil, fmt.Errorf("%d HyperConverged resources found, select one with --namespace", len(list.Items))
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestFindHCO(t *testing.T) {
+	tests := []struct {
+		name      string
+		hcos      []string // namespaces
+		namespace string
+		want      string
+		wantErr   bool
+	}{
+		{name: "none", namespace: "openshift-cnv", wantErr: true},
+		{name: "one in the namespace", hcos: []string{"openshift-cnv", "tenant-a"}, namespace: "openshift-cnv", want: "openshift-cnv"},
+		{name: "one in the cluster", hcos: []string{"tenant-a"}, want: "tenant-a"},
+		{name: "several in the cluster", hcos: []string{"openshift-cnv", "tenant-a"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			for _, ns := range tt.hcos {
+				objs = append(objs, pkgcontext.NewMockHCO(pkgcontext.HCOName, ns))
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+			hco, err := FindHCO(context.Background(), c, tt.namespace)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindHCO() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && hco.GetNamespace() != tt.want {
+				t.Errorf("FindHCO() namespace = %q, want %q", hco.GetNamespace(), tt.want)
+			}
+		})
+	}
+}
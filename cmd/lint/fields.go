@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"text/template/parse"
+)
+
+// fieldChecker walks a template parse tree and checks every context field chain
+// (.Topology.IsHCP, $.HCO.Object, ...) against the Go type it will be executed with.
+//
+// Checking stops at maps and interfaces (e.g. .HCO.Object and .Images) since
+// their keys are only known at render time. Inside range and with blocks the
+// type of dot is unknown, so relative fields there are not checked.
+type fieldChecker struct {
+	tree     *parse.Tree
+	root     reflect.Type
+	problems []string
+}
+
+// checkFields returns one message per field reference in tree that does not
+// exist on root, prefixed with the template location ("name:line:col").
+func checkFields(tree *parse.Tree, root reflect.Type) []string {
+	c := &fieldChecker{tree: tree, root: root}
+	if tree.Root != nil {
+		c.walk(tree.Root, root)
+	}
+	return c.problems
+}
+
+func (c *fieldChecker) walk(node parse.Node, dot reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(child, dot)
+		}
+	case *parse.ActionNode:
+		c.walkPipe(n.Pipe, dot)
+	case *parse.IfNode:
+		c.walkPipe(n.Pipe, dot)
+		c.walk(n.List, dot)
+		c.walk(n.ElseList, dot)
+	case *parse.RangeNode:
+		c.walkPipe(n.Pipe, dot)
+		c.walk(n.List, nil)
+		c.walk(n.ElseList, dot)
+	case *parse.WithNode:
+		c.walkPipe(n.Pipe, dot)
+		c.walk(n.List, nil)
+		c.walk(n.ElseList, dot)
+	case *parse.TemplateNode:
+		c.walkPipe(n.Pipe, dot)
+	}
+}
+
+func (c *fieldChecker) walkPipe(pipe *parse.PipeNode, dot reflect.Type) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			c.walkArg(arg, dot)
+		}
+	}
+}
+
+func (c *fieldChecker) walkArg(arg parse.Node, dot reflect.Type) {
+	switch n := arg.(type) {
+	case *parse.FieldNode:
+		c.resolve(n, dot, n.Ident)
+	case *parse.VariableNode:
+		// Only $ has a statically known type; other variables are assigned at render time
+		if len(n.Ident) > 0 && n.Ident[0] == "$" {
+			c.resolve(n, c.root, n.Ident[1:])
+		}
+	case *parse.ChainNode:
+		if pipe, ok := n.Node.(*parse.PipeNode); ok {
+			c.walkPipe(pipe, dot)
+		}
+	case *parse.PipeNode:
+		c.walkPipe(n, dot)
+	}
+}
+
+// resolve follows names from t the way text/template evaluates a field chain:
+// methods first, then struct fields, stopping at dynamic types.
+func (c *fieldChecker) resolve(node parse.Node, t reflect.Type, names []string) {
+	for _, name := range names {
+		if t == nil {
+			return
+		}
+
+		ptr := t
+		if ptr.Kind() != reflect.Pointer && ptr.Kind() != reflect.Interface {
+			ptr = reflect.PointerTo(t)
+		}
+		if method, ok := ptr.MethodByName(name); ok {
+			if method.Type.NumOut() == 0 {
+				return
+			}
+			t = method.Type.Out(0)
+			continue
+		}
+
+		base := t
+		for base.Kind() == reflect.Pointer {
+			base = base.Elem()
+		}
+
+		switch base.Kind() {
+		case reflect.Map, reflect.Interface:
+			return
+		case reflect.Struct:
+			field, ok := base.FieldByName(name)
+			if !ok || !field.IsExported() {
+				c.report(node, "unknown field %s on %s", name, base)
+				return
+			}
+			t = field.Type
+		default:
+			c.report(node, "cannot access field %s on %s", name, base)
+			return
+		}
+	}
+}
+
+func (c *fieldChecker) report(node parse.Node, format string, args ...any) {
+	location, _ := c.tree.ErrorContext(node)
+	c.problems = append(c.problems, location+": "+fmt.Sprintf(format, args...))
+}
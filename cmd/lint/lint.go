@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	embeddedassets "github.com/kubevirt/virt-platform-autopilot/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+const (
+	// activeDir holds the managed assets; tombstones are static and not linted
+	activeDir = "active"
+
+	// catalogFile is the asset catalog, which is YAML but not an asset itself
+	catalogFile = "active/metadata.yaml"
+)
+
+// Finding is a single lint problem. Profile is empty for problems that do not
+// depend on the render context (parse errors, unknown fields, catalog issues).
+type Finding struct {
+	Path    string
+	Profile string
+	Message string
+}
+
+func (f Finding) String() string {
+	if f.Profile == "" {
+		return fmt.Sprintf("%s: %s", f.Path, f.Message)
+	}
+	return fmt.Sprintf("%s [%s]: %s", f.Path, f.Profile, f.Message)
+}
+
+// NewLintCommand creates the lint subcommand
+func NewLintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Check the embedded asset templates for errors",
+		Long: `Statically check every asset shipped in the binary, without a cluster.
+
+The linter:
+- Parses every template, catching syntax errors and functions outside the allowlist
+- Checks that referenced context fields (.Topology.IsHCP, .Hardware.GPUPresent, ...)
+  exist on the render context
+- Renders every catalog asset against built-in cluster profiles (plain Kubernetes,
+  OpenShift bare metal, HCP on AWS with proxy and FIPS, upgrading compact cluster)
+  and checks that the output is valid YAML with apiVersion, kind and metadata.name
+- Reports YAML and template files under active/ that no catalog entry references
+
+Examples:
+  # Lint the assets (exits non-zero when problems are found)
+  virt-platform-autopilot lint
+
+Exit codes:
+  0  no problems found
+  1  at least one problem found
+`,
+		Args: cobra.NoArgs,
+		RunE: runLint,
+	}
+}
+
+// runLint executes the lint command
+func runLint(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	findings, err := lintAssets(embeddedassets.EmbeddedFS, loader, registry.ListAssetsByReconcileOrder())
+	if err != nil {
+		return err
+	}
+
+	return report(cmd.OutOrStdout(), findings)
+}
+
+// report prints findings and returns an error when there are any
+func report(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		_, _ = fmt.Fprintln(w, f)
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%d problem(s) found", len(findings))
+	}
+	_, _ = fmt.Fprintln(w, "No problems found")
+	return nil
+}
+
+// lintAssets checks every file under active/ in fsys and renders every catalog
+// asset against the built-in profiles
+func lintAssets(fsys fs.FS, loader *assets.Loader, catalog []assets.AssetMetadata) ([]Finding, error) {
+	renderer := engine.NewRenderer(loader)
+
+	findings, err := lintFiles(fsys, renderer, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := builtinProfiles()
+	for i := range catalog {
+		asset := &catalog[i]
+		if _, err := fs.Stat(fsys, asset.Path); err != nil {
+			// Already reported by lintFiles
+			continue
+		}
+
+		for _, prof := range profiles {
+			objs, err := renderer.RenderMultiAsset(asset, prof.ctx)
+			findings = append(findings, lintRendered(asset.Path, prof.name, objs, err)...)
+
+			// Static YAML renders the same under every profile
+			if !assets.IsTemplate(asset.Path) {
+				break
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// lintFiles parses every template under active/ and cross-checks the files
+// against the catalog in both directions
+func lintFiles(fsys fs.FS, renderer *engine.Renderer, catalog []assets.AssetMetadata) ([]Finding, error) {
+	var findings []Finding
+
+	referenced := make(map[string]bool, len(catalog))
+	for _, asset := range catalog {
+		referenced[asset.Path] = true
+	}
+
+	err := fs.WalkDir(fsys, activeDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || p == catalogFile {
+			return err
+		}
+
+		isTemplate := assets.IsTemplate(p)
+		if !isTemplate && path.Ext(p) != ".yaml" {
+			// Helper files pulled in with readAsset
+			return nil
+		}
+		if !referenced[p] {
+			findings = append(findings, Finding{Path: p, Message: "not referenced by any catalog entry"})
+		}
+		if !isTemplate {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, lintTemplate(renderer, p, string(content))...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk assets: %w", err)
+	}
+
+	for _, asset := range catalog {
+		if _, err := fs.Stat(fsys, asset.Path); err != nil {
+			findings = append(findings, Finding{Path: asset.Path, Message: fmt.Sprintf("catalog entry %s: file not found", asset.Name)})
+		}
+	}
+
+	return findings, nil
+}
+
+// lintTemplate parses a template with the renderer's function map and checks its
+// field references against RenderContext
+func lintTemplate(renderer *engine.Renderer, p, content string) []Finding {
+	tmpl, err := renderer.ParseTemplate(p, content)
+	if err != nil {
+		return []Finding{{Path: p, Message: err.Error()}}
+	}
+
+	root := reflect.TypeOf(&pkgcontext.RenderContext{})
+	var findings []Finding
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		for _, problem := range checkFields(t.Tree, root) {
+			findings = append(findings, Finding{Path: p, Message: problem})
+		}
+	}
+	return findings
+}
+
+// lintRendered checks the result of rendering one asset under one profile
+func lintRendered(p, profileName string, objs []*unstructured.Unstructured, renderErr error) []Finding {
+	if renderErr != nil {
+		if _, skipped := engine.SkipReason(renderErr); skipped {
+			return nil
+		}
+		return []Finding{{Path: p, Profile: profileName, Message: renderErr.Error()}}
+	}
+
+	var findings []Finding
+	for i, obj := range objs {
+		var missing []string
+		if obj.GetAPIVersion() == "" {
+			missing = append(missing, "apiVersion")
+		}
+		if obj.GetKind() == "" {
+			missing = append(missing, "kind")
+		}
+		if obj.GetName() == "" {
+			missing = append(missing, "metadata.name")
+		}
+		if len(missing) > 0 {
+			findings = append(findings, Finding{
+				Path:    p,
+				Profile: profileName,
+				Message: fmt.Sprintf("document %d is missing %s", i+1, strings.Join(missing, ", ")),
+			})
+		}
+	}
+	return findings
+}
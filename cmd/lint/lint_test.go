@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	embeddedassets "github.com/kubevirt/virt-platform-autopilot/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// TestEmbeddedAssetsAreClean runs the linter over the assets shipped in the binary
+func TestEmbeddedAssetsAreClean(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	findings, err := lintAssets(embeddedassets.EmbeddedFS, loader, registry.ListAssetsByReconcileOrder())
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestLintTemplate(t *testing.T) {
+	renderer := engine.NewRenderer(assets.NewLoader())
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{"valid fields and methods", `{{ if and .Topology.IsHCP .Proxy.Enabled }}{{ .HCO.GetNamespace }}{{ end }}`, nil},
+		{"map access is not checked", `{{ .HCO.Object.spec.foo }}{{ .Images.anything }}`, nil},
+		{"root variable", `{{ $.Hardware.GPUPresent }}`, nil},
+		{"fields inside range are not checked", `{{ range .Proxy.EnvVars }}{{ .name }}{{ end }}`, nil},
+		{"unknown field", `{{ if .Topology.IsOpenShift }}x{{ end }}`,
+			[]string{"t.tpl:1:15: unknown field IsOpenShift on context.TopologyContext"}},
+		{"unknown top-level field", `{{ .Hardware.GPUPresent }}{{ .Nodes }}`,
+			[]string{"t.tpl:1:29: unknown field Nodes on context.RenderContext"}},
+		{"field on scalar", `{{ .FIPS.Enabled }}`,
+			[]string{"t.tpl:1:8: cannot access field Enabled on bool"}},
+		{"unknown function", `{{ env "HOME" }}`,
+			[]string{`failed to parse template: template: t.tpl:1: function "env" not defined`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []string
+			for _, f := range lintTemplate(renderer, "t.tpl", tt.content) {
+				assert.Equal(t, "t.tpl", f.Path)
+				messages = append(messages, f.Message)
+			}
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestCheckFieldsUnstructured(t *testing.T) {
+	renderer := engine.NewRenderer(assets.NewLoader())
+	tmpl, err := renderer.ParseTemplate("t", `{{ .HCO.Object }}{{ .HCO.GetLabels }}{{ .HCO.Missing }}`)
+	require.NoError(t, err)
+
+	problems := checkFields(tmpl.Tree, reflect.TypeOf(&pkgcontext.RenderContext{}))
+	assert.Equal(t, []string{"t:1:44: unknown field Missing on unstructured.Unstructured"}, problems)
+}
+
+func TestLintRendered(t *testing.T) {
+	valid := &unstructured.Unstructured{}
+	valid.SetAPIVersion("v1")
+	valid.SetKind("ConfigMap")
+	valid.SetName("cm")
+
+	unnamed := &unstructured.Unstructured{}
+	unnamed.SetKind("ConfigMap")
+
+	assert.Empty(t, lintRendered("a.yaml.tpl", "p", []*unstructured.Unstructured{valid}, nil))
+	assert.Empty(t, lintRendered("a.yaml.tpl", "p", nil, &engine.SkipError{Asset: "a", Reason: "not needed"}))
+
+	findings := lintRendered("a.yaml.tpl", "p", []*unstructured.Unstructured{valid, unnamed}, nil)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "a.yaml.tpl [p]: document 2 is missing apiVersion, metadata.name", findings[0].String())
+
+	findings = lintRendered("a.yaml.tpl", "p", nil, errors.New("boom"))
+	require.Len(t, findings, 1)
+	assert.Equal(t, "boom", findings[0].Message)
+}
+
+func TestLintFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"active/metadata.yaml":      {Data: []byte("assets: []\n")},
+		"active/a/used.yaml":        {Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n")},
+		"active/a/orphan.yaml":      {Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n")},
+		"active/a/helper/script.py": {Data: []byte("print()\n")},
+	}
+	catalog := []assets.AssetMetadata{
+		{Name: "used", Path: "active/a/used.yaml"},
+		{Name: "gone", Path: "active/a/gone.yaml"},
+	}
+
+	findings, err := lintFiles(fsys, engine.NewRenderer(assets.NewLoader()), catalog)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+	assert.Equal(t, []string{
+		"active/a/orphan.yaml: not referenced by any catalog entry",
+		"active/a/gone.yaml: catalog entry gone: file not found",
+	}, lines)
+}
+
+func TestReport(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, report(&out, nil))
+	assert.Equal(t, "No problems found\n", out.String())
+
+	out.Reset()
+	err := report(&out, []Finding{{Path: "x.yaml", Message: "bad"}})
+	require.Error(t, err)
+	assert.Equal(t, "x.yaml: bad\n", out.String())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// profile is a synthetic cluster every template is rendered against
+type profile struct {
+	name string
+	ctx  *pkgcontext.RenderContext
+}
+
+// builtinProfiles covers the cluster shapes the detectors can report, so template
+// branches guarded by topology, hardware, proxy and upgrade state all get rendered.
+func builtinProfiles() []profile {
+	newContext := func() *pkgcontext.RenderContext {
+		hco := pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)
+		return pkgcontext.NewRenderContext(hco)
+	}
+
+	kubernetes := newContext()
+
+	bareMetal := newContext()
+	bareMetal.Topology = &pkgcontext.TopologyContext{
+		ControlPlaneTopology: "HighlyAvailable",
+		CloudProvider:        "BareMetal",
+		IsBareMetal:          true,
+		MasterCount:          3,
+		WorkerCount:          3,
+		TotalNodeCount:       6,
+	}
+	bareMetal.Hardware = &pkgcontext.HardwareContext{
+		PCIDevicesPresent: true,
+		NUMANodesPresent:  true,
+		VFIOCapable:       true,
+		USBDevicesPresent: true,
+		GPUPresent:        true,
+	}
+	bareMetal.Images = map[string]string{
+		"kubevirt-metrics-exporter": "quay.io/kubevirt/metrics-exporter:latest",
+	}
+	bareMetal.Mirrors = &pkgcontext.MirrorContext{}
+	bareMetal.Mirrors.AddMirror("quay.io/kubevirt", "mirror.example.com:5000/kubevirt")
+	bareMetal.Storage = &pkgcontext.StorageContext{
+		RWXStorageClasses:   []string{"ocs-storagecluster-ceph-rbd"},
+		DefaultStorageClass: "ocs-storagecluster-ceph-rbd",
+		ODFPresent:          true,
+		CephPresent:         true,
+		SnapshotClasses:     []string{"ocs-storagecluster-rbdplugin-snapclass"},
+	}
+	bareMetal.PerformanceProfile = &pkgcontext.PerformanceProfileContext{
+		Ready:          true,
+		ReservedCPUs:   "0-1,32-33",
+		IsolatedCPUs:   "2-31,34-63",
+		HugePageSize:   "1G",
+		HugePages:      64,
+		TopologyPolicy: "restricted",
+		CPUs:           64,
+		NUMANodes:      2,
+		Workers:        3,
+	}
+	// Mixed-arch: the arm64 workers have their own pool, so worker MachineConfigs stay on amd64
+	bareMetal.Architecture = &pkgcontext.ArchitectureContext{
+		Architectures: []string{pkgcontext.ArchAMD64, pkgcontext.ArchARM64},
+		Roles: map[string][]string{
+			"worker":       {pkgcontext.ArchAMD64},
+			"worker-arm64": {pkgcontext.ArchARM64},
+		},
+	}
+	bareMetal.HugePages = &pkgcontext.HugePagesContext{
+		Ready:      true,
+		PageSize:   "1Gi",
+		PageSizeKB: 1 << 20,
+		VMsPerNode: 8,
+		PagesPerVM: 16,
+		MaxPercent: 80,
+		Role:       "worker",
+		Pools: []pkgcontext.HugePagesPool{
+			{Name: "worker", Nodes: 2, NUMANodes: 2, PagesPerNUMANode: 64, Pages: 128, Ready: true},
+			{Name: "worker-small", Nodes: 1, NUMANodes: 1,
+				Reason: "node worker-2 has 64Gi per NUMA node, 128Gi of hugepages requested (at most 80%)"},
+		},
+	}
+	// Density settings enable the overcommit KubeletConfig and KSM MachineConfig;
+	// node placement exercises the placement branches of MetalLB and the metrics exporter
+	bareMetal.HCO.Object["spec"] = map[string]any{
+		"higherWorkloadDensity": map[string]any{"memoryOvercommitPercentage": int64(150)},
+		"ksmConfiguration":      map[string]any{"nodeLabelSelector": map[string]any{}},
+		"infra": map[string]any{"nodePlacement": map[string]any{
+			"nodeSelector": map[string]any{"node-role.kubernetes.io/infra": ""},
+			"tolerations":  []any{map[string]any{"key": "node-role.kubernetes.io/infra", "operator": "Exists", "effect": "NoSchedule"}},
+		}},
+		"workloads": map[string]any{"nodePlacement": map[string]any{
+			"nodeSelector": map[string]any{"kubevirt.io/schedulable": "true"},
+		}},
+	}
+	bareMetal.Placement = pkgcontext.NewPlacementContext(bareMetal.HCO)
+	bareMetal.Network = &pkgcontext.NetworkContext{
+		NetworkType:    pkgcontext.NetworkTypeOVNKubernetes,
+		MTU:            8901,
+		MultusPresent:  true,
+		NMStatePresent: true,
+		IPFamilies:     []string{pkgcontext.IPFamilyIPv4, pkgcontext.IPFamilyIPv6},
+	}
+
+	hostedCloud := newContext()
+	hostedCloud.Topology = &pkgcontext.TopologyContext{
+		IsHCP:                true,
+		ControlPlaneTopology: "External",
+		CloudProvider:        "AWS",
+		IsAWS:                true,
+		WorkerCount:          2,
+		TotalNodeCount:       2,
+	}
+	hostedCloud.Proxy = &pkgcontext.ProxyContext{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    ".cluster.local,.svc,10.0.0.0/16",
+		TrustedCA:  "user-ca-bundle",
+	}
+	hostedCloud.FIPS = true
+
+	upgrading := newContext()
+	upgrading.Topology = &pkgcontext.TopologyContext{
+		IsCompact:            true,
+		ControlPlaneTopology: "HighlyAvailable",
+		CloudProvider:        "VSphere",
+		IsVSphere:            true,
+		MasterCount:          3,
+		TotalNodeCount:       3,
+	}
+	upgrading.Upgrade = &pkgcontext.UpgradeContext{
+		ClusterVersionProgressing: true,
+		TargetVersion:             "4.19.0",
+		UpdatingPools:             []string{"master"},
+	}
+	upgrading.Descheduler = &pkgcontext.DeschedulerContext{
+		Sensitivity:                  "high",
+		MigratablePercent:            40,
+		DeviationThresholds:          "AsymmetricHigh",
+		LowNodeUtilizationThresholds: "High",
+		IntervalSeconds:              180,
+	}
+
+	return []profile{
+		{name: "kubernetes", ctx: kubernetes},
+		{name: "openshift-baremetal", ctx: bareMetal},
+		{name: "hcp-aws-proxy-fips", ctx: hostedCloud},
+		{name: "compact-upgrading", ctx: upgrading},
+	}
+}
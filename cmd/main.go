@@ -18,12 +18,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	eventsv1 "k8s.io/api/events/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -40,7 +45,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	"github.com/kubevirt/virt-platform-autopilot/cmd/adopt"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/bench"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/catalogdiff"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/cleanup"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	debugcmd "github.com/kubevirt/virt-platform-autopilot/cmd/debug"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/docs"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/generate"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/lint"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/preflight"
 	"github.com/kubevirt/virt-platform-autopilot/cmd/render"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/rollback"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/simulate"
+	waitcmd "github.com/kubevirt/virt-platform-autopilot/cmd/wait"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/api"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
@@ -73,10 +92,25 @@ func main() {
 clusters for optimal virtualization workload performance by managing
 platform-level resources based on HyperConverged configuration.`,
 	}
+	// The completion command below replaces cobra's default one
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	// Add subcommands
 	rootCmd.AddCommand(newRunCommand())
 	rootCmd.AddCommand(render.NewRenderCommand())
+	rootCmd.AddCommand(lint.NewLintCommand())
+	rootCmd.AddCommand(catalogdiff.NewCatalogDiffCommand())
+	rootCmd.AddCommand(debugcmd.NewDebugCommand())
+	rootCmd.AddCommand(simulate.NewSimulateCommand())
+	rootCmd.AddCommand(generate.NewGenerateCommand())
+	rootCmd.AddCommand(waitcmd.NewWaitCommand())
+	rootCmd.AddCommand(rollback.NewRollbackCommand())
+	rootCmd.AddCommand(adopt.NewAdoptCommand())
+	rootCmd.AddCommand(preflight.NewPreflightCommand())
+	rootCmd.AddCommand(cleanup.NewCleanupCommand())
+	rootCmd.AddCommand(bench.NewBenchCommand())
+	rootCmd.AddCommand(docs.NewDocsCommand())
+	rootCmd.AddCommand(completion.NewCompletionCommand())
 
 	// Default to run command if no subcommand specified (backward compatibility)
 	if len(os.Args) == 1 || (len(os.Args) > 1 && os.Args[1][0] == '-') {
@@ -86,6 +120,10 @@ platform-level resources based on HyperConverged configuration.`,
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var exitErr *render.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -94,10 +132,47 @@ platform-level resources based on HyperConverged configuration.`,
 func newRunCommand() *cobra.Command {
 	var metricsAddr string
 	var debugAddr string
+	var apiAddr string
+	var apiCertFile string
+	var apiKeyFile string
+	var apiAllowedOrigins []string
 	var enableLeaderElection bool
 	var probeAddr string
 	var namespace string
+	var watchNamespaces string
+	var configFile string
+	var logAssets string
+	var hardwareRemovalGracePeriod time.Duration
+	var deferRebootsDuringUpgrade bool
+	var maxRebootChanges int
+	var maxRebootNodes int
+	var maxDeletions int
+	canary := engine.CanaryRollout{Timeout: engine.DefaultCanaryTimeout}
+	var imageMapping string
+	var renderHistorySize int
+	var renderHistoryConfigMap string
+	var cacheStatsInterval time.Duration
+	var cacheSyncTimeout time.Duration
+	var labelRepairInterval time.Duration
+	var labelRepairMode string
+	var nodeEventDebounce time.Duration
+	var exportManagedResources bool
+	var ownerReferences bool
+	var notificationWebhook controller.NotificationWebhook
+	var differentialSync bool
+	rateLimiter := controller.DefaultRateLimiterOptions()
+	applyTimeouts := engine.DefaultApplyTimeouts()
+	recreationLimits := engine.DefaultRecreationLimits()
+	asyncApply := engine.DefaultAsyncApply()
+	conditionEvaluation := controller.DefaultConditionEvaluation()
+	var assetErrorThreshold float64
+	var shardName string
+	var shardComponents string
+	var shardExcludeComponents string
+	var remoteCatalog assets.RemoteCatalog
+	var catalogRefreshInterval time.Duration
 	var crdValidationTimeout time.Duration
+	var waitForHCOCRD bool
 	var enableDebugServer bool
 	var development bool
 
@@ -109,28 +184,196 @@ func newRunCommand() *cobra.Command {
 			return runController(
 				metricsAddr,
 				debugAddr,
+				apiAddr,
+				apiCertFile,
+				apiKeyFile,
+				apiAllowedOrigins,
 				probeAddr,
 				namespace,
+				watchNamespaces,
+				configFile,
+				logAssets,
+				hardwareRemovalGracePeriod,
+				deferRebootsDuringUpgrade,
+				maxRebootChanges,
+				maxRebootNodes,
+				maxDeletions,
+				canary,
+				imageMapping,
+				renderHistorySize,
+				renderHistoryConfigMap,
+				cacheStatsInterval,
+				cacheSyncTimeout,
+				labelRepairInterval,
+				labelRepairMode,
+				nodeEventDebounce,
+				exportManagedResources,
+				ownerReferences,
+				notificationWebhook,
+				differentialSync,
+				rateLimiter,
+				applyTimeouts,
+				recreationLimits,
+				asyncApply,
+				conditionEvaluation,
+				assetErrorThreshold,
+				shardName,
+				shardComponents,
+				shardExcludeComponents,
+				remoteCatalog,
+				catalogRefreshInterval,
 				enableLeaderElection,
 				enableDebugServer,
 				development,
 				crdValidationTimeout,
+				waitForHCOCRD,
 			)
 		},
 	}
 
 	cmd.Flags().StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	cmd.Flags().StringVar(&debugAddr, "debug-bind-address", "127.0.0.1:8081", "The address the debug endpoint binds to (localhost only for security).")
+	cmd.Flags().StringVar(&apiAddr, "api-bind-address", "0",
+		"The address the authenticated external API (render, inventory, exclusions, console plugin views) binds to. \"0\" disables the API.")
+	cmd.Flags().StringVar(&apiCertFile, "api-tls-cert-file", "", "TLS certificate the external API is served with.")
+	cmd.Flags().StringVar(&apiKeyFile, "api-tls-key-file", "", "TLS private key the external API is served with.")
+	cmd.Flags().StringSliceVar(&apiAllowedOrigins, "api-cors-allowed-origins", nil,
+		"Browser origins (e.g. the OpenShift console URL) allowed to call the external API with CORS. Empty allows none.")
 	cmd.Flags().StringVar(&probeAddr, "health-probe-bind-address", ":8082", "The address the probe endpoint binds to.")
 	cmd.Flags().BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	cmd.Flags().StringVar(&namespace, "namespace", "openshift-cnv",
 		"The namespace where HyperConverged CR is located.")
+	cmd.Flags().StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma-separated list of additional namespaces whose HyperConverged CRs are reconciled, "+
+			"or \"*\" for all namespaces. Each HCO is reconciled with its own render context; cluster-scoped objects "+
+			"and objects outside an HCO's namespace are applied only by the HCO in --namespace.")
+	cmd.Flags().StringVar(&configFile, "config", "",
+		"Path to an AutopilotConfig file (YAML) with cluster-wide policy such as apply mutators.")
+	cmd.Flags().StringVar(&logAssets, "log-assets", "",
+		"Comma-separated asset names whose per-asset info logs are kept; other assets only log errors. "+
+			"Every per-asset log line carries asset and assetID keys for correlation.")
+	cmd.Flags().DurationVar(&hardwareRemovalGracePeriod, "hardware-removal-grace-period", 0,
+		"Keep hardware-dependent assets applied for this long after the last matching node disappears "+
+			"(e.g. GPU nodes scaled down by the autoscaler), to avoid MachineConfig churn and node reboots. 0 disables damping.")
+	cmd.Flags().BoolVar(&deferRebootsDuringUpgrade, "defer-reboots-during-upgrade", true,
+		"Hold back MachineConfig, KubeletConfig and ContainerRuntimeConfig changes while a cluster upgrade "+
+			"or MachineConfigPool rollout is in progress, and apply them once the cluster is stable.")
+	cmd.Flags().IntVar(&maxRebootChanges, "max-reboot-changes", engine.DefaultMaxRebootChanges,
+		"Hold back all MachineConfig, KubeletConfig and ContainerRuntimeConfig changes of a reconcile if more than this many "+
+			"existing objects are modified (first-time creations are not counted), until acknowledged with the "+engine.BlastRadiusAckAnnotation+" annotation on the HCO. 0 disables the limit.")
+	cmd.Flags().IntVar(&maxRebootNodes, "max-reboot-nodes", 0,
+		"Hold back all MachineConfig, KubeletConfig and ContainerRuntimeConfig changes of a reconcile if the MachineConfigPools "+
+			"they roll out to have more than this many nodes, until acknowledged with the "+engine.BlastRadiusAckAnnotation+
+			" annotation on the HCO. 0 disables the limit.")
+	cmd.Flags().IntVar(&maxDeletions, "max-deletions", engine.DefaultMaxDeletions,
+		"Hold back all tombstone deletions of a reconcile if there are more than this many, "+
+			"until acknowledged with the "+engine.BlastRadiusAckAnnotation+" annotation on the HCO. 0 disables the limit.")
+	cmd.Flags().StringVar(&canary.Pool, "canary-pool", "",
+		"MachineConfigPool that MachineConfig, KubeletConfig and ContainerRuntimeConfig changes roll out to first. "+
+			"The other pools they target are paused until the canary pool is updated; if it degrades or times out, "+
+			"the changes are rolled back. Empty disables canary rollouts.")
+	cmd.Flags().DurationVar(&canary.Timeout, "canary-timeout", engine.DefaultCanaryTimeout,
+		"How long the canary pool may take to update before the changes are rolled back.")
+	cmd.Flags().StringVar(&imageMapping, "image-mapping", "",
+		"YAML or JSON file mapping image references to digest references. "+
+			"Image fields of rendered assets that match an entry are applied pinned by digest.")
+	cmd.Flags().IntVar(&renderHistorySize, "render-history-size", engine.DefaultRenderHistorySize,
+		"How many previously applied versions of every asset to keep for /debug/history and the rollback command. 0 disables the history.")
+	cmd.Flags().StringVar(&renderHistoryConfigMap, "render-history-configmap", "",
+		"Persist the render history in this ConfigMap in --namespace, so it survives restarts and the rollback command can read it "+
+			"(e.g. "+engine.DefaultRenderHistoryConfigMap+"). Empty keeps it in memory only.")
+	cmd.Flags().DurationVar(&cacheStatsInterval, "cache-stats-interval", time.Minute,
+		"How often to export informer cache object counts and estimated memory per watched type. 0 disables collection.")
+	cmd.Flags().DurationVar(&cacheSyncTimeout, "cache-sync-timeout", controller.DefaultCacheSyncTimeout,
+		"How long the informer caches of the HCO and all managed types may take to sync at startup. "+
+			"Reconciles wait for the sync so nothing is applied against a partial view; past the timeout the manager exits.")
+	cmd.Flags().DurationVar(&labelRepairInterval, "label-repair-interval", 10*time.Minute,
+		"How often to look for objects the autopilot applied whose managed-by label was removed, "+
+			"which hides them from the cache and from tombstone cleanup. 0 disables the pass.")
+	cmd.Flags().StringVar(&labelRepairMode, "label-repair-mode", string(controller.LabelRepairRelabel),
+		"What the label repair pass does with such objects: relabel restores the label, flag only reports them. "+
+			"Tombstoned objects are always only reported.")
+	cmd.Flags().DurationVar(&nodeEventDebounce, "node-event-debounce", controller.DefaultNodeEventDebounce,
+		"Watch nodes and reconcile when their labels or resources change, coalescing the changes of this window "+
+			"into one reconcile so label churn from Node Feature Discovery does not re-render continuously. "+
+			"0 disables the watch; node changes are then picked up by the periodic resync.")
+	cmd.Flags().BoolVar(&exportManagedResources, "export-managed-resources", true,
+		"Mirror the state of every applied object into a ManagedResource in the HCO's namespace "+
+			"(oc get managedresources -l component=...). Has no effect unless the "+controller.ManagedResourceCRDName+" CRD is installed.")
+	cmd.Flags().BoolVar(&ownerReferences, "owner-references", false,
+		"Set an owner reference to an AutopilotDeployment, itself owned by the HCO, on the managed objects in the HCO's "+
+			"namespace, so deleting the HyperConverged garbage-collects them even if cleanup never runs. Cluster-scoped objects "+
+			"and objects in other namespaces cannot be owned this way. Has no effect unless the "+controller.AutopilotDeploymentCRDName+" CRD is installed.")
+	cmd.Flags().StringVar(&notificationWebhook.URL, "notification-webhook-url", "",
+		"POST a JSON summary of every reconcile pass that changed, corrected or failed something (changed resources, "+
+			"drift corrections, errors) to this URL, e.g. a chat-ops or ticketing integration. Empty disables it.")
+	cmd.Flags().StringVar(&notificationWebhook.SecretFile, "notification-webhook-secret-file", "",
+		"File holding the key the summaries are signed with (HMAC-SHA256 of the body in the "+
+			controller.NotificationSignatureHeader+" header), typically a mounted Secret. Re-read on every post.")
+	cmd.Flags().BoolVar(&differentialSync, "differential-sync", true,
+		"In the first reconcile after a start or upgrade, skip the drift check (an SSA dry-run per object) of objects whose "+
+			engine.DesiredHashAnnotation+" annotation matches the current rendering, so only assets whose desired state changed "+
+			"are re-applied. Edits made while the operator was down are corrected by the next periodic resync.")
+	cmd.Flags().DurationVar(&rateLimiter.BaseDelay, "rate-limiter-base-delay", rateLimiter.BaseDelay,
+		"Initial retry delay after a failed reconcile of an HCO; doubles on every consecutive failure.")
+	cmd.Flags().DurationVar(&rateLimiter.MaxDelay, "rate-limiter-max-delay", rateLimiter.MaxDelay,
+		"Upper bound of the per-HCO retry delay.")
+	cmd.Flags().Float64Var(&rateLimiter.QPS, "rate-limiter-qps", rateLimiter.QPS,
+		"Overall rate of retries across all HCOs (token bucket refill rate).")
+	cmd.Flags().IntVar(&rateLimiter.Burst, "rate-limiter-burst", rateLimiter.Burst,
+		"Token bucket size: retries allowed in a burst before --rate-limiter-qps applies.")
+	cmd.Flags().Float64Var(&rateLimiter.Jitter, "rate-limiter-jitter", rateLimiter.Jitter,
+		"Stretch every retry delay by a random fraction up to this value (0-1), "+
+			"so HCOs failing together do not retry in lockstep. 0 disables jitter.")
+	cmd.Flags().DurationVar(&applyTimeouts.PerAsset, "asset-apply-timeout", applyTimeouts.PerAsset,
+		"Cancel rendering, drift detection and apply of a single asset after this long (e.g. a hung admission webhook), "+
+			"so the remaining assets are still reconciled. 0 disables the timeout.")
+	cmd.Flags().DurationVar(&applyTimeouts.Total, "reconcile-timeout", applyTimeouts.Total,
+		"Upper bound of one pass over all assets; assets not reached in time are reported as timed out and retried. 0 disables the timeout.")
+	cmd.Flags().IntVar(&recreationLimits.Max, "recreation-limit", recreationLimits.Max,
+		"How often a managed object deleted outside the autopilot is recreated within --recreation-window before it is "+
+			"left deleted for --recreation-cooldown. 0 always recreates it.")
+	cmd.Flags().DurationVar(&recreationLimits.Window, "recreation-window", recreationLimits.Window,
+		"Window the recreations of a deleted managed object are counted in.")
+	cmd.Flags().DurationVar(&recreationLimits.Cooldown, "recreation-cooldown", recreationLimits.Cooldown,
+		"How long a managed object that keeps being deleted is left deleted before it is recreated again.")
+	cmd.Flags().DurationVar(&asyncApply.Timeout, "async-apply-timeout", asyncApply.Timeout,
+		"Retry objects whose admission webhook is unavailable or too slow in the background, each apply bounded by this "+
+			"timeout, instead of failing the reconcile. 0 disables the retries.")
+	cmd.Flags().DurationVar(&asyncApply.MaxRetryDelay, "apply-retry-max-delay", asyncApply.MaxRetryDelay,
+		"Longest wait between the retries of an object whose admission is unavailable, including a Retry-After of the API server.")
+	cmd.Flags().IntVar(&conditionEvaluation.Parallelism, "condition-parallelism", conditionEvaluation.Parallelism,
+		"How many asset conditions that query the cluster (crd, operator, storage-class) are evaluated at once at the start of a reconcile.")
+	cmd.Flags().DurationVar(&conditionEvaluation.Timeout, "condition-timeout", conditionEvaluation.Timeout,
+		"Fail a condition that queries the cluster after this long; its assets are skipped until the next reconcile. 0 disables the timeout.")
+	cmd.Flags().Float64Var(&assetErrorThreshold, "readyz-asset-error-threshold", controller.DefaultAssetErrorThreshold,
+		"Report not ready on /readyz/assets while the last reconcile of an HCO failed for at least this fraction (0-1] of its assets, "+
+			"so a rollout of a version that breaks asset application does not complete. 0 disables the check.")
+	cmd.Flags().StringVar(&shardName, "shard", "",
+		"Run as the named controller shard, reconciling only the assets of the components selected by --components "+
+			"or --exclude-components. Each shard has its own leader election and HCO conditions (suffixed with the shard name); "+
+			"the shards of a cluster must own disjoint component sets covering every component.")
+	cmd.Flags().StringVar(&shardComponents, "components", "",
+		"Comma-separated asset components (e.g. MachineConfig,KubeletConfig) the --shard owns.")
+	cmd.Flags().StringVar(&shardExcludeComponents, "exclude-components", "",
+		"Comma-separated asset components the --shard does not own; it owns every other component.")
+	cmd.Flags().StringVar(&remoteCatalog.URL, "catalog-url", "",
+		"Fetch the asset catalog from this https:// URL or oci:// artifact (a zip of the assets directory) instead of using the embedded one. "+
+			"The embedded catalog is used until the remote one has been fetched and validated once; later failures keep the last loaded one.")
+	cmd.Flags().StringVar(&remoteCatalog.SHA256, "catalog-sha256", "",
+		"Hex-encoded SHA-256 the --catalog-url archive must have; any other content is rejected. "+
+			"Required unless --catalog-url references an OCI artifact by @sha256: digest.")
+	cmd.Flags().DurationVar(&catalogRefreshInterval, "catalog-refresh-interval", time.Hour,
+		"How often to retry fetching --catalog-url until it has loaded once; its pinned content cannot change afterwards. 0 fetches it only at startup.")
 	cmd.Flags().DurationVar(&crdValidationTimeout, "crd-validation-timeout", 10*time.Second,
 		"Timeout for validating that required CRDs exist at startup.")
+	cmd.Flags().BoolVar(&waitForHCOCRD, "wait-for-hco-crd", false,
+		"If the HyperConverged CRD is missing at startup, start anyway and report NotReady until it is established, "+
+			"then start reconciling. By default the process exits so the missing CRD is visible immediately.")
 	cmd.Flags().BoolVar(&enableDebugServer, "enable-debug-server", true,
-		"Enable debug HTTP server with /debug/render and /debug/exclusions endpoints.")
+		"Enable debug HTTP server with /debug/render, /debug/simulate, /debug/reconcile-dry-run, /debug/exclusions, /debug/history and /debug/loglevel endpoints.")
 	cmd.Flags().BoolVar(&development, "development", true,
 		"Enable development mode logging.")
 
@@ -141,19 +384,127 @@ func newRunCommand() *cobra.Command {
 func runController(
 	metricsAddr string,
 	debugAddr string,
+	apiAddr string,
+	apiCertFile string,
+	apiKeyFile string,
+	apiAllowedOrigins []string,
 	probeAddr string,
 	namespace string,
+	watchNamespaces string,
+	configFile string,
+	logAssets string,
+	hardwareRemovalGracePeriod time.Duration,
+	deferRebootsDuringUpgrade bool,
+	maxRebootChanges int,
+	maxRebootNodes int,
+	maxDeletions int,
+	canary engine.CanaryRollout,
+	imageMapping string,
+	renderHistorySize int,
+	renderHistoryConfigMap string,
+	cacheStatsInterval time.Duration,
+	cacheSyncTimeout time.Duration,
+	labelRepairInterval time.Duration,
+	labelRepairMode string,
+	nodeEventDebounce time.Duration,
+	exportManagedResources bool,
+	ownerReferences bool,
+	notificationWebhook controller.NotificationWebhook,
+	differentialSync bool,
+	rateLimiter controller.RateLimiterOptions,
+	applyTimeouts engine.ApplyTimeouts,
+	recreationLimits engine.RecreationLimits,
+	asyncApply engine.AsyncApply,
+	conditionEvaluation controller.ConditionEvaluation,
+	assetErrorThreshold float64,
+	shardName string,
+	shardComponents string,
+	shardExcludeComponents string,
+	remoteCatalog assets.RemoteCatalog,
+	catalogRefreshInterval time.Duration,
 	enableLeaderElection bool,
 	enableDebugServer bool,
 	development bool,
 	crdValidationTimeout time.Duration,
+	waitForHCOCRD bool,
 ) error {
-	// Setup logging
+	// Setup logging. The level is atomic so it can be changed at runtime
+	// via SIGHUP or the /debug/loglevel endpoint.
+	initialLevel := zapcore.InfoLevel
+	if development {
+		initialLevel = zapcore.DebugLevel
+	}
+	logLevel := debug.NewLogLevel(initialLevel)
 	opts := zap.Options{
 		Development: development,
+		Level:       logLevel.Atomic(),
 	}
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := rateLimiter.Validate(); err != nil {
+		setupLog.Error(err, "invalid rate limiter settings")
+		return err
+	}
+	if err := canary.Validate(); err != nil {
+		setupLog.Error(err, "invalid canary rollout settings")
+		return err
+	}
+	if cacheSyncTimeout < 0 {
+		err := fmt.Errorf("cache sync timeout must not be negative, got %s", cacheSyncTimeout)
+		setupLog.Error(err, "invalid cache sync timeout")
+		return err
+	}
+	if err := controller.LabelRepairMode(labelRepairMode).Validate(); err != nil {
+		setupLog.Error(err, "invalid label repair mode")
+		return err
+	}
+	if err := notificationWebhook.Validate(); err != nil {
+		setupLog.Error(err, "invalid notification webhook")
+		return err
+	}
+	if err := applyTimeouts.Validate(); err != nil {
+		setupLog.Error(err, "invalid apply timeouts")
+		return err
+	}
+	if err := recreationLimits.Validate(); err != nil {
+		setupLog.Error(err, "invalid recreation limits")
+		return err
+	}
+	if err := asyncApply.Validate(); err != nil {
+		setupLog.Error(err, "invalid async apply settings")
+		return err
+	}
+	if err := conditionEvaluation.Validate(); err != nil {
+		setupLog.Error(err, "invalid condition evaluation settings")
+		return err
+	}
+	if assetErrorThreshold < 0 || assetErrorThreshold > 1 {
+		err := fmt.Errorf("asset error threshold must be between 0 and 1, got %v", assetErrorThreshold)
+		setupLog.Error(err, "invalid readiness settings")
+		return err
+	}
+	if renderHistoryConfigMap != "" && renderHistorySize <= 0 {
+		err := fmt.Errorf("--render-history-configmap requires a positive --render-history-size")
+		setupLog.Error(err, "invalid render history settings")
+		return err
+	}
+	shard, err := controller.NewShard(shardName, shardComponents, shardExcludeComponents)
+	if err != nil {
+		setupLog.Error(err, "invalid shard settings")
+		return err
+	}
+	if remoteCatalog.URL != "" {
+		if err := remoteCatalog.Validate(); err != nil {
+			setupLog.Error(err, "invalid remote catalog settings")
+			return err
+		}
+	}
+	if apiAddr != "0" && (apiCertFile == "" || apiKeyFile == "") {
+		err := fmt.Errorf("--api-bind-address requires --api-tls-cert-file and --api-tls-key-file")
+		setupLog.Error(err, "invalid API settings")
+		return err
+	}
+
 	// Create label selector for cache filtering
 	// Only cache resources managed by this autopilot (reduces memory in large clusters)
 	managedByRequirement, err := labels.NewRequirement(
@@ -172,6 +523,45 @@ func runController(
 	hcoForCache := &unstructured.Unstructured{}
 	hcoForCache.SetGroupVersionKind(pkgcontext.HCOGVK)
 
+	// The asset catalog declares where namespaced assets live, so their informers
+	// can be restricted to those namespaces instead of watching cluster-wide
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		setupLog.Error(err, "unable to load asset registry")
+		return err
+	}
+	// A remote catalog is fetched before the cache is configured, so the namespaces
+	// it renders into are watched
+	var remoteCatalogDigest string
+	if remoteCatalog.URL != "" {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		remoteLoader, remoteRegistry, digest, err := controller.LoadRemoteCatalog(fetchCtx, &remoteCatalog)
+		cancel()
+		if err != nil {
+			setupLog.Error(err, "remote catalog unavailable, starting with the embedded catalog", "url", remoteCatalog.URL)
+		} else {
+			loader, registry, remoteCatalogDigest = remoteLoader, remoteRegistry, digest
+			setupLog.Info("Using remote asset catalog", "url", remoteCatalog.URL, "checksum", digest,
+				"version", registry.CatalogVersion(), "digest", registry.CatalogDigest())
+		}
+	}
+	byObject, err := assetCacheNamespaces(registry, loader)
+	if err != nil {
+		setupLog.Error(err, "unable to derive cache namespaces from assets")
+		return err
+	}
+	// Watch all HCOs (labeled or not) to adopt pre-existing ones
+	byObject[hcoForCache] = cache.ByObject{Label: labels.Everything()}
+	// Watch all CRDs for soft dependency detection
+	// CRDs are managed by other operators and won't have our label
+	byObject[&apiextensionsv1.CustomResourceDefinition{}] = cache.ByObject{Label: labels.Everything()}
+	// Nodes feed hardware and topology detection and carry no managed-by label
+	byObject[&corev1.Node{}] = cache.ByObject{Label: labels.Everything(), Transform: controller.TrimNodeForCache}
+	// Watch the user's overrides ConfigMaps, which live next to the HCO without our label
+	cacheUnlabeledConfigMaps(byObject, append([]string{namespace}, strings.Split(watchNamespaces, ",")...))
+
+	leaderElectionID := shard.LeaderElectionID("virt-platform-autopilot.kubevirt.io")
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -179,23 +569,13 @@ func runController(
 		},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "virt-platform-autopilot.kubevirt.io",
+		LeaderElectionID:       leaderElectionID,
 		Cache: cache.Options{
 			// By default, only cache objects with our managed-by label
 			// This dramatically reduces memory usage in large clusters
 			DefaultLabelSelector: managedBySelector,
 			// IMPORTANT: Exempt certain resource types from label filtering
-			ByObject: map[client.Object]cache.ByObject{
-				// Watch all HCOs (labeled or not) to adopt pre-existing ones
-				hcoForCache: {
-					Label: labels.Everything(),
-				},
-				// Watch all CRDs for soft dependency detection
-				// CRDs are managed by other operators and won't have our label
-				&apiextensionsv1.CustomResourceDefinition{}: {
-					Label: labels.Everything(),
-				},
-			},
+			ByObject: byObject,
 		},
 	})
 	if err != nil {
@@ -210,16 +590,18 @@ func runController(
 	// Use a short-lived context for validation (not the signal handler context)
 	validateCtx, cancel := context.WithTimeout(context.Background(), crdValidationTimeout)
 	defer cancel()
-	hcoCRDInstalled, err := crdChecker.IsCRDInstalled(validateCtx, "hyperconvergeds.hco.kubevirt.io")
+	hcoCRDInstalled, err := crdChecker.IsCRDInstalled(validateCtx, pkgcontext.HCOCRDName)
 	if err != nil {
 		setupLog.Error(err, "failed to check for HCO CRD")
 		return err
 	}
-	if !hcoCRDInstalled {
+	if !hcoCRDInstalled && !waitForHCOCRD {
 		setupLog.Error(nil, "HyperConverged CRD not found - this component requires the HCO CRD to be installed by OLM")
 		return fmt.Errorf("HCO CRD not found")
 	}
-	setupLog.Info("HCO CRD validation passed")
+	if hcoCRDInstalled {
+		setupLog.Info("HCO CRD validation passed")
+	}
 
 	// Setup platform controller
 	// The API reader bypasses cache to detect and adopt unlabeled objects
@@ -232,6 +614,85 @@ func runController(
 		setupLog.Error(err, "unable to create platform reconciler")
 		return err
 	}
+	if remoteCatalog.URL != "" {
+		reconciler.SetCatalog(loader, registry)
+		reconciler.SetRemoteCatalog(&remoteCatalog, catalogRefreshInterval, remoteCatalogDigest)
+		// Serve the reconciler's catalog from the debug and API endpoints, so they follow refreshes
+		loader, registry = reconciler.Catalog()
+	}
+	if shard.Name != "" {
+		reconciler.SetShard(shard)
+		setupLog.Info("Running as controller shard", "shard", shard.String())
+	}
+	if watchNamespaces != "" {
+		reconciler.SetWatchNamespaces(strings.Split(watchNamespaces, ","))
+		setupLog.Info("Watching HyperConverged CRs in additional namespaces", "namespaces", watchNamespaces)
+	}
+
+	// Load cluster-wide policy and install the configured apply mutators. Reconciles
+	// re-read the file, so edits to the mounted ConfigMap apply without a restart.
+	if err := reconciler.LoadConfig(configFile); err != nil {
+		setupLog.Error(err, "unable to load autopilot config", "available", engine.RegisteredMutators())
+		return err
+	}
+
+	if hardwareRemovalGracePeriod > 0 {
+		reconciler.SetHardwareRemovalGracePeriod(hardwareRemovalGracePeriod)
+		setupLog.Info("Hardware churn damping enabled", "gracePeriod", hardwareRemovalGracePeriod)
+	}
+	reconciler.SetDeferRebootsDuringUpgrade(deferRebootsDuringUpgrade)
+	reconciler.SetBlastRadiusLimits(maxRebootChanges, maxDeletions)
+	reconciler.SetMaxRebootNodes(maxRebootNodes)
+	if canary.Pool != "" {
+		reconciler.SetCanaryRollout(canary)
+		setupLog.Info("Canary rollout of node-rebooting changes enabled", "pool", canary.Pool, "timeout", canary.Timeout)
+	}
+	reconciler.SetApplyTimeouts(applyTimeouts)
+	reconciler.SetRecreationLimits(recreationLimits)
+	reconciler.SetAsyncApply(asyncApply)
+	reconciler.SetConditionEvaluation(conditionEvaluation)
+	reconciler.SetAssetErrorThreshold(assetErrorThreshold)
+	if imageMapping != "" {
+		mapping, err := engine.LoadImageMapping(imageMapping)
+		if err != nil {
+			setupLog.Error(err, "unable to load image mapping")
+			return err
+		}
+		reconciler.SetImageResolver(mapping)
+		setupLog.Info("Image digest pinning enabled", "mapping", imageMapping, "entries", len(mapping))
+	}
+	var renderHistory *engine.RenderHistory
+	if renderHistorySize > 0 {
+		renderHistory = engine.NewRenderHistory(renderHistorySize)
+		if renderHistoryConfigMap != "" {
+			renderHistory.SetConfigMap(mgr.GetClient(), mgr.GetAPIReader(), namespace, renderHistoryConfigMap)
+			loadCtx, cancel := context.WithTimeout(context.Background(), crdValidationTimeout)
+			err := renderHistory.Load(loadCtx)
+			cancel()
+			if err != nil {
+				// A lost history only limits rollbacks; it is rebuilt as assets are applied
+				setupLog.Error(err, "unable to load render history, starting with an empty one")
+			}
+		}
+		reconciler.SetRenderHistory(renderHistory)
+		setupLog.Info("Render history enabled", "size", renderHistorySize, "configMap", renderHistoryConfigMap)
+	}
+	reconciler.SetCacheStatsInterval(cacheStatsInterval)
+	reconciler.SetCacheSyncTimeout(cacheSyncTimeout)
+	reconciler.SetLabelRepair(labelRepairInterval, controller.LabelRepairMode(labelRepairMode))
+	reconciler.SetNodeEventDebounce(nodeEventDebounce)
+	reconciler.SetManagedResourceExport(exportManagedResources)
+	reconciler.SetOwnerReferences(ownerReferences)
+	if notificationWebhook.URL != "" {
+		reconciler.SetNotificationWebhook(notificationWebhook)
+		setupLog.Info("Reconcile summaries posted to notification webhook", "signed", notificationWebhook.SecretFile != "")
+	}
+	reconciler.SetDifferentialSync(differentialSync)
+	reconciler.SetRateLimiterOptions(rateLimiter)
+	if logAssets != "" {
+		reconciler.SetAssetLogFilter(strings.Split(logAssets, ","))
+		setupLog.Info("Per-asset logging restricted", "assets", logAssets)
+	}
 
 	// Setup event recorder
 	eventRecorder := util.NewEventRecorder(
@@ -244,23 +705,54 @@ func runController(
 	signalCtx := ctrl.SetupSignalHandler()
 	ctx, cancel := context.WithCancel(signalCtx)
 	reconciler.SetShutdownFunc(cancel)
+	logLevel.WatchSIGHUP(ctx)
+
+	// Without the HCO CRD the controller cannot watch HyperConverged yet: defer its
+	// setup until OLM has installed and established the CRD
+	var hcoCRDWaiter *util.CRDWaiter
+	if hcoCRDInstalled {
+		if err = reconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to setup platform controller")
+			return err
+		}
+	} else {
+		setupLog.Info("HyperConverged CRD not found, waiting for it to be established before starting the platform controller")
+		hcoCRDWaiter = util.NewCRDWaiter(mgr.GetAPIReader(), pkgcontext.HCOCRDName, func() error {
+			setupLog.Info("HyperConverged CRD established, starting platform controller")
+			return reconciler.SetupWithManager(mgr)
+		})
+		if err := mgr.Add(hcoCRDWaiter); err != nil {
+			setupLog.Error(err, "unable to add HCO CRD waiter")
+			return err
+		}
+	}
 
-	if err = reconciler.SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to setup platform controller")
-		return err
+	// With leader election, every replica watches the lease so standby replicas can
+	// report whether a healthy leader exists
+	var leaderObserver *controller.LeaderObserver
+	if enableLeaderElection {
+		leaderObserver = controller.NewLeaderObserver(mgr.GetAPIReader(),
+			controller.LeaderElectionNamespace(namespace), leaderElectionID, mgr.Elected())
+		if err := mgr.Add(leaderObserver); err != nil {
+			setupLog.Error(err, "unable to add leader observer")
+			return err
+		}
 	}
 
 	// Setup debug server if enabled
 	if enableDebugServer {
 		setupLog.Info("Starting debug server", "address", debugAddr)
-		loader := assets.NewLoader()
-		registry, err := assets.NewRegistry(loader)
-		if err != nil {
-			setupLog.Error(err, "unable to load asset registry for debug server")
-			return err
-		}
-
 		debugServer := debug.NewServer(mgr.GetClient(), loader, registry)
+		debugServer.SetLogLevel(logLevel)
+		debugServer.SetAPIReader(mgr.GetAPIReader())
+		debugServer.SetMutators(reconciler.Mutators)
+		debugServer.SetHardwareHistory(reconciler.HardwareHistory)
+		if renderHistory != nil {
+			debugServer.SetRenderHistory(renderHistory)
+		}
+		if leaderObserver != nil {
+			debugServer.SetLeaderStatus(leaderObserver.Status)
+		}
 		debugMux := http.NewServeMux()
 		debugServer.InstallHandlers(debugMux)
 
@@ -286,15 +778,38 @@ func runController(
 		}()
 	}
 
+	// Setup the external API if enabled; it runs on every replica
+	if apiAddr != "0" {
+		// Events are not cached, so the drift events are listed with the API reader
+		querier := debug.NewServer(mgr.GetClient(), loader, registry)
+		querier.SetAPIReader(mgr.GetAPIReader())
+		apiServer := api.NewServer(apiAddr, apiCertFile, apiKeyFile, querier, api.NewAuthorizer(mgr.GetClient()))
+		apiServer.SetAllowedOrigins(apiAllowedOrigins)
+		if err := mgr.Add(apiServer); err != nil {
+			setupLog.Error(err, "unable to add API server")
+			return err
+		}
+	}
+
 	// Setup health checks
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		return err
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	readyCheck := healthz.Ping
+	if hcoCRDWaiter != nil {
+		readyCheck = hcoCRDWaiter.ReadyzCheck
+	}
+	if err := mgr.AddReadyzCheck("readyz", readyCheck); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		return err
 	}
+	if assetErrorThreshold > 0 {
+		if err := mgr.AddReadyzCheck("assets", reconciler.AssetsReadyzCheck); err != nil {
+			setupLog.Error(err, "unable to set up assets ready check")
+			return err
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -304,3 +819,69 @@ func runController(
 
 	return nil
 }
+
+// cacheUnlabeledConfigMaps caches every ConfigMap in the HCO namespaces, so changes to an
+// overrides ConfigMap trigger a reconcile. Elsewhere ConfigMaps keep the managed-by filter
+// and any asset namespace restriction. With --watch-namespaces=* nothing is added: caching
+// all ConfigMaps cluster-wide costs too much, and changes are picked up on the next resync.
+func cacheUnlabeledConfigMaps(byObject map[client.Object]cache.ByObject, namespaces []string) {
+	if slices.Contains(namespaces, controller.AllNamespaces) {
+		return
+	}
+
+	var key client.Object = &corev1.ConfigMap{}
+	config := cache.ByObject{Namespaces: map[string]cache.Config{cache.AllNamespaces: {}}}
+	for obj, existing := range byObject {
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			key, config = obj, existing
+			break
+		}
+	}
+
+	for _, ns := range namespaces {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			config.Namespaces[ns] = cache.Config{LabelSelector: labels.Everything()}
+		}
+	}
+	byObject[key] = config
+}
+
+// assetCacheNamespaces restricts the informers of namespaced asset kinds to the namespaces
+// the catalog places them in (plus tombstoned objects of the same kind, so they stay
+// visible for cleanup). Only kinds known to the typed scheme are restricted: CRD-backed
+// kinds may not be installed, and an unresolvable ByObject entry would fail manager start.
+func assetCacheNamespaces(registry *assets.Registry, loader *assets.Loader) (map[client.Object]cache.ByObject, error) {
+	namespaces := registry.AssetNamespaces()
+
+	tombstones, err := loader.LoadTombstones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tombstones: %w", err)
+	}
+	for _, ts := range tombstones {
+		if _, ok := namespaces[ts.GVK]; ok && ts.Namespace != "" && !slices.Contains(namespaces[ts.GVK], ts.Namespace) {
+			namespaces[ts.GVK] = append(namespaces[ts.GVK], ts.Namespace)
+		}
+	}
+
+	byObject := make(map[client.Object]cache.ByObject)
+	for gvk, nsList := range namespaces {
+		if !scheme.Recognizes(gvk) {
+			continue
+		}
+		obj, err := scheme.New(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", gvk, err)
+		}
+		clientObj, ok := obj.(client.Object)
+		if !ok {
+			continue
+		}
+		config := cache.ByObject{Namespaces: make(map[string]cache.Config, len(nsList))}
+		for _, ns := range nsList {
+			config.Namespaces[ns] = cache.Config{}
+		}
+		byObject[clientObj] = config
+		setupLog.Info("Restricting cache to asset namespaces", "kind", gvk.Kind, "namespaces", nsList)
+	}
+	return byObject, nil
+}
@@ -0,0 +1,449 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	embeddedassets "github.com/kubevirt/virt-platform-autopilot/assets"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/rbac"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+// Status is the outcome of one check
+type Status string
+
+const (
+	// StatusPass means nothing stands in the way
+	StatusPass Status = "PASS"
+	// StatusWarn means enabling works but has an effect the admin should know about
+	StatusWarn Status = "WARN"
+	// StatusFail means the autopilot would not work correctly once enabled
+	StatusFail Status = "FAIL"
+)
+
+// Names of the checks, in the order they run
+const (
+	CheckHyperConverged  = "hyperconverged"
+	CheckServerSideApply = "server-side-apply"
+	CheckRBAC            = "rbac"
+	CheckDependencies    = "dependent-operators"
+	CheckPlan            = "plan"
+	CheckConflicts       = "existing-objects"
+)
+
+const (
+	// defaultServiceAccount is the operator's ServiceAccount in the OLM bundle and config/
+	defaultServiceAccount = "virt-platform-autopilot"
+	// maxListed bounds the fields or assets listed in one detail line
+	maxListed = 5
+)
+
+// Check is the result of one pre-flight check
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Details list what the message summarizes, e.g. the missing permissions
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the result of all checks
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Count returns the number of checks with status
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Options select what Run checks against
+type Options struct {
+	// ServiceAccount is the operator's ServiceAccount as namespace/name
+	ServiceAccount string
+}
+
+var (
+	kubeconfig     string
+	namespace      string
+	serviceAccount string
+	outputFormat   string
+)
+
+// NewPreflightCommand creates the preflight subcommand
+func NewPreflightCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Check a cluster before enabling the autopilot on it",
+		Long: `Check what enabling the autopilot on the HyperConverged would do, before the
+platform.kubevirt.io/autopilot annotation is set:
+
+  server-side-apply    the API server accepts the server-side apply dry-runs the
+                       autopilot relies on
+  rbac                 the operator's ServiceAccount holds every permission the
+                       managed kinds need
+  dependent-operators  the operators whose CRDs assets require are installed;
+                       assets of missing ones are skipped
+  plan                 every included asset renders and dry-run applies, and how
+                       many objects would be created or updated
+  existing-objects     objects the autopilot would take over and change, because
+                       they already exist without its label
+
+Every check reports PASS, WARN or FAIL. Nothing is written to the cluster. The
+command exits non-zero when a check fails.
+
+Examples:
+  virt-platform-autopilot preflight --kubeconfig ~/.kube/config
+  virt-platform-autopilot preflight --service-account=openshift-cnv/virt-platform-autopilot --output=json
+`,
+		Args: cobra.NoArgs,
+		RunE: runPreflight,
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the HyperConverged CR (required when there are several)")
+	cmd.Flags().StringVar(&serviceAccount, "service-account", "",
+		"The operator's ServiceAccount as namespace/name (default: virt-platform-autopilot in the HyperConverged's namespace)")
+	cmd.Flags().StringVar(&outputFormat, "output", "text", "Output format: text or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// runPreflight executes the preflight command
+func runPreflight(cmd *cobra.Command, _ []string) error {
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	cmd.SilenceUsage = true
+
+	c, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	ctx := context.Background()
+	hco, err := cluster.FindHCO(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+	report := Run(ctx, c, loader, registry, hco, Options{ServiceAccount: serviceAccount})
+	if err := writeReport(cmd.OutOrStdout(), report, outputFormat); err != nil {
+		return err
+	}
+	if failed := report.Count(StatusFail); failed > 0 {
+		return fmt.Errorf("%d preflight checks failed", failed)
+	}
+	return nil
+}
+
+// Run runs every check against hco. Checks that cannot be completed fail with the
+// error; the others still run.
+func Run(ctx context.Context, c client.Client, loader *assets.Loader, registry *assets.Registry,
+	hco *unstructured.Unstructured, opts Options) Report {
+	sa := opts.ServiceAccount
+	if sa == "" {
+		sa = hco.GetNamespace() + "/" + defaultServiceAccount
+	}
+
+	report := Report{Checks: []Check{
+		checkHyperConverged(hco),
+		checkServerSideApply(ctx, c, hco.GetNamespace()),
+		checkRBAC(ctx, c, sa),
+		checkDependencies(ctx, c, registry),
+	}}
+	report.Checks = append(report.Checks, checkPlan(ctx, c, loader, registry, hco)...)
+	return report
+}
+
+// checkHyperConverged reports the HCO checked and whether the autopilot already runs on it
+func checkHyperConverged(hco *unstructured.Unstructured) Check {
+	name := hco.GetNamespace() + "/" + hco.GetName()
+	if _, enabled := overrides.ParseAutopilotScope(hco); enabled {
+		return Check{Name: CheckHyperConverged, Status: StatusWarn,
+			Message: fmt.Sprintf("%s already carries %s; the autopilot manages it", name, overrides.AnnotationAutopilotEnabled)}
+	}
+	return Check{Name: CheckHyperConverged, Status: StatusPass, Message: name + " found, the autopilot is not enabled yet"}
+}
+
+// checkServerSideApply dry-runs a server-side apply of a ConfigMap, as drift detection does
+func checkServerSideApply(ctx context.Context, c client.Client, namespace string) Check {
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetNamespace(namespace)
+	cm.SetName("virt-platform-autopilot-preflight")
+	_, err := engine.NewDriftDetector(c).DryRunApply(ctx, cm)
+	switch {
+	case err == nil:
+		return Check{Name: CheckServerSideApply, Status: StatusPass, Message: "server-side apply dry-runs are accepted"}
+	case apierrors.IsForbidden(err):
+		return Check{Name: CheckServerSideApply, Status: StatusWarn,
+			Message: "could not verify server-side apply: your user may not apply ConfigMaps in " + namespace}
+	default:
+		return Check{Name: CheckServerSideApply, Status: StatusFail, Message: err.Error()}
+	}
+}
+
+// checkRBAC reviews, for the operator's ServiceAccount, every verb on every resource
+// kind the catalog and its tombstones manage
+func checkRBAC(ctx context.Context, c client.Client, sa string) Check {
+	saNamespace, saName, ok := strings.Cut(sa, "/")
+	if !ok || saNamespace == "" || saName == "" {
+		return Check{Name: CheckRBAC, Status: StatusFail, Message: fmt.Sprintf("invalid ServiceAccount %q, expected namespace/name", sa)}
+	}
+	rules, err := rbac.DynamicRules(embeddedassets.EmbeddedFS)
+	if err != nil {
+		return Check{Name: CheckRBAC, Status: StatusFail, Message: fmt.Sprintf("failed to derive the required rules: %v", err)}
+	}
+
+	user := fmt.Sprintf("system:serviceaccount:%s:%s", saNamespace, saName)
+	groups := []string{"system:serviceaccounts", "system:serviceaccounts:" + saNamespace, "system:authenticated"}
+	var missing []string
+	checked := 0
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+						User:   user,
+						Groups: groups,
+						ResourceAttributes: &authorizationv1.ResourceAttributes{
+							Group:    group,
+							Resource: resource,
+							Verb:     verb,
+						},
+					}}
+					if err := c.Create(ctx, review); err != nil {
+						return Check{Name: CheckRBAC, Status: StatusFail, Message: fmt.Sprintf("subject access review failed: %v", err)}
+					}
+					checked++
+					if !review.Status.Allowed {
+						missing = append(missing, fmt.Sprintf("%s %s", verb, groupResource(group, resource)))
+					}
+				}
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return Check{Name: CheckRBAC, Status: StatusFail,
+			Message: fmt.Sprintf("%s lacks %d of %d permissions on managed kinds", user, len(missing), checked),
+			Details: missing}
+	}
+	return Check{Name: CheckRBAC, Status: StatusPass, Message: fmt.Sprintf("%s holds all %d permissions on managed kinds", user, checked)}
+}
+
+func groupResource(group, resource string) string {
+	if group == "" {
+		return resource
+	}
+	return group + "/" + resource
+}
+
+// checkDependencies checks the CRDs assets require or are gated on, which the
+// dependent operators install
+func checkDependencies(ctx context.Context, c client.Client, registry *assets.Registry) Check {
+	dependents := make(map[string][]string)
+	for _, asset := range registry.ListAssetsByReconcileOrder() {
+		for _, crd := range []string{asset.RequiredCRD, asset.GateCRD} {
+			if crd != "" && !slices.Contains(dependents[crd], asset.Name) {
+				dependents[crd] = append(dependents[crd], asset.Name)
+			}
+		}
+	}
+	crds := make([]string, 0, len(dependents))
+	for crd := range dependents {
+		crds = append(crds, crd)
+	}
+	sort.Strings(crds)
+
+	checker := util.NewCRDChecker(c)
+	var missing []string
+	for _, crd := range crds {
+		installed, err := checker.IsCRDInstalled(ctx, crd)
+		if err != nil {
+			return Check{Name: CheckDependencies, Status: StatusFail, Message: fmt.Sprintf("failed to check CRD %s: %v", crd, err)}
+		}
+		if !installed {
+			missing = append(missing, fmt.Sprintf("%s not installed, skips %s", crd, truncate(dependents[crd])))
+		}
+	}
+
+	if len(missing) > 0 {
+		return Check{Name: CheckDependencies, Status: StatusWarn,
+			Message: fmt.Sprintf("%d of %d CRDs of dependent operators are missing; their assets are skipped", len(missing), len(crds)),
+			Details: missing}
+	}
+	return Check{Name: CheckDependencies, Status: StatusPass, Message: fmt.Sprintf("all %d CRDs of dependent operators are installed", len(crds))}
+}
+
+// checkPlan plans a reconcile of hco as if the autopilot were enabled on it, and
+// reports failing assets and the existing objects it would take over and change
+func checkPlan(ctx context.Context, c client.Client, loader *assets.Loader, registry *assets.Registry,
+	hco *unstructured.Unstructured) []Check {
+	enabled := hco.DeepCopy()
+	if _, ok := overrides.ParseAutopilotScope(enabled); !ok {
+		annotations := enabled.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[overrides.AnnotationAutopilotEnabled] = "true"
+		enabled.SetAnnotations(annotations)
+	}
+
+	renderCtx, inclusions, err := controller.EvaluateInclusion(ctx, c, registry, enabled)
+	if err != nil {
+		return []Check{{Name: CheckPlan, Status: StatusFail, Message: fmt.Sprintf("failed to evaluate asset inclusion: %v", err)}}
+	}
+
+	planner := engine.NewPlanner(c, c, loader)
+	summary := make(map[engine.PlanAction]int)
+	var failures, conflicts []string
+	for _, inclusion := range inclusions {
+		if !inclusion.Included {
+			continue
+		}
+		assetMeta, err := registry.GetAsset(inclusion.Asset)
+		if err != nil {
+			return []Check{{Name: CheckPlan, Status: StatusFail, Message: err.Error()}}
+		}
+		plan := planner.PlanAsset(ctx, assetMeta, renderCtx)
+		summary[plan.Action]++
+		switch plan.Action {
+		case engine.PlanError:
+			failures = append(failures, fmt.Sprintf("%s: %s: %s", plan.Asset, plan.Reason, plan.Message))
+		case engine.PlanUpdate:
+			conflict, err := takeOver(ctx, c, plan)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", plan.Asset, err))
+			} else if conflict != "" {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	planCheck := Check{Name: CheckPlan, Status: StatusPass, Message: fmt.Sprintf(
+		"enabling creates %d objects and updates %d; %d already match and %d are skipped",
+		summary[engine.PlanCreate], summary[engine.PlanUpdate], summary[engine.PlanUnchanged], summary[engine.PlanSkip])}
+	if len(failures) > 0 {
+		planCheck.Status = StatusFail
+		planCheck.Message = fmt.Sprintf("%d included assets would fail to reconcile", len(failures))
+		planCheck.Details = failures
+	}
+
+	conflictCheck := Check{Name: CheckConflicts, Status: StatusPass, Message: "no existing object would be taken over and changed"}
+	if len(conflicts) > 0 {
+		conflictCheck.Status = StatusWarn
+		conflictCheck.Message = fmt.Sprintf("%d existing objects without the %s label would be taken over and changed; "+
+			"keep their customizations with the adopt command", len(conflicts), engine.ManagedByLabel)
+		conflictCheck.Details = conflicts
+	}
+	return []Check{planCheck, conflictCheck}
+}
+
+// takeOver describes the update plan makes to an existing object the autopilot does
+// not manage yet, or returns "" when the object already carries its label
+func takeOver(ctx context.Context, c client.Reader, plan engine.ObjectPlan) (string, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(plan.Object.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKey{Namespace: plan.Namespace, Name: plan.Name}, live); err != nil {
+		return "", fmt.Errorf("failed to get %s %s: %w", plan.Kind, plan.Name, err)
+	}
+	if live.GetLabels()[engine.ManagedByLabel] == engine.ManagedByValue {
+		return "", nil
+	}
+
+	// The management label and annotations the apply adds are not customizations
+	var fields []string
+	for _, field := range plan.Fields {
+		if !strings.HasPrefix(field, "metadata.") {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%s %s (asset %s): %s", plan.Kind, objectName(plan.Namespace, plan.Name), plan.Asset,
+		truncate(fields)), nil
+}
+
+// truncate joins the first maxListed items of list
+func truncate(list []string) string {
+	if len(list) <= maxListed {
+		return strings.Join(list, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(list[:maxListed], ", "), len(list)-maxListed)
+}
+
+// writeReport prints report in format
+func writeReport(w io.Writer, report Report, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%-4s  %-20s %s\n", check.Status, check.Name, check.Message)
+		for _, detail := range check.Details {
+			fmt.Fprintf(w, "        - %s\n", detail)
+		}
+	}
+	fmt.Fprintf(w, "Summary: %d passed, %d warnings, %d failed\n",
+		report.Count(StatusPass), report.Count(StatusWarn), report.Count(StatusFail))
+	return nil
+}
+
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+func newHCO() *unstructured.Unstructured {
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	hco.SetNamespace("openshift-cnv")
+	hco.SetName("kubevirt-hyperconverged")
+	return hco
+}
+
+// reviewer answers SubjectAccessReviews, denying the resources in denied
+func reviewer(denied ...string) interceptor.Funcs {
+	return interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			review.Status.Allowed = !slices.Contains(denied, review.Spec.ResourceAttributes.Resource)
+			return nil
+		},
+	}
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestCheckHyperConverged(t *testing.T) {
+	hco := newHCO()
+	assert.Equal(t, StatusPass, checkHyperConverged(hco).Status)
+
+	hco.SetAnnotations(map[string]string{overrides.AnnotationAutopilotEnabled: "true"})
+	check := checkHyperConverged(hco)
+	assert.Equal(t, StatusWarn, check.Status)
+	assert.Contains(t, check.Message, "already carries")
+}
+
+func TestCheckRBAC(t *testing.T) {
+	ctx := context.Background()
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	funcs := reviewer()
+	create := funcs.Create
+	funcs.Create = func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+			reviews = append(reviews, review.Spec)
+		}
+		return create(ctx, c, obj, opts...)
+	}
+	c := fake.NewClientBuilder().WithInterceptorFuncs(funcs).Build()
+
+	check := checkRBAC(ctx, c, "openshift-cnv/virt-platform-autopilot")
+	assert.Equal(t, StatusPass, check.Status, check.Message)
+	require.NotEmpty(t, reviews)
+	assert.Equal(t, "system:serviceaccount:openshift-cnv:virt-platform-autopilot", reviews[0].User)
+	assert.Contains(t, reviews[0].Groups, "system:serviceaccounts:openshift-cnv")
+
+	c = fake.NewClientBuilder().WithInterceptorFuncs(reviewer("machineconfigs")).Build()
+	check = checkRBAC(ctx, c, "openshift-cnv/virt-platform-autopilot")
+	assert.Equal(t, StatusFail, check.Status)
+	assert.Contains(t, check.Details, "patch machineconfiguration.openshift.io/machineconfigs")
+	for _, detail := range check.Details {
+		assert.Contains(t, detail, "machineconfigs")
+	}
+
+	check = checkRBAC(ctx, c, "virt-platform-autopilot")
+	assert.Equal(t, StatusFail, check.Status)
+	assert.Contains(t, check.Message, "expected namespace/name")
+}
+
+func TestCheckDependencies(t *testing.T) {
+	ctx := context.Background()
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+	check := checkDependencies(ctx, c, registry)
+	assert.Equal(t, StatusWarn, check.Status)
+	require.NotEmpty(t, check.Details)
+	assert.Contains(t, check.Details[0], "not installed, skips")
+
+	var crds []client.Object
+	for _, asset := range registry.ListAssetsByReconcileOrder() {
+		for _, name := range []string{asset.RequiredCRD, asset.GateCRD} {
+			if name != "" {
+				crds = append(crds, &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+		}
+	}
+	c = fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dedupe(crds)...).Build()
+	check = checkDependencies(ctx, c, registry)
+	assert.Equal(t, StatusPass, check.Status, check.Details)
+}
+
+func dedupe(objects []client.Object) []client.Object {
+	seen := make(map[string]bool)
+	var out []client.Object
+	for _, obj := range objects {
+		if !seen[obj.GetName()] {
+			seen[obj.GetName()] = true
+			out = append(out, obj)
+		}
+	}
+	return out
+}
+
+func TestTakeOver(t *testing.T) {
+	ctx := context.Background()
+	live := &unstructured.Unstructured{}
+	live.SetAPIVersion("v1")
+	live.SetKind("ConfigMap")
+	live.SetNamespace("openshift-cnv")
+	live.SetName("virt-settings")
+	c := fake.NewClientBuilder().WithObjects(live.DeepCopy()).Build()
+	plan := engine.ObjectPlan{Asset: "virt-settings", Kind: "ConfigMap", Namespace: "openshift-cnv", Name: "virt-settings",
+		Action: engine.PlanUpdate, Object: live, Fields: []string{"metadata.labels", "data.mode"}}
+
+	conflict, err := takeOver(ctx, c, plan)
+	require.NoError(t, err)
+	assert.Equal(t, "ConfigMap openshift-cnv/virt-settings (asset virt-settings): data.mode", conflict)
+
+	// Only the management metadata changes
+	plan.Fields = []string{"metadata.labels"}
+	conflict, err = takeOver(ctx, c, plan)
+	require.NoError(t, err)
+	assert.Empty(t, conflict)
+
+	// Objects the autopilot already manages are not taken over
+	live.SetLabels(map[string]string{engine.ManagedByLabel: engine.ManagedByValue})
+	c = fake.NewClientBuilder().WithObjects(live.DeepCopy()).Build()
+	plan.Fields = []string{"data.mode"}
+	conflict, err = takeOver(ctx, c, plan)
+	require.NoError(t, err)
+	assert.Empty(t, conflict)
+}
+
+func TestRunFailsWithoutPermissions(t *testing.T) {
+	ctx := context.Background()
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+	hco := newHCO()
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(hco).
+		WithInterceptorFuncs(reviewer("machineconfigs")).Build()
+
+	report := Run(ctx, c, loader, registry, hco, Options{})
+	names := make([]string, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		names = append(names, check.Name)
+	}
+	assert.Equal(t, []string{CheckHyperConverged, CheckServerSideApply, CheckRBAC, CheckDependencies, CheckPlan, CheckConflicts}, names)
+	assert.Equal(t, StatusFail, report.Checks[2].Status)
+	assert.GreaterOrEqual(t, report.Count(StatusFail), 1)
+}
+
+func TestWriteReport(t *testing.T) {
+	report := Report{Checks: []Check{
+		{Name: CheckRBAC, Status: StatusFail, Message: "lacks 1 of 2 permissions", Details: []string{"patch configmaps"}},
+		{Name: CheckPlan, Status: StatusPass, Message: "enabling creates 1 objects"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeReport(&out, report, "text"))
+	assert.Contains(t, out.String(), "FAIL  rbac")
+	assert.Contains(t, out.String(), "        - patch configmaps")
+	assert.Contains(t, out.String(), "Summary: 1 passed, 0 warnings, 1 failed")
+
+	out.Reset()
+	require.NoError(t, writeReport(&out, report, "json"))
+	var decoded Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+}
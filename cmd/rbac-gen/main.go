@@ -101,8 +101,16 @@ func formatRulesWithComments(static, transitive, dynamic []rbac.Rule) string {
 		"Events (for observability - modern events.k8s.io/v1 API)",
 		"Leader Election",
 		"CRD Discovery (for soft dependency detection and template introspection)",
-		"OpenShift Infrastructure CR (for topology detection: HCP, compact, cloud provider)",
+		"OpenShift Infrastructure, Proxy, ClusterVersion, Network and ImageDigestMirrorSet CRs (for topology detection, proxy/trusted-CA propagation, upgrade safe-mode, network detection and image mirrors)",
 		"Namespaces (pre-apply guard: verify target namespace before consuming a rate-limit token)",
+		"MachineConfigPools (upgrade safe-mode: defer reboot-triggering assets while pools roll out)",
+		"ImageContentSourcePolicies (deprecated image mirror configuration)",
+		"HyperConverged status (reconcile failure condition)",
+		"Storage capability detection (StorageClasses, StorageProfiles, VolumeSnapshotClasses, ODF StorageClusters)",
+		"Network detection (Cluster Network Operator config, NMState instances)",
+		"ConfigMaps (user overrides ConfigMap referenced from the HCO)",
+		"TokenReviews and SubjectAccessReviews (external API authentication and authorization)",
+		"ManagedResources (inventory of applied objects)",
 	}
 	for i, rule := range static {
 		if i < len(staticComments) {
@@ -125,20 +133,24 @@ func formatRulesWithComments(static, transitive, dynamic []rbac.Rule) string {
 	b.WriteString("  # ========================================\n")
 	for _, rule := range dynamic {
 		comment := commentForAPIGroup(rule.APIGroups[0])
-		hasDelete := false
+		if comment == "" {
+			comment = rule.APIGroups[0]
+			if comment == "" {
+				comment = "Core"
+			}
+		}
+		if rule.Scope == "Namespaced" {
+			comment += " - namespaced"
+		} else {
+			comment += " - cluster-scoped"
+		}
 		for _, v := range rule.Verbs {
 			if v == "delete" {
-				hasDelete = true
+				comment += " (includes tombstone cleanup)"
 				break
 			}
 		}
-		if comment != "" {
-			if hasDelete {
-				fmt.Fprintf(&b, "  # %s (includes tombstone cleanup)\n", comment)
-			} else {
-				fmt.Fprintf(&b, "  # %s\n", comment)
-			}
-		}
+		fmt.Fprintf(&b, "  # %s\n", comment)
 		writeRule(&b, rule)
 	}
 
@@ -174,6 +186,8 @@ func commentForAPIGroup(group string) string {
 		return "MachineConfig & KubeletConfig"
 	case "operator.openshift.io":
 		return "KubeDescheduler"
+	case "performance.openshift.io":
+		return "Node Tuning PerformanceProfile"
 	case "remediation.medik8s.io":
 		return "NodeHealthCheck"
 	case "self-node-remediation.medik8s.io":
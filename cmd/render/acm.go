@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+const (
+	// outputACMPolicy is the --output value that wraps assets in ACM policies
+	outputACMPolicy = "acm-policy"
+
+	acmPolicyAPIVersion = "policy.open-cluster-management.io/v1"
+
+	// acmPolicyPrefix starts every policy name; it is short because ACM replicates a
+	// policy into each cluster namespace as <namespace>.<name>, limited to 63 characters
+	acmPolicyPrefix = "autopilot-"
+
+	acmMaxReplicatedName = 63
+)
+
+var (
+	policyNamespace   string
+	policyRemediation string
+	policyPlacement   string
+)
+
+// validatePolicyFlags checks the ACM policy flags
+func validatePolicyFlags() error {
+	if policyRemediation != "inform" && policyRemediation != "enforce" {
+		return fmt.Errorf("invalid --policy-remediation %q, expected inform or enforce", policyRemediation)
+	}
+	if policyNamespace == "" {
+		return fmt.Errorf("--policy-namespace must not be empty")
+	}
+	return nil
+}
+
+// acmPolicies wraps every included asset in a Policy with one ConfigurationPolicy
+// that requires the rendered object (musthave). With a placement, a PlacementBinding
+// binding all policies to it is appended.
+func acmPolicies(outputs []pkgrender.RenderOutput, namespace, remediation, placement string) ([]map[string]any, error) {
+	var documents []map[string]any
+	var subjects []any
+	for _, output := range outputs {
+		if output.Status != "INCLUDED" || output.Object == nil {
+			continue
+		}
+
+		name := acmPolicyPrefix + output.Asset
+		if replicated := len(namespace) + 1 + len(name); replicated > acmMaxReplicatedName {
+			return nil, fmt.Errorf("policy %s/%s is too long: ACM replicates it as %s.%s (%d characters, at most %d); use a shorter --policy-namespace",
+				namespace, name, namespace, name, replicated, acmMaxReplicatedName)
+		}
+
+		documents = append(documents, map[string]any{
+			"apiVersion": acmPolicyAPIVersion,
+			"kind":       "Policy",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+				"annotations": map[string]any{
+					"policy.open-cluster-management.io/standard":   "NIST SP 800-53",
+					"policy.open-cluster-management.io/categories": "CM Configuration Management",
+					"policy.open-cluster-management.io/controls":   "CM-2 Baseline Configuration",
+					"platform.kubevirt.io/asset":                   output.Asset,
+				},
+			},
+			"spec": map[string]any{
+				"disabled":          false,
+				"remediationAction": remediation,
+				"policy-templates": []any{
+					map[string]any{
+						"objectDefinition": map[string]any{
+							"apiVersion": acmPolicyAPIVersion,
+							"kind":       "ConfigurationPolicy",
+							"metadata":   map[string]any{"name": name},
+							"spec": map[string]any{
+								"remediationAction": remediation,
+								"severity":          "medium",
+								"object-templates": []any{
+									map[string]any{
+										"complianceType":   "musthave",
+										"objectDefinition": output.Object.Object,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+		subjects = append(subjects, map[string]any{
+			"apiGroup": "policy.open-cluster-management.io",
+			"kind":     "Policy",
+			"name":     name,
+		})
+	}
+
+	if placement != "" && len(subjects) > 0 {
+		documents = append(documents, map[string]any{
+			"apiVersion": acmPolicyAPIVersion,
+			"kind":       "PlacementBinding",
+			"metadata": map[string]any{
+				"name":      acmPolicyPrefix + "binding",
+				"namespace": namespace,
+			},
+			"placementRef": map[string]any{
+				"apiGroup": "cluster.open-cluster-management.io",
+				"kind":     "Placement",
+				"name":     placement,
+			},
+			"subjects": subjects,
+		})
+	}
+	return documents, nil
+}
+
+// writeACMPolicies writes the policies of outputs as multi-document YAML
+func writeACMPolicies(w io.Writer, outputs []pkgrender.RenderOutput) error {
+	documents, err := acmPolicies(outputs, policyNamespace, policyRemediation, policyPlacement)
+	if err != nil {
+		return err
+	}
+	for _, document := range documents {
+		data, err := yaml.Marshal(document)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", document["kind"], err)
+		}
+		fmt.Fprint(w, string(data))
+		fmt.Fprintln(w, "---")
+	}
+	return nil
+}
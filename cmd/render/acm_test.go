@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+func TestACMPolicies(t *testing.T) {
+	mc := &unstructured.Unstructured{}
+	mc.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	mc.SetKind("MachineConfig")
+	mc.SetName("90-worker-swap-online")
+
+	outputs := []pkgrender.RenderOutput{
+		{Asset: "swap-enable", Status: "INCLUDED", Object: mc},
+		{Asset: "pci-passthrough", Status: "EXCLUDED", Reason: engine.ReasonConditionsNotMet},
+	}
+
+	documents, err := acmPolicies(outputs, "virt-policies", "enforce", "virt-clusters")
+	require.NoError(t, err)
+	require.Len(t, documents, 2, "one policy for the included asset and the binding")
+
+	policy := &unstructured.Unstructured{Object: documents[0]}
+	assert.Equal(t, "Policy", policy.GetKind())
+	assert.Equal(t, "autopilot-swap-enable", policy.GetName())
+	assert.Equal(t, "virt-policies", policy.GetNamespace())
+	assert.Equal(t, "swap-enable", policy.GetAnnotations()["platform.kubevirt.io/asset"])
+	remediation, _, _ := unstructured.NestedString(policy.Object, "spec", "remediationAction")
+	assert.Equal(t, "enforce", remediation)
+
+	templates, _, _ := unstructured.NestedSlice(policy.Object, "spec", "policy-templates")
+	require.Len(t, templates, 1)
+	configPolicy := &unstructured.Unstructured{Object: templates[0].(map[string]any)["objectDefinition"].(map[string]any)}
+	assert.Equal(t, "ConfigurationPolicy", configPolicy.GetKind())
+	objectTemplates, _, _ := unstructured.NestedSlice(configPolicy.Object, "spec", "object-templates")
+	require.Len(t, objectTemplates, 1)
+	assert.Equal(t, "musthave", objectTemplates[0].(map[string]any)["complianceType"])
+	assert.Equal(t, mc.Object, objectTemplates[0].(map[string]any)["objectDefinition"])
+
+	binding := &unstructured.Unstructured{Object: documents[1]}
+	assert.Equal(t, "PlacementBinding", binding.GetKind())
+	placement, _, _ := unstructured.NestedString(binding.Object, "placementRef", "name")
+	assert.Equal(t, "virt-clusters", placement)
+	subjects, _, _ := unstructured.NestedSlice(binding.Object, "subjects")
+	assert.Equal(t, []any{map[string]any{
+		"apiGroup": "policy.open-cluster-management.io", "kind": "Policy", "name": "autopilot-swap-enable",
+	}}, subjects)
+}
+
+func TestACMPoliciesWithoutPlacement(t *testing.T) {
+	outputs := []pkgrender.RenderOutput{{Asset: "swap-enable", Status: "INCLUDED", Object: &unstructured.Unstructured{Object: map[string]any{}}}}
+
+	documents, err := acmPolicies(outputs, "policies", "inform", "")
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, "Policy", documents[0]["kind"])
+}
+
+func TestACMPoliciesNameLength(t *testing.T) {
+	outputs := []pkgrender.RenderOutput{{Asset: "metrics-exporter-scc-clusterrolebinding", Status: "INCLUDED", Object: &unstructured.Unstructured{Object: map[string]any{}}}}
+
+	_, err := acmPolicies(outputs, "virt-platform-policies", "inform", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use a shorter --policy-namespace")
+}
+
+// TestACMPoliciesFitCatalog keeps every asset name short enough for the default namespace
+func TestACMPoliciesFitCatalog(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	var outputs []pkgrender.RenderOutput
+	for _, asset := range registry.ListAssets(nil) {
+		outputs = append(outputs, pkgrender.RenderOutput{Asset: asset.Name, Status: "INCLUDED", Object: &unstructured.Unstructured{Object: map[string]any{}}})
+	}
+	_, err = acmPolicies(outputs, "policies", "inform", "")
+	assert.NoError(t, err)
+
+	rendered := pkgrender.BuildOutputs(registry.ListAssetsByReconcileOrder(), engine.NewRenderer(loader),
+		pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")), false)
+	documents, err := acmPolicies(rendered, "policies", "inform", "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, documents)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+// bootstrapFilePrefix starts every generated file name. openshift-install applies
+// manifests in file name order and reserves the 99_ prefix for user additions.
+const bootstrapFilePrefix = "99_virt-platform-autopilot"
+
+// installTimeGroups are the API groups served by the OpenShift release payload while
+// the cluster bootstraps. Everything else (the HCO itself, KubeDescheduler, MetalLB,
+// the observability stack) is installed later through OLM, so its CRDs do not exist yet.
+var installTimeGroups = map[string]bool{
+	"":                                  true,
+	"apps":                              true,
+	"rbac.authorization.k8s.io":         true,
+	"config.openshift.io":               true,
+	"machineconfiguration.openshift.io": true,
+	"security.openshift.io":             true,
+	"monitoring.coreos.com":             true,
+	"performance.openshift.io":          true,
+}
+
+var (
+	bootstrapHCOFile   string
+	bootstrapProfile   string
+	bootstrapOutputDir string
+)
+
+// clusterProfile is the --profile document: what the detectors would report on the
+// cluster being installed. Keys match the RenderContext field names, e.g.
+//
+//	topology: {cloudProvider: BareMetal, isBareMetal: true, masterCount: 3, workerCount: 2}
+//	hardware: {numaNodesPresent: true}
+//	fips: true
+type clusterProfile struct {
+	Hardware           *pkgcontext.HardwareContext           `json:"hardware,omitempty"`
+	Topology           *pkgcontext.TopologyContext           `json:"topology,omitempty"`
+	Proxy              *pkgcontext.ProxyContext              `json:"proxy,omitempty"`
+	FIPS               bool                                  `json:"fips,omitempty"`
+	Mirrors            []pkgcontext.ImageMirror              `json:"mirrors,omitempty"`
+	Storage            *pkgcontext.StorageContext            `json:"storage,omitempty"`
+	Network            *pkgcontext.NetworkContext            `json:"network,omitempty"`
+	Architecture       *pkgcontext.ArchitectureContext       `json:"architecture,omitempty"`
+	PerformanceProfile *pkgcontext.PerformanceProfileContext `json:"performanceProfile,omitempty"`
+	HugePages          *pkgcontext.HugePagesContext          `json:"hugePages,omitempty"`
+	Images             map[string]string                     `json:"images,omitempty"`
+}
+
+// bootstrapManifest is one file written by render bootstrap
+type bootstrapManifest struct {
+	File   string
+	Output pkgrender.RenderOutput
+}
+
+// newBootstrapCommand creates the render bootstrap subcommand
+func newBootstrapCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Render day-0 manifests for openshift-install",
+		Long: `Render platform assets into files for the openshift-install manifests/ directory,
+so a cluster comes up with its virtualization platform configuration already present.
+
+Nothing is read from a cluster: the HyperConverged comes from --hco-file and what the
+detectors would normally discover (topology, hardware, network, proxy, FIPS, ...) comes
+from the --profile file. Without a profile every detector reports nothing.
+
+Files are named 99_virt-platform-autopilot_NNN_<asset>.yaml in reconcile order and
+carry the managed-by label, so the controller adopts them once it is installed.
+Assets whose API is not served during installation (operator CRs installed through
+OLM) are skipped and listed; the controller creates them on day 1.
+
+Examples:
+  openshift-install create manifests --dir=install
+  virt-platform-autopilot render bootstrap --hco-file=hco.yaml \
+    --profile=baremetal-profile.yaml --output-dir=install/openshift
+  openshift-install create cluster --dir=install
+`,
+		RunE: runBootstrap,
+	}
+
+	cmd.Flags().StringVar(&bootstrapHCOFile, "hco-file", "", "Path to HyperConverged YAML file, or - for stdin (required)")
+	cmd.Flags().StringVar(&bootstrapProfile, "profile", "", "Path to a YAML cluster profile replacing runtime detection")
+	cmd.Flags().StringVar(&bootstrapOutputDir, "output-dir", "", "Directory the manifests are written to (required)")
+	_ = cmd.MarkFlagRequired("hco-file")
+	_ = cmd.MarkFlagRequired("output-dir")
+
+	return cmd
+}
+
+// runBootstrap executes the render bootstrap command
+func runBootstrap(cmd *cobra.Command, args []string) error {
+	var hco *unstructured.Unstructured
+	var err error
+	if bootstrapHCOFile == stdinPath {
+		hco, err = loadHCOFromReader(cmd.InOrStdin())
+	} else {
+		hco, err = loadHCOFromFile(bootstrapHCOFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load HCO: %w", err)
+	}
+
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	if bootstrapProfile != "" {
+		profile, err := loadClusterProfile(bootstrapProfile)
+		if err != nil {
+			return fmt.Errorf("failed to load profile: %w", err)
+		}
+		profile.apply(renderCtx)
+	}
+
+	cmd.SilenceUsage = true
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	outputs := pkgrender.BuildOutputs(registry.ListAssetsByReconcileOrder(), engine.NewRenderer(loader), renderCtx, false)
+	manifests, skipped, err := bootstrapManifests(outputs)
+	if err != nil {
+		return err
+	}
+
+	if err := writeBootstrapManifests(bootstrapOutputDir, manifests); err != nil {
+		return err
+	}
+	printBootstrapSummary(cmd.OutOrStdout(), manifests, skipped)
+	return nil
+}
+
+// loadClusterProfile reads a clusterProfile from a YAML or JSON file
+func loadClusterProfile(path string) (*clusterProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	profile := &clusterProfile{}
+	if err := yaml.UnmarshalStrict(data, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// apply replaces the detector results of renderCtx with the profile's values.
+// Sections the profile omits keep the empty defaults of NewRenderContext.
+func (p *clusterProfile) apply(renderCtx *pkgcontext.RenderContext) {
+	if p.Hardware != nil {
+		renderCtx.Hardware = p.Hardware
+	}
+	if p.Topology != nil {
+		renderCtx.Topology = p.Topology
+	}
+	if p.Proxy != nil {
+		renderCtx.Proxy = p.Proxy
+	}
+	renderCtx.FIPS = p.FIPS
+	// AddMirror keeps the most specific source first, as the detector does
+	for _, mirror := range p.Mirrors {
+		renderCtx.Mirrors.AddMirror(mirror.Source, mirror.Mirrors...)
+	}
+	if p.Storage != nil {
+		renderCtx.Storage = p.Storage
+	}
+	if p.Network != nil {
+		renderCtx.Network = p.Network
+	}
+	if p.Architecture != nil {
+		renderCtx.Architecture = p.Architecture
+	}
+	if p.PerformanceProfile != nil {
+		renderCtx.PerformanceProfile = p.PerformanceProfile
+	}
+	if p.HugePages != nil {
+		renderCtx.HugePages = p.HugePages
+	}
+	for name, image := range p.Images {
+		renderCtx.Images[name] = image
+	}
+}
+
+// bootstrapManifests numbers the included outputs in reconcile order and labels them
+// as managed. Outputs whose API group is not served at install time are returned as
+// skipped. A render error fails the whole run: a partial day-0 configuration is worse
+// than none, since the controller would not know the rest is missing.
+func bootstrapManifests(outputs []pkgrender.RenderOutput) ([]bootstrapManifest, []pkgrender.RenderOutput, error) {
+	var manifests []bootstrapManifest
+	var skipped []pkgrender.RenderOutput
+	var errs []string
+
+	for _, output := range outputs {
+		switch output.Status {
+		case "ERROR":
+			errs = append(errs, fmt.Sprintf("%s: %s", output.Asset, output.Message))
+			continue
+		case "INCLUDED":
+		default:
+			continue
+		}
+
+		gvk := output.Object.GroupVersionKind()
+		if !installTimeGroups[gvk.Group] {
+			output.Reason = engine.ReasonNotServedDuringInstallation
+			output.Message = fmt.Sprintf("%s/%s is not served during installation", gvk.GroupVersion(), gvk.Kind)
+			skipped = append(skipped, output)
+			continue
+		}
+
+		labels := output.Object.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[engine.ManagedByLabel] = engine.ManagedByValue
+		output.Object.SetLabels(labels)
+
+		manifests = append(manifests, bootstrapManifest{
+			File:   fmt.Sprintf("%s_%03d_%s.yaml", bootstrapFilePrefix, len(manifests), output.Asset),
+			Output: output,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("failed to render assets:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return manifests, skipped, nil
+}
+
+// writeBootstrapManifests writes each manifest to dir, creating it if needed
+func writeBootstrapManifests(dir string, manifests []bootstrapManifest) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for _, manifest := range manifests {
+		data, err := yaml.Marshal(manifest.Output.Object.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", manifest.Output.Asset, err)
+		}
+		header := fmt.Sprintf("# Asset: %s\n# Generated by virt-platform-autopilot render bootstrap\n", manifest.Output.Asset)
+		if err := os.WriteFile(filepath.Join(dir, manifest.File), append([]byte(header), data...), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", manifest.File, err)
+		}
+	}
+	return nil
+}
+
+// printBootstrapSummary lists the written files and the assets left for day 1
+func printBootstrapSummary(w io.Writer, manifests []bootstrapManifest, skipped []pkgrender.RenderOutput) {
+	for _, manifest := range manifests {
+		fmt.Fprintf(w, "wrote %s\n", manifest.File)
+	}
+	for _, output := range skipped {
+		fmt.Fprintf(w, "skipped %s: %s (applied by the controller after installation)\n", output.Asset, output.Message)
+	}
+	fmt.Fprintf(w, "%d manifests written, %d assets deferred to day 1\n", len(manifests), len(skipped))
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+const bootstrapHCOYAML = `apiVersion: hco.kubevirt.io/v1beta1
+kind: HyperConverged
+metadata:
+  name: kubevirt-hyperconverged
+  namespace: openshift-cnv
+  annotations:
+    platform.kubevirt.io/openshift: "true"
+`
+
+func TestLoadClusterProfile(t *testing.T) {
+	profileYAML := `topology:
+  cloudProvider: BareMetal
+  isBareMetal: true
+  masterCount: 3
+hardware:
+  pciDevicesPresent: true
+fips: true
+mirrors:
+  - source: quay.io/kubevirt
+    mirrors: [mirror.example.com/kubevirt]
+  - source: quay.io
+    mirrors: [mirror.example.com]
+images:
+  kubevirt-metrics-exporter: quay.io/kubevirt/metrics-exporter:v1
+`
+	path := filepath.Join(t.TempDir(), "profile.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profileYAML), 0644))
+
+	profile, err := loadClusterProfile(path)
+	require.NoError(t, err)
+
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace))
+	profile.apply(renderCtx)
+
+	assert.True(t, renderCtx.Topology.IsBareMetal)
+	assert.Equal(t, 3, renderCtx.Topology.MasterCount)
+	assert.True(t, renderCtx.Hardware.PCIDevicesPresent)
+	assert.True(t, renderCtx.FIPS)
+	assert.Equal(t, "quay.io/kubevirt/metrics-exporter:v1", renderCtx.Images["kubevirt-metrics-exporter"])
+	// The most specific mirror source wins regardless of file order
+	assert.Equal(t, "mirror.example.com/kubevirt/virt:v1", renderCtx.Mirrors.Rewrite("quay.io/kubevirt/virt:v1"))
+	// Sections the profile omits keep their empty defaults
+	require.NotNil(t, renderCtx.Storage)
+	assert.False(t, renderCtx.Storage.ODFPresent)
+}
+
+func TestLoadClusterProfileUnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("topolgy:\n  isBareMetal: true\n"), 0644))
+
+	_, err := loadClusterProfile(path)
+	assert.Error(t, err)
+}
+
+func TestCheckConditionsHardwareFromProfile(t *testing.T) {
+	asset := &assets.AssetMetadata{
+		Name:       "test",
+		Conditions: []assets.AssetCondition{{Type: assets.ConditionTypeHardwareDetection, Detector: "numaNodesPresent"}},
+	}
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace))
+	assert.False(t, pkgrender.CheckConditions(asset, renderCtx))
+
+	renderCtx.Hardware = &pkgcontext.HardwareContext{NUMANodesPresent: true}
+	assert.True(t, pkgrender.CheckConditions(asset, renderCtx))
+}
+
+func TestBootstrapManifests(t *testing.T) {
+	object := func(apiVersion, kind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetName(name)
+		return obj
+	}
+
+	outputs := []pkgrender.RenderOutput{
+		{Asset: "swap-enable", Status: "INCLUDED", Object: object("machineconfiguration.openshift.io/v1", "MachineConfig", "90-swap")},
+		{Asset: "descheduler", Status: "INCLUDED", Object: object("operator.openshift.io/v1", "KubeDescheduler", "cluster")},
+		{Asset: "metallb", Status: "EXCLUDED", Reason: engine.ReasonConditionsNotMet},
+		{Asset: "monitoring-rbac", Status: "INCLUDED", Object: object("rbac.authorization.k8s.io/v1", "ClusterRole", "reader")},
+	}
+
+	manifests, skipped, err := bootstrapManifests(outputs)
+	require.NoError(t, err)
+
+	require.Len(t, manifests, 2)
+	assert.Equal(t, "99_virt-platform-autopilot_000_swap-enable.yaml", manifests[0].File)
+	assert.Equal(t, "99_virt-platform-autopilot_001_monitoring-rbac.yaml", manifests[1].File)
+	assert.Equal(t, engine.ManagedByValue, manifests[0].Output.Object.GetLabels()[engine.ManagedByLabel])
+
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "descheduler", skipped[0].Asset)
+	assert.Equal(t, engine.ReasonNotServedDuringInstallation, skipped[0].Reason)
+	assert.Contains(t, skipped[0].Message, "operator.openshift.io/v1/KubeDescheduler")
+
+	outputs = append(outputs, pkgrender.RenderOutput{Asset: "broken", Status: "ERROR", Reason: engine.ReasonRenderFailed, Message: "template: bad"})
+	_, _, err = bootstrapManifests(outputs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken: template: bad")
+}
+
+func TestRunBootstrap(t *testing.T) {
+	tmpDir := t.TempDir()
+	hcoPath := filepath.Join(tmpDir, "hco.yaml")
+	require.NoError(t, os.WriteFile(hcoPath, []byte(bootstrapHCOYAML), 0644))
+	profilePath := filepath.Join(tmpDir, "profile.yaml")
+	require.NoError(t, os.WriteFile(profilePath, []byte("hardware:\n  pciDevicesPresent: true\n"), 0644))
+	outputDir := filepath.Join(tmpDir, "openshift")
+
+	var stdout bytes.Buffer
+	cmd := NewRenderCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"bootstrap", "--hco-file=" + hcoPath, "--profile=" + profilePath, "--output-dir=" + outputDir})
+	require.NoError(t, cmd.Execute())
+
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+		data, err := os.ReadFile(filepath.Join(outputDir, entry.Name()))
+		require.NoError(t, err)
+
+		obj := &unstructured.Unstructured{}
+		require.NoError(t, yaml.Unmarshal(data, &obj.Object))
+		assert.True(t, installTimeGroups[obj.GroupVersionKind().Group], "%s: %s is not served at install time", entry.Name(), obj.GetAPIVersion())
+		assert.NotEqual(t, pkgcontext.HCOKind, obj.GetKind())
+		assert.True(t, engine.HasManagedByLabel(obj), "%s lacks the managed-by label", entry.Name())
+	}
+	assert.True(t, slices.IsSorted(files))
+	// The profile's PCI devices enable the hardware-gated MachineConfig
+	assert.True(t, slices.ContainsFunc(files, func(f string) bool { return strings.HasSuffix(f, "_pci-passthrough.yaml") }), files)
+	assert.Contains(t, stdout.String(), "assets deferred to day 1")
+}
+
+func TestRunBootstrapRequiresOutputDir(t *testing.T) {
+	cmd := NewRenderCommand()
+	cmd.SetArgs([]string{"bootstrap", "--hco-file=hco.yaml"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "output-dir")
+}
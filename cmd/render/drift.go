@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+// markDrift compares every included output against the live cluster and sets
+// Drifted where the next reconcile would change the object.
+//
+// It mirrors the controller's view of the effective desired state: unmanaged and
+// paused objects are skipped, and the live object's JSON patch and ignore-fields
+// annotations and the HCO's overrides ConfigMap are honoured so intentional
+// customizations don't count as drift.
+// Missing objects (or missing CRDs) count as drift since the controller would create them.
+// Fields the previous rendering set and this one no longer does are listed in DroppedFields.
+func markDrift(ctx context.Context, c client.Client, outputs []pkgrender.RenderOutput, patches map[string]overrides.AssetPatch) error {
+	detector := engine.NewDriftDetector(c)
+
+	for i := range outputs {
+		output := &outputs[i]
+		if output.Status != "INCLUDED" || output.Object == nil {
+			continue
+		}
+
+		desired := output.Object.DeepCopy()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		err := c.Get(ctx, client.ObjectKeyFromObject(desired), live)
+		if errors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			output.Drifted = true
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s %s: %w", desired.GetKind(), desired.GetName(), err)
+		}
+
+		if _, delegated, _ := overrides.DelegatedTo(live); delegated || overrides.IsUnmanaged(live) || overrides.IsPaused(live) {
+			continue
+		}
+
+		rendered := engine.RenderedFields(desired)
+		output.DroppedFields = engine.DroppedFields(live, rendered)
+
+		if patch, ok := patches[output.Asset]; ok && !patch.Expired(time.Now()) && live.GetAnnotations()[overrides.PatchAnnotation] == "" {
+			// Applied on creation too, like the controller does; a bad patch is skipped
+			_ = overrides.ApplyAssetPatch(desired, patch)
+		}
+
+		if patch := live.GetAnnotations()[overrides.PatchAnnotation]; patch != "" {
+			annotations := desired.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[overrides.PatchAnnotation] = patch
+			if patchType, ok := live.GetAnnotations()[overrides.PatchTypeAnnotation]; ok {
+				annotations[overrides.PatchTypeAnnotation] = patchType
+			}
+			desired.SetAnnotations(annotations)
+			// An invalid patch is ignored by the controller too
+			if overrides.ValidateAnnotations(desired) == nil {
+				_, _ = overrides.ApplyPatch(desired)
+			}
+		}
+
+		desired, err = overrides.MaskIgnoredFields(desired, live)
+		if err != nil {
+			return fmt.Errorf("failed to mask ignored fields for %s %s: %w", live.GetKind(), live.GetName(), err)
+		}
+
+		labels := desired.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[engine.ManagedByLabel] = engine.ManagedByValue
+		desired.SetLabels(labels)
+		if _, recorded := live.GetAnnotations()[engine.RenderedFieldsAnnotation]; recorded {
+			engine.SetRenderedFields(desired, rendered)
+		}
+		if _, recorded := live.GetAnnotations()[engine.DesiredHashAnnotation]; recorded {
+			engine.SetDesiredHash(desired)
+		}
+
+		drifted, err := detector.DetectDrift(ctx, desired, live)
+		if err != nil {
+			return fmt.Errorf("drift detection failed for %s %s: %w", desired.GetKind(), desired.GetName(), err)
+		}
+		output.Drifted = drifted
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+func newConfigMap(name, value string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	_ = unstructured.SetNestedStringMap(obj.Object, map[string]string{"key": value}, "data")
+	return obj
+}
+
+func TestMarkDrift(t *testing.T) {
+	managed := func(obj *unstructured.Unstructured) *unstructured.Unstructured {
+		obj.SetLabels(map[string]string{engine.ManagedByLabel: engine.ManagedByValue})
+		return obj
+	}
+
+	inSync := managed(newConfigMap("in-sync", "desired"))
+	changed := managed(newConfigMap("changed", "edited"))
+	unmanaged := managed(newConfigMap("unmanaged", "edited"))
+	unmanaged.SetAnnotations(map[string]string{overrides.AnnotationMode: overrides.ModeUnmanaged})
+
+	c := fake.NewClientBuilder().WithObjects(inSync, changed, unmanaged).Build()
+
+	outputs := []pkgrender.RenderOutput{
+		{Asset: "in-sync", Status: "INCLUDED", Object: newConfigMap("in-sync", "desired")},
+		{Asset: "changed", Status: "INCLUDED", Object: newConfigMap("changed", "desired")},
+		{Asset: "unmanaged", Status: "INCLUDED", Object: newConfigMap("unmanaged", "desired")},
+		{Asset: "missing", Status: "INCLUDED", Object: newConfigMap("missing", "desired")},
+		{Asset: "excluded", Status: "EXCLUDED"},
+	}
+
+	require.NoError(t, markDrift(context.Background(), c, outputs, nil))
+
+	drifted := map[string]bool{}
+	for _, output := range outputs {
+		drifted[output.Asset] = output.Drifted
+	}
+	assert.Equal(t, map[string]bool{
+		"in-sync":   false,
+		"changed":   true,
+		"unmanaged": false,
+		"missing":   true,
+		"excluded":  false,
+	}, drifted)
+}
+
+func TestMarkDriftDroppedFields(t *testing.T) {
+	live := newConfigMap("cfg", "desired")
+	live.SetLabels(map[string]string{engine.ManagedByLabel: engine.ManagedByValue})
+	engine.SetRenderedFields(live, []string{"/data/key", "/data/legacy"})
+	c := fake.NewClientBuilder().WithObjects(live).Build()
+
+	outputs := []pkgrender.RenderOutput{
+		{Asset: "cfg", Status: "INCLUDED", Object: newConfigMap("cfg", "desired")},
+	}
+	require.NoError(t, markDrift(context.Background(), c, outputs, nil))
+	assert.Equal(t, []string{"/data/legacy"}, outputs[0].DroppedFields)
+
+	var buf bytes.Buffer
+	require.NoError(t, pkgrender.WriteYAML(&buf, outputs))
+	assert.Contains(t, buf.String(), "# Dropped fields: /data/legacy")
+}
@@ -19,28 +19,43 @@ package render
 import (
 	"context"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
 )
 
+// stdinPath is the --hco-file value that reads the HCO from stdin
+const stdinPath = "-"
+
 var (
 	kubeconfig   string
 	hcoFile      string
 	assetFilter  string
+	onlyComps    []string
+	skipComps    []string
 	showExcluded bool
 	outputFormat string
+	failOn       []string
+	summaryFile  string
+	imageMapping string
+	imageStreams string
+	sets         []string
+	setAnnots    []string
 )
 
 // NewRenderCommand creates the render subcommand
@@ -67,20 +82,85 @@ Examples:
   # Offline mode: provide HCO as input
   virt-platform-autopilot render --hco-file=hco.yaml
 
+  # Offline mode: read HCO from stdin
+  oc get hco -n openshift-cnv kubevirt-hyperconverged -o yaml | virt-platform-autopilot render --hco-file=-
+
   # Show excluded assets with reasons
   virt-platform-autopilot render --show-excluded --hco-file=hco.yaml
 
+  # GitOps: one pipeline stage per component
+  virt-platform-autopilot render --hco-file=hco.yaml --only-component=MachineConfig,KubeletConfig > node-config.yaml
+  virt-platform-autopilot render --hco-file=hco.yaml --skip-component=MachineConfig,KubeletConfig > platform.yaml
+
+  # What if: layer field and annotation overrides over the HCO (file or cluster)
+  virt-platform-autopilot render --kubeconfig=/path/to/kubeconfig --output=status \
+    --set spec.featureGates.deployKubeSecondaryDNS=true \
+    --set-annotation platform.kubevirt.io/enable-metallb=true
+
   # JSON output
   virt-platform-autopilot render --output=json --hco-file=hco.yaml
+
+  # ACM: one Policy per included asset, bound to a hub Placement for rollout to spoke clusters
+  virt-platform-autopilot render --hco-file=hco.yaml --output=acm-policy \
+    --policy-namespace=virt-policies --policy-remediation=enforce --policy-placement=virt-clusters
+
+  # CI smoke test: fail on render errors and write counts for later steps
+  virt-platform-autopilot render --hco-file=hco.yaml --fail-on=error --summary-file=summary.json
+
+  # Fail if the cluster differs from what would be applied
+  virt-platform-autopilot render --kubeconfig=/path/to/kubeconfig --fail-on=drift
+
+  # Day-0: write manifests for openshift-install (see render bootstrap --help)
+  virt-platform-autopilot render bootstrap --hco-file=hco.yaml --profile=profile.yaml --output-dir=install/openshift
+
+  # Pin image tags to digests for a disconnected mirror; the JSON "images" field
+  # of each asset lists every source reference and its digest
+  virt-platform-autopilot render --hco-file=hco.yaml --image-mapping=digests.yaml --output=json
+  virt-platform-autopilot render --kubeconfig=/path/to/kubeconfig --image-stream-namespace=openshift-cnv
+
+Exit codes:
+  0  success (no --fail-on condition met)
+  1  invalid flags or input, cluster unreachable
+  2  --fail-on=error and at least one asset failed to render
+  3  --fail-on=drift and at least one live object differs or is missing
+  4  --fail-on=excluded and at least one asset was excluded or filtered
+When several conditions are met the lowest of codes 2-4 is returned.
 `,
 		RunE: runRender,
 	}
 
 	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (for cluster mode)")
-	cmd.Flags().StringVar(&hcoFile, "hco-file", "", "Path to HyperConverged YAML file (for offline mode)")
+	cmd.Flags().StringVar(&hcoFile, "hco-file", "", "Path to HyperConverged YAML file, or - for stdin (for offline mode)")
 	cmd.Flags().StringVar(&assetFilter, "asset", "", "Render only this specific asset")
+	cmd.Flags().StringSliceVar(&onlyComps, "only-component", nil,
+		"Render only the assets of these components, e.g. MachineConfig (comma-separated or repeated)")
+	cmd.Flags().StringSliceVar(&skipComps, "skip-component", nil,
+		"Render every asset except those of these components (comma-separated or repeated)")
 	cmd.Flags().BoolVar(&showExcluded, "show-excluded", false, "Include excluded/filtered assets in output")
-	cmd.Flags().StringVar(&outputFormat, "output", "yaml", "Output format: yaml, json, or status")
+	cmd.Flags().StringVar(&outputFormat, "output", "yaml", "Output format: yaml, json, status, or acm-policy")
+	cmd.Flags().StringSliceVar(&failOn, "fail-on", nil,
+		"Exit non-zero when any of these conditions is met: error, excluded, drift (drift requires --kubeconfig)")
+	cmd.Flags().StringVar(&summaryFile, "summary-file", "", "Write a JSON summary of per-status counts to this path")
+	cmd.Flags().StringVar(&imageMapping, "image-mapping", "",
+		"YAML or JSON file mapping image references to digest references; matching image fields are pinned")
+	cmd.Flags().StringVar(&imageStreams, "image-stream-namespace", "",
+		"Pin image references tracked by an ImageStream in this namespace (requires --kubeconfig)")
+	cmd.Flags().StringVar(&policyNamespace, "policy-namespace", "policies",
+		"Hub namespace of the policies written by --output=acm-policy")
+	cmd.Flags().StringVar(&policyRemediation, "policy-remediation", "inform",
+		"remediationAction of the policies written by --output=acm-policy: inform or enforce")
+	cmd.Flags().StringVar(&policyPlacement, "policy-placement", "",
+		"Placement the policies written by --output=acm-policy are bound to with a PlacementBinding; none when empty")
+	cmd.Flags().StringArrayVar(&sets, "set", nil,
+		"Override an HCO field before rendering, e.g. spec.featureGates.deployKubeSecondaryDNS=true "+
+			"(value parsed as YAML, null removes the field; repeatable)")
+	cmd.Flags().StringArrayVar(&setAnnots, "set-annotation", nil,
+		"Set an HCO annotation before rendering, e.g. platform.kubevirt.io/enable-metallb=true (repeatable)")
+
+	cmd.AddCommand(newBootstrapCommand())
+
+	_ = cmd.RegisterFlagCompletionFunc("asset", completion.AssetNames)
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values("yaml", "json", "status", "acm-policy"))
 
 	return cmd
 }
@@ -97,6 +177,30 @@ func runRender(cmd *cobra.Command, args []string) error {
 	if kubeconfig != "" && hcoFile != "" {
 		return fmt.Errorf("--kubeconfig and --hco-file are mutually exclusive")
 	}
+	if len(onlyComps) > 0 && len(skipComps) > 0 {
+		return fmt.Errorf("--only-component and --skip-component are mutually exclusive")
+	}
+	if assetFilter != "" && len(onlyComps)+len(skipComps) > 0 {
+		return fmt.Errorf("--asset cannot be combined with --only-component or --skip-component")
+	}
+	if err := validateFailOn(failOn); err != nil {
+		return err
+	}
+	checkDrift := slices.Contains(failOn, FailOnDrift)
+	if checkDrift && kubeconfig == "" {
+		return fmt.Errorf("--fail-on=drift requires --kubeconfig")
+	}
+	if imageStreams != "" && kubeconfig == "" {
+		return fmt.Errorf("--image-stream-namespace requires --kubeconfig")
+	}
+	if outputFormat == outputACMPolicy {
+		if err := validatePolicyFlags(); err != nil {
+			return err
+		}
+	}
+
+	// Flags are valid: from here on failures are runtime results, not usage errors
+	cmd.SilenceUsage = true
 
 	loader := assets.NewLoader()
 	registry, err := assets.NewRegistry(loader)
@@ -107,18 +211,40 @@ func runRender(cmd *cobra.Command, args []string) error {
 	renderer := engine.NewRenderer(loader)
 
 	var hco *unstructured.Unstructured
-	if hcoFile != "" {
+	var k8sClient client.Client
+	if hcoFile == stdinPath {
+		hco, err = loadHCOFromReader(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to load HCO from stdin: %w", err)
+		}
+	} else if hcoFile != "" {
 		hco, err = loadHCOFromFile(hcoFile)
 		if err != nil {
 			return fmt.Errorf("failed to load HCO from file: %w", err)
 		}
 	} else {
-		hco, err = loadHCOFromCluster(ctx, kubeconfig)
+		k8sClient, err = cluster.NewClient(kubeconfig)
+		if err != nil {
+			return fmt.Errorf("failed to connect to cluster: %w", err)
+		}
+		hco, err = loadHCOFromCluster(ctx, k8sClient)
 		if err != nil {
 			return fmt.Errorf("failed to load HCO from cluster: %w", err)
 		}
 	}
 
+	if err := applyOverrides(hco, sets, setAnnots); err != nil {
+		return err
+	}
+
+	resolver, err := buildImageResolver(k8sClient)
+	if err != nil {
+		return err
+	}
+	if resolver != nil {
+		renderer.SetImageResolver(resolver)
+	}
+
 	renderCtx := pkgcontext.NewRenderContext(hco)
 
 	var assetsToRender []assets.AssetMetadata
@@ -129,12 +255,130 @@ func runRender(cmd *cobra.Command, args []string) error {
 		}
 		assetsToRender = []assets.AssetMetadata{*asset}
 	} else {
-		assetsToRender = registry.ListAssetsByReconcileOrder()
+		assetsToRender, err = filterComponents(registry.ListAssetsByReconcileOrder(), onlyComps, skipComps)
+		if err != nil {
+			return err
+		}
 	}
 
-	outputs := pkgrender.BuildOutputs(assetsToRender, renderer, renderCtx, showExcluded)
+	// Always build excluded outputs so the summary and --fail-on see them;
+	// they are dropped from the printed output below unless --show-excluded is set.
+	outputs := pkgrender.BuildOutputs(assetsToRender, renderer, renderCtx, true)
 
-	return writeOutput(outputs, outputFormat)
+	if checkDrift {
+		// Invalid entries are dropped, as the controller does
+		patches, err := overrides.LoadOverridePatches(ctx, k8sClient, hco)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		}
+		if err := markDrift(ctx, k8sClient, outputs, patches); err != nil {
+			return err
+		}
+	}
+
+	summary := summarize(outputs)
+	summary.DriftChecked = checkDrift
+	failErr := evaluateFailOn(&summary, failOn)
+
+	if err := writeOutput(visibleOutputs(outputs, showExcluded), outputFormat); err != nil {
+		return err
+	}
+	warnDeprecated(cmd.ErrOrStderr(), outputs)
+	warnTemplates(cmd.ErrOrStderr(), outputs)
+
+	if summaryFile != "" {
+		if err := writeSummaryFile(summaryFile, summary); err != nil {
+			return err
+		}
+	}
+
+	return failErr
+}
+
+// filterComponents keeps the assets of the only components, or drops those of the skip
+// components. Names are matched exactly; an unknown one is an error, so a typo in a
+// pipeline does not silently render everything or nothing.
+func filterComponents(all []assets.AssetMetadata, only, skip []string) ([]assets.AssetMetadata, error) {
+	if len(only) == 0 && len(skip) == 0 {
+		return all, nil
+	}
+
+	known := make(map[string]bool)
+	for _, asset := range all {
+		known[asset.Component] = true
+	}
+	selected := make(map[string]bool)
+	for _, component := range append(slices.Clone(only), skip...) {
+		if !known[component] {
+			components := slices.Sorted(maps.Keys(known))
+			return nil, fmt.Errorf("unknown component %q, expected one of: %s", component, strings.Join(components, ", "))
+		}
+		selected[component] = true
+	}
+
+	keep := len(only) > 0
+	filtered := make([]assets.AssetMetadata, 0, len(all))
+	for _, asset := range all {
+		if selected[asset.Component] == keep {
+			filtered = append(filtered, asset)
+		}
+	}
+	return filtered, nil
+}
+
+// buildImageResolver combines the --image-mapping file and the --image-stream-namespace
+// lookup, in that order, or returns nil when neither is set
+func buildImageResolver(k8sClient client.Reader) (engine.ImageResolver, error) {
+	var resolvers engine.ImageResolvers
+	if imageMapping != "" {
+		mapping, err := engine.LoadImageMapping(imageMapping)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, mapping)
+	}
+	if imageStreams != "" {
+		resolvers = append(resolvers, engine.NewImageStreamResolver(k8sClient, imageStreams))
+	}
+	if len(resolvers) == 0 {
+		return nil, nil
+	}
+	return resolvers, nil
+}
+
+// warnDeprecated prints a warning for every included asset that is deprecated.
+// It goes to stderr so YAML and JSON on stdout stay machine-readable.
+func warnDeprecated(w io.Writer, outputs []pkgrender.RenderOutput) {
+	for _, output := range outputs {
+		if output.Status == "INCLUDED" && output.Deprecated != "" {
+			fmt.Fprintf(w, "Warning: %s\n", output.Deprecated)
+		}
+	}
+}
+
+// warnTemplates prints the warnings templates reported, whether or not the asset was
+// included: a warning often explains why it was skipped.
+func warnTemplates(w io.Writer, outputs []pkgrender.RenderOutput) {
+	for _, output := range outputs {
+		for _, warning := range output.Warnings {
+			fmt.Fprintf(w, "Warning: asset %s: %s\n", output.Asset, warning)
+		}
+	}
+}
+
+// visibleOutputs drops excluded and filtered outputs unless showExcluded is set
+func visibleOutputs(outputs []pkgrender.RenderOutput, showExcluded bool) []pkgrender.RenderOutput {
+	if showExcluded {
+		return outputs
+	}
+	visible := make([]pkgrender.RenderOutput, 0, len(outputs))
+	for _, output := range outputs {
+		if output.Status == "EXCLUDED" || output.Status == "FILTERED" {
+			continue
+		}
+		visible = append(visible, output)
+	}
+	return visible
 }
 
 // loadHCOFromFile loads HCO from a YAML file
@@ -144,11 +388,42 @@ func loadHCOFromFile(path string) (*unstructured.Unstructured, error) {
 		return nil, err
 	}
 
+	return decodeHCO(data)
+}
+
+// loadHCOFromReader loads HCO from a YAML stream such as stdin
+func loadHCOFromReader(r io.Reader) (*unstructured.Unstructured, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, fmt.Errorf("no input received")
+	}
+
+	return decodeHCO(data)
+}
+
+// decodeHCO parses a HyperConverged object.
+// A List (as printed by `oc get hco -o yaml` without a name) is accepted and
+// its first item is used, matching cluster mode.
+func decodeHCO(data []byte) (*unstructured.Unstructured, error) {
 	hco := &unstructured.Unstructured{}
 	if err := yaml.Unmarshal(data, hco); err != nil {
 		return nil, err
 	}
 
+	if hco.IsList() {
+		list, err := hco.ToList()
+		if err != nil {
+			return nil, err
+		}
+		if len(list.Items) == 0 {
+			return nil, fmt.Errorf("no HyperConverged resources found in list")
+		}
+		hco = &list.Items[0]
+	}
+
 	if hco.GetKind() != "HyperConverged" {
 		return nil, fmt.Errorf("expected kind HyperConverged, got %s", hco.GetKind())
 	}
@@ -157,24 +432,7 @@ func loadHCOFromFile(path string) (*unstructured.Unstructured, error) {
 }
 
 // loadHCOFromCluster loads HCO from the cluster
-func loadHCOFromCluster(ctx context.Context, kubeconfigPath string) (*unstructured.Unstructured, error) {
-	var config *rest.Config
-	var err error
-
-	if kubeconfigPath != "" {
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
-	} else {
-		config, err = rest.InClusterConfig()
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to build config: %w", err)
-	}
-
-	k8sClient, err := client.New(config, client.Options{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
-	}
-
+func loadHCOFromCluster(ctx context.Context, k8sClient client.Client) (*unstructured.Unstructured, error) {
 	hcoList := &unstructured.UnstructuredList{}
 	hcoList.SetGroupVersionKind(pkgcontext.HCOGVK)
 	hcoList.SetAPIVersion("hco.kubevirt.io/v1")
@@ -199,6 +457,8 @@ func writeOutput(outputs []pkgrender.RenderOutput, format string) error {
 		return pkgrender.WriteJSON(os.Stdout, outputs)
 	case "status":
 		return writeStatusOutput(outputs)
+	case outputACMPolicy:
+		return writeACMPolicies(os.Stdout, outputs)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -210,7 +470,10 @@ func writeStatusOutput(outputs []pkgrender.RenderOutput) error {
 	fmt.Println(strings.Repeat("-", 100))
 
 	for _, output := range outputs {
-		reason := output.Reason
+		reason := string(output.Reason)
+		if reason == "" && output.Deprecated != "" {
+			reason = "deprecated"
+		}
 		if reason == "" {
 			reason = "-"
 		}
@@ -221,22 +484,11 @@ func writeStatusOutput(outputs []pkgrender.RenderOutput) error {
 			truncate(reason, 35))
 	}
 
-	included, excluded, filtered, errors := 0, 0, 0, 0
-	for _, output := range outputs {
-		switch output.Status {
-		case "INCLUDED":
-			included++
-		case "EXCLUDED":
-			excluded++
-		case "FILTERED":
-			filtered++
-		case "ERROR":
-			errors++
-		}
-	}
+	summary := summarize(outputs)
 
 	fmt.Println(strings.Repeat("-", 100))
-	fmt.Printf("Summary: %d included, %d excluded, %d filtered, %d errors\n", included, excluded, filtered, errors)
+	fmt.Printf("Summary: %d included, %d excluded, %d filtered, %d errors, %d deprecated\n",
+		summary.Included, summary.Excluded, summary.Filtered, summary.Errors, summary.Deprecated)
 
 	return nil
 }
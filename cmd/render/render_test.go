@@ -18,6 +18,7 @@ package render
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,9 +26,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
 	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
 )
 
@@ -79,6 +82,50 @@ func TestLoadHCOFromFileNotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestLoadHCOFromReader(t *testing.T) {
+	hcoYAML := `apiVersion: hco.kubevirt.io/v1
+kind: HyperConverged
+metadata:
+  name: kubevirt-hyperconverged
+  namespace: openshift-cnv
+`
+	listYAML := `apiVersion: v1
+kind: List
+items:
+- apiVersion: hco.kubevirt.io/v1
+  kind: HyperConverged
+  metadata:
+    name: kubevirt-hyperconverged
+    namespace: openshift-cnv
+`
+
+	tests := []struct {
+		name     string
+		input    string
+		errorMsg string
+	}{
+		{name: "single object", input: hcoYAML},
+		{name: "list from oc get", input: listYAML},
+		{name: "empty input", input: "  \n", errorMsg: "no input received"},
+		{name: "empty list", input: "apiVersion: v1\nkind: List\nitems: []\n", errorMsg: "no HyperConverged resources found"},
+		{name: "wrong kind", input: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n", errorMsg: "expected kind HyperConverged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco, err := loadHCOFromReader(strings.NewReader(tt.input))
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "HyperConverged", hco.GetKind())
+			assert.Equal(t, "kubevirt-hyperconverged", hco.GetName())
+		})
+	}
+}
+
 func TestCheckConditions(t *testing.T) {
 	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
 	hco.SetAnnotations(map[string]string{
@@ -129,7 +176,7 @@ func TestCheckConditions(t *testing.T) {
 			shouldPass: false,
 		},
 		{
-			name: "hardware detection always fails",
+			name: "hardware detection fails when nothing is detected",
 			asset: &assets.AssetMetadata{
 				Name: "test",
 				Conditions: []assets.AssetCondition{
@@ -165,7 +212,8 @@ func TestWriteYAMLOutput(t *testing.T) {
 			Path:      "test/excluded.yaml",
 			Component: "ExcludedComponent",
 			Status:    "EXCLUDED",
-			Reason:    "Conditions not met",
+			Reason:    engine.ReasonTemplateSkipped,
+			Message:   "MTV is not installed",
 		},
 	}
 
@@ -188,7 +236,8 @@ func TestWriteYAMLOutput(t *testing.T) {
 	assert.Contains(t, output, "# Asset: test-asset")
 	assert.Contains(t, output, "# Status: INCLUDED")
 	assert.Contains(t, output, "# Asset: excluded-asset")
-	assert.Contains(t, output, "# Reason: Conditions not met")
+	assert.Contains(t, output, "# Reason: TemplateSkipped")
+	assert.Contains(t, output, "# Message: MTV is not installed")
 	assert.Contains(t, output, "---")
 }
 
@@ -233,19 +282,20 @@ func TestWriteStatusOutput(t *testing.T) {
 			Asset:     "excluded-asset",
 			Component: "Component2",
 			Status:    "EXCLUDED",
-			Reason:    "Conditions not met",
+			Reason:    engine.ReasonConditionsNotMet,
 		},
 		{
 			Asset:     "filtered-asset",
 			Component: "Component3",
 			Status:    "FILTERED",
-			Reason:    "Root exclusion",
+			Reason:    engine.ReasonDisabledResources,
 		},
 		{
 			Asset:     "error-asset",
 			Component: "Component4",
 			Status:    "ERROR",
-			Reason:    "Parse error",
+			Reason:    engine.ReasonRenderFailed,
+			Message:   "Parse error",
 		},
 	}
 
@@ -269,6 +319,7 @@ func TestWriteStatusOutput(t *testing.T) {
 	assert.Contains(t, output, "STATUS")
 	assert.Contains(t, output, "COMPONENT")
 	assert.Contains(t, output, "REASON")
+	assert.Contains(t, output, "ConditionsNotMet")
 
 	// Verify summary
 	assert.Contains(t, output, "Summary:")
@@ -312,8 +363,12 @@ func TestRenderCommandFlags(t *testing.T) {
 	assert.NotNil(t, flags.Lookup("kubeconfig"))
 	assert.NotNil(t, flags.Lookup("hco-file"))
 	assert.NotNil(t, flags.Lookup("asset"))
+	assert.NotNil(t, flags.Lookup("only-component"))
+	assert.NotNil(t, flags.Lookup("skip-component"))
 	assert.NotNil(t, flags.Lookup("show-excluded"))
 	assert.NotNil(t, flags.Lookup("output"))
+	assert.NotNil(t, flags.Lookup("set"))
+	assert.NotNil(t, flags.Lookup("set-annotation"))
 }
 
 func TestRunRenderValidation(t *testing.T) {
@@ -333,6 +388,7 @@ metadata:
 	tests := []struct {
 		name        string
 		args        []string
+		stdin       string
 		expectError bool
 		errorMsg    string
 	}{
@@ -353,12 +409,49 @@ metadata:
 			args:        []string{"--hco-file=" + hcoPath, "--output=status"},
 			expectError: false,
 		},
+		{
+			name:        "hco from stdin",
+			args:        []string{"--hco-file=-", "--output=status"},
+			stdin:       hcoYAML,
+			expectError: false,
+		},
+		{
+			name:        "invalid policy remediation",
+			args:        []string{"--hco-file=" + hcoPath, "--output=acm-policy", "--policy-remediation=fix"},
+			expectError: true,
+			errorMsg:    `invalid --policy-remediation "fix"`,
+		},
+		{
+			name:        "only and skip components",
+			args:        []string{"--hco-file=" + hcoPath, "--only-component=MachineConfig", "--skip-component=KubeletConfig"},
+			expectError: true,
+			errorMsg:    "--only-component and --skip-component are mutually exclusive",
+		},
+		{
+			name:        "asset and component filter",
+			args:        []string{"--hco-file=" + hcoPath, "--asset=swap-enable", "--skip-component=KubeletConfig"},
+			expectError: true,
+			errorMsg:    "--asset cannot be combined",
+		},
+		{
+			name:        "unknown component",
+			args:        []string{"--hco-file=" + hcoPath, "--output=status", "--only-component=MachineConfigs"},
+			expectError: true,
+			errorMsg:    `unknown component "MachineConfigs"`,
+		},
+		{
+			name:        "empty stdin",
+			args:        []string{"--hco-file=-", "--output=status"},
+			expectError: true,
+			errorMsg:    "failed to load HCO from stdin",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cmd := NewRenderCommand()
 			cmd.SetArgs(tt.args)
+			cmd.SetIn(strings.NewReader(tt.stdin))
 
 			err := cmd.Execute()
 
@@ -449,3 +542,152 @@ func TestRenderOutputFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteYAMLOutputImages(t *testing.T) {
+	outputs := []pkgrender.RenderOutput{{
+		Asset:  "metrics-exporter",
+		Status: "INCLUDED",
+		Images: []engine.PinnedImage{
+			{Path: "spec.template.spec.containers[0].image", Source: "quay.io/org/img:v1", Digest: "quay.io/org/img@sha256:abc"},
+			{Path: "spec.template.spec.initContainers[0].image", Source: "quay.io/org/init:v1"},
+		},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, pkgrender.WriteYAML(&buf, outputs))
+	assert.Contains(t, buf.String(), "# Image: quay.io/org/img:v1 -> quay.io/org/img@sha256:abc\n")
+	assert.Contains(t, buf.String(), "# Image: quay.io/org/init:v1\n")
+}
+
+func TestWriteYAMLNormalizesObjects(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]any{
+		"kind":       "ConfigMap",
+		"apiVersion": "v1",
+		"metadata": map[string]any{
+			"name":              "cfg",
+			"namespace":         "openshift-cnv",
+			"uid":               "0b9c5d1e",
+			"resourceVersion":   "4711",
+			"generation":        int64(3),
+			"creationTimestamp": "2026-01-01T00:00:00Z",
+			"managedFields":     []any{map[string]any{"manager": "virt-platform-autopilot"}},
+			"labels":            map[string]any{},
+			"annotations": map[string]any{
+				engine.DesiredHashAnnotation:                       "abc",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"example.com/team":                                 "virt",
+			},
+		},
+		"data":   map[string]any{"b": "2", "a": "1"},
+		"status": map[string]any{"observed": true},
+	}}
+	outputs := []pkgrender.RenderOutput{{Asset: "cfg", Path: "cfg.yaml", Component: "ConfigMap", Status: "INCLUDED", Object: object}}
+
+	var buf bytes.Buffer
+	require.NoError(t, pkgrender.WriteYAML(&buf, outputs))
+	assert.Equal(t, `# Asset: cfg
+# Path: cfg.yaml
+# Component: ConfigMap
+# Status: INCLUDED
+apiVersion: v1
+data:
+  a: "1"
+  b: "2"
+kind: ConfigMap
+metadata:
+  annotations:
+    example.com/team: virt
+  name: cfg
+  namespace: openshift-cnv
+---
+`, buf.String())
+	assert.Equal(t, "4711", object.GetResourceVersion(), "the output's object must not be modified")
+
+	buf.Reset()
+	require.NoError(t, pkgrender.WriteJSON(&buf, outputs))
+	assert.NotContains(t, buf.String(), "resourceVersion")
+	assert.NotContains(t, buf.String(), "observed")
+}
+
+func TestRenderOutputIsReproducible(t *testing.T) {
+	render := func() string {
+		loader := assets.NewLoader()
+		registry, err := assets.NewRegistry(loader)
+		require.NoError(t, err)
+		hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+		hco.SetAnnotations(map[string]string{"platform.kubevirt.io/enable-hugepages": "true"})
+		outputs := pkgrender.BuildOutputs(registry.ListAssetsByReconcileOrder(), engine.NewRenderer(loader),
+			pkgcontext.NewRenderContext(hco), true)
+
+		var buf bytes.Buffer
+		require.NoError(t, pkgrender.WriteYAML(&buf, outputs))
+		require.NoError(t, pkgrender.WriteJSON(&buf, outputs))
+		return buf.String()
+	}
+
+	first := render()
+	for range 5 {
+		require.Equal(t, first, render(), "consecutive renders with the same inputs must be byte-identical")
+	}
+}
+
+func TestBuildImageResolver(t *testing.T) {
+	t.Cleanup(func() { imageMapping, imageStreams = "", "" })
+
+	resolver, err := buildImageResolver(nil)
+	require.NoError(t, err)
+	assert.Nil(t, resolver, "no resolver without --image-mapping or --image-stream-namespace")
+
+	mappingPath := filepath.Join(t.TempDir(), "digests.yaml")
+	require.NoError(t, os.WriteFile(mappingPath, []byte("quay.io/org/img:v1: quay.io/org/img@sha256:abc\n"), 0644))
+	imageMapping = mappingPath
+	resolver, err = buildImageResolver(nil)
+	require.NoError(t, err)
+	pinned, err := resolver.Resolve(context.Background(), "quay.io/org/img:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/org/img@sha256:abc", pinned)
+
+	imageMapping = filepath.Join(t.TempDir(), "missing.yaml")
+	_, err = buildImageResolver(nil)
+	assert.Error(t, err)
+}
+
+func TestRenderImageStreamsRequiresKubeconfig(t *testing.T) {
+	cmd := NewRenderCommand()
+	cmd.SetArgs([]string{"--hco-file=hco.yaml", "--image-stream-namespace=openshift-cnv"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--image-stream-namespace requires --kubeconfig")
+}
+
+func TestFilterComponents(t *testing.T) {
+	all := []assets.AssetMetadata{
+		{Name: "swap-enable", Component: "MachineConfig"},
+		{Name: "kubelet-tuning", Component: "KubeletConfig"},
+		{Name: "descheduler", Component: "KubeDescheduler"},
+		{Name: "pci-passthrough", Component: "MachineConfig"},
+	}
+	names := func(list []assets.AssetMetadata) []string {
+		var out []string
+		for _, asset := range list {
+			out = append(out, asset.Name)
+		}
+		return out
+	}
+
+	got, err := filterComponents(all, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, got, len(all))
+
+	got, err = filterComponents(all, []string{"MachineConfig", "KubeDescheduler"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"swap-enable", "descheduler", "pci-passthrough"}, names(got))
+
+	got, err = filterComponents(all, nil, []string{"MachineConfig"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kubelet-tuning", "descheduler"}, names(got))
+
+	_, err = filterComponents(all, nil, []string{"machineconfig"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected one of: KubeDescheduler, KubeletConfig, MachineConfig")
+}
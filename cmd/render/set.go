@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// applyOverrides layers --set and --set-annotation values over hco, in flag order.
+//
+// A --set value is parsed as YAML, so true, 3 and {a: b} become a bool, a number and a
+// map; quote it ('"true"') to force a string. null removes the field, an empty value
+// sets an empty string. A literal dot in a path segment is written \. (e.g. spec.x\.y.z).
+func applyOverrides(hco *unstructured.Unstructured, sets, annotations []string) error {
+	for _, set := range sets {
+		path, raw, ok := strings.Cut(set, "=")
+		if !ok || path == "" {
+			return fmt.Errorf("invalid --set %q: expected path=value", set)
+		}
+		fields := splitPath(path)
+		for _, field := range fields {
+			if field == "" {
+				return fmt.Errorf("invalid --set %q: empty path segment", set)
+			}
+		}
+		if fields[0] == "apiVersion" || fields[0] == "kind" {
+			return fmt.Errorf("invalid --set %q: %s cannot be overridden", set, fields[0])
+		}
+
+		var value any
+		if raw == "" {
+			value = ""
+		} else if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return fmt.Errorf("invalid --set %q: %w", set, err)
+		}
+		if value == nil {
+			unstructured.RemoveNestedField(hco.Object, fields...)
+			continue
+		}
+		if err := unstructured.SetNestedField(hco.Object, value, fields...); err != nil {
+			return fmt.Errorf("invalid --set %q: %w", set, err)
+		}
+	}
+
+	for _, annotation := range annotations {
+		key, value, ok := strings.Cut(annotation, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --set-annotation %q: expected key=value", annotation)
+		}
+		merged := hco.GetAnnotations()
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		merged[key] = value
+		hco.SetAnnotations(merged)
+	}
+	return nil
+}
+
+// splitPath splits a dotted field path, honouring \. as a literal dot
+func splitPath(path string) []string {
+	var fields []string
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case path[i] == '.':
+			fields = append(fields, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	return append(fields, current.String())
+}
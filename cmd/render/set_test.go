@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	require.NoError(t, unstructured.SetNestedField(hco.Object, "drop-me", "spec", "obsolete"))
+
+	require.NoError(t, applyOverrides(hco, []string{
+		"spec.featureGates.deployKubeSecondaryDNS=true",
+		`spec.featureGates.quoted="true"`,
+		"spec.workloads.nodePlacement={nodeSelector: {role: virt}}",
+		"spec.obsolete=null",
+		"spec.empty=",
+		`metadata.labels.example\.com/team=virt`,
+	}, []string{
+		"platform.kubevirt.io/enable-metallb=true",
+		"platform.kubevirt.io/patch=a=b",
+	}))
+
+	enabled, _, _ := unstructured.NestedBool(hco.Object, "spec", "featureGates", "deployKubeSecondaryDNS")
+	assert.True(t, enabled)
+	quoted, _, _ := unstructured.NestedString(hco.Object, "spec", "featureGates", "quoted")
+	assert.Equal(t, "true", quoted)
+	role, _, _ := unstructured.NestedString(hco.Object, "spec", "workloads", "nodePlacement", "nodeSelector", "role")
+	assert.Equal(t, "virt", role)
+	_, found, _ := unstructured.NestedFieldNoCopy(hco.Object, "spec", "obsolete")
+	assert.False(t, found)
+	empty, found, _ := unstructured.NestedString(hco.Object, "spec", "empty")
+	assert.True(t, found)
+	assert.Empty(t, empty)
+	assert.Equal(t, "virt", hco.GetLabels()["example.com/team"])
+	assert.Equal(t, "true", hco.GetAnnotations()["platform.kubevirt.io/enable-metallb"])
+	assert.Equal(t, "a=b", hco.GetAnnotations()["platform.kubevirt.io/patch"])
+}
+
+func TestApplyOverridesErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		sets        []string
+		annotations []string
+	}{
+		{"missing value", []string{"spec.featureGates.x"}, nil},
+		{"empty segment", []string{"spec..x=1"}, nil},
+		{"kind", []string{"kind=Other"}, nil},
+		{"invalid YAML", []string{"spec.x={unclosed"}, nil},
+		{"annotation without value", nil, []string{"platform.kubevirt.io/enable-metallb"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+			assert.Error(t, applyOverrides(hco, tt.sets, tt.annotations))
+		})
+	}
+}
+
+func TestApplyOverridesChangesRender(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+	mtv, err := registry.GetAsset("mtv-operator")
+	require.NoError(t, err)
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	assert.False(t, pkgrender.CheckConditions(mtv, pkgcontext.NewRenderContext(hco)))
+
+	require.NoError(t, applyOverrides(hco, nil, []string{"platform.kubevirt.io/enable-mtv=true"}))
+	assert.True(t, pkgrender.CheckConditions(mtv, pkgcontext.NewRenderContext(hco)))
+}
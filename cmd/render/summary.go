@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+// --fail-on conditions
+const (
+	FailOnError    = "error"
+	FailOnExcluded = "excluded"
+	FailOnDrift    = "drift"
+)
+
+// Exit codes returned when a --fail-on condition is met.
+// Generic failures (bad flags, unreachable cluster, ...) keep exit code 1.
+const (
+	ExitCodeError    = 2
+	ExitCodeDrift    = 3
+	ExitCodeExcluded = 4
+)
+
+// failOnOrder lists the conditions by precedence: when several are met,
+// the exit code of the first one wins.
+var failOnOrder = []string{FailOnError, FailOnDrift, FailOnExcluded}
+
+// ExitError is returned by the render command when a --fail-on condition is met.
+// main uses Code as the process exit code.
+type ExitError struct {
+	Code int
+	Msg  string
+}
+
+func (e *ExitError) Error() string {
+	return e.Msg
+}
+
+// Summary holds the per-status counts of a render run.
+// It is written as JSON by --summary-file for CI post-processing.
+type Summary struct {
+	Total    int `json:"total"`
+	Included int `json:"included"`
+	Excluded int `json:"excluded"`
+	Filtered int `json:"filtered"`
+	Errors   int `json:"errors"`
+	// Deprecated counts included assets the catalog marks as deprecated
+	Deprecated int `json:"deprecated"`
+	// Drifted is only meaningful when DriftChecked is true (--fail-on=drift)
+	Drifted      int      `json:"drifted"`
+	DriftChecked bool     `json:"driftChecked"`
+	FailedOn     []string `json:"failedOn,omitempty"`
+}
+
+// summarize counts outputs by status
+func summarize(outputs []pkgrender.RenderOutput) Summary {
+	summary := Summary{Total: len(outputs)}
+	for _, output := range outputs {
+		switch output.Status {
+		case "INCLUDED":
+			summary.Included++
+		case "EXCLUDED":
+			summary.Excluded++
+		case "FILTERED":
+			summary.Filtered++
+		case "ERROR":
+			summary.Errors++
+		}
+		if output.Drifted {
+			summary.Drifted++
+		}
+		if output.Status == "INCLUDED" && output.Deprecated != "" {
+			summary.Deprecated++
+		}
+	}
+	return summary
+}
+
+// validateFailOn rejects unknown --fail-on conditions
+func validateFailOn(conditions []string) error {
+	for _, c := range conditions {
+		switch c {
+		case FailOnError, FailOnExcluded, FailOnDrift:
+		default:
+			return fmt.Errorf("invalid --fail-on value %q (must be one of: %s)", c, strings.Join(failOnOrder, ", "))
+		}
+	}
+	return nil
+}
+
+// evaluateFailOn records the met conditions in summary.FailedOn and returns an
+// ExitError for the highest-precedence one, or nil when none is met.
+// "excluded" covers both condition-excluded and root-filtered assets.
+func evaluateFailOn(summary *Summary, conditions []string) error {
+	requested := make(map[string]bool, len(conditions))
+	for _, c := range conditions {
+		requested[c] = true
+	}
+
+	met := map[string]bool{
+		FailOnError:    summary.Errors > 0,
+		FailOnDrift:    summary.Drifted > 0,
+		FailOnExcluded: summary.Excluded+summary.Filtered > 0,
+	}
+	codes := map[string]int{
+		FailOnError:    ExitCodeError,
+		FailOnDrift:    ExitCodeDrift,
+		FailOnExcluded: ExitCodeExcluded,
+	}
+
+	summary.FailedOn = nil
+	for _, c := range failOnOrder {
+		if requested[c] && met[c] {
+			summary.FailedOn = append(summary.FailedOn, c)
+		}
+	}
+	if len(summary.FailedOn) == 0 {
+		return nil
+	}
+
+	first := summary.FailedOn[0]
+	return &ExitError{
+		Code: codes[first],
+		Msg: fmt.Sprintf("render failed on %s (%d errors, %d drifted, %d excluded, %d filtered)",
+			strings.Join(summary.FailedOn, ", "), summary.Errors, summary.Drifted, summary.Excluded, summary.Filtered),
+	}
+}
+
+// writeSummaryFile writes summary as JSON to path
+func writeSummaryFile(path string, summary Summary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write summary file: %w", err)
+	}
+	return nil
+}
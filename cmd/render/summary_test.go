@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+func TestSummarize(t *testing.T) {
+	outputs := []pkgrender.RenderOutput{
+		{Asset: "a", Status: "INCLUDED"},
+		{Asset: "b", Status: "INCLUDED", Drifted: true, Deprecated: "asset b is deprecated"},
+		{Asset: "c", Status: "EXCLUDED", Deprecated: "asset c is deprecated"},
+		{Asset: "d", Status: "FILTERED"},
+		{Asset: "e", Status: "ERROR"},
+	}
+
+	summary := summarize(outputs)
+	assert.Equal(t, Summary{Total: 5, Included: 2, Excluded: 1, Filtered: 1, Errors: 1, Drifted: 1, Deprecated: 1}, summary)
+}
+
+func TestEvaluateFailOn(t *testing.T) {
+	tests := []struct {
+		name         string
+		summary      Summary
+		failOn       []string
+		expectCode   int
+		expectFailed []string
+	}{
+		{
+			name:    "no conditions requested",
+			summary: Summary{Errors: 1, Drifted: 1, Excluded: 1},
+		},
+		{
+			name:    "condition requested but not met",
+			summary: Summary{Included: 3},
+			failOn:  []string{FailOnError, FailOnExcluded},
+		},
+		{
+			name:         "errors",
+			summary:      Summary{Errors: 2},
+			failOn:       []string{FailOnError},
+			expectCode:   ExitCodeError,
+			expectFailed: []string{FailOnError},
+		},
+		{
+			name:         "filtered counts as excluded",
+			summary:      Summary{Filtered: 1},
+			failOn:       []string{FailOnExcluded},
+			expectCode:   ExitCodeExcluded,
+			expectFailed: []string{FailOnExcluded},
+		},
+		{
+			name:         "drift",
+			summary:      Summary{Drifted: 1},
+			failOn:       []string{FailOnDrift},
+			expectCode:   ExitCodeDrift,
+			expectFailed: []string{FailOnDrift},
+		},
+		{
+			name:         "precedence follows error, drift, excluded",
+			summary:      Summary{Errors: 1, Drifted: 1, Excluded: 1},
+			failOn:       []string{FailOnExcluded, FailOnDrift, FailOnError},
+			expectCode:   ExitCodeError,
+			expectFailed: []string{FailOnError, FailOnDrift, FailOnExcluded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := tt.summary
+			err := evaluateFailOn(&summary, tt.failOn)
+			assert.Equal(t, tt.expectFailed, summary.FailedOn)
+
+			if tt.expectCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var exitErr *ExitError
+			require.True(t, errors.As(err, &exitErr))
+			assert.Equal(t, tt.expectCode, exitErr.Code)
+		})
+	}
+}
+
+func TestValidateFailOn(t *testing.T) {
+	assert.NoError(t, validateFailOn([]string{"error", "excluded", "drift"}))
+	assert.NoError(t, validateFailOn(nil))
+
+	err := validateFailOn([]string{"error", "warnings"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --fail-on value "warnings"`)
+}
+
+func TestRenderFailOnAndSummaryFile(t *testing.T) {
+	hcoYAML := `apiVersion: hco.kubevirt.io/v1beta1
+kind: HyperConverged
+metadata:
+  name: kubevirt-hyperconverged
+  namespace: openshift-cnv
+`
+	tmpDir := t.TempDir()
+	hcoPath := filepath.Join(tmpDir, "hco.yaml")
+	require.NoError(t, os.WriteFile(hcoPath, []byte(hcoYAML), 0644))
+	summaryPath := filepath.Join(tmpDir, "summary.json")
+
+	// A default HCO leaves conditional assets excluded, so --fail-on=excluded trips
+	cmd := NewRenderCommand()
+	cmd.SetArgs([]string{"--hco-file=" + hcoPath, "--output=status", "--fail-on=excluded", "--summary-file=" + summaryPath})
+	err := cmd.Execute()
+
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr), "expected ExitError, got %v", err)
+	assert.Equal(t, ExitCodeExcluded, exitErr.Code)
+
+	data, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+	summary := Summary{}
+	require.NoError(t, json.Unmarshal(data, &summary))
+	assert.Positive(t, summary.Excluded)
+	assert.Equal(t, summary.Total, summary.Included+summary.Excluded+summary.Filtered+summary.Errors)
+	assert.Equal(t, []string{FailOnExcluded}, summary.FailedOn)
+	assert.False(t, summary.DriftChecked)
+}
+
+func TestRenderFailOnDriftRequiresKubeconfig(t *testing.T) {
+	cmd := NewRenderCommand()
+	cmd.SetArgs([]string{"--hco-file=hco.yaml", "--fail-on=drift"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--fail-on=drift requires --kubeconfig")
+}
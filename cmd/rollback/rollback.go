@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+var (
+	kubeconfig string
+	namespace  string
+	configMap  string
+	asset      string
+	revision   int64
+	list       bool
+	dryRun     bool
+)
+
+// Options select the version to roll back to
+type Options struct {
+	Asset string
+	// Revision to restore; 0 selects the one before the newest
+	Revision int64
+	DryRun   bool
+}
+
+// NewRollbackCommand creates the rollback subcommand
+func NewRollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Re-apply a previously applied version of an asset",
+		Long: `Restore an asset to a version the autopilot applied before, taken from the
+render history ConfigMap the controller keeps with --render-history-configmap.
+
+The version is applied with the autopilot's field manager, and the object is
+annotated with platform.kubevirt.io/reconcile-paused so the controller does not
+immediately re-apply the current, bad render. Remove the annotation once the
+template is fixed (e.g. through the overrides ConfigMap or a catalog update) to
+hand the object back to the autopilot.
+
+Examples:
+  virt-platform-autopilot rollback --asset=swap-enable --list
+  virt-platform-autopilot rollback --asset=swap-enable --dry-run
+  virt-platform-autopilot rollback --asset=swap-enable --revision=3
+`,
+		Args: cobra.NoArgs,
+		RunE: runRollback,
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&namespace, "namespace", "openshift-cnv", "Namespace of the render history ConfigMap")
+	cmd.Flags().StringVar(&configMap, "configmap", engine.DefaultRenderHistoryConfigMap, "Name of the render history ConfigMap")
+	cmd.Flags().StringVar(&asset, "asset", "", "Asset to roll back (required)")
+	cmd.Flags().Int64Var(&revision, "revision", 0, "Revision to restore (default: the one before the newest)")
+	cmd.Flags().BoolVar(&list, "list", false, "List the recorded revisions of the asset instead of rolling back")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the object that would be applied instead of applying it")
+	_ = cmd.MarkFlagRequired("asset")
+	_ = cmd.RegisterFlagCompletionFunc("asset", completion.AssetNames)
+
+	return cmd
+}
+
+// runRollback executes the rollback command
+func runRollback(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	c, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	ctx := context.Background()
+	entries, err := History(ctx, c, namespace, configMap, asset)
+	if err != nil {
+		return err
+	}
+	if list {
+		writeRevisions(cmd.OutOrStdout(), entries)
+		return nil
+	}
+	return Rollback(ctx, c, entries, Options{Asset: asset, Revision: revision, DryRun: dryRun}, cmd.OutOrStdout())
+}
+
+// History reads the recorded versions of asset from the render history ConfigMap, oldest first
+func History(ctx context.Context, c client.Reader, namespace, name, asset string) ([]engine.HistoryEntry, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, fmt.Errorf("failed to read render history ConfigMap %s/%s (is --render-history-configmap set?): %w", namespace, name, err)
+	}
+	history, err := engine.ParseRenderHistory(cm)
+	if err != nil {
+		return nil, err
+	}
+	entries := history[asset]
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no history recorded for asset %s", asset)
+	}
+	return entries, nil
+}
+
+// Rollback applies the selected revision of entries, paused against reconciliation
+func Rollback(ctx context.Context, c client.Client, entries []engine.HistoryEntry, opts Options, w io.Writer) error {
+	entry, err := selectRevision(entries, opts.Revision)
+	if err != nil {
+		return fmt.Errorf("asset %s: %w", opts.Asset, err)
+	}
+
+	obj := entry.Object.DeepCopy()
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[overrides.AnnotationReconcilePaused] = "true"
+	obj.SetAnnotations(annotations)
+
+	if opts.DryRun {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal object: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	if _, err := engine.NewApplier(c, nil).Apply(ctx, obj, true); err != nil {
+		return fmt.Errorf("failed to roll back asset %s: %w", opts.Asset, err)
+	}
+	fmt.Fprintf(w, "Rolled back %s %s to revision %d (applied %s)\n",
+		obj.GetKind(), objectName(obj.GetNamespace(), obj.GetName()), entry.Revision, entry.AppliedAt.UTC().Format("2006-01-02T15:04:05Z"))
+	fmt.Fprintf(w, "Reconciliation is paused; remove the %s annotation to resume it\n", overrides.AnnotationReconcilePaused)
+	return nil
+}
+
+// selectRevision returns the entry with the given revision, or the one before the
+// newest when revision is 0. Entries are ordered oldest first.
+func selectRevision(entries []engine.HistoryEntry, revision int64) (engine.HistoryEntry, error) {
+	if revision == 0 {
+		if len(entries) < 2 {
+			return engine.HistoryEntry{}, fmt.Errorf("no previous revision recorded")
+		}
+		return entries[len(entries)-2], nil
+	}
+	for _, entry := range entries {
+		if entry.Revision == revision {
+			return entry, nil
+		}
+	}
+	return engine.HistoryEntry{}, fmt.Errorf("revision %d not recorded (available: %s)", revision, revisionList(entries))
+}
+
+// writeRevisions prints a table of the recorded revisions, newest first
+func writeRevisions(w io.Writer, entries []engine.HistoryEntry) {
+	fmt.Fprintf(w, "%-10s %-22s %s\n", "REVISION", "APPLIED", "OBJECT")
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		fmt.Fprintf(w, "%-10d %-22s %s/%s\n", entry.Revision, entry.AppliedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			entry.Object.GetKind(), objectName(entry.Object.GetNamespace(), entry.Object.GetName()))
+	}
+}
+
+func revisionList(entries []engine.HistoryEntry) string {
+	revisions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		revisions = append(revisions, fmt.Sprint(entry.Revision))
+	}
+	return strings.Join(revisions, ", ")
+}
+
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollback
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+func newConfigMapObject(value string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName("virt-settings")
+	_ = unstructured.SetNestedField(obj.Object, value, "data", "setting")
+	return obj
+}
+
+// recordHistory records one version per value the way the controller does
+func recordHistory(t *testing.T, c client.Client, values ...string) {
+	t.Helper()
+	h := engine.NewRenderHistory(engine.DefaultRenderHistorySize)
+	h.SetConfigMap(c, c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap)
+	for _, value := range values {
+		h.Record(context.Background(), "virt-settings", newConfigMapObject(value))
+	}
+}
+
+func TestRollbackToPreviousRevision(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	recordHistory(t, c, "good", "bad")
+
+	entries, err := History(ctx, c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap, "virt-settings")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	var out bytes.Buffer
+	require.NoError(t, Rollback(ctx, c, entries, Options{Asset: "virt-settings"}, &out))
+	assert.Contains(t, out.String(), "to revision 1")
+
+	live := newConfigMapObject("")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(live), live))
+	value, _, _ := unstructured.NestedString(live.Object, "data", "setting")
+	assert.Equal(t, "good", value)
+	assert.Equal(t, "true", live.GetAnnotations()[overrides.AnnotationReconcilePaused],
+		"expected the restored object to be paused against reconciliation")
+	assert.Equal(t, engine.ManagedByValue, live.GetLabels()[engine.ManagedByLabel])
+}
+
+func TestRollbackDryRun(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	recordHistory(t, c, "one", "two", "three")
+
+	entries, err := History(ctx, c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap, "virt-settings")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Rollback(ctx, c, entries, Options{Asset: "virt-settings", Revision: 1, DryRun: true}, &out))
+	assert.Contains(t, out.String(), "setting: one")
+	assert.Contains(t, out.String(), overrides.AnnotationReconcilePaused)
+
+	live := newConfigMapObject("")
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(live), live), "expected a dry run to apply nothing")
+}
+
+func TestRollbackErrors(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+
+	_, err := History(ctx, c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap, "virt-settings")
+	assert.ErrorContains(t, err, "--render-history-configmap")
+
+	recordHistory(t, c, "only")
+	_, err = History(ctx, c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap, "other")
+	assert.ErrorContains(t, err, "no history recorded for asset other")
+
+	entries, err := History(ctx, c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap, "virt-settings")
+	require.NoError(t, err)
+	assert.ErrorContains(t, Rollback(ctx, c, entries, Options{Asset: "virt-settings"}, &bytes.Buffer{}), "no previous revision")
+	assert.ErrorContains(t, Rollback(ctx, c, entries, Options{Asset: "virt-settings", Revision: 7}, &bytes.Buffer{}), "available: 1")
+}
+
+func TestWriteRevisions(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	recordHistory(t, c, "one", "two")
+	entries, err := History(context.Background(), c, "openshift-cnv", engine.DefaultRenderHistoryConfigMap, "virt-settings")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	writeRevisions(&out, entries)
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.True(t, bytes.HasPrefix(lines[1], []byte("2 ")), "expected the newest revision first, got %q", lines[1])
+	assert.Contains(t, string(lines[1]), "ConfigMap/openshift-cnv/virt-settings")
+}
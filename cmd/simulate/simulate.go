@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/completion"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/scenario"
+)
+
+var (
+	scenarioFile string
+	outputFormat string
+)
+
+// Result is the outcome of simulating one scenario
+type Result struct {
+	Scenario string                      `json:"scenario" yaml:"scenario"`
+	Detected map[string]bool             `json:"detected" yaml:"detected"`
+	Assets   []controller.AssetInclusion `json:"assets" yaml:"assets"`
+	// Failures lists the scenario expectations that were not met
+	Failures []string `json:"failures,omitempty" yaml:"failures,omitempty"`
+}
+
+// NewSimulateCommand creates the simulate subcommand
+func NewSimulateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Evaluate asset inclusion against a synthetic cluster",
+		Long: `Run hardware, topology, storage and network detection and asset condition
+evaluation against a scenario file instead of a cluster, and print which assets
+the autopilot would manage and why the others are skipped.
+
+A scenario bundles an HCO, synthetic nodes, the installed CRDs and any other
+cluster objects the detectors read. Ready-made scenarios live in scenarios/.
+When the scenario has an expect section, a mismatch makes the command fail.
+
+Examples:
+  virt-platform-autopilot simulate --scenario=scenarios/gpu-cluster.yaml
+  virt-platform-autopilot simulate --scenario=scenarios/compact-cluster.yaml --output=json
+`,
+		Args: cobra.NoArgs,
+		RunE: runSimulate,
+	}
+
+	cmd.Flags().StringVar(&scenarioFile, "scenario", "", "Path to the scenario YAML file (required)")
+	cmd.Flags().StringVar(&outputFormat, "output", "status", "Output format: status, yaml, or json")
+	_ = cmd.MarkFlagRequired("scenario")
+	_ = cmd.RegisterFlagCompletionFunc("output", completion.Values("status", "yaml", "json"))
+
+	return cmd
+}
+
+// runSimulate executes the simulate command
+func runSimulate(cmd *cobra.Command, _ []string) error {
+	if outputFormat != "status" && outputFormat != "yaml" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	s, err := scenario.Load(scenarioFile)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	result, err := Run(context.Background(), s, registry)
+	if err != nil {
+		return err
+	}
+	if err := writeResult(cmd.OutOrStdout(), result, outputFormat); err != nil {
+		return err
+	}
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("scenario %s: %d expectation(s) not met", s.Name, len(result.Failures))
+	}
+	return nil
+}
+
+// Run evaluates every asset against the scenario's synthetic cluster
+func Run(ctx context.Context, s *scenario.Scenario, registry *assets.Registry) (*Result, error) {
+	c, err := s.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build scenario client: %w", err)
+	}
+
+	renderCtx, inclusions, err := controller.EvaluateInclusion(ctx, c, registry, s.HCO)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate scenario %s: %w", s.Name, err)
+	}
+
+	detected := renderCtx.Hardware.AsMap()
+	for key, value := range renderCtx.Storage.AsMap() {
+		detected[key] = value
+	}
+	for key, value := range renderCtx.Network.AsMap() {
+		detected[key] = value
+	}
+	detected["fips"] = renderCtx.FIPS
+
+	included := make(map[string]bool, len(inclusions))
+	for _, inclusion := range inclusions {
+		included[inclusion.Asset] = inclusion.Included
+	}
+
+	return &Result{
+		Scenario: s.Name,
+		Detected: detected,
+		Assets:   inclusions,
+		Failures: s.Check(included),
+	}, nil
+}
+
+// writeResult prints the result in the requested format
+func writeResult(w io.Writer, result *Result, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case "yaml":
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		writeStatus(w, result)
+		return nil
+	}
+}
+
+// writeStatus prints the detected capabilities and an inclusion table
+func writeStatus(w io.Writer, result *Result) {
+	fmt.Fprintf(w, "Scenario: %s\n", result.Scenario)
+
+	var detected []string
+	for key, value := range result.Detected {
+		if value {
+			detected = append(detected, key)
+		}
+	}
+	sort.Strings(detected)
+	if len(detected) == 0 {
+		detected = []string{"none"}
+	}
+	fmt.Fprintf(w, "Detected: %s\n\n", strings.Join(detected, ", "))
+
+	fmt.Fprintf(w, "%-40s %-10s %-20s %s\n", "ASSET", "STATUS", "COMPONENT", "REASON")
+	fmt.Fprintln(w, strings.Repeat("-", 110))
+	included := 0
+	for _, asset := range result.Assets {
+		status, reason := "EXCLUDED", asset.Reason
+		if asset.Included {
+			status, reason = "INCLUDED", "-"
+			included++
+		}
+		fmt.Fprintf(w, "%-40s %-10s %-20s %s\n", asset.Asset, status, asset.Component, reason)
+	}
+	fmt.Fprintln(w, strings.Repeat("-", 110))
+	fmt.Fprintf(w, "Summary: %d included, %d excluded\n", included, len(result.Assets)-included)
+
+	for _, failure := range result.Failures {
+		fmt.Fprintf(w, "FAIL: %s\n", failure)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/scenario"
+)
+
+// TestShippedScenarios keeps the scenarios in the repository in line with the catalog:
+// every expectation they declare must hold.
+func TestShippedScenarios(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+
+	paths, err := filepath.Glob(filepath.Join("..", "..", "scenarios", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			s, err := scenario.Load(path)
+			require.NoError(t, err)
+			require.NotNil(t, s.Expect, "shipped scenarios should declare expectations")
+
+			result, err := Run(context.Background(), s, registry)
+			require.NoError(t, err)
+			assert.Empty(t, result.Failures)
+		})
+	}
+}
+
+func TestRunSimulateFailsOnUnmetExpectation(t *testing.T) {
+	scenarioYAML := `hco:
+  apiVersion: hco.kubevirt.io/v1beta1
+  kind: HyperConverged
+  metadata: {name: kubevirt-hyperconverged, namespace: openshift-cnv}
+expect:
+  included: [swap-enable]
+`
+	path := filepath.Join(t.TempDir(), "idle.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(scenarioYAML), 0644))
+
+	var stdout bytes.Buffer
+	cmd := NewSimulateCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--scenario=" + path})
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scenario idle: 1 expectation(s) not met")
+	assert.Contains(t, stdout.String(), "Scenario: idle")
+	assert.Contains(t, stdout.String(), "FAIL: expected swap-enable to be included, but it is excluded")
+}
+
+func TestRunSimulateValidation(t *testing.T) {
+	cmd := NewSimulateCommand()
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scenario")
+
+	cmd = NewSimulateCommand()
+	cmd.SetArgs([]string{"--scenario=x.yaml", "--output=table"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output format")
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/cmd/internal/cluster"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+var (
+	kubeconfig string
+	namespace  string
+	timeout    time.Duration
+	interval   time.Duration
+)
+
+// NewWaitCommand creates the wait subcommand
+func NewWaitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until the autopilot has converged on the current HCO generation",
+		Long: `Poll the cluster until the autopilot has fully reconciled the current generation
+of the HyperConverged CR and every asset it includes is applied and healthy, then
+exit 0. Exit non-zero with what is still pending when the timeout expires.
+
+Converged means:
+  - the PlatformAutopilotReconciled condition reports metadata.generation
+  - PlatformAutopilotReconcileFailing is not True
+  - the object of every included asset exists and carries the managed-by label
+  - no such object reports Available or Ready False, Degraded True, or a
+    status.observedGeneration behind its metadata.generation
+
+Included assets are decided as the controller does, from the live cluster. Assets
+gated on an image condition are only checked when the RELATED_IMAGE_* variables
+are set, e.g. when run inside the operator pod.
+
+Examples:
+  virt-platform-autopilot wait --kubeconfig=$KUBECONFIG --timeout=10m
+  oc exec -n openshift-cnv deploy/virt-platform-autopilot -- /manager wait --timeout=15m
+`,
+		Args: cobra.NoArgs,
+		RunE: runWait,
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: in-cluster config)")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the HyperConverged CR (required when there are several)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait before failing")
+	cmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "Time between checks")
+
+	return cmd
+}
+
+// runWait executes the wait command
+func runWait(cmd *cobra.Command, _ []string) error {
+	if timeout <= 0 || interval <= 0 {
+		return fmt.Errorf("--timeout and --interval must be positive")
+	}
+	cmd.SilenceUsage = true
+
+	c, err := cluster.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		return fmt.Errorf("failed to load asset registry: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return Wait(ctx, c, registry, namespace, interval, cmd.OutOrStdout())
+}
+
+// Wait checks convergence every interval until it is reached or ctx expires.
+// Pending items are printed whenever they change.
+func Wait(ctx context.Context, c client.Client, registry *assets.Registry, namespace string, interval time.Duration, w io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last []string
+	for {
+		status, err := Check(ctx, c, registry, namespace)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			return err
+		case err == nil && len(status.Pending) == 0:
+			fmt.Fprintf(w, "Converged: generation %d of %s/%s, %d asset(s) applied and healthy\n",
+				status.Generation, status.Namespace, status.Name, status.Assets)
+			return nil
+		case err == nil && strings.Join(status.Pending, "\n") != strings.Join(last, "\n"):
+			fmt.Fprintf(w, "Waiting for %d item(s):\n  %s\n", len(status.Pending), strings.Join(status.Pending, "\n  "))
+			last = status.Pending
+		}
+
+		select {
+		case <-ctx.Done():
+			if len(last) == 0 {
+				return fmt.Errorf("timed out waiting for convergence: %w", ctx.Err())
+			}
+			return fmt.Errorf("timed out waiting for convergence, still pending: %s", strings.Join(last, "; "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status is the outcome of one convergence check
+type Status struct {
+	Namespace  string
+	Name       string
+	Generation int64
+	// Assets counts the included assets that render an object
+	Assets int
+	// Pending lists why the autopilot has not converged yet; empty once it has
+	Pending []string
+}
+
+// Check evaluates convergence once. Errors are reserved for conditions waiting
+// cannot fix, such as a missing HCO or an autopilot that is not enabled.
+func Check(ctx context.Context, c client.Client, registry *assets.Registry, namespace string) (*Status, error) {
+	hco, err := cluster.FindHCO(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if _, enabled := overrides.ParseAutopilotScope(hco); !enabled {
+		return nil, fmt.Errorf("autopilot is not enabled on %s/%s (%s)",
+			hco.GetNamespace(), hco.GetName(), overrides.AnnotationAutopilotEnabled)
+	}
+
+	status := &Status{Namespace: hco.GetNamespace(), Name: hco.GetName(), Generation: hco.GetGeneration()}
+	status.Pending = append(status.Pending, hcoPending(hco)...)
+
+	renderCtx, inclusions, err := controller.EvaluateInclusion(ctx, c, registry, hco)
+	if err != nil {
+		return nil, err
+	}
+	renderer := engine.NewRenderer(assets.NewLoader())
+	renderer.SetClient(c)
+
+	for _, inclusion := range inclusions {
+		if !inclusion.Included {
+			continue
+		}
+		asset, err := registry.GetAsset(inclusion.Asset)
+		if err != nil {
+			return nil, err
+		}
+		desired, err := renderer.RenderAsset(asset, renderCtx)
+		if err != nil {
+			if _, skipped := engine.SkipReason(err); skipped {
+				continue
+			}
+			status.Pending = append(status.Pending, fmt.Sprintf("%s: render failed: %v", asset.Name, err))
+			continue
+		}
+		if desired == nil {
+			continue
+		}
+		status.Assets++
+		if reason := objectPending(ctx, c, desired); reason != "" {
+			status.Pending = append(status.Pending, fmt.Sprintf("%s: %s", asset.Name, reason))
+		}
+	}
+	return status, nil
+}
+
+// hcoPending reports the autopilot conditions on the HCO that block convergence.
+// Sharded controllers report their conditions with a shard suffix; all of them must agree.
+func hcoPending(hco *unstructured.Unstructured) []string {
+	var pending []string
+	reconciled := findConditions(hco, controller.ConditionReconciled)
+	if len(reconciled) == 0 {
+		pending = append(pending, fmt.Sprintf("HCO generation %d not fully reconciled yet (last: 0)", hco.GetGeneration()))
+	}
+	for _, condition := range reconciled {
+		observed, _, _ := unstructured.NestedInt64(condition, "observedGeneration")
+		if condition["status"] != "True" || observed != hco.GetGeneration() {
+			pending = append(pending, fmt.Sprintf("%sHCO generation %d not fully reconciled yet (last: %d)",
+				shardPrefix(condition, controller.ConditionReconciled), hco.GetGeneration(), observed))
+		}
+	}
+	for _, failing := range findConditions(hco, controller.ConditionReconcileFailing) {
+		if failing["status"] == "True" {
+			pending = append(pending, fmt.Sprintf("%sreconcile failing: %v",
+				shardPrefix(failing, controller.ConditionReconcileFailing), failing["message"]))
+		}
+	}
+	return pending
+}
+
+// objectPending returns why the live counterpart of desired is not applied and healthy, or ""
+func objectPending(ctx context.Context, c client.Client, desired *unstructured.Unstructured) string {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	switch {
+	case errors.IsNotFound(err):
+		return fmt.Sprintf("%s %s not found", desired.GetKind(), objectName(desired))
+	case err != nil:
+		return fmt.Sprintf("failed to get %s %s: %v", desired.GetKind(), objectName(desired), err)
+	}
+
+	if live.GetLabels()[engine.ManagedByLabel] != engine.ManagedByValue {
+		return fmt.Sprintf("%s %s lacks the %s label", live.GetKind(), objectName(live), engine.ManagedByLabel)
+	}
+	if observed, found, _ := unstructured.NestedInt64(live.Object, "status", "observedGeneration"); found && observed < live.GetGeneration() {
+		return fmt.Sprintf("%s %s generation %d not observed yet (last: %d)", live.GetKind(), objectName(live), live.GetGeneration(), observed)
+	}
+	for _, unhealthy := range []struct{ condition, status string }{
+		{"Available", "False"},
+		{"Ready", "False"},
+		{"Degraded", "True"},
+	} {
+		if cond := findCondition(live, unhealthy.condition); cond != nil && cond["status"] == unhealthy.status {
+			return fmt.Sprintf("%s %s is %s=%s: %v", live.GetKind(), objectName(live), unhealthy.condition, unhealthy.status, cond["message"])
+		}
+	}
+	return ""
+}
+
+// findCondition returns status.conditions[type=condType] of obj, or nil
+func findCondition(obj *unstructured.Unstructured, condType string) map[string]any {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, raw := range conditions {
+		if c, ok := raw.(map[string]any); ok && c["type"] == condType {
+			return c
+		}
+	}
+	return nil
+}
+
+// findConditions returns the conditions of type condType, including its shard-suffixed variants
+func findConditions(obj *unstructured.Unstructured, condType string) []map[string]any {
+	var found []map[string]any
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, raw := range conditions {
+		c, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if t, _ := c["type"].(string); t == condType || strings.HasPrefix(t, condType+"-") {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// shardPrefix names the shard of a shard-suffixed condition for messages
+func shardPrefix(condition map[string]any, condType string) string {
+	t, _ := condition["type"].(string)
+	if shard, ok := strings.CutPrefix(t, condType+"-"); ok {
+		return "shard " + shard + ": "
+	}
+	return ""
+}
+
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/scenario"
+)
+
+const hcoYAML = `hco:
+  apiVersion: hco.kubevirt.io/v1
+  kind: HyperConverged
+  metadata:
+    name: kubevirt-hyperconverged
+    namespace: openshift-cnv
+    generation: 4
+    annotations:
+      platform.kubevirt.io/autopilot: metrics-exporter-namespace
+      platform.kubevirt.io/enable-metrics-exporter: "true"
+`
+
+const reconciledStatus = `  status:
+    conditions:
+      - type: PlatformAutopilotReconciled
+        status: "True"
+        reason: ReconcileSucceeded
+        observedGeneration: 4
+`
+
+const managedNamespace = `objects:
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: kubevirt-metrics-exporter
+      labels:
+        platform.kubevirt.io/managed-by: virt-platform-autopilot
+`
+
+func scenarioClient(t *testing.T, doc string) client.Client {
+	t.Helper()
+	s, err := scenario.Parse([]byte(doc))
+	require.NoError(t, err)
+	c, err := s.Client()
+	require.NoError(t, err)
+	return c
+}
+
+func TestCheck(t *testing.T) {
+	t.Setenv("RELATED_IMAGE_KUBEVIRT_METRICS_EXPORTER", "quay.io/kubevirt/metrics-exporter:latest")
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("pending", func(t *testing.T) {
+		status, err := Check(ctx, scenarioClient(t, hcoYAML), registry, "")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), status.Generation)
+		assert.Equal(t, 1, status.Assets)
+		require.Len(t, status.Pending, 2)
+		assert.Contains(t, status.Pending[0], "HCO generation 4 not fully reconciled yet (last: 0)")
+		assert.Contains(t, status.Pending[1], "metrics-exporter-namespace: Namespace kubevirt-metrics-exporter not found")
+	})
+
+	t.Run("converged", func(t *testing.T) {
+		status, err := Check(ctx, scenarioClient(t, hcoYAML+reconciledStatus+managedNamespace), registry, "")
+		require.NoError(t, err)
+		assert.Empty(t, status.Pending)
+	})
+
+	t.Run("reconcile failing", func(t *testing.T) {
+		failing := reconciledStatus + `      - type: PlatformAutopilotReconcileFailing
+        status: "True"
+        reason: ConsecutiveFailures
+        message: webhook unavailable
+`
+		status, err := Check(ctx, scenarioClient(t, hcoYAML+failing+managedNamespace), registry, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reconcile failing: webhook unavailable"}, status.Pending)
+	})
+
+	t.Run("sharded", func(t *testing.T) {
+		sharded := `  status:
+    conditions:
+      - type: PlatformAutopilotReconciled-machine-config
+        status: "True"
+        reason: ReconcileSucceeded
+        observedGeneration: 3
+      - type: PlatformAutopilotReconciled-default
+        status: "True"
+        reason: ReconcileSucceeded
+        observedGeneration: 4
+`
+		status, err := Check(ctx, scenarioClient(t, hcoYAML+sharded+managedNamespace), registry, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"shard machine-config: HCO generation 4 not fully reconciled yet (last: 3)"}, status.Pending)
+	})
+
+	t.Run("unlabeled object", func(t *testing.T) {
+		unlabeled := `objects:
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: kubevirt-metrics-exporter
+`
+		status, err := Check(ctx, scenarioClient(t, hcoYAML+reconciledStatus+unlabeled), registry, "")
+		require.NoError(t, err)
+		require.Len(t, status.Pending, 1)
+		assert.Contains(t, status.Pending[0], "lacks the platform.kubevirt.io/managed-by label")
+	})
+
+	t.Run("autopilot not enabled", func(t *testing.T) {
+		idle := `hco:
+  apiVersion: hco.kubevirt.io/v1
+  kind: HyperConverged
+  metadata: {name: kubevirt-hyperconverged, namespace: openshift-cnv}
+`
+		_, err := Check(ctx, scenarioClient(t, idle), registry, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "autopilot is not enabled")
+	})
+}
+
+func TestWait(t *testing.T) {
+	t.Setenv("RELATED_IMAGE_KUBEVIRT_METRICS_EXPORTER", "quay.io/kubevirt/metrics-exporter:latest")
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	require.NoError(t, err)
+
+	t.Run("converged", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Wait(context.Background(), scenarioClient(t, hcoYAML+reconciledStatus+managedNamespace), registry, "", time.Millisecond, &out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Converged: generation 4 of openshift-cnv/kubevirt-hyperconverged, 1 asset(s) applied and healthy")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var out bytes.Buffer
+		err := Wait(ctx, scenarioClient(t, hcoYAML+reconciledStatus), registry, "", 10*time.Millisecond, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "still pending: metrics-exporter-namespace: Namespace kubevirt-metrics-exporter not found")
+		// Unchanged pending items are printed once
+		assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("Waiting for")))
+	})
+}
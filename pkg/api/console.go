@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+// ConsolePathPrefix is the path the endpoints shaped for the OpenShift console plugin
+// are served under
+const ConsolePathPrefix = PathPrefix + "console/"
+
+const (
+	// defaultDriftEvents and maxDriftEvents bound the drift events of one response
+	defaultDriftEvents = 50
+	maxDriftEvents     = 500
+)
+
+// Health states of ConsoleHealth
+const (
+	// HealthHealthy means every managed object exists
+	HealthHealthy = "Healthy"
+	// HealthDegraded means managed objects are missing or could not be read
+	HealthDegraded = "Degraded"
+)
+
+// ConsoleAsset is one catalog asset as the console lists it: whether it is applied and,
+// when not, the reason code to show and localize
+type ConsoleAsset struct {
+	Asset     string `json:"asset"`
+	Component string `json:"component"`
+	// Status is INCLUDED, EXCLUDED, FILTERED or ERROR, as in render output
+	Status   string        `json:"status"`
+	Included bool          `json:"included"`
+	Reason   engine.Reason `json:"reason,omitempty"`
+	Message  string        `json:"message,omitempty"`
+	// Kind, Namespace and Name identify the rendered object of an included asset, so the
+	// console can link to it
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// ConsoleAssetList is the response of /api/v1alpha1/console/assets
+type ConsoleAssetList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []ConsoleAsset `json:"items"`
+}
+
+// ConsoleHealth is the response of /api/v1alpha1/console/health: the inventory with
+// the counts a dashboard card shows
+type ConsoleHealth struct {
+	metav1.TypeMeta `json:",inline"`
+	// State is Healthy or Degraded
+	State string `json:"state"`
+	Total int    `json:"total"`
+	// Managed objects carry the autopilot's label; Unmanaged ones exist without it, e.g.
+	// before the first apply adopts them
+	Managed   int                   `json:"managed"`
+	Unmanaged int                   `json:"unmanaged"`
+	Missing   int                   `json:"missing"`
+	Errors    int                   `json:"errors"`
+	Items     []debug.InventoryItem `json:"items"`
+}
+
+// DriftEventList is the response of /api/v1alpha1/console/drift-events
+type DriftEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []debug.DriftEvent `json:"items"`
+}
+
+// handleConsoleAssets returns every catalog asset with its inclusion state
+func (s *Server) handleConsoleAssets(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	outputs, err := s.querier.Render(ctx, true)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, metav1.StatusReasonServiceUnavailable, err.Error())
+		return
+	}
+	items := make([]ConsoleAsset, 0, len(outputs))
+	for _, output := range outputs {
+		items = append(items, consoleAsset(output))
+	}
+	writeJSON(w, ConsoleAssetList{TypeMeta: typeMeta("ConsoleAssetList"), Items: items})
+}
+
+func consoleAsset(output pkgrender.RenderOutput) ConsoleAsset {
+	asset := ConsoleAsset{
+		Asset:      output.Asset,
+		Component:  output.Component,
+		Status:     output.Status,
+		Included:   output.Status == "INCLUDED",
+		Reason:     output.Reason,
+		Message:    output.Message,
+		Deprecated: output.Deprecated,
+	}
+	if output.Object != nil {
+		asset.Kind, asset.Namespace, asset.Name = output.Object.GetKind(), output.Object.GetNamespace(), output.Object.GetName()
+	}
+	return asset
+}
+
+// handleConsoleHealth returns the inventory summarized into a health state
+func (s *Server) handleConsoleHealth(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	items, err := s.querier.Inventory(ctx)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, metav1.StatusReasonServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, inventoryHealth(items))
+}
+
+func inventoryHealth(items []debug.InventoryItem) ConsoleHealth {
+	health := ConsoleHealth{TypeMeta: typeMeta("ConsoleHealth"), State: HealthHealthy, Total: len(items), Items: nonNil(items)}
+	for _, item := range items {
+		switch {
+		case item.Error != "":
+			health.Errors++
+		case !item.Present:
+			health.Missing++
+		case item.Managed:
+			health.Managed++
+		default:
+			health.Unmanaged++
+		}
+	}
+	if health.Errors > 0 || health.Missing > 0 {
+		health.State = HealthDegraded
+	}
+	return health
+}
+
+// handleConsoleDriftEvents returns the recent drift events, newest first. Query
+// parameters: limit=<n> (default 50, at most 500).
+func (s *Server) handleConsoleDriftEvents(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	limit := defaultDriftEvents
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			writeStatus(w, http.StatusBadRequest, metav1.StatusReasonBadRequest, fmt.Sprintf("invalid limit %q: must be a positive integer", value))
+			return
+		}
+		limit = min(n, maxDriftEvents)
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	events, err := s.querier.DriftEvents(ctx, limit)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, metav1.StatusReasonServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, DriftEventList{TypeMeta: typeMeta("DriftEventList"), Items: nonNil(events)})
+}
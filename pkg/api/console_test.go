@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+func TestConsoleAssets(t *testing.T) {
+	mc := &unstructured.Unstructured{}
+	mc.SetKind("MachineConfig")
+	mc.SetName("90-worker-swap")
+	querier := &fakeQuerier{outputs: []pkgrender.RenderOutput{
+		{Asset: "swap-enable", Component: "MachineConfig", Status: "INCLUDED", Object: mc},
+		{Asset: "pci-passthrough", Component: "MachineConfig", Status: "EXCLUDED",
+			Reason: engine.ReasonConditionsNotMet},
+	}}
+	server, _ := newTestServer(querier, "")
+
+	rec := get(t, server.Handler(), ConsolePathPrefix+"assets", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list ConsoleAssetList
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, "ConsoleAssetList", list.Kind)
+	require.Len(t, list.Items, 2, "excluded assets are listed too")
+	assert.Equal(t, ConsoleAsset{Asset: "swap-enable", Component: "MachineConfig", Status: "INCLUDED", Included: true,
+		Kind: "MachineConfig", Name: "90-worker-swap"}, list.Items[0])
+	assert.False(t, list.Items[1].Included)
+	assert.Equal(t, engine.ReasonConditionsNotMet, list.Items[1].Reason)
+}
+
+func TestConsoleHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		inventory []debug.InventoryItem
+		wantState string
+	}{
+		{"empty", nil, HealthHealthy},
+		{"managed and unmanaged", []debug.InventoryItem{
+			{Asset: "a", Present: true, Managed: true}, {Asset: "b", Present: true},
+		}, HealthHealthy},
+		{"missing object", []debug.InventoryItem{{Asset: "a", Present: true, Managed: true}, {Asset: "b"}}, HealthDegraded},
+		{"lookup error", []debug.InventoryItem{{Asset: "a", Error: "forbidden"}}, HealthDegraded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(&fakeQuerier{inventory: tt.inventory}, "")
+			rec := get(t, server.Handler(), ConsolePathPrefix+"health", "valid")
+			require.Equal(t, http.StatusOK, rec.Code)
+			var health ConsoleHealth
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &health))
+			assert.Equal(t, tt.wantState, health.State)
+			assert.Equal(t, len(tt.inventory), health.Total)
+			assert.Equal(t, health.Total, health.Managed+health.Unmanaged+health.Missing+health.Errors)
+			assert.NotNil(t, health.Items)
+		})
+	}
+}
+
+func TestConsoleDriftEvents(t *testing.T) {
+	drifts := make([]debug.DriftEvent, 60)
+	for i := range drifts {
+		drifts[i] = debug.DriftEvent{Reason: "DriftCorrected", Kind: "MachineConfig", Name: "90-worker-swap"}
+	}
+	server, _ := newTestServer(&fakeQuerier{drifts: drifts}, "")
+	handler := server.Handler()
+
+	decode := func(rec *httptest.ResponseRecorder) DriftEventList {
+		var list DriftEventList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		return list
+	}
+
+	rec := get(t, handler, ConsolePathPrefix+"drift-events", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, decode(rec).Items, defaultDriftEvents)
+
+	rec = get(t, handler, ConsolePathPrefix+"drift-events?limit=5", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, decode(rec).Items, 5)
+
+	rec = get(t, handler, ConsolePathPrefix+"drift-events?limit=-1", "valid")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCORS(t *testing.T) {
+	const console = "https://console-openshift-console.apps.example.com"
+	server, _ := newTestServer(&fakeQuerier{}, "")
+	server.SetAllowedOrigins([]string{console})
+	handler := server.Handler()
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, ConsolePathPrefix+"health", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		req.Header.Set("Access-Control-Request-Headers", "authorization")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("preflight from an allowed origin", func(t *testing.T) {
+		rec := preflight(console)
+		assert.Equal(t, http.StatusNoContent, rec.Code, "preflights carry no token and are not authorized")
+		assert.Equal(t, console, rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	})
+
+	t.Run("preflight from another origin", func(t *testing.T) {
+		rec := preflight("https://evil.example.com")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("request from an allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, ConsolePathPrefix+"health", nil)
+		req.Header.Set("Origin", console)
+		req.Header.Set("Authorization", "Bearer valid")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, console, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("request without a token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, ConsolePathPrefix+"health", nil)
+		req.Header.Set("Origin", console)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "CORS does not bypass authentication")
+	})
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api serves the autopilot's render, inventory and exclusion queries to
+// external orchestrators (fleet management, ACM policies, portals), and views shaped
+// for the OpenShift console plugin, as a versioned JSON API on its own TLS listener.
+// Unlike the debug endpoints, which bind to localhost without authentication, every
+// request is authenticated and authorized against the cluster.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+const (
+	// APIVersion is the apiVersion of every response body
+	APIVersion = "autopilot.kubevirt.io/v1alpha1"
+
+	// PathPrefix is the path every endpoint is served under
+	PathPrefix = "/api/v1alpha1/"
+
+	requestTimeout = 30 * time.Second
+)
+
+// Querier answers the API's queries against the live HCO; *debug.Server implements it
+type Querier interface {
+	Render(ctx context.Context, showExcluded bool) ([]pkgrender.RenderOutput, error)
+	Inventory(ctx context.Context) ([]debug.InventoryItem, error)
+	Exclusions(ctx context.Context) ([]debug.ExclusionInfo, error)
+	DriftEvents(ctx context.Context, limit int) ([]debug.DriftEvent, error)
+}
+
+// RenderList is the response of /api/v1alpha1/render
+type RenderList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []pkgrender.RenderOutput `json:"items"`
+}
+
+// InventoryList is the response of /api/v1alpha1/inventory
+type InventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []debug.InventoryItem `json:"items"`
+}
+
+// ExclusionList is the response of /api/v1alpha1/exclusions
+type ExclusionList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []debug.ExclusionInfo `json:"items"`
+}
+
+// Server serves the API. It implements manager.Runnable; it only reads, so it runs on
+// every replica, not just the leader.
+type Server struct {
+	addr       string
+	certFile   string
+	keyFile    string
+	querier    Querier
+	authorizer *Authorizer
+	// allowedOrigins are the browser origins CORS requests are accepted from
+	allowedOrigins []string
+}
+
+// NewServer creates an API server listening on addr with the given certificate and key
+func NewServer(addr, certFile, keyFile string, querier Querier, authorizer *Authorizer) *Server {
+	return &Server{
+		addr:       addr,
+		certFile:   certFile,
+		keyFile:    keyFile,
+		querier:    querier,
+		authorizer: authorizer,
+	}
+}
+
+// Handler returns the authenticated handler of all endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathPrefix+"render", s.handleRender)
+	mux.HandleFunc(PathPrefix+"inventory", s.handleInventory)
+	mux.HandleFunc(PathPrefix+"exclusions", s.handleExclusions)
+	mux.HandleFunc(ConsolePathPrefix+"assets", s.handleConsoleAssets)
+	mux.HandleFunc(ConsolePathPrefix+"health", s.handleConsoleHealth)
+	mux.HandleFunc(ConsolePathPrefix+"drift-events", s.handleConsoleDriftEvents)
+	return s.cors(s.authorize(mux))
+}
+
+// Start implements manager.Runnable: it serves until ctx is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("api")
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("Serving API", "address", listener.Addr().String())
+		errCh <- server.ServeTLS(listener, s.certFile, s.keyFile)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("API server failed: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// authorize rejects requests whose caller is not allowed to get the requested path
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision, err := s.authorizer.Authorize(r.Context(), r)
+		if err != nil {
+			log.FromContext(r.Context()).Error(err, "Failed to authorize API request", "path", r.URL.Path)
+			writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "authorization failed")
+			return
+		}
+		if !decision.Allowed {
+			reason := metav1.StatusReasonForbidden
+			if decision.Status == http.StatusUnauthorized {
+				reason = metav1.StatusReasonUnauthorized
+			}
+			writeStatus(w, decision.Status, reason, decision.Reason)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleRender renders every asset against the live HCO. Query parameters:
+// asset=<name> returns only that asset, show-excluded=true adds excluded and filtered assets.
+func (s *Server) handleRender(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	asset := r.URL.Query().Get("asset")
+	// A single asset is always reported, whether or not it is included
+	showExcluded := asset != "" || r.URL.Query().Get("show-excluded") == "true"
+
+	outputs, err := s.querier.Render(ctx, showExcluded)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, metav1.StatusReasonServiceUnavailable, err.Error())
+		return
+	}
+	if asset != "" {
+		outputs = filterAsset(outputs, asset)
+		if len(outputs) == 0 {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("asset %q not found", asset))
+			return
+		}
+	}
+	writeJSON(w, RenderList{TypeMeta: typeMeta("RenderList"), Items: nonNil(outputs)})
+}
+
+// handleInventory returns the live state of the object of every included asset
+func (s *Server) handleInventory(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	items, err := s.querier.Inventory(ctx)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, metav1.StatusReasonServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, InventoryList{TypeMeta: typeMeta("InventoryList"), Items: nonNil(items)})
+}
+
+// handleExclusions returns every excluded or filtered asset with the reason
+func (s *Server) handleExclusions(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+
+	exclusions, err := s.querier.Exclusions(ctx)
+	if err != nil {
+		writeStatus(w, http.StatusServiceUnavailable, metav1.StatusReasonServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, ExclusionList{TypeMeta: typeMeta("ExclusionList"), Items: nonNil(exclusions)})
+}
+
+func filterAsset(outputs []pkgrender.RenderOutput, asset string) []pkgrender.RenderOutput {
+	var filtered []pkgrender.RenderOutput
+	for _, output := range outputs {
+		if output.Asset == asset {
+			filtered = append(filtered, output)
+		}
+	}
+	return filtered
+}
+
+// nonNil makes empty results encode as [] rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: APIVersion, Kind: kind}
+}
+
+func allowGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet {
+		return true
+	}
+	writeStatus(w, http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, fmt.Sprintf("failed to marshal response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// writeStatus answers with a Kubernetes Status object, which clients of the API
+// server already know how to decode
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, message string) {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	}
+	data, _ := json.Marshal(status)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
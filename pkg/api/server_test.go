@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/debug"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+type fakeQuerier struct {
+	outputs    []pkgrender.RenderOutput
+	inventory  []debug.InventoryItem
+	exclusions []debug.ExclusionInfo
+	drifts     []debug.DriftEvent
+	err        error
+}
+
+func (q *fakeQuerier) Render(_ context.Context, showExcluded bool) ([]pkgrender.RenderOutput, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	var outputs []pkgrender.RenderOutput
+	for _, output := range q.outputs {
+		if showExcluded || output.Status == "INCLUDED" {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+func (q *fakeQuerier) Inventory(context.Context) ([]debug.InventoryItem, error) {
+	return q.inventory, q.err
+}
+
+func (q *fakeQuerier) Exclusions(context.Context) ([]debug.ExclusionInfo, error) {
+	return q.exclusions, q.err
+}
+
+func (q *fakeQuerier) DriftEvents(_ context.Context, limit int) ([]debug.DriftEvent, error) {
+	if len(q.drifts) > limit {
+		return q.drifts[:limit], q.err
+	}
+	return q.drifts, q.err
+}
+
+// reviewingClient accepts the token "valid" for user alice, who may only get allowedPath,
+// or every path when allowedPath is empty
+func reviewingClient(allowedPath string, reviews *[]authorizationv1.SubjectAccessReviewSpec) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == "valid" {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"fleet"}}
+				}
+			case *authorizationv1.SubjectAccessReview:
+				*reviews = append(*reviews, review.Spec)
+				review.Status.Allowed = allowedPath == "" || review.Spec.NonResourceAttributes.Path == allowedPath
+			default:
+				return errors.New("unexpected create")
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func newTestServer(querier Querier, allowedPath string) (*Server, *[]authorizationv1.SubjectAccessReviewSpec) {
+	reviews := &[]authorizationv1.SubjectAccessReviewSpec{}
+	return NewServer(":0", "", "", querier, NewAuthorizer(reviewingClient(allowedPath, reviews))), reviews
+}
+
+func get(t *testing.T, handler http.Handler, path, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeStatus(t *testing.T, rec *httptest.ResponseRecorder) metav1.Status {
+	t.Helper()
+	var status metav1.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	return status
+}
+
+func TestAuthorization(t *testing.T) {
+	server, reviews := newTestServer(&fakeQuerier{}, PathPrefix+"inventory")
+	handler := server.Handler()
+
+	t.Run("missing token", func(t *testing.T) {
+		rec := get(t, handler, PathPrefix+"inventory", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, metav1.StatusReasonUnauthorized, decodeStatus(t, rec).Reason)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		rec := get(t, handler, PathPrefix+"inventory", "stolen")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("path not granted", func(t *testing.T) {
+		rec := get(t, handler, PathPrefix+"render", "valid")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, decodeStatus(t, rec).Message, `user "alice" may not get /api/v1alpha1/render`)
+	})
+
+	t.Run("path granted", func(t *testing.T) {
+		*reviews = nil
+		rec := get(t, handler, PathPrefix+"inventory", "valid")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		require.Len(t, *reviews, 1)
+		assert.Equal(t, "alice", (*reviews)[0].User)
+		assert.Equal(t, []string{"fleet"}, (*reviews)[0].Groups)
+		assert.Equal(t, &authorizationv1.NonResourceAttributes{Path: PathPrefix + "inventory", Verb: "get"},
+			(*reviews)[0].NonResourceAttributes)
+	})
+}
+
+func TestRender(t *testing.T) {
+	querier := &fakeQuerier{outputs: []pkgrender.RenderOutput{
+		{Asset: "swap-enable", Status: "INCLUDED"},
+		{Asset: "pci-passthrough", Status: "EXCLUDED", Reason: engine.ReasonConditionsNotMet},
+	}}
+	server, _ := newTestServer(querier, "")
+	handler := server.Handler()
+
+	decode := func(rec *httptest.ResponseRecorder) RenderList {
+		var list RenderList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		return list
+	}
+
+	rec := get(t, handler, PathPrefix+"render", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	list := decode(rec)
+	assert.Equal(t, APIVersion, list.APIVersion)
+	assert.Equal(t, "RenderList", list.Kind)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "swap-enable", list.Items[0].Asset)
+
+	rec = get(t, handler, PathPrefix+"render?show-excluded=true", "valid")
+	assert.Len(t, decode(rec).Items, 2)
+
+	// A single asset is reported even when excluded
+	rec = get(t, handler, PathPrefix+"render?asset=pci-passthrough", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	list = decode(rec)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, engine.ReasonConditionsNotMet, list.Items[0].Reason)
+
+	rec = get(t, handler, PathPrefix+"render?asset=unknown", "valid")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, metav1.StatusReasonNotFound, decodeStatus(t, rec).Reason)
+}
+
+func TestInventoryAndExclusions(t *testing.T) {
+	querier := &fakeQuerier{exclusions: []debug.ExclusionInfo{{Asset: "pci-passthrough", Reason: engine.ReasonConditionsNotMet}}}
+	server, _ := newTestServer(querier, "")
+	handler := server.Handler()
+
+	rec := get(t, handler, PathPrefix+"inventory", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"apiVersion":"autopilot.kubevirt.io/v1alpha1","kind":"InventoryList","items":[]}`, rec.Body.String())
+
+	rec = get(t, handler, PathPrefix+"exclusions", "valid")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list ExclusionList
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, "ExclusionList", list.Kind)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "pci-passthrough", list.Items[0].Asset)
+}
+
+func TestQueryErrors(t *testing.T) {
+	server, _ := newTestServer(&fakeQuerier{err: errors.New("no HyperConverged resources found")}, "")
+	handler := server.Handler()
+
+	for _, endpoint := range []string{"render", "inventory", "exclusions", "console/assets", "console/health", "console/drift-events"} {
+		rec := get(t, handler, PathPrefix+endpoint, "valid")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, endpoint)
+		assert.Equal(t, "no HyperConverged resources found", decodeStatus(t, rec).Message, endpoint)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, PathPrefix+"render", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
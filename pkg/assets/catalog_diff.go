@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CatalogDiff is the difference between two asset catalogs, e.g. the catalogs
+// shipped by two operator versions
+type CatalogDiff struct {
+	FromVersion string          `json:"fromVersion,omitempty"`
+	ToVersion   string          `json:"toVersion,omitempty"`
+	Added       []AssetMetadata `json:"added"`
+	Removed     []AssetMetadata `json:"removed"`
+	Changed     []AssetChange   `json:"changed"`
+	Unchanged   int             `json:"unchanged"`
+}
+
+// AssetChange lists the catalog fields that differ for an asset present in both catalogs
+type AssetChange struct {
+	Name   string        `json:"name"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange is a single changed catalog field, formatted for display
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Empty reports whether the two catalogs manage the same assets in the same way
+func (d *CatalogDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffCatalogs compares assets by name. Added and changed assets are listed in
+// the order of the to catalog, removed assets in the order of the from catalog.
+func DiffCatalogs(from, to *AssetCatalog) CatalogDiff {
+	diff := CatalogDiff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Added:       []AssetMetadata{},
+		Removed:     []AssetMetadata{},
+		Changed:     []AssetChange{},
+	}
+
+	previous := make(map[string]*AssetMetadata, len(from.Assets))
+	for i := range from.Assets {
+		previous[from.Assets[i].Name] = &from.Assets[i]
+	}
+	current := make(map[string]bool, len(to.Assets))
+
+	for i := range to.Assets {
+		next := &to.Assets[i]
+		current[next.Name] = true
+
+		prev, ok := previous[next.Name]
+		if !ok {
+			diff.Added = append(diff.Added, *next)
+			continue
+		}
+
+		fields := diffAssetFields(prev, next)
+		if len(fields) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		diff.Changed = append(diff.Changed, AssetChange{Name: next.Name, Fields: fields})
+	}
+
+	for _, asset := range from.Assets {
+		if !current[asset.Name] {
+			diff.Removed = append(diff.Removed, asset)
+		}
+	}
+
+	return diff
+}
+
+// diffAssetFields compares the catalog (metadata.yaml) fields of two versions of an asset
+func diffAssetFields(a, b *AssetMetadata) []FieldChange {
+	pairs := []struct {
+		field    string
+		from, to string
+	}{
+		{"path", a.Path, b.Path},
+		{"phase", strconv.Itoa(a.Phase), strconv.Itoa(b.Phase)},
+		{"install", string(a.Install), string(b.Install)},
+		{"component", a.Component, b.Component},
+		{"scope", string(a.Scope), string(b.Scope)},
+		{"group", a.Group, b.Group},
+		{"gate_crd", a.GateCRD, b.GateCRD},
+		{"reconcile_order", strconv.Itoa(a.ReconcileOrder), strconv.Itoa(b.ReconcileOrder)},
+		{"conditions", FormatConditions(a.Conditions), FormatConditions(b.Conditions)},
+		{"deprecated", strconv.FormatBool(a.Deprecated), strconv.FormatBool(b.Deprecated)},
+		{"replaced_by", a.ReplacedBy, b.ReplacedBy},
+		{"removal_version", a.RemovalVersion, b.RemovalVersion},
+	}
+
+	var fields []FieldChange
+	for _, p := range pairs {
+		if p.from != p.to {
+			fields = append(fields, FieldChange{Field: p.field, From: p.from, To: p.to})
+		}
+	}
+	return fields
+}
+
+// String formats a condition compactly, e.g. annotation(platform.kubevirt.io/enable-mtv=true)
+func (c AssetCondition) String() string {
+	var args []string
+	if c.Detector != "" {
+		args = append(args, c.Detector)
+	}
+	switch {
+	case c.Key != "" && c.Value != "":
+		args = append(args, c.Key+"="+c.Value)
+	case c.Key != "":
+		args = append(args, c.Key)
+	case c.Value != "":
+		args = append(args, c.Value)
+	}
+	if len(args) == 0 {
+		return string(c.Type)
+	}
+	return fmt.Sprintf("%s(%s)", c.Type, strings.Join(args, ", "))
+}
+
+// FormatConditions joins conditions with " AND " (all must hold), or returns "none"
+func FormatConditions(conditions []AssetCondition) string {
+	if len(conditions) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(conditions))
+	for i, c := range conditions {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " AND ")
+}
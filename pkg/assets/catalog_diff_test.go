@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	from := &AssetCatalog{Assets: []AssetMetadata{
+		{Name: "same", Path: "active/a/same.yaml", Install: InstallModeAlways},
+		{Name: "promoted", Path: "active/a/promoted.yaml", Install: InstallModeOptIn,
+			Conditions: []AssetCondition{{Type: ConditionTypeAnnotation, Key: "platform.kubevirt.io/enable-x", Value: "true"}}},
+		{Name: "dropped", Path: "active/a/dropped.yaml"},
+	}}
+	to := &AssetCatalog{Assets: []AssetMetadata{
+		{Name: "new", Path: "active/a/new.yaml", Install: InstallModeAlways},
+		{Name: "same", Path: "active/a/same.yaml", Install: InstallModeAlways},
+		{Name: "promoted", Path: "active/a/promoted.yaml", Install: InstallModeAlways},
+	}}
+
+	diff := DiffCatalogs(from, to)
+
+	if len(diff.Added) != 1 || diff.Added[0].Name != "new" {
+		t.Errorf("Added = %v, want [new]", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "dropped" {
+		t.Errorf("Removed = %v, want [dropped]", diff.Removed)
+	}
+	if diff.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", diff.Unchanged)
+	}
+
+	wantChanged := []AssetChange{{
+		Name: "promoted",
+		Fields: []FieldChange{
+			{Field: "install", From: "opt-in", To: "always"},
+			{Field: "conditions", From: "annotation(platform.kubevirt.io/enable-x=true)", To: "none"},
+		},
+	}}
+	if !reflect.DeepEqual(diff.Changed, wantChanged) {
+		t.Errorf("Changed = %+v, want %+v", diff.Changed, wantChanged)
+	}
+	if diff.Empty() {
+		t.Error("Empty() = true for differing catalogs")
+	}
+
+	if same := DiffCatalogs(to, to); !same.Empty() || same.Unchanged != len(to.Assets) {
+		t.Errorf("DiffCatalogs(to, to) = %+v, want empty", same)
+	}
+}
+
+func TestFormatConditions(t *testing.T) {
+	tests := []struct {
+		conditions []AssetCondition
+		expected   string
+	}{
+		{nil, "none"},
+		{[]AssetCondition{{Type: ConditionTypeFIPS}}, "fips"},
+		{[]AssetCondition{{Type: ConditionTypeHardwareDetection, Detector: "pciDevicesPresent"}}, "hardware-detection(pciDevicesPresent)"},
+		{[]AssetCondition{{Type: ConditionTypeImage, Key: "kubevirt-metrics-exporter"}}, "image(kubevirt-metrics-exporter)"},
+		{
+			[]AssetCondition{
+				{Type: ConditionTypeFeatureGate, Value: "downwardMetrics"},
+				{Type: ConditionTypeAnnotation, Key: "a", Value: "b"},
+			},
+			"feature-gate(downwardMetrics) AND annotation(a=b)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := FormatConditions(tt.conditions); got != tt.expected {
+			t.Errorf("FormatConditions(%v) = %q, want %q", tt.conditions, got, tt.expected)
+		}
+	}
+}
@@ -17,11 +17,12 @@ limitations under the License.
 package assets
 
 import (
-	"embed"
 	"fmt"
 	"io/fs"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
@@ -39,21 +40,50 @@ const (
 	MaxYAMLDepth = 100
 )
 
-// Loader handles loading and parsing assets from embedded filesystem
+// CatalogPath is the path of the asset catalog within an asset filesystem
+const CatalogPath = "active/metadata.yaml"
+
+// Loader handles loading and parsing assets from the embedded filesystem or, when
+// a remote catalog is configured, from the catalog archive that replaced it
 type Loader struct {
-	fs embed.FS
+	content atomic.Pointer[loaderContent]
+}
+
+// loaderContent is one immutable asset filesystem with its parsed catalog
+type loaderContent struct {
+	fs fs.FS
+
+	catalogOnce sync.Once
+	catalog     *AssetCatalog
+	catalogErr  error
 }
 
 // NewLoader creates a new asset loader
 func NewLoader() *Loader {
-	return &Loader{
-		fs: embeddedassets.EmbeddedFS,
-	}
+	return NewLoaderFromFS(embeddedassets.EmbeddedFS)
+}
+
+// NewLoaderFromFS creates a loader serving assets from fsys, laid out like the
+// assets directory (active/metadata.yaml, active/..., tombstones/...)
+func NewLoaderFromFS(fsys fs.FS) *Loader {
+	l := &Loader{}
+	l.content.Store(&loaderContent{fs: fsys})
+	return l
+}
+
+// Replace makes l serve the assets of other. Loads already in progress finish
+// against the previous assets.
+func (l *Loader) Replace(other *Loader) {
+	l.content.Store(other.content.Load())
+}
+
+func (l *Loader) fs() fs.FS {
+	return l.content.Load().fs
 }
 
 // LoadAsset loads a single asset by path and returns its raw content
 func (l *Loader) LoadAsset(path string) ([]byte, error) {
-	data, err := l.fs.ReadFile(path)
+	data, err := fs.ReadFile(l.fs(), path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read asset %s: %w", path, err)
 	}
@@ -61,6 +91,21 @@ func (l *Loader) LoadAsset(path string) ([]byte, error) {
 	return data, nil
 }
 
+// Catalog returns the parsed asset catalog, parsed once per set of assets.
+// Unlike a Registry it carries no fields derived from the asset files.
+func (l *Loader) Catalog() (*AssetCatalog, error) {
+	content := l.content.Load()
+	content.catalogOnce.Do(func() {
+		data, err := fs.ReadFile(content.fs, CatalogPath)
+		if err != nil {
+			content.catalogErr = err
+			return
+		}
+		content.catalog, content.catalogErr = ParseCatalog(data)
+	})
+	return content.catalog, content.catalogErr
+}
+
 // LoadAssetAsUnstructured loads an asset and parses it as an unstructured object
 // This is for non-template assets (raw YAML)
 func (l *Loader) LoadAssetAsUnstructured(path string) (*unstructured.Unstructured, error) {
@@ -94,7 +139,7 @@ func (l *Loader) ListAssets(pattern string) ([]string, error) {
 	var matches []string
 
 	// Walk the embedded filesystem
-	err := fs.WalkDir(l.fs, ".", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(l.fs(), ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OutputPath splits the dotted field path of an output, e.g. "metadata.name"
+func OutputPath(path string) []string {
+	return strings.Split(path, ".")
+}
+
+// validatePipelines checks that every input names another asset declaring outputs,
+// that output paths are well formed, and that inputs do not form a cycle.
+func validatePipelines(catalog *AssetCatalog) error {
+	byName := make(map[string]*AssetMetadata, len(catalog.Assets))
+	for i := range catalog.Assets {
+		byName[catalog.Assets[i].Name] = &catalog.Assets[i]
+	}
+
+	for _, asset := range catalog.Assets {
+		for name, path := range asset.Outputs {
+			if name == "" {
+				return fmt.Errorf("asset %s declares an output without a name", asset.Name)
+			}
+			for _, field := range OutputPath(path) {
+				if field == "" {
+					return fmt.Errorf("asset %s output %s has invalid path %q", asset.Name, name, path)
+				}
+			}
+		}
+		for _, input := range asset.Inputs {
+			producer, ok := byName[input]
+			switch {
+			case input == asset.Name:
+				return fmt.Errorf("asset %s cannot consume its own outputs", asset.Name)
+			case !ok:
+				return fmt.Errorf("asset %s consumes unknown asset %s", asset.Name, input)
+			case len(producer.Outputs) == 0:
+				return fmt.Errorf("asset %s consumes %s, which declares no outputs", asset.Name, input)
+			}
+		}
+	}
+
+	// Depth-first search over inputs; an asset met again while on the stack closes a cycle
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(catalog.Assets))
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("asset inputs form a cycle: %s", strings.Join(append(chain, name), " -> "))
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		for _, input := range byName[name].Inputs {
+			if err := visit(input, append(chain, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		return nil
+	}
+	for _, asset := range catalog.Assets {
+		if err := visit(asset.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
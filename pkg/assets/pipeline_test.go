@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePipelines(t *testing.T) {
+	name := map[string]string{"name": "metadata.name"}
+	tests := []struct {
+		name    string
+		assets  []AssetMetadata
+		wantErr string
+	}{
+		{"no pipelines", []AssetMetadata{{Name: "a"}, {Name: "b"}}, ""},
+		{"chain", []AssetMetadata{
+			{Name: "a", Outputs: name},
+			{Name: "b", Outputs: name, Inputs: []string{"a"}},
+			{Name: "c", Inputs: []string{"a", "b"}},
+		}, ""},
+		{"unknown input", []AssetMetadata{{Name: "a", Inputs: []string{"missing"}}}, "unknown asset missing"},
+		{"input without outputs", []AssetMetadata{{Name: "a"}, {Name: "b", Inputs: []string{"a"}}}, "declares no outputs"},
+		{"self input", []AssetMetadata{{Name: "a", Outputs: name, Inputs: []string{"a"}}}, "its own outputs"},
+		{"empty path segment", []AssetMetadata{{Name: "a", Outputs: map[string]string{"name": "metadata..name"}}}, "invalid path"},
+		{"cycle", []AssetMetadata{
+			{Name: "a", Outputs: name, Inputs: []string{"c"}},
+			{Name: "b", Outputs: name, Inputs: []string{"a"}},
+			{Name: "c", Outputs: name, Inputs: []string{"b"}},
+		}, "a -> c -> b -> a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelines(&AssetCatalog{Assets: tt.assets})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validatePipelines() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validatePipelines() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,12 +18,17 @@ package assets
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 )
 
@@ -35,6 +40,15 @@ const (
 	InstallModeOptIn  InstallMode = "opt-in"
 )
 
+// Scope declares whether an asset renders cluster-scoped or namespaced objects.
+// Values match CustomResourceDefinition spec.scope.
+type Scope string
+
+const (
+	ScopeCluster    Scope = "Cluster"
+	ScopeNamespaced Scope = "Namespaced"
+)
+
 // ConditionType defines the type of condition for asset activation
 type ConditionType string
 
@@ -43,14 +57,34 @@ const (
 	ConditionTypeFeatureGate       ConditionType = "feature-gate"
 	ConditionTypeAnnotation        ConditionType = "annotation"
 	ConditionTypeImage             ConditionType = "image"
+	ConditionTypeFIPS              ConditionType = "fips"
+	ConditionTypeStorage           ConditionType = "storage"
+	ConditionTypeNetwork           ConditionType = "network"
+	ConditionTypeIPFamily          ConditionType = "ip-family"
+	ConditionTypeArchitecture      ConditionType = "architecture"
+
+	// Conditions that query live cluster resources when evaluated
+	ConditionTypeCRD          ConditionType = "crd"
+	ConditionTypeOperator     ConditionType = "operator"
+	ConditionTypeStorageClass ConditionType = "storage-class"
 )
 
+// QueriesCluster reports whether conditions of this type are evaluated against live
+// cluster resources rather than the render context
+func (t ConditionType) QueriesCluster() bool {
+	switch t {
+	case ConditionTypeCRD, ConditionTypeOperator, ConditionTypeStorageClass:
+		return true
+	}
+	return false
+}
+
 // AssetCondition defines a condition that must be met for an asset to be applied
 type AssetCondition struct {
 	Type     ConditionType `json:"type"`
-	Detector string        `json:"detector,omitempty"` // For hardware-detection
+	Detector string        `json:"detector,omitempty"` // For hardware-detection/storage/network
 	Key      string        `json:"key,omitempty"`      // For annotation
-	Value    string        `json:"value,omitempty"`    // For annotation/feature-gate
+	Value    string        `json:"value,omitempty"`    // For annotation/feature-gate/fips/ip-family/architecture/crd/operator/storage-class
 }
 
 // AssetMetadata defines the metadata for a managed asset
@@ -62,39 +96,75 @@ type AssetMetadata struct {
 	Phase           int                        `json:"phase"`
 	Install         InstallMode                `json:"install"`
 	Component       string                     `json:"component"`
+	Scope           Scope                      `json:"scope,omitempty"` // Cluster or Namespaced; required in the embedded catalog
 	ReconcileOrder  int                        `json:"reconcile_order"`
 	Conditions      []AssetCondition           `json:"conditions,omitempty"`
-	RenderedContent *unstructured.Unstructured `json:"-"` // Cached rendered content
-	RequiredCRD     string                     `json:"-"` // Derived from template at load time; empty for core API types
+	Deprecated      bool                       `json:"deprecated,omitempty"`      // Asset is scheduled for removal (tombstoning)
+	ReplacedBy      string                     `json:"replaced_by,omitempty"`     // Optional name of the asset superseding this one
+	RemovalVersion  string                     `json:"removal_version,omitempty"` // Optional release in which the asset is tombstoned
+	Outputs         map[string]string          `json:"outputs,omitempty"`         // Named values other assets can consume: output name to dotted field path
+	Inputs          []string                   `json:"inputs,omitempty"`          // Assets whose outputs this asset's template reads via .Outputs
+	APIVersions     []string                   `json:"api_versions,omitempty"`    // Candidate apiVersions, most preferred first; the first one the installed CRD serves is rendered
+	RenderedContent *unstructured.Unstructured `json:"-"`                         // Cached rendered content
+	RequiredCRD     string                     `json:"-"`                         // Derived from template at load time; empty for core API types
 }
 
 // AssetCatalog contains all asset metadata
 type AssetCatalog struct {
-	Assets []AssetMetadata `json:"assets"`
+	// Version is bumped by hand when the set of managed assets or their behavior changes
+	Version string          `json:"version,omitempty"`
+	Assets  []AssetMetadata `json:"assets"`
 }
 
 // Registry manages the asset catalog and provides querying capabilities
 type Registry struct {
+	// mu guards the catalog against Replace; the catalog itself is never modified
+	mu      sync.RWMutex
 	catalog *AssetCatalog
 	loader  *Loader
+
+	// namespaces holds, per namespaced kind, the literal namespaces its assets render into.
+	// A nil set means at least one asset of that kind has a templated namespace.
+	namespaces map[schema.GroupVersionKind]map[string]bool
+
+	// kinds holds every apiVersion/kind the assets declare, candidate apiVersions included
+	kinds map[schema.GroupVersionKind]bool
+
+	// digest is a short hash of metadata.yaml and every asset file it references
+	digest string
 }
 
 // NewRegistry creates a new asset registry
 func NewRegistry(loader *Loader) (*Registry, error) {
 	// Load metadata.yaml
-	data, err := loader.LoadAsset("active/metadata.yaml")
+	data, err := loader.LoadAsset(CatalogPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load asset catalog: %w", err)
 	}
 
-	catalog := &AssetCatalog{}
-	if err := yaml.Unmarshal(data, catalog); err != nil {
-		return nil, fmt.Errorf("failed to parse asset catalog: %w", err)
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &Registry{
+		catalog:    catalog,
+		loader:     loader,
+		namespaces: make(map[schema.GroupVersionKind]map[string]bool),
+		kinds:      make(map[schema.GroupVersionKind]bool),
 	}
 
-	// Derive RequiredCRD for each asset by parsing its template
+	digest := sha256.New()
+	digest.Write(data)
+
+	// Derive RequiredCRD for each asset by parsing its template, and check that the
+	// objects it declares match its scope
 	for i := range catalog.Assets {
 		asset := &catalog.Assets[i]
+		if asset.Scope == "" {
+			return nil, fmt.Errorf("invalid asset catalog: asset %s must declare scope (%s or %s)",
+				asset.Name, ScopeCluster, ScopeNamespaced)
+		}
 		if asset.Path == "" {
 			continue
 		}
@@ -102,17 +172,252 @@ func NewRegistry(loader *Loader) (*Registry, error) {
 		if err != nil {
 			continue // non-fatal; RequiredCRD stays empty
 		}
-		asset.RequiredCRD = extractRequiredCRD(content, strings.HasSuffix(asset.Path, ".tpl"))
+		digest.Write([]byte(asset.Path))
+		digest.Write(content)
+		isTemplate := strings.HasSuffix(asset.Path, ".tpl")
+		asset.RequiredCRD = extractRequiredCRD(content, isTemplate)
+
+		objects := extractObjects(content, isTemplate)
+		if err := asset.checkAPIVersions(objects); err != nil {
+			return nil, fmt.Errorf("invalid asset catalog: %w", err)
+		}
+		for _, obj := range objects {
+			registry.kinds[obj.gvk] = true
+			for _, apiVersion := range asset.APIVersions {
+				registry.kinds[schema.FromAPIVersionAndKind(apiVersion, obj.gvk.Kind)] = true
+			}
+			if err := asset.checkNamespacePresence(obj.gvk.Kind, obj.name, obj.hasNamespace); err != nil {
+				return nil, fmt.Errorf("invalid asset catalog: %w", err)
+			}
+			if asset.Scope == ScopeNamespaced {
+				registry.recordNamespace(obj.gvk, obj.namespace)
+			}
+		}
 	}
+	registry.digest = hex.EncodeToString(digest.Sum(nil))[:12]
+
+	return registry, nil
+}
+
+// Replace makes r serve the catalog of other, e.g. one fetched from a remote source.
+// Assets already returned by r stay valid but describe the previous catalog.
+func (r *Registry) Replace(other *Registry) {
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.catalog = other.catalog
+	r.loader = other.loader
+	r.namespaces = other.namespaces
+	r.kinds = other.kinds
+	r.digest = other.digest
+}
+
+// CatalogVersion returns the version declared in metadata.yaml, or "" if none
+func (r *Registry) CatalogVersion() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.catalog.Version
+}
+
+// CatalogDigest returns a short content hash of the catalog and its asset files.
+// Unlike CatalogVersion it changes with every content change, bumped or not.
+func (r *Registry) CatalogDigest() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.digest
+}
 
-	return &Registry{
-		catalog: catalog,
-		loader:  loader,
-	}, nil
+// recordNamespace adds namespace to the set of gvk, or marks gvk as unrestricted
+// when the namespace is templated
+func (r *Registry) recordNamespace(gvk schema.GroupVersionKind, namespace string) {
+	set, seen := r.namespaces[gvk]
+	if seen && set == nil {
+		return
+	}
+	if namespace == "" || namespace == templatePlaceholder {
+		r.namespaces[gvk] = nil
+		return
+	}
+	if set == nil {
+		set = make(map[string]bool)
+		r.namespaces[gvk] = set
+	}
+	set[namespace] = true
+}
+
+// Kinds returns every apiVersion/kind the catalog's assets declare, including the
+// candidate apiVersions of assets with api_versions, sorted
+func (r *Registry) Kinds() []schema.GroupVersionKind {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	kinds := make([]schema.GroupVersionKind, 0, len(r.kinds))
+	for gvk := range r.kinds {
+		kinds = append(kinds, gvk)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+	return kinds
+}
+
+// AssetNamespaces returns, for each namespaced kind whose assets all render into literal
+// namespaces, those namespaces sorted. Kinds with a templated namespace anywhere are omitted,
+// since the namespace is only known at render time.
+func (r *Registry) AssetNamespaces() map[schema.GroupVersionKind][]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make(map[schema.GroupVersionKind][]string)
+	for gvk, set := range r.namespaces {
+		if set == nil {
+			continue
+		}
+		namespaces := make([]string, 0, len(set))
+		for ns := range set {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Strings(namespaces)
+		result[gvk] = namespaces
+	}
+	return result
+}
+
+// CheckNamespacesWithin returns an error when an asset of a kind restricted to the
+// namespaces in allowed (as returned by AssetNamespaces) renders into another or a
+// templated namespace. A cache restricted to allowed would not see such objects.
+func (r *Registry) CheckNamespacesWithin(allowed map[schema.GroupVersionKind][]string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for gvk, set := range r.namespaces {
+		restricted, ok := allowed[gvk]
+		if !ok {
+			continue
+		}
+		if set == nil {
+			return fmt.Errorf("%s objects render into a templated namespace, but are only watched in %v", gvk.Kind, restricted)
+		}
+		for ns := range set {
+			if !slices.Contains(restricted, ns) {
+				return fmt.Errorf("%s objects render into namespace %s, but are only watched in %v", gvk.Kind, ns, restricted)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckScope returns an error when obj's namespace presence contradicts the asset's
+// declared scope. Assets without a declared scope are not checked.
+func (a *AssetMetadata) CheckScope(obj *unstructured.Unstructured) error {
+	if obj == nil {
+		return nil
+	}
+	return a.checkNamespacePresence(obj.GetKind(), obj.GetName(), obj.GetNamespace() != "")
+}
+
+func (a *AssetMetadata) checkNamespacePresence(kind, name string, hasNamespace bool) error {
+	switch {
+	case a.Scope == ScopeCluster && hasNamespace:
+		return fmt.Errorf("asset %s is declared scope %s but %s %s sets metadata.namespace", a.Name, a.Scope, kind, name)
+	case a.Scope == ScopeNamespaced && !hasNamespace:
+		return fmt.Errorf("asset %s is declared scope %s but %s %s has no metadata.namespace", a.Name, a.Scope, kind, name)
+	}
+	return nil
+}
+
+// checkAPIVersions verifies that the apiVersion candidates can replace the apiVersion
+// of every object in the asset: the objects must be custom resources of one group, and
+// the candidates must be versions of that group including the one the file declares,
+// so offline renders without a cluster stay consistent with the catalog.
+func (a *AssetMetadata) checkAPIVersions(objects []assetObject) error {
+	if len(a.APIVersions) == 0 {
+		return nil
+	}
+	if a.RequiredCRD == "" {
+		return fmt.Errorf("asset %s sets api_versions but does not manage a custom resource", a.Name)
+	}
+	for _, obj := range objects {
+		if crdNameFromGVK(obj.gvk.GroupVersion().String(), obj.gvk.Kind) != a.RequiredCRD {
+			return fmt.Errorf("asset %s sets api_versions but %s %s is not a %s", a.Name, obj.gvk.Kind, obj.name, a.RequiredCRD)
+		}
+		if !slices.Contains(a.APIVersions, obj.gvk.GroupVersion().String()) {
+			return fmt.Errorf("asset %s declares apiVersion %s, which is missing from its api_versions", a.Name, obj.gvk.GroupVersion())
+		}
+	}
+	group := strings.SplitN(a.RequiredCRD, ".", 2)[1]
+	for _, candidate := range a.APIVersions {
+		gv, err := schema.ParseGroupVersion(candidate)
+		if err != nil || gv.Group != group || gv.Version == "" {
+			return fmt.Errorf("asset %s has api_versions entry %q, want a version of group %s", a.Name, candidate, group)
+		}
+	}
+	return nil
+}
+
+// ParseCatalog parses and validates metadata.yaml content
+func ParseCatalog(data []byte) (*AssetCatalog, error) {
+	catalog := &AssetCatalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse asset catalog: %w", err)
+	}
+
+	if err := validateDeprecations(catalog); err != nil {
+		return nil, fmt.Errorf("invalid asset catalog: %w", err)
+	}
+	if err := validatePipelines(catalog); err != nil {
+		return nil, fmt.Errorf("invalid asset catalog: %w", err)
+	}
+	for _, asset := range catalog.Assets {
+		if asset.Scope != "" && asset.Scope != ScopeCluster && asset.Scope != ScopeNamespaced {
+			return nil, fmt.Errorf("invalid asset catalog: asset %s has scope %q, want %s or %s",
+				asset.Name, asset.Scope, ScopeCluster, ScopeNamespaced)
+		}
+	}
+
+	return catalog, nil
+}
+
+// DeprecationNotice returns a human-readable deprecation message, or "" if the asset is not deprecated
+func (a *AssetMetadata) DeprecationNotice() string {
+	if !a.Deprecated {
+		return ""
+	}
+	notice := fmt.Sprintf("asset %s is deprecated", a.Name)
+	if a.RemovalVersion != "" {
+		notice += fmt.Sprintf(" and will be removed in %s", a.RemovalVersion)
+	}
+	if a.ReplacedBy != "" {
+		notice += fmt.Sprintf("; use %s instead", a.ReplacedBy)
+	}
+	return notice
+}
+
+// validateDeprecations checks that replaced_by and removal_version are only set on
+// deprecated assets and that replaced_by names another asset in the catalog.
+func validateDeprecations(catalog *AssetCatalog) error {
+	names := make(map[string]bool, len(catalog.Assets))
+	for _, asset := range catalog.Assets {
+		names[asset.Name] = true
+	}
+
+	for _, asset := range catalog.Assets {
+		if !asset.Deprecated && (asset.ReplacedBy != "" || asset.RemovalVersion != "") {
+			return fmt.Errorf("asset %s sets replaced_by or removal_version without deprecated: true", asset.Name)
+		}
+		if asset.ReplacedBy == "" {
+			continue
+		}
+		if asset.ReplacedBy == asset.Name {
+			return fmt.Errorf("asset %s cannot be replaced by itself", asset.Name)
+		}
+		if !names[asset.ReplacedBy] {
+			return fmt.Errorf("asset %s is replaced by unknown asset %s", asset.Name, asset.ReplacedBy)
+		}
+	}
+	return nil
 }
 
 // GetAsset returns asset metadata by name
 func (r *Registry) GetAsset(name string) (*AssetMetadata, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	for i := range r.catalog.Assets {
 		if r.catalog.Assets[i].Name == name {
 			return &r.catalog.Assets[i], nil
@@ -123,6 +428,8 @@ func (r *Registry) GetAsset(name string) (*AssetMetadata, error) {
 
 // ListAssets returns all assets, optionally filtered by phase
 func (r *Registry) ListAssets(phase *int) []AssetMetadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	if phase == nil {
 		return r.catalog.Assets
 	}
@@ -136,12 +443,14 @@ func (r *Registry) ListAssets(phase *int) []AssetMetadata {
 	return filtered
 }
 
-// ListAssetsByReconcileOrder returns assets sorted by reconcile_order
+// ListAssetsByReconcileOrder returns assets sorted by reconcile_order, keeping catalog order within a step
 func (r *Registry) ListAssetsByReconcileOrder() []AssetMetadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	sorted := make([]AssetMetadata, len(r.catalog.Assets))
 	copy(sorted, r.catalog.Assets)
 
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].ReconcileOrder < sorted[j].ReconcileOrder
 	})
 
@@ -181,6 +490,8 @@ func (r *Registry) IsManagedCRD(crdName string) bool {
 	if crdName == "" {
 		return false
 	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	for i := range r.catalog.Assets {
 		if r.catalog.Assets[i].RequiredCRD == crdName {
 			return true
@@ -218,6 +529,41 @@ func extractRequiredCRD(content []byte, isTemplate bool) string {
 	return ""
 }
 
+// assetObject is a top-level object declared by an asset file
+type assetObject struct {
+	gvk          schema.GroupVersionKind
+	name         string
+	namespace    string // templatePlaceholder when templated
+	hasNamespace bool
+}
+
+// extractObjects parses raw asset content and returns every top-level object in it.
+// Template expressions are replaced by templatePlaceholder, so a templated namespace
+// still counts as present.
+func extractObjects(content []byte, isTemplate bool) []assetObject {
+	if isTemplate {
+		content = preprocessAssetTemplate(content)
+	}
+	var objects []assetObject
+	for _, doc := range strings.Split(string(content), "\n---\n") {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(strings.TrimSpace(doc)), &obj.Object); err != nil || obj.Object == nil {
+			continue
+		}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			continue
+		}
+		_, hasNamespace, _ := unstructured.NestedFieldNoCopy(obj.Object, "metadata", "namespace")
+		objects = append(objects, assetObject{
+			gvk:          obj.GroupVersionKind(),
+			name:         obj.GetName(),
+			namespace:    obj.GetNamespace(),
+			hasNamespace: hasNamespace,
+		})
+	}
+	return objects
+}
+
 // crdNameFromGVK derives the CRD name from an apiVersion+kind pair.
 // Returns "" for core API group types (e.g. apiVersion "v1") which have no CRD.
 func crdNameFromGVK(apiVersion, kind string) string {
@@ -276,11 +622,17 @@ func preprocessAssetTemplate(content []byte) []byte {
 	}
 	content = []byte(strings.Join(filtered, "\n"))
 	backtickRe := regexp.MustCompile("\\{\\{`[^`]*`\\}\\}")
-	content = backtickRe.ReplaceAll(content, []byte(`dummy-value`))
+	content = backtickRe.ReplaceAll(content, []byte(templatePlaceholder))
 	exprRe := regexp.MustCompile(`\{\{[^}]+\}\}`)
-	return exprRe.ReplaceAll(content, []byte(`dummy-value`))
+	return exprRe.ReplaceAll(content, []byte(templatePlaceholder))
 }
 
+// templatePlaceholder replaces template expressions when asset templates are parsed without rendering
+const templatePlaceholder = "dummy-value"
+
+// conditionArchitectures are the values of architecture conditions, as in the kubernetes.io/arch label
+var conditionArchitectures = []string{"amd64", "arm64", "ppc64le", "s390x"}
+
 // ConditionEvaluator defines the interface for evaluating asset conditions
 type ConditionEvaluator interface {
 	EvaluateCondition(ctx context.Context, condition AssetCondition) (bool, error)
@@ -292,6 +644,15 @@ type DefaultConditionEvaluator struct {
 	FeatureGates    map[string]bool   // Feature gate states
 	Annotations     map[string]string // Annotation values
 	Images          map[string]string // Container images from RELATED_IMAGE_* env vars
+	FIPS            bool              // Cluster installed in FIPS mode
+	Storage         map[string]bool   // Storage capability detection results
+	Network         map[string]bool   // Network capability detection results
+	IPFamilies      map[string]bool   // Address families carried by the cluster network
+	Architectures   map[string]bool   // CPU architectures of the nodes
+
+	// Cluster evaluates the conditions that query the cluster (see ConditionType.QueriesCluster).
+	// Without it, e.g. in offline rendering, such conditions are not met.
+	Cluster ConditionEvaluator
 }
 
 // EvaluateCondition evaluates a single condition
@@ -332,6 +693,57 @@ func (e *DefaultConditionEvaluator) EvaluateCondition(ctx context.Context, condi
 		img, ok := e.Images[condition.Key]
 		return ok && img != "", nil
 
+	case ConditionTypeFIPS:
+		// value "true" (default) requires FIPS mode, "false" requires it to be off
+		switch condition.Value {
+		case "", "true":
+			return e.FIPS, nil
+		case "false":
+			return !e.FIPS, nil
+		default:
+			return false, fmt.Errorf("fips condition value must be \"true\" or \"false\", got %q", condition.Value)
+		}
+
+	case ConditionTypeStorage:
+		if condition.Detector == "" {
+			return false, fmt.Errorf("storage condition requires detector field")
+		}
+		detected, ok := e.Storage[condition.Detector]
+		return ok && detected, nil
+
+	case ConditionTypeNetwork:
+		if condition.Detector == "" {
+			return false, fmt.Errorf("network condition requires detector field")
+		}
+		detected, ok := e.Network[condition.Detector]
+		return ok && detected, nil
+
+	case ConditionTypeIPFamily:
+		// value names a family the cluster network must carry, so IPv4-only assets
+		// declare "IPv4" and drop out on IPv6-only clusters
+		if condition.Value != "IPv4" && condition.Value != "IPv6" {
+			return false, fmt.Errorf("ip-family condition value must be \"IPv4\" or \"IPv6\", got %q", condition.Value)
+		}
+		return e.IPFamilies[condition.Value], nil
+
+	case ConditionTypeArchitecture:
+		// value names an architecture some node must run, so assets with arch-specific
+		// settings drop out on clusters without such nodes
+		if !slices.Contains(conditionArchitectures, condition.Value) {
+			return false, fmt.Errorf("architecture condition value must be one of %s, got %q",
+				strings.Join(conditionArchitectures, ", "), condition.Value)
+		}
+		return e.Architectures[condition.Value], nil
+
+	case ConditionTypeCRD, ConditionTypeOperator, ConditionTypeStorageClass:
+		if condition.Value == "" {
+			return false, fmt.Errorf("%s condition requires value field", condition.Type)
+		}
+		if e.Cluster == nil {
+			return false, nil
+		}
+		return e.Cluster.EvaluateCondition(ctx, condition)
+
 	default:
 		return false, fmt.Errorf("unknown condition type: %s", condition.Type)
 	}
@@ -18,7 +18,12 @@ package assets
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestNewRegistry(t *testing.T) {
@@ -103,6 +108,59 @@ func TestGetAsset(t *testing.T) {
 	})
 }
 
+func TestDeprecationNotice(t *testing.T) {
+	tests := []struct {
+		name  string
+		asset AssetMetadata
+		want  string
+	}{
+		{"not deprecated", AssetMetadata{Name: "a"}, ""},
+		{"deprecated", AssetMetadata{Name: "a", Deprecated: true}, "asset a is deprecated"},
+		{"with removal version", AssetMetadata{Name: "a", Deprecated: true, RemovalVersion: "4.20"},
+			"asset a is deprecated and will be removed in 4.20"},
+		{"with replacement", AssetMetadata{Name: "a", Deprecated: true, ReplacedBy: "b", RemovalVersion: "4.20"},
+			"asset a is deprecated and will be removed in 4.20; use b instead"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.asset.DeprecationNotice(); got != tt.want {
+				t.Errorf("DeprecationNotice() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDeprecations(t *testing.T) {
+	tests := []struct {
+		name    string
+		assets  []AssetMetadata
+		wantErr string
+	}{
+		{"no deprecations", []AssetMetadata{{Name: "a"}, {Name: "b"}}, ""},
+		{"valid replacement", []AssetMetadata{{Name: "a", Deprecated: true, ReplacedBy: "b", RemovalVersion: "4.20"}, {Name: "b"}}, ""},
+		{"unknown replacement", []AssetMetadata{{Name: "a", Deprecated: true, ReplacedBy: "missing"}}, "unknown asset missing"},
+		{"self replacement", []AssetMetadata{{Name: "a", Deprecated: true, ReplacedBy: "a"}}, "replaced by itself"},
+		{"replacement without deprecated", []AssetMetadata{{Name: "a", ReplacedBy: "b"}, {Name: "b"}}, "without deprecated"},
+		{"removal version without deprecated", []AssetMetadata{{Name: "a", RemovalVersion: "4.20"}}, "without deprecated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDeprecations(&AssetCatalog{Assets: tt.assets})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateDeprecations() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateDeprecations() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestListAssets(t *testing.T) {
 	loader := NewLoader()
 	registry, err := NewRegistry(loader)
@@ -365,6 +423,78 @@ func TestDefaultConditionEvaluator_EvaluateCondition(t *testing.T) {
 		testImageConditions(ctx, t)
 	})
 
+	t.Run("fips conditions", func(t *testing.T) {
+		testFIPSConditions(ctx, t)
+	})
+
+	t.Run("storage conditions", func(t *testing.T) {
+		testStorageConditions(ctx, t)
+	})
+
+	t.Run("network conditions", func(t *testing.T) {
+		evaluator := &DefaultConditionEvaluator{Network: map[string]bool{"ovnKubernetes": true, "multusPresent": false}}
+		for detector, want := range map[string]bool{"ovnKubernetes": true, "multusPresent": false, "unknown": false} {
+			satisfied, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeNetwork, Detector: detector})
+			if err != nil || satisfied != want {
+				t.Errorf("EvaluateCondition(%s) = %v, %v, want %v", detector, satisfied, err, want)
+			}
+		}
+		if _, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeNetwork}); err == nil {
+			t.Error("EvaluateCondition() should return error for network condition without detector")
+		}
+	})
+
+	t.Run("ip-family conditions", func(t *testing.T) {
+		evaluator := &DefaultConditionEvaluator{IPFamilies: map[string]bool{"IPv4": false, "IPv6": true}}
+		for family, want := range map[string]bool{"IPv4": false, "IPv6": true} {
+			satisfied, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeIPFamily, Value: family})
+			if err != nil || satisfied != want {
+				t.Errorf("EvaluateCondition(%s) on IPv6-only = %v, %v, want %v", family, satisfied, err, want)
+			}
+		}
+		if _, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeIPFamily, Value: "ipv4"}); err == nil {
+			t.Error("EvaluateCondition() should return error for an unknown ip-family value")
+		}
+	})
+
+	t.Run("architecture conditions", func(t *testing.T) {
+		evaluator := &DefaultConditionEvaluator{Architectures: map[string]bool{"amd64": true, "arm64": true, "s390x": false}}
+		for arch, want := range map[string]bool{"amd64": true, "arm64": true, "s390x": false} {
+			satisfied, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeArchitecture, Value: arch})
+			if err != nil || satisfied != want {
+				t.Errorf("EvaluateCondition(%s) on amd64+arm64 = %v, %v, want %v", arch, satisfied, err, want)
+			}
+		}
+		if _, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeArchitecture, Value: "x86_64"}); err == nil {
+			t.Error("EvaluateCondition() should return error for an architecture not named as in kubernetes.io/arch")
+		}
+	})
+
+	t.Run("cluster conditions", func(t *testing.T) {
+		condition := AssetCondition{Type: ConditionTypeOperator, Value: "metallb-operator"}
+
+		// Offline there is no cluster to ask
+		offline := &DefaultConditionEvaluator{}
+		if satisfied, err := offline.EvaluateCondition(ctx, condition); err != nil || satisfied {
+			t.Errorf("EvaluateCondition() without a cluster = %v, %v, want false", satisfied, err)
+		}
+
+		var asked []AssetCondition
+		evaluator := &DefaultConditionEvaluator{Cluster: conditionFunc(func(c AssetCondition) (bool, error) {
+			asked = append(asked, c)
+			return true, nil
+		})}
+		if satisfied, err := evaluator.EvaluateCondition(ctx, condition); err != nil || !satisfied {
+			t.Errorf("EvaluateCondition() = %v, %v, want true", satisfied, err)
+		}
+		if len(asked) != 1 || asked[0] != condition {
+			t.Errorf("cluster evaluator asked %v, want %v", asked, condition)
+		}
+		if _, err := evaluator.EvaluateCondition(ctx, AssetCondition{Type: ConditionTypeCRD}); err == nil {
+			t.Error("EvaluateCondition() should return error for crd condition without value")
+		}
+	})
+
 	t.Run("unknown condition type", func(t *testing.T) {
 		evaluator := &DefaultConditionEvaluator{}
 		condition := AssetCondition{Type: ConditionType("unknown-type")}
@@ -376,6 +506,13 @@ func TestDefaultConditionEvaluator_EvaluateCondition(t *testing.T) {
 	})
 }
 
+// conditionFunc adapts a function to ConditionEvaluator
+type conditionFunc func(AssetCondition) (bool, error)
+
+func (f conditionFunc) EvaluateCondition(_ context.Context, condition AssetCondition) (bool, error) {
+	return f(condition)
+}
+
 func testHardwareDetectionConditions(ctx context.Context, t *testing.T) {
 	t.Helper()
 
@@ -504,6 +641,72 @@ func testImageConditions(ctx context.Context, t *testing.T) {
 	}
 }
 
+func testFIPSConditions(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	tests := []struct {
+		name          string
+		fips          bool
+		value         string
+		wantSatisfied bool
+		wantErr       bool
+	}{
+		{"fips cluster, default value", true, "", true, false},
+		{"non-fips cluster, default value", false, "", false, false},
+		{"fips cluster, requires fips", true, "true", true, false},
+		{"fips cluster, requires non-fips", true, "false", false, false},
+		{"non-fips cluster, requires non-fips", false, "false", true, false},
+		{"invalid value", true, "yes", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := &DefaultConditionEvaluator{FIPS: tt.fips}
+			condition := AssetCondition{Type: ConditionTypeFIPS, Value: tt.value}
+
+			satisfied, err := evaluator.EvaluateCondition(ctx, condition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EvaluateCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && satisfied != tt.wantSatisfied {
+				t.Errorf("EvaluateCondition() = %v, want %v", satisfied, tt.wantSatisfied)
+			}
+		})
+	}
+}
+
+func testStorageConditions(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	storage := map[string]bool{"rwxStorageClass": true, "snapshotClass": false}
+	tests := []struct {
+		name          string
+		detector      string
+		wantSatisfied bool
+		wantErr       bool
+	}{
+		{"detected", "rwxStorageClass", true, false},
+		{"not detected", "snapshotClass", false, false},
+		{"unknown detector", "tapeLibrary", false, false},
+		{"missing detector", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := &DefaultConditionEvaluator{Storage: storage}
+			condition := AssetCondition{Type: ConditionTypeStorage, Detector: tt.detector}
+
+			satisfied, err := evaluator.EvaluateCondition(ctx, condition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EvaluateCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && satisfied != tt.wantSatisfied {
+				t.Errorf("EvaluateCondition() = %v, want %v", satisfied, tt.wantSatisfied)
+			}
+		})
+	}
+}
+
 // TestNoDuplicateAssetNames validates that all assets in metadata.yaml have unique names.
 // Duplicate names cause GetAsset to silently return only the first match, breaking
 // the debug endpoint and making catalog entries unreachable by name.
@@ -598,3 +801,169 @@ func TestCrdNameFromGVK(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckScope(t *testing.T) {
+	object := func(namespace string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetKind("ConfigMap")
+		obj.SetName("example")
+		obj.SetNamespace(namespace)
+		return obj
+	}
+
+	tests := []struct {
+		name    string
+		scope   Scope
+		obj     *unstructured.Unstructured
+		wantErr string
+	}{
+		{"cluster without namespace", ScopeCluster, object(""), ""},
+		{"cluster with namespace", ScopeCluster, object("ns"), "sets metadata.namespace"},
+		{"namespaced with namespace", ScopeNamespaced, object("ns"), ""},
+		{"namespaced without namespace", ScopeNamespaced, object(""), "has no metadata.namespace"},
+		{"undeclared scope", "", object("ns"), ""},
+		{"nil object", ScopeCluster, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asset := &AssetMetadata{Name: "example", Scope: tt.scope}
+			err := asset.CheckScope(tt.obj)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckScope() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckScope() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCatalogRejectsUnknownScope(t *testing.T) {
+	data := []byte("assets:\n  - name: example\n    path: active/example.yaml\n    scope: Global\n")
+	if _, err := ParseCatalog(data); err == nil || !strings.Contains(err.Error(), "Global") {
+		t.Errorf("ParseCatalog() error = %v, want unknown scope error", err)
+	}
+}
+
+func TestEmbeddedAssetsMatchScope(t *testing.T) {
+	// NewRegistry fails when an embedded asset contradicts its declared scope
+	registry, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	for _, asset := range registry.ListAssets(nil) {
+		if asset.Scope == "" {
+			t.Errorf("asset %s has no scope", asset.Name)
+		}
+	}
+}
+
+func TestAssetNamespaces(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	service := schema.GroupVersionKind{Version: "v1", Kind: "Service"}
+
+	registry := &Registry{namespaces: make(map[schema.GroupVersionKind]map[string]bool)}
+	registry.recordNamespace(deployment, "b")
+	registry.recordNamespace(deployment, "a")
+	registry.recordNamespace(deployment, "a")
+	registry.recordNamespace(service, "a")
+	registry.recordNamespace(service, templatePlaceholder)
+	registry.recordNamespace(service, "b")
+
+	namespaces := registry.AssetNamespaces()
+	if got := namespaces[deployment]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("AssetNamespaces()[Deployment] = %v, want [a b]", got)
+	}
+	if got, ok := namespaces[service]; ok {
+		t.Errorf("AssetNamespaces()[Service] = %v, want omitted (templated namespace)", got)
+	}
+}
+
+func TestKinds(t *testing.T) {
+	registry, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	kinds := registry.Kinds()
+	for _, want := range []schema.GroupVersionKind{
+		{Group: "hco.kubevirt.io", Version: "v1", Kind: "HyperConverged"},
+		{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfig"},
+		{Version: "v1", Kind: "Service"},
+	} {
+		if !slices.Contains(kinds, want) {
+			t.Errorf("Kinds() = %v, missing %s", kinds, want)
+		}
+	}
+	if !slices.IsSortedFunc(kinds, func(a, b schema.GroupVersionKind) int { return strings.Compare(a.String(), b.String()) }) {
+		t.Errorf("Kinds() = %v, want sorted", kinds)
+	}
+}
+
+func TestCatalogVersionAndDigest(t *testing.T) {
+	registry, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if registry.CatalogVersion() == "" {
+		t.Error("CatalogVersion() is empty; metadata.yaml must declare a version")
+	}
+	digest := registry.CatalogDigest()
+	if len(digest) != 12 {
+		t.Errorf("CatalogDigest() = %q, want 12 hex characters", digest)
+	}
+
+	again, err := NewRegistry(NewLoader())
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if again.CatalogDigest() != digest {
+		t.Errorf("CatalogDigest() not stable: %q then %q", digest, again.CatalogDigest())
+	}
+}
+
+func TestCheckAPIVersions(t *testing.T) {
+	nhc := assetObject{
+		gvk:  schema.GroupVersionKind{Group: "remediation.medik8s.io", Version: "v1alpha1", Kind: "NodeHealthCheck"},
+		name: "workers",
+	}
+	configMap := assetObject{gvk: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, name: "example"}
+
+	tests := []struct {
+		name        string
+		requiredCRD string
+		apiVersions []string
+		objects     []assetObject
+		wantErr     string
+	}{
+		{"no candidates", "", nil, []assetObject{configMap}, ""},
+		{"valid candidates", "nodehealthchecks.remediation.medik8s.io",
+			[]string{"remediation.medik8s.io/v1beta1", "remediation.medik8s.io/v1alpha1"}, []assetObject{nhc}, ""},
+		{"core type", "", []string{"v1"}, []assetObject{configMap}, "does not manage a custom resource"},
+		{"template version not listed", "nodehealthchecks.remediation.medik8s.io",
+			[]string{"remediation.medik8s.io/v1beta1"}, []assetObject{nhc}, "missing from its api_versions"},
+		{"other group", "nodehealthchecks.remediation.medik8s.io",
+			[]string{"remediation.medik8s.io/v1alpha1", "example.io/v1"}, []assetObject{nhc}, "want a version of group remediation.medik8s.io"},
+		{"mixed kinds", "nodehealthchecks.remediation.medik8s.io",
+			[]string{"remediation.medik8s.io/v1alpha1"}, []assetObject{nhc, configMap}, "is not a nodehealthchecks.remediation.medik8s.io"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asset := &AssetMetadata{Name: "example", RequiredCRD: tt.requiredCRD, APIVersions: tt.apiVersions}
+			err := asset.checkAPIVersions(tt.objects)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkAPIVersions() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkAPIVersions() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
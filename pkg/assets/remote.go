@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// MaxCatalogArchiveSize bounds the size of a downloaded catalog archive
+	MaxCatalogArchiveSize = 64 * 1024 * 1024 // 64MB
+	// MaxCatalogFileSize bounds the decompressed size of one file in the archive
+	MaxCatalogFileSize = 16 * 1024 * 1024 // 16MB
+	// MaxCatalogExtractedSize bounds the decompressed size of all files in the archive
+	MaxCatalogExtractedSize = 256 * 1024 * 1024 // 256MB
+
+	ociScheme = "oci://"
+
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// RemoteCatalog fetches an asset catalog published out-of-band of operator releases,
+// as a zip archive laid out like the assets directory (active/metadata.yaml, the
+// asset files it references and, optionally, tombstones/). The archive is served
+// either over HTTPS or as the single layer of an OCI artifact:
+//
+//	https://example.com/catalogs/v1.4.2.zip
+//	oci://quay.io/example/autopilot-catalog:v1.4.2
+//
+// OCI artifacts are pulled anonymously, using a bearer token when the registry asks for one.
+//
+// The catalog drives MachineConfigs on every node, so its content must be pinned:
+// either by SHA256 or, for OCI artifacts, by referencing the manifest by digest
+// (oci://quay.io/example/autopilot-catalog@sha256:...).
+type RemoteCatalog struct {
+	// URL is the https:// or oci:// location of the archive
+	URL string
+	// SHA256 pins the hex-encoded SHA-256 of the archive; any other content is rejected.
+	// Optional only for OCI references by digest.
+	SHA256 string
+	// Client performs the requests; defaults to http.DefaultClient
+	Client *http.Client
+}
+
+// Validate checks the URL scheme and the checksum format, and that the content is pinned
+func (c *RemoteCatalog) Validate() error {
+	ref, oci := strings.CutPrefix(c.URL, ociScheme)
+	if !strings.HasPrefix(c.URL, "https://") && !oci {
+		return fmt.Errorf("catalog URL %q must start with https:// or %s", c.URL, ociScheme)
+	}
+	if c.SHA256 != "" {
+		if sum, err := hex.DecodeString(c.SHA256); err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("catalog checksum %q is not a hex-encoded SHA-256", c.SHA256)
+		}
+		return nil
+	}
+	if oci {
+		if _, _, reference, err := parseOCIReference(ref); err == nil && isDigest(reference) {
+			return nil
+		}
+	}
+	return fmt.Errorf("catalog URL %q is mutable: set its checksum, or reference the OCI artifact by @sha256: digest", c.URL)
+}
+
+// isDigest reports whether an OCI reference is a sha256 manifest digest
+func isDigest(reference string) bool {
+	sum, ok := strings.CutPrefix(reference, "sha256:")
+	if !ok {
+		return false
+	}
+	decoded, err := hex.DecodeString(sum)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// Fetch downloads the archive, verifies its checksum and returns a loader serving it
+// together with the archive's SHA-256. The catalog itself is not validated here;
+// build a Registry from the loader for that.
+func (c *RemoteCatalog) Fetch(ctx context.Context) (*Loader, string, error) {
+	if err := c.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if ref, ok := strings.CutPrefix(c.URL, ociScheme); ok {
+		data, err = c.fetchOCI(ctx, ref)
+	} else {
+		data, err = c.get(ctx, c.URL, "", "")
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch catalog from %s: %w", c.URL, err)
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	if c.SHA256 != "" && !strings.EqualFold(digest, c.SHA256) {
+		return nil, "", fmt.Errorf("catalog from %s has checksum %s, expected %s", c.URL, digest, c.SHA256)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, "", fmt.Errorf("catalog from %s is not a zip archive: %w", c.URL, err)
+	}
+	if err := checkExtractedSize(archive); err != nil {
+		return nil, "", fmt.Errorf("catalog from %s: %w", c.URL, err)
+	}
+	return NewLoaderFromFS(archive), digest, nil
+}
+
+// checkExtractedSize decompresses every file of archive, failing once a file exceeds
+// MaxCatalogFileSize or all of them MaxCatalogExtractedSize. The sizes in the zip
+// headers are not trusted. The archive is held in memory, so later reads of its
+// files decompress to the same bytes.
+func checkExtractedSize(archive *zip.Reader) error {
+	var total int64
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		n, err := io.Copy(io.Discard, io.LimitReader(r, MaxCatalogFileSize+1))
+		_ = r.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+		if n > MaxCatalogFileSize {
+			return fmt.Errorf("%s exceeds the maximum file size of %d bytes", file.Name, MaxCatalogFileSize)
+		}
+		total += n
+		if total > MaxCatalogExtractedSize {
+			return fmt.Errorf("archive exceeds the maximum extracted size of %d bytes", MaxCatalogExtractedSize)
+		}
+	}
+	return nil
+}
+
+// fetchOCI pulls the single layer of the OCI artifact ref (registry/repository[:tag|@digest])
+func (c *RemoteCatalog) fetchOCI(ctx context.Context, ref string) ([]byte, error) {
+	registry, repository, reference, err := parseOCIReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	base := "https://" + registry + "/v2/" + repository
+
+	token, err := c.ociToken(ctx, base+"/manifests/"+reference, repository)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.get(ctx, base+"/manifests/"+reference, token, mediaTypeOCIManifest+", "+mediaTypeDockerManifest)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	if isDigest(reference) {
+		sum := sha256.Sum256(data)
+		if got := "sha256:" + hex.EncodeToString(sum[:]); got != reference {
+			return nil, fmt.Errorf("manifest digest mismatch: got %s, expected %s", got, reference)
+		}
+	}
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if len(manifest.Layers) != 1 {
+		return nil, fmt.Errorf("expected an artifact with one layer holding the catalog archive, got %d layers", len(manifest.Layers))
+	}
+
+	layer := manifest.Layers[0].Digest
+	blob, err := c.get(ctx, base+"/blobs/"+layer, token, "")
+	if err != nil {
+		return nil, fmt.Errorf("layer %s: %w", layer, err)
+	}
+	sum := sha256.Sum256(blob)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != layer {
+		return nil, fmt.Errorf("layer digest mismatch: got %s, expected %s", got, layer)
+	}
+	return blob, nil
+}
+
+// parseOCIReference splits registry/repository[:tag|@digest]; the tag defaults to latest
+func parseOCIReference(ref string) (registry, repository, reference string, err error) {
+	registry, repository, ok := strings.Cut(ref, "/")
+	if !ok || registry == "" || repository == "" {
+		return "", "", "", fmt.Errorf("invalid OCI reference %q, expected registry/repository[:tag|@digest]", ref)
+	}
+	if name, digest, ok := strings.Cut(repository, "@"); ok {
+		return registry, name, digest, nil
+	}
+	if i := strings.LastIndex(repository, ":"); i >= 0 {
+		return registry, repository[:i], repository[i+1:], nil
+	}
+	return registry, repository, "latest", nil
+}
+
+// ociToken returns the anonymous bearer token the registry requires for pulling,
+// or "" when the registry allows unauthenticated pulls
+func (c *RemoteCatalog) ociToken(ctx context.Context, probeURL, repository string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, probeURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", mediaTypeOCIManifest+", "+mediaTypeDockerManifest)
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return "", nil
+	}
+
+	challenge := parseBearerChallenge(resp.Header.Get("WWW-Authenticate"))
+	realm := challenge["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry requires authentication without offering a bearer token realm")
+	}
+	query := url.Values{}
+	if service := challenge["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := challenge["scope"]
+	if scope == "" {
+		scope = "repository:" + repository + ":pull"
+	}
+	query.Set("scope", scope)
+
+	data, err := c.get(ctx, realm+"?"+query.Encode(), "", "")
+	if err != nil {
+		return "", fmt.Errorf("token: %w", err)
+	}
+	var response struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if response.Token != "" {
+		return response.Token, nil
+	}
+	return response.AccessToken, nil
+}
+
+// parseBearerChallenge parses `Bearer realm="...",service="...",scope="..."`
+func parseBearerChallenge(header string) map[string]string {
+	params := make(map[string]string)
+	rest, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return params
+	}
+	for _, part := range strings.Split(rest, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+	return params
+}
+
+// get downloads rawURL, bounded by MaxCatalogArchiveSize
+func (c *RemoteCatalog) get(ctx context.Context, rawURL, token, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxCatalogArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxCatalogArchiveSize {
+		return nil, fmt.Errorf("response exceeds maximum size of %d bytes", MaxCatalogArchiveSize)
+	}
+	return data, nil
+}
+
+func (c *RemoteCatalog) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
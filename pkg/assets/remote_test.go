@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	embeddedassets "github.com/kubevirt/virt-platform-autopilot/assets"
+)
+
+// zipEmbeddedAssets archives the embedded assets, letting mutate change files first
+func zipEmbeddedAssets(t *testing.T, mutate func(files map[string][]byte)) []byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := fs.WalkDir(embeddedassets.EmbeddedFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files[path], err = fs.ReadFile(embeddedassets.EmbeddedFS, path)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to read embedded assets: %v", err)
+	}
+	if mutate != nil {
+		mutate(files)
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for path, data := range files {
+		f, err := w.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRemoteCatalog_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog RemoteCatalog
+		wantErr bool
+	}{
+		{name: "unpinned https", catalog: RemoteCatalog{URL: "https://example.com/catalog.zip"}, wantErr: true},
+		{name: "unpinned oci tag", catalog: RemoteCatalog{URL: "oci://quay.io/example/catalog:v1"}, wantErr: true},
+		{name: "oci digest", catalog: RemoteCatalog{URL: "oci://quay.io/example/catalog@sha256:" + strings.Repeat("ab", 32)}},
+		{name: "oci short digest", catalog: RemoteCatalog{URL: "oci://quay.io/example/catalog@sha256:abcd"}, wantErr: true},
+		{name: "pinned oci tag", catalog: RemoteCatalog{URL: "oci://quay.io/example/catalog:v1", SHA256: strings.Repeat("ab", 32)}},
+		{name: "plain http", catalog: RemoteCatalog{URL: "http://example.com/catalog.zip", SHA256: strings.Repeat("ab", 32)}, wantErr: true},
+		{name: "pinned", catalog: RemoteCatalog{URL: "https://example.com/catalog.zip", SHA256: strings.Repeat("ab", 32)}},
+		{name: "short checksum", catalog: RemoteCatalog{URL: "https://example.com/catalog.zip", SHA256: "abcd"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.catalog.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemoteCatalog_FetchHTTPS(t *testing.T) {
+	archive := zipEmbeddedAssets(t, func(files map[string][]byte) {
+		files[CatalogPath] = append([]byte("version: hotfix-1\n"), bytes.Replace(files[CatalogPath], []byte("version:"), []byte("previous_version:"), 1)...)
+	})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	t.Run("serves the archive", func(t *testing.T) {
+		source := &RemoteCatalog{URL: server.URL + "/catalog.zip", SHA256: sha256Hex(archive), Client: server.Client()}
+		loader, digest, err := source.Fetch(context.Background())
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		if digest != sha256Hex(archive) {
+			t.Errorf("Fetch() digest = %s, want %s", digest, sha256Hex(archive))
+		}
+		catalog, err := loader.Catalog()
+		if err != nil {
+			t.Fatalf("Catalog() error = %v", err)
+		}
+		if catalog.Version != "hotfix-1" {
+			t.Errorf("catalog version = %q, want hotfix-1", catalog.Version)
+		}
+	})
+
+	t.Run("rejects an unpinned URL", func(t *testing.T) {
+		source := &RemoteCatalog{URL: server.URL + "/catalog.zip", Client: server.Client()}
+		_, _, err := source.Fetch(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "is mutable") {
+			t.Errorf("Fetch() error = %v, want the unpinned URL rejected", err)
+		}
+	})
+
+	t.Run("rejects a checksum mismatch", func(t *testing.T) {
+		source := &RemoteCatalog{URL: server.URL + "/catalog.zip", SHA256: strings.Repeat("0", 64), Client: server.Client()}
+		_, _, err := source.Fetch(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "expected "+strings.Repeat("0", 64)) {
+			t.Errorf("Fetch() error = %v, want checksum mismatch", err)
+		}
+	})
+}
+
+func TestRemoteCatalog_FetchOCI(t *testing.T) {
+	archive := zipEmbeddedAssets(t, nil)
+	layer := "sha256:" + sha256Hex(archive)
+	manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"layers":[{"mediaType":"application/zip","digest":%q}]}`,
+		mediaTypeOCIManifest, layer)
+	manifestDigest := "sha256:" + sha256Hex([]byte(manifest))
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:example/catalog:pull" {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:example/catalog:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/example/catalog/manifests/" + manifestDigest:
+			w.Header().Set("Content-Type", mediaTypeOCIManifest)
+			_, _ = w.Write([]byte(manifest))
+		case "/v2/example/catalog/manifests/sha256:" + strings.Repeat("0", 64):
+			// A registry serving other content than the digest names
+			w.Header().Set("Content-Type", mediaTypeOCIManifest)
+			_, _ = w.Write([]byte(manifest))
+		case "/v2/example/catalog/blobs/" + layer:
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	source := &RemoteCatalog{URL: "oci://" + host + "/example/catalog@" + manifestDigest, Client: server.Client()}
+	loader, digest, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if digest != sha256Hex(archive) {
+		t.Errorf("Fetch() digest = %s, want %s", digest, sha256Hex(archive))
+	}
+	if _, err := NewRegistry(loader); err != nil {
+		t.Errorf("NewRegistry() on the fetched catalog error = %v", err)
+	}
+
+	source.URL = "oci://" + host + "/example/catalog@sha256:" + strings.Repeat("0", 64)
+	if _, _, err := source.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "manifest digest mismatch") {
+		t.Errorf("Fetch() error = %v, want a manifest digest mismatch", err)
+	}
+}
+
+func TestRemoteCatalog_FetchExtractedSizeLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		files int // number of files of size bytes
+		size  int
+		want  string
+	}{
+		{name: "file too large", files: 1, size: MaxCatalogFileSize + 1, want: "exceeds the maximum file size"},
+		{name: "archive too large", files: MaxCatalogExtractedSize/MaxCatalogFileSize + 1, size: MaxCatalogFileSize,
+			want: "maximum extracted size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Zeros compress to a small fraction, well within MaxCatalogArchiveSize
+			var buf bytes.Buffer
+			w := zip.NewWriter(&buf)
+			for i := range tt.files {
+				f, err := w.Create(fmt.Sprintf("active/file-%d.yaml", i))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := f.Write(make([]byte, tt.size)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			archive := buf.Bytes()
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(archive)
+			}))
+			defer server.Close()
+
+			source := &RemoteCatalog{URL: server.URL + "/catalog.zip", SHA256: sha256Hex(archive), Client: server.Client()}
+			if _, _, err := source.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Fetch() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref                             string
+		registry, repository, reference string
+		wantErr                         bool
+	}{
+		{ref: "quay.io/example/catalog:v1", registry: "quay.io", repository: "example/catalog", reference: "v1"},
+		{ref: "quay.io/example/catalog", registry: "quay.io", repository: "example/catalog", reference: "latest"},
+		{ref: "localhost:5000/catalog@sha256:abc", registry: "localhost:5000", repository: "catalog", reference: "sha256:abc"},
+		{ref: "catalog", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			registry, repository, reference, err := parseOCIReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOCIReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if registry != tt.registry || repository != tt.repository || reference != tt.reference {
+				t.Errorf("parseOCIReference() = %s, %s, %s, want %s, %s, %s",
+					registry, repository, reference, tt.registry, tt.repository, tt.reference)
+			}
+		})
+	}
+}
+
+func TestLoaderReplace(t *testing.T) {
+	loader := NewLoader()
+	embedded, err := loader.Catalog()
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+
+	archive := zipEmbeddedAssets(t, func(files map[string][]byte) {
+		files[CatalogPath] = []byte("version: replaced\nassets: []\n")
+	})
+	replacement, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader.Replace(NewLoaderFromFS(replacement))
+
+	catalog, err := loader.Catalog()
+	if err != nil {
+		t.Fatalf("Catalog() after Replace error = %v", err)
+	}
+	if catalog.Version != "replaced" || catalog == embedded {
+		t.Errorf("Catalog() after Replace = version %q, want the replacement catalog", catalog.Version)
+	}
+}
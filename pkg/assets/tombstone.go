@@ -43,13 +43,19 @@ type TombstoneMetadata struct {
 	Object    *unstructured.Unstructured // Full object definition
 }
 
+// CRDName returns the CRD serving the tombstoned resource, or "" for built-in APIs
+func (t TombstoneMetadata) CRDName() string {
+	return crdNameFromGVK(t.GVK.GroupVersion().String(), t.GVK.Kind)
+}
+
 // LoadTombstones scans the tombstones directory and loads all tombstone definitions
 // Returns a slice of TombstoneMetadata for resources to be deleted
 func (l *Loader) LoadTombstones() ([]TombstoneMetadata, error) {
 	var tombstones []TombstoneMetadata
+	fsys := l.fs()
 
 	// Walk the tombstones directory
-	err := fs.WalkDir(l.fs, TombstonesDir, func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(fsys, TombstonesDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			// If tombstones directory doesn't exist, return empty list (not an error)
 			if strings.Contains(err.Error(), "no such file or directory") ||
@@ -70,7 +76,7 @@ func (l *Loader) LoadTombstones() ([]TombstoneMetadata, error) {
 		}
 
 		// Load and parse tombstone file
-		data, err := l.fs.ReadFile(path)
+		data, err := fs.ReadFile(fsys, path)
 		if err != nil {
 			return fmt.Errorf("failed to read tombstone file %s: %w", path, err)
 		}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// AutopilotConfig holds cluster-wide policy for the autopilot.
+// It is read once at startup from the file passed via --config (typically a mounted ConfigMap).
+type AutopilotConfig struct {
+	// Mutators are applied, in order, to every desired object before drift detection and apply
+	Mutators []MutatorSpec `json:"mutators,omitempty"`
+}
+
+// MutatorSpec selects a registered mutator plugin and carries its plugin-specific configuration
+type MutatorSpec struct {
+	Name   string          `json:"name"`
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// Parse decodes an AutopilotConfig from YAML or JSON
+func Parse(data []byte) (*AutopilotConfig, error) {
+	cfg := &AutopilotConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse autopilot config: %w", err)
+	}
+
+	for i, m := range cfg.Mutators {
+		if m.Name == "" {
+			return nil, fmt.Errorf("mutator %d: name is required", i)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Load reads an AutopilotConfig from path.
+// An empty path yields the zero config so the flag can be left unset.
+func Load(path string) (*AutopilotConfig, error) {
+	if path == "" {
+		return &AutopilotConfig{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read autopilot config %s: %w", path, err)
+	}
+	return Parse(data)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantErr      bool
+		wantMutators int
+	}{
+		{
+			name:         "empty document",
+			input:        "",
+			wantMutators: 0,
+		},
+		{
+			name: "mutator with nested config",
+			input: `
+mutators:
+  - name: image-pull-secrets
+    config:
+      secrets: [mirror-pull]
+`,
+			wantMutators: 1,
+		},
+		{
+			name: "mutator without name",
+			input: `
+mutators:
+  - config: {}
+`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown top-level field",
+			input:   "mutatorz: []",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Parse([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(cfg.Mutators) != tt.wantMutators {
+				t.Errorf("Parse() mutators = %d, want %d", len(cfg.Mutators), tt.wantMutators)
+			}
+		})
+	}
+}
+
+func TestParseKeepsMutatorConfigAsJSON(t *testing.T) {
+	cfg, err := Parse([]byte("mutators:\n- name: x\n  config:\n    secrets: [a, b]\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var decoded struct {
+		Secrets []string `json:"secrets"`
+	}
+	if err := json.Unmarshal(cfg.Mutators[0].Config, &decoded); err != nil {
+		t.Fatalf("mutator config is not valid JSON: %v", err)
+	}
+	if len(decoded.Secrets) != 2 || decoded.Secrets[0] != "a" || decoded.Secrets[1] != "b" {
+		t.Errorf("decoded secrets = %v, want [a b]", decoded.Secrets)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil || cfg == nil || len(cfg.Mutators) != 0 {
+		t.Errorf("Load(\"\") = %v, %v; want empty config", cfg, err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file should fail")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("mutators:\n- name: image-pull-secrets\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Mutators) != 1 || cfg.Mutators[0].Name != "image-pull-secrets" {
+		t.Errorf("Load() mutators = %+v", cfg.Mutators)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ArchAMD64, ArchARM64, ArchPPC64LE and ArchS390X are the node architectures OpenShift
+	// supports, named as in the kubernetes.io/arch label
+	ArchAMD64   = "amd64"
+	ArchARM64   = "arm64"
+	ArchPPC64LE = "ppc64le"
+	ArchS390X   = "s390x"
+
+	// ArchLabel is set on every node by the kubelet
+	ArchLabel = "kubernetes.io/arch"
+
+	nodeWorkerRoleLabel = "node-role.kubernetes.io/worker"
+)
+
+// Architectures lists the supported node architectures, the values of architecture conditions
+var Architectures = []string{ArchAMD64, ArchARM64, ArchPPC64LE, ArchS390X}
+
+// ArchitectureContext holds the CPU architectures of the nodes, so arch-specific assets
+// such as MachineConfigs with kernel arguments only reach the nodes that can take them.
+// Available in templates as .Architecture.
+type ArchitectureContext struct {
+	// Architectures are the architectures of the nodes, sorted
+	Architectures []string `example:"[amd64, arm64]"`
+
+	// Roles maps the machineconfiguration.openshift.io/role of every MachineConfigPool with
+	// worker nodes, and "worker", to the sorted architectures of the nodes a MachineConfig
+	// with that role reaches. Custom pools usually render the worker MachineConfigs too, so
+	// on a mixed-arch cluster "worker" reaches every architecture. Empty without pools.
+	Roles map[string][]string `example:"worker: [amd64, arm64], worker-arm64: [arm64]"`
+}
+
+// NewArchitectureContext collects the architectures of nodes and of the MachineConfigPools
+// they belong to. A node without the kubernetes.io/arch label falls back to
+// status.nodeInfo.architecture.
+func NewArchitectureContext(nodes []corev1.Node, pools []MachineConfigPool) *ArchitectureContext {
+	result := &ArchitectureContext{}
+	for i := range nodes {
+		result.Architectures = addArchitecture(result.Architectures, nodeArchitecture(&nodes[i]))
+	}
+	if len(pools) == 0 {
+		return result
+	}
+
+	members := AssignPoolNodes(nodes, pools)
+	result.Roles = map[string][]string{WorkerPool: nil}
+	for _, pool := range pools {
+		if slices.ContainsFunc(members[pool.Name], func(node *corev1.Node) bool {
+			_, ok := node.Labels[nodeWorkerRoleLabel]
+			return ok
+		}) {
+			result.Roles[pool.Role()] = nil
+		}
+	}
+	for role := range result.Roles {
+		for _, pool := range pools {
+			if !pool.Renders(role) {
+				continue
+			}
+			for _, node := range members[pool.Name] {
+				result.Roles[role] = addArchitecture(result.Roles[role], nodeArchitecture(node))
+			}
+		}
+	}
+	return result
+}
+
+func nodeArchitecture(node *corev1.Node) string {
+	if arch := node.Labels[ArchLabel]; arch != "" {
+		return arch
+	}
+	return node.Status.NodeInfo.Architecture
+}
+
+// addArchitecture inserts arch into the sorted set archs
+func addArchitecture(archs []string, arch string) []string {
+	if arch == "" {
+		return archs
+	}
+	i, found := slices.BinarySearch(archs, arch)
+	if found {
+		return archs
+	}
+	return slices.Insert(archs, i, arch)
+}
+
+// Has reports whether any node runs arch. A cluster whose architectures are unknown
+// (offline rendering) is assumed to be amd64 only.
+func (a *ArchitectureContext) Has(arch string) bool {
+	if a == nil || len(a.Architectures) == 0 {
+		return arch == ArchAMD64
+	}
+	return slices.Contains(a.Architectures, arch)
+}
+
+// Mixed reports whether the nodes run more than one architecture.
+func (a *ArchitectureContext) Mixed() bool {
+	return a != nil && len(a.Architectures) > 1
+}
+
+// RoleFor returns the role to label a MachineConfig for arch with, so that it reaches
+// only nodes running arch: "worker" when all workers run arch (or the pools are unknown),
+// otherwise the first role by name whose nodes all run arch. It is empty when no role
+// qualifies, e.g. for amd64 when the arm64 workers' pool also renders worker MachineConfigs.
+func (a *ArchitectureContext) RoleFor(arch string) string {
+	if !a.Has(arch) {
+		return ""
+	}
+	if a == nil || len(a.Roles) == 0 {
+		return WorkerPool
+	}
+	if workers := a.Roles[WorkerPool]; len(workers) == 0 || slices.Equal(workers, []string{arch}) {
+		return WorkerPool
+	}
+	roles := make([]string, 0, len(a.Roles))
+	for role := range a.Roles {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+	for _, role := range roles {
+		if slices.Equal(a.Roles[role], []string{arch}) {
+			return role
+		}
+	}
+	return ""
+}
+
+// ArchitectureSet converts ArchitectureContext to a map for architecture condition evaluation
+func (a *ArchitectureContext) ArchitectureSet() map[string]bool {
+	set := make(map[string]bool, len(Architectures))
+	for _, arch := range Architectures {
+		set[arch] = a.Has(arch)
+	}
+	return set
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func archNode(name, arch string, roles ...string) corev1.Node {
+	labels := map[string]string{ArchLabel: arch}
+	for _, role := range roles {
+		labels["node-role.kubernetes.io/"+role] = ""
+	}
+	return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func archPool(name string, roles ...string) MachineConfigPool {
+	return MachineConfigPool{
+		Name:         name,
+		NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/" + name: ""}},
+		MachineConfigSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key: MachineConfigRoleLabel, Operator: metav1.LabelSelectorOpIn, Values: roles,
+		}}},
+	}
+}
+
+func TestNewArchitectureContext(t *testing.T) {
+	// The arm64 workers are in a custom pool that also renders the worker MachineConfigs
+	pools := []MachineConfigPool{
+		archPool("master", "master"),
+		archPool("worker", "worker"),
+		archPool("worker-arm64", "worker", "worker-arm64"),
+	}
+	unlabeled := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+		Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{Architecture: ArchAMD64}},
+	}
+	nodes := []corev1.Node{
+		archNode("master-0", ArchAMD64, "master"),
+		archNode("worker-0", ArchAMD64, "worker"),
+		unlabeled,
+		archNode("arm-0", ArchARM64, "worker", "worker-arm64"),
+	}
+
+	got := NewArchitectureContext(nodes, pools)
+
+	want := &ArchitectureContext{
+		Architectures: []string{ArchAMD64, ArchARM64},
+		Roles: map[string][]string{
+			"worker":       {ArchAMD64, ArchARM64},
+			"worker-arm64": {ArchARM64},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewArchitectureContext() = %+v, want %+v", got, want)
+	}
+	if !got.Mixed() {
+		t.Error("Mixed() = false on amd64 and arm64 nodes")
+	}
+	if role := got.RoleFor(ArchARM64); role != "worker-arm64" {
+		t.Errorf("RoleFor(arm64) = %q, want worker-arm64", role)
+	}
+	// every worker MachineConfig also reaches the arm64 pool
+	if role := got.RoleFor(ArchAMD64); role != "" {
+		t.Errorf("RoleFor(amd64) = %q, want none", role)
+	}
+	if role := got.RoleFor(ArchS390X); role != "" {
+		t.Errorf("RoleFor(s390x) = %q, want none without s390x nodes", role)
+	}
+}
+
+func TestArchitectureContextDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		architecture *ArchitectureContext
+		wantAMD64    bool
+		wantARM64    bool
+		wantRole     string
+	}{
+		{"unknown is amd64", &ArchitectureContext{}, true, false, "worker"},
+		{"nil is amd64", nil, true, false, "worker"},
+		{"arm64 without pools", &ArchitectureContext{Architectures: []string{ArchARM64}}, false, true, ""},
+		{"compact cluster", &ArchitectureContext{
+			Architectures: []string{ArchAMD64},
+			Roles:         map[string][]string{"worker": nil},
+		}, true, false, "worker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := tt.architecture.ArchitectureSet()
+			if set[ArchAMD64] != tt.wantAMD64 || set[ArchARM64] != tt.wantARM64 {
+				t.Errorf("ArchitectureSet() = %v, want amd64=%v arm64=%v", set, tt.wantAMD64, tt.wantARM64)
+			}
+			if role := tt.architecture.RoleFor(ArchAMD64); role != tt.wantRole {
+				t.Errorf("RoleFor(amd64) = %q, want %q", role, tt.wantRole)
+			}
+		})
+	}
+}
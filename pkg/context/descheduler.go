@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// DeschedulerSensitivityAnnotation on the HCO says how disruptive a live migration is
+	// for the workloads: "low", "medium" or "high".
+	DeschedulerSensitivityAnnotation = "platform.kubevirt.io/descheduler-sensitivity"
+
+	// DeschedulerMigratableAnnotation on the HCO gives the share of VMs, in percent,
+	// that can live-migrate.
+	DeschedulerMigratableAnnotation = "platform.kubevirt.io/descheduler-migratable-percent"
+
+	// deschedulerBaseInterval is the descheduling interval at low sensitivity
+	deschedulerBaseInterval = 60
+
+	// lowMigratablePercent is the ratio below which most evictions cannot be served by
+	// a migration, so rebalancing is made one step more conservative.
+	lowMigratablePercent = 50
+)
+
+// deschedulerLevels are the threshold presets of the KubeDescheduler profile customizations
+var deschedulerLevels = []string{"Low", "Medium", "High"}
+
+// DeschedulerContext holds KubeDescheduler tuning derived from workload hints on the HCO.
+// Without hints it is empty and the profile defaults apply. Available in templates as .Descheduler.
+type DeschedulerContext struct {
+	// Sensitivity and MigratablePercent are the hints as given; MigratablePercent is -1 when unset.
+	Sensitivity       string `example:"High"`
+	MigratablePercent int64  `example:"40"`
+
+	// DeviationThresholds is the devDeviationThresholds preset for the RelieveAndMigrate profiles,
+	// LowNodeUtilizationThresholds the devLowNodeUtilizationThresholds preset for LongLifecycle.
+	DeviationThresholds          string `example:"High"`
+	LowNodeUtilizationThresholds string `example:"High"`
+
+	// IntervalSeconds is the descheduling interval, 0 when not tuned.
+	IntervalSeconds int64 `example:"3600"`
+}
+
+// Tuned reports whether any hint changed the descheduler profile
+func (d *DeschedulerContext) Tuned() bool {
+	return d != nil && d.DeviationThresholds != ""
+}
+
+// NewDeschedulerContext derives descheduler tuning from the HCO workload hints.
+// The more sensitive the workloads and the fewer VMs can migrate, the higher the
+// thresholds and the longer the interval, so fewer VMs are moved. Invalid hints are
+// ignored and reported in the error; the result is always usable.
+func NewDeschedulerContext(hco *unstructured.Unstructured) (*DeschedulerContext, error) {
+	d := &DeschedulerContext{MigratablePercent: -1}
+	if hco == nil {
+		return d, nil
+	}
+	annotations := hco.GetAnnotations()
+
+	var errs []error
+	level := -1
+	if value, ok := annotations[DeschedulerSensitivityAnnotation]; ok {
+		switch value {
+		case "low":
+			level = 0
+		case "medium":
+			level = 1
+		case "high":
+			level = 2
+		default:
+			errs = append(errs, fmt.Errorf("%s: %q is not one of low, medium, high", DeschedulerSensitivityAnnotation, value))
+		}
+		if level >= 0 {
+			d.Sensitivity = value
+		}
+	}
+
+	if value, ok := annotations[DeschedulerMigratableAnnotation]; ok {
+		percent, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || percent < 0 || percent > 100 {
+			errs = append(errs, fmt.Errorf("%s: %q is not a percentage between 0 and 100", DeschedulerMigratableAnnotation, value))
+		} else {
+			d.MigratablePercent = percent
+		}
+	}
+
+	if level < 0 && d.MigratablePercent < 0 {
+		return d, errors.Join(errs...)
+	}
+	if level < 0 {
+		level = 1
+	}
+	if d.MigratablePercent >= 0 && d.MigratablePercent < lowMigratablePercent {
+		level = min(level+1, len(deschedulerLevels)-1)
+	}
+
+	d.DeviationThresholds = "Asymmetric" + deschedulerLevels[level]
+	d.LowNodeUtilizationThresholds = deschedulerLevels[level]
+	d.IntervalSeconds = int64(deschedulerBaseInterval * (level + 1))
+	return d, errors.Join(errs...)
+}
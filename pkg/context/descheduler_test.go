@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+)
+
+func TestNewDeschedulerContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        DeschedulerContext
+		wantErr     bool
+	}{
+		{
+			name: "no hints",
+			want: DeschedulerContext{MigratablePercent: -1},
+		},
+		{
+			name:        "low sensitivity",
+			annotations: map[string]string{DeschedulerSensitivityAnnotation: "low"},
+			want: DeschedulerContext{Sensitivity: "low", MigratablePercent: -1,
+				DeviationThresholds: "AsymmetricLow", LowNodeUtilizationThresholds: "Low", IntervalSeconds: 60},
+		},
+		{
+			name:        "migratable ratio alone defaults to medium",
+			annotations: map[string]string{DeschedulerMigratableAnnotation: "90"},
+			want: DeschedulerContext{MigratablePercent: 90,
+				DeviationThresholds: "AsymmetricMedium", LowNodeUtilizationThresholds: "Medium", IntervalSeconds: 120},
+		},
+		{
+			name: "few migratable VMs raise the level",
+			annotations: map[string]string{
+				DeschedulerSensitivityAnnotation: "low",
+				DeschedulerMigratableAnnotation:  "30",
+			},
+			want: DeschedulerContext{Sensitivity: "low", MigratablePercent: 30,
+				DeviationThresholds: "AsymmetricMedium", LowNodeUtilizationThresholds: "Medium", IntervalSeconds: 120},
+		},
+		{
+			name: "level is capped at high",
+			annotations: map[string]string{
+				DeschedulerSensitivityAnnotation: "high",
+				DeschedulerMigratableAnnotation:  "0",
+			},
+			want: DeschedulerContext{Sensitivity: "high", MigratablePercent: 0,
+				DeviationThresholds: "AsymmetricHigh", LowNodeUtilizationThresholds: "High", IntervalSeconds: 180},
+		},
+		{
+			name: "invalid sensitivity is ignored",
+			annotations: map[string]string{
+				DeschedulerSensitivityAnnotation: "extreme",
+				DeschedulerMigratableAnnotation:  "80",
+			},
+			want: DeschedulerContext{MigratablePercent: 80,
+				DeviationThresholds: "AsymmetricMedium", LowNodeUtilizationThresholds: "Medium", IntervalSeconds: 120},
+			wantErr: true,
+		},
+		{
+			name:        "out of range ratio is ignored",
+			annotations: map[string]string{DeschedulerMigratableAnnotation: "150"},
+			want:        DeschedulerContext{MigratablePercent: -1},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco := NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+			hco.SetAnnotations(tt.annotations)
+
+			got, err := NewDeschedulerContext(hco)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDeschedulerContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if *got != tt.want {
+				t.Errorf("NewDeschedulerContext() = %+v, want %+v", *got, tt.want)
+			}
+			if got.Tuned() != (tt.want.DeviationThresholds != "") {
+				t.Errorf("Tuned() = %v", got.Tuned())
+			}
+		})
+	}
+}
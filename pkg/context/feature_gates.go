@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Feature gate states of the HCO v1 list form
+const (
+	FeatureGateEnabled  = "Enabled"
+	FeatureGateDisabled = "Disabled"
+)
+
+// HCOFeatureGates returns the feature gates set in spec.featureGates of hco, by name.
+// The HCO API expresses them in two shapes:
+//
+//   - v1: a list of {name, state} objects, where a missing state means Enabled
+//   - v1beta1: a map of gate names to booleans (spec.featureGates.<name>: true)
+//
+// Map values given as strings ("true", "Enabled", ...) are accepted too, as the
+// render --set flag and hand-written HCO files produce them. Gates without a value
+// are left out, so the HCO default applies.
+func HCOFeatureGates(hco *unstructured.Unstructured) map[string]bool {
+	gates := make(map[string]bool)
+	if hco == nil {
+		return gates
+	}
+
+	featureGates, _, _ := unstructured.NestedFieldNoCopy(hco.Object, "spec", "featureGates")
+	switch featureGates := featureGates.(type) {
+	case []any:
+		for _, item := range featureGates {
+			switch gate := item.(type) {
+			case map[string]any:
+				name, _ := gate["name"].(string)
+				state, _ := gate["state"].(string)
+				if name != "" {
+					gates[name] = state != FeatureGateDisabled
+				}
+			case string:
+				// A bare name enables the gate
+				if gate != "" {
+					gates[gate] = true
+				}
+			}
+		}
+	case map[string]any:
+		for name, value := range featureGates {
+			if enabled, ok := featureGateValue(value); ok {
+				gates[name] = enabled
+			}
+		}
+	}
+	return gates
+}
+
+// featureGateValue interprets a value of the map form
+func featureGateValue(value any) (enabled, ok bool) {
+	switch value := value.(type) {
+	case bool:
+		return value, true
+	case string:
+		switch value {
+		case FeatureGateEnabled:
+			return true, true
+		case FeatureGateDisabled:
+			return false, true
+		}
+		enabled, err := strconv.ParseBool(value)
+		return enabled, err == nil
+	}
+	return false, false
+}
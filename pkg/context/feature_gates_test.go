@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestHCOFeatureGates(t *testing.T) {
+	tests := []struct {
+		name string
+		hco  *unstructured.Unstructured
+		want map[string]bool
+	}{
+		{
+			name: "with feature gates",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"featureGates": []any{
+							map[string]any{"name": "FeatureGate1"},
+							map[string]any{"name": "FeatureGate2"},
+							map[string]any{"name": "ExperimentalFeature"},
+						},
+					},
+				},
+			},
+			want: map[string]bool{
+				"FeatureGate1":        true,
+				"FeatureGate2":        true,
+				"ExperimentalFeature": true,
+			},
+		},
+		{
+			name: "disabled feature gate",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"featureGates": []any{
+							map[string]any{"name": "EnabledGate"},
+							map[string]any{"name": "DisabledGate", "state": "Disabled"},
+						},
+					},
+				},
+			},
+			want: map[string]bool{
+				"EnabledGate":  true,
+				"DisabledGate": false,
+			},
+		},
+		{
+			name: "feature gate with no state defaults to enabled",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"featureGates": []any{
+							map[string]any{"name": "NoStateGate"},
+						},
+					},
+				},
+			},
+			want: map[string]bool{
+				"NoStateGate": true,
+			},
+		},
+		{
+			name: "empty feature gates",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"featureGates": []any{},
+					},
+				},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "no feature gates field",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{},
+				},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "no spec field",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{},
+			},
+			want: map[string]bool{},
+		},
+		{
+			name: "single feature gate",
+			hco: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"featureGates": []any{
+							map[string]any{"name": "SingleFeature"},
+						},
+					},
+				},
+			},
+			want: map[string]bool{
+				"SingleFeature": true,
+			},
+		},
+		{
+			name: "map form",
+			hco: hcoWithSpec(map[string]any{
+				"featureGates": map[string]any{
+					"deployKubeSecondaryDNS": true,
+					"downwardMetrics":        false,
+				},
+			}),
+			want: map[string]bool{
+				"deployKubeSecondaryDNS": true,
+				"downwardMetrics":        false,
+			},
+		},
+		{
+			name: "map form with string values",
+			hco: hcoWithSpec(map[string]any{
+				"featureGates": map[string]any{
+					"deployKubeSecondaryDNS": "true",
+					"downwardMetrics":        "Disabled",
+					"alignCPUs":              "Enabled",
+					"objectGraph":            "maybe",
+				},
+			}),
+			want: map[string]bool{
+				"deployKubeSecondaryDNS": true,
+				"downwardMetrics":        false,
+				"alignCPUs":              true,
+			},
+		},
+		{
+			name: "map form without value keeps the default",
+			hco: hcoWithSpec(map[string]any{
+				"featureGates": map[string]any{
+					"declarativeHotplugVolumes": nil,
+					"alignCPUs":                 true,
+				},
+			}),
+			want: map[string]bool{
+				"alignCPUs": true,
+			},
+		},
+		{
+			name: "list of names",
+			hco: hcoWithSpec(map[string]any{
+				"featureGates": []any{"deployKubeSecondaryDNS", ""},
+			}),
+			want: map[string]bool{
+				"deployKubeSecondaryDNS": true,
+			},
+		},
+		{
+			name: "unexpected shape",
+			hco: hcoWithSpec(map[string]any{
+				"featureGates": "deployKubeSecondaryDNS",
+			}),
+			want: map[string]bool{},
+		},
+		{
+			name: "nil HCO",
+			want: map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HCOFeatureGates(tt.hco)
+
+			if len(got) != len(tt.want) {
+				t.Errorf("HCOFeatureGates() returned %d gates, want %d", len(got), len(tt.want))
+			}
+
+			for gate, enabled := range tt.want {
+				if gotEnabled, exists := got[gate]; !exists {
+					t.Errorf("HCOFeatureGates() missing gate %q", gate)
+				} else if gotEnabled != enabled {
+					t.Errorf("HCOFeatureGates()[%q] = %v, want %v", gate, gotEnabled, enabled)
+				}
+			}
+
+			for gate := range got {
+				if _, exists := tt.want[gate]; !exists {
+					t.Errorf("HCOFeatureGates() has unexpected gate %q", gate)
+				}
+			}
+		})
+	}
+}
+
+// loadHCOSchema returns the spec schema of version in the HyperConverged CRD the
+// integration tests install
+func loadHCOSchema(t *testing.T, version string) apiextensionsv1.JSONSchemaProps {
+	t.Helper()
+	data, err := os.ReadFile("../../test/crds/kubevirt/hyperconverged-crd.yaml")
+	if err != nil {
+		t.Fatalf("failed to read the HyperConverged CRD: %v", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		t.Fatalf("failed to parse the HyperConverged CRD: %v", err)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return v.Schema.OpenAPIV3Schema.Properties["spec"]
+		}
+	}
+	t.Fatalf("HyperConverged CRD has no version %s", version)
+	return apiextensionsv1.JSONSchemaProps{}
+}
+
+// TestHCOFeatureGatesMapForm reads the defaulted spec of the v1beta1 HyperConverged,
+// whose featureGates is a map of booleans
+func TestHCOFeatureGatesMapForm(t *testing.T) {
+	schema := loadHCOSchema(t, "v1beta1")
+	if schema.Default == nil {
+		t.Fatal("v1beta1 spec has no default")
+	}
+	spec := map[string]any{}
+	if err := yaml.Unmarshal(schema.Default.Raw, &spec); err != nil {
+		t.Fatalf("failed to parse the v1beta1 spec default: %v", err)
+	}
+	defaults, ok := spec["featureGates"].(map[string]any)
+	if !ok || len(defaults) == 0 {
+		t.Fatalf("v1beta1 spec default has no featureGates map: %v", spec["featureGates"])
+	}
+
+	hco := hcoWithSpec(spec)
+	hco.SetAPIVersion("hco.kubevirt.io/v1beta1")
+	got := HCOFeatureGates(hco)
+	want := make(map[string]bool)
+	for name, value := range defaults {
+		want[name] = value.(bool)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HCOFeatureGates() = %v, want %v", got, want)
+	}
+	if !got["decentralizedLiveMigration"] || got["downwardMetrics"] {
+		t.Errorf("unexpected gate states %v", got)
+	}
+}
+
+// TestHCOFeatureGatesListForm checks every state the v1 HyperConverged schema allows
+func TestHCOFeatureGatesListForm(t *testing.T) {
+	schema := loadHCOSchema(t, "v1")
+	featureGates := schema.Properties["featureGates"]
+	if featureGates.Type != "array" || featureGates.Items == nil || featureGates.Items.Schema == nil {
+		t.Fatalf("v1 featureGates is not a list: %+v", featureGates)
+	}
+	states := featureGates.Items.Schema.Properties["state"].Enum
+	if len(states) == 0 {
+		t.Fatal("v1 featureGates state has no enum")
+	}
+
+	items := []any{map[string]any{"name": "noState"}}
+	want := map[string]bool{"noState": true}
+	for _, state := range states {
+		var value string
+		if err := yaml.Unmarshal(state.Raw, &value); err != nil {
+			t.Fatalf("failed to parse state %s: %v", state.Raw, err)
+		}
+		switch value {
+		case FeatureGateEnabled:
+			want["gate"+value] = true
+		case FeatureGateDisabled:
+			want["gate"+value] = false
+		default:
+			t.Fatalf("v1 featureGates state %q is not handled", value)
+		}
+		items = append(items, map[string]any{"name": "gate" + value, "state": value})
+	}
+
+	got := HCOFeatureGates(hcoWithSpec(map[string]any{"featureGates": items}))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HCOFeatureGates() = %v, want %v", got, want)
+	}
+}
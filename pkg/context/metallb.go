@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// MetalLBAddressPoolsAnnotation on the HCO lists the address ranges of the default
+	// MetalLB pool, comma-separated, each a CIDR or a "start-end" range.
+	MetalLBAddressPoolsAnnotation = "platform.kubevirt.io/metallb-address-pools"
+
+	// metalLBDerivedPoolSize is the number of addresses of a pool derived from the inventory
+	metalLBDerivedPoolSize = 16
+
+	// metalLBScanLimit bounds how far below the end of a machine network a free range is
+	// looked for, so a large IPv6 network is not walked address by address
+	metalLBScanLimit = 4096
+)
+
+// MetalLBInventory is the bare-metal host inventory MetalLB pools are derived from and
+// checked against. It is gathered by the controller where BareMetalHosts exist.
+type MetalLBInventory struct {
+	// MachineNetworks are the CIDRs of the network the nodes are attached to
+	MachineNetworks []string
+
+	// Reserved maps addresses in use to what uses them, e.g. "host worker-0" or "API VIP"
+	Reserved map[string]string
+}
+
+// MetalLBContext holds the address pools of the default MetalLB IPAddressPool.
+// Available in templates as .MetalLB.
+type MetalLBContext struct {
+	// AddressPools are the address ranges of the default pool, as CIDRs or "start-end"
+	// ranges: the valid entries of the metallb-address-pools annotation, or else one
+	// free range at the end of each machine network of the bare-metal inventory.
+	// Empty when neither is available.
+	AddressPools []string `example:"[192.168.111.239-192.168.111.254]"`
+
+	// Derived is true when AddressPools come from the host inventory, not the annotation
+	Derived bool `example:"true"`
+
+	// Conflicts describe annotation entries that do not parse, ranges overlapping an
+	// address in use or lying outside the machine networks, and machine networks
+	// without a free range. Templates report them as render warnings.
+	Conflicts []string `example:"[address pool 192.168.111.10-192.168.111.20 contains 192.168.111.12, the address of host worker-0]"`
+}
+
+// addressRange is an inclusive range of addresses of one family
+type addressRange struct {
+	first, last netip.Addr
+}
+
+func (r addressRange) contains(addr netip.Addr) bool {
+	return addr.Is4() == r.first.Is4() && r.first.Compare(addr) <= 0 && addr.Compare(r.last) <= 0
+}
+
+// NewMetalLBContext selects the default MetalLB pool. Ranges set on the HCO win and are
+// checked against the inventory; without them, pools are derived from the inventory.
+// inventory is nil when there is no bare-metal host inventory, in which case only the
+// annotation is used and nothing is checked.
+func NewMetalLBContext(hco *unstructured.Unstructured, inventory *MetalLBInventory) *MetalLBContext {
+	m := &MetalLBContext{}
+
+	var networks []netip.Prefix
+	if inventory != nil {
+		for _, cidr := range inventory.MachineNetworks {
+			network, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+			if err != nil {
+				m.Conflicts = append(m.Conflicts, fmt.Sprintf("machine network %q ignored: %v", cidr, err))
+				continue
+			}
+			networks = append(networks, network.Masked())
+		}
+	}
+
+	var value string
+	if hco != nil {
+		value = strings.TrimSpace(hco.GetAnnotations()[MetalLBAddressPoolsAnnotation])
+	}
+	if value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			r, err := parseAddressRange(entry)
+			if err != nil {
+				m.Conflicts = append(m.Conflicts, fmt.Sprintf("address pool %q ignored: %v", entry, err))
+				continue
+			}
+			m.AddressPools = append(m.AddressPools, entry)
+			if inventory != nil {
+				m.Conflicts = append(m.Conflicts, checkAddressRange(entry, r, networks, inventory.Reserved)...)
+			}
+		}
+		return m
+	}
+
+	if inventory == nil {
+		return m
+	}
+	for _, network := range networks {
+		r, ok := freeRangeAtEnd(network, inventory.Reserved)
+		if !ok {
+			m.Conflicts = append(m.Conflicts, fmt.Sprintf("machine network %s has no %d free addresses at its end", network, metalLBDerivedPoolSize))
+			continue
+		}
+		m.AddressPools = append(m.AddressPools, r.first.String()+"-"+r.last.String())
+		m.Derived = true
+	}
+	return m
+}
+
+// parseAddressRange parses a CIDR or a "start-end" range
+func parseAddressRange(entry string) (addressRange, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return addressRange{}, err
+		}
+		prefix = prefix.Masked()
+		return addressRange{first: prefix.Addr(), last: lastAddr(prefix)}, nil
+	}
+
+	start, end, ok := strings.Cut(entry, "-")
+	if !ok {
+		return addressRange{}, fmt.Errorf("expected a CIDR or a start-end range")
+	}
+	first, err := netip.ParseAddr(strings.TrimSpace(start))
+	if err != nil {
+		return addressRange{}, err
+	}
+	last, err := netip.ParseAddr(strings.TrimSpace(end))
+	if err != nil {
+		return addressRange{}, err
+	}
+	if first.Is4() != last.Is4() {
+		return addressRange{}, fmt.Errorf("range mixes IPv4 and IPv6")
+	}
+	if last.Less(first) {
+		return addressRange{}, fmt.Errorf("range ends before it starts")
+	}
+	return addressRange{first: first, last: last}, nil
+}
+
+// checkAddressRange reports the reserved addresses inside r and whether r lies outside
+// every machine network of its family
+func checkAddressRange(entry string, r addressRange, networks []netip.Prefix, reserved map[string]string) []string {
+	var conflicts []string
+
+	addresses := make([]string, 0, len(reserved))
+	for address := range reserved {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		addr, err := netip.ParseAddr(address)
+		if err == nil && r.contains(addr) {
+			conflicts = append(conflicts, fmt.Sprintf("address pool %s contains %s, the address of %s", entry, address, reserved[address]))
+		}
+	}
+
+	var sameFamily []string
+	for _, network := range networks {
+		if network.Addr().Is4() != r.first.Is4() {
+			continue
+		}
+		if network.Contains(r.first) && network.Contains(r.last) {
+			return conflicts
+		}
+		sameFamily = append(sameFamily, network.String())
+	}
+	if len(sameFamily) > 0 {
+		conflicts = append(conflicts, fmt.Sprintf("address pool %s is outside the machine networks %s, so its addresses cannot be announced on the node network",
+			entry, strings.Join(sameFamily, ", ")))
+	}
+	return conflicts
+}
+
+// freeRangeAtEnd returns the highest range of metalLBDerivedPoolSize addresses of
+// network that holds no reserved address. Installers hand out node addresses from the
+// start of the network, so its end is the least likely to be in use.
+func freeRangeAtEnd(network netip.Prefix, reserved map[string]string) (addressRange, bool) {
+	used := make(map[netip.Addr]bool, len(reserved))
+	for address := range reserved {
+		if addr, err := netip.ParseAddr(address); err == nil {
+			used[addr] = true
+		}
+	}
+
+	last := lastAddr(network)
+	if network.Addr().Is4() {
+		last = last.Prev() // broadcast
+	}
+	free := 0
+	addr := last
+	for scanned := 0; scanned < metalLBScanLimit; scanned++ {
+		// The network address itself is not assignable
+		if !addr.IsValid() || !network.Contains(addr) || addr == network.Addr() {
+			break
+		}
+		if used[addr] {
+			free = 0
+			last = addr.Prev()
+		} else if free++; free == metalLBDerivedPoolSize {
+			return addressRange{first: addr, last: last}, true
+		}
+		addr = addr.Prev()
+	}
+	return addressRange{}, false
+}
+
+// lastAddr returns the highest address of prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
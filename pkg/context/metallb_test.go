@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewMetalLBContext(t *testing.T) {
+	inventory := &MetalLBInventory{
+		MachineNetworks: []string{"192.168.111.0/24"},
+		Reserved: map[string]string{
+			"192.168.111.5":  "the API VIP",
+			"192.168.111.12": "host worker-0",
+			"192.168.111.20": "node worker-1",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		annotation string
+		inventory  *MetalLBInventory
+		want       *MetalLBContext
+	}{
+		{
+			name: "no annotation and no inventory",
+			want: &MetalLBContext{},
+		},
+		{
+			name:       "annotation without inventory is not checked",
+			annotation: "192.168.111.10-192.168.111.20, 10.0.0.0/28",
+			want:       &MetalLBContext{AddressPools: []string{"192.168.111.10-192.168.111.20", "10.0.0.0/28"}},
+		},
+		{
+			name:      "derived from the end of the machine network",
+			inventory: inventory,
+			want: &MetalLBContext{
+				AddressPools: []string{"192.168.111.239-192.168.111.254"},
+				Derived:      true,
+			},
+		},
+		{
+			name: "derived range skips reserved addresses",
+			inventory: &MetalLBInventory{
+				MachineNetworks: []string{"192.168.111.0/24"},
+				Reserved:        map[string]string{"192.168.111.250": "the ingress VIP"},
+			},
+			want: &MetalLBContext{
+				AddressPools: []string{"192.168.111.234-192.168.111.249"},
+				Derived:      true,
+			},
+		},
+		{
+			name:      "IPv6 machine network",
+			inventory: &MetalLBInventory{MachineNetworks: []string{"fd00::/64"}},
+			want: &MetalLBContext{
+				AddressPools: []string{"fd00::ffff:ffff:ffff:fff0-fd00::ffff:ffff:ffff:ffff"},
+				Derived:      true,
+			},
+		},
+		{
+			name:      "network too small",
+			inventory: &MetalLBInventory{MachineNetworks: []string{"192.168.1.0/29"}},
+			want: &MetalLBContext{Conflicts: []string{
+				"machine network 192.168.1.0/29 has no 16 free addresses at its end",
+			}},
+		},
+		{
+			name:       "annotation wins and is checked against the inventory",
+			annotation: "10.0.0.0/28,192.168.111.10-192.168.111.20,bogus,192.168.111.30-192.168.111.25",
+			inventory:  inventory,
+			want: &MetalLBContext{
+				AddressPools: []string{"10.0.0.0/28", "192.168.111.10-192.168.111.20"},
+				Conflicts: []string{
+					"address pool 10.0.0.0/28 is outside the machine networks 192.168.111.0/24, so its addresses cannot be announced on the node network",
+					"address pool 192.168.111.10-192.168.111.20 contains 192.168.111.12, the address of host worker-0",
+					"address pool 192.168.111.10-192.168.111.20 contains 192.168.111.20, the address of node worker-1",
+					`address pool "bogus" ignored: expected a CIDR or a start-end range`,
+					`address pool "192.168.111.30-192.168.111.25" ignored: range ends before it starts`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco := &unstructured.Unstructured{Object: map[string]any{}}
+			if tt.annotation != "" {
+				hco.SetAnnotations(map[string]string{MetalLBAddressPoolsAnnotation: tt.annotation})
+			}
+			got := NewMetalLBContext(hco, tt.inventory)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMetalLBContext() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NodePlacement is one nodePlacement block of the HCO. The fields are kept as
+// unstructured values so templates can emit them verbatim with toJson.
+type NodePlacement struct {
+	// NodeSelector, Affinity and Tolerations are the nodePlacement fields of the same names
+	NodeSelector map[string]any `example:"kubernetes.io/os: linux"`
+	Affinity     map[string]any
+	Tolerations  []any
+}
+
+// PlacementContext carries the HCO's placement policy, so platform components the
+// autopilot deploys land on the same nodes as the matching CNV components.
+// Available in templates as .Placement; a block is nil when the HCO leaves it unset.
+type PlacementContext struct {
+	// Infra is spec.infra.nodePlacement: where CNV runs its control components
+	Infra *NodePlacement
+	// Workloads is spec.workloads.nodePlacement: where VMs and node agents run
+	Workloads *NodePlacement
+}
+
+// NewPlacementContext reads spec.infra.nodePlacement and spec.workloads.nodePlacement
+// from hco. Malformed fields are ignored, as the HCO webhook already validates them.
+func NewPlacementContext(hco *unstructured.Unstructured) *PlacementContext {
+	p := &PlacementContext{}
+	if hco == nil {
+		return p
+	}
+	p.Infra = nodePlacement(hco, "infra")
+	p.Workloads = nodePlacement(hco, "workloads")
+	return p
+}
+
+// IsEmpty reports whether neither block sets anything
+func (p *PlacementContext) IsEmpty() bool {
+	return p == nil || (p.Infra == nil && p.Workloads == nil)
+}
+
+func nodePlacement(hco *unstructured.Unstructured, component string) *NodePlacement {
+	path := []string{"spec", component, "nodePlacement"}
+	placement := &NodePlacement{}
+	placement.NodeSelector, _, _ = unstructured.NestedMap(hco.Object, append(path, "nodeSelector")...)
+	placement.Affinity, _, _ = unstructured.NestedMap(hco.Object, append(path, "affinity")...)
+	placement.Tolerations, _, _ = unstructured.NestedSlice(hco.Object, append(path, "tolerations")...)
+	if len(placement.NodeSelector) == 0 && len(placement.Affinity) == 0 && len(placement.Tolerations) == 0 {
+		return nil
+	}
+	return placement
+}
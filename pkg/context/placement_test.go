@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewPlacementContext(t *testing.T) {
+	infraSelector := map[string]any{"node-role.kubernetes.io/infra": ""}
+	tolerations := []any{map[string]any{"key": "infra", "operator": "Exists"}}
+	affinity := map[string]any{"nodeAffinity": map[string]any{}}
+
+	tests := []struct {
+		name  string
+		spec  map[string]any
+		want  *PlacementContext
+		empty bool
+	}{
+		{
+			name:  "no placement",
+			spec:  map[string]any{},
+			want:  &PlacementContext{},
+			empty: true,
+		},
+		{
+			name: "empty blocks are unset",
+			spec: map[string]any{
+				"infra": map[string]any{"nodePlacement": map[string]any{"nodeSelector": map[string]any{}}},
+			},
+			want:  &PlacementContext{},
+			empty: true,
+		},
+		{
+			name: "infra only",
+			spec: map[string]any{
+				"infra": map[string]any{"nodePlacement": map[string]any{
+					"nodeSelector": infraSelector,
+					"tolerations":  tolerations,
+				}},
+			},
+			want: &PlacementContext{Infra: &NodePlacement{NodeSelector: infraSelector, Tolerations: tolerations}},
+		},
+		{
+			name: "workloads affinity",
+			spec: map[string]any{
+				"workloads": map[string]any{"nodePlacement": map[string]any{"affinity": affinity}},
+			},
+			want: &PlacementContext{Workloads: &NodePlacement{Affinity: affinity}},
+		},
+		{
+			name: "malformed fields are ignored",
+			spec: map[string]any{
+				"workloads": map[string]any{"nodePlacement": map[string]any{
+					"nodeSelector": "not-a-map",
+					"affinity":     affinity,
+				}},
+			},
+			want: &PlacementContext{Workloads: &NodePlacement{Affinity: affinity}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco := NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+			if err := unstructured.SetNestedField(hco.Object, tt.spec, "spec"); err != nil {
+				t.Fatalf("failed to set spec: %v", err)
+			}
+
+			got := NewPlacementContext(hco)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPlacementContext() = %+v, want %+v", got, tt.want)
+			}
+			if got.IsEmpty() != tt.empty {
+				t.Errorf("IsEmpty() = %v, want %v", got.IsEmpty(), tt.empty)
+			}
+		})
+	}
+}
+
+func TestPlacementContextIsEmptyNil(t *testing.T) {
+	var p *PlacementContext
+	if !p.IsEmpty() {
+		t.Error("nil PlacementContext should be empty")
+	}
+	if !NewPlacementContext(nil).IsEmpty() {
+		t.Error("placement of a nil HCO should be empty")
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"cmp"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// WorkerPool is the default pool of worker nodes; custom pools take nodes out of it
+	WorkerPool = "worker"
+
+	// MachineConfigRoleLabel on a MachineConfig selects the pools it is rendered into
+	MachineConfigRoleLabel = "machineconfiguration.openshift.io/role"
+)
+
+// Role returns the MachineConfigRoleLabel value that renders a MachineConfig into the
+// pool: the machineConfigSelector matchLabels value, or for a matchExpressions selector
+// (custom pools typically select "In [worker, <pool>]") the pool's own name when listed,
+// else the first value.
+func (p MachineConfigPool) Role() string {
+	selector := p.MachineConfigSelector
+	if selector == nil {
+		return p.Name
+	}
+	if role, ok := selector.MatchLabels[MachineConfigRoleLabel]; ok {
+		return role
+	}
+	for _, expr := range selector.MatchExpressions {
+		if expr.Key != MachineConfigRoleLabel || expr.Operator != metav1.LabelSelectorOpIn || len(expr.Values) == 0 {
+			continue
+		}
+		if slices.Contains(expr.Values, p.Name) {
+			return p.Name
+		}
+		return expr.Values[0]
+	}
+	return p.Name
+}
+
+// Renders reports whether the pool renders MachineConfigs labeled with role. Custom
+// pools usually render the worker MachineConfigs as well as their own.
+func (p MachineConfigPool) Renders(role string) bool {
+	if p.MachineConfigSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.MachineConfigSelector)
+	return err == nil && selector.Matches(labels.Set{MachineConfigRoleLabel: role})
+}
+
+// AssignPoolNodes returns the nodes of each pool by pool name; pools without nodes are
+// left out. Custom pools select a subset of the workers, which the Machine Config
+// Operator then takes out of the worker pool, so a node matching a custom pool belongs
+// to it alone. A nil or invalid node selector matches no node.
+func AssignPoolNodes(nodes []corev1.Node, pools []MachineConfigPool) map[string][]*corev1.Node {
+	sorted := slices.Clone(pools)
+	slices.SortFunc(sorted, func(a, b MachineConfigPool) int {
+		// the worker pool last: it only gets the nodes no custom pool claimed
+		if (a.Name == WorkerPool) != (b.Name == WorkerPool) {
+			if a.Name == WorkerPool {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	selectors := make([]labels.Selector, len(sorted))
+	for i, pool := range sorted {
+		selectors[i] = labels.Nothing()
+		if pool.NodeSelector == nil {
+			continue
+		}
+		if selector, err := metav1.LabelSelectorAsSelector(pool.NodeSelector); err == nil {
+			selectors[i] = selector
+		}
+	}
+
+	members := make(map[string][]*corev1.Node)
+	for i := range nodes {
+		node := &nodes[i]
+		for j, pool := range sorted {
+			if selectors[j].Matches(labels.Set(node.Labels)) {
+				members[pool.Name] = append(members[pool.Name], node)
+				break
+			}
+		}
+	}
+	return members
+}
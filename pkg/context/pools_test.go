@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMachineConfigPoolRole(t *testing.T) {
+	in := func(values ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key: MachineConfigRoleLabel, Operator: metav1.LabelSelectorOpIn, Values: values,
+		}}}
+	}
+	tests := []struct {
+		name     string
+		pool     MachineConfigPool
+		want     string
+		renders  []string
+		excludes []string
+	}{
+		{name: "matchLabels",
+			pool: MachineConfigPool{Name: "worker", MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{MachineConfigRoleLabel: "worker"}}},
+			want: "worker", renders: []string{"worker"}, excludes: []string{"master"}},
+		{name: "custom pool listing its own name",
+			pool: MachineConfigPool{Name: "worker-cnf", MachineConfigSelector: in("worker", "worker-cnf")},
+			want: "worker-cnf", renders: []string{"worker", "worker-cnf"}},
+		{name: "custom pool named differently",
+			pool: MachineConfigPool{Name: "gpu", MachineConfigSelector: in("worker", "accelerated")},
+			want: "worker"},
+		{name: "no selector",
+			pool: MachineConfigPool{Name: "infra"},
+			want: "infra", excludes: []string{"infra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pool.Role(); got != tt.want {
+				t.Errorf("Role() = %q, want %q", got, tt.want)
+			}
+			for _, role := range tt.renders {
+				if !tt.pool.Renders(role) {
+					t.Errorf("Renders(%q) = false, want true", role)
+				}
+			}
+			for _, role := range tt.excludes {
+				if tt.pool.Renders(role) {
+					t.Errorf("Renders(%q) = true, want false", role)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignPoolNodes(t *testing.T) {
+	selector := func(label string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{label: ""}}
+	}
+	pools := []MachineConfigPool{
+		{Name: "worker", NodeSelector: selector("node-role.kubernetes.io/worker")},
+		{Name: "master", NodeSelector: selector("node-role.kubernetes.io/master")},
+		{Name: "worker-cnf", NodeSelector: selector("node-role.kubernetes.io/worker-cnf")},
+		{Name: "infra"},
+	}
+	node := func(name string, roles ...string) corev1.Node {
+		labels := map[string]string{}
+		for _, role := range roles {
+			labels["node-role.kubernetes.io/"+role] = ""
+		}
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	nodes := []corev1.Node{
+		node("master-0", "master", "worker"), // schedulable master
+		node("worker-0", "worker"),
+		node("cnf-0", "worker", "worker-cnf"),
+	}
+
+	got := AssignPoolNodes(nodes, pools)
+
+	want := map[string][]string{"master": {"master-0"}, "worker": {"worker-0"}, "worker-cnf": {"cnf-0"}}
+	if len(got) != len(want) {
+		t.Errorf("AssignPoolNodes() has pools %v, want %v", got, want)
+	}
+	for pool, names := range want {
+		if len(got[pool]) != len(names) || got[pool][0].Name != names[0] {
+			t.Errorf("pool %s has nodes %v, want %v", pool, got[pool], names)
+		}
+	}
+}
@@ -17,8 +17,16 @@ limitations under the License.
 package context
 
 import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 )
 
 const (
@@ -31,6 +39,9 @@ const (
 	// HCOKind is the kind for HyperConverged
 	HCOKind = "HyperConverged"
 
+	// HCOCRDName is the CustomResourceDefinition OLM installs for HyperConverged
+	HCOCRDName = "hyperconvergeds.hco.kubevirt.io"
+
 	// HCOName is the expected name of the HCO instance
 	HCOName = "kubevirt-hyperconverged"
 
@@ -47,21 +58,147 @@ var (
 	}
 )
 
-// RenderContext contains all data needed for rendering asset templates
+// RenderContext contains all data needed for rendering asset templates.
+// The detector and example struct tags feed the generated template context
+// reference (docs context), next to the doc comments.
 type RenderContext struct {
-	HCO      *unstructured.Unstructured // Full HCO object, templates access directly
-	Hardware *HardwareContext           // Cluster-discovered hardware info
-	Topology *TopologyContext           // Cluster topology info (HCP, compact, node counts)
-	Images   map[string]string          // Container images from RELATED_IMAGE_* env vars
+	// Full HCO object, templates access directly
+	HCO *unstructured.Unstructured `detector:"the reconciled HyperConverged"`
+
+	// Cluster-discovered hardware info
+	Hardware *HardwareContext `detector:"Node Feature Discovery labels and GPU resources of the nodes"`
+
+	// Cluster topology info (HCP, compact, node counts)
+	Topology *TopologyContext `detector:"node role labels and the cluster Infrastructure"`
+
+	// Cluster-wide egress proxy and trusted CA
+	Proxy *ProxyContext `detector:"the cluster Proxy"`
+
+	// Cluster installed in FIPS mode
+	FIPS bool `detector:"the FIPS flag of the installer MachineConfigs" example:"false"`
+
+	// In-progress cluster upgrade / MachineConfigPool rollout
+	Upgrade *UpgradeContext `detector:"the ClusterVersion and the MachineConfigPools"`
+
+	// Image mirrors from ImageDigestMirrorSet / ImageContentSourcePolicy
+	Mirrors *MirrorContext `detector:"ImageDigestMirrorSets and ImageContentSourcePolicies"`
+
+	// Storage capabilities (RWX, ODF/Ceph, volume snapshots)
+	Storage *StorageContext `detector:"StorageClasses, CDI StorageProfiles, VolumeSnapshotClasses and ODF StorageClusters"`
+
+	// Cluster network type, Multus, NMState and MTU
+	Network *NetworkContext `detector:"the cluster Network config, NetworkAttachmentDefinition support and NMState"`
+
+	// CPU architectures of the nodes and of the MachineConfig roles
+	Architecture *ArchitectureContext `detector:"the kubernetes.io/arch label of the nodes and the MachineConfigPools"`
+
+	// Recommended PerformanceProfile parameters for the workers
+	PerformanceProfile *PerformanceProfileContext `detector:"CPU, memory and NUMA layout of the worker nodes"`
+
+	// Per-pool hugepage allocation sized from HCO VM density hints
+	HugePages *HugePagesContext `detector:"hugepage density hints on the HyperConverged and the memory and NUMA layout of each MachineConfigPool"`
+
+	// KubeDescheduler tuning from HCO workload hints
+	Descheduler *DeschedulerContext `detector:"workload hint annotations on the HyperConverged"`
+
+	// HCO infra and workloads node placement
+	Placement *PlacementContext `detector:"spec.infra and spec.workloads of the HyperConverged"`
+
+	// KubeVirt rate limit mode: "" (KubeVirt defaults), "annotation" or "highBurst"
+	TuningPolicy string `detector:"spec.virtualization.tuningPolicy of the HyperConverged" example:"highBurst"`
+
+	// Feature gates set on the HCO by name, from either the list or the map form
+	FeatureGates map[string]bool `detector:"spec.featureGates of the HyperConverged" example:"deployKubeSecondaryDNS: true"`
+
+	// Live migration limits and timeouts CNV applies
+	LiveMigration *LiveMigrationContext `detector:"spec.virtualization.liveMigrationConfig of the HyperConverged"`
+
+	// VM CPU overcommit and storage workload resources
+	ResourceRequirements *ResourceRequirementsContext `detector:"spec.virtualization and spec.storage.workloadResourceRequirements of the HyperConverged"`
+
+	// Default MetalLB address pool, set on the HCO or derived from the bare-metal hosts
+	MetalLB *MetalLBContext `detector:"the metallb-address-pools annotation of the HyperConverged, BareMetalHosts, node addresses and the bare-metal Infrastructure"`
+
+	// Container images from RELATED_IMAGE_* env vars
+	Images map[string]string `detector:"RELATED_IMAGE_* environment variables of the operator" example:"kubevirt-metrics-exporter: quay.io/kubevirt/kubevirt-metrics-exporter:v1.0.0"`
+
+	// OverridePatches are the user patches from the HCO's overrides ConfigMap, keyed by asset name
+	OverridePatches map[string]overrides.AssetPatch `detector:"the overrides ConfigMap of the HyperConverged"`
+
+	// Exclusions are the AutopilotExclusions in the HCO namespace, merged with the
+	// disabled-resources annotation when deciding what to apply
+	Exclusions []overrides.Exclusion `detector:"AutopilotExclusions in the namespace of the HyperConverged"`
+
+	// Owner is the AutopilotDeployment of the HCO, set as owner reference on the managed
+	// objects in the HCO namespace (nil = no owner references)
+	Owner *metav1.OwnerReference `detector:"the AutopilotDeployment of the HyperConverged, with --owner-references"`
+
+	// Outputs are the named outputs of rendered assets, keyed by asset name and output name.
+	// The renderer fills it; a template reads the outputs of the assets in its inputs.
+	Outputs map[string]map[string]any `detector:"the outputs of the assets rendered before"`
+
+	// warnings are the render warnings of the assets rendered with this context
+	warnings map[string][]string
+
+	// exclusionMatches are the objects each exclusion kept from being applied, by exclusion name
+	exclusionMatches map[string][]overrides.ExcludedObject
+}
+
+// Warnings returns the render warnings the template of asset emitted in its last render
+func (c *RenderContext) Warnings(asset string) []string {
+	if c == nil {
+		return nil
+	}
+	return c.warnings[asset]
+}
+
+// SetWarnings replaces the render warnings of asset; the renderer calls it on every render
+func (c *RenderContext) SetWarnings(asset string, warnings []string) {
+	if c == nil {
+		return
+	}
+	if len(warnings) == 0 {
+		delete(c.warnings, asset)
+		return
+	}
+	if c.warnings == nil {
+		c.warnings = make(map[string][]string)
+	}
+	c.warnings[asset] = warnings
+}
+
+// RecordExclusionMatch notes that the exclusion named source kept obj from being applied
+func (c *RenderContext) RecordExclusionMatch(source string, obj overrides.ExcludedObject) {
+	if c == nil {
+		return
+	}
+	if c.exclusionMatches == nil {
+		c.exclusionMatches = make(map[string][]overrides.ExcludedObject)
+	}
+	if !slices.Contains(c.exclusionMatches[source], obj) {
+		c.exclusionMatches[source] = append(c.exclusionMatches[source], obj)
+	}
+}
+
+// ExclusionMatches returns the objects the exclusion named source matched so far
+func (c *RenderContext) ExclusionMatches(source string) []overrides.ExcludedObject {
+	if c == nil {
+		return nil
+	}
+	return c.exclusionMatches[source]
 }
 
 // HardwareContext contains cluster hardware detection results
 type HardwareContext struct {
-	PCIDevicesPresent bool // For PCI passthrough
-	NUMANodesPresent  bool // For NUMA topology
-	VFIOCapable       bool // For VFIO device assignment
-	USBDevicesPresent bool // For USB passthrough
-	GPUPresent        bool // For GPU operator
+	PCIDevicesPresent bool `example:"true"` // PCI devices detected, for PCI passthrough
+	NUMANodesPresent  bool `example:"true"` // Multi-NUMA topology detected
+	VFIOCapable       bool `example:"true"` // IOMMU enabled, for VFIO device assignment
+	USBDevicesPresent bool `example:"true"` // USB devices detected, for USB passthrough
+	GPUPresent        bool `example:"true"` // GPUs detected, for the GPU operator
+
+	// PendingRemoval maps detector keys (see AsMap) that are currently held true by
+	// hardware churn damping to the time they will be released. Empty when nothing is pending.
+	PendingRemoval map[string]time.Time
 }
 
 // TopologyContext contains cluster topology detection results.
@@ -69,40 +206,427 @@ type HardwareContext struct {
 type TopologyContext struct {
 	// IsHCP is true when the cluster uses a Hosted Control Plane (HyperShift).
 	// Detected via Infrastructure CR status.controlPlaneTopology == "External".
-	IsHCP bool
+	IsHCP bool `example:"false"`
 
 	// IsCompact is true when all visible master nodes also carry the worker role,
 	// meaning control-plane nodes run regular workloads (typical 3-node clusters).
-	IsCompact bool
+	IsCompact bool `example:"true"`
 
 	// ControlPlaneTopology is the raw value from Infrastructure CR
 	// status.controlPlaneTopology: "HighlyAvailable", "SingleReplica", or "External".
 	// Empty string when the Infrastructure CR is unavailable (non-OpenShift).
-	ControlPlaneTopology string
+	ControlPlaneTopology string `example:"HighlyAvailable"`
 
 	// CloudProvider is the raw platform type from Infrastructure CR
 	// status.platformStatus.type.  Known values: "AWS", "Azure", "GCP",
 	// "BareMetal", "VSphere", "OpenStack", "IBMCloud", "Nutanix", "PowerVS",
 	// "External", "None".  Empty on non-OpenShift clusters.
-	CloudProvider string
+	CloudProvider string `example:"BareMetal"`
 
 	// Convenience booleans derived from CloudProvider.
-	IsAWS       bool
-	IsAzure     bool
-	IsGCP       bool
-	IsBareMetal bool
-	IsVSphere   bool
-	IsOpenStack bool
+	IsAWS       bool `example:"false"`
+	IsAzure     bool `example:"false"`
+	IsGCP       bool `example:"false"`
+	IsBareMetal bool `example:"true"`
+	IsVSphere   bool `example:"false"`
+	IsOpenStack bool `example:"false"`
 
 	// MasterCount is the number of nodes with the master or control-plane role label.
-	MasterCount int
+	MasterCount int `example:"3"`
 
 	// WorkerCount is the number of nodes carrying the worker role but NOT the master
 	// role (dedicated workers). Zero in compact clusters.
-	WorkerCount int
+	WorkerCount int `example:"0"`
 
 	// TotalNodeCount is the total number of nodes visible to the operator.
-	TotalNodeCount int
+	TotalNodeCount int `example:"3"`
+}
+
+// UpgradeContext describes cluster upgrade activity that makes node reboots unsafe.
+// Available in templates as .Upgrade.
+type UpgradeContext struct {
+	// ClusterVersionProgressing is true while the ClusterVersion "version" reports
+	// Progressing=True, i.e. an OpenShift upgrade is rolling out.
+	ClusterVersionProgressing bool `example:"false"`
+
+	// TargetVersion is ClusterVersion status.desired.version. Empty on non-OpenShift clusters.
+	TargetVersion string `example:"4.18.3"`
+
+	// UpdatingPools lists the MachineConfigPools reporting Updating=True, sorted by name.
+	UpdatingPools []string `example:"[worker]"`
+
+	// Pools are all MachineConfigPools, sorted by name, for predicting which pools a
+	// reboot-triggering change rolls out to. Empty without the Machine Config Operator.
+	Pools []MachineConfigPool
+}
+
+// MachineConfigPool is the part of a MachineConfigPool that decides which changes roll
+// out to it and how many nodes then drain and reboot
+type MachineConfigPool struct {
+	// Name and Labels are the pool's metadata; KubeletConfigs and ContainerRuntimeConfigs select pools by label
+	Name   string            `example:"worker"`
+	Labels map[string]string `example:"pools.operator.machineconfiguration.openshift.io/worker: \"\""`
+	// MachineConfigSelector selects the MachineConfigs rendered into the pool; nil selects none
+	MachineConfigSelector *metav1.LabelSelector
+	// NodeSelector selects the nodes of the pool; nil selects none
+	NodeSelector *metav1.LabelSelector
+	// MachineCount is the number of nodes in the pool (status.machineCount)
+	MachineCount int `example:"9"`
+	// Paused is spec.paused: the pool renders new configs but does not roll them out
+	Paused bool `example:"false"`
+	// Updated and Degraded are the pool's Updated and Degraded conditions
+	Updated  bool `example:"true"`
+	Degraded bool `example:"false"`
+	// Configuration is status.configuration.name, the rendered config the pool's nodes are on
+	Configuration string `example:"rendered-worker-5f2c8d0e1b6a"`
+}
+
+// InProgress reports whether a cluster upgrade or MachineConfigPool rollout is underway
+func (u *UpgradeContext) InProgress() bool {
+	return u != nil && (u.ClusterVersionProgressing || len(u.UpdatingPools) > 0)
+}
+
+// ImageMirror maps a source registry or repository to its mirrors, in order of preference
+type ImageMirror struct {
+	// Source is a registry or repository, Mirrors replace it in order of preference
+	Source  string   `example:"quay.io/kubevirt"`
+	Mirrors []string `example:"[mirror.example.com:5000/kubevirt]"`
+}
+
+// MirrorContext contains the cluster's image mirror configuration, merged from
+// ImageDigestMirrorSet (config.openshift.io/v1) and the deprecated
+// ImageContentSourcePolicy (operator.openshift.io/v1alpha1). Empty on connected
+// and non-OpenShift clusters. Available in templates as .Mirrors.
+type MirrorContext struct {
+	// Mirrors is sorted by source, longest first, so the most specific entry matches first
+	Mirrors []ImageMirror
+}
+
+// Enabled reports whether any mirror is configured.
+func (m *MirrorContext) Enabled() bool {
+	return m != nil && len(m.Mirrors) > 0
+}
+
+// Rewrite returns ref with its registry/repository replaced by the first mirror of
+// the most specific matching source, keeping the tag or digest. A source matches
+// when it equals the repository of ref or is a parent path of it. ref is returned
+// unchanged when no source matches.
+// Usage: image: {{ .Mirrors.Rewrite (index .Images "kubevirt-metrics-exporter") }}
+func (m *MirrorContext) Rewrite(ref string) string {
+	if !m.Enabled() || ref == "" {
+		return ref
+	}
+	for _, mirror := range m.Mirrors {
+		if len(mirror.Mirrors) == 0 {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(ref, mirror.Source); ok && isReferenceSuffix(rest) {
+			return mirror.Mirrors[0] + rest
+		}
+	}
+	return ref
+}
+
+// isReferenceSuffix reports whether rest, the part of an image reference after a
+// mirror source, starts a child path, a tag or a digest. ":5000/..." is a registry
+// port, so "registry.example.com" does not match "registry.example.com:5000/img".
+func isReferenceSuffix(rest string) bool {
+	switch {
+	case rest == "":
+		return true
+	case rest[0] == '/' || rest[0] == '@':
+		return true
+	case rest[0] == ':':
+		return !strings.Contains(rest, "/")
+	}
+	return false
+}
+
+// AddMirror records mirrors for source, appending to an existing entry without duplicates,
+// and keeps Mirrors sorted most specific first.
+func (m *MirrorContext) AddMirror(source string, mirrors ...string) {
+	i := slices.IndexFunc(m.Mirrors, func(e ImageMirror) bool { return e.Source == source })
+	if i < 0 {
+		m.Mirrors = append(m.Mirrors, ImageMirror{Source: source})
+		i = len(m.Mirrors) - 1
+	}
+	for _, mirror := range mirrors {
+		if mirror != "" && !slices.Contains(m.Mirrors[i].Mirrors, mirror) {
+			m.Mirrors[i].Mirrors = append(m.Mirrors[i].Mirrors, mirror)
+		}
+	}
+	sort.SliceStable(m.Mirrors, func(a, b int) bool {
+		if len(m.Mirrors[a].Source) != len(m.Mirrors[b].Source) {
+			return len(m.Mirrors[a].Source) > len(m.Mirrors[b].Source)
+		}
+		return m.Mirrors[a].Source < m.Mirrors[b].Source
+	})
+}
+
+// StorageContext describes the storage capabilities VM workloads depend on: RWX volumes
+// for live migration and volume snapshots for backup. Available in templates as .Storage.
+type StorageContext struct {
+	// RWXStorageClasses lists the StorageClasses that can provision ReadWriteMany
+	// volumes, sorted by name.
+	RWXStorageClasses []string `example:"[ocs-storagecluster-ceph-rbd-virtualization]"`
+
+	// DefaultStorageClass is the StorageClass annotated as the cluster default,
+	// or the virt default when one is set. Empty when there is none.
+	DefaultStorageClass string `example:"ocs-storagecluster-ceph-rbd-virtualization"`
+
+	// ODFPresent is true when an OpenShift Data Foundation StorageCluster exists.
+	ODFPresent bool `example:"true"`
+
+	// CephPresent is true when a StorageClass is backed by a Ceph CSI driver
+	// (ODF, Rook or an external Ceph cluster).
+	CephPresent bool `example:"true"`
+
+	// SnapshotClasses lists the VolumeSnapshotClasses, sorted by name.
+	SnapshotClasses []string `example:"[ocs-storagecluster-rbdplugin-snapclass]"`
+}
+
+// HasRWX reports whether any StorageClass supports ReadWriteMany volumes.
+func (s *StorageContext) HasRWX() bool {
+	return s != nil && len(s.RWXStorageClasses) > 0
+}
+
+// DefaultSupportsRWX reports whether the default StorageClass supports ReadWriteMany,
+// i.e. VMs created without an explicit StorageClass are live-migratable.
+func (s *StorageContext) DefaultSupportsRWX() bool {
+	return s != nil && s.DefaultStorageClass != "" && slices.Contains(s.RWXStorageClasses, s.DefaultStorageClass)
+}
+
+// HasSnapshots reports whether any VolumeSnapshotClass is available.
+func (s *StorageContext) HasSnapshots() bool {
+	return s != nil && len(s.SnapshotClasses) > 0
+}
+
+// AsMap converts StorageContext to a map for storage condition evaluation
+func (s *StorageContext) AsMap() map[string]bool {
+	return map[string]bool{
+		"rwxStorageClass":        s.HasRWX(),
+		"defaultStorageClassRWX": s.DefaultSupportsRWX(),
+		"odfPresent":             s != nil && s.ODFPresent,
+		"cephPresent":            s != nil && s.CephPresent,
+		"snapshotClass":          s.HasSnapshots(),
+	}
+}
+
+const (
+	// NetworkTypeOVNKubernetes is the network.config networkType of OVN-Kubernetes clusters
+	NetworkTypeOVNKubernetes = "OVNKubernetes"
+
+	// NetworkTypeOpenShiftSDN is the network.config networkType of legacy OpenShift SDN clusters
+	NetworkTypeOpenShiftSDN = "OpenShiftSDN"
+
+	// IPFamilyIPv4 and IPFamilyIPv6 name the address families, as in Service spec.ipFamilies
+	IPFamilyIPv4 = "IPv4"
+	IPFamilyIPv6 = "IPv6"
+)
+
+// NetworkContext contains cluster networking detection results.
+// Available in templates as .Network.
+type NetworkContext struct {
+	// NetworkType is network.config/cluster status.networkType, e.g. "OVNKubernetes"
+	// or "OpenShiftSDN". Empty on non-OpenShift clusters.
+	NetworkType string `example:"OVNKubernetes"`
+
+	// MTU is the cluster network MTU from network.config/cluster status.clusterNetworkMTU.
+	// Zero when unknown.
+	MTU int64 `example:"1400"`
+
+	// MultusPresent is true when NetworkAttachmentDefinitions are served and the
+	// Cluster Network Operator has not disabled Multus (spec.disableMultiNetwork).
+	MultusPresent bool `example:"true"`
+
+	// NMStatePresent is true when an NMState instance exists, i.e. the kubernetes-nmstate
+	// handler is deployed and NodeNetworkConfigurationPolicies can be applied.
+	NMStatePresent bool `example:"false"`
+
+	// IPFamilies are the cluster's address families, primary first, from the service
+	// and cluster network CIDRs. Empty when unknown.
+	IPFamilies []string `example:"[IPv4 IPv6]"`
+}
+
+// IsOVNKubernetes reports whether the cluster runs OVN-Kubernetes.
+func (n *NetworkContext) IsOVNKubernetes() bool {
+	return n != nil && n.NetworkType == NetworkTypeOVNKubernetes
+}
+
+// IsOpenShiftSDN reports whether the cluster runs the legacy OpenShift SDN.
+func (n *NetworkContext) IsOpenShiftSDN() bool {
+	return n != nil && n.NetworkType == NetworkTypeOpenShiftSDN
+}
+
+// HasIPFamily reports whether the cluster network carries family ("IPv4" or "IPv6").
+// A cluster whose families are unknown is assumed to be IPv4 single-stack.
+func (n *NetworkContext) HasIPFamily(family string) bool {
+	if n == nil || len(n.IPFamilies) == 0 {
+		return family == IPFamilyIPv4
+	}
+	return slices.Contains(n.IPFamilies, family)
+}
+
+// IsDualStack reports whether the cluster network carries both IPv4 and IPv6.
+func (n *NetworkContext) IsDualStack() bool {
+	return n.HasIPFamily(IPFamilyIPv4) && n.HasIPFamily(IPFamilyIPv6)
+}
+
+// IsIPv6Only reports whether the cluster network carries IPv6 but not IPv4.
+func (n *NetworkContext) IsIPv6Only() bool {
+	return n.HasIPFamily(IPFamilyIPv6) && !n.HasIPFamily(IPFamilyIPv4)
+}
+
+// AsMap converts NetworkContext to a map for network condition evaluation
+func (n *NetworkContext) AsMap() map[string]bool {
+	return map[string]bool{
+		"ovnKubernetes":  n.IsOVNKubernetes(),
+		"openShiftSDN":   n.IsOpenShiftSDN(),
+		"multusPresent":  n != nil && n.MultusPresent,
+		"nmstatePresent": n != nil && n.NMStatePresent,
+		"dualStack":      n.IsDualStack(),
+		"ipv6Only":       n.IsIPv6Only(),
+	}
+}
+
+// IPFamilySet converts NetworkContext to a map for ip-family condition evaluation
+func (n *NetworkContext) IPFamilySet() map[string]bool {
+	return map[string]bool{
+		IPFamilyIPv4: n.HasIPFamily(IPFamilyIPv4),
+		IPFamilyIPv6: n.HasIPFamily(IPFamilyIPv6),
+	}
+}
+
+// PerformanceProfileContext holds the parameters of a recommended Node Tuning Operator
+// PerformanceProfile for the worker pool, computed from the workers' CPU, memory and
+// NUMA layout. Available in templates as .PerformanceProfile.
+type PerformanceProfileContext struct {
+	// Ready is true when the workers qualify for a profile; otherwise Reason says why not.
+	Ready  bool   `example:"true"`
+	Reason string `example:"workers have different CPU layouts"`
+
+	// ReservedCPUs and IsolatedCPUs are cpusets (e.g. "0-1,32-33") covering every CPU.
+	ReservedCPUs string `example:"0-1,32-33"`
+	IsolatedCPUs string `example:"2-31,34-63"`
+
+	// HugePageSize is the hugepage size ("1G") and HugePages the number of pages per node.
+	// HugePages is zero when no hugepages should be allocated.
+	HugePageSize string `example:"1G"`
+	HugePages    int64  `example:"16"`
+
+	// TopologyPolicy is the Topology Manager policy for the profile's numa section.
+	TopologyPolicy string `example:"single-numa-node"`
+
+	// CPUs and NUMANodes describe the worker layout the parameters were computed for.
+	CPUs      int64 `example:"64"`
+	NUMANodes int   `example:"2"`
+
+	// Workers is the number of worker nodes the profile applies to.
+	Workers int `example:"6"`
+}
+
+// HugePagesContext holds the hugepage allocation the HCO density hints ask for, and how it
+// fits each MachineConfigPool that runs VMs. Available in templates as .HugePages.
+type HugePagesContext struct {
+	// Ready is true when at least one pool can hold the allocation; otherwise Reason says why not.
+	Ready  bool   `example:"true"`
+	Reason string `example:"no hugepage density hints on the HyperConverged"`
+
+	// PageSize is the hugepage size as a quantity and PageSizeKB the same in the kB unit of
+	// the kernel's /sys/devices/system/node/node*/hugepages/hugepages-<size>kB directories.
+	PageSize   string `example:"1Gi"`
+	PageSizeKB int64  `example:"1048576"`
+
+	// VMsPerNode and PagesPerVM are the density hints the allocation is computed from.
+	VMsPerNode int   `example:"8"`
+	PagesPerVM int64 `example:"16"`
+
+	// MaxPercent is the largest share of a NUMA node's memory that may become hugepages;
+	// nodes skip the allocation on NUMA nodes where it would exceed this.
+	MaxPercent int `example:"80"`
+
+	// Role is the machineconfiguration.openshift.io/role the MachineConfig is rendered
+	// into: "worker", or "master" on compact clusters.
+	Role string `example:"worker"`
+
+	// Pools holds one entry per MachineConfigPool with worker nodes, sorted by name.
+	Pools []HugePagesPool
+}
+
+// HugePagesPool is the expected hugepage allocation on the nodes of one MachineConfigPool
+type HugePagesPool struct {
+	// Name is the pool name, Nodes its number of nodes and NUMANodes the NUMA nodes of each
+	// as far as Node Feature Discovery reports them (1, or 2 for "more than one").
+	Name      string `example:"worker"`
+	Nodes     int    `example:"6"`
+	NUMANodes int    `example:"2"`
+
+	// PagesPerNUMANode is allocated on every NUMA node, so VMs fit on a single one;
+	// Pages is the resulting total per node.
+	PagesPerNUMANode int64 `example:"64"`
+	Pages            int64 `example:"128"`
+
+	// Ready is false when the pool does not render the Role MachineConfigs or its smallest
+	// node cannot hold the allocation; Reason says why.
+	Ready  bool   `example:"true"`
+	Reason string `example:"node worker-3 has 48Gi per NUMA node, 64Gi of hugepages requested"`
+}
+
+const (
+	// TrustedCAInjectLabel is set on an empty ConfigMap to have the OpenShift
+	// Cluster Network Operator inject the merged trusted CA bundle into it.
+	TrustedCAInjectLabel = "config.openshift.io/inject-trusted-cabundle"
+
+	// TrustedCABundleKey is the ConfigMap key the injected CA bundle is written to.
+	TrustedCABundleKey = "ca-bundle.crt"
+)
+
+// ProxyContext contains the cluster-wide egress proxy settings.
+// Populated from the OpenShift Proxy CR (config.openshift.io/v1, name "cluster");
+// all fields are empty on non-OpenShift or non-proxied clusters.
+// Available in templates as .Proxy.
+type ProxyContext struct {
+	// HTTPProxy, HTTPSProxy and NoProxy are the effective values from the Proxy CR
+	// status, which already include the cluster-internal noProxy defaults.
+	HTTPProxy  string `example:"http://proxy.example.com:3128"`
+	HTTPSProxy string `example:"http://proxy.example.com:3128"`
+	NoProxy    string `example:".cluster.local,.svc,10.0.0.0/16,localhost"`
+
+	// TrustedCA is spec.trustedCA.name: the user-provided CA bundle ConfigMap in
+	// openshift-config. Workloads should not mount it directly; instead they create a
+	// ConfigMap labeled TrustedCAInjectLabel and mount its TrustedCABundleKey.
+	TrustedCA string `example:"user-ca-bundle"`
+}
+
+// Enabled reports whether an HTTP or HTTPS proxy is configured.
+func (p *ProxyContext) Enabled() bool {
+	return p != nil && (p.HTTPProxy != "" || p.HTTPSProxy != "")
+}
+
+// HasTrustedCA reports whether a custom trusted CA bundle is configured.
+func (p *ProxyContext) HasTrustedCA() bool {
+	return p != nil && p.TrustedCA != ""
+}
+
+// EnvVars returns the HTTP_PROXY/HTTPS_PROXY/NO_PROXY container env entries for the
+// non-empty proxy settings, in a stable order. Returns nil when no proxy is configured.
+// Usage: {{- range .Proxy.EnvVars }} - name: {{ .name }} value: {{ .value | quote }}{{- end }}
+func (p *ProxyContext) EnvVars() []map[string]any {
+	if !p.Enabled() {
+		return nil
+	}
+
+	var env []map[string]any
+	for _, kv := range []struct{ name, value string }{
+		{"HTTP_PROXY", p.HTTPProxy},
+		{"HTTPS_PROXY", p.HTTPSProxy},
+		{"NO_PROXY", p.NoProxy},
+	} {
+		if kv.value != "" {
+			env = append(env, map[string]any{"name": kv.name, "value": kv.value})
+		}
+	}
+	return env
 }
 
 // AsMap converts TopologyContext to a flat map for condition evaluation.
@@ -135,13 +659,49 @@ func (h *HardwareContext) AsMap() map[string]bool {
 	}
 }
 
+// SetDetector sets the field backing a detector key from AsMap.
+// Unknown keys are ignored.
+func (h *HardwareContext) SetDetector(key string, present bool) {
+	switch key {
+	case "pciDevicesPresent":
+		h.PCIDevicesPresent = present
+	case "numaNodesPresent":
+		h.NUMANodesPresent = present
+	case "vfioCapable":
+		h.VFIOCapable = present
+	case "usbDevicesPresent":
+		h.USBDevicesPresent = present
+	case "gpuPresent":
+		h.GPUPresent = present
+	}
+}
+
 // NewRenderContext creates a new render context from an HCO object
 func NewRenderContext(hco *unstructured.Unstructured) *RenderContext {
+	// Invalid hints are dropped here; the controller logs them when it builds the context
+	descheduler, _ := NewDeschedulerContext(hco)
 	return &RenderContext{
-		HCO:      hco,
-		Hardware: &HardwareContext{},
-		Topology: &TopologyContext{},
-		Images:   make(map[string]string),
+		HCO:                  hco,
+		Hardware:             &HardwareContext{},
+		Topology:             &TopologyContext{},
+		Proxy:                &ProxyContext{},
+		Upgrade:              &UpgradeContext{},
+		Mirrors:              &MirrorContext{},
+		Storage:              &StorageContext{},
+		Network:              &NetworkContext{},
+		Architecture:         &ArchitectureContext{},
+		PerformanceProfile:   &PerformanceProfileContext{},
+		HugePages:            &HugePagesContext{},
+		Descheduler:          descheduler,
+		Placement:            NewPlacementContext(hco),
+		TuningPolicy:         HCOTuningPolicy(hco),
+		FeatureGates:         HCOFeatureGates(hco),
+		LiveMigration:        NewLiveMigrationContext(hco),
+		ResourceRequirements: NewResourceRequirementsContext(hco),
+		MetalLB:              NewMetalLBContext(hco, nil),
+		Images:               make(map[string]string),
+		OverridePatches:      make(map[string]overrides.AssetPatch),
+		Outputs:              make(map[string]map[string]any),
 	}
 }
 
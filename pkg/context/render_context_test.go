@@ -17,6 +17,7 @@ limitations under the License.
 package context
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -100,3 +101,183 @@ func TestHardwareContext_AsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageContext_AsMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *StorageContext
+		want    map[string]bool
+	}{
+		{
+			name: "ODF with RWX default and snapshots",
+			storage: &StorageContext{
+				RWXStorageClasses:   []string{"ocs-storagecluster-ceph-rbd", "ocs-storagecluster-cephfs"},
+				DefaultStorageClass: "ocs-storagecluster-ceph-rbd",
+				ODFPresent:          true,
+				CephPresent:         true,
+				SnapshotClasses:     []string{"ocs-storagecluster-rbdplugin-snapclass"},
+			},
+			want: map[string]bool{
+				"rwxStorageClass":        true,
+				"defaultStorageClassRWX": true,
+				"odfPresent":             true,
+				"cephPresent":            true,
+				"snapshotClass":          true,
+			},
+		},
+		{
+			name: "RWX class that is not the default",
+			storage: &StorageContext{
+				RWXStorageClasses:   []string{"nfs"},
+				DefaultStorageClass: "lvms-vg1",
+			},
+			want: map[string]bool{
+				"rwxStorageClass":        true,
+				"defaultStorageClassRWX": false,
+				"odfPresent":             false,
+				"cephPresent":            false,
+				"snapshotClass":          false,
+			},
+		},
+		{
+			name:    "nil context",
+			storage: nil,
+			want: map[string]bool{
+				"rwxStorageClass":        false,
+				"defaultStorageClassRWX": false,
+				"odfPresent":             false,
+				"cephPresent":            false,
+				"snapshotClass":          false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.storage.AsMap()
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("AsMap()[%q] = %v, want %v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNetworkContext_AsMap(t *testing.T) {
+	ovn := (&NetworkContext{NetworkType: NetworkTypeOVNKubernetes, MultusPresent: true}).AsMap()
+	if !ovn["ovnKubernetes"] || ovn["openShiftSDN"] || !ovn["multusPresent"] || ovn["nmstatePresent"] {
+		t.Errorf("AsMap() = %v for an OVN-Kubernetes cluster with Multus", ovn)
+	}
+
+	var missing *NetworkContext
+	for detector, detected := range missing.AsMap() {
+		if detected {
+			t.Errorf("AsMap()[%q] = true on a nil NetworkContext", detector)
+		}
+	}
+}
+
+func TestNetworkContext_IPFamilies(t *testing.T) {
+	tests := []struct {
+		name                string
+		network             *NetworkContext
+		ipv4, ipv6          bool
+		dualStack, ipv6Only bool
+	}{
+		{"unknown counts as IPv4", &NetworkContext{}, true, false, false, false},
+		{"nil counts as IPv4", nil, true, false, false, false},
+		{"dual-stack", &NetworkContext{IPFamilies: []string{IPFamilyIPv6, IPFamilyIPv4}}, true, true, true, false},
+		{"IPv6-only", &NetworkContext{IPFamilies: []string{IPFamilyIPv6}}, false, true, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := tt.network.IPFamilySet()
+			if set[IPFamilyIPv4] != tt.ipv4 || set[IPFamilyIPv6] != tt.ipv6 {
+				t.Errorf("IPFamilySet() = %v, want IPv4=%v IPv6=%v", set, tt.ipv4, tt.ipv6)
+			}
+			if got := tt.network.IsDualStack(); got != tt.dualStack {
+				t.Errorf("IsDualStack() = %v, want %v", got, tt.dualStack)
+			}
+			if got := tt.network.AsMap()["ipv6Only"]; got != tt.ipv6Only {
+				t.Errorf("AsMap()[ipv6Only] = %v, want %v", got, tt.ipv6Only)
+			}
+		})
+	}
+}
+
+func TestMirrorContext_Rewrite(t *testing.T) {
+	mirrors := &MirrorContext{}
+	mirrors.AddMirror("quay.io/kubevirt", "mirror.example.com:5000/kubevirt")
+	mirrors.AddMirror("quay.io/kubevirt/metrics-exporter", "mirror.example.com:5000/exporter")
+	mirrors.AddMirror("registry.example.com", "mirror.example.com:5000/example")
+
+	tests := []struct {
+		name    string
+		mirrors *MirrorContext
+		ref     string
+		want    string
+	}{
+		{"nil context", nil, "quay.io/kubevirt/virt-launcher:v1", "quay.io/kubevirt/virt-launcher:v1"},
+		{"repository under source", mirrors, "quay.io/kubevirt/virt-launcher:v1", "mirror.example.com:5000/kubevirt/virt-launcher:v1"},
+		{"most specific source wins", mirrors, "quay.io/kubevirt/metrics-exporter@sha256:abc", "mirror.example.com:5000/exporter@sha256:abc"},
+		{"source equals repository", mirrors, "quay.io/kubevirt/metrics-exporter:v0.3", "mirror.example.com:5000/exporter:v0.3"},
+		{"source is not a path prefix", mirrors, "quay.io/kubevirt-extra/img:v1", "quay.io/kubevirt-extra/img:v1"},
+		{"registry port is not a tag", mirrors, "registry.example.com:5000/img:v1", "registry.example.com:5000/img:v1"},
+		{"no matching source", mirrors, "docker.io/library/busybox:latest", "docker.io/library/busybox:latest"},
+		{"empty reference", mirrors, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mirrors.Rewrite(tt.ref); got != tt.want {
+				t.Errorf("Rewrite(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyContext_EnvVars(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy *ProxyContext
+		want  []map[string]any
+	}{
+		{
+			name:  "nil proxy",
+			proxy: nil,
+			want:  nil,
+		},
+		{
+			name:  "noProxy alone does not enable the proxy",
+			proxy: &ProxyContext{NoProxy: ".cluster.local"},
+			want:  nil,
+		},
+		{
+			name:  "https only",
+			proxy: &ProxyContext{HTTPSProxy: "http://proxy:3128", NoProxy: ".cluster.local"},
+			want: []map[string]any{
+				{"name": "HTTPS_PROXY", "value": "http://proxy:3128"},
+				{"name": "NO_PROXY", "value": ".cluster.local"},
+			},
+		},
+		{
+			name:  "all settings",
+			proxy: &ProxyContext{HTTPProxy: "http://p:80", HTTPSProxy: "http://p:443", NoProxy: "localhost"},
+			want: []map[string]any{
+				{"name": "HTTP_PROXY", "value": "http://p:80"},
+				{"name": "HTTPS_PROXY", "value": "http://p:443"},
+				{"name": "NO_PROXY", "value": "localhost"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.proxy.EnvVars(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnvVars() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+)
+
+// contextSources are the files declaring the RenderContext types; their doc comments
+// are the field and method descriptions of the schema, so they cannot drift apart
+//
+//go:embed render_context.go descheduler.go placement.go metallb.go tuning.go pools.go architecture.go
+var contextSources embed.FS
+
+// ContextField documents one field reachable from the template root, e.g. .Topology.IsCompact.
+// Elements of slices are written as [], e.g. .Mirrors.Mirrors[].Source.
+type ContextField struct {
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Detector names what the value is detected from, per the detector tag of its top-level field
+	Detector string `json:"detector,omitempty"`
+	// Example is a representative value, per the field's example tag
+	Example string `json:"example,omitempty"`
+}
+
+// ContextMethod documents a method templates can call on a context value,
+// e.g. .Mirrors.Rewrite with the signature Rewrite(string) string
+type ContextMethod struct {
+	Path        string `json:"path"`
+	Signature   string `json:"signature"`
+	Description string `json:"description,omitempty"`
+}
+
+// ContextSchema describes everything a template can read from the RenderContext
+type ContextSchema struct {
+	Fields  []ContextField  `json:"fields"`
+	Methods []ContextMethod `json:"methods"`
+}
+
+// Schema derives the ContextSchema from the RenderContext type: field names and types
+// by reflection, descriptions from the doc comments, detectors and examples from the
+// detector and example struct tags. Fields are in declaration order.
+func Schema() (*ContextSchema, error) {
+	docs, err := parseContextDocs()
+	if err != nil {
+		return nil, err
+	}
+	w := &schemaWalker{docs: docs, schema: &ContextSchema{}, visited: make(map[reflect.Type]bool)}
+	w.walk(reflect.TypeOf(RenderContext{}), "", "")
+	return w.schema, nil
+}
+
+type schemaWalker struct {
+	docs    contextDocs
+	schema  *ContextSchema
+	visited map[reflect.Type]bool
+}
+
+// walk documents the exported fields of struct type t found at path, then its methods
+func (w *schemaWalker) walk(t reflect.Type, path, detector string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := path + "." + field.Name
+		fieldDetector := detector
+		if tag := field.Tag.Get("detector"); tag != "" {
+			fieldDetector = tag
+		}
+		w.schema.Fields = append(w.schema.Fields, ContextField{
+			Path:        fieldPath,
+			Type:        typeName(field.Type),
+			Description: w.docs.fields[t.Name()+"."+field.Name],
+			Detector:    fieldDetector,
+			Example:     field.Tag.Get("example"),
+		})
+
+		// Descend into the context's own types; others (unstructured, LabelSelector) are opaque
+		elem := field.Type
+		for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Slice {
+			if elem.Kind() == reflect.Slice {
+				fieldPath += "[]"
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct && elem.PkgPath() == t.PkgPath() {
+			w.walk(elem, fieldPath, fieldDetector)
+			w.methods(elem, fieldPath)
+		}
+	}
+}
+
+// methods documents the exported methods of t that return a value; the others
+// (e.g. AddMirror) mutate the context and are of no use in a template
+func (w *schemaWalker) methods(t reflect.Type, path string) {
+	if w.visited[t] {
+		return
+	}
+	w.visited[t] = true
+
+	ptr := reflect.PointerTo(t)
+	for i := 0; i < ptr.NumMethod(); i++ {
+		method := ptr.Method(i)
+		if method.Type.NumOut() == 0 {
+			continue
+		}
+		w.schema.Methods = append(w.schema.Methods, ContextMethod{
+			Path:        path + "." + method.Name,
+			Signature:   signature(method),
+			Description: w.docs.methods[t.Name()+"."+method.Name],
+		})
+	}
+}
+
+// signature formats method without its receiver, e.g. Rewrite(string) string
+func signature(method reflect.Method) string {
+	var in, out []string
+	for i := 1; i < method.Type.NumIn(); i++ { // 0 is the receiver
+		in = append(in, typeName(method.Type.In(i)))
+	}
+	for i := 0; i < method.Type.NumOut(); i++ {
+		out = append(out, typeName(method.Type.Out(i)))
+	}
+	result := strings.Join(out, ", ")
+	if len(out) > 1 {
+		result = "(" + result + ")"
+	}
+	return fmt.Sprintf("%s(%s) %s", method.Name, strings.Join(in, ", "), result)
+}
+
+// typeName is the Go type of t with this package's qualifier dropped
+func typeName(t reflect.Type) string {
+	name := strings.ReplaceAll(t.String(), "interface {}", "any")
+	return strings.ReplaceAll(name, "context.", "")
+}
+
+// contextDocs are the doc comments of the context types, keyed by Type.Field and Type.Method
+type contextDocs struct {
+	fields  map[string]string
+	methods map[string]string
+}
+
+func parseContextDocs() (contextDocs, error) {
+	docs := contextDocs{fields: make(map[string]string), methods: make(map[string]string)}
+
+	entries, err := contextSources.ReadDir(".")
+	if err != nil {
+		return docs, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		src, err := contextSources.ReadFile(entry.Name())
+		if err != nil {
+			return docs, err
+		}
+		file, err := parser.ParseFile(fset, entry.Name(), src, parser.ParseComments)
+		if err != nil {
+			return docs, fmt.Errorf("failed to parse %s: %w", entry.Name(), err)
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						if st, ok := ts.Type.(*ast.StructType); ok {
+							structDocs(fset, ts.Name.Name, st, docs.fields)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) != 1 || decl.Doc == nil {
+					continue
+				}
+				recv := decl.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					docs.methods[ident.Name+"."+decl.Name.Name] = commentText(decl.Doc)
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+// structDocs records the comments of the fields of st. A comment above a block of
+// fields without blank lines in between, such as "HTTPProxy, HTTPSProxy and NoProxy
+// are ...", describes every field of the block.
+func structDocs(fset *token.FileSet, typeName string, st *ast.StructType, into map[string]string) {
+	var blockDoc string
+	lastLine := -1
+	for _, field := range st.Fields.List {
+		line := fset.Position(field.Pos()).Line
+		switch {
+		case field.Doc != nil:
+			blockDoc = commentText(field.Doc)
+		case line != lastLine+1:
+			blockDoc = ""
+		}
+		lastLine = fset.Position(field.End()).Line
+
+		doc := blockDoc
+		if field.Comment != nil {
+			doc = commentText(field.Comment)
+		}
+		for _, name := range field.Names {
+			into[typeName+"."+name.Name] = doc
+		}
+	}
+}
+
+// commentText joins a comment into one line and drops template usage examples,
+// which are Go template syntax rather than prose
+func commentText(group *ast.CommentGroup) string {
+	var lines []string
+	for _, line := range strings.Split(group.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "Usage:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	schema, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+
+	fields := make(map[string]ContextField, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fields[field.Path] = field
+	}
+
+	compact, ok := fields[".Topology.IsCompact"]
+	if !ok {
+		t.Fatal("schema lacks .Topology.IsCompact")
+	}
+	if compact.Type != "bool" || compact.Example != "true" {
+		t.Errorf(".Topology.IsCompact = %+v, want a bool with an example", compact)
+	}
+	if !strings.HasPrefix(compact.Description, "IsCompact is true when") {
+		t.Errorf(".Topology.IsCompact description = %q, want its doc comment", compact.Description)
+	}
+	// Nested fields inherit the detector of their top-level field
+	if compact.Detector != fields[".Topology"].Detector || compact.Detector == "" {
+		t.Errorf(".Topology.IsCompact detector = %q, want the one of .Topology", compact.Detector)
+	}
+
+	// A comment above a block of fields describes each of them
+	if got, want := fields[".Proxy.NoProxy"].Description, fields[".Proxy.HTTPProxy"].Description; got != want || got == "" {
+		t.Errorf(".Proxy.NoProxy description = %q, want the block comment %q", got, want)
+	}
+	if _, ok := fields[".Upgrade.Pools[].MachineCount"]; !ok {
+		t.Error("schema lacks the slice element field .Upgrade.Pools[].MachineCount")
+	}
+	if pools := fields[".Upgrade.Pools"]; pools.Type != "[]MachineConfigPool" {
+		t.Errorf(".Upgrade.Pools type = %q, want []MachineConfigPool", pools.Type)
+	}
+}
+
+// TestSchemaDocumented fails when a context field or method is added without a doc
+// comment, or a top-level field without a detector tag
+func TestSchemaDocumented(t *testing.T) {
+	schema, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	for _, field := range schema.Fields {
+		if field.Description == "" {
+			t.Errorf("field %s has no doc comment", field.Path)
+		}
+		if field.Detector == "" {
+			t.Errorf("field %s has no detector tag", field.Path)
+		}
+	}
+	for _, method := range schema.Methods {
+		if method.Description == "" {
+			t.Errorf("method %s has no doc comment", method.Path)
+		}
+	}
+}
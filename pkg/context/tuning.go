@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// TuningPolicyAnnotation takes KubeVirt's rate limits from the hco.kubevirt.io/tuningPolicy annotation
+	TuningPolicyAnnotation = "annotation"
+	// TuningPolicyHighBurst raises KubeVirt's rate limits for large clusters
+	TuningPolicyHighBurst = "highBurst"
+)
+
+// LiveMigrationContext carries spec.virtualization.liveMigrationConfig of the HCO, so
+// templates can size eviction and bandwidth settings to the migrations CNV allows.
+// Unset fields hold the HCO's defaults. Available in templates as .LiveMigration.
+type LiveMigrationContext struct {
+	// ParallelMigrationsPerCluster is the number of migrations running at once in the cluster
+	ParallelMigrationsPerCluster int64 `example:"5"`
+	// ParallelOutboundMigrationsPerNode is the number of outbound migrations at once per node
+	ParallelOutboundMigrationsPerNode int64 `example:"2"`
+	// BandwidthPerMigration is the bandwidth limit of each migration as a quantity of
+	// bytes per second; empty when unlimited
+	BandwidthPerMigration string `example:"64Mi"`
+	// CompletionTimeoutPerGiB is the seconds a migration may take per GiB of guest
+	CompletionTimeoutPerGiB int64 `example:"150"`
+	// ProgressTimeout is the seconds a migration may go without progress
+	ProgressTimeout int64 `example:"150"`
+	// Network is the NetworkAttachmentDefinition migrations run over; empty for the pod network
+	Network string `example:"migration-network"`
+	// AllowAutoConverge lets KubeVirt throttle a guest so its migration converges
+	AllowAutoConverge bool
+	// AllowPostCopy lets KubeVirt fall back to post-copy migration
+	AllowPostCopy bool
+}
+
+// NewLiveMigrationContext reads spec.virtualization.liveMigrationConfig from hco
+func NewLiveMigrationContext(hco *unstructured.Unstructured) *LiveMigrationContext {
+	m := &LiveMigrationContext{
+		ParallelMigrationsPerCluster:      5,
+		ParallelOutboundMigrationsPerNode: 2,
+		CompletionTimeoutPerGiB:           150,
+		ProgressTimeout:                   150,
+	}
+	if hco == nil {
+		return m
+	}
+	config, _, _ := unstructured.NestedMap(hco.Object, "spec", "virtualization", "liveMigrationConfig")
+	setInt(config, "parallelMigrationsPerCluster", &m.ParallelMigrationsPerCluster)
+	setInt(config, "parallelOutboundMigrationsPerNode", &m.ParallelOutboundMigrationsPerNode)
+	setInt(config, "completionTimeoutPerGiB", &m.CompletionTimeoutPerGiB)
+	setInt(config, "progressTimeout", &m.ProgressTimeout)
+	m.BandwidthPerMigration, _, _ = unstructured.NestedString(config, "bandwidthPerMigration")
+	m.Network, _, _ = unstructured.NestedString(config, "network")
+	m.AllowAutoConverge, _, _ = unstructured.NestedBool(config, "allowAutoConverge")
+	m.AllowPostCopy, _, _ = unstructured.NestedBool(config, "allowPostCopy")
+	return m
+}
+
+// BandwidthBytes returns BandwidthPerMigration in bytes per second, or 0 when unlimited
+func (m *LiveMigrationContext) BandwidthBytes() int64 {
+	if m == nil || m.BandwidthPerMigration == "" {
+		return 0
+	}
+	q, err := resource.ParseQuantity(m.BandwidthPerMigration)
+	if err != nil {
+		return 0
+	}
+	return q.Value()
+}
+
+// ResourceRequirementsContext carries the HCO settings that size VM pods: the CPU
+// overcommit of spec.virtualization and the resources of storage workloads.
+// Available in templates as .ResourceRequirements.
+type ResourceRequirementsContext struct {
+	// VMICPUAllocationRatio is the vCPUs per requested physical CPU (spec.virtualization.vmiCPUAllocationRatio)
+	VMICPUAllocationRatio int64 `example:"10"`
+	// AutoCPULimitNamespaceLabelSelector selects the namespaces whose VM pods get a CPU limit;
+	// nil when unset
+	AutoCPULimitNamespaceLabelSelector map[string]any
+	// StorageWorkloads is spec.storage.workloadResourceRequirements, the resources of CDI
+	// import and upload pods, kept as is so templates can emit it with toJson; nil when unset
+	StorageWorkloads map[string]any `example:"limits: {cpu: 500m, memory: 1Gi}"`
+}
+
+// NewResourceRequirementsContext reads the resource settings from hco
+func NewResourceRequirementsContext(hco *unstructured.Unstructured) *ResourceRequirementsContext {
+	r := &ResourceRequirementsContext{VMICPUAllocationRatio: 10}
+	if hco == nil {
+		return r
+	}
+	virtualization, _, _ := unstructured.NestedMap(hco.Object, "spec", "virtualization")
+	setInt(virtualization, "vmiCPUAllocationRatio", &r.VMICPUAllocationRatio)
+	r.AutoCPULimitNamespaceLabelSelector, _, _ = unstructured.NestedMap(virtualization, "autoCPULimitNamespaceLabelSelector")
+	r.StorageWorkloads, _, _ = unstructured.NestedMap(hco.Object, "spec", "storage", "workloadResourceRequirements")
+	return r
+}
+
+// HCOTuningPolicy returns spec.virtualization.tuningPolicy of hco, or "" when KubeVirt's
+// default rate limits apply
+func HCOTuningPolicy(hco *unstructured.Unstructured) string {
+	if hco == nil {
+		return ""
+	}
+	policy, _, _ := unstructured.NestedString(hco.Object, "spec", "virtualization", "tuningPolicy")
+	return policy
+}
+
+// setInt overwrites *dst with the integer at key of obj, if it is set and positive.
+// Malformed values are ignored, as the HCO webhook already validates them.
+func setInt(obj map[string]any, key string, dst *int64) {
+	if value, found, err := unstructured.NestedInt64(obj, key); err == nil && found && value > 0 {
+		*dst = value
+	}
+}
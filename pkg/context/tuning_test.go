@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func hcoWithSpec(spec map[string]any) *unstructured.Unstructured {
+	hco := NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.Object["spec"] = spec
+	return hco
+}
+
+func TestNewLiveMigrationContext(t *testing.T) {
+	defaults := &LiveMigrationContext{
+		ParallelMigrationsPerCluster:      5,
+		ParallelOutboundMigrationsPerNode: 2,
+		CompletionTimeoutPerGiB:           150,
+		ProgressTimeout:                   150,
+	}
+	if got := NewLiveMigrationContext(hcoWithSpec(map[string]any{})); !reflect.DeepEqual(got, defaults) {
+		t.Errorf("NewLiveMigrationContext() without config = %+v, want the HCO defaults", got)
+	}
+
+	hco := hcoWithSpec(map[string]any{
+		"virtualization": map[string]any{
+			"liveMigrationConfig": map[string]any{
+				"parallelMigrationsPerCluster": int64(10),
+				"bandwidthPerMigration":        "64Mi",
+				"network":                      "migration-network",
+				"allowPostCopy":                true,
+				// Malformed values keep the default
+				"progressTimeout": "soon",
+			},
+		},
+	})
+	want := &LiveMigrationContext{
+		ParallelMigrationsPerCluster:      10,
+		ParallelOutboundMigrationsPerNode: 2,
+		BandwidthPerMigration:             "64Mi",
+		CompletionTimeoutPerGiB:           150,
+		ProgressTimeout:                   150,
+		Network:                           "migration-network",
+		AllowPostCopy:                     true,
+	}
+	got := NewLiveMigrationContext(hco)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewLiveMigrationContext() = %+v, want %+v", got, want)
+	}
+	if bytes := got.BandwidthBytes(); bytes != 64*1024*1024 {
+		t.Errorf("BandwidthBytes() = %d, want %d", bytes, 64*1024*1024)
+	}
+	if bytes := defaults.BandwidthBytes(); bytes != 0 {
+		t.Errorf("BandwidthBytes() without a limit = %d, want 0", bytes)
+	}
+}
+
+func TestNewResourceRequirementsContext(t *testing.T) {
+	if got := NewResourceRequirementsContext(nil); got.VMICPUAllocationRatio != 10 || got.StorageWorkloads != nil {
+		t.Errorf("NewResourceRequirementsContext(nil) = %+v, want the defaults", got)
+	}
+
+	limits := map[string]any{"limits": map[string]any{"cpu": "500m"}}
+	selector := map[string]any{"matchLabels": map[string]any{"autocpulimit": "true"}}
+	hco := hcoWithSpec(map[string]any{
+		"virtualization": map[string]any{
+			"tuningPolicy":                       TuningPolicyHighBurst,
+			"vmiCPUAllocationRatio":              int64(4),
+			"autoCPULimitNamespaceLabelSelector": selector,
+		},
+		"storage": map[string]any{"workloadResourceRequirements": limits},
+	})
+	want := &ResourceRequirementsContext{
+		VMICPUAllocationRatio:              4,
+		AutoCPULimitNamespaceLabelSelector: selector,
+		StorageWorkloads:                   limits,
+	}
+	if got := NewResourceRequirementsContext(hco); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewResourceRequirementsContext() = %+v, want %+v", got, want)
+	}
+	if got := HCOTuningPolicy(hco); got != TuningPolicyHighBurst {
+		t.Errorf("HCOTuningPolicy() = %q, want %q", got, TuningPolicyHighBurst)
+	}
+	if got := NewRenderContext(hco); got.TuningPolicy != TuningPolicyHighBurst || got.LiveMigration == nil || got.ResourceRequirements.VMICPUAllocationRatio != 4 {
+		t.Errorf("NewRenderContext() did not carry the HCO virtualization settings: %+v", got)
+	}
+}
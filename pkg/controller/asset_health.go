@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// DefaultAssetErrorThreshold is the fraction of failed assets at which the assets
+// readiness check fails unless configured
+const DefaultAssetErrorThreshold = 0.5
+
+// assetPass is the outcome of the last asset pass of one HCO
+type assetPass struct {
+	failed int
+	total  int
+}
+
+// assetHealth keeps the last asset pass of every HCO for the assets readiness check
+type assetHealth struct {
+	mu     sync.Mutex
+	passes map[types.NamespacedName]assetPass
+}
+
+// record stores the outcome of a pass; passes without assets are forgotten
+func (h *assetHealth) record(key types.NamespacedName, failed, total int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if total == 0 {
+		delete(h.passes, key)
+		return
+	}
+	if h.passes == nil {
+		h.passes = make(map[types.NamespacedName]assetPass)
+	}
+	h.passes[key] = assetPass{failed: failed, total: total}
+}
+
+// forget drops the HCO, e.g. once it is deleted or the autopilot is disabled on it
+func (h *assetHealth) forget(key types.NamespacedName) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.passes, key)
+}
+
+// degraded lists every HCO whose failed fraction reached threshold, sorted by key
+func (h *assetHealth) degraded(threshold float64) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var degraded []string
+	for key, pass := range h.passes {
+		if float64(pass.failed)/float64(pass.total) >= threshold {
+			degraded = append(degraded, fmt.Sprintf("%s: %d/%d assets failed", key, pass.failed, pass.total))
+		}
+	}
+	slices.Sort(degraded)
+	return degraded
+}
+
+// SetAssetErrorThreshold sets the fraction of failed assets, in (0, 1], at which
+// AssetsReadyzCheck fails
+func (r *PlatformReconciler) SetAssetErrorThreshold(threshold float64) {
+	r.assetErrorThreshold = threshold
+}
+
+// AssetsReadyzCheck is a healthz.Checker that fails while the last asset pass of any
+// HCO failed for at least the configured fraction of its assets, so a rollout of an
+// operator version that breaks asset application stalls instead of completing.
+// It passes until a first pass completed: a replica that is not the leader, or whose
+// HCOs have the autopilot disabled, applies nothing and is never degraded.
+func (r *PlatformReconciler) AssetsReadyzCheck(_ *http.Request) error {
+	threshold := r.assetErrorThreshold
+	if threshold <= 0 {
+		threshold = DefaultAssetErrorThreshold
+	}
+	degraded := r.assetHealth.degraded(threshold)
+	if len(degraded) == 0 {
+		return nil
+	}
+	return fmt.Errorf("asset error threshold %.0f%% reached: %s", threshold*100, strings.Join(degraded, "; "))
+}
+
+// recordAssetHealth stores the outcome of an asset pass: the failures ReconcileAssets
+// reported plus the assets skipped because their conditions could not be evaluated
+func (r *PlatformReconciler) recordAssetHealth(key types.NamespacedName, attempted, conditionFailures int, err error) {
+	failed := conditionFailures
+	var assetErrs *engine.AssetErrors
+	if errors.As(err, &assetErrs) {
+		failed += len(assetErrs.Failures)
+	}
+	r.assetHealth.record(key, failed, attempted+conditionFailures)
+}
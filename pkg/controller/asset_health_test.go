@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func failedAssets(n, total int) error {
+	errs := &engine.AssetErrors{Total: total}
+	for range n {
+		errs.Failures = append(errs.Failures, engine.AssetFailure{Asset: "a", Reason: engine.ReasonApplyFailed, Err: errors.New("boom")})
+	}
+	return errs
+}
+
+func TestAssetsReadyzCheck(t *testing.T) {
+	r := &PlatformReconciler{}
+	r.SetAssetErrorThreshold(0.5)
+	key := types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}
+
+	if err := r.AssetsReadyzCheck(nil); err != nil {
+		t.Fatalf("ready before any pass, got %v", err)
+	}
+
+	r.recordAssetHealth(key, 10, 0, failedAssets(4, 10))
+	if err := r.AssetsReadyzCheck(nil); err != nil {
+		t.Errorf("4/10 failed is below the threshold, got %v", err)
+	}
+
+	// Condition errors count towards both the failures and the assets of the pass
+	r.recordAssetHealth(key, 8, 2, failedAssets(3, 8))
+	err := r.AssetsReadyzCheck(nil)
+	if err == nil {
+		t.Fatal("5/10 failed reaches the threshold, want error")
+	}
+	if !strings.Contains(err.Error(), "openshift-cnv/kubevirt-hyperconverged: 5/10 assets failed") {
+		t.Errorf("unexpected message: %v", err)
+	}
+
+	r.recordAssetHealth(key, 10, 0, nil)
+	if err := r.AssetsReadyzCheck(nil); err != nil {
+		t.Errorf("ready after a clean pass, got %v", err)
+	}
+
+	r.recordAssetHealth(key, 2, 0, failedAssets(2, 2))
+	if r.AssetsReadyzCheck(nil) == nil {
+		t.Fatal("all assets failed, want error")
+	}
+	r.assetHealth.forget(key)
+	if err := r.AssetsReadyzCheck(nil); err != nil {
+		t.Errorf("ready once the HCO is forgotten, got %v", err)
+	}
+}
+
+func TestAssetsReadyzCheckIgnoresEmptyPasses(t *testing.T) {
+	r := &PlatformReconciler{}
+	key := types.NamespacedName{Namespace: "ns", Name: "hco"}
+
+	r.recordAssetHealth(key, 1, 0, failedAssets(1, 1))
+	r.recordAssetHealth(key, 0, 0, nil)
+	if err := r.AssetsReadyzCheck(nil); err != nil {
+		t.Errorf("a pass without assets should clear the HCO, got %v", err)
+	}
+}
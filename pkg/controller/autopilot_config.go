@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/config"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// LoadConfig installs the apply mutators of the AutopilotConfig at path and makes every
+// reconcile re-read the file, so a policy edit in the mounted ConfigMap applies on the
+// next reconcile without a restart. An empty path installs no mutators and never reloads.
+func (r *PlatformReconciler) LoadConfig(path string) error {
+	if path == "" {
+		r.SetMutators(nil)
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read autopilot config %s: %w", path, err)
+	}
+	mutators, err := buildConfigMutators(data)
+	if err != nil {
+		return err
+	}
+
+	r.SetMutators(mutators)
+	r.configMu.Lock()
+	r.configPath = path
+	r.configDigest = configDigest(data)
+	r.configMu.Unlock()
+	return nil
+}
+
+// reloadConfig rebuilds the apply mutators when the config file changed since they were
+// built. A config that cannot be read or fails to build keeps the mutators in use, so a
+// bad edit never drops policy from the managed objects.
+func (r *PlatformReconciler) reloadConfig(ctx context.Context) {
+	r.configMu.Lock()
+	path, current, failed := r.configPath, r.configDigest, r.configFailed
+	r.configMu.Unlock()
+	if path == "" {
+		return
+	}
+	logger := log.FromContext(ctx).WithName("config-reload")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		logger.Error(err, "Failed to read autopilot config, keeping the current policy", "path", path)
+		return
+	}
+	digest := configDigest(data)
+	if digest == current || digest == failed {
+		return
+	}
+
+	mutators, err := buildConfigMutators(data)
+	if err != nil {
+		logger.Error(err, "Invalid autopilot config, keeping the current policy",
+			"path", path, "checksum", digest, "available", engine.RegisteredMutators())
+		r.configMu.Lock()
+		r.configFailed = digest
+		r.configMu.Unlock()
+		return
+	}
+
+	// Like a catalog swap, wait for a running reconcile so it applies one policy throughout
+	r.catalogMu.Lock()
+	r.SetMutators(mutators)
+	r.catalogMu.Unlock()
+	r.configMu.Lock()
+	r.configDigest = digest
+	r.configFailed = ""
+	r.configMu.Unlock()
+	logger.Info("Autopilot config reloaded", "path", path, "checksum", digest, "mutators", len(mutators))
+}
+
+// buildConfigMutators parses an AutopilotConfig and instantiates its mutators
+func buildConfigMutators(data []byte) ([]engine.Mutator, error) {
+	cfg, err := config.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return engine.BuildMutators(cfg)
+}
+
+// configDigest returns the checksum that tells whether the config file changed
+func configDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigReload(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, nil, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	costCenter := func() string {
+		t.Helper()
+		obj := &unstructured.Unstructured{Object: map[string]any{"kind": "ConfigMap", "metadata": map[string]any{"name": "x"}}}
+		for _, m := range reconciler.Mutators() {
+			if err := m.Mutate(context.Background(), nil, obj, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return obj.GetLabels()["cost-center"]
+	}
+
+	writeConfig(`
+mutators:
+- name: managed-metadata
+  config:
+    labels: {cost-center: "4711"}
+`)
+	if err := reconciler.LoadConfig(path); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := costCenter(); got != "4711" {
+		t.Fatalf("cost-center = %q after load, want 4711", got)
+	}
+
+	writeConfig(`
+mutators:
+- name: managed-metadata
+  config:
+    labels: {cost-center: "4712"}
+`)
+	reconciler.reloadConfig(context.Background())
+	if got := costCenter(); got != "4712" {
+		t.Errorf("cost-center = %q after an edit, want 4712", got)
+	}
+
+	// A broken edit keeps the policy in use
+	writeConfig(`
+mutators:
+- name: managed-metadata
+  config:
+    labels: {platform.kubevirt.io/managed-by: someone}
+`)
+	reconciler.reloadConfig(context.Background())
+	if got := costCenter(); got != "4712" {
+		t.Errorf("cost-center = %q after an invalid edit, want the previous 4712", got)
+	}
+
+	// Removing the policy removes the labels from the desired objects
+	writeConfig("mutators: []\n")
+	reconciler.reloadConfig(context.Background())
+	if got := costCenter(); got != "" {
+		t.Errorf("cost-center = %q after the policy was removed, want none", got)
+	}
+}
+
+func TestLoadConfigRejectsInvalidConfig(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, nil, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reconciler.LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("mutators:\n- name: does-not-exist\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reconciler.LoadConfig(path); err == nil {
+		t.Error("expected an error for an unknown mutator")
+	}
+	if err := reconciler.LoadConfig(""); err != nil || reconciler.Mutators() != nil {
+		t.Errorf("LoadConfig(\"\") = %v with %d mutators, want no error and none", err, len(reconciler.Mutators()))
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// AutopilotDeploymentCRDName is the CRD of the AutopilotDeployment objects
+const AutopilotDeploymentCRDName = "autopilotdeployments.platform.kubevirt.io"
+
+// AutopilotDeploymentGVK is the kind of the objects owning the managed resources of an HCO
+var AutopilotDeploymentGVK = schema.GroupVersionKind{Group: "platform.kubevirt.io", Version: "v1alpha1", Kind: "AutopilotDeployment"}
+
+// AutopilotDeploymentCRD returns the CustomResourceDefinition of AutopilotDeployment. The
+// CRD is optional: without it no owner references are set, whatever --owner-references says.
+func AutopilotDeploymentCRD() *apiextensionsv1.CustomResourceDefinition {
+	str := apiextensionsv1.JSONSchemaProps{Type: "string"}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: AutopilotDeploymentCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: AutopilotDeploymentGVK.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       AutopilotDeploymentGVK.Kind,
+				ListKind:   AutopilotDeploymentGVK.Kind + "List",
+				Plural:     "autopilotdeployments",
+				Singular:   "autopilotdeployment",
+				ShortNames: []string{"apdep"},
+				Categories: []string{"virt-platform-autopilot"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    AutopilotDeploymentGVK.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Description: "AutopilotDeployment is the owner of the objects virt-platform-autopilot applies in the " +
+						"namespace of a HyperConverged, which owns it in turn, so deleting the HyperConverged garbage-collects " +
+						"them. It is created by the autopilot and holds no configuration; deleting it deletes those objects.",
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"apiVersion": str,
+						"kind":       str,
+						"metadata":   {Type: "object"},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// SetOwnerReferences makes the managed objects in an HCO's namespace dependents of an
+// AutopilotDeployment owned by the HCO, when the AutopilotDeployment CRD is installed
+func (r *PlatformReconciler) SetOwnerReferences(enabled bool) {
+	r.ownerReferences = enabled
+}
+
+// ensureAutopilotDeployment returns the owner reference to the AutopilotDeployment of hco,
+// creating the object when missing. It is nil when owner references are disabled, the
+// CRD is not installed or hco has no UID to own the AutopilotDeployment by. The object
+// is named like the AutopilotStatus, so every shard owns its own objects.
+func (r *PlatformReconciler) ensureAutopilotDeployment(ctx context.Context, hco *unstructured.Unstructured) (*metav1.OwnerReference, error) {
+	if !r.ownerReferences || hco.GetUID() == "" {
+		return nil, nil
+	}
+	installed, err := r.crdChecker.IsCRDInstalled(ctx, AutopilotDeploymentCRDName)
+	if err != nil || !installed {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(AutopilotDeploymentGVK)
+	err = r.Get(ctx, client.ObjectKey{Namespace: hco.GetNamespace(), Name: r.autopilotStatusName(hco)}, obj)
+	if apierrors.IsNotFound(err) {
+		obj = &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(AutopilotDeploymentGVK)
+		obj.SetNamespace(hco.GetNamespace())
+		obj.SetName(r.autopilotStatusName(hco))
+		obj.SetLabels(map[string]string{engine.ManagedByLabel: engine.ManagedByValue})
+		obj.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: hco.GetAPIVersion(),
+			Kind:       hco.GetKind(),
+			Name:       hco.GetName(),
+			UID:        hco.GetUID(),
+			Controller: ptr.To(false),
+		}})
+		err = r.Create(ctx, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AutopilotDeployment: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get AutopilotDeployment: %w", err)
+	}
+
+	return &metav1.OwnerReference{
+		APIVersion: AutopilotDeploymentGVK.GroupVersion().String(),
+		Kind:       AutopilotDeploymentGVK.Kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+		Controller: ptr.To(false),
+	}, nil
+}
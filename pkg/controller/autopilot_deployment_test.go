@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// TestAutopilotDeploymentCRDManifest keeps config/crd in sync with AutopilotDeploymentCRD
+func TestAutopilotDeploymentCRDManifest(t *testing.T) {
+	data, err := os.ReadFile("../../config/crd/" + AutopilotDeploymentCRDName + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		t.Fatal(err)
+	}
+	if !equality.Semantic.DeepEqual(manifest, AutopilotDeploymentCRD()) {
+		t.Errorf("config/crd/%s.yaml is out of date with AutopilotDeploymentCRD()", AutopilotDeploymentCRDName)
+	}
+}
+
+func TestEnsureAutopilotDeployment(t *testing.T) {
+	ctx := context.Background()
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetUID("hco-uid")
+
+	newReconciler := func(t *testing.T, withCRD bool) (*PlatformReconciler, client.Client) {
+		t.Helper()
+		scheme := runtime.NewScheme()
+		_ = apiextensionsv1.AddToScheme(scheme)
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		if withCRD {
+			builder = builder.WithObjects(&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: AutopilotDeploymentCRDName}})
+		}
+		c := builder.Build()
+		reconciler, err := NewPlatformReconciler(c, c, "openshift-cnv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reconciler, c
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		reconciler, _ := newReconciler(t, true)
+		owner, err := reconciler.ensureAutopilotDeployment(ctx, hco)
+		if err != nil || owner != nil {
+			t.Errorf("ensureAutopilotDeployment() = %v, %v, want no owner", owner, err)
+		}
+	})
+
+	t.Run("without the CRD", func(t *testing.T) {
+		reconciler, _ := newReconciler(t, false)
+		reconciler.SetOwnerReferences(true)
+		owner, err := reconciler.ensureAutopilotDeployment(ctx, hco)
+		if err != nil || owner != nil {
+			t.Errorf("ensureAutopilotDeployment() = %v, %v, want the CRD to be optional", owner, err)
+		}
+	})
+
+	t.Run("created once and owned by the HCO", func(t *testing.T) {
+		reconciler, c := newReconciler(t, true)
+		reconciler.SetOwnerReferences(true)
+		owner, err := reconciler.ensureAutopilotDeployment(ctx, hco)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if owner == nil || owner.Kind != AutopilotDeploymentGVK.Kind || owner.Name != "kubevirt-hyperconverged" ||
+			owner.APIVersion != "platform.kubevirt.io/v1alpha1" || owner.Controller == nil || *owner.Controller {
+			t.Fatalf("owner reference = %+v", owner)
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(AutopilotDeploymentGVK)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}, obj); err != nil {
+			t.Fatal(err)
+		}
+		refs := obj.GetOwnerReferences()
+		if len(refs) != 1 || refs[0].UID != "hco-uid" {
+			t.Errorf("AutopilotDeployment owner references = %v, want the HCO", refs)
+		}
+		if !engine.HasManagedByLabel(obj) {
+			t.Error("AutopilotDeployment is missing the managed-by label")
+		}
+
+		again, err := reconciler.ensureAutopilotDeployment(ctx, hco)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.UID != obj.GetUID() {
+			t.Errorf("second pass returned owner UID %q, want the existing %q", again.UID, obj.GetUID())
+		}
+	})
+}
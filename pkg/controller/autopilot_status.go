@@ -0,0 +1,417 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// AutopilotStatusCRDName is the CRD of the AutopilotStatus objects
+const AutopilotStatusCRDName = "autopilotstatuses.platform.kubevirt.io"
+
+// ClusterOperator-style condition types of AutopilotStatus, with the meaning the
+// OpenShift ClusterOperator API gives them
+const (
+	// StatusAvailable is False when the assets are too broken for the platform configuration to be relied on
+	StatusAvailable = "Available"
+	// StatusProgressing is True while changes are being rolled out or held back
+	StatusProgressing = "Progressing"
+	// StatusDegraded is True when some assets failed to reconcile
+	StatusDegraded = "Degraded"
+)
+
+// Reasons of the AutopilotStatus conditions
+const (
+	StatusReasonAsExpected                 = "AsExpected"
+	StatusReasonAssetsFailed               = "AssetsFailed"
+	StatusReasonAssetErrorThresholdReached = "AssetErrorThresholdReached"
+	StatusReasonApplyingChanges            = "ApplyingChanges"
+	StatusReasonChangesPending             = "ChangesPending"
+	StatusReasonMachineConfigRollout       = "MachineConfigPoolsUpdating"
+)
+
+// maxStatusAssetsListed bounds the assets a condition message names
+const maxStatusAssetsListed = 5
+
+// AutopilotStatusGVK is the kind of the aggregate status objects
+var AutopilotStatusGVK = schema.GroupVersionKind{Group: "platform.kubevirt.io", Version: "v1alpha1", Kind: "AutopilotStatus"}
+
+// AutopilotStatusCRD returns the CustomResourceDefinition of AutopilotStatus. The CRD is
+// optional: without it the aggregate status is only exported as metrics. Its printer
+// columns mirror `oc get clusteroperator`.
+func AutopilotStatusCRD() *apiextensionsv1.CustomResourceDefinition {
+	str := apiextensionsv1.JSONSchemaProps{Type: "string"}
+	conditionColumn := func(condition string) apiextensionsv1.CustomResourceColumnDefinition {
+		return apiextensionsv1.CustomResourceColumnDefinition{
+			Name:     condition,
+			Type:     "string",
+			JSONPath: fmt.Sprintf(`.status.conditions[?(@.type=="%s")].status`, condition),
+		}
+	}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: AutopilotStatusCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: AutopilotStatusGVK.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       AutopilotStatusGVK.Kind,
+				ListKind:   AutopilotStatusGVK.Kind + "List",
+				Plural:     "autopilotstatuses",
+				Singular:   "autopilotstatus",
+				ShortNames: []string{"apst"},
+				Categories: []string{"virt-platform-autopilot"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    AutopilotStatusGVK.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Description: "AutopilotStatus aggregates the state of every asset virt-platform-autopilot reconciles " +
+						"for a HyperConverged into Available, Progressing and Degraded conditions with ClusterOperator " +
+						"semantics. It is written by the autopilot; changes made by users are overwritten.",
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"apiVersion": str,
+						"kind":       str,
+						"metadata":   {Type: "object"},
+						"status": {
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"catalogVersion": {Type: "string", Description: "Version of the asset catalog in use"},
+								"assets": {
+									Type:        "object",
+									Description: "Number of assets per state (see ManagedResource) in the last pass",
+									AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+										Allows: true,
+										Schema: &apiextensionsv1.JSONSchemaProps{Type: "integer"},
+									},
+								},
+								"skippedPhases": {
+									Type:        "array",
+									Description: "Phases disabled by the " + DisabledPhasesAnnotation + " annotation and the assets they skipped",
+									Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+										Type: "object",
+										Properties: map[string]apiextensionsv1.JSONSchemaProps{
+											"phase":  {Type: "integer"},
+											"assets": {Type: "integer"},
+										},
+									}},
+								},
+								"conditions": {
+									Type: "array",
+									Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+										Type: "object",
+										Properties: map[string]apiextensionsv1.JSONSchemaProps{
+											"type":               str,
+											"status":             str,
+											"reason":             str,
+											"message":            str,
+											"lastTransitionTime": {Type: "string", Format: "date-time"},
+											"observedGeneration": {Type: "integer", Format: "int64"},
+										},
+									}},
+								},
+							},
+						},
+					},
+				}},
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{
+					{Name: "Version", Type: "string", JSONPath: ".status.catalogVersion", Description: "Asset catalog version"},
+					conditionColumn(StatusAvailable),
+					conditionColumn(StatusProgressing),
+					conditionColumn(StatusDegraded),
+					{Name: "Since", Type: "date", JSONPath: `.status.conditions[?(@.type=="Available")].lastTransitionTime`},
+					{Name: "Message", Type: "string", JSONPath: `.status.conditions[?(@.type=="Degraded")].message`, Priority: 1},
+				},
+			}},
+		},
+	}
+}
+
+// statusAggregator collects the outcome of every asset of a pass, per HCO namespace, for
+// the AutopilotStatus conditions and the notification webhook. It implements
+// engine.InventorySink.
+type statusAggregator struct {
+	mu      sync.Mutex
+	pending map[string]map[string]engine.ObjectReport // HCO namespace -> asset -> last report
+}
+
+// ObjectReconciled implements engine.InventorySink
+func (a *statusAggregator) ObjectReconciled(hco *unstructured.Unstructured, report engine.ObjectReport) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reportsLocked(hco.GetNamespace())[report.Asset] = report
+}
+
+// AssetFailed implements engine.InventorySink
+func (a *statusAggregator) AssetFailed(hco *unstructured.Unstructured, asset string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	reports := a.reportsLocked(hco.GetNamespace())
+	report := reports[asset]
+	report.Asset = asset
+	report.State = engine.ObjectFailed
+	report.Reason = engine.AssetErrorReason(err)
+	report.Message = err.Error()
+	reports[asset] = report
+}
+
+func (a *statusAggregator) reportsLocked(namespace string) map[string]engine.ObjectReport {
+	if a.pending == nil {
+		a.pending = make(map[string]map[string]engine.ObjectReport)
+	}
+	reports, ok := a.pending[namespace]
+	if !ok {
+		reports = make(map[string]engine.ObjectReport)
+		a.pending[namespace] = reports
+	}
+	return reports
+}
+
+// take returns and forgets the reports of the pass in namespace
+func (a *statusAggregator) take(namespace string) map[string]engine.ObjectReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	reports := a.pending[namespace]
+	delete(a.pending, namespace)
+	return reports
+}
+
+// inventorySinks reports to every sink in order
+type inventorySinks []engine.InventorySink
+
+// ObjectReconciled implements engine.InventorySink
+func (s inventorySinks) ObjectReconciled(hco *unstructured.Unstructured, report engine.ObjectReport) {
+	for _, sink := range s {
+		sink.ObjectReconciled(hco, report)
+	}
+}
+
+// AssetFailed implements engine.InventorySink
+func (s inventorySinks) AssetFailed(hco *unstructured.Unstructured, asset string, err error) {
+	for _, sink := range s {
+		sink.AssetFailed(hco, asset, err)
+	}
+}
+
+// aggregateConditions maps the reports of a pass and the MachineConfigPools still rolling
+// out onto ClusterOperator conditions. Degraded is any failed asset; Available turns False
+// once the failed fraction reaches threshold, the point where AssetsReadyzCheck fails too;
+// Progressing covers changes applied in the pass, changes held back and pool rollouts.
+func aggregateConditions(reports map[string]engine.ObjectReport, updatingPools []string, threshold float64, generation int64) []metav1.Condition {
+	byState := make(map[engine.ObjectState][]string)
+	var failures []string
+	for _, asset := range slices.Sorted(maps.Keys(reports)) {
+		report := reports[asset]
+		byState[report.State] = append(byState[report.State], asset)
+		if report.State == engine.ObjectFailed {
+			failures = append(failures, fmt.Sprintf("%s (%s)", asset, report.Reason))
+		}
+	}
+	total := len(reports)
+	failed := len(failures)
+
+	condition := func(conditionType string, status bool, reason, message string) metav1.Condition {
+		c := metav1.Condition{Type: conditionType, Status: metav1.ConditionFalse, Reason: reason, Message: message, ObservedGeneration: generation}
+		if status {
+			c.Status = metav1.ConditionTrue
+		}
+		return c
+	}
+
+	available := condition(StatusAvailable, true, StatusReasonAsExpected, fmt.Sprintf("%d assets reconciled", total))
+	if total > 0 && float64(failed)/float64(total) >= threshold {
+		available = condition(StatusAvailable, false, StatusReasonAssetErrorThresholdReached,
+			fmt.Sprintf("%d of %d assets failed, at least %.0f%%: %s", failed, total, threshold*100, listAssets(failures)))
+	}
+
+	degraded := condition(StatusDegraded, false, StatusReasonAsExpected, "No asset failed")
+	if failed > 0 {
+		degraded = condition(StatusDegraded, true, StatusReasonAssetsFailed,
+			fmt.Sprintf("%d of %d assets failed: %s", failed, total, listAssets(failures)))
+	}
+
+	progressing := condition(StatusProgressing, false, StatusReasonAsExpected, "All changes are rolled out")
+	switch {
+	case len(updatingPools) > 0:
+		progressing = condition(StatusProgressing, true, StatusReasonMachineConfigRollout,
+			"MachineConfigPools updating: "+strings.Join(updatingPools, ", "))
+	case len(byState[engine.ObjectPending]) > 0:
+		progressing = condition(StatusProgressing, true, StatusReasonChangesPending,
+			"Changes held back for: "+listAssets(byState[engine.ObjectPending]))
+	case len(byState[engine.ObjectApplied]) > 0:
+		progressing = condition(StatusProgressing, true, StatusReasonApplyingChanges,
+			"Applied changes to: "+listAssets(byState[engine.ObjectApplied]))
+	}
+
+	return []metav1.Condition{available, progressing, degraded}
+}
+
+// listAssets joins names, naming at most maxStatusAssetsListed of them
+func listAssets(names []string) string {
+	if len(names) <= maxStatusAssetsListed {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxStatusAssetsListed], ", "), len(names)-maxStatusAssetsListed)
+}
+
+// autopilotStatusName is the name of the AutopilotStatus of hco: the HCO's name, with the
+// shard's appended so every shard reports on its own components
+func (r *PlatformReconciler) autopilotStatusName(hco *unstructured.Unstructured) string {
+	if r.shard.Name == "" {
+		return hco.GetName()
+	}
+	return hco.GetName() + "-" + r.shard.Name
+}
+
+// updateAutopilotStatus aggregates reports, those of the asset pass that just ended, into
+// the ClusterOperator conditions, exports them as metrics and, when the CRD is installed,
+// writes them to the AutopilotStatus next to the HCO, along with the number of assets
+// skipped per disabled phase. Transition times only move when a status changes.
+func (r *PlatformReconciler) updateAutopilotStatus(ctx context.Context, renderCtx *pkgcontext.RenderContext,
+	reports map[string]engine.ObjectReport, skippedPhases map[int]int) error {
+	hco := renderCtx.HCO
+	threshold := r.assetErrorThreshold
+	if threshold <= 0 {
+		threshold = DefaultAssetErrorThreshold
+	}
+	var updatingPools []string
+	if renderCtx.Upgrade != nil {
+		updatingPools = renderCtx.Upgrade.UpdatingPools
+	}
+	conditions := aggregateConditions(reports, updatingPools, threshold, hco.GetGeneration())
+	for _, c := range conditions {
+		observability.SetStatusCondition(hco.GetNamespace(), hco.GetName(), c.Type, c.Reason, c.Status == metav1.ConditionTrue)
+	}
+
+	installed, err := r.crdChecker.IsCRDInstalled(ctx, AutopilotStatusCRDName)
+	if err != nil || !installed {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(AutopilotStatusGVK)
+	err = r.Get(ctx, client.ObjectKey{Namespace: hco.GetNamespace(), Name: r.autopilotStatusName(hco)}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get AutopilotStatus: %w", err)
+	}
+	exists := err == nil
+
+	desired := r.autopilotStatus(hco, reports, skippedPhases, conditions, existing, exists)
+	if !exists {
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create AutopilotStatus: %w", err)
+		}
+		return nil
+	}
+	if equality.Semantic.DeepEqual(desired.Object, existing.Object) {
+		return nil
+	}
+	if err := r.Update(ctx, desired); err != nil {
+		return fmt.Errorf("failed to update AutopilotStatus: %w", err)
+	}
+	return nil
+}
+
+// autopilotStatus builds the AutopilotStatus of hco, starting from existing when it exists
+func (r *PlatformReconciler) autopilotStatus(hco *unstructured.Unstructured, reports map[string]engine.ObjectReport,
+	skippedPhases map[int]int, conditions []metav1.Condition, existing *unstructured.Unstructured, exists bool) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	var current []metav1.Condition
+	if exists {
+		obj = existing.DeepCopy()
+		if raw, found, _ := unstructured.NestedSlice(obj.Object, "status", "conditions"); found {
+			for _, item := range raw {
+				if m, ok := item.(map[string]any); ok {
+					c := metav1.Condition{}
+					if runtime.DefaultUnstructuredConverter.FromUnstructured(m, &c) == nil {
+						current = append(current, c)
+					}
+				}
+			}
+		}
+	} else {
+		obj.SetGroupVersionKind(AutopilotStatusGVK)
+		obj.SetNamespace(hco.GetNamespace())
+		obj.SetName(r.autopilotStatusName(hco))
+		if hco.GetUID() != "" {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{
+				APIVersion: hco.GetAPIVersion(),
+				Kind:       hco.GetKind(),
+				Name:       hco.GetName(),
+				UID:        hco.GetUID(),
+				Controller: ptr.To(false),
+			}})
+		}
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[engine.ManagedByLabel] = engine.ManagedByValue
+	obj.SetLabels(labels)
+
+	for _, c := range conditions {
+		meta.SetStatusCondition(&current, c)
+	}
+	statusConditions := make([]any, 0, len(current))
+	for _, c := range current {
+		m, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&c)
+		statusConditions = append(statusConditions, m)
+	}
+	assets := make(map[string]any)
+	for _, report := range reports {
+		count, _ := assets[string(report.State)].(int64)
+		assets[string(report.State)] = count + 1
+	}
+
+	status := map[string]any{
+		"catalogVersion": r.registry.CatalogVersion(),
+		"assets":         assets,
+		"conditions":     statusConditions,
+	}
+	if len(skippedPhases) > 0 {
+		status["skippedPhases"] = skippedPhaseList(skippedPhases)
+	}
+	obj.Object["status"] = status
+	return obj
+}
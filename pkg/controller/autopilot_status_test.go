@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// TestAutopilotStatusCRDManifest keeps config/crd in sync with AutopilotStatusCRD
+func TestAutopilotStatusCRDManifest(t *testing.T) {
+	data, err := os.ReadFile("../../config/crd/" + AutopilotStatusCRDName + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		t.Fatal(err)
+	}
+	if !equality.Semantic.DeepEqual(manifest, AutopilotStatusCRD()) {
+		t.Errorf("config/crd/%s.yaml is out of date with AutopilotStatusCRD()", AutopilotStatusCRDName)
+	}
+}
+
+func TestAggregateConditions(t *testing.T) {
+	reports := func(states ...engine.ObjectState) map[string]engine.ObjectReport {
+		out := make(map[string]engine.ObjectReport, len(states))
+		for i, state := range states {
+			asset := string(rune('a' + i))
+			out[asset] = engine.ObjectReport{Asset: asset, State: state, Reason: engine.ReasonRenderFailed}
+		}
+		return out
+	}
+	type want struct {
+		status  metav1.ConditionStatus
+		reason  string
+		message string
+	}
+
+	tests := []struct {
+		name          string
+		reports       map[string]engine.ObjectReport
+		updatingPools []string
+		want          map[string]want
+	}{
+		{
+			name:    "everything in sync",
+			reports: reports(engine.ObjectInSync, engine.ObjectInSync, engine.ObjectUnmanaged),
+			want: map[string]want{
+				StatusAvailable:   {metav1.ConditionTrue, StatusReasonAsExpected, "3 assets reconciled"},
+				StatusProgressing: {metav1.ConditionFalse, StatusReasonAsExpected, ""},
+				StatusDegraded:    {metav1.ConditionFalse, StatusReasonAsExpected, ""},
+			},
+		},
+		{
+			name:    "a failed asset degrades without making the platform unavailable",
+			reports: reports(engine.ObjectInSync, engine.ObjectInSync, engine.ObjectFailed),
+			want: map[string]want{
+				StatusAvailable:   {metav1.ConditionTrue, StatusReasonAsExpected, ""},
+				StatusProgressing: {metav1.ConditionFalse, StatusReasonAsExpected, ""},
+				StatusDegraded:    {metav1.ConditionTrue, StatusReasonAssetsFailed, "1 of 3 assets failed: c (RenderFailed)"},
+			},
+		},
+		{
+			name:    "the error threshold makes the platform unavailable",
+			reports: reports(engine.ObjectInSync, engine.ObjectFailed),
+			want: map[string]want{
+				StatusAvailable: {metav1.ConditionFalse, StatusReasonAssetErrorThresholdReached, "1 of 2 assets failed, at least 50%"},
+				StatusDegraded:  {metav1.ConditionTrue, StatusReasonAssetsFailed, ""},
+			},
+		},
+		{
+			name:    "applied changes are progressing",
+			reports: reports(engine.ObjectApplied, engine.ObjectInSync),
+			want: map[string]want{
+				StatusProgressing: {metav1.ConditionTrue, StatusReasonApplyingChanges, "Applied changes to: a"},
+			},
+		},
+		{
+			name:    "held back changes outrank applied ones",
+			reports: reports(engine.ObjectApplied, engine.ObjectPending),
+			want: map[string]want{
+				StatusProgressing: {metav1.ConditionTrue, StatusReasonChangesPending, "Changes held back for: b"},
+			},
+		},
+		{
+			name:          "a MachineConfigPool rollout is progressing",
+			reports:       reports(engine.ObjectPending),
+			updatingPools: []string{"worker"},
+			want: map[string]want{
+				StatusProgressing: {metav1.ConditionTrue, StatusReasonMachineConfigRollout, "MachineConfigPools updating: worker"},
+			},
+		},
+		{
+			name: "long lists are cut",
+			reports: reports(engine.ObjectFailed, engine.ObjectFailed, engine.ObjectFailed, engine.ObjectFailed,
+				engine.ObjectFailed, engine.ObjectFailed, engine.ObjectFailed),
+			want: map[string]want{
+				StatusDegraded: {metav1.ConditionTrue, StatusReasonAssetsFailed, "e (RenderFailed) and 2 more"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := aggregateConditions(tt.reports, tt.updatingPools, DefaultAssetErrorThreshold, 7)
+			if len(conditions) != 3 {
+				t.Fatalf("got %d conditions, want Available, Progressing and Degraded", len(conditions))
+			}
+			for conditionType, w := range tt.want {
+				c := meta.FindStatusCondition(conditions, conditionType)
+				if c == nil {
+					t.Fatalf("condition %s missing", conditionType)
+				}
+				if c.Status != w.status || c.Reason != w.reason || !strings.Contains(c.Message, w.message) {
+					t.Errorf("%s = %s/%s %q, want %s/%s containing %q", conditionType, c.Status, c.Reason, c.Message, w.status, w.reason, w.message)
+				}
+				if c.ObservedGeneration != 7 {
+					t.Errorf("%s observedGeneration = %d, want 7", conditionType, c.ObservedGeneration)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateAutopilotStatus(t *testing.T) {
+	ctx := context.Background()
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetUID("hco-uid")
+
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: AutopilotStatusCRDName}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+	reconciler, err := NewPlatformReconciler(c, c, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderCtx := &pkgcontext.RenderContext{HCO: hco}
+
+	get := func() *unstructured.Unstructured {
+		t.Helper()
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(AutopilotStatusGVK)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}, obj); err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+	condition := func(obj *unstructured.Unstructured, conditionType string) map[string]any {
+		t.Helper()
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, item := range conditions {
+			if m := item.(map[string]any); m["type"] == conditionType {
+				return m
+			}
+		}
+		t.Fatalf("condition %s missing in %v", conditionType, conditions)
+		return nil
+	}
+	report := func(asset string, state engine.ObjectState) {
+		reconciler.assetStatus.ObjectReconciled(hco, engine.ObjectReport{Asset: asset, State: state})
+	}
+
+	// First pass: one asset fails
+	report("swap-enable", engine.ObjectInSync)
+	report("descheduler", engine.ObjectApplied)
+	reconciler.assetStatus.AssetFailed(hco, "descheduler", errors.New("admission webhook denied the request"))
+	report("hugepages", engine.ObjectInSync)
+	if err := reconciler.updateAutopilotStatus(ctx, renderCtx, reconciler.assetStatus.take("openshift-cnv"), nil); err != nil {
+		t.Fatalf("updateAutopilotStatus() error = %v", err)
+	}
+	obj := get()
+	if owners := obj.GetOwnerReferences(); len(owners) != 1 || owners[0].UID != "hco-uid" {
+		t.Errorf("ownerReferences = %v, want the HCO", owners)
+	}
+	if degraded := condition(obj, StatusDegraded); degraded["status"] != "True" {
+		t.Errorf("Degraded = %v, want True", degraded)
+	}
+	assets, _, _ := unstructured.NestedMap(obj.Object, "status", "assets")
+	if assets["InSync"] != int64(2) || assets["Failed"] != int64(1) {
+		t.Errorf("status.assets = %v, want 2 InSync and 1 Failed", assets)
+	}
+	if got := testutil.ToFloat64(observability.StatusConditions.WithLabelValues(
+		"openshift-cnv", "kubevirt-hyperconverged", StatusDegraded, StatusReasonAssetsFailed)); got != 1 {
+		t.Errorf("status_conditions{Degraded,AssetsFailed} = %v, want 1", got)
+	}
+	available := condition(obj, StatusAvailable)
+
+	// Second pass: the failure is fixed and phase 2 is disabled. Degraded transitions,
+	// Available keeps its time.
+	report("swap-enable", engine.ObjectInSync)
+	report("descheduler", engine.ObjectApplied)
+	report("hugepages", engine.ObjectInSync)
+	if err := reconciler.updateAutopilotStatus(ctx, renderCtx, reconciler.assetStatus.take("openshift-cnv"), map[int]int{2: 7}); err != nil {
+		t.Fatalf("second updateAutopilotStatus() error = %v", err)
+	}
+	obj = get()
+	skipped, _, _ := unstructured.NestedSlice(obj.Object, "status", "skippedPhases")
+	if want := []any{map[string]any{"phase": int64(2), "assets": int64(7)}}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("status.skippedPhases = %v, want %v", skipped, want)
+	}
+	if degraded := condition(obj, StatusDegraded); degraded["status"] != "False" {
+		t.Errorf("Degraded = %v, want False", degraded)
+	}
+	if progressing := condition(obj, StatusProgressing); progressing["reason"] != StatusReasonApplyingChanges {
+		t.Errorf("Progressing = %v, want ApplyingChanges", progressing)
+	}
+	if got := condition(obj, StatusAvailable)["lastTransitionTime"]; got != available["lastTransitionTime"] {
+		t.Errorf("Available lastTransitionTime moved without a transition: %v -> %v", available["lastTransitionTime"], got)
+	}
+	if got := testutil.CollectAndCount(observability.StatusConditions); got != 3 {
+		t.Errorf("status_conditions has %d series, want one per condition", got)
+	}
+
+	// An unchanged outcome is not written again
+	before := obj.GetResourceVersion()
+	report("swap-enable", engine.ObjectInSync)
+	report("descheduler", engine.ObjectApplied)
+	report("hugepages", engine.ObjectInSync)
+	if err := reconciler.updateAutopilotStatus(ctx, renderCtx, reconciler.assetStatus.take("openshift-cnv"), map[int]int{2: 7}); err != nil {
+		t.Fatalf("third updateAutopilotStatus() error = %v", err)
+	}
+	if after := get().GetResourceVersion(); after != before {
+		t.Errorf("status rewritten without a change: resourceVersion %s -> %s", before, after)
+	}
+
+	// Re-enabling the phase drops it from the status
+	report("swap-enable", engine.ObjectInSync)
+	if err := reconciler.updateAutopilotStatus(ctx, renderCtx, reconciler.assetStatus.take("openshift-cnv"), nil); err != nil {
+		t.Fatalf("fourth updateAutopilotStatus() error = %v", err)
+	}
+	if _, found, _ := unstructured.NestedSlice(get().Object, "status", "skippedPhases"); found {
+		t.Error("status.skippedPhases kept after the phase was re-enabled")
+	}
+
+	observability.DeleteStatusConditions("openshift-cnv", "kubevirt-hyperconverged")
+}
+
+func TestUpdateAutopilotStatusWithoutCRD(t *testing.T) {
+	ctx := context.Background()
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, err := NewPlatformReconciler(c, c, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reconciler.assetStatus.ObjectReconciled(hco, engine.ObjectReport{Asset: "swap-enable", State: engine.ObjectInSync})
+	if err := reconciler.updateAutopilotStatus(ctx, &pkgcontext.RenderContext{HCO: hco}, reconciler.assetStatus.take("openshift-cnv"), nil); err != nil {
+		t.Fatalf("updateAutopilotStatus() error = %v, want the CRD to be optional", err)
+	}
+	if got := testutil.ToFloat64(observability.StatusConditions.WithLabelValues(
+		"openshift-cnv", "kubevirt-hyperconverged", StatusAvailable, StatusReasonAsExpected)); got != 1 {
+		t.Errorf("status_conditions{Available,AsExpected} = %v, want 1 without the CRD", got)
+	}
+	observability.DeleteStatusConditions("openshift-cnv", "kubevirt-hyperconverged")
+}
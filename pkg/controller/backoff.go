@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+const (
+	// ConditionReconcileFailing is the HCO status condition reporting repeated reconcile failures
+	ConditionReconcileFailing = "PlatformAutopilotReconcileFailing"
+
+	// failureConditionThreshold is the number of consecutive failures after which
+	// ConditionReconcileFailing is set to True
+	failureConditionThreshold = 3
+)
+
+// RateLimiterOptions tunes how failed reconciles are retried. Failures of one HCO back
+// off exponentially from BaseDelay to MaxDelay; QPS and Burst size the token bucket
+// shared by all retries. Jitter spreads each delay by up to that fraction.
+type RateLimiterOptions struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	QPS       float64
+	Burst     int
+	Jitter    float64
+}
+
+// DefaultRateLimiterOptions matches controller-runtime's default controller rate limiter
+func DefaultRateLimiterOptions() RateLimiterOptions {
+	return RateLimiterOptions{
+		BaseDelay: 5 * time.Millisecond,
+		MaxDelay:  1000 * time.Second,
+		QPS:       10,
+		Burst:     100,
+	}
+}
+
+// Validate rejects settings the work queue cannot honour
+func (o RateLimiterOptions) Validate() error {
+	switch {
+	case o.BaseDelay <= 0:
+		return fmt.Errorf("rate limiter base delay must be positive, got %s", o.BaseDelay)
+	case o.MaxDelay < o.BaseDelay:
+		return fmt.Errorf("rate limiter max delay %s is below the base delay %s", o.MaxDelay, o.BaseDelay)
+	case o.QPS <= 0:
+		return fmt.Errorf("rate limiter QPS must be positive, got %v", o.QPS)
+	case o.Burst <= 0:
+		return fmt.Errorf("rate limiter burst must be positive, got %d", o.Burst)
+	case o.Jitter < 0 || o.Jitter > 1:
+		return fmt.Errorf("rate limiter jitter must be between 0 and 1, got %v", o.Jitter)
+	}
+	return nil
+}
+
+// newRateLimiter builds the work queue rate limiter for opts (zero opts = defaults)
+func newRateLimiter(opts RateLimiterOptions) workqueue.TypedRateLimiter[reconcile.Request] {
+	if opts == (RateLimiterOptions{}) {
+		opts = DefaultRateLimiterOptions()
+	}
+	var limiter workqueue.TypedRateLimiter[reconcile.Request] = workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](opts.BaseDelay, opts.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(opts.QPS), opts.Burst)},
+	)
+	if opts.Jitter > 0 {
+		limiter = &jitterRateLimiter{TypedRateLimiter: limiter, jitter: opts.Jitter}
+	}
+	return limiter
+}
+
+// jitterRateLimiter stretches every delay of the wrapped limiter by a random fraction
+// up to jitter, so HCOs that failed together are not all retried at the same instant.
+type jitterRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+	jitter float64
+}
+
+func (j *jitterRateLimiter) When(item reconcile.Request) time.Duration {
+	delay := j.TypedRateLimiter.When(item)
+	return delay + time.Duration(rand.Float64()*j.jitter*float64(delay))
+}
+
+// failureTracker counts consecutive reconcile failures per HCO
+type failureTracker struct {
+	mu       sync.Mutex
+	failures map[types.NamespacedName]int
+}
+
+// record updates the count for key after a reconcile and returns the new count
+func (f *failureTracker) record(key types.NamespacedName, err error) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.failures, key)
+		return 0
+	}
+	if f.failures == nil {
+		f.failures = make(map[types.NamespacedName]int)
+	}
+	f.failures[key]++
+	return f.failures[key]
+}
+
+// recordReconcileResult updates the failure count of the HCO and its
+// ConditionReconcileFailing condition. The condition is only written when it changes
+// (on reaching the threshold and on the first success after it), so the status write
+// does not cut the retry backoff short by triggering a new HCO event every failure.
+func (r *PlatformReconciler) recordReconcileResult(ctx context.Context, key types.NamespacedName, reconcileErr error) {
+	count := r.failures.record(key, reconcileErr)
+	observability.SetReconcileFailures(key.Namespace, key.Name, count)
+
+	if count > 0 && count < failureConditionThreshold {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    ConditionReconcileFailing,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ReconcileSucceeded",
+		Message: "The last platform reconcile succeeded",
+	}
+	if count > 0 {
+		condition.Status = metav1.ConditionTrue
+		// Classified failures carry their reason, so alerts can tell e.g. conflicts from render bugs
+		condition.Reason = "ConsecutiveFailures"
+		if reason := engine.ReasonOf(reconcileErr); reason != "" {
+			condition.Reason = string(reason)
+		}
+		condition.Message = fmt.Sprintf("Platform reconcile failed %d consecutive times: %v", count, reconcileErr)
+	}
+
+	if err := r.setHCOCondition(ctx, key, condition, false); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to update HCO reconcile condition", "error", err.Error())
+	}
+}
+
+// setHCOCondition sets condition on the HCO status if its status or reason differ, or
+// its message when compareMessage is set (for messages that only change with the spec).
+// A False condition is only written to replace a True one. Conditions owned by the
+// HCO operator are left untouched. The condition's observedGeneration defaults to the
+// live HCO generation, and its type is suffixed with the shard name when sharded.
+func (r *PlatformReconciler) setHCOCondition(ctx context.Context, key types.NamespacedName, condition metav1.Condition, compareMessage bool) error {
+	condition.Type = r.shard.ConditionType(condition.Type)
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := r.Get(ctx, key, hco); err != nil {
+		return err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(hco.Object, "status", "conditions")
+	index := -1
+	for i, raw := range conditions {
+		if c, ok := raw.(map[string]any); ok && c["type"] == condition.Type {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 && condition.Status == metav1.ConditionFalse {
+		return nil
+	}
+	if index >= 0 {
+		existing := conditions[index].(map[string]any)
+		if existing["status"] == string(condition.Status) && existing["reason"] == condition.Reason &&
+			(!compareMessage || existing["message"] == condition.Message) {
+			return nil
+		}
+	}
+
+	if condition.ObservedGeneration == 0 {
+		condition.ObservedGeneration = hco.GetGeneration()
+	}
+	condition.LastTransitionTime = metav1.Now()
+	updated, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&condition)
+	if err != nil {
+		return err
+	}
+	if index >= 0 {
+		conditions[index] = updated
+	} else {
+		conditions = append(conditions, updated)
+	}
+
+	if err := unstructured.SetNestedSlice(hco.Object, conditions, "status", "conditions"); err != nil {
+		return err
+	}
+	return r.Status().Update(ctx, hco)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func TestRateLimiterOptionsValidate(t *testing.T) {
+	valid := DefaultRateLimiterOptions()
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("default options invalid: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*RateLimiterOptions)
+	}{
+		{"zero base delay", func(o *RateLimiterOptions) { o.BaseDelay = 0 }},
+		{"max below base", func(o *RateLimiterOptions) { o.MaxDelay = time.Millisecond }},
+		{"zero QPS", func(o *RateLimiterOptions) { o.QPS = 0 }},
+		{"zero burst", func(o *RateLimiterOptions) { o.Burst = 0 }},
+		{"jitter above 1", func(o *RateLimiterOptions) { o.Jitter = 1.5 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultRateLimiterOptions()
+			tt.modify(&opts)
+			if err := opts.Validate(); err == nil {
+				t.Error("Validate() = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewRateLimiterBackoff(t *testing.T) {
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "hco"}}
+	limiter := newRateLimiter(RateLimiterOptions{BaseDelay: time.Second, MaxDelay: 4 * time.Second, QPS: 100, Burst: 100})
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		if got := limiter.When(item); got != want {
+			t.Errorf("When() = %s, want %s", got, want)
+		}
+	}
+	limiter.Forget(item)
+	if got := limiter.When(item); got != time.Second {
+		t.Errorf("When() after Forget = %s, want %s", got, time.Second)
+	}
+}
+
+func TestNewRateLimiterJitter(t *testing.T) {
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "hco"}}
+	limiter := newRateLimiter(RateLimiterOptions{BaseDelay: time.Second, MaxDelay: time.Second, QPS: 100, Burst: 100, Jitter: 0.5})
+
+	for i := 0; i < 20; i++ {
+		if got := limiter.When(item); got < time.Second || got > 1500*time.Millisecond {
+			t.Fatalf("When() = %s, want within [1s, 1.5s]", got)
+		}
+	}
+}
+
+func TestRecordReconcileResult(t *testing.T) {
+	observability.ReconcileConsecutiveFailures.Reset()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).WithStatusSubresource(hco).Build()
+	r := &PlatformReconciler{Client: fakeClient}
+	key := types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}
+	ctx := context.Background()
+
+	condition := func() map[string]any {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := fakeClient.Get(ctx, key, live); err != nil {
+			t.Fatalf("failed to get HCO: %v", err)
+		}
+		conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+		for _, c := range conditions {
+			if m := c.(map[string]any); m["type"] == ConditionReconcileFailing {
+				return m
+			}
+		}
+		return nil
+	}
+
+	// Success without a prior failure writes nothing
+	r.recordReconcileResult(ctx, key, nil)
+	if c := condition(); c != nil {
+		t.Fatalf("condition set after success: %v", c)
+	}
+
+	// Below the threshold only the metric moves
+	failure := errors.New("apply failed")
+	for i := 1; i < failureConditionThreshold; i++ {
+		r.recordReconcileResult(ctx, key, failure)
+	}
+	if c := condition(); c != nil {
+		t.Fatalf("condition set below threshold: %v", c)
+	}
+	gauge := observability.ReconcileConsecutiveFailures.WithLabelValues(key.Namespace, key.Name)
+	if val := testutil.ToFloat64(gauge); val != failureConditionThreshold-1 {
+		t.Errorf("reconcile_consecutive_failures = %v, want %d", val, failureConditionThreshold-1)
+	}
+
+	r.recordReconcileResult(ctx, key, failure)
+	if c := condition(); c == nil || c["status"] != "True" || c["reason"] != "ConsecutiveFailures" {
+		t.Fatalf("condition after threshold = %v, want True/ConsecutiveFailures", c)
+	}
+
+	// A classified failure names its reason
+	conflict := &engine.AssetErrors{Total: 1, Failures: []engine.AssetFailure{
+		{Asset: "psi-enable", Reason: engine.ReasonApplyConflict, Err: errors.New("conflict")},
+	}}
+	r.recordReconcileResult(ctx, key, conflict)
+	if c := condition(); c == nil || c["reason"] != string(engine.ReasonApplyConflict) {
+		t.Fatalf("condition after a conflict = %v, want reason %s", c, engine.ReasonApplyConflict)
+	}
+
+	r.recordReconcileResult(ctx, key, nil)
+	if c := condition(); c == nil || c["status"] != "False" {
+		t.Fatalf("condition after recovery = %v, want False", c)
+	}
+	if count := testutil.CollectAndCount(observability.ReconcileConsecutiveFailures); count != 0 {
+		t.Errorf("reconcile_consecutive_failures series = %d, want 0", count)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func TestCacheStatsCollector(t *testing.T) {
+	observability.CacheObjects.Reset()
+	observability.CacheEstimatedBytes.Reset()
+
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	var objects []client.Object
+	objects = append(objects, hco)
+	for _, name := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		objects = append(objects, &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	// Watched but nothing cached yet: reported as zero rather than missing
+	machineConfigGVK := schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfig"}
+
+	collector := newCacheStatsCollector(fakeClient, []cachedType{
+		unstructuredCachedType(pkgcontext.HCOGVK),
+		{gvk: crdGVK, list: &apiextensionsv1.CustomResourceDefinitionList{}},
+		unstructuredCachedType(machineConfigGVK),
+	}, time.Minute)
+	collector.collect(context.Background())
+
+	tests := []struct {
+		gvk           schema.GroupVersionKind
+		expectedCount float64
+	}{
+		{pkgcontext.HCOGVK, 1},
+		{crdGVK, 3},
+		{machineConfigGVK, 0},
+	}
+	for _, tt := range tests {
+		count := testutil.ToFloat64(observability.CacheObjects.WithLabelValues(tt.gvk.Group, tt.gvk.Version, tt.gvk.Kind))
+		if count != tt.expectedCount {
+			t.Errorf("cache_objects{kind=%q} = %v, want %v", tt.gvk.Kind, count, tt.expectedCount)
+		}
+	}
+
+	hcoJSON, err := json.Marshal(hco)
+	if err != nil {
+		t.Fatalf("failed to marshal HCO: %v", err)
+	}
+	hcoBytes := testutil.ToFloat64(observability.CacheEstimatedBytes.WithLabelValues(pkgcontext.HCOGroup, pkgcontext.HCOVersion, pkgcontext.HCOKind))
+	// The fake client adds a resourceVersion, so the cached copy is slightly larger
+	if hcoBytes < float64(len(hcoJSON)) {
+		t.Errorf("cache_estimated_bytes for HCO = %v, want at least %d", hcoBytes, len(hcoJSON))
+	}
+	mcBytes := testutil.ToFloat64(observability.CacheEstimatedBytes.WithLabelValues(machineConfigGVK.Group, machineConfigGVK.Version, machineConfigGVK.Kind))
+	if mcBytes != 0 {
+		t.Errorf("cache_estimated_bytes for an empty type = %v, want 0", mcBytes)
+	}
+}
+
+func TestCacheStatsCollectorStopsOnCancel(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	collector := newCacheStatsCollector(fakeClient, nil, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- collector.Start(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start() = %v, want nil on cancellation", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() did not return after the context was cancelled")
+	}
+	if collector.NeedLeaderElection() {
+		t.Error("NeedLeaderElection() = true, want every replica to report its cache")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+const (
+	// DefaultCacheSyncTimeout bounds how long the informers of the watched types may take
+	// to sync at startup before the manager gives up
+	DefaultCacheSyncTimeout = 2 * time.Minute
+
+	// cacheWarmupRecheck is how soon a reconcile arriving before the caches synced is retried
+	cacheWarmupRecheck = 2 * time.Second
+)
+
+// informerGetter is the part of cache.Cache the warmup needs
+type informerGetter interface {
+	GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error)
+}
+
+// cacheWarmup waits for the informers of every watched type, including the managed
+// types discovered at setup, to complete their initial list, and records how long each
+// took. Reconciles are held back until it is done: acting on a partially filled cache
+// right after a restart would see managed objects as missing and recreate them, or
+// detect drift against an incomplete view.
+type cacheWarmup struct {
+	informers informerGetter
+	scheme    *runtime.Scheme
+	types     []cachedType
+	timeout   time.Duration
+
+	synced chan struct{}
+	once   sync.Once
+}
+
+func newCacheWarmup(informers informerGetter, scheme *runtime.Scheme, types []cachedType, timeout time.Duration) *cacheWarmup {
+	return &cacheWarmup{
+		informers: informers,
+		scheme:    scheme,
+		types:     types,
+		timeout:   timeout,
+		synced:    make(chan struct{}),
+	}
+}
+
+// Start implements manager.Runnable. It returns an error, stopping the manager, when a
+// type does not sync within the timeout: a pod that never sees its full cache should
+// restart rather than sit idle.
+func (w *cacheWarmup) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("cache-warmup")
+	observability.SetCacheSynced(false)
+	start := time.Now()
+
+	waitCtx := ctx
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		unsynced []string
+	)
+	for _, t := range w.types {
+		wg.Add(1)
+		go func(t cachedType) {
+			defer wg.Done()
+			if err := w.waitForType(waitCtx, t); err != nil {
+				logger.Error(err, "Informer did not sync", "gvk", t.gvk.String())
+				mu.Lock()
+				unsynced = append(unsynced, t.gvk.String())
+				mu.Unlock()
+				return
+			}
+			elapsed := time.Since(start)
+			observability.SetInformerSyncDuration(t.gvk.Group, t.gvk.Version, t.gvk.Kind, elapsed)
+			logger.V(1).Info("Informer synced", "gvk", t.gvk.String(), "duration", elapsed)
+		}(t)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if len(unsynced) > 0 {
+		sort.Strings(unsynced)
+		return fmt.Errorf("informers not synced within %s: %s", w.timeout, strings.Join(unsynced, ", "))
+	}
+
+	logger.Info("Informer caches synced, reconciles may proceed", "types", len(w.types), "duration", time.Since(start))
+	observability.SetCacheSynced(true)
+	w.once.Do(func() { close(w.synced) })
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Every replica has its own cache to wait for.
+func (w *cacheWarmup) NeedLeaderElection() bool {
+	return false
+}
+
+// Synced reports whether every watched type has synced
+func (w *cacheWarmup) Synced() bool {
+	select {
+	case <-w.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitForType gets the informer of t without blocking on it, then waits for its sync
+// so the wait honors ctx
+func (w *cacheWarmup) waitForType(ctx context.Context, t cachedType) error {
+	obj, err := t.object(w.scheme)
+	if err != nil {
+		return err
+	}
+	informer, err := w.informers.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("failed to get informer: %w", err)
+	}
+	if !toolscache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("timed out waiting for the initial list: %w", ctx.Err())
+	}
+	return nil
+}
+
+// object returns an empty object of the type, typed or unstructured like its list,
+// so the informer looked up is the one the watch uses
+func (t cachedType) object(scheme *runtime.Scheme) (client.Object, error) {
+	if _, ok := t.list.(*unstructured.UnstructuredList); ok {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(t.gvk)
+		return obj, nil
+	}
+	typed, err := scheme.New(t.gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", t.gvk, err)
+	}
+	obj, ok := typed.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a client.Object", t.gvk)
+	}
+	return obj, nil
+}
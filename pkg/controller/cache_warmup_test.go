@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// fakeInformer only answers HasSynced; the warmup calls nothing else
+type fakeInformer struct {
+	cache.Informer
+	synced atomic.Bool
+}
+
+func (f *fakeInformer) HasSynced() bool {
+	return f.synced.Load()
+}
+
+// fakeInformers hands out one fakeInformer per GVK, recording the object types asked for
+type fakeInformers struct {
+	informers map[schema.GroupVersionKind]*fakeInformer
+	requested chan string
+}
+
+func (f *fakeInformers) GetInformer(_ context.Context, obj client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	kind := "typed"
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		kind = "unstructured"
+	}
+	if _, ok := obj.(*apiextensionsv1.CustomResourceDefinition); ok {
+		// scheme.New leaves TypeMeta empty
+		gvk = crdGVK
+	}
+	f.requested <- kind + " " + gvk.Kind
+	return f.informers[gvk], nil
+}
+
+func newTestCacheWarmup(t *testing.T, timeout time.Duration) (*cacheWarmup, *fakeInformers) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	machineConfigGVK := schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfig"}
+	informers := &fakeInformers{
+		informers: map[schema.GroupVersionKind]*fakeInformer{
+			pkgcontext.HCOGVK: {},
+			crdGVK:            {},
+			machineConfigGVK:  {},
+		},
+		requested: make(chan string, 3),
+	}
+	warmup := newCacheWarmup(informers, scheme, []cachedType{
+		unstructuredCachedType(pkgcontext.HCOGVK),
+		{gvk: crdGVK, list: &apiextensionsv1.CustomResourceDefinitionList{}},
+		unstructuredCachedType(machineConfigGVK),
+	}, timeout)
+	return warmup, informers
+}
+
+func TestCacheWarmupWaitsForEveryType(t *testing.T) {
+	observability.InformerSyncDuration.Reset()
+	warmup, informers := newTestCacheWarmup(t, time.Minute)
+
+	done := make(chan error, 1)
+	go func() { done <- warmup.Start(context.Background()) }()
+
+	requested := map[string]bool{}
+	for range 3 {
+		requested[<-informers.requested] = true
+	}
+	for _, want := range []string{"unstructured HyperConverged", "typed CustomResourceDefinition", "unstructured MachineConfig"} {
+		if !requested[want] {
+			t.Errorf("informer for %q not requested, got %v", want, requested)
+		}
+	}
+
+	// One type syncing is not enough
+	informers.informers[pkgcontext.HCOGVK].synced.Store(true)
+	informers.informers[crdGVK].synced.Store(true)
+	time.Sleep(300 * time.Millisecond)
+	if warmup.Synced() {
+		t.Fatal("warmup reported synced while MachineConfig has not synced")
+	}
+	if got := testutil.ToFloat64(observability.CacheSynced); got != 0 {
+		t.Errorf("cache_synced = %v before all types synced, want 0", got)
+	}
+
+	for _, informer := range informers.informers {
+		informer.synced.Store(true)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("warmup did not finish after all types synced")
+	}
+
+	if !warmup.Synced() {
+		t.Error("warmup not synced after Start returned")
+	}
+	if got := testutil.ToFloat64(observability.CacheSynced); got != 1 {
+		t.Errorf("cache_synced = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(observability.InformerSyncDuration); got != 3 {
+		t.Errorf("informer_sync_duration_seconds has %d series, want 3", got)
+	}
+}
+
+func TestCacheWarmupTimeout(t *testing.T) {
+	warmup, informers := newTestCacheWarmup(t, 200*time.Millisecond)
+	informers.informers[pkgcontext.HCOGVK].synced.Store(true)
+	informers.informers[crdGVK].synced.Store(true)
+
+	err := warmup.Start(context.Background())
+	if err == nil {
+		t.Fatal("expected an error when a type does not sync in time")
+	}
+	if !strings.Contains(err.Error(), "MachineConfig") || strings.Contains(err.Error(), "HyperConverged") {
+		t.Errorf("error should name only the unsynced type, got %v", err)
+	}
+	if warmup.Synced() {
+		t.Error("warmup reported synced after a timeout")
+	}
+}
+
+func TestCacheWarmupStopped(t *testing.T) {
+	warmup, _ := newTestCacheWarmup(t, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Shutdown during warmup is not a sync failure
+	if err := warmup.Start(ctx); err != nil {
+		t.Errorf("Start() with a cancelled context error = %v, want nil", err)
+	}
+	if warmup.Synced() {
+		t.Error("warmup reported synced after being stopped")
+	}
+}
+
+func TestReconcileWaitsForCacheWarmup(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, nil, "openshift-cnv")
+	if err != nil {
+		t.Fatalf("failed to create reconciler: %v", err)
+	}
+	warmup, _ := newTestCacheWarmup(t, time.Minute)
+	reconciler.cacheWarmup = warmup
+
+	triggers := testutil.ToFloat64(observability.ReconcileTriggersTotal.WithLabelValues(observability.TriggerCacheWarmup))
+	result, err := reconciler.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != cacheWarmupRecheck {
+		t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, cacheWarmupRecheck)
+	}
+	if got := testutil.ToFloat64(observability.ReconcileTriggersTotal.WithLabelValues(observability.TriggerCacheWarmup)); got != triggers+1 {
+		t.Errorf("cache_warmup triggers = %v, want %v", got, triggers+1)
+	}
+}
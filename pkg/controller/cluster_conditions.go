@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+var subscriptionListGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "SubscriptionList"}
+
+// ConditionEvaluation bounds the evaluation of asset conditions that query the cluster
+// (crd, operator, storage-class). Each distinct condition is evaluated once per reconcile.
+type ConditionEvaluation struct {
+	// Parallelism is how many conditions are evaluated at once
+	Parallelism int
+	// Timeout bounds a single condition; an expired one fails its assets' condition check
+	Timeout time.Duration
+}
+
+// DefaultConditionEvaluation returns the settings used unless configured
+func DefaultConditionEvaluation() ConditionEvaluation {
+	return ConditionEvaluation{
+		Parallelism: 4,
+		Timeout:     10 * time.Second,
+	}
+}
+
+// Validate rejects a parallelism below one and a negative timeout
+func (c ConditionEvaluation) Validate() error {
+	switch {
+	case c.Parallelism < 1:
+		return fmt.Errorf("condition parallelism must be at least 1, got %d", c.Parallelism)
+	case c.Timeout < 0:
+		return fmt.Errorf("condition timeout must not be negative, got %s", c.Timeout)
+	}
+	return nil
+}
+
+// SetConditionEvaluation sets the parallelism and timeout of cluster-querying conditions
+func (r *PlatformReconciler) SetConditionEvaluation(settings ConditionEvaluation) {
+	r.conditionEvaluation = settings
+}
+
+// clusterConditions evaluates the conditions that query live cluster resources and
+// caches their results, so an operator or StorageClass lookup shared by several
+// assets is made once per reconcile. It is the Cluster of a DefaultConditionEvaluator.
+type clusterConditions struct {
+	reader     client.Reader
+	crdChecker *util.CRDChecker
+	settings   ConditionEvaluation
+
+	mu      sync.Mutex
+	results map[assets.AssetCondition]conditionResult
+}
+
+type conditionResult struct {
+	satisfied bool
+	err       error
+}
+
+func newClusterConditions(reader client.Reader, crdChecker *util.CRDChecker, settings ConditionEvaluation) *clusterConditions {
+	if settings.Parallelism < 1 {
+		settings = DefaultConditionEvaluation()
+	}
+	return &clusterConditions{
+		reader:     reader,
+		crdChecker: crdChecker,
+		settings:   settings,
+		results:    make(map[assets.AssetCondition]conditionResult),
+	}
+}
+
+// clusterConditionsFor evaluates the cluster-querying conditions of the assets this
+// reconcile may apply, before they are walked in reconcile order
+func (r *PlatformReconciler) clusterConditionsFor(ctx context.Context, allowlist map[string]bool) *clusterConditions {
+	var candidates []assets.AssetMetadata
+	for _, asset := range r.registry.ListAssetsByReconcileOrder() {
+		if asset.ReconcileOrder == 0 || !r.shard.Owns(asset.Component) || !isInAllowlist(&asset, allowlist) {
+			continue
+		}
+		candidates = append(candidates, asset)
+	}
+
+	conditions := newClusterConditions(r.apiReader, r.crdChecker, r.conditionEvaluation)
+	conditions.prefetch(ctx, candidates)
+	return conditions
+}
+
+// prefetch evaluates the distinct cluster-querying conditions of assetList concurrently,
+// at most Parallelism at a time, so reconcile latency does not grow with every such
+// condition the catalog gains
+func (c *clusterConditions) prefetch(ctx context.Context, assetList []assets.AssetMetadata) {
+	seen := make(map[assets.AssetCondition]bool)
+	var pending []assets.AssetCondition
+	for i := range assetList {
+		for _, condition := range assetList[i].Conditions {
+			if !condition.Type.QueriesCluster() || condition.Value == "" || seen[condition] {
+				continue
+			}
+			seen[condition] = true
+			pending = append(pending, condition)
+		}
+	}
+
+	slots := make(chan struct{}, c.settings.Parallelism)
+	var wg sync.WaitGroup
+	for _, condition := range pending {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			// The result is cached; errors surface when an asset's conditions are checked
+			_, _ = c.EvaluateCondition(ctx, condition)
+		}()
+	}
+	wg.Wait()
+}
+
+// EvaluateCondition implements assets.ConditionEvaluator. Results, errors included,
+// are cached for the lifetime of c.
+func (c *clusterConditions) EvaluateCondition(ctx context.Context, condition assets.AssetCondition) (bool, error) {
+	c.mu.Lock()
+	result, cached := c.results[condition]
+	c.mu.Unlock()
+	if cached {
+		return result.satisfied, result.err
+	}
+
+	result.satisfied, result.err = c.evaluate(ctx, condition)
+
+	c.mu.Lock()
+	c.results[condition] = result
+	c.mu.Unlock()
+	return result.satisfied, result.err
+}
+
+// evaluate queries the cluster for one condition under the per-condition timeout
+func (c *clusterConditions) evaluate(ctx context.Context, condition assets.AssetCondition) (bool, error) {
+	if c.settings.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.settings.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	var satisfied bool
+	var err error
+	switch condition.Type {
+	case assets.ConditionTypeCRD:
+		satisfied, err = c.crdChecker.IsCRDInstalled(ctx, condition.Value)
+	case assets.ConditionTypeOperator:
+		satisfied, err = c.operatorInstalled(ctx, condition.Value)
+	case assets.ConditionTypeStorageClass:
+		satisfied, err = c.storageClassPresent(ctx, condition.Value)
+	default:
+		return false, fmt.Errorf("condition type %s does not query the cluster", condition.Type)
+	}
+	observability.ObserveConditionEvaluation(string(condition.Type), time.Since(start))
+
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false, fmt.Errorf("condition %s timed out after %s: %w", condition, c.settings.Timeout, err)
+	}
+	return satisfied, err
+}
+
+// operatorInstalled reports whether an OLM Subscription to the package has installed a CSV
+func (c *clusterConditions) operatorInstalled(ctx context.Context, pkg string) (bool, error) {
+	subscriptions, err := listIfInstalled(ctx, c.reader, subscriptionListGVK)
+	if err != nil {
+		return false, err
+	}
+	for i := range subscriptions {
+		name, _, _ := unstructured.NestedString(subscriptions[i].Object, "spec", "name")
+		installed, _, _ := unstructured.NestedString(subscriptions[i].Object, "status", "installedCSV")
+		if name == pkg && installed != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// storageClassPresent reports whether a StorageClass uses the provisioner
+func (c *clusterConditions) storageClassPresent(ctx context.Context, provisioner string) (bool, error) {
+	classes, err := listIfInstalled(ctx, c.reader, storageClassListGVK)
+	if err != nil {
+		return false, err
+	}
+	for i := range classes {
+		if value, _, _ := unstructured.NestedString(classes[i].Object, "provisioner"); value == provisioner {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+func newConditionTestClient(t *testing.T, funcs interceptor.Funcs) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	subscription := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "Subscription",
+		"metadata":   map[string]any{"name": "metallb", "namespace": "metallb-system"},
+		"spec":       map[string]any{"name": "metallb-operator"},
+		"status":     map[string]any{"installedCSV": "metallb-operator.v4.18.0"},
+	}}
+	pending := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "Subscription",
+		"metadata":   map[string]any{"name": "nmstate", "namespace": "openshift-nmstate"},
+		"spec":       map[string]any{"name": "kubernetes-nmstate-operator"},
+	}}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "metallbs.metallb.io"}},
+			&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "ocs-rbd"}, Provisioner: "openshift-storage.rbd.csi.ceph.com"},
+			subscription,
+			pending,
+		).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+func TestClusterConditions(t *testing.T) {
+	c := newConditionTestClient(t, interceptor.Funcs{})
+	conditions := newClusterConditions(c, util.NewCRDChecker(c), DefaultConditionEvaluation())
+	ctx := context.Background()
+
+	tests := []struct {
+		condition assets.AssetCondition
+		want      bool
+	}{
+		{assets.AssetCondition{Type: assets.ConditionTypeCRD, Value: "metallbs.metallb.io"}, true},
+		{assets.AssetCondition{Type: assets.ConditionTypeCRD, Value: "nmstates.nmstate.io"}, false},
+		{assets.AssetCondition{Type: assets.ConditionTypeOperator, Value: "metallb-operator"}, true},
+		// Subscribed but no CSV installed yet
+		{assets.AssetCondition{Type: assets.ConditionTypeOperator, Value: "kubernetes-nmstate-operator"}, false},
+		{assets.AssetCondition{Type: assets.ConditionTypeStorageClass, Value: "openshift-storage.rbd.csi.ceph.com"}, true},
+		{assets.AssetCondition{Type: assets.ConditionTypeStorageClass, Value: "nfs.csi.k8s.io"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition.String(), func(t *testing.T) {
+			got, err := conditions.EvaluateCondition(ctx, tt.condition)
+			if err != nil {
+				t.Fatalf("EvaluateCondition() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateCondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterConditionsPrefetch(t *testing.T) {
+	var lists, running, maxRunning atomic.Int32
+	c := newConditionTestClient(t, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			lists.Add(1)
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxRunning.Load()
+				if n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return c.List(ctx, list, opts...)
+		},
+	})
+	conditions := newClusterConditions(c, util.NewCRDChecker(c), ConditionEvaluation{Parallelism: 2, Timeout: time.Second})
+
+	storage := func(provisioner string) assets.AssetCondition {
+		return assets.AssetCondition{Type: assets.ConditionTypeStorageClass, Value: provisioner}
+	}
+	assetList := []assets.AssetMetadata{
+		{Name: "a", Conditions: []assets.AssetCondition{storage("p1"), storage("p2")}},
+		{Name: "b", Conditions: []assets.AssetCondition{storage("p1"), storage("p3"), {Type: assets.ConditionTypeAnnotation, Key: "k"}}},
+		{Name: "c", Conditions: []assets.AssetCondition{storage("p4")}},
+	}
+	conditions.prefetch(context.Background(), assetList)
+
+	// Four distinct cluster conditions, each evaluated once
+	if got := lists.Load(); got != 4 {
+		t.Errorf("List calls = %d, want 4", got)
+	}
+	if got := maxRunning.Load(); got != 2 {
+		t.Errorf("concurrent evaluations = %d, want the parallelism of 2", got)
+	}
+
+	// Checking the assets afterwards is served from the cache
+	if _, err := conditions.EvaluateCondition(context.Background(), storage("p3")); err != nil {
+		t.Fatalf("EvaluateCondition() error = %v", err)
+	}
+	if got := lists.Load(); got != 4 {
+		t.Errorf("List calls after prefetch = %d, want 4", got)
+	}
+}
+
+func TestClusterConditionsTimeout(t *testing.T) {
+	c := newConditionTestClient(t, interceptor.Funcs{
+		List: func(ctx context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	conditions := newClusterConditions(c, util.NewCRDChecker(c), ConditionEvaluation{Parallelism: 1, Timeout: 10 * time.Millisecond})
+
+	condition := assets.AssetCondition{Type: assets.ConditionTypeOperator, Value: "metallb-operator"}
+	_, err := conditions.EvaluateCondition(context.Background(), condition)
+	if err == nil || !strings.Contains(err.Error(), "operator(metallb-operator) timed out after 10ms") {
+		t.Fatalf("EvaluateCondition() error = %v, want a timeout", err)
+	}
+
+	// Through the evaluator the timeout fails the asset's condition check
+	registry := &assets.Registry{}
+	evaluator := &assets.DefaultConditionEvaluator{Cluster: conditions}
+	asset := &assets.AssetMetadata{Name: "metallb", Install: assets.InstallModeOptIn, Conditions: []assets.AssetCondition{condition}}
+	if _, err := registry.ShouldApply(context.Background(), asset, evaluator); err == nil {
+		t.Error("ShouldApply() error = nil, want the cached timeout")
+	}
+}
+
+func TestConditionEvaluationValidate(t *testing.T) {
+	if err := DefaultConditionEvaluation().Validate(); err != nil {
+		t.Errorf("default settings invalid: %v", err)
+	}
+	if err := (ConditionEvaluation{Parallelism: 0}).Validate(); err == nil {
+		t.Error("expected an error for zero parallelism")
+	}
+	if err := (ConditionEvaluation{Parallelism: 1, Timeout: -time.Second}).Validate(); err == nil {
+		t.Error("expected an error for a negative timeout")
+	}
+}
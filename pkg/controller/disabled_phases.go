@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DisabledPhasesAnnotation on the HCO lists, comma-separated, the asset phases the
+// autopilot skips entirely, e.g. "2" to leave every phase 2 asset alone
+const DisabledPhasesAnnotation = "platform.kubevirt.io/disabled-phases"
+
+// ParseDisabledPhases parses the disabled-phases annotation. An empty annotation
+// disables nothing. Phase 0, the HCO golden config, cannot be disabled.
+func ParseDisabledPhases(annotation string) (map[int]bool, error) {
+	phases := make(map[int]bool)
+	if strings.TrimSpace(annotation) == "" {
+		return phases, nil
+	}
+	for _, field := range strings.Split(annotation, ",") {
+		field = strings.TrimSpace(field)
+		phase, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid phase %q in %s annotation: not an integer", field, DisabledPhasesAnnotation)
+		}
+		if phase < 1 {
+			return nil, fmt.Errorf("invalid phase %d in %s annotation: only phases 1 and above can be disabled", phase, DisabledPhasesAnnotation)
+		}
+		phases[phase] = true
+	}
+	return phases, nil
+}
+
+// disabledPhases returns the phases disabled on hco. A malformed annotation disables
+// nothing, like a malformed disabled-resources annotation excludes nothing.
+func disabledPhases(hco *unstructured.Unstructured) (map[int]bool, error) {
+	phases, err := ParseDisabledPhases(hco.GetAnnotations()[DisabledPhasesAnnotation])
+	if err != nil {
+		return map[int]bool{}, err
+	}
+	return phases, nil
+}
+
+// skippedPhaseList renders the number of assets skipped per disabled phase for the
+// AutopilotStatus, ordered by phase
+func skippedPhaseList(skipped map[int]int) []any {
+	phases := make([]int, 0, len(skipped))
+	for phase := range skipped {
+		phases = append(phases, phase)
+	}
+	slices.Sort(phases)
+	list := make([]any, 0, len(phases))
+	for _, phase := range phases {
+		list = append(list, map[string]any{"phase": int64(phase), "assets": int64(skipped[phase])})
+	}
+	return list
+}
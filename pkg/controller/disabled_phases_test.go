@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+func TestParseDisabledPhases(t *testing.T) {
+	tests := []struct {
+		annotation string
+		want       []int
+		wantErr    bool
+	}{
+		{annotation: "", want: nil},
+		{annotation: "  ", want: nil},
+		{annotation: "3", want: []int{3}},
+		{annotation: "3,4", want: []int{3, 4}},
+		{annotation: " 2 , 4,2 ", want: []int{2, 4}},
+		{annotation: "0", wantErr: true},
+		{annotation: "-1", wantErr: true},
+		{annotation: "observability", wantErr: true},
+		{annotation: "3,", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.annotation, func(t *testing.T) {
+			got, err := ParseDisabledPhases(tt.annotation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDisabledPhases(%q) error = %v, wantErr %v", tt.annotation, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := make(map[int]bool)
+			for _, phase := range tt.want {
+				want[phase] = true
+			}
+			if !maps.Equal(got, want) {
+				t.Errorf("ParseDisabledPhases(%q) = %v, want %v", tt.annotation, got, want)
+			}
+		})
+	}
+}
+
+func TestSkippedPhaseList(t *testing.T) {
+	got := skippedPhaseList(map[int]int{4: 2, 3: 12})
+	want := []any{
+		map[string]any{"phase": int64(3), "assets": int64(12)},
+		map[string]any{"phase": int64(4), "assets": int64(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("skippedPhaseList() = %v, want %v", got, want)
+	}
+}
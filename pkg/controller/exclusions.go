@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// AutopilotExclusion status states
+const (
+	// ExclusionMatched means the exclusion kept at least one object from being applied
+	ExclusionMatched = "Matched"
+	// ExclusionUnmatched means no object the autopilot renders is selected by the exclusion
+	ExclusionUnmatched = "Unmatched"
+	// ExclusionExpired means the exclusion's expiresAt has passed and it no longer applies
+	ExclusionExpired = "Expired"
+	// ExclusionInvalid means the spec could not be parsed; the exclusion is ignored
+	ExclusionInvalid = "Invalid"
+)
+
+// AutopilotExclusionCRD returns the CustomResourceDefinition of AutopilotExclusion. The
+// CRD is optional: without it only the disabled-resources annotation excludes objects.
+func AutopilotExclusionCRD() *apiextensionsv1.CustomResourceDefinition {
+	str := apiextensionsv1.JSONSchemaProps{Type: "string"}
+	describedStr := func(description string) apiextensionsv1.JSONSchemaProps {
+		return apiextensionsv1.JSONSchemaProps{Type: "string", Description: description}
+	}
+	column := func(name, path, description string, priority int32) apiextensionsv1.CustomResourceColumnDefinition {
+		return apiextensionsv1.CustomResourceColumnDefinition{
+			Name: name, Type: "string", JSONPath: path, Description: description, Priority: priority,
+		}
+	}
+	gvk := overrides.AutopilotExclusionGVK
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: overrides.AutopilotExclusionCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: gvk.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       gvk.Kind,
+				ListKind:   gvk.Kind + "List",
+				Plural:     "autopilotexclusions",
+				Singular:   "autopilotexclusion",
+				ShortNames: []string{"apex"},
+				Categories: []string{"virt-platform-autopilot"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    gvk.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Description: "AutopilotExclusion stops virt-platform-autopilot from applying the objects it selects " +
+						"for the HyperConverged in the same namespace. Empty selector fields match anything.",
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"apiVersion": str,
+						"kind":       str,
+						"metadata":   {Type: "object"},
+						"spec": {
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"kind":      describedStr("Kind of the excluded objects, e.g. MachineConfig"),
+								"namespace": describedStr("Namespace of the excluded objects; glob patterns are accepted"),
+								"name":      describedStr("Name of the excluded objects; glob patterns are accepted"),
+								"component": describedStr("Component whose assets are excluded, e.g. KubeDescheduler"),
+								"expiresAt": {
+									Type:        "string",
+									Format:      "date-time",
+									Description: "Time after which the exclusion no longer applies",
+								},
+								"reason": describedStr("Why the objects are excluded"),
+							},
+						},
+						"status": {
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"state":              str,
+								"message":            str,
+								"observedGeneration": {Type: "integer", Format: "int64"},
+								"matchedResources": {
+									Type: "array",
+									Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+										Type: "object",
+										Properties: map[string]apiextensionsv1.JSONSchemaProps{
+											"asset":     str,
+											"kind":      str,
+											"namespace": str,
+											"name":      str,
+										},
+									}},
+								},
+							},
+						},
+					},
+				}},
+				Subresources: &apiextensionsv1.CustomResourceSubresources{
+					Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+				},
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{
+					column("Kind", ".spec.kind", "Kind of the excluded objects", 0),
+					column("Name", ".spec.name", "Name of the excluded objects", 0),
+					column("Component", ".spec.component", "Component whose assets are excluded", 0),
+					column("State", ".status.state", "Whether the exclusion matched anything", 0),
+					column("Reason", ".spec.reason", "Why the objects are excluded", 1),
+					{Name: "Expires", Type: "date", JSONPath: ".spec.expiresAt", Description: "Time the exclusion ends"},
+					{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+				},
+			}},
+		},
+	}
+}
+
+// exclusionSource returns the watch on AutopilotExclusions, or nil when their CRD is not
+// installed. Users create them without the managed-by label, so they are watched
+// through a cache of their own rather than the label-filtered manager cache. Status
+// writes do not change the generation and so do not trigger a reconcile.
+func (r *PlatformReconciler) exclusionSource(ctx context.Context, mgr ctrl.Manager) (source.Source, error) {
+	installed, err := r.crdChecker.IsCRDInstalled(ctx, overrides.AutopilotExclusionCRDName)
+	if err != nil || !installed {
+		return nil, err
+	}
+
+	opts := cache.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()}
+	if !r.allNamespaces {
+		opts.DefaultNamespaces = map[string]cache.Config{r.Namespace: {}}
+		for ns := range r.watchNamespaces {
+			opts.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+	exclusionCache, err := cache.New(mgr.GetConfig(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AutopilotExclusion cache: %w", err)
+	}
+	if err := mgr.Add(exclusionCache); err != nil {
+		return nil, fmt.Errorf("failed to add AutopilotExclusion cache: %w", err)
+	}
+	r.markCRDAsWatched(overrides.AutopilotExclusionCRDName)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(overrides.AutopilotExclusionGVK)
+	return source.Kind(exclusionCache, client.Object(obj),
+		handler.EnqueueRequestsFromMapFunc(r.exclusionRequests), predicate.GenerationChangedPredicate{}), nil
+}
+
+// exclusionRequests maps a changed AutopilotExclusion to the in-scope HCOs of its namespace
+func (r *PlatformReconciler) exclusionRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	hcoList := &unstructured.UnstructuredList{}
+	hcoList.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := r.List(ctx, hcoList, client.InNamespace(o.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list HCOs for AutopilotExclusion", "exclusion", o.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range hcoList.Items {
+		hco := &hcoList.Items[i]
+		if !r.isWatchedNamespace(hco.GetNamespace()) {
+			continue
+		}
+		observability.IncReconcileTrigger(observability.TriggerExclusionChange)
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: hco.GetName(), Namespace: hco.GetNamespace()},
+		})
+	}
+	return requests
+}
+
+// updateExclusionStatus reports on every AutopilotExclusion in the HCO namespace what
+// it matched in the pass that used renderCtx. Only changed statuses are written.
+func (r *PlatformReconciler) updateExclusionStatus(ctx context.Context, renderCtx *pkgcontext.RenderContext) error {
+	installed, err := r.crdChecker.IsCRDInstalled(ctx, overrides.AutopilotExclusionCRDName)
+	if err != nil || !installed {
+		return err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(overrides.AutopilotExclusionGVK.GroupVersion().WithKind(overrides.AutopilotExclusionGVK.Kind + "List"))
+	if err := r.apiReader.List(ctx, list, client.InNamespace(renderCtx.HCO.GetNamespace())); err != nil {
+		return fmt.Errorf("failed to list AutopilotExclusions: %w", err)
+	}
+
+	now := time.Now()
+	var errs []error
+	for i := range list.Items {
+		obj := &list.Items[i]
+		status := exclusionStatus(obj, renderCtx, now)
+		existing, _, _ := unstructured.NestedMap(obj.Object, "status")
+		if equality.Semantic.DeepEqual(existing, status) {
+			continue
+		}
+		if err := unstructured.SetNestedMap(obj.Object, status, "status"); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := r.Status().Update(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to update status of AutopilotExclusion %s: %w", obj.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// exclusionStatus builds the status of the AutopilotExclusion obj after the pass that used renderCtx
+func exclusionStatus(obj *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext, now time.Time) map[string]any {
+	status := map[string]any{"observedGeneration": obj.GetGeneration()}
+
+	exclusion, err := overrides.ParseExclusion(obj)
+	switch {
+	case err != nil:
+		status["state"] = ExclusionInvalid
+		status["message"] = err.Error()
+		return status
+	case exclusion.Expired(now):
+		status["state"] = ExclusionExpired
+		status["message"] = fmt.Sprintf("expired at %s", exclusion.ExpiresAt.Format(time.RFC3339))
+		return status
+	}
+
+	matches := append([]overrides.ExcludedObject(nil), renderCtx.ExclusionMatches(exclusion.Source)...)
+	if len(matches) == 0 {
+		status["state"] = ExclusionUnmatched
+		status["message"] = fmt.Sprintf("no object rendered for HyperConverged %s matches", renderCtx.HCO.GetName())
+		return status
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Asset != matches[j].Asset {
+			return matches[i].Asset < matches[j].Asset
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	resources := make([]any, 0, len(matches))
+	for _, match := range matches {
+		resource := map[string]any{"asset": match.Asset, "kind": match.Kind, "name": match.Name}
+		if match.Namespace != "" {
+			resource["namespace"] = match.Namespace
+		}
+		resources = append(resources, resource)
+	}
+	status["state"] = ExclusionMatched
+	status["message"] = fmt.Sprintf("%d object(s) not applied", len(matches))
+	status["matchedResources"] = resources
+	return status
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// TestAutopilotExclusionCRDManifest keeps config/crd in sync with AutopilotExclusionCRD
+func TestAutopilotExclusionCRDManifest(t *testing.T) {
+	data, err := os.ReadFile("../../config/crd/" + overrides.AutopilotExclusionCRDName + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		t.Fatal(err)
+	}
+	if !equality.Semantic.DeepEqual(manifest, AutopilotExclusionCRD()) {
+		t.Errorf("config/crd/%s.yaml is out of date with AutopilotExclusionCRD()", overrides.AutopilotExclusionCRDName)
+	}
+}
+
+func newExclusion(name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetGroupVersionKind(overrides.AutopilotExclusionGVK)
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName(name)
+	obj.SetGeneration(1)
+	return obj
+}
+
+func TestUpdateExclusionStatus(t *testing.T) {
+	ctx := context.Background()
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+
+	exclusions := []client.Object{
+		newExclusion("swap", map[string]any{"kind": "MachineConfig", "name": "50-swap", "reason": "vendor tuning"}),
+		newExclusion("idle", map[string]any{"kind": "ConfigMap", "name": "nothing-renders-this"}),
+		newExclusion("expired", map[string]any{"component": "KubeDescheduler", "expiresAt": "2020-01-01T00:00:00Z"}),
+		newExclusion("broken", map[string]any{"name": "no-kind"}),
+	}
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: overrides.AutopilotExclusionCRDName}}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(exclusions, hco, crd)...).
+		WithStatusSubresource(exclusions...).
+		Build()
+	reconciler, err := NewPlatformReconciler(c, c, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renderCtx := &pkgcontext.RenderContext{HCO: hco}
+	renderCtx.RecordExclusionMatch("swap", overrides.ExcludedObject{Asset: "swap-enable", Kind: "MachineConfig", Name: "50-swap"})
+	if err := reconciler.updateExclusionStatus(ctx, renderCtx); err != nil {
+		t.Fatalf("updateExclusionStatus() error = %v", err)
+	}
+
+	get := func(name string) *unstructured.Unstructured {
+		t.Helper()
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(overrides.AutopilotExclusionGVK)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: name}, obj); err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+	wantStates := map[string]string{
+		"swap":    ExclusionMatched,
+		"idle":    ExclusionUnmatched,
+		"expired": ExclusionExpired,
+		"broken":  ExclusionInvalid,
+	}
+	for name, want := range wantStates {
+		state, _, _ := unstructured.NestedString(get(name).Object, "status", "state")
+		if state != want {
+			t.Errorf("%s: status.state = %q, want %q", name, state, want)
+		}
+	}
+
+	matched, _, _ := unstructured.NestedSlice(get("swap").Object, "status", "matchedResources")
+	if len(matched) != 1 {
+		t.Fatalf("swap: matchedResources = %v, want the excluded MachineConfig", matched)
+	}
+	if asset := matched[0].(map[string]any)["asset"]; asset != "swap-enable" {
+		t.Errorf("swap: matched asset = %v, want swap-enable", asset)
+	}
+
+	// An unchanged outcome is not written again
+	before := get("swap").GetResourceVersion()
+	if err := reconciler.updateExclusionStatus(ctx, renderCtx); err != nil {
+		t.Fatalf("second updateExclusionStatus() error = %v", err)
+	}
+	if after := get("swap").GetResourceVersion(); after != before {
+		t.Errorf("status rewritten without a change: resourceVersion %s -> %s", before, after)
+	}
+}
+
+func TestUpdateExclusionStatusWithoutCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, err := NewPlatformReconciler(c, c, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderCtx := &pkgcontext.RenderContext{HCO: pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")}
+	if err := reconciler.updateExclusionStatus(context.Background(), renderCtx); err != nil {
+		t.Errorf("updateExclusionStatus() without the CRD error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// expiringEntry is a user exclusion or override patch that stops applying at a set time
+type expiringEntry struct {
+	source  string // where the entry is configured
+	name    string
+	expires time.Time
+}
+
+// expiringEntries lists the entries of renderCtx that carry an expiry, sorted by source and name
+func expiringEntries(renderCtx *pkgcontext.RenderContext) []expiringEntry {
+	var entries []expiringEntry
+
+	// An invalid annotation is reported by the patcher, which then ignores it
+	rules, _ := engine.ExclusionRulesFromObject(renderCtx.HCO)
+	for _, rule := range rules {
+		if rule.Expires != nil {
+			entries = append(entries, expiringEntry{source: engine.DisabledResourcesAnnotation, name: rule.String(), expires: *rule.Expires})
+		}
+	}
+	for asset, patch := range renderCtx.OverridePatches {
+		if patch.Expires != nil {
+			entries = append(entries, expiringEntry{source: "overrides ConfigMap patch", name: asset, expires: *patch.Expires})
+		}
+	}
+	for _, exclusion := range renderCtx.Exclusions {
+		if exclusion.ExpiresAt != nil {
+			entries = append(entries, expiringEntry{source: "AutopilotExclusion", name: exclusion.Source, expires: *exclusion.ExpiresAt})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].source != entries[j].source {
+			return entries[i].source < entries[j].source
+		}
+		return entries[i].name < entries[j].name
+	})
+	return entries
+}
+
+// expiryTracker remembers the expired entries already announced, so each expiry is
+// reported once per operator run instead of on every reconcile after it
+type expiryTracker struct {
+	mu        sync.Mutex
+	announced map[string]bool
+}
+
+// firstSeen reports whether key is announced for the first time
+func (t *expiryTracker) firstSeen(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.announced[key] {
+		return false
+	}
+	if t.announced == nil {
+		t.announced = make(map[string]bool)
+	}
+	t.announced[key] = true
+	return true
+}
+
+// checkExpirations emits an OverrideExpired event for every expired entry of renderCtx
+// and returns how long until the next one expires, 0 when none is pending, so Reconcile
+// can requeue and resume managing the covered resources on time.
+func (r *PlatformReconciler) checkExpirations(ctx context.Context, hco *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext, now time.Time) time.Duration {
+	logger := log.FromContext(ctx)
+
+	var next time.Duration
+	for _, entry := range expiringEntries(renderCtx) {
+		if now.Before(entry.expires) {
+			// Small margin so the entry is past its expiry when we run
+			if until := entry.expires.Sub(now) + time.Second; next == 0 || until < next {
+				next = until
+			}
+			continue
+		}
+
+		// The expiry is part of the key, so an entry extended and expired again is announced again
+		key := hco.GetNamespace() + "/" + hco.GetName() + "/" + entry.source + "/" + entry.name + "@" + entry.expires.UTC().Format(time.RFC3339)
+		if !r.expiries.firstSeen(key) {
+			continue
+		}
+		logger.Info("Temporary exclusion or override expired, managing its resources again",
+			"source", entry.source,
+			"entry", entry.name,
+			"expired", entry.expires.UTC().Format(time.RFC3339),
+		)
+		if r.eventRecorder != nil {
+			r.eventRecorder.OverrideExpired(hco, entry.source, entry.name, entry.expires)
+		}
+	}
+	return next
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func TestCheckExpirations(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+	soon := now.Add(10 * time.Minute)
+	later := now.Add(24 * time.Hour)
+
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	hco.SetNamespace("openshift-cnv")
+	hco.SetName("kubevirt-hyperconverged")
+	hco.SetAnnotations(map[string]string{engine.DisabledResourcesAnnotation: `
+- kind: KubeDescheduler
+  name: cluster
+  expires: ` + expired.Format(time.RFC3339) + `
+- kind: ConfigMap
+  name: permanent
+`})
+
+	renderCtx := &pkgcontext.RenderContext{
+		HCO: hco,
+		OverridePatches: map[string]overrides.AssetPatch{
+			"swap": {Type: overrides.PatchTypeMerge, Patch: `{}`, Expires: &later},
+		},
+		Exclusions: []overrides.Exclusion{
+			{Source: "vendor-swap", Kind: "MachineConfig", ExpiresAt: &soon},
+			{Source: "old-hotfix", Component: "KubeDescheduler", ExpiresAt: &expired},
+		},
+	}
+
+	rec := eventtest.NewRecorder()
+	r := &PlatformReconciler{eventRecorder: util.NewEventRecorder(rec)}
+
+	next := r.checkExpirations(context.Background(), hco, renderCtx, now)
+	if want := 10*time.Minute + time.Second; next != want {
+		t.Errorf("next expiry in %s, want %s", next, want)
+	}
+	if got := rec.Count(util.EventReasonOverrideExpired); got != 2 {
+		t.Fatalf("got %d OverrideExpired events, want 2 (the expired rule and AutopilotExclusion)", got)
+	}
+	rec.ExpectEvent(t, util.EventReasonOverrideExpired, "HyperConverged", "kubevirt-hyperconverged")
+
+	// Each expiry is announced once
+	rec.Reset()
+	r.checkExpirations(context.Background(), hco, renderCtx, now.Add(time.Minute))
+	rec.ExpectNoEvent(t, util.EventReasonOverrideExpired, "", "")
+
+	// Until the next entry expires
+	if next := r.checkExpirations(context.Background(), hco, renderCtx, soon); next != 24*time.Hour-10*time.Minute+time.Second {
+		t.Errorf("next expiry in %s after the exclusion expired", next)
+	}
+	if got := rec.Count(util.EventReasonOverrideExpired); got != 1 {
+		t.Errorf("got %d OverrideExpired events when vendor-swap expired, want 1", got)
+	}
+}
+
+func TestCheckExpirationsWithoutExpiries(t *testing.T) {
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	renderCtx := &pkgcontext.RenderContext{HCO: hco}
+
+	r := &PlatformReconciler{}
+	if next := r.checkExpirations(context.Background(), hco, renderCtx, time.Now()); next != 0 {
+		t.Errorf("next expiry in %s, want 0 without expiring entries", next)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// hardwareHistorySize is the number of detection changes a hardwareHistory keeps
+const hardwareHistorySize = 100
+
+// HardwareSample is one hardware detection result that differed from the previous one
+type HardwareSample struct {
+	// Time is when the detection ran
+	Time time.Time `json:"time"`
+	// Nodes is the number of nodes the detection scanned
+	Nodes int `json:"nodes"`
+	// Detected maps each detector to whether a node matched it
+	Detected map[string]bool `json:"detected"`
+	// Held lists the detectors no node matches but churn damping holds true
+	Held []string `json:"held,omitempty"`
+	// Changed lists the detectors whose detected or held state differs from the previous sample
+	Changed []string `json:"changed,omitempty"`
+}
+
+// hardwareHistory keeps the recent changes of the hardware detection results, to
+// tell why assets gated on a detector keep being applied and removed, e.g. on
+// clusters whose autoscaler adds and removes GPU nodes
+type hardwareHistory struct {
+	mu      sync.Mutex
+	size    int
+	samples []HardwareSample // oldest first
+	now     func() time.Time
+}
+
+func newHardwareHistory() *hardwareHistory {
+	return &hardwareHistory{size: hardwareHistorySize, now: time.Now}
+}
+
+// record exports the detection results as metrics and adds them to the history
+// when they differ from the last sample. detected is the result of the node scan,
+// hardware the same after churn damping.
+func (h *hardwareHistory) record(detected map[string]bool, hardware *pkgcontext.HardwareContext, nodes int) {
+	if h == nil {
+		return
+	}
+	for detector, present := range detected {
+		observability.SetHardwareDetected(detector, present)
+	}
+
+	held := slices.Sorted(maps.Keys(hardware.PendingRemoval))
+	sample := HardwareSample{Nodes: nodes, Detected: maps.Clone(detected), Held: held}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.samples) > 0 {
+		sample.Changed = changedDetectors(h.samples[len(h.samples)-1], sample)
+		if len(sample.Changed) == 0 {
+			return
+		}
+	}
+	sample.Time = h.now()
+	h.samples = append(h.samples, sample)
+	if len(h.samples) > h.size {
+		h.samples = slices.Delete(h.samples, 0, len(h.samples)-h.size)
+	}
+}
+
+// changedDetectors returns, sorted, the detectors detected or held differently in prev and next
+func changedDetectors(prev, next HardwareSample) []string {
+	var changed []string
+	for detector, present := range next.Detected {
+		if prev.Detected[detector] != present ||
+			slices.Contains(prev.Held, detector) != slices.Contains(next.Held, detector) {
+			changed = append(changed, detector)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// list returns the recorded detection changes, newest first
+func (h *hardwareHistory) list() []HardwareSample {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	samples := make([]HardwareSample, len(h.samples))
+	for i, sample := range h.samples {
+		samples[len(h.samples)-1-i] = sample
+	}
+	return samples
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func TestHardwareHistory(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	h := newHardwareHistory()
+	h.now = func() time.Time { return now }
+
+	gpu := &pkgcontext.HardwareContext{GPUPresent: true}
+	h.record(gpu.AsMap(), gpu, 3)
+	if got := testutil.ToFloat64(observability.HardwareDetected.WithLabelValues("gpuPresent")); got != 1 {
+		t.Errorf("hardware_detected{gpuPresent} = %v, want 1", got)
+	}
+
+	// Same result: nothing recorded
+	now = start.Add(time.Minute)
+	h.record(gpu.AsMap(), gpu, 3)
+	if samples := h.list(); len(samples) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(samples))
+	}
+
+	// GPU node scaled down, detector held by damping
+	now = start.Add(2 * time.Minute)
+	detected := (&pkgcontext.HardwareContext{}).AsMap()
+	held := &pkgcontext.HardwareContext{GPUPresent: true,
+		PendingRemoval: map[string]time.Time{"gpuPresent": start.Add(12 * time.Minute)}}
+	h.record(detected, held, 2)
+	if got := testutil.ToFloat64(observability.HardwareDetected.WithLabelValues("gpuPresent")); got != 0 {
+		t.Errorf("hardware_detected{gpuPresent} = %v, want 0", got)
+	}
+
+	// Released at the end of the grace period
+	now = start.Add(12 * time.Minute)
+	h.record(detected, &pkgcontext.HardwareContext{}, 2)
+
+	samples := h.list()
+	if len(samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(samples))
+	}
+	if !samples[0].Time.Equal(now) || samples[0].Held != nil || !reflect.DeepEqual(samples[0].Changed, []string{"gpuPresent"}) {
+		t.Errorf("unexpected newest sample %+v", samples[0])
+	}
+	if samples[1].Nodes != 2 || samples[1].Detected["gpuPresent"] || !reflect.DeepEqual(samples[1].Held, []string{"gpuPresent"}) {
+		t.Errorf("unexpected held sample %+v", samples[1])
+	}
+	if !samples[2].Time.Equal(start) || samples[2].Changed != nil {
+		t.Errorf("unexpected first sample %+v", samples[2])
+	}
+}
+
+func TestHardwareHistoryBounded(t *testing.T) {
+	h := newHardwareHistory()
+	h.size = 2
+	for i := range 5 {
+		hardware := &pkgcontext.HardwareContext{GPUPresent: i%2 == 0}
+		h.record(hardware.AsMap(), hardware, i)
+	}
+
+	samples := h.list()
+	if len(samples) != 2 || samples[0].Nodes != 4 || samples[1].Nodes != 3 {
+		t.Errorf("expected the last 2 samples, got %+v", samples)
+	}
+}
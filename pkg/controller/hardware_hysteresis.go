@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// hardwareHysteresis damps hardware detection churn.
+//
+// Detectors flip to false as soon as the last matching node disappears, e.g. when
+// the cluster autoscaler scales a GPU MachineSet to zero. Removing the dependent
+// assets right away (often MachineConfigs) triggers a rolling reboot of the pool,
+// only to re-add them when the nodes scale back up. With a grace period, a detector
+// that was true stays true until it has been unseen for the whole period.
+type hardwareHysteresis struct {
+	mu          sync.Mutex
+	gracePeriod time.Duration
+	lastSeen    map[string]time.Time
+	pending     map[string]bool
+	now         func() time.Time
+}
+
+func newHardwareHysteresis(gracePeriod time.Duration) *hardwareHysteresis {
+	return &hardwareHysteresis{
+		gracePeriod: gracePeriod,
+		lastSeen:    make(map[string]time.Time),
+		pending:     make(map[string]bool),
+		now:         time.Now,
+	}
+}
+
+// apply holds recently-seen detectors true in hardware and records them in
+// hardware.PendingRemoval. It returns the detectors that entered the pending
+// state during this call, sorted, so the caller can report each transition once.
+func (h *hardwareHysteresis) apply(hardware *pkgcontext.HardwareContext) []string {
+	if h == nil || h.gracePeriod <= 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	var entered []string
+	for detector, present := range hardware.AsMap() {
+		if present {
+			h.lastSeen[detector] = now
+			delete(h.pending, detector)
+			observability.SetHardwarePendingRemoval(detector, time.Time{})
+			continue
+		}
+
+		seen, ok := h.lastSeen[detector]
+		if !ok {
+			continue
+		}
+
+		deadline := seen.Add(h.gracePeriod)
+		if !now.Before(deadline) {
+			// Grace period over: let the detector go false and forget it
+			delete(h.lastSeen, detector)
+			delete(h.pending, detector)
+			observability.SetHardwarePendingRemoval(detector, time.Time{})
+			continue
+		}
+
+		hardware.SetDetector(detector, true)
+		if hardware.PendingRemoval == nil {
+			hardware.PendingRemoval = make(map[string]time.Time)
+		}
+		hardware.PendingRemoval[detector] = deadline
+		observability.SetHardwarePendingRemoval(detector, deadline)
+
+		if !h.pending[detector] {
+			h.pending[detector] = true
+			entered = append(entered, detector)
+		}
+	}
+
+	sort.Strings(entered)
+	return entered
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestHardwareHysteresis(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	h := newHardwareHysteresis(10 * time.Minute)
+	h.now = func() time.Time { return now }
+
+	// GPU nodes present: nothing pending
+	hw := &pkgcontext.HardwareContext{GPUPresent: true}
+	if entered := h.apply(hw); entered != nil {
+		t.Fatalf("expected no transitions, got %v", entered)
+	}
+	if !hw.GPUPresent || len(hw.PendingRemoval) != 0 {
+		t.Fatalf("unexpected hardware %+v", hw)
+	}
+
+	// GPU nodes scaled down: detector held true and reported once
+	now = start.Add(2 * time.Minute)
+	hw = &pkgcontext.HardwareContext{}
+	if entered := h.apply(hw); !reflect.DeepEqual(entered, []string{"gpuPresent"}) {
+		t.Fatalf("expected gpuPresent to enter pending removal, got %v", entered)
+	}
+	if !hw.GPUPresent {
+		t.Fatal("expected GPUPresent to be held true")
+	}
+	if want := start.Add(10 * time.Minute); !hw.PendingRemoval["gpuPresent"].Equal(want) {
+		t.Errorf("pending removal deadline = %v, want %v", hw.PendingRemoval["gpuPresent"], want)
+	}
+
+	// Still within the grace period: held, but not reported again
+	now = start.Add(9 * time.Minute)
+	hw = &pkgcontext.HardwareContext{}
+	if entered := h.apply(hw); entered != nil {
+		t.Errorf("expected no new transitions, got %v", entered)
+	}
+	if !hw.GPUPresent {
+		t.Error("expected GPUPresent to still be held true")
+	}
+
+	// Grace period over: detector released
+	now = start.Add(10 * time.Minute)
+	hw = &pkgcontext.HardwareContext{}
+	h.apply(hw)
+	if hw.GPUPresent || len(hw.PendingRemoval) != 0 {
+		t.Errorf("expected GPU detector released, got %+v", hw)
+	}
+
+	// Released detectors are forgotten: staying absent does not re-hold them
+	now = start.Add(11 * time.Minute)
+	hw = &pkgcontext.HardwareContext{}
+	h.apply(hw)
+	if hw.GPUPresent {
+		t.Error("expected GPU detector to stay released")
+	}
+}
+
+func TestHardwareHysteresisFlapResetsDeadline(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	h := newHardwareHysteresis(10 * time.Minute)
+	h.now = func() time.Time { return now }
+
+	h.apply(&pkgcontext.HardwareContext{PCIDevicesPresent: true})
+
+	now = start.Add(5 * time.Minute)
+	h.apply(&pkgcontext.HardwareContext{})
+
+	// Nodes come back, then vanish again: new grace period from the last sighting
+	now = start.Add(6 * time.Minute)
+	h.apply(&pkgcontext.HardwareContext{PCIDevicesPresent: true})
+
+	now = start.Add(7 * time.Minute)
+	hw := &pkgcontext.HardwareContext{}
+	if entered := h.apply(hw); !reflect.DeepEqual(entered, []string{"pciDevicesPresent"}) {
+		t.Errorf("expected a new pending transition, got %v", entered)
+	}
+	if want := start.Add(16 * time.Minute); !hw.PendingRemoval["pciDevicesPresent"].Equal(want) {
+		t.Errorf("pending removal deadline = %v, want %v", hw.PendingRemoval["pciDevicesPresent"], want)
+	}
+}
+
+func TestHardwareHysteresisDisabled(t *testing.T) {
+	var h *hardwareHysteresis
+	hw := &pkgcontext.HardwareContext{}
+	if entered := h.apply(hw); entered != nil || hw.GPUPresent {
+		t.Errorf("nil hysteresis must be a no-op, got %v %+v", entered, hw)
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		hardware *pkgcontext.HardwareContext
+		want     time.Duration
+	}{
+		{"nil hardware", nil, resyncPeriod},
+		{"nothing pending", &pkgcontext.HardwareContext{}, resyncPeriod},
+		{
+			"pending beyond resync",
+			&pkgcontext.HardwareContext{PendingRemoval: map[string]time.Time{"gpuPresent": now.Add(time.Hour)}},
+			resyncPeriod,
+		},
+		{
+			"earliest pending deadline wins",
+			&pkgcontext.HardwareContext{PendingRemoval: map[string]time.Time{
+				"gpuPresent":        now.Add(3 * time.Minute),
+				"pciDevicesPresent": now.Add(time.Minute),
+			}},
+			time.Minute + time.Second,
+		},
+		{
+			"overdue deadline requeues promptly",
+			&pkgcontext.HardwareContext{PendingRemoval: map[string]time.Time{"gpuPresent": now.Add(-time.Minute)}},
+			time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requeueAfter(tt.hardware, now); got != tt.want {
+				t.Errorf("requeueAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
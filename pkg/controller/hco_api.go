@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// ConditionUnsupportedHCOAPI is the HCO status condition reporting report-only mode
+// because HyperConverged is served at an API version the assets were not written for
+const ConditionUnsupportedHCOAPI = "PlatformAutopilotUnsupportedHCOAPI"
+
+// SupportedHCOVersions is the support matrix: the HyperConverged API versions the
+// golden config and the asset templates are written against
+var SupportedHCOVersions = []string{"v1", "v1beta1"}
+
+// checkHCOAPI compares the versions the HyperConverged CRD serves with the support
+// matrix and returns why nothing may be applied, or "" when the API is supported. A
+// missing CRD is not an unsupported API: there is no HCO to reconcile then.
+func (r *PlatformReconciler) checkHCOAPI(ctx context.Context) (string, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pkgcontext.HCOCRDName}, crd); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get the HyperConverged CRD: %w", err)
+	}
+
+	newest, reason := unsupportedHCOAPI(crd)
+	if newest != "" {
+		observability.SetHCOAPISupport(newest, reason == "")
+	}
+	return reason, nil
+}
+
+// unsupportedHCOAPI returns the newest version crd serves, in Kubernetes version order,
+// and why it is unsupported: the version the autopilot reads is no longer served, or a
+// newer one is, whose semantics the assets may not match.
+func unsupportedHCOAPI(crd *apiextensionsv1.CustomResourceDefinition) (newest, reason string) {
+	var served []string
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			served = append(served, v.Name)
+		}
+	}
+	if len(served) == 0 {
+		return "", ""
+	}
+	slices.SortFunc(served, func(a, b string) int {
+		return version.CompareKubeAwareVersionStrings(b, a)
+	})
+
+	newest = served[0]
+	switch {
+	case !slices.Contains(served, pkgcontext.HCOVersion):
+		reason = fmt.Sprintf("HyperConverged %s is no longer served (served: %s)",
+			pkgcontext.HCOVersion, strings.Join(served, ", "))
+	case !slices.Contains(SupportedHCOVersions, newest):
+		reason = fmt.Sprintf("HyperConverged is served at %s, newer than the supported versions %s",
+			newest, strings.Join(SupportedHCOVersions, ", "))
+	}
+	return newest, reason
+}
+
+// recordHCOAPISupport reports report-only mode, and its end, in the HCO status
+func (r *PlatformReconciler) recordHCOAPISupport(ctx context.Context, hco *unstructured.Unstructured, reason string) {
+	key := types.NamespacedName{Namespace: hco.GetNamespace(), Name: hco.GetName()}
+	condition := metav1.Condition{
+		Type:    ConditionUnsupportedHCOAPI,
+		Status:  metav1.ConditionFalse,
+		Reason:  "HCOAPISupported",
+		Message: "The served HyperConverged API versions are supported",
+	}
+	if reason != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UnsupportedHCOAPIVersion"
+		condition.Message = reason + "; drift is reported but not corrected until the autopilot is upgraded"
+	}
+
+	// The message names the served versions, so a further API change rewrites the condition
+	if err := r.setHCOCondition(ctx, key, condition, true); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to update HCO API support condition", "error", err.Error())
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func hcoCRDServing(versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: pkgcontext.HCOCRDName}}
+	for _, v := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+	}
+	return crd
+}
+
+func TestUnsupportedHCOAPI(t *testing.T) {
+	tests := []struct {
+		name           string
+		crd            *apiextensionsv1.CustomResourceDefinition
+		expectedNewest string
+		expectedReason string
+	}{
+		{name: "current versions", crd: hcoCRDServing("v1beta1", "v1"), expectedNewest: "v1"},
+		{name: "newer pre-release version", crd: hcoCRDServing("v1", "v2alpha1"), expectedNewest: "v1"},
+		{name: "newer GA version", crd: hcoCRDServing("v1", "v2"), expectedNewest: "v2", expectedReason: "served at v2"},
+		{name: "v1 no longer served", crd: hcoCRDServing("v2"), expectedNewest: "v2", expectedReason: "v1 is no longer served"},
+		{name: "no served version", crd: hcoCRDServing()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newest, reason := unsupportedHCOAPI(tt.crd)
+			if newest != tt.expectedNewest {
+				t.Errorf("newest = %q, want %q", newest, tt.expectedNewest)
+			}
+			if tt.expectedReason == "" && reason != "" || !strings.Contains(reason, tt.expectedReason) {
+				t.Errorf("reason = %q, want it to contain %q", reason, tt.expectedReason)
+			}
+		})
+	}
+
+	// An unserved version is not considered
+	crd := hcoCRDServing("v1", "v2")
+	crd.Spec.Versions[1].Served = false
+	if newest, reason := unsupportedHCOAPI(crd); newest != "v1" || reason != "" {
+		t.Errorf("unsupportedHCOAPI() with v2 not served = %q, %q; want v1 and supported", newest, reason)
+	}
+}
+
+func TestCheckHCOAPI(t *testing.T) {
+	observability.HCOAPISupported.Reset()
+
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}
+
+	// Without the CRD there is nothing to judge
+	r := &PlatformReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+	if reason, err := r.checkHCOAPI(ctx); err != nil || reason != "" {
+		t.Fatalf("checkHCOAPI() without CRD = %q, %v; want supported", reason, err)
+	}
+
+	crd := hcoCRDServing("v1beta1", "v1", "v2")
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd, hco).WithStatusSubresource(hco).Build()
+	r = &PlatformReconciler{Client: fakeClient}
+	reason, err := r.checkHCOAPI(ctx)
+	if err != nil || !strings.Contains(reason, "served at v2") {
+		t.Fatalf("checkHCOAPI() = %q, %v; want unsupported v2", reason, err)
+	}
+	if val := testutil.ToFloat64(observability.HCOAPISupported.WithLabelValues("v2")); val != 0 {
+		t.Errorf("hco_api_supported{version=v2} = %v, want 0", val)
+	}
+
+	condition := func() map[string]any {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := fakeClient.Get(ctx, key, live); err != nil {
+			t.Fatalf("failed to get HCO: %v", err)
+		}
+		conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+		for _, c := range conditions {
+			if m := c.(map[string]any); m["type"] == ConditionUnsupportedHCOAPI {
+				return m
+			}
+		}
+		return nil
+	}
+	r.recordHCOAPISupport(ctx, hco, reason)
+	if c := condition(); c == nil || c["status"] != "True" || c["reason"] != "UnsupportedHCOAPIVersion" {
+		t.Fatalf("condition = %v, want True/UnsupportedHCOAPIVersion", c)
+	}
+
+	// Once v2 is no longer served, report-only mode ends
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: pkgcontext.HCOCRDName}, crd); err != nil {
+		t.Fatalf("failed to get CRD: %v", err)
+	}
+	crd.Spec.Versions = crd.Spec.Versions[:2]
+	if err := fakeClient.Update(ctx, crd); err != nil {
+		t.Fatalf("failed to update CRD: %v", err)
+	}
+	if reason, err = r.checkHCOAPI(ctx); err != nil || reason != "" {
+		t.Fatalf("checkHCOAPI() after dropping v2 = %q, %v; want supported", reason, err)
+	}
+	if count := testutil.CollectAndCount(observability.HCOAPISupported); count != 1 {
+		t.Errorf("hco_api_supported series = %d, want 1", count)
+	}
+	if val := testutil.ToFloat64(observability.HCOAPISupported.WithLabelValues("v1")); val != 1 {
+		t.Errorf("hco_api_supported{version=v1} = %v, want 1", val)
+	}
+	r.recordHCOAPISupport(ctx, hco, reason)
+	if c := condition(); c["status"] != "False" || c["reason"] != "HCOAPISupported" {
+		t.Errorf("condition after upgrade = %v, want False/HCOAPISupported", c)
+	}
+}
@@ -19,18 +19,29 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/hugepages"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/perfprofile"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
 )
 
@@ -42,9 +53,18 @@ const (
 	nodeControlPlaneRoleLabel = "node-role.kubernetes.io/control-plane"
 	nodeWorkerRoleLabel       = "node-role.kubernetes.io/worker"
 
+	// topologyManagerPolicyAnnotation marks nodes whose kubelet aligns NUMA resources
+	topologyManagerPolicyAnnotation = "kubevirt.io/topology-manager-policy"
+
 	// infrastructureResourceName is the singleton Infrastructure CR name on OpenShift.
 	infrastructureResourceName = "cluster"
 
+	// proxyResourceName is the singleton cluster-wide Proxy CR name on OpenShift.
+	proxyResourceName = "cluster"
+
+	// clusterVersionResourceName is the singleton ClusterVersion CR name on OpenShift.
+	clusterVersionResourceName = "version"
+
 	// controlPlaneTopologyExternal is the Infrastructure CR value that indicates HCP.
 	controlPlaneTopologyExternal = "External"
 )
@@ -52,21 +72,41 @@ const (
 // RenderContextBuilder builds RenderContext from cluster state
 type RenderContextBuilder struct {
 	client        client.Client
+	apiReader     client.Reader // reads objects outside the label-filtered cache
 	eventRecorder *util.EventRecorder
+	hysteresis    *hardwareHysteresis // nil = no hardware churn damping
+	history       *hardwareHistory    // nil = detection results are not recorded
 }
 
 // NewRenderContextBuilder creates a new RenderContext builder
 func NewRenderContextBuilder(c client.Client) *RenderContextBuilder {
 	return &RenderContextBuilder{
-		client: c,
+		client:    c,
+		apiReader: c,
 	}
 }
 
+// SetAPIReader sets the uncached reader used for user-owned objects such as the
+// overrides ConfigMap, which carry no managed-by label and so are not in the cache
+func (b *RenderContextBuilder) SetAPIReader(reader client.Reader) {
+	b.apiReader = reader
+}
+
 // SetEventRecorder sets the event recorder for hardware detection events
 func (b *RenderContextBuilder) SetEventRecorder(recorder *util.EventRecorder) {
 	b.eventRecorder = recorder
 }
 
+// SetHardwareRemovalGracePeriod keeps a hardware detector true for gracePeriod after
+// the last matching node disappears. Zero disables damping.
+func (b *RenderContextBuilder) SetHardwareRemovalGracePeriod(gracePeriod time.Duration) {
+	if gracePeriod <= 0 {
+		b.hysteresis = nil
+		return
+	}
+	b.hysteresis = newHardwareHysteresis(gracePeriod)
+}
+
 // Build constructs a RenderContext from the current HCO state
 func (b *RenderContextBuilder) Build(ctx context.Context, hco *unstructured.Unstructured) (*pkgcontext.RenderContext, error) {
 	logger := log.FromContext(ctx)
@@ -84,6 +124,16 @@ func (b *RenderContextBuilder) Build(ctx context.Context, hco *unstructured.Unst
 
 	// Detect hardware capabilities from nodes.
 	hardware := detectHardware(nodes)
+	detected := hardware.AsMap()
+	for _, detector := range b.hysteresis.apply(hardware) {
+		until := hardware.PendingRemoval[detector]
+		logger.Info("Hardware no longer detected, keeping dependent assets until grace period ends",
+			"detector", detector, "until", until)
+		if b.eventRecorder != nil {
+			b.eventRecorder.HardwarePendingRemoval(hco, detector, until)
+		}
+	}
+	b.history.record(detected, hardware, len(nodes))
 
 	// Detect cluster topology from nodes and Infrastructure CR.
 	topology, err := b.detectTopology(ctx, nodes)
@@ -98,11 +148,106 @@ func (b *RenderContextBuilder) Build(ctx context.Context, hco *unstructured.Unst
 		topology = &pkgcontext.TopologyContext{}
 	}
 
+	// Detect cluster-wide proxy and trusted CA so workloads get egress settings.
+	proxy, err := b.detectProxy(ctx)
+	if err != nil {
+		logger.Error(err, "Proxy detection failed, rendering without proxy settings",
+			"hco", hco.GetName())
+		proxy = &pkgcontext.ProxyContext{}
+	}
+
+	// Detect FIPS mode so crypto-sensitive assets can vary their output.
+	fips, err := b.detectFIPS(ctx)
+	if err != nil {
+		logger.Error(err, "FIPS detection failed, assuming FIPS is disabled",
+			"hco", hco.GetName())
+	}
+
+	// Detect upgrades and pool rollouts so reboot-triggering assets can be deferred.
+	upgrade, err := b.detectUpgrade(ctx)
+	if err != nil {
+		logger.Error(err, "Upgrade detection failed, assuming the cluster is stable",
+			"hco", hco.GetName())
+	}
+
+	// Detect image mirrors so templated image references can point at them on disconnected installs.
+	mirrors, err := b.detectMirrors(ctx)
+	if err != nil {
+		logger.Error(err, "Image mirror detection failed, rendering with source image references",
+			"hco", hco.GetName())
+	}
+
+	// Detect storage capabilities so migration and backup settings match what storage supports.
+	storage, err := b.detectStorage(ctx)
+	if err != nil {
+		logger.Error(err, "Storage detection failed, assuming no RWX or snapshot support",
+			"hco", hco.GetName())
+	}
+
+	// Detect the network plugin, Multus and NMState so networking assets can adapt.
+	network, err := b.detectNetwork(ctx)
+	if err != nil {
+		logger.Error(err, "Network detection failed, rendering without network capabilities",
+			"hco", hco.GetName())
+	}
+
+	// Gather the bare-metal host inventory the default MetalLB pool is derived from.
+	metalLBInventory, err := b.detectMetalLBInventory(ctx, nodes)
+	if err != nil {
+		logger.Error(err, "MetalLB inventory detection incomplete, address pools may not be derived",
+			"hco", hco.GetName())
+	}
+
+	// Tune the descheduler from the workload hints on the HCO.
+	descheduler, err := pkgcontext.NewDeschedulerContext(hco)
+	if err != nil {
+		logger.Error(err, "Invalid descheduler hints ignored",
+			"hco", hco.GetName())
+	}
+
+	// Load the asset patches kept in the HCO's overrides ConfigMap; a bad entry
+	// is reported and dropped while the remaining ones still apply.
+	overridePatches, err := overrides.LoadOverridePatches(ctx, b.apiReader, hco)
+	if err != nil {
+		logger.Error(err, "Invalid overrides ConfigMap entries ignored",
+			"hco", hco.GetName())
+		if b.eventRecorder != nil {
+			b.eventRecorder.InvalidPatch(hco, "ConfigMap", hco.GetNamespace(),
+				hco.GetAnnotations()[overrides.AnnotationOverridesConfigMap], err.Error())
+		}
+	}
+
+	// Invalid AutopilotExclusions are dropped here; their status says why
+	exclusions, err := overrides.LoadExclusions(ctx, b.apiReader, hco.GetNamespace())
+	if err != nil {
+		logger.Error(err, "AutopilotExclusions not loaded, ignoring them",
+			"hco", hco.GetName())
+	}
+
 	return &pkgcontext.RenderContext{
-		HCO:      hco,
-		Hardware: hardware,
-		Topology: topology,
-		Images:   loadImages(),
+		HCO:                  hco,
+		Hardware:             hardware,
+		Topology:             topology,
+		Proxy:                proxy,
+		FIPS:                 fips,
+		Upgrade:              upgrade,
+		Mirrors:              mirrors,
+		Storage:              storage,
+		Network:              network,
+		Architecture:         pkgcontext.NewArchitectureContext(nodes, upgrade.Pools),
+		PerformanceProfile:   perfprofile.Recommend(nodes, hco),
+		HugePages:            hugepages.Recommend(nodes, upgrade.Pools, hco),
+		Descheduler:          descheduler,
+		Placement:            pkgcontext.NewPlacementContext(hco),
+		TuningPolicy:         pkgcontext.HCOTuningPolicy(hco),
+		FeatureGates:         pkgcontext.HCOFeatureGates(hco),
+		LiveMigration:        pkgcontext.NewLiveMigrationContext(hco),
+		ResourceRequirements: pkgcontext.NewResourceRequirementsContext(hco),
+		MetalLB:              pkgcontext.NewMetalLBContext(hco, metalLBInventory),
+		Images:               loadImages(),
+		OverridePatches:      overridePatches,
+		Exclusions:           exclusions,
+		Outputs:              make(map[string]map[string]any),
 	}, nil
 }
 
@@ -226,6 +371,453 @@ func (b *RenderContextBuilder) detectTopology(ctx context.Context, nodes []corev
 	return topology, nil
 }
 
+// detectProxy reads the OpenShift cluster-wide Proxy CR.
+// A missing CR (non-OpenShift cluster) yields an empty ProxyContext.
+func (b *RenderContextBuilder) detectProxy(ctx context.Context) (*pkgcontext.ProxyContext, error) {
+	proxy := &pkgcontext.ProxyContext{}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "Proxy",
+	})
+
+	err := b.client.Get(ctx, types.NamespacedName{Name: proxyResourceName}, obj)
+	switch {
+	case err == nil:
+		// status holds the effective values (including the generated noProxy list)
+		proxy.HTTPProxy, _, _ = unstructured.NestedString(obj.Object, "status", "httpProxy")
+		proxy.HTTPSProxy, _, _ = unstructured.NestedString(obj.Object, "status", "httpsProxy")
+		proxy.NoProxy, _, _ = unstructured.NestedString(obj.Object, "status", "noProxy")
+		proxy.TrustedCA, _, _ = unstructured.NestedString(obj.Object, "spec", "trustedCA", "name")
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		// Non-OpenShift cluster — no cluster-wide proxy.
+	default:
+		return proxy, fmt.Errorf("failed to fetch Proxy CR: %w", err)
+	}
+
+	return proxy, nil
+}
+
+// fipsMachineConfigNames are the MachineConfigs the installer creates from
+// install-config `fips: true`; they carry spec.fips and only exist on FIPS clusters.
+var fipsMachineConfigNames = []string{"99-master-fips", "99-worker-fips"}
+
+// detectFIPS reports whether the cluster was installed in FIPS mode.
+// The install-config fips flag is surfaced by the installer as spec.fips on the
+// 99-{master,worker}-fips MachineConfigs, so we read those instead of the
+// kube-system install-config ConfigMap (which would need broad ConfigMap RBAC).
+func (b *RenderContextBuilder) detectFIPS(ctx context.Context) (bool, error) {
+	for _, name := range fipsMachineConfigNames {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   "machineconfiguration.openshift.io",
+			Version: "v1",
+			Kind:    "MachineConfig",
+		})
+
+		err := b.client.Get(ctx, types.NamespacedName{Name: name}, mc)
+		switch {
+		case err == nil:
+			if fips, _, _ := unstructured.NestedBool(mc.Object, "spec", "fips"); fips {
+				return true, nil
+			}
+		case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+			// Not a FIPS install, or not OpenShift.
+		default:
+			return false, fmt.Errorf("failed to fetch MachineConfig %s: %w", name, err)
+		}
+	}
+
+	return false, nil
+}
+
+// detectUpgrade reads the ClusterVersion and MachineConfigPools to find out whether
+// an OpenShift upgrade or a pool rollout is underway. Missing resources (non-OpenShift
+// cluster) yield an idle UpgradeContext. On error the context gathered so far is returned.
+func (b *RenderContextBuilder) detectUpgrade(ctx context.Context) (*pkgcontext.UpgradeContext, error) {
+	upgrade := &pkgcontext.UpgradeContext{}
+
+	cv := &unstructured.Unstructured{}
+	cv.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterVersion",
+	})
+	err := b.client.Get(ctx, types.NamespacedName{Name: clusterVersionResourceName}, cv)
+	switch {
+	case err == nil:
+		upgrade.ClusterVersionProgressing = hasTrueCondition(cv, "Progressing")
+		upgrade.TargetVersion, _, _ = unstructured.NestedString(cv.Object, "status", "desired", "version")
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		// Not OpenShift.
+	default:
+		return upgrade, fmt.Errorf("failed to fetch ClusterVersion: %w", err)
+	}
+
+	pools := &unstructured.UnstructuredList{}
+	pools.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "machineconfiguration.openshift.io",
+		Version: "v1",
+		Kind:    "MachineConfigPoolList",
+	})
+	err = b.client.List(ctx, pools)
+	switch {
+	case err == nil:
+		for i := range pools.Items {
+			pool := &pools.Items[i]
+			if hasTrueCondition(pool, "Updating") {
+				upgrade.UpdatingPools = append(upgrade.UpdatingPools, pool.GetName())
+			}
+			upgrade.Pools = append(upgrade.Pools, toMachineConfigPool(pool))
+		}
+		sort.Strings(upgrade.UpdatingPools)
+		sort.Slice(upgrade.Pools, func(i, j int) bool { return upgrade.Pools[i].Name < upgrade.Pools[j].Name })
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		// No Machine Config Operator.
+	default:
+		return upgrade, fmt.Errorf("failed to list MachineConfigPools: %w", err)
+	}
+
+	return upgrade, nil
+}
+
+// toMachineConfigPool extracts the selectors, node count and rollout state of a MachineConfigPool.
+// A selector that cannot be decoded is left nil, so the pool matches no change or node.
+func toMachineConfigPool(pool *unstructured.Unstructured) pkgcontext.MachineConfigPool {
+	result := pkgcontext.MachineConfigPool{Name: pool.GetName(), Labels: pool.GetLabels()}
+	if raw, found, _ := unstructured.NestedMap(pool.Object, "spec", "machineConfigSelector"); found {
+		selector := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, selector); err == nil {
+			result.MachineConfigSelector = selector
+		}
+	}
+	if raw, found, _ := unstructured.NestedMap(pool.Object, "spec", "nodeSelector"); found {
+		selector := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, selector); err == nil {
+			result.NodeSelector = selector
+		}
+	}
+	count, _, _ := unstructured.NestedInt64(pool.Object, "status", "machineCount")
+	result.MachineCount = int(count)
+	result.Paused, _, _ = unstructured.NestedBool(pool.Object, "spec", "paused")
+	result.Updated = hasTrueCondition(pool, "Updated")
+	result.Degraded = hasTrueCondition(pool, "Degraded")
+	result.Configuration, _, _ = unstructured.NestedString(pool.Object, "status", "configuration", "name")
+	return result
+}
+
+// mirrorSources are the mirror configuration kinds merged into MirrorContext, with the
+// spec field holding their source/mirrors entries
+var mirrorSources = []struct {
+	gvk   schema.GroupVersionKind
+	field string
+}{
+	{schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ImageDigestMirrorSetList"}, "imageDigestMirrors"},
+	{schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1alpha1", Kind: "ImageContentSourcePolicyList"}, "repositoryDigestMirrors"},
+}
+
+// detectMirrors merges the mirrors of all ImageDigestMirrorSets and ImageContentSourcePolicies.
+// Missing kinds (non-OpenShift cluster, or ICSP removed in newer releases) are skipped.
+// On error the mirrors gathered so far are returned.
+func (b *RenderContextBuilder) detectMirrors(ctx context.Context) (*pkgcontext.MirrorContext, error) {
+	mirrors := &pkgcontext.MirrorContext{}
+
+	for _, source := range mirrorSources {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(source.gvk)
+		err := b.client.List(ctx, list)
+		switch {
+		case err == nil:
+		case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+			continue
+		default:
+			return mirrors, fmt.Errorf("failed to list %s: %w", strings.TrimSuffix(source.gvk.Kind, "List"), err)
+		}
+
+		for i := range list.Items {
+			entries, _, _ := unstructured.NestedSlice(list.Items[i].Object, "spec", source.field)
+			for _, e := range entries {
+				entry, ok := e.(map[string]any)
+				if !ok {
+					continue
+				}
+				src, _, _ := unstructured.NestedString(entry, "source")
+				targets, _, _ := unstructured.NestedStringSlice(entry, "mirrors")
+				if src != "" && len(targets) > 0 {
+					mirrors.AddMirror(src, targets...)
+				}
+			}
+		}
+	}
+
+	return mirrors, nil
+}
+
+const (
+	// defaultStorageClassAnnotation marks the cluster default StorageClass.
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+	// defaultVirtStorageClassAnnotation marks the StorageClass CDI prefers for VM disks;
+	// it takes precedence over the cluster default.
+	defaultVirtStorageClassAnnotation = "storageclass.kubevirt.io/is-default-virt-class"
+
+	// cephCSIDriverSuffix is shared by the Ceph RBD and CephFS CSI drivers, whatever
+	// prefix ODF, Rook or an external Ceph deployment gives them.
+	cephCSIDriverSuffix = ".csi.ceph.com"
+)
+
+// rwxProvisioners are CSI drivers known to support ReadWriteMany, used for StorageClasses
+// without a CDI StorageProfile. Ceph RBD and CephFS are matched by cephCSIDriverSuffix.
+var rwxProvisioners = map[string]bool{
+	"nfs.csi.k8s.io":        true,
+	"csi.trident.netapp.io": true,
+	"pxd.portworx.com":      true,
+}
+
+var (
+	storageClassListGVK        = schema.GroupVersionKind{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClassList"}
+	storageProfileListGVK      = schema.GroupVersionKind{Group: "cdi.kubevirt.io", Version: "v1beta1", Kind: "StorageProfileList"}
+	volumeSnapshotClassListGVK = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotClassList"}
+	odfStorageClusterListGVK   = schema.GroupVersionKind{Group: "ocs.openshift.io", Version: "v1", Kind: "StorageClusterList"}
+)
+
+// detectStorage reads StorageClasses, CDI StorageProfiles, VolumeSnapshotClasses and
+// ODF StorageClusters. A StorageClass counts as RWX-capable when its StorageProfile
+// lists a ReadWriteMany claim property set; without a profile, its provisioner decides.
+// Kinds that are not installed are skipped. On error the context gathered so far is returned.
+func (b *RenderContextBuilder) detectStorage(ctx context.Context) (*pkgcontext.StorageContext, error) {
+	storage := &pkgcontext.StorageContext{}
+
+	classes, err := b.listIfInstalled(ctx, storageClassListGVK)
+	if err != nil {
+		return storage, err
+	}
+	profiles, err := b.listIfInstalled(ctx, storageProfileListGVK)
+	if err != nil {
+		return storage, err
+	}
+	profileRWX := make(map[string]bool, len(profiles))
+	for i := range profiles {
+		profileRWX[profiles[i].GetName()] = storageProfileSupportsRWX(&profiles[i])
+	}
+
+	var clusterDefault, virtDefault string
+	for i := range classes {
+		class := &classes[i]
+		provisioner, _, _ := unstructured.NestedString(class.Object, "provisioner")
+		isCeph := strings.HasSuffix(provisioner, cephCSIDriverSuffix)
+		storage.CephPresent = storage.CephPresent || isCeph
+
+		rwx, hasProfile := profileRWX[class.GetName()]
+		if !hasProfile {
+			rwx = isCeph || rwxProvisioners[provisioner]
+		}
+		if rwx {
+			storage.RWXStorageClasses = append(storage.RWXStorageClasses, class.GetName())
+		}
+
+		annotations := class.GetAnnotations()
+		if annotations[defaultVirtStorageClassAnnotation] == "true" {
+			virtDefault = class.GetName()
+		}
+		if annotations[defaultStorageClassAnnotation] == "true" {
+			clusterDefault = class.GetName()
+		}
+	}
+	sort.Strings(storage.RWXStorageClasses)
+	storage.DefaultStorageClass = clusterDefault
+	if virtDefault != "" {
+		storage.DefaultStorageClass = virtDefault
+	}
+
+	snapshotClasses, err := b.listIfInstalled(ctx, volumeSnapshotClassListGVK)
+	if err != nil {
+		return storage, err
+	}
+	for i := range snapshotClasses {
+		storage.SnapshotClasses = append(storage.SnapshotClasses, snapshotClasses[i].GetName())
+	}
+	sort.Strings(storage.SnapshotClasses)
+
+	clusters, err := b.listIfInstalled(ctx, odfStorageClusterListGVK)
+	if err != nil {
+		return storage, err
+	}
+	storage.ODFPresent = len(clusters) > 0
+
+	return storage, nil
+}
+
+// listIfInstalled lists all objects of a List GVK, returning no items when the kind is not installed
+func (b *RenderContextBuilder) listIfInstalled(ctx context.Context, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	return listIfInstalled(ctx, b.client, gvk)
+}
+
+func listIfInstalled(ctx context.Context, reader client.Reader, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	err := reader.List(ctx, list)
+	switch {
+	case err == nil:
+		return list.Items, nil
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to list %s: %w", strings.TrimSuffix(gvk.Kind, "List"), err)
+	}
+}
+
+// storageProfileSupportsRWX reports whether a CDI StorageProfile advertises ReadWriteMany
+func storageProfileSupportsRWX(profile *unstructured.Unstructured) bool {
+	sets, _, _ := unstructured.NestedSlice(profile.Object, "status", "claimPropertySets")
+	for _, s := range sets {
+		set, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		modes, _, _ := unstructured.NestedStringSlice(set, "accessModes")
+		if slices.Contains(modes, string(corev1.ReadWriteMany)) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// networkResourceName is the singleton Network CR name in both config.openshift.io
+	// and operator.openshift.io.
+	networkResourceName = "cluster"
+
+	// networkAttachmentDefinitionCRD is the Multus CRD; it is only served where Multus is installed.
+	networkAttachmentDefinitionCRD = "network-attachment-definitions.k8s.cni.cncf.io"
+)
+
+var (
+	networkConfigGVK   = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Network"}
+	networkOperatorGVK = schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: "Network"}
+	nmstateListGVK     = schema.GroupVersionKind{Group: "nmstate.io", Version: "v1", Kind: "NMStateList"}
+)
+
+// detectNetwork reads the network type and MTU from network.config/cluster, checks
+// whether Multus is installed and enabled, and whether an NMState instance exists.
+// Missing resources (non-OpenShift cluster, operators not installed) leave the
+// matching fields empty. On error the context gathered so far is returned.
+func (b *RenderContextBuilder) detectNetwork(ctx context.Context) (*pkgcontext.NetworkContext, error) {
+	network := &pkgcontext.NetworkContext{}
+
+	config, err := b.getIfInstalled(ctx, networkConfigGVK, networkResourceName)
+	if err != nil {
+		return network, err
+	}
+	if config != nil {
+		network.NetworkType, _, _ = unstructured.NestedString(config.Object, "status", "networkType")
+		if network.NetworkType == "" {
+			network.NetworkType, _, _ = unstructured.NestedString(config.Object, "spec", "networkType")
+		}
+		network.MTU, _, _ = unstructured.NestedInt64(config.Object, "status", "clusterNetworkMTU")
+		network.IPFamilies = networkIPFamilies(config)
+	}
+
+	// Typed, so the read is served by the CRD informer the controller already runs.
+	err = b.client.Get(ctx, types.NamespacedName{Name: networkAttachmentDefinitionCRD}, &apiextensionsv1.CustomResourceDefinition{})
+	switch {
+	case err == nil:
+		network.MultusPresent = true
+	case apierrors.IsNotFound(err):
+	default:
+		return network, fmt.Errorf("failed to fetch CustomResourceDefinition %s: %w", networkAttachmentDefinitionCRD, err)
+	}
+	if network.MultusPresent {
+		operator, err := b.getIfInstalled(ctx, networkOperatorGVK, networkResourceName)
+		if err != nil {
+			return network, err
+		}
+		if operator != nil {
+			disabled, _, _ := unstructured.NestedBool(operator.Object, "spec", "disableMultiNetwork")
+			network.MultusPresent = !disabled
+		}
+	}
+
+	instances, err := b.listIfInstalled(ctx, nmstateListGVK)
+	if err != nil {
+		return network, err
+	}
+	network.NMStatePresent = len(instances) > 0
+
+	return network, nil
+}
+
+// networkIPFamilies returns the address families of the service and cluster network
+// CIDRs in network.config/cluster, primary first. The service network decides the
+// primary family, as it does for the cluster's own Services.
+func networkIPFamilies(config *unstructured.Unstructured) []string {
+	var cidrs []string
+	for _, section := range []string{"status", "spec"} {
+		serviceNetwork, _, _ := unstructured.NestedStringSlice(config.Object, section, "serviceNetwork")
+		clusterNetwork, _, _ := unstructured.NestedSlice(config.Object, section, "clusterNetwork")
+		cidrs = append(cidrs, serviceNetwork...)
+		for _, entry := range clusterNetwork {
+			if m, ok := entry.(map[string]any); ok {
+				if cidr, ok := m["cidr"].(string); ok {
+					cidrs = append(cidrs, cidr)
+				}
+			}
+		}
+		if len(cidrs) > 0 {
+			break
+		}
+	}
+
+	var families []string
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		family := pkgcontext.IPFamilyIPv4
+		if !prefix.Addr().Is4() {
+			family = pkgcontext.IPFamilyIPv6
+		}
+		if !slices.Contains(families, family) {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
+// getIfInstalled fetches the cluster-scoped object name of kind gvk, returning nil when
+// the object does not exist or the kind is not installed
+func (b *RenderContextBuilder) getIfInstalled(ctx context.Context, gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err := b.client.Get(ctx, types.NamespacedName{Name: name}, obj)
+	switch {
+	case err == nil:
+		return obj, nil
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to fetch %s %s: %w", gvk.Kind, name, err)
+	}
+}
+
+// hasTrueCondition reports whether obj has status.conditions[type=condType] with status "True"
+func hasTrueCondition(obj *unstructured.Unstructured, condType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if cond["type"] == condType {
+			return cond["status"] == "True"
+		}
+	}
+	return false
+}
+
 // hasPCIDevices checks if node has PCI devices suitable for passthrough
 func hasPCIDevices(node *corev1.Node) bool {
 	// Check for common PCI device labels/annotations
@@ -257,7 +849,7 @@ func hasNUMATopology(node *corev1.Node) bool {
 	}
 
 	// Check annotations for topology manager policy
-	if policy, exists := node.Annotations["kubevirt.io/topology-manager-policy"]; exists && policy != "" {
+	if policy, exists := node.Annotations[topologyManagerPolicyAnnotation]; exists && policy != "" {
 		return true
 	}
 
@@ -19,10 +19,12 @@ package controller
 import (
 	"context"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -31,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
 )
 
@@ -422,6 +425,7 @@ func infraCR(cpTopology, platformType string) *unstructured.Unstructured {
 func fakeBuilderWith(objects ...client.Object) *RenderContextBuilder {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
 	return NewRenderContextBuilder(
 		fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
 	)
@@ -578,6 +582,424 @@ func TestDetectTopology_CloudProviders(t *testing.T) {
 	}
 }
 
+func proxyCR(httpProxy, httpsProxy, noProxy, trustedCA string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "config.openshift.io/v1",
+		"kind":       "Proxy",
+		"metadata":   map[string]any{"name": "cluster"},
+		"spec":       map[string]any{},
+		"status": map[string]any{
+			"httpProxy":  httpProxy,
+			"httpsProxy": httpsProxy,
+			"noProxy":    noProxy,
+		},
+	}}
+	if trustedCA != "" {
+		obj.Object["spec"] = map[string]any{"trustedCA": map[string]any{"name": trustedCA}}
+	}
+	return obj
+}
+
+func TestDetectProxy(t *testing.T) {
+	t.Run("proxied cluster with trusted CA", func(t *testing.T) {
+		proxy, err := fakeBuilderWith(proxyCR("http://proxy:3128", "http://proxy:3128", ".cluster.local,10.0.0.0/16", "user-ca-bundle")).
+			detectProxy(context.Background())
+		if err != nil {
+			t.Fatalf("detectProxy() error = %v", err)
+		}
+		if !proxy.Enabled() {
+			t.Error("expected proxy to be enabled")
+		}
+		if proxy.HTTPSProxy != "http://proxy:3128" || proxy.NoProxy != ".cluster.local,10.0.0.0/16" {
+			t.Errorf("unexpected proxy settings: %+v", proxy)
+		}
+		if !proxy.HasTrustedCA() || proxy.TrustedCA != "user-ca-bundle" {
+			t.Errorf("TrustedCA = %q, want user-ca-bundle", proxy.TrustedCA)
+		}
+	})
+
+	t.Run("Proxy CR without proxy settings", func(t *testing.T) {
+		proxy, err := fakeBuilderWith(proxyCR("", "", "", "")).detectProxy(context.Background())
+		if err != nil {
+			t.Fatalf("detectProxy() error = %v", err)
+		}
+		if proxy.Enabled() || proxy.HasTrustedCA() {
+			t.Errorf("expected no proxy, got %+v", proxy)
+		}
+	})
+
+	t.Run("non-OpenShift cluster", func(t *testing.T) {
+		proxy, err := fakeBuilderWith().detectProxy(context.Background())
+		if err != nil {
+			t.Fatalf("detectProxy() error = %v", err)
+		}
+		if proxy == nil || proxy.Enabled() {
+			t.Errorf("expected empty ProxyContext, got %+v", proxy)
+		}
+	})
+}
+
+func fipsMachineConfig(name string, fips bool) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "MachineConfig",
+		"metadata":   map[string]any{"name": name},
+		"spec":       map[string]any{"fips": fips},
+	}}
+}
+
+func TestDetectFIPS(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    bool
+	}{
+		{"no FIPS MachineConfigs", nil, false},
+		{"worker FIPS MachineConfig", []client.Object{fipsMachineConfig("99-worker-fips", true)}, true},
+		{"master FIPS MachineConfig", []client.Object{fipsMachineConfig("99-master-fips", true)}, true},
+		{"FIPS MachineConfig with fips disabled", []client.Object{fipsMachineConfig("99-worker-fips", false)}, false},
+		{"unrelated MachineConfig with fips", []client.Object{fipsMachineConfig("50-custom", true)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fakeBuilderWith(tt.objects...).detectFIPS(context.Background())
+			if err != nil {
+				t.Fatalf("detectFIPS() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("detectFIPS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func clusterVersion(progressing, desired string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "config.openshift.io/v1",
+		"kind":       "ClusterVersion",
+		"metadata":   map[string]any{"name": "version"},
+		"status": map[string]any{
+			"desired": map[string]any{"version": desired},
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "True"},
+				map[string]any{"type": "Progressing", "status": progressing},
+			},
+		},
+	}}
+}
+
+func machineConfigPool(name, updating string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "MachineConfigPool",
+		"metadata":   map[string]any{"name": name},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Updating", "status": updating},
+			},
+		},
+	}}
+}
+
+func TestDetectUpgrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		objects         []client.Object
+		wantProgressing bool
+		wantTarget      string
+		wantPools       []string
+	}{
+		{"non-OpenShift cluster", nil, false, "", nil},
+		{"stable cluster", []client.Object{
+			clusterVersion("False", "4.18.3"),
+			machineConfigPool("master", "False"),
+			machineConfigPool("worker", "False"),
+		}, false, "4.18.3", nil},
+		{"cluster upgrade in progress", []client.Object{
+			clusterVersion("True", "4.19.0"),
+		}, true, "4.19.0", nil},
+		{"pools rolling out", []client.Object{
+			clusterVersion("False", "4.18.3"),
+			machineConfigPool("worker", "True"),
+			machineConfigPool("master", "False"),
+			machineConfigPool("infra", "True"),
+		}, false, "4.18.3", []string{"infra", "worker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fakeBuilderWith(tt.objects...).detectUpgrade(context.Background())
+			if err != nil {
+				t.Fatalf("detectUpgrade() error = %v", err)
+			}
+			if got.ClusterVersionProgressing != tt.wantProgressing {
+				t.Errorf("ClusterVersionProgressing = %v, want %v", got.ClusterVersionProgressing, tt.wantProgressing)
+			}
+			if got.TargetVersion != tt.wantTarget {
+				t.Errorf("TargetVersion = %q, want %q", got.TargetVersion, tt.wantTarget)
+			}
+			if !reflect.DeepEqual(got.UpdatingPools, tt.wantPools) {
+				t.Errorf("UpdatingPools = %v, want %v", got.UpdatingPools, tt.wantPools)
+			}
+			wantInProgress := tt.wantProgressing || len(tt.wantPools) > 0
+			if got.InProgress() != wantInProgress {
+				t.Errorf("InProgress() = %v, want %v", got.InProgress(), wantInProgress)
+			}
+		})
+	}
+}
+
+func TestDetectUpgradePools(t *testing.T) {
+	worker := machineConfigPool("worker", "False")
+	worker.SetLabels(map[string]string{"pools.operator.machineconfiguration.openshift.io/worker": ""})
+	worker.Object["spec"] = map[string]any{
+		"machineConfigSelector": map[string]any{
+			"matchLabels": map[string]any{"machineconfiguration.openshift.io/role": "worker"},
+		},
+		"nodeSelector": map[string]any{
+			"matchLabels": map[string]any{"node-role.kubernetes.io/worker": ""},
+		},
+	}
+	_ = unstructured.SetNestedField(worker.Object, int64(12), "status", "machineCount")
+	_ = unstructured.SetNestedField(worker.Object, true, "spec", "paused")
+	_ = unstructured.SetNestedField(worker.Object, "rendered-worker-1", "status", "configuration", "name")
+	_ = unstructured.SetNestedSlice(worker.Object, []any{
+		map[string]any{"type": "Updating", "status": "False"},
+		map[string]any{"type": "Updated", "status": "True"},
+		map[string]any{"type": "Degraded", "status": "False"},
+	}, "status", "conditions")
+
+	got, err := fakeBuilderWith(worker, machineConfigPool("master", "False")).detectUpgrade(context.Background())
+	if err != nil {
+		t.Fatalf("detectUpgrade() error = %v", err)
+	}
+	if len(got.Pools) != 2 || got.Pools[0].Name != "master" || got.Pools[1].Name != "worker" {
+		t.Fatalf("Pools = %+v, want master and worker sorted by name", got.Pools)
+	}
+	if got.Pools[0].MachineConfigSelector != nil {
+		t.Errorf("master MachineConfigSelector = %v, want nil without a selector", got.Pools[0].MachineConfigSelector)
+	}
+	pool := got.Pools[1]
+	if pool.MachineCount != 12 {
+		t.Errorf("worker MachineCount = %d, want 12", pool.MachineCount)
+	}
+	if pool.MachineConfigSelector == nil || pool.MachineConfigSelector.MatchLabels["machineconfiguration.openshift.io/role"] != "worker" {
+		t.Errorf("worker MachineConfigSelector = %v, want the role selector", pool.MachineConfigSelector)
+	}
+	if pool.NodeSelector == nil || len(pool.NodeSelector.MatchLabels) != 1 {
+		t.Errorf("worker NodeSelector = %v, want the worker role selector", pool.NodeSelector)
+	}
+	if _, ok := pool.Labels["pools.operator.machineconfiguration.openshift.io/worker"]; !ok {
+		t.Errorf("worker Labels = %v, want the pool label", pool.Labels)
+	}
+	if !pool.Paused || !pool.Updated || pool.Degraded || pool.Configuration != "rendered-worker-1" {
+		t.Errorf("worker rollout state = paused %v, updated %v, degraded %v, configuration %q; want paused, updated, not degraded, rendered-worker-1",
+			pool.Paused, pool.Updated, pool.Degraded, pool.Configuration)
+	}
+}
+
+func mirrorObject(apiVersion, kind, name, field string, entries ...map[string]any) *unstructured.Unstructured {
+	list := make([]any, len(entries))
+	for i := range entries {
+		list[i] = entries[i]
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name},
+		"spec":       map[string]any{field: list},
+	}}
+}
+
+func TestDetectMirrors(t *testing.T) {
+	idms := mirrorObject("config.openshift.io/v1", "ImageDigestMirrorSet", "cnv", "imageDigestMirrors",
+		map[string]any{"source": "quay.io/kubevirt", "mirrors": []any{"mirror.example.com:5000/kubevirt"}},
+		map[string]any{"source": "registry.redhat.io", "mirrors": []any{"mirror.example.com:5000/redhat"}},
+	)
+	icsp := mirrorObject("operator.openshift.io/v1alpha1", "ImageContentSourcePolicy", "legacy", "repositoryDigestMirrors",
+		map[string]any{"source": "quay.io/kubevirt", "mirrors": []any{"backup.example.com/kubevirt", "mirror.example.com:5000/kubevirt"}},
+		map[string]any{"source": "quay.io/kubevirt/metrics-exporter", "mirrors": []any{"mirror.example.com:5000/exporter"}},
+	)
+
+	mirrors, err := fakeBuilderWith(idms, icsp).detectMirrors(context.Background())
+	if err != nil {
+		t.Fatalf("detectMirrors() error = %v", err)
+	}
+	want := []pkgcontext.ImageMirror{
+		{Source: "quay.io/kubevirt/metrics-exporter", Mirrors: []string{"mirror.example.com:5000/exporter"}},
+		{Source: "registry.redhat.io", Mirrors: []string{"mirror.example.com:5000/redhat"}},
+		{Source: "quay.io/kubevirt", Mirrors: []string{"mirror.example.com:5000/kubevirt", "backup.example.com/kubevirt"}},
+	}
+	if !reflect.DeepEqual(mirrors.Mirrors, want) {
+		t.Errorf("Mirrors = %+v, want %+v", mirrors.Mirrors, want)
+	}
+
+	t.Run("non-OpenShift cluster", func(t *testing.T) {
+		mirrors, err := fakeBuilderWith().detectMirrors(context.Background())
+		if err != nil {
+			t.Fatalf("detectMirrors() error = %v", err)
+		}
+		if mirrors == nil || mirrors.Enabled() {
+			t.Errorf("expected empty MirrorContext, got %+v", mirrors)
+		}
+	})
+}
+
+func clusterObject(apiVersion, kind, name string, fields map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	if obj.Object == nil {
+		obj.Object = map[string]any{}
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return obj
+}
+
+func TestDetectStorage(t *testing.T) {
+	cephRBD := clusterObject("storage.k8s.io/v1", "StorageClass", "ocs-storagecluster-ceph-rbd",
+		map[string]any{"provisioner": "openshift-storage.rbd.csi.ceph.com"})
+	cephRBD.SetAnnotations(map[string]string{defaultVirtStorageClassAnnotation: "true"})
+	lvms := clusterObject("storage.k8s.io/v1", "StorageClass", "lvms-vg1",
+		map[string]any{"provisioner": "topolvm.io"})
+	lvms.SetAnnotations(map[string]string{defaultStorageClassAnnotation: "true"})
+	nfs := clusterObject("storage.k8s.io/v1", "StorageClass", "nfs",
+		map[string]any{"provisioner": "nfs.csi.k8s.io"})
+	// The profile overrides the provisioner guess: this NFS export is RWO only
+	nfsProfile := clusterObject("cdi.kubevirt.io/v1beta1", "StorageProfile", "nfs", map[string]any{
+		"status": map[string]any{"claimPropertySets": []any{
+			map[string]any{"accessModes": []any{"ReadWriteOnce"}, "volumeMode": "Filesystem"},
+		}},
+	})
+	hostpath := clusterObject("storage.k8s.io/v1", "StorageClass", "hostpath-csi",
+		map[string]any{"provisioner": "kubevirt.io.hostpath-provisioner"})
+	hostpathProfile := clusterObject("cdi.kubevirt.io/v1beta1", "StorageProfile", "hostpath-csi", map[string]any{
+		"status": map[string]any{"claimPropertySets": []any{
+			map[string]any{"accessModes": []any{"ReadWriteMany"}, "volumeMode": "Block"},
+		}},
+	})
+	snapshotClass := clusterObject("snapshot.storage.k8s.io/v1", "VolumeSnapshotClass", "ocs-storagecluster-rbdplugin-snapclass", nil)
+	storageCluster := clusterObject("ocs.openshift.io/v1", "StorageCluster", "ocs-storagecluster", nil)
+	storageCluster.SetNamespace("openshift-storage")
+
+	storage, err := fakeBuilderWith(cephRBD, lvms, nfs, nfsProfile, hostpath, hostpathProfile, snapshotClass, storageCluster).
+		detectStorage(context.Background())
+	if err != nil {
+		t.Fatalf("detectStorage() error = %v", err)
+	}
+	want := &pkgcontext.StorageContext{
+		RWXStorageClasses:   []string{"hostpath-csi", "ocs-storagecluster-ceph-rbd"},
+		DefaultStorageClass: "ocs-storagecluster-ceph-rbd",
+		ODFPresent:          true,
+		CephPresent:         true,
+		SnapshotClasses:     []string{"ocs-storagecluster-rbdplugin-snapclass"},
+	}
+	if !reflect.DeepEqual(storage, want) {
+		t.Errorf("detectStorage() = %+v, want %+v", storage, want)
+	}
+	if !storage.DefaultSupportsRWX() {
+		t.Error("DefaultSupportsRWX() = false for a Ceph RBD virt default class")
+	}
+
+	t.Run("no storage", func(t *testing.T) {
+		storage, err := fakeBuilderWith(lvms).detectStorage(context.Background())
+		if err != nil {
+			t.Fatalf("detectStorage() error = %v", err)
+		}
+		for detector, detected := range storage.AsMap() {
+			if detected {
+				t.Errorf("detector %s = true on a cluster with only an RWO StorageClass", detector)
+			}
+		}
+		if storage.DefaultStorageClass != "lvms-vg1" {
+			t.Errorf("DefaultStorageClass = %q, want lvms-vg1", storage.DefaultStorageClass)
+		}
+	})
+}
+
+func TestDetectNetwork(t *testing.T) {
+	networkConfig := clusterObject("config.openshift.io/v1", "Network", "cluster", map[string]any{
+		"spec":   map[string]any{"networkType": "OVNKubernetes"},
+		"status": map[string]any{"networkType": "OVNKubernetes", "clusterNetworkMTU": int64(8901)},
+	})
+	nadCRD := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: networkAttachmentDefinitionCRD},
+	}
+	multusDisabled := clusterObject("operator.openshift.io/v1", "Network", "cluster", map[string]any{
+		"spec": map[string]any{"disableMultiNetwork": true},
+	})
+	nmstate := clusterObject("nmstate.io/v1", "NMState", "nmstate", nil)
+	dualStack := clusterObject("config.openshift.io/v1", "Network", "cluster", map[string]any{
+		"status": map[string]any{
+			"networkType":    "OVNKubernetes",
+			"serviceNetwork": []any{"fd02::/112", "172.30.0.0/16"},
+			"clusterNetwork": []any{
+				map[string]any{"cidr": "10.128.0.0/14", "hostPrefix": int64(23)},
+				map[string]any{"cidr": "fd01::/48", "hostPrefix": int64(64)},
+			},
+		},
+	})
+	ipv6Spec := clusterObject("config.openshift.io/v1", "Network", "cluster", map[string]any{
+		"spec": map[string]any{
+			"networkType":    "OVNKubernetes",
+			"serviceNetwork": []any{"fd02::/112"},
+			"clusterNetwork": []any{map[string]any{"cidr": "fd01::/48"}},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    *pkgcontext.NetworkContext
+	}{
+		{"non-OpenShift without Multus", nil, &pkgcontext.NetworkContext{}},
+		{
+			name:    "OVN-Kubernetes with Multus and NMState",
+			objects: []client.Object{networkConfig, nadCRD, nmstate},
+			want: &pkgcontext.NetworkContext{
+				NetworkType:    "OVNKubernetes",
+				MTU:            8901,
+				MultusPresent:  true,
+				NMStatePresent: true,
+			},
+		},
+		{
+			name:    "Multus disabled by the Cluster Network Operator",
+			objects: []client.Object{networkConfig, nadCRD, multusDisabled},
+			want:    &pkgcontext.NetworkContext{NetworkType: "OVNKubernetes", MTU: 8901},
+		},
+		{
+			name:    "dual-stack with IPv6 primary",
+			objects: []client.Object{dualStack},
+			want: &pkgcontext.NetworkContext{
+				NetworkType: "OVNKubernetes",
+				IPFamilies:  []string{pkgcontext.IPFamilyIPv6, pkgcontext.IPFamilyIPv4},
+			},
+		},
+		{
+			name:    "IPv6-only from spec before the status is populated",
+			objects: []client.Object{ipv6Spec},
+			want: &pkgcontext.NetworkContext{
+				NetworkType: "OVNKubernetes",
+				IPFamilies:  []string{pkgcontext.IPFamilyIPv6},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := fakeBuilderWith(tt.objects...).detectNetwork(context.Background())
+			if err != nil {
+				t.Fatalf("detectNetwork() error = %v", err)
+			}
+			if !reflect.DeepEqual(network, tt.want) {
+				t.Errorf("detectNetwork() = %+v, want %+v", network, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewRenderContextBuilder(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -683,6 +1105,36 @@ func TestRenderContextBuilder_Build(t *testing.T) {
 		}
 	})
 
+	t.Run("loads patches from the overrides ConfigMap through the API reader", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = corev1.AddToScheme(scheme)
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "autopilot-overrides", Namespace: "test-namespace"},
+			Data: map[string]string{
+				"metrics-service": `{"metadata": {"labels": {"team": "virt"}}}`,
+				"metrics-service" + overrides.PatchTypeKeySuffix: overrides.PatchTypeMerge,
+				"broken": `[{"op": "nope"}]`,
+			},
+		}
+
+		// The cached client does not hold the unlabeled ConfigMap; only the API reader does
+		builder := NewRenderContextBuilder(fake.NewClientBuilder().WithScheme(scheme).Build())
+		builder.SetAPIReader(fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build())
+
+		hco := &unstructured.Unstructured{}
+		hco.SetName("test-hco")
+		hco.SetNamespace("test-namespace")
+		hco.SetAnnotations(map[string]string{overrides.AnnotationOverridesConfigMap: "autopilot-overrides"})
+
+		renderCtx, err := builder.Build(ctx, hco)
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if len(renderCtx.OverridePatches) != 1 || renderCtx.OverridePatches["metrics-service"].Type != overrides.PatchTypeMerge {
+			t.Errorf("OverridePatches = %v, want the valid metrics-service entry only", renderCtx.OverridePatches)
+		}
+	})
+
 	t.Run("returns error when HCO is nil", func(t *testing.T) {
 		scheme := runtime.NewScheme()
 		_ = corev1.AddToScheme(scheme)
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+// AssetInclusion is the reconcile decision for one asset
+type AssetInclusion struct {
+	Asset     string `json:"asset" yaml:"asset"`
+	Component string `json:"component" yaml:"component"`
+	Included  bool   `json:"included" yaml:"included"`
+	// Reason says why an asset is excluded, naming the first gate that failed
+	Reason engine.Reason `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Message details the reason, e.g. the missing CRD or the unmet condition
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// EvaluateInclusion builds the render context for hco from c and decides, asset by
+// asset, whether Reconcile would apply it: activation gate, disabled phases, allowlist,
+// CRD availability and conditions, in the same order. Nothing is rendered or applied.
+func EvaluateInclusion(
+	ctx context.Context,
+	c client.Client,
+	registry *assets.Registry,
+	hco *unstructured.Unstructured,
+) (*pkgcontext.RenderContext, []AssetInclusion, error) {
+	renderCtx, err := NewRenderContextBuilder(c).Build(ctx, hco)
+	if err != nil {
+		return nil, nil, err
+	}
+	inclusions, err := EvaluateConditions(ctx, c, registry, hco, renderCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return renderCtx, inclusions, nil
+}
+
+// EvaluateConditions is EvaluateInclusion with the render context already built, so
+// callers timing condition evaluation do not measure detection with it
+func EvaluateConditions(
+	ctx context.Context,
+	c client.Client,
+	registry *assets.Registry,
+	hco *unstructured.Unstructured,
+	renderCtx *pkgcontext.RenderContext,
+) ([]AssetInclusion, error) {
+	allowlist, enabled := overrides.ParseAutopilotScope(hco)
+	// A malformed disabled-phases annotation disables nothing, as in Reconcile
+	phases, _ := disabledPhases(hco)
+	crdChecker := util.NewCRDChecker(c)
+	allAssets := registry.ListAssetsByReconcileOrder()
+
+	cluster := newClusterConditions(c, crdChecker, DefaultConditionEvaluation())
+	if enabled {
+		cluster.prefetch(ctx, allAssets)
+	}
+	evaluator := newConditionEvaluator(hco, renderCtx)
+	evaluator.Cluster = cluster
+
+	inclusions := make([]AssetInclusion, 0, len(allAssets))
+	for i := range allAssets {
+		asset := &allAssets[i]
+		reason, message, err := exclusionReason(ctx, asset, enabled, phases, allowlist, crdChecker, evaluator)
+		if err != nil {
+			return nil, err
+		}
+		inclusions = append(inclusions, AssetInclusion{
+			Asset:     asset.Name,
+			Component: asset.Component,
+			Included:  reason == "",
+			Reason:    reason,
+			Message:   message,
+		})
+	}
+	return inclusions, nil
+}
+
+// exclusionReason returns why asset would be skipped, with a message naming the gate,
+// or "" when it would be applied
+func exclusionReason(
+	ctx context.Context,
+	asset *assets.AssetMetadata,
+	enabled bool,
+	phases map[int]bool,
+	allowlist map[string]bool,
+	crdChecker *util.CRDChecker,
+	evaluator assets.ConditionEvaluator,
+) (engine.Reason, string, error) {
+	if !enabled {
+		return engine.ReasonAutopilotDisabled, fmt.Sprintf("autopilot not enabled (%s)", overrides.AnnotationAutopilotEnabled), nil
+	}
+	if phases[asset.Phase] {
+		return engine.ReasonPhaseDisabled, fmt.Sprintf("phase %d disabled (%s)", asset.Phase, DisabledPhasesAnnotation), nil
+	}
+	if !isInAllowlist(asset, allowlist) {
+		return engine.ReasonNotInAllowlist, "not in asset allowlist", nil
+	}
+
+	for _, crd := range []string{asset.RequiredCRD, asset.GateCRD} {
+		if crd == "" {
+			continue
+		}
+		installed, err := crdChecker.IsCRDInstalled(ctx, crd)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to check CRD %s: %w", crd, err)
+		}
+		if !installed {
+			return engine.ReasonCRDMissing, fmt.Sprintf("CRD %s not installed", crd), nil
+		}
+	}
+
+	if asset.Install == assets.InstallModeOptIn && len(asset.Conditions) == 0 {
+		return engine.ReasonConditionsNotMet, "opt-in asset without conditions", nil
+	}
+	for _, condition := range asset.Conditions {
+		satisfied, err := evaluator.EvaluateCondition(ctx, condition)
+		if err != nil {
+			return "", "", &engine.ConditionError{Asset: asset.Name, Err: fmt.Errorf("condition %s: %w", condition, err)}
+		}
+		if !satisfied {
+			return engine.ReasonConditionsNotMet, fmt.Sprintf("condition not met: %s", condition), nil
+		}
+	}
+	return "", "", nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+func TestEvaluateInclusion(t *testing.T) {
+	registry, err := assets.NewRegistry(assets.NewLoader())
+	if err != nil {
+		t.Fatalf("failed to load registry: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "machineconfigs.machineconfiguration.openshift.io"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+
+	evaluate := func(annotations map[string]string) map[string]AssetInclusion {
+		t.Helper()
+		hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+		hco.SetAnnotations(annotations)
+		_, inclusions, err := EvaluateInclusion(context.Background(), c, registry, hco)
+		if err != nil {
+			t.Fatalf("EvaluateInclusion() error = %v", err)
+		}
+		byName := make(map[string]AssetInclusion, len(inclusions))
+		for _, inclusion := range inclusions {
+			byName[inclusion.Asset] = inclusion
+		}
+		return byName
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		asset        string
+		wantIncluded bool
+		wantReason   engine.Reason
+		wantMessage  string
+	}{
+		{"not activated", nil, "swap-enable", false,
+			engine.ReasonAutopilotDisabled, "autopilot not enabled (platform.kubevirt.io/autopilot)"},
+		{"activated", map[string]string{overrides.AnnotationAutopilotEnabled: "true"}, "swap-enable", true, "", ""},
+		{"phase disabled", map[string]string{
+			overrides.AnnotationAutopilotEnabled: "true",
+			DisabledPhasesAnnotation:             "1",
+		}, "swap-enable", false, engine.ReasonPhaseDisabled, "phase 1 disabled (platform.kubevirt.io/disabled-phases)"},
+		{"other phase disabled", map[string]string{
+			overrides.AnnotationAutopilotEnabled: "true",
+			DisabledPhasesAnnotation:             "2, 3",
+		}, "swap-enable", true, "", ""},
+		{"outside the allowlist", map[string]string{overrides.AnnotationAutopilotEnabled: "metrics-service"}, "swap-enable", false,
+			engine.ReasonNotInAllowlist, "not in asset allowlist"},
+		{"CRD missing", map[string]string{overrides.AnnotationAutopilotEnabled: "true"}, "kubelet-perf-settings", false,
+			engine.ReasonCRDMissing, "CRD kubeletconfigs.machineconfiguration.openshift.io not installed"},
+		{"no hardware detected", map[string]string{
+			overrides.AnnotationAutopilotEnabled: "true",
+			"platform.kubevirt.io/openshift":     "true",
+		}, "pci-passthrough", false, engine.ReasonConditionsNotMet, "condition not met: hardware-detection(pciDevicesPresent)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := evaluate(tt.annotations)[tt.asset]
+			if !ok {
+				t.Fatalf("asset %s missing from results", tt.asset)
+			}
+			if got.Included != tt.wantIncluded || got.Reason != tt.wantReason || got.Message != tt.wantMessage {
+				t.Errorf("%s = (included=%v, reason=%q, message=%q), want (%v, %q, %q)",
+					tt.asset, got.Included, got.Reason, got.Message, tt.wantIncluded, tt.wantReason, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+// LabelRepairMode selects what the label repair pass does with an object the autopilot
+// applied but whose managed-by label was removed
+type LabelRepairMode string
+
+const (
+	// LabelRepairRelabel restores the label
+	LabelRepairRelabel LabelRepairMode = "relabel"
+	// LabelRepairFlag only reports the object through an event and a metric
+	LabelRepairFlag LabelRepairMode = "flag"
+)
+
+// Validate rejects unknown modes
+func (m LabelRepairMode) Validate() error {
+	switch m {
+	case LabelRepairRelabel, LabelRepairFlag:
+		return nil
+	}
+	return fmt.Errorf("unknown label repair mode %q, expected %s or %s", m, LabelRepairRelabel, LabelRepairFlag)
+}
+
+// labelRepairer periodically looks for objects the autopilot applied (per their
+// managedFields) that lack the managed-by label. Such objects drop out of the filtered
+// informer cache, so their drift goes unnoticed, and tombstones refuse to delete them.
+//
+// Objects of the watched managed types are relabeled or flagged depending on the mode.
+// Tombstoned objects are always only flagged: restoring their label would make the next
+// reconcile delete a resource someone deliberately unlabeled to keep.
+type labelRepairer struct {
+	reader     client.Reader // uncached: unlabeled objects are invisible to the cache
+	writer     client.Client
+	types      []schema.GroupVersionKind
+	tombstones []assets.TombstoneMetadata
+	mode       LabelRepairMode
+	interval   time.Duration
+	recorder   *util.EventRecorder
+}
+
+func newLabelRepairer(reader client.Reader, writer client.Client, types []schema.GroupVersionKind,
+	tombstones []assets.TombstoneMetadata, mode LabelRepairMode, interval time.Duration, recorder *util.EventRecorder) *labelRepairer {
+	return &labelRepairer{
+		reader:     reader,
+		writer:     writer,
+		types:      types,
+		tombstones: tombstones,
+		mode:       mode,
+		interval:   interval,
+		recorder:   recorder,
+	}
+}
+
+// Start implements manager.Runnable: it repairs immediately and then every interval
+func (l *labelRepairer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		l.repair(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Relabeling writes to the cluster, so only the leader repairs.
+func (l *labelRepairer) NeedLeaderElection() bool {
+	return true
+}
+
+// repair runs one pass and updates the unlabeled objects metric
+func (l *labelRepairer) repair(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("label-repair")
+	unrepaired := make(map[string]int)
+
+	// Tombstoned objects are handled below, whatever their type
+	tombstoned := make(map[string]bool, len(l.tombstones))
+	for _, ts := range l.tombstones {
+		tombstoned[tombstoneKey(ts.GVK, ts.Namespace, ts.Name)] = true
+	}
+
+	for _, gvk := range l.types {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := l.reader.List(ctx, list); err != nil {
+			logger.Error(err, "Failed to list objects", "gvk", gvk.String())
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			obj.SetGroupVersionKind(gvk)
+			if !needsLabelRepair(obj) || tombstoned[tombstoneKey(gvk, obj.GetNamespace(), obj.GetName())] {
+				continue
+			}
+			if l.mode == LabelRepairFlag {
+				l.flag(ctx, obj, fmt.Sprintf("label repair mode is %s", LabelRepairFlag))
+				unrepaired[gvk.Kind]++
+				continue
+			}
+			if err := l.relabel(ctx, obj); err != nil {
+				logger.Error(err, "Failed to restore managed-by label",
+					"kind", gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+				unrepaired[gvk.Kind]++
+			}
+		}
+	}
+
+	for _, ts := range l.tombstones {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(ts.GVK)
+		err := l.reader.Get(ctx, types.NamespacedName{Namespace: ts.Namespace, Name: ts.Name}, obj)
+		if err != nil {
+			if !errors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+				logger.Error(err, "Failed to get tombstoned object", "kind", ts.GVK.Kind, "name", ts.Name)
+			}
+			continue
+		}
+		if needsLabelRepair(obj) {
+			l.flag(ctx, obj, "it is tombstoned and will not be deleted; restore the label to let it be cleaned up")
+			unrepaired[ts.GVK.Kind]++
+		}
+	}
+
+	observability.SetUnlabeledObjects(unrepaired)
+}
+
+func tombstoneKey(gvk schema.GroupVersionKind, namespace, name string) string {
+	return gvk.GroupKind().String() + "/" + namespace + "/" + name
+}
+
+// needsLabelRepair reports whether obj was applied by the autopilot but lost its label
+func needsLabelRepair(obj *metav1.PartialObjectMetadata) bool {
+	return obj.GetLabels()[engine.ManagedByLabel] != engine.ManagedByValue && engine.AppliedByAutopilot(obj)
+}
+
+// relabel restores the managed-by label with a merge patch. Server-side apply is not
+// used here: an apply of only the label would drop the autopilot's ownership of
+// every other field of the object.
+func (l *labelRepairer) relabel(ctx context.Context, obj *metav1.PartialObjectMetadata) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{engine.ManagedByLabel: engine.ManagedByValue},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	// The patch response overwrites obj, so keep its kind for reporting
+	gvk := obj.GroupVersionKind()
+	if err := l.writer.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch), client.FieldOwner(engine.FieldManager)); err != nil {
+		return err
+	}
+	obj.SetGroupVersionKind(gvk)
+
+	log.FromContext(ctx).Info("Restored managed-by label",
+		"kind", gvk.Kind,
+		"namespace", obj.GetNamespace(),
+		"name", obj.GetName(),
+	)
+	observability.IncLabelRepair(gvk.Kind)
+	if l.recorder != nil {
+		l.recorder.ObjectLabelRepaired(obj, engine.ManagedByLabel)
+	}
+	return nil
+}
+
+// flag reports an unlabeled object that is left as is
+func (l *labelRepairer) flag(ctx context.Context, obj *metav1.PartialObjectMetadata, reason string) {
+	log.FromContext(ctx).Info("Object applied by the autopilot lacks the managed-by label",
+		"kind", obj.GroupVersionKind().Kind,
+		"namespace", obj.GetNamespace(),
+		"name", obj.GetName(),
+		"reason", reason,
+	)
+	if l.recorder != nil {
+		l.recorder.ObjectLabelMissing(obj, engine.ManagedByLabel, reason)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func newLabelRepairConfigMap(name, manager string, operation metav1.ManagedFieldsOperationType, labeled bool) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "openshift-cnv",
+		ManagedFields: []metav1.ManagedFieldsEntry{{
+			Manager:    manager,
+			Operation:  operation,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)},
+		}},
+	}}
+	if labeled {
+		cm.Labels = map[string]string{engine.ManagedByLabel: engine.ManagedByValue}
+	}
+	return cm
+}
+
+func TestLabelRepair(t *testing.T) {
+	configMapGVK := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	tombstones := []assets.TombstoneMetadata{{GVK: configMapGVK, Namespace: "openshift-cnv", Name: "retired"}}
+
+	tests := []struct {
+		name          string
+		mode          LabelRepairMode
+		wantRelabeled []string
+		wantRepairs   float64
+		wantFlagged   float64
+	}{
+		// "retired" is tombstoned, so it is only flagged in either mode
+		{name: "relabel", mode: LabelRepairRelabel, wantRelabeled: []string{"stripped"}, wantRepairs: 1, wantFlagged: 1},
+		{name: "flag", mode: LabelRepairFlag, wantFlagged: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observability.LabelRepairsTotal.Reset()
+
+			fakeClient := fake.NewClientBuilder().WithObjects(
+				newLabelRepairConfigMap("stripped", engine.FieldManager, metav1.ManagedFieldsOperationApply, false),
+				newLabelRepairConfigMap("labeled", engine.FieldManager, metav1.ManagedFieldsOperationApply, true),
+				newLabelRepairConfigMap("foreign", "kubectl-client-side-apply", metav1.ManagedFieldsOperationUpdate, false),
+				newLabelRepairConfigMap("retired", engine.FieldManager, metav1.ManagedFieldsOperationApply, false),
+			).WithReturnManagedFields().Build()
+
+			repairer := newLabelRepairer(fakeClient, fakeClient, []schema.GroupVersionKind{configMapGVK},
+				tombstones, tt.mode, time.Minute, nil)
+			repairer.repair(context.Background())
+
+			for _, name := range []string{"stripped", "labeled", "foreign", "retired"} {
+				cm := &corev1.ConfigMap{}
+				if err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "openshift-cnv", Name: name}, cm); err != nil {
+					t.Fatalf("failed to get %s: %v", name, err)
+				}
+				want := name == "labeled"
+				for _, relabeled := range tt.wantRelabeled {
+					want = want || name == relabeled
+				}
+				if got := cm.Labels[engine.ManagedByLabel] == engine.ManagedByValue; got != want {
+					t.Errorf("%s labeled = %v, want %v", name, got, want)
+				}
+			}
+
+			if val := testutil.ToFloat64(observability.LabelRepairsTotal.WithLabelValues("ConfigMap")); val != tt.wantRepairs {
+				t.Errorf("label_repairs_total{kind=ConfigMap} = %v, want %v", val, tt.wantRepairs)
+			}
+			if val := testutil.ToFloat64(observability.UnlabeledObjects.WithLabelValues("ConfigMap")); val != tt.wantFlagged {
+				t.Errorf("unlabeled_objects{kind=ConfigMap} = %v, want %v", val, tt.wantFlagged)
+			}
+		})
+	}
+}
+
+func TestLabelRepairModeValidate(t *testing.T) {
+	for _, mode := range []LabelRepairMode{LabelRepairRelabel, LabelRepairFlag} {
+		if err := mode.Validate(); err != nil {
+			t.Errorf("Validate(%q) error = %v", mode, err)
+		}
+	}
+	if err := LabelRepairMode("delete").Validate(); err == nil {
+		t.Error("Validate(\"delete\") succeeded, want an error")
+	}
+}
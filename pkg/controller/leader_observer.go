@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+const (
+	// DefaultLeaderObserveInterval is how often every replica reads the leader election lease
+	DefaultLeaderObserveInterval = 15 * time.Second
+
+	// inClusterNamespaceFile holds the pod's namespace, where controller-runtime puts the
+	// lease unless told otherwise
+	inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Leader election roles of a replica
+const (
+	RoleLeader  = "leader"
+	RoleStandby = "standby"
+)
+
+// LeaderStatus is what a replica observed of the leader election of its controller
+type LeaderStatus struct {
+	// Identity is this replica's hostname, the prefix of its lease holder identity
+	Identity string `json:"identity"`
+	// Role is leader or standby
+	Role string `json:"role"`
+	// Lease is the namespace/name of the leader election lease
+	Lease string `json:"lease"`
+	// Holder is the holder identity recorded in the lease
+	Holder string `json:"holder,omitempty"`
+	// LeaseTransitions counts the leadership changes recorded in the lease
+	LeaseTransitions int32 `json:"leaseTransitions"`
+	// LastTransition is when the current holder acquired the lease
+	LastTransition *time.Time `json:"lastTransition,omitempty"`
+	// LastRenew is when the holder last renewed the lease
+	LastRenew *time.Time `json:"lastRenew,omitempty"`
+	// LeaseDurationSeconds is how long the lease is valid after a renewal
+	LeaseDurationSeconds int32 `json:"leaseDurationSeconds,omitempty"`
+	// LeaderHealthy is true while the lease has a holder that renewed it in time
+	LeaderHealthy bool `json:"leaderHealthy"`
+	// ObservedAt is when the lease was last read
+	ObservedAt *time.Time `json:"observedAt,omitempty"`
+	// Error is the error of the last read, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// LeaderObserver periodically reads the leader election lease on every replica, so
+// standby replicas can report whether a healthy leader exists. It exports the role of
+// the replica and the state of the lease as metrics and serves them to /debug/standby.
+type LeaderObserver struct {
+	reader   client.Reader
+	lease    types.NamespacedName
+	identity string
+	elected  <-chan struct{}
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.RWMutex
+	status LeaderStatus
+}
+
+// NewLeaderObserver returns an observer of the lease namespace/name. elected is closed
+// once this replica became the leader (manager.Elected).
+func NewLeaderObserver(reader client.Reader, namespace, name string, elected <-chan struct{}) *LeaderObserver {
+	identity, _ := os.Hostname()
+	lease := types.NamespacedName{Namespace: namespace, Name: name}
+	return &LeaderObserver{
+		reader:   reader,
+		lease:    lease,
+		identity: identity,
+		elected:  elected,
+		interval: DefaultLeaderObserveInterval,
+		now:      time.Now,
+		status:   LeaderStatus{Identity: identity, Role: RoleStandby, Lease: lease.String()},
+	}
+}
+
+// LeaderElectionNamespace returns the namespace controller-runtime keeps the lease in
+// when none is configured: the pod's own namespace, or fallback outside a cluster
+func LeaderElectionNamespace(fallback string) string {
+	data, err := os.ReadFile(inClusterNamespaceFile)
+	if err != nil {
+		return fallback
+	}
+	if namespace := strings.TrimSpace(string(data)); namespace != "" {
+		return namespace
+	}
+	return fallback
+}
+
+// Start implements manager.Runnable: it observes immediately and then every interval
+func (o *LeaderObserver) Start(ctx context.Context) error {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		o.observe(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Standby replicas are the ones that need to watch the leader.
+func (o *LeaderObserver) NeedLeaderElection() bool {
+	return false
+}
+
+// Status returns the last observation
+func (o *LeaderObserver) Status() LeaderStatus {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.status
+}
+
+// observe reads the lease and updates the status and metrics
+func (o *LeaderObserver) observe(ctx context.Context) {
+	now := o.now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	status := o.status
+	status.Role = RoleStandby
+	select {
+	case <-o.elected:
+		status.Role = RoleLeader
+	default:
+	}
+
+	lease := &coordinationv1.Lease{}
+	if err := o.reader.Get(ctx, o.lease, lease); err != nil {
+		log.FromContext(ctx).WithName("leader-observer").Error(err, "Failed to read the leader election lease", "lease", o.lease.String())
+		// The last lease state is kept; whether it is still healthy is re-evaluated below
+		status.Error = err.Error()
+	} else {
+		status.Error = ""
+		status.ObservedAt = &now
+		status.Holder = ""
+		if lease.Spec.HolderIdentity != nil {
+			status.Holder = *lease.Spec.HolderIdentity
+		}
+		status.LeaseTransitions = 0
+		if lease.Spec.LeaseTransitions != nil {
+			status.LeaseTransitions = *lease.Spec.LeaseTransitions
+		}
+		status.LeaseDurationSeconds = 0
+		if lease.Spec.LeaseDurationSeconds != nil {
+			status.LeaseDurationSeconds = *lease.Spec.LeaseDurationSeconds
+		}
+		status.LastTransition = nil
+		if lease.Spec.AcquireTime != nil {
+			acquired := lease.Spec.AcquireTime.Time
+			status.LastTransition = &acquired
+		}
+		status.LastRenew = nil
+		if lease.Spec.RenewTime != nil {
+			renewed := lease.Spec.RenewTime.Time
+			status.LastRenew = &renewed
+		}
+	}
+	status.LeaderHealthy = status.Holder != "" && status.LastRenew != nil &&
+		now.Sub(*status.LastRenew) <= time.Duration(status.LeaseDurationSeconds)*time.Second
+	o.status = status
+
+	var lastTransition time.Time
+	if status.LastTransition != nil {
+		lastTransition = *status.LastTransition
+	}
+	observability.SetLeaderElection(status.Role == RoleLeader, status.LeaderHealthy, status.LeaseTransitions, lastTransition)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func TestLeaderObserver(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = coordinationv1.AddToScheme(scheme)
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	acquired := now.Add(-time.Hour)
+	newLease := func(renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "autopilot-lock", Namespace: "openshift-cnv"},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To("autopilot-0_1234"),
+				LeaseDurationSeconds: ptr.To[int32](15),
+				AcquireTime:          &metav1.MicroTime{Time: acquired},
+				RenewTime:            &metav1.MicroTime{Time: renewed},
+				LeaseTransitions:     ptr.To[int32](3),
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		lease           *coordinationv1.Lease
+		elected         bool
+		expectedRole    string
+		expectedHealthy bool
+		expectedError   bool
+	}{
+		{name: "standby with a healthy leader", lease: newLease(now.Add(-5 * time.Second)), expectedRole: RoleStandby, expectedHealthy: true},
+		{name: "leader", lease: newLease(now.Add(-5 * time.Second)), elected: true, expectedRole: RoleLeader, expectedHealthy: true},
+		{name: "leader stopped renewing", lease: newLease(now.Add(-time.Minute)), expectedRole: RoleStandby},
+		{name: "no lease yet", expectedRole: RoleStandby, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.lease != nil {
+				builder = builder.WithObjects(tt.lease)
+			}
+			elected := make(chan struct{})
+			if tt.elected {
+				close(elected)
+			}
+
+			observer := NewLeaderObserver(builder.Build(), "openshift-cnv", "autopilot-lock", elected)
+			observer.now = func() time.Time { return now }
+			observer.observe(context.Background())
+
+			status := observer.Status()
+			assert.Equal(t, "openshift-cnv/autopilot-lock", status.Lease)
+			assert.Equal(t, tt.expectedRole, status.Role)
+			assert.Equal(t, tt.expectedHealthy, status.LeaderHealthy)
+			assert.Equal(t, tt.expectedError, status.Error != "")
+			assert.Equal(t, tt.expectedHealthy, testutil.ToFloat64(observability.LeaderElectionLeaderHealthy) == 1)
+			assert.Equal(t, tt.elected, testutil.ToFloat64(observability.LeaderElectionIsLeader) == 1)
+			if tt.lease == nil {
+				return
+			}
+			assert.Equal(t, "autopilot-0_1234", status.Holder)
+			assert.Equal(t, int32(3), status.LeaseTransitions)
+			assert.Equal(t, float64(3), testutil.ToFloat64(observability.LeaderElectionTransitions))
+			assert.Equal(t, float64(acquired.Unix()), testutil.ToFloat64(observability.LeaderElectionLastTransition))
+		})
+	}
+}
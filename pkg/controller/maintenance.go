@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// ConditionMaintenance is the HCO status condition reporting an open maintenance window
+const ConditionMaintenance = "PlatformAutopilotMaintenance"
+
+// recordMaintenanceWindow reports the HCO's maintenance window in its status (end time)
+// and metrics (time left) and returns the time left, 0 when none is open. The patcher
+// enforces the window itself; this only makes it visible and lets Reconcile requeue for its end.
+func (r *PlatformReconciler) recordMaintenanceWindow(ctx context.Context, hco *unstructured.Unstructured, now time.Time) time.Duration {
+	key := types.NamespacedName{Namespace: hco.GetNamespace(), Name: hco.GetName()}
+
+	until, active, err := overrides.MaintenanceUntil(hco, now)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Ignoring maintenance window, drift will be corrected")
+	}
+
+	var remaining time.Duration
+	condition := metav1.Condition{
+		Type:    ConditionMaintenance,
+		Status:  metav1.ConditionFalse,
+		Reason:  "MaintenanceWindowClosed",
+		Message: "No maintenance window is open; drift is corrected",
+	}
+	if active {
+		remaining = until.Sub(now)
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "MaintenanceWindowOpen"
+		condition.Message = fmt.Sprintf("Until %s drift is detected but not corrected "+
+			"and MachineConfig, KubeletConfig and ContainerRuntimeConfig changes are not applied",
+			until.UTC().Format(time.RFC3339))
+	}
+	observability.SetMaintenanceRemaining(key.Namespace, key.Name, remaining)
+
+	// The message carries the end time, so moving the window rewrites the condition
+	if err := r.setHCOCondition(ctx, key, condition, true); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to update HCO maintenance condition", "error", err.Error())
+	}
+	return remaining
+}
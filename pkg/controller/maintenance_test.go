@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+func TestRecordMaintenanceWindow(t *testing.T) {
+	observability.MaintenanceWindowRemaining.Reset()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).WithStatusSubresource(hco).Build()
+	r := &PlatformReconciler{Client: fakeClient}
+	key := types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	condition := func() map[string]any {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := fakeClient.Get(ctx, key, live); err != nil {
+			t.Fatalf("failed to get HCO: %v", err)
+		}
+		conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+		for _, c := range conditions {
+			if m := c.(map[string]any); m["type"] == ConditionMaintenance {
+				return m
+			}
+		}
+		return nil
+	}
+	gauge := observability.MaintenanceWindowRemaining.WithLabelValues(key.Namespace, key.Name)
+
+	// Window open for two hours
+	hco.SetAnnotations(map[string]string{overrides.AnnotationMaintenanceUntil: "2026-10-16T14:00:00Z"})
+	if remaining := r.recordMaintenanceWindow(ctx, hco, now); remaining != 2*time.Hour {
+		t.Errorf("remaining = %s, want 2h", remaining)
+	}
+	c := condition()
+	if c == nil || c["status"] != "True" || c["reason"] != "MaintenanceWindowOpen" {
+		t.Fatalf("condition = %v, want True/MaintenanceWindowOpen", c)
+	}
+	if msg, _ := c["message"].(string); !strings.Contains(msg, "2026-10-16T14:00:00Z") {
+		t.Errorf("message = %q, want the window end", msg)
+	}
+	if val := testutil.ToFloat64(gauge); val != 7200 {
+		t.Errorf("maintenance_window_remaining_seconds = %v, want 7200", val)
+	}
+
+	// Extending the window rewrites the message
+	hco.SetAnnotations(map[string]string{overrides.AnnotationMaintenanceUntil: "2026-10-16T18:00:00Z"})
+	r.recordMaintenanceWindow(ctx, hco, now)
+	if msg, _ := condition()["message"].(string); !strings.Contains(msg, "2026-10-16T18:00:00Z") {
+		t.Errorf("message = %q after extending, want the new end", msg)
+	}
+
+	// Past the end the window closes on its own
+	if remaining := r.recordMaintenanceWindow(ctx, hco, now.Add(7*time.Hour)); remaining != 0 {
+		t.Errorf("remaining after expiry = %s, want 0", remaining)
+	}
+	if c := condition(); c["status"] != "False" || c["reason"] != "MaintenanceWindowClosed" {
+		t.Errorf("condition after expiry = %v, want False/MaintenanceWindowClosed", c)
+	}
+	if count := testutil.CollectAndCount(observability.MaintenanceWindowRemaining); count != 0 {
+		t.Errorf("maintenance_window_remaining_seconds series = %d, want 0", count)
+	}
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+const (
+	// ManagedResourceCRDName is the CRD of the ManagedResource inventory objects
+	ManagedResourceCRDName = "managedresources.platform.kubevirt.io"
+
+	// ManagedResourceComponentLabel and ManagedResourceAssetLabel carry the asset's
+	// component and name, for `oc get managedresources -l component=...`
+	ManagedResourceComponentLabel = "component"
+	ManagedResourceAssetLabel     = "asset"
+
+	// maxManagedResourceMessage bounds the status message, which may hold an apply error
+	maxManagedResourceMessage = 1024
+)
+
+// ManagedResourceGVK is the kind of the inventory objects
+var ManagedResourceGVK = schema.GroupVersionKind{Group: "platform.kubevirt.io", Version: "v1alpha1", Kind: "ManagedResource"}
+
+// ManagedResourceCRD returns the CustomResourceDefinition of ManagedResource. The CRD is
+// optional: without it the autopilot exports nothing. Its printer columns make the
+// inventory readable with plain `oc get`.
+func ManagedResourceCRD() *apiextensionsv1.CustomResourceDefinition {
+	str := apiextensionsv1.JSONSchemaProps{Type: "string"}
+	timestamp := apiextensionsv1.JSONSchemaProps{Type: "string", Format: "date-time"}
+	target := apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"apiVersion": str,
+			"kind":       str,
+			"namespace":  str,
+			"name":       str,
+		},
+	}
+	column := func(name, path, description string, priority int32) apiextensionsv1.CustomResourceColumnDefinition {
+		return apiextensionsv1.CustomResourceColumnDefinition{
+			Name: name, Type: "string", JSONPath: path, Description: description, Priority: priority,
+		}
+	}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: ManagedResourceCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: ManagedResourceGVK.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       ManagedResourceGVK.Kind,
+				ListKind:   ManagedResourceGVK.Kind + "List",
+				Plural:     "managedresources",
+				Singular:   "managedresource",
+				ShortNames: []string{"mres"},
+				Categories: []string{"virt-platform-autopilot"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    ManagedResourceGVK.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Description: "ManagedResource reports the state of one object applied by virt-platform-autopilot. " +
+						"It is written by the autopilot; changes made by users are overwritten.",
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"apiVersion": str,
+						"kind":       str,
+						"metadata":   {Type: "object"},
+						"spec": {
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"asset":     str,
+								"component": str,
+								"target":    target,
+							},
+						},
+						"status": {
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"state":              str,
+								"reason":             str,
+								"message":            str,
+								"lastTransitionTime": timestamp,
+								"lastDrift": {
+									Type:        "object",
+									Description: "The last drift the autopilot corrected and the field managers that caused it",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"time": timestamp,
+										"modifiers": {
+											Type: "array",
+											Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+												Type: "object",
+												Properties: map[string]apiextensionsv1.JSONSchemaProps{
+													"manager":   str,
+													"operation": str,
+													"time":      timestamp,
+													"fields": {
+														Type:  "array",
+														Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &str},
+													},
+												},
+											}},
+										},
+									},
+								},
+							},
+						},
+					},
+				}},
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{
+					column("Asset", ".spec.asset", "Asset the object is rendered from", 0),
+					column("Component", ".spec.component", "Component of the asset", 0),
+					column("Kind", ".spec.target.kind", "Kind of the applied object", 0),
+					column("Target-Namespace", ".spec.target.namespace", "Namespace of the applied object", 1),
+					column("Target", ".spec.target.name", "Name of the applied object", 0),
+					column("State", ".status.state", "Outcome of the last reconcile", 0),
+					column("Reason", ".status.reason", "Reason code of the state", 1),
+					column("Message", ".status.message", "Why the object is not in sync", 1),
+					column("Drifted-By", ".status.lastDrift.modifiers[0].manager", "Field manager of the last corrected drift", 1),
+					{Name: "Since", Type: "date", JSONPath: ".status.lastTransitionTime", Description: "Time of the last state change"},
+					{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+				},
+			}},
+		},
+	}
+}
+
+// managedResourceExporter mirrors the outcome of every asset of a reconcile pass into
+// ManagedResource objects in the HCO's namespace, one per applied object and named
+// after its asset. It implements engine.InventorySink: the patcher reports during the
+// pass, and flush writes the objects once the pass is over. Objects of assets that
+// were not reconciled (excluded, template skipped, removed from the catalog) are deleted.
+type managedResourceExporter struct {
+	client     client.Client
+	crdChecker *util.CRDChecker
+	owns       func(component string) bool // Only objects of the shard's components are touched
+	now        func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]map[string]*engine.ObjectReport // HCO namespace -> asset -> last report
+}
+
+func newManagedResourceExporter(c client.Client, crdChecker *util.CRDChecker, owns func(string) bool) *managedResourceExporter {
+	return &managedResourceExporter{
+		client:     c,
+		crdChecker: crdChecker,
+		owns:       owns,
+		now:        time.Now,
+		pending:    make(map[string]map[string]*engine.ObjectReport),
+	}
+}
+
+// ObjectReconciled implements engine.InventorySink
+func (e *managedResourceExporter) ObjectReconciled(hco *unstructured.Unstructured, report engine.ObjectReport) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reportsLocked(hco.GetNamespace())[report.Asset] = &report
+}
+
+// AssetFailed implements engine.InventorySink. An asset that failed before rendering
+// has no target; flush then keeps the target of its existing ManagedResource.
+func (e *managedResourceExporter) AssetFailed(hco *unstructured.Unstructured, asset string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	reports := e.reportsLocked(hco.GetNamespace())
+	report, ok := reports[asset]
+	if !ok {
+		report = &engine.ObjectReport{Asset: asset}
+		reports[asset] = report
+	}
+	report.State = engine.ObjectFailed
+	report.Reason = engine.AssetErrorReason(err)
+	report.Message = err.Error()
+}
+
+func (e *managedResourceExporter) reportsLocked(namespace string) map[string]*engine.ObjectReport {
+	reports, ok := e.pending[namespace]
+	if !ok {
+		reports = make(map[string]*engine.ObjectReport)
+		e.pending[namespace] = reports
+	}
+	return reports
+}
+
+// flush writes the reports of the pass for hco and deletes the ManagedResources of
+// assets that were not reported. Nothing is written when the CRD is not installed.
+func (e *managedResourceExporter) flush(ctx context.Context, hco *unstructured.Unstructured) error {
+	namespace := hco.GetNamespace()
+	e.mu.Lock()
+	reports := e.pending[namespace]
+	delete(e.pending, namespace)
+	e.mu.Unlock()
+
+	installed, err := e.crdChecker.IsCRDInstalled(ctx, ManagedResourceCRDName)
+	if err != nil || !installed {
+		return err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(ManagedResourceGVK.GroupVersion().WithKind(ManagedResourceGVK.Kind + "List"))
+	if err := e.client.List(ctx, list, client.InNamespace(namespace),
+		client.MatchingLabels{engine.ManagedByLabel: engine.ManagedByValue}); err != nil {
+		return fmt.Errorf("failed to list ManagedResources: %w", err)
+	}
+
+	var errs []error
+	for i := range list.Items {
+		existing := &list.Items[i]
+		if !e.owns(existing.GetLabels()[ManagedResourceComponentLabel]) {
+			continue
+		}
+		asset := existing.GetLabels()[ManagedResourceAssetLabel]
+		report, ok := reports[asset]
+		delete(reports, asset)
+		if !ok {
+			if err := e.client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to delete ManagedResource %s: %w", existing.GetName(), err))
+			}
+			continue
+		}
+		desired := e.managedResource(hco, report, existing)
+		if equality.Semantic.DeepEqual(desired.Object, existing.Object) {
+			continue
+		}
+		if err := e.client.Update(ctx, desired); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update ManagedResource %s: %w", asset, err))
+		}
+	}
+
+	assetNames := make([]string, 0, len(reports))
+	for asset := range reports {
+		assetNames = append(assetNames, asset)
+	}
+	sort.Strings(assetNames)
+	for _, asset := range assetNames {
+		report := reports[asset]
+		if report.Kind == "" {
+			continue // Failed before rendering and never exported: nothing to show
+		}
+		if err := e.client.Create(ctx, e.managedResource(hco, report, nil)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create ManagedResource %s: %w", asset, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// managedResource builds the ManagedResource for report, starting from the existing
+// object when there is one. The transition time only moves when the state changes.
+func (e *managedResourceExporter) managedResource(hco *unstructured.Unstructured, report *engine.ObjectReport,
+	existing *unstructured.Unstructured) *unstructured.Unstructured {
+	var obj *unstructured.Unstructured
+	if existing != nil {
+		obj = existing.DeepCopy()
+	} else {
+		obj = &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ManagedResourceGVK)
+		obj.SetNamespace(hco.GetNamespace())
+		obj.SetName(report.Asset)
+		if hco.GetUID() != "" {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{
+				APIVersion: hco.GetAPIVersion(),
+				Kind:       hco.GetKind(),
+				Name:       hco.GetName(),
+				UID:        hco.GetUID(),
+				Controller: ptr.To(false),
+			}})
+		}
+	}
+
+	// A failure before rendering keeps the last known target
+	if report.Kind != "" {
+		_ = unstructured.SetNestedField(obj.Object, report.Asset, "spec", "asset")
+		_ = unstructured.SetNestedField(obj.Object, report.Component, "spec", "component")
+		_ = unstructured.SetNestedStringMap(obj.Object, map[string]string{
+			"apiVersion": report.APIVersion,
+			"kind":       report.Kind,
+			"namespace":  report.Namespace,
+			"name":       report.Name,
+		}, "spec", "target")
+	}
+	component, _, _ := unstructured.NestedString(obj.Object, "spec", "component")
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[engine.ManagedByLabel] = engine.ManagedByValue
+	labels[ManagedResourceAssetLabel] = report.Asset
+	labels[ManagedResourceComponentLabel] = component
+	obj.SetLabels(labels)
+
+	message := report.Message
+	if len(message) > maxManagedResourceMessage {
+		message = message[:maxManagedResourceMessage-3] + "..."
+	}
+	previous, _, _ := unstructured.NestedString(obj.Object, "status", "state")
+	transition, _, _ := unstructured.NestedString(obj.Object, "status", "lastTransitionTime")
+	if previous != string(report.State) || transition == "" {
+		transition = e.now().UTC().Format(time.RFC3339)
+	}
+	// The last drift stays on record until another one is corrected
+	lastDrift, _, _ := unstructured.NestedMap(obj.Object, "status", "lastDrift")
+	if len(report.Modifiers) > 0 {
+		lastDrift = map[string]any{
+			"time":      e.now().UTC().Format(time.RFC3339),
+			"modifiers": modifiersStatus(report.Modifiers),
+		}
+	}
+	status := map[string]any{
+		"state":              string(report.State),
+		"lastTransitionTime": transition,
+	}
+	if lastDrift != nil {
+		status["lastDrift"] = lastDrift
+	}
+	if report.Reason != "" {
+		status["reason"] = string(report.Reason)
+	}
+	if message != "" {
+		status["message"] = message
+	}
+	obj.Object["status"] = status
+	return obj
+}
+
+// modifiersStatus converts modifiers to the status.lastDrift.modifiers list
+func modifiersStatus(modifiers []engine.FieldModifier) []any {
+	out := make([]any, 0, len(modifiers))
+	for _, m := range modifiers {
+		fields := make([]any, 0, len(m.Fields))
+		for _, field := range m.Fields {
+			fields = append(fields, field)
+		}
+		entry := map[string]any{"manager": m.Manager, "fields": fields}
+		if m.Operation != "" {
+			entry["operation"] = m.Operation
+		}
+		if m.Time != nil {
+			entry["time"] = m.Time.UTC().Format(time.RFC3339)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+// TestManagedResourceCRDManifest keeps config/crd in sync with ManagedResourceCRD
+func TestManagedResourceCRDManifest(t *testing.T) {
+	data, err := os.ReadFile("../../config/crd/" + ManagedResourceCRDName + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		t.Fatal(err)
+	}
+	if !equality.Semantic.DeepEqual(manifest, ManagedResourceCRD()) {
+		t.Errorf("config/crd/%s.yaml is out of date with ManagedResourceCRD()", ManagedResourceCRDName)
+	}
+}
+
+func newManagedResourceTestExporter(t *testing.T, crdInstalled bool, objects ...client.Object) (*managedResourceExporter, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	if crdInstalled {
+		objects = append(objects, &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: ManagedResourceCRDName}})
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	exporter := newManagedResourceExporter(c, util.NewCRDChecker(c), func(component string) bool {
+		return component != "Other"
+	})
+	return exporter, c
+}
+
+func listManagedResources(t *testing.T, c client.Client) map[string]*unstructured.Unstructured {
+	t.Helper()
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(ManagedResourceGVK.GroupVersion().WithKind(ManagedResourceGVK.Kind + "List"))
+	if err := c.List(context.Background(), list, client.InNamespace("openshift-cnv")); err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		byName[list.Items[i].GetName()] = &list.Items[i]
+	}
+	return byName
+}
+
+func managedResourceState(obj *unstructured.Unstructured) (state, message, since string) {
+	state, _, _ = unstructured.NestedString(obj.Object, "status", "state")
+	message, _, _ = unstructured.NestedString(obj.Object, "status", "message")
+	since, _, _ = unstructured.NestedString(obj.Object, "status", "lastTransitionTime")
+	return state, message, since
+}
+
+func TestManagedResourceExporter(t *testing.T) {
+	ctx := context.Background()
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+
+	// A ManagedResource of a component owned by another shard must be left alone
+	foreign := &unstructured.Unstructured{}
+	foreign.SetGroupVersionKind(ManagedResourceGVK)
+	foreign.SetNamespace("openshift-cnv")
+	foreign.SetName("other-asset")
+	foreign.SetLabels(map[string]string{
+		engine.ManagedByLabel:         engine.ManagedByValue,
+		ManagedResourceAssetLabel:     "other-asset",
+		ManagedResourceComponentLabel: "Other",
+	})
+	exporter, c := newManagedResourceTestExporter(t, true, foreign)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exporter.now = func() time.Time { return now }
+
+	report := func(asset, component string, state engine.ObjectState) {
+		exporter.ObjectReconciled(hco, engine.ObjectReport{
+			Asset: asset, Component: component, APIVersion: "v1", Kind: "ConfigMap",
+			Namespace: "openshift-cnv", Name: asset + "-cm", State: state,
+		})
+	}
+
+	// First pass: two exported objects; a failure before rendering has nothing to show
+	report("in-sync", "Descheduler", engine.ObjectInSync)
+	report("failing", "KubeletConfig", engine.ObjectPending)
+	exporter.AssetFailed(hco, "failing", errors.New("admission webhook denied the request"))
+	exporter.AssetFailed(hco, "unrendered", errors.New("template error"))
+	if err := exporter.flush(ctx, hco); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	objects := listManagedResources(t, c)
+	if len(objects) != 3 {
+		t.Fatalf("got %d ManagedResources, want in-sync, failing and the foreign one", len(objects))
+	}
+	inSync := objects["in-sync"]
+	if inSync.GetLabels()[ManagedResourceComponentLabel] != "Descheduler" || inSync.GetLabels()[ManagedResourceAssetLabel] != "in-sync" {
+		t.Errorf("in-sync labels = %v, want component and asset labels", inSync.GetLabels())
+	}
+	if target, _, _ := unstructured.NestedString(inSync.Object, "spec", "target", "name"); target != "in-sync-cm" {
+		t.Errorf("in-sync target name = %q, want in-sync-cm", target)
+	}
+	if state, _, since := managedResourceState(inSync); state != string(engine.ObjectInSync) || since != "2026-10-16T12:00:00Z" {
+		t.Errorf("in-sync status = %s since %s, want InSync since the first pass", state, since)
+	}
+	if state, message, _ := managedResourceState(objects["failing"]); state != string(engine.ObjectFailed) || message != "admission webhook denied the request" {
+		t.Errorf("failing status = %s (%s), want Failed with the apply error", state, message)
+	}
+
+	// Second pass: in-sync keeps its state and transition time, failing now fails
+	// before rendering and keeps its target, and an unreported asset is deleted
+	now = now.Add(time.Hour)
+	report("in-sync", "Descheduler", engine.ObjectInSync)
+	exporter.AssetFailed(hco, "failing", errors.New("template error"))
+	if err := exporter.flush(ctx, hco); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	objects = listManagedResources(t, c)
+	if state, _, since := managedResourceState(objects["in-sync"]); state != string(engine.ObjectInSync) || since != "2026-10-16T12:00:00Z" {
+		t.Errorf("in-sync status = %s since %s, want the first transition time", state, since)
+	}
+	failing := objects["failing"]
+	if target, _, _ := unstructured.NestedString(failing.Object, "spec", "target", "name"); target != "failing-cm" {
+		t.Errorf("failing target name = %q, want the last known target", target)
+	}
+	if failing.GetLabels()[ManagedResourceComponentLabel] != "KubeletConfig" {
+		t.Errorf("failing component label = %q, want KubeletConfig", failing.GetLabels()[ManagedResourceComponentLabel])
+	}
+
+	// Third pass: only in-sync is reconciled, now with an apply
+	now = now.Add(time.Hour)
+	report("in-sync", "Descheduler", engine.ObjectApplied)
+	if err := exporter.flush(ctx, hco); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	objects = listManagedResources(t, c)
+	if _, ok := objects["failing"]; ok {
+		t.Error("ManagedResource of an asset that was not reconciled was not deleted")
+	}
+	if _, ok := objects["other-asset"]; !ok {
+		t.Error("ManagedResource of another shard's component was deleted")
+	}
+	if state, _, since := managedResourceState(objects["in-sync"]); state != string(engine.ObjectApplied) || since != "2026-10-16T14:00:00Z" {
+		t.Errorf("in-sync status = %s since %s, want Applied since the third pass", state, since)
+	}
+}
+
+func TestManagedResourceExporterLastDrift(t *testing.T) {
+	ctx := context.Background()
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	exporter, c := newManagedResourceTestExporter(t, true)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exporter.now = func() time.Time { return now }
+
+	report := engine.ObjectReport{
+		Asset: "edited", Component: "Descheduler", APIVersion: "v1", Kind: "ConfigMap",
+		Namespace: "openshift-cnv", Name: "edited-cm", State: engine.ObjectApplied,
+		Modifiers: []engine.FieldModifier{{Manager: "kubectl-edit", Operation: "Update", Fields: []string{"data.a"}}},
+	}
+	exporter.ObjectReconciled(hco, report)
+	if err := exporter.flush(ctx, hco); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	want := map[string]any{
+		"time": "2026-10-16T12:00:00Z",
+		"modifiers": []any{map[string]any{
+			"manager": "kubectl-edit", "operation": "Update", "fields": []any{"data.a"},
+		}},
+	}
+	lastDrift, _, _ := unstructured.NestedMap(listManagedResources(t, c)["edited"].Object, "status", "lastDrift")
+	if !equality.Semantic.DeepEqual(lastDrift, want) {
+		t.Errorf("status.lastDrift = %v, want %v", lastDrift, want)
+	}
+
+	// An in-sync pass keeps the record of the last correction
+	now = now.Add(time.Hour)
+	report.State, report.Modifiers = engine.ObjectInSync, nil
+	exporter.ObjectReconciled(hco, report)
+	if err := exporter.flush(ctx, hco); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	lastDrift, _, _ = unstructured.NestedMap(listManagedResources(t, c)["edited"].Object, "status", "lastDrift")
+	if !equality.Semantic.DeepEqual(lastDrift, want) {
+		t.Errorf("status.lastDrift after an in-sync pass = %v, want %v", lastDrift, want)
+	}
+}
+
+func TestManagedResourceExporterWithoutCRD(t *testing.T) {
+	exporter, c := newManagedResourceTestExporter(t, false)
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	exporter.ObjectReconciled(hco, engine.ObjectReport{
+		Asset: "in-sync", APIVersion: "v1", Kind: "ConfigMap", Name: "cm", State: engine.ObjectInSync,
+	})
+	if err := exporter.flush(context.Background(), hco); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	if objects := listManagedResources(t, c); len(objects) != 0 {
+		t.Errorf("got %d ManagedResources without the CRD, want none", len(objects))
+	}
+	if len(exporter.pending) != 0 {
+		t.Error("reports of the pass were not discarded")
+	}
+}
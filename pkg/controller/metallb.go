@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+var (
+	bareMetalHostListGVK = schema.GroupVersionKind{Group: "metal3.io", Version: "v1alpha1", Kind: "BareMetalHostList"}
+	infrastructureGVK    = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Infrastructure"}
+)
+
+// detectMetalLBInventory gathers the machine networks and the addresses in use on a
+// bare-metal cluster, for deriving and checking MetalLB pools. It returns nil when no
+// BareMetalHosts exist: elsewhere nothing is known about which addresses are free.
+func (b *RenderContextBuilder) detectMetalLBInventory(ctx context.Context, nodes []corev1.Node) (*pkgcontext.MetalLBInventory, error) {
+	hosts, err := b.listIfInstalled(ctx, bareMetalHostListGVK)
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+
+	inventory := &pkgcontext.MetalLBInventory{Reserved: make(map[string]string)}
+	reserve := func(address, owner string) {
+		if _, taken := inventory.Reserved[address]; address != "" && !taken {
+			inventory.Reserved[address] = owner
+		}
+	}
+
+	for i := range hosts {
+		nics, _, _ := unstructured.NestedSlice(hosts[i].Object, "status", "hardware", "nics")
+		for _, n := range nics {
+			if nic, ok := n.(map[string]any); ok {
+				ip, _, _ := unstructured.NestedString(nic, "ip")
+				reserve(ip, "host "+hosts[i].GetName())
+			}
+		}
+	}
+	for i := range nodes {
+		for _, address := range nodes[i].Status.Addresses {
+			if address.Type == corev1.NodeInternalIP || address.Type == corev1.NodeExternalIP {
+				reserve(address.Address, "node "+nodes[i].Name)
+			}
+		}
+	}
+
+	infra, err := b.getIfInstalled(ctx, infrastructureGVK, infrastructureResourceName)
+	if err != nil {
+		return nil, err
+	}
+	if infra != nil {
+		platform := []string{"status", "platformStatus", "baremetal"}
+		inventory.MachineNetworks, _, _ = unstructured.NestedStringSlice(infra.Object, append(platform, "machineNetworks")...)
+		apiVIPs, _, _ := unstructured.NestedStringSlice(infra.Object, append(platform, "apiServerInternalIPs")...)
+		for _, vip := range apiVIPs {
+			reserve(vip, "the API VIP")
+		}
+		ingressVIPs, _, _ := unstructured.NestedStringSlice(infra.Object, append(platform, "ingressIPs")...)
+		for _, vip := range ingressVIPs {
+			reserve(vip, "the ingress VIP")
+		}
+	}
+	if len(inventory.MachineNetworks) == 0 {
+		return inventory, fmt.Errorf("%d BareMetalHosts found, but the Infrastructure lists no machine networks", len(hosts))
+	}
+	return inventory, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestDetectMetalLBInventory(t *testing.T) {
+	host := clusterObject("metal3.io/v1alpha1", "BareMetalHost", "worker-0", map[string]any{
+		"status": map[string]any{"hardware": map[string]any{"nics": []any{
+			map[string]any{"name": "eno1", "ip": "192.168.111.20"},
+			map[string]any{"name": "eno2"},
+		}}},
+	})
+	host.SetNamespace("openshift-machine-api")
+	infra := clusterObject("config.openshift.io/v1", "Infrastructure", "cluster", map[string]any{
+		"status": map[string]any{"platformStatus": map[string]any{
+			"type": "BareMetal",
+			"baremetal": map[string]any{
+				"machineNetworks":      []any{"192.168.111.0/24"},
+				"apiServerInternalIPs": []any{"192.168.111.5"},
+				"ingressIPs":           []any{"192.168.111.4"},
+			},
+		}},
+	})
+	nodes := []corev1.Node{{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "192.168.111.20"},
+			{Type: corev1.NodeInternalIP, Address: "192.168.111.21"},
+			{Type: corev1.NodeHostName, Address: "worker-0"},
+		}},
+	}}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    *pkgcontext.MetalLBInventory
+		wantErr bool
+	}{
+		{name: "no BareMetalHosts", objects: []client.Object{infra}},
+		{
+			name:    "hosts, nodes and VIPs",
+			objects: []client.Object{host, infra},
+			want: &pkgcontext.MetalLBInventory{
+				MachineNetworks: []string{"192.168.111.0/24"},
+				Reserved: map[string]string{
+					"192.168.111.20": "host worker-0",
+					"192.168.111.21": "node worker-0",
+					"192.168.111.5":  "the API VIP",
+					"192.168.111.4":  "the ingress VIP",
+				},
+			},
+		},
+		{
+			name:    "hosts without machine networks",
+			objects: []client.Object{host},
+			want: &pkgcontext.MetalLBInventory{Reserved: map[string]string{
+				"192.168.111.20": "host worker-0",
+				"192.168.111.21": "node worker-0",
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory, err := fakeBuilderWith(tt.objects...).detectMetalLBInventory(context.Background(), nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("detectMetalLBInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(inventory, tt.want) {
+				t.Errorf("detectMetalLBInventory() = %+v, want %+v", inventory, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// DefaultNodeEventDebounce is how long node changes are coalesced before the HCOs are
+// reconciled, unless configured
+const DefaultNodeEventDebounce = 30 * time.Second
+
+// nodeEventDebouncer turns node changes into HCO reconciles for hardware and topology
+// detection. Node Feature Discovery relabels nodes in bursts, and every reconcile
+// re-renders all assets, so the first relevant event schedules one reconcile at the
+// end of the window and further events until then are only counted: the reconcile
+// lists nodes when it runs and sees all of them.
+type nodeEventDebouncer struct {
+	window   time.Duration
+	requests func(context.Context) []reconcile.Request
+	now      func() time.Time
+
+	mu           sync.Mutex
+	pendingUntil time.Time // end of the window of the scheduled reconcile
+}
+
+func newNodeEventDebouncer(window time.Duration, requests func(context.Context) []reconcile.Request) *nodeEventDebouncer {
+	return &nodeEventDebouncer{
+		window:   window,
+		requests: requests,
+		now:      time.Now,
+	}
+}
+
+// handler returns the event handler of the node watch. Updates only count when they
+// change what detection reads, which filters out the periodic status heartbeats.
+func (d *nodeEventDebouncer) handler() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, _ event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			d.observe(ctx, q)
+		},
+		DeleteFunc: func(ctx context.Context, _ event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			d.observe(ctx, q)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if okOld && okNew && !nodeDetectionChanged(oldNode, newNode) {
+				return
+			}
+			d.observe(ctx, q)
+		},
+	}
+}
+
+// observe schedules a reconcile of every in-scope HCO at the end of a new window, or
+// counts the event as suppressed when one is already scheduled
+func (d *nodeEventDebouncer) observe(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if now.Before(d.pendingUntil) {
+		observability.IncNodeEventSuppressed()
+		return
+	}
+	d.pendingUntil = now.Add(d.window)
+
+	observability.IncReconcileTrigger(observability.TriggerNodeChange)
+	for _, req := range d.requests(ctx) {
+		q.AddAfter(req, d.window)
+	}
+}
+
+// nodeDetectionChanged reports whether an update changes the node fields hardware and
+// topology detection read: labels, the topology manager annotation and the resource
+// names in the capacity
+func nodeDetectionChanged(oldNode, newNode *corev1.Node) bool {
+	if !maps.Equal(oldNode.Labels, newNode.Labels) {
+		return true
+	}
+	if oldNode.Annotations[topologyManagerPolicyAnnotation] != newNode.Annotations[topologyManagerPolicyAnnotation] {
+		return true
+	}
+	if len(oldNode.Status.Capacity) != len(newNode.Status.Capacity) {
+		return true
+	}
+	for resource := range newNode.Status.Capacity {
+		if _, ok := oldNode.Status.Capacity[resource]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TrimNodeForCache drops the fields of a node detection never reads before it is
+// cached. Nodes are cached without the managed-by label filter, and their image list
+// and managed fields are most of their size.
+func TrimNodeForCache(obj any) (any, error) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return obj, nil
+	}
+	node.ManagedFields = nil
+	node.Status.Images = nil
+	return node, nil
+}
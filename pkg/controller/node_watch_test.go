@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// delayRecorder records AddAfter calls instead of queueing
+type delayRecorder struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+	added []time.Duration
+}
+
+func (q *delayRecorder) AddAfter(_ reconcile.Request, delay time.Duration) {
+	q.added = append(q.added, delay)
+}
+
+func TestNodeEventDebouncer(t *testing.T) {
+	hco := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	debouncer := newNodeEventDebouncer(30*time.Second, func(context.Context) []reconcile.Request {
+		return []reconcile.Request{hco}
+	})
+	debouncer.now = func() time.Time { return now }
+	h := debouncer.handler()
+	q := &delayRecorder{}
+	ctx := context.Background()
+
+	node := func(labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: labels}}
+	}
+	relabel := event.UpdateEvent{
+		ObjectOld: node(map[string]string{"feature.node.kubernetes.io/pci-present": "true"}),
+		ObjectNew: node(map[string]string{"feature.node.kubernetes.io/pci-present": "true", "feature.node.kubernetes.io/usb-present": "true"}),
+	}
+	suppressed := testutil.ToFloat64(observability.NodeEventsSuppressedTotal)
+
+	h.Create(ctx, event.CreateEvent{Object: node(nil)}, q)
+	if len(q.added) != 1 || q.added[0] != 30*time.Second {
+		t.Fatalf("first event enqueued %v, want one reconcile after 30s", q.added)
+	}
+
+	now = now.Add(10 * time.Second)
+	h.Update(ctx, relabel, q)
+	h.Delete(ctx, event.DeleteEvent{Object: node(nil)}, q)
+	if len(q.added) != 1 {
+		t.Errorf("events within the window enqueued %d reconciles, want none", len(q.added)-1)
+	}
+	if got := testutil.ToFloat64(observability.NodeEventsSuppressedTotal) - suppressed; got != 2 {
+		t.Errorf("suppressed events = %v, want 2", got)
+	}
+
+	heartbeat := event.UpdateEvent{ObjectOld: node(nil), ObjectNew: node(nil)}
+	now = now.Add(30 * time.Second)
+	h.Update(ctx, heartbeat, q)
+	if len(q.added) != 1 {
+		t.Errorf("update without detection changes enqueued a reconcile")
+	}
+
+	h.Update(ctx, relabel, q)
+	if len(q.added) != 2 {
+		t.Errorf("event after the window enqueued %d reconciles, want a new one", len(q.added)-1)
+	}
+}
+
+func TestNodeDetectionChanged(t *testing.T) {
+	base := func() *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "worker-0",
+				Labels:      map[string]string{nodeWorkerRoleLabel: ""},
+				Annotations: map[string]string{topologyManagerPolicyAnnotation: "single-numa-node"},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*corev1.Node)
+		want   bool
+	}{
+		{"no change", func(*corev1.Node) {}, false},
+		{"heartbeat", func(n *corev1.Node) {
+			n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, LastHeartbeatTime: metav1.Now()}}
+		}, false},
+		{"capacity quantity", func(n *corev1.Node) { n.Status.Capacity[corev1.ResourceCPU] = resource.MustParse("16") }, false},
+		{"unrelated annotation", func(n *corev1.Node) { n.Annotations["example.io/state"] = "done" }, false},
+		{"label added", func(n *corev1.Node) { n.Labels["feature.node.kubernetes.io/iommu-enabled"] = "true" }, true},
+		{"label removed", func(n *corev1.Node) { delete(n.Labels, nodeWorkerRoleLabel) }, true},
+		{"topology policy", func(n *corev1.Node) { n.Annotations[topologyManagerPolicyAnnotation] = "" }, true},
+		{"device resource", func(n *corev1.Node) { n.Status.Capacity["nvidia.com/gpu"] = resource.MustParse("1") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newNode := base()
+			tt.mutate(newNode)
+			if got := nodeDetectionChanged(base(), newNode); got != tt.want {
+				t.Errorf("nodeDetectionChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimNodeForCache(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Labels: map[string]string{nodeWorkerRoleLabel: ""},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}}},
+		Status: corev1.NodeStatus{Images: []corev1.ContainerImage{{Names: []string{"quay.io/example:latest"}}}},
+	}
+	trimmed, err := TrimNodeForCache(node)
+	if err != nil {
+		t.Fatalf("TrimNodeForCache() error = %v", err)
+	}
+	got := trimmed.(*corev1.Node)
+	if got.ManagedFields != nil || got.Status.Images != nil {
+		t.Errorf("TrimNodeForCache() kept managed fields or images: %+v", got)
+	}
+	if _, ok := got.Labels[nodeWorkerRoleLabel]; !ok {
+		t.Errorf("TrimNodeForCache() dropped labels")
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+const (
+	// NotificationSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body,
+	// keyed with the webhook secret
+	NotificationSignatureHeader = "X-Autopilot-Signature-256"
+
+	// notificationTimeout bounds one post, so a slow receiver does not hold up later summaries for long
+	notificationTimeout = 10 * time.Second
+
+	// notificationQueueSize is how many summaries wait for delivery; more are dropped
+	notificationQueueSize = 64
+)
+
+// NotificationWebhook is where a summary of every reconcile pass that changed or failed
+// something is posted
+type NotificationWebhook struct {
+	// URL receives the summaries; empty disables notifications
+	URL string
+	// SecretFile holds the HMAC key the summaries are signed with, re-read on every post
+	// so a rotated Secret is picked up. Empty sends them unsigned.
+	SecretFile string
+}
+
+// Validate rejects a URL that is not absolute http(s) and a secret file without a URL
+func (w NotificationWebhook) Validate() error {
+	if w.URL == "" {
+		if w.SecretFile != "" {
+			return fmt.Errorf("notification webhook secret file set without a webhook URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("invalid notification webhook URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("notification webhook URL must be an absolute http or https URL, got %q", w.URL)
+	}
+	return nil
+}
+
+// SetNotificationWebhook posts a summary of the reconcile passes to webhook
+func (r *PlatformReconciler) SetNotificationWebhook(webhook NotificationWebhook) {
+	if webhook.URL == "" {
+		r.notifier = nil
+		return
+	}
+	r.notifier = &webhookNotifier{
+		webhook: webhook,
+		client:  &http.Client{Timeout: notificationTimeout},
+		queue:   make(chan *reconcileSummary, notificationQueueSize),
+	}
+}
+
+// reconcileSummary is the JSON document posted to the notification webhook
+type reconcileSummary struct {
+	HCO              summaryHCO      `json:"hco"`
+	Shard            string          `json:"shard,omitempty"`
+	Time             time.Time       `json:"time"`
+	CatalogVersion   string          `json:"catalogVersion"`
+	Assets           map[string]int  `json:"assets"`
+	Changed          []summaryObject `json:"changed,omitempty"`
+	DriftCorrections []summaryObject `json:"driftCorrections,omitempty"`
+	Errors           []summaryError  `json:"errors,omitempty"`
+}
+
+type summaryHCO struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+}
+
+type summaryObject struct {
+	Asset      string `json:"asset"`
+	Component  string `json:"component,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Modifiers are the field managers whose changes a drift correction reverted
+	Modifiers []engine.FieldModifier `json:"modifiers,omitempty"`
+}
+
+type summaryError struct {
+	Asset   string             `json:"asset"`
+	Reason  engine.ErrorReason `json:"reason,omitempty"`
+	Message string             `json:"message"`
+}
+
+// newReconcileSummary sums up the reports of a pass. Applied objects are drift
+// corrections when the report names the managers whose changes were reverted.
+func newReconcileSummary(hco *unstructured.Unstructured, reports map[string]engine.ObjectReport, now time.Time) *reconcileSummary {
+	summary := &reconcileSummary{
+		HCO:    summaryHCO{Namespace: hco.GetNamespace(), Name: hco.GetName(), Generation: hco.GetGeneration()},
+		Time:   now.UTC(),
+		Assets: make(map[string]int),
+	}
+	for _, asset := range slices.Sorted(maps.Keys(reports)) {
+		report := reports[asset]
+		summary.Assets[string(report.State)]++
+		object := summaryObject{
+			Asset:      report.Asset,
+			Component:  report.Component,
+			APIVersion: report.APIVersion,
+			Kind:       report.Kind,
+			Namespace:  report.Namespace,
+			Name:       report.Name,
+		}
+		switch report.State {
+		case engine.ObjectApplied:
+			if len(report.Modifiers) > 0 {
+				object.Modifiers = report.Modifiers
+				summary.DriftCorrections = append(summary.DriftCorrections, object)
+			} else {
+				summary.Changed = append(summary.Changed, object)
+			}
+		case engine.ObjectFailed:
+			summary.Errors = append(summary.Errors, summaryError{Asset: report.Asset, Reason: report.Reason, Message: report.Message})
+		}
+	}
+	return summary
+}
+
+// eventful reports whether the pass changed or failed anything; quiet resyncs are not posted
+func (s *reconcileSummary) eventful() bool {
+	return len(s.Changed) > 0 || len(s.DriftCorrections) > 0 || len(s.Errors) > 0
+}
+
+// webhookNotifier posts reconcile summaries to a NotificationWebhook. Reconciles only
+// queue them; run posts them in the background, so a slow receiver never stalls a reconcile.
+type webhookNotifier struct {
+	webhook NotificationWebhook
+	client  *http.Client
+	queue   chan *reconcileSummary
+}
+
+// enqueue queues summary for delivery and reports whether it fit; a full queue drops it
+func (n *webhookNotifier) enqueue(summary *reconcileSummary) bool {
+	select {
+	case n.queue <- summary:
+		return true
+	default:
+		return false
+	}
+}
+
+// run delivers queued summaries until ctx is done
+func (n *webhookNotifier) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case summary := <-n.queue:
+			n.deliver(ctx, summary)
+		}
+	}
+}
+
+// deliver posts summary and counts the result. Like the inventory, notifications are
+// informational: a failed post is logged and counted, not retried.
+func (n *webhookNotifier) deliver(ctx context.Context, summary *reconcileSummary) {
+	if err := n.post(ctx, summary); err != nil {
+		observability.IncNotification("failed")
+		log.FromContext(ctx).Error(err, "Failed to post reconcile summary", "url", redactURL(n.webhook.URL))
+		return
+	}
+	observability.IncNotification("delivered")
+}
+
+// post sends summary, signed when a secret file is configured
+func (n *webhookNotifier) post(ctx context.Context, summary *reconcileSummary) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "virt-platform-autopilot")
+	if n.webhook.SecretFile != "" {
+		secret, err := os.ReadFile(n.webhook.SecretFile)
+		if err != nil {
+			return fmt.Errorf("failed to read notification webhook secret: %w", err)
+		}
+		req.Header.Set(NotificationSignatureHeader, signNotification(bytes.TrimSpace(secret), body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// signNotification returns the NotificationSignatureHeader value of body
+func signNotification(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyReconcile queues the summary of the pass that just ended for delivery, when it
+// changed or failed anything. When the receiver falls behind and the queue is full, the
+// summary is dropped and counted rather than holding up the reconcile.
+func (r *PlatformReconciler) notifyReconcile(ctx context.Context, hco *unstructured.Unstructured, reports map[string]engine.ObjectReport) {
+	if r.notifier == nil {
+		return
+	}
+	summary := newReconcileSummary(hco, reports, time.Now())
+	if !summary.eventful() {
+		return
+	}
+	summary.Shard = r.shard.Name
+	summary.CatalogVersion = r.registry.CatalogVersion()
+
+	if !r.notifier.enqueue(summary) {
+		observability.IncNotification("dropped")
+		log.FromContext(ctx).Info("Notification queue full, dropping reconcile summary",
+			"url", redactURL(r.notifier.webhook.URL), "queueSize", notificationQueueSize)
+	}
+}
+
+// redactURL drops credentials and the query, which may hold a token, from a URL for logging
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// ConditionReconciled is the HCO status condition whose observedGeneration is the HCO
+// generation the autopilot last fully reconciled. Once it equals metadata.generation,
+// the latest HCO edit has been acted upon.
+const ConditionReconciled = "PlatformAutopilotReconciled"
+
+// recordObservedGeneration reports that every asset was reconciled against hco, the
+// effective HCO the render context was built from. Its generation already includes the
+// golden config the autopilot applied, so the autopilot's own edit does not look pending.
+func (r *PlatformReconciler) recordObservedGeneration(ctx context.Context, hco *unstructured.Unstructured) {
+	key := types.NamespacedName{Namespace: hco.GetNamespace(), Name: hco.GetName()}
+	generation := hco.GetGeneration()
+	observability.SetHCOObservedGeneration(key.Namespace, key.Name, generation)
+
+	condition := metav1.Condition{
+		Type:   ConditionReconciled,
+		Status: metav1.ConditionTrue,
+		Reason: "ReconcileSucceeded",
+		// The message changes with the generation, so each new generation rewrites the condition
+		Message:            fmt.Sprintf("Generation %d of the HyperConverged is fully reconciled", generation),
+		ObservedGeneration: generation,
+	}
+	if err := r.setHCOCondition(ctx, key, condition, true); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to update HCO reconciled condition", "error", err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+func TestRecordObservedGeneration(t *testing.T) {
+	observability.HCOObservedGeneration.Reset()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetGeneration(3)
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).WithStatusSubresource(hco).Build()
+	r := &PlatformReconciler{Client: fakeClient}
+	key := types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}
+	ctx := context.Background()
+
+	condition := func() map[string]any {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := fakeClient.Get(ctx, key, live); err != nil {
+			t.Fatalf("failed to get HCO: %v", err)
+		}
+		conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+		for _, c := range conditions {
+			if m := c.(map[string]any); m["type"] == ConditionReconciled {
+				return m
+			}
+		}
+		return nil
+	}
+	gauge := observability.HCOObservedGeneration.WithLabelValues(key.Namespace, key.Name)
+
+	reconciled := hco.DeepCopy()
+	reconciled.SetGeneration(2)
+	r.recordObservedGeneration(ctx, reconciled)
+
+	// The condition reports the reconciled generation, not the newer live one
+	c := condition()
+	if c == nil || c["status"] != "True" || c["observedGeneration"] != int64(2) {
+		t.Fatalf("condition = %v, want True with observedGeneration 2", c)
+	}
+	if val := testutil.ToFloat64(gauge); val != 2 {
+		t.Errorf("hco_observed_generation = %v, want 2", val)
+	}
+
+	reconciled.SetGeneration(3)
+	r.recordObservedGeneration(ctx, reconciled)
+	if c := condition(); c["observedGeneration"] != int64(3) {
+		t.Errorf("observedGeneration = %v after the next reconcile, want 3", c["observedGeneration"])
+	}
+	if val := testutil.ToFloat64(gauge); val != 3 {
+		t.Errorf("hco_observed_generation = %v, want 3", val)
+	}
+}
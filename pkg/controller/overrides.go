@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// overridesConfigMapRequests maps a changed ConfigMap to the in-scope HCOs that name it
+// in their overrides annotation
+func (r *PlatformReconciler) overridesConfigMapRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	hcoList := &unstructured.UnstructuredList{}
+	hcoList.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := r.List(ctx, hcoList, client.InNamespace(o.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list HCOs for overrides ConfigMap", "configMap", o.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range hcoList.Items {
+		hco := &hcoList.Items[i]
+		if !r.isWatchedNamespace(hco.GetNamespace()) ||
+			hco.GetAnnotations()[overrides.AnnotationOverridesConfigMap] != o.GetName() {
+			continue
+		}
+		observability.IncReconcileTrigger(observability.TriggerOverridesChange)
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: hco.GetName(), Namespace: hco.GetNamespace()},
+		})
+	}
+	return requests
+}
+
+// checkOverrideAssets reports overrides ConfigMap keys that name no asset in the catalog.
+// Such a patch never applies, which is almost always a typo in the asset name.
+func (r *PlatformReconciler) checkOverrideAssets(ctx context.Context, hco *unstructured.Unstructured, patches map[string]overrides.AssetPatch) {
+	var unknown []string
+	for asset := range patches {
+		if _, err := r.registry.GetAsset(asset); err != nil {
+			unknown = append(unknown, asset)
+		}
+	}
+	if len(unknown) == 0 {
+		return
+	}
+	sort.Strings(unknown)
+
+	configMap := hco.GetAnnotations()[overrides.AnnotationOverridesConfigMap]
+	reason := fmt.Sprintf("no asset named %v in the catalog", unknown)
+	log.FromContext(ctx).Info("Overrides ConfigMap has patches for unknown assets",
+		"configMap", configMap, "assets", unknown)
+	if r.eventRecorder != nil {
+		r.eventRecorder.InvalidPatch(hco, "ConfigMap", hco.GetNamespace(), configMap, reason)
+	}
+}
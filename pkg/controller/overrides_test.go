@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+func TestOverridesConfigMapRequests(t *testing.T) {
+	referencing := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	referencing.SetAnnotations(map[string]string{overrides.AnnotationOverridesConfigMap: "autopilot-overrides"})
+	other := pkgcontext.NewMockHCO("tenant", "tenant-ns")
+	other.SetAnnotations(map[string]string{overrides.AnnotationOverridesConfigMap: "autopilot-overrides"})
+
+	fakeClient := fake.NewClientBuilder().WithObjects(referencing, other).Build()
+	r := &PlatformReconciler{Client: fakeClient, Namespace: "openshift-cnv"}
+	r.SetWatchNamespaces([]string{AllNamespaces})
+
+	configMap := func(name, namespace string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		want      []types.NamespacedName
+	}{
+		{"referenced", configMap("autopilot-overrides", "openshift-cnv"),
+			[]types.NamespacedName{{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}}},
+		{"other name", configMap("kubevirt-config", "openshift-cnv"), nil},
+		{"same name in a namespace without a referencing HCO", configMap("autopilot-overrides", "default"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := r.overridesConfigMapRequests(context.Background(), tt.configMap)
+			if len(requests) != len(tt.want) {
+				t.Fatalf("requests = %v, want %v", requests, tt.want)
+			}
+			for i, req := range requests {
+				if req.NamespacedName != tt.want[i] {
+					t.Errorf("request %d = %v, want %v", i, req.NamespacedName, tt.want[i])
+				}
+			}
+		})
+	}
+}
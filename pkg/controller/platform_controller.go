@@ -20,46 +20,99 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
 )
 
+// AllNamespaces is the --watch-namespaces value that selects HyperConverged CRs in every namespace.
+const AllNamespaces = "*"
+
 // PlatformReconciler reconciles the virt platform based on HCO state
 type PlatformReconciler struct {
 	client.Client
 	Namespace string
 
+	// watchNamespaces lists additional namespaces whose HCOs are reconciled (nil = Namespace only).
+	// allNamespaces overrides it and reconciles HCOs cluster-wide.
+	watchNamespaces map[string]bool
+	allNamespaces   bool
+
+	apiReader           client.Reader // Uncached, for user-owned objects outside the label-filtered cache
 	loader              *assets.Loader
 	registry            *assets.Registry
+	catalogMu           sync.RWMutex // Held by Reconcile so the catalog is not replaced mid-reconcile
 	patcher             *engine.Patcher
 	tombstoneReconciler *engine.TombstoneReconciler
 	contextBuilder      *RenderContextBuilder
-	conditionEvaluator  *assets.DefaultConditionEvaluator
 	crdChecker          *util.CRDChecker
 	eventRecorder       *util.EventRecorder
-	watchedCRDs         map[string]bool    // Track CRDs we're watching to avoid restart loops
-	watchedCRDsMu       sync.RWMutex       // Protects watchedCRDs from concurrent access
-	shutdownFunc        context.CancelFunc // Graceful shutdown instead of os.Exit
-	shutdownMu          sync.Mutex         // Protects shutdownFunc
+	watchedCRDs         map[string]bool          // Track CRDs we're watching to avoid restart loops
+	watchedCRDsMu       sync.RWMutex             // Protects watchedCRDs from concurrent access
+	shutdownFunc        context.CancelFunc       // Graceful shutdown instead of os.Exit
+	shutdownMu          sync.Mutex               // Protects shutdownFunc
+	cacheStatsInterval  time.Duration            // Cache metrics collection period (0 = disabled)
+	cacheSyncTimeout    time.Duration            // Startup informer sync bound (0 = DefaultCacheSyncTimeout)
+	cacheWarmup         *cacheWarmup             // Holds reconciles back until the caches synced (nil = no gate)
+	labelRepairInterval time.Duration            // Label repair period (0 = disabled)
+	labelRepairMode     LabelRepairMode          // What label repair does with unlabeled objects
+	nodeEventDebounce   time.Duration            // Node watch coalescing window (0 = no node watch)
+	rateLimiter         RateLimiterOptions       // Retry backoff of failed reconciles (zero = defaults)
+	failures            failureTracker           // Consecutive reconcile failures per HCO
+	expiries            expiryTracker            // Expired exclusions and overrides already announced
+	shard               Shard                    // Components this controller owns (zero = all)
+	managedResources    *managedResourceExporter // ManagedResource inventory (nil = disabled)
+	conditionEvaluation ConditionEvaluation      // Cluster-querying condition bounds (zero = defaults)
+	assetHealth         assetHealth              // Last asset pass per HCO, for AssetsReadyzCheck
+	assetStatus         *statusAggregator        // Asset outcomes of the running pass, for AutopilotStatus and notifications
+	notifier            *webhookNotifier         // Reconcile summaries to a webhook (nil = disabled)
+	assetErrorThreshold float64                  // Failed asset fraction failing AssetsReadyzCheck (0 = default)
+	ownerReferences     bool                     // Own the objects in the HCO namespace by its AutopilotDeployment
+
+	// Remote catalog refresh, see SetRemoteCatalog
+	remoteCatalog          *assets.RemoteCatalog
+	remoteCatalogDigest    string
+	catalogRefreshInterval time.Duration
+	catalogChanged         chan event.GenericEvent
+
+	// Background applies of objects whose admission was unavailable, see SetAsyncApply
+	asyncApply   engine.AsyncApply
+	applyRetries chan event.GenericEvent
+
+	// AutopilotConfig reload, see LoadConfig
+	configMu     sync.Mutex
+	configPath   string
+	configDigest string // Checksum of the config the mutators were built from
+	configFailed string // Checksum of the last config that failed to load, logged once
+	mutators     []engine.Mutator
 }
 
 // NewPlatformReconciler creates a new platform reconciler
@@ -73,19 +126,83 @@ func NewPlatformReconciler(c client.Client, apiReader client.Reader, namespace s
 	if err != nil {
 		return nil, fmt.Errorf("failed to create asset registry: %w", err)
 	}
+	recordCatalogMetrics(registry)
+
+	contextBuilder := NewRenderContextBuilder(c)
+	contextBuilder.history = newHardwareHistory()
+	reader := client.Reader(c)
+	if apiReader != nil {
+		contextBuilder.SetAPIReader(apiReader)
+		reader = apiReader
+	}
 
-	return &PlatformReconciler{
+	r := &PlatformReconciler{
 		Client:              c,
 		Namespace:           namespace,
+		apiReader:           reader,
 		loader:              loader,
 		registry:            registry,
 		patcher:             engine.NewPatcher(c, apiReader, loader),
 		tombstoneReconciler: engine.NewTombstoneReconciler(c, loader),
-		contextBuilder:      NewRenderContextBuilder(c),
-		conditionEvaluator:  &assets.DefaultConditionEvaluator{},
+		contextBuilder:      contextBuilder,
 		crdChecker:          util.NewCRDChecker(apiReader), // Use apiReader (not cache-dependent)
 		watchedCRDs:         make(map[string]bool),
-	}, nil
+		assetStatus:         &statusAggregator{},
+	}
+	r.patcher.SetInventorySink(r.assetStatus)
+	r.tombstoneReconciler.SetDeletionRecorder(r.patcher.ExpectDeletion)
+	return r, nil
+}
+
+// recordCatalogMetrics exports the composition and version of the active catalog
+func recordCatalogMetrics(registry *assets.Registry) {
+	observability.CatalogAssets.Reset()
+	for _, asset := range registry.ListAssets(nil) {
+		observability.CatalogAssets.WithLabelValues(asset.Component, string(asset.Install), strconv.Itoa(asset.Phase)).Inc()
+	}
+	observability.SetCatalogInfo(registry.CatalogVersion(), registry.CatalogDigest())
+}
+
+// Catalog returns the loader and registry of the catalog in use. Both keep serving
+// the current catalog when SetCatalog or a remote catalog refresh replaces it.
+func (r *PlatformReconciler) Catalog() (*assets.Loader, *assets.Registry) {
+	return r.loader, r.registry
+}
+
+// SetAsyncApply makes objects whose admission webhook is unavailable or slow be retried
+// in the background instead of failing the pass; a zero timeout disables it
+func (r *PlatformReconciler) SetAsyncApply(settings engine.AsyncApply) {
+	r.asyncApply = settings
+	r.patcher.SetAsyncApply(settings)
+}
+
+// notifyApplyRetry enqueues every HCO when an object is due for a retry or its
+// background apply ended. A pending notification already covers this one.
+func (r *PlatformReconciler) notifyApplyRetry() {
+	select {
+	case r.applyRetries <- event.GenericEvent{Object: &unstructured.Unstructured{}}:
+	default:
+	}
+}
+
+// SetCatalog replaces the asset catalog, waiting for a running reconcile to finish
+func (r *PlatformReconciler) SetCatalog(loader *assets.Loader, registry *assets.Registry) {
+	r.catalogMu.Lock()
+	r.loader.Replace(loader)
+	r.registry.Replace(registry)
+	r.catalogMu.Unlock()
+	recordCatalogMetrics(registry)
+}
+
+// SetRemoteCatalog makes the controller re-fetch source every interval. digest is the
+// checksum of the remote catalog already installed with SetCatalog, or "" if the
+// embedded catalog is in use because the initial fetch failed.
+func (r *PlatformReconciler) SetRemoteCatalog(source *assets.RemoteCatalog, interval time.Duration, digest string) {
+	r.remoteCatalog = source
+	r.catalogRefreshInterval = interval
+	r.remoteCatalogDigest = digest
+	r.catalogChanged = make(chan event.GenericEvent, 1)
+	observability.SetCatalogRemote(digest != "")
 }
 
 // SetEventRecorder sets the event recorder for this reconciler
@@ -105,6 +222,241 @@ func (r *PlatformReconciler) SetEventRecorder(recorder *util.EventRecorder) {
 	}
 }
 
+// SetMutators installs the AutopilotConfig policy mutators on the patcher
+func (r *PlatformReconciler) SetMutators(mutators []engine.Mutator) {
+	r.configMu.Lock()
+	r.mutators = mutators
+	r.configMu.Unlock()
+	if r.patcher != nil {
+		r.patcher.SetMutators(mutators)
+	}
+}
+
+// Mutators returns the apply mutators in use, which a config reload may replace
+func (r *PlatformReconciler) Mutators() []engine.Mutator {
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+	return r.mutators
+}
+
+// SetHardwareRemovalGracePeriod enables hardware churn damping on the render context builder
+func (r *PlatformReconciler) SetHardwareRemovalGracePeriod(gracePeriod time.Duration) {
+	if r.contextBuilder != nil {
+		r.contextBuilder.SetHardwareRemovalGracePeriod(gracePeriod)
+	}
+}
+
+// HardwareHistory returns the recent changes of the hardware detection results, newest first
+func (r *PlatformReconciler) HardwareHistory() []HardwareSample {
+	return r.contextBuilder.history.list()
+}
+
+// SetRateLimiterOptions sets the retry backoff used for failed reconciles.
+// Must be called before SetupWithManager.
+func (r *PlatformReconciler) SetRateLimiterOptions(opts RateLimiterOptions) {
+	r.rateLimiter = opts
+}
+
+// SetDeferRebootsDuringUpgrade enables upgrade safe-mode on the patcher
+func (r *PlatformReconciler) SetDeferRebootsDuringUpgrade(enabled bool) {
+	if r.patcher != nil {
+		r.patcher.SetDeferRebootsDuringUpgrade(enabled)
+	}
+}
+
+// SetBlastRadiusLimits caps the node-rebooting changes and tombstone deletions of a
+// single reconcile; larger batches wait for an acknowledgement on the HCO. 0 disables a limit.
+func (r *PlatformReconciler) SetBlastRadiusLimits(maxRebootChanges, maxDeletions int) {
+	if r.patcher != nil {
+		r.patcher.SetMaxRebootChanges(maxRebootChanges)
+	}
+	if r.tombstoneReconciler != nil {
+		r.tombstoneReconciler.SetMaxDeletions(maxDeletions)
+	}
+}
+
+// SetCanaryRollout rolls node-rebooting changes out to a canary MachineConfigPool first
+func (r *PlatformReconciler) SetCanaryRollout(canary engine.CanaryRollout) {
+	if r.patcher != nil {
+		r.patcher.SetCanaryRollout(canary)
+	}
+}
+
+// SetRenderHistory keeps the last applied versions of every asset in history
+func (r *PlatformReconciler) SetRenderHistory(history *engine.RenderHistory) {
+	if r.patcher != nil {
+		r.patcher.SetRenderHistory(history)
+	}
+}
+
+// SetImageResolver enables digest pinning of image references in rendered assets
+func (r *PlatformReconciler) SetImageResolver(resolver engine.ImageResolver) {
+	if r.patcher != nil {
+		r.patcher.SetImageResolver(resolver)
+	}
+}
+
+// SetCacheStatsInterval enables informer cache metrics (cache_objects, cache_estimated_bytes),
+// collected every interval. Must be called before SetupWithManager; 0 disables collection.
+func (r *PlatformReconciler) SetCacheStatsInterval(interval time.Duration) {
+	r.cacheStatsInterval = interval
+}
+
+// SetCacheSyncTimeout bounds how long the informers of the watched types may take to
+// sync at startup; past it the manager stops. Must be called before SetupWithManager;
+// 0 selects DefaultCacheSyncTimeout.
+func (r *PlatformReconciler) SetCacheSyncTimeout(timeout time.Duration) {
+	r.cacheSyncTimeout = timeout
+}
+
+// SetLabelRepair enables the periodic pass restoring (or, in flag mode, reporting) the
+// managed-by label on objects the autopilot applied. Must be called before SetupWithManager;
+// an interval of 0 disables it.
+func (r *PlatformReconciler) SetLabelRepair(interval time.Duration, mode LabelRepairMode) {
+	r.labelRepairInterval = interval
+	r.labelRepairMode = mode
+}
+
+// SetNodeEventDebounce enables the node watch, so hardware and topology changes are
+// picked up without waiting for the periodic resync. Node events are coalesced into one
+// reconcile per window. Must be called before SetupWithManager; 0 disables the watch.
+func (r *PlatformReconciler) SetNodeEventDebounce(window time.Duration) {
+	r.nodeEventDebounce = window
+}
+
+// SetShard restricts the controller to the assets and tombstones of the shard's
+// components. Must be called before SetupWithManager.
+func (r *PlatformReconciler) SetShard(shard Shard) {
+	r.shard = shard
+	r.tombstoneReconciler.SetKindFilter(shard.Owns)
+}
+
+// SetManagedResourceExport enables mirroring the state of every applied object into
+// ManagedResource objects in the HCO's namespace, when their CRD is installed
+func (r *PlatformReconciler) SetManagedResourceExport(enabled bool) {
+	if !enabled {
+		r.managedResources = nil
+		r.patcher.SetInventorySink(r.assetStatus)
+		return
+	}
+	r.managedResources = newManagedResourceExporter(r.Client, r.crdChecker, func(component string) bool {
+		return r.shard.Owns(component)
+	})
+	r.patcher.SetInventorySink(inventorySinks{r.assetStatus, r.managedResources})
+}
+
+// SetDifferentialSync lets the first reconcile after a start skip the drift check of
+// objects whose desired state did not change since the previous operator applied them
+func (r *PlatformReconciler) SetDifferentialSync(enabled bool) {
+	r.patcher.SetDifferentialSync(enabled)
+}
+
+// SetRecreationLimits bounds how often managed objects deleted outside the autopilot
+// are recreated before they are left deleted for a cool-down
+func (r *PlatformReconciler) SetRecreationLimits(limits engine.RecreationLimits) {
+	r.patcher.SetRecreationLimits(limits)
+}
+
+// SetAssetLogFilter limits per-asset informational logging to the named assets
+func (r *PlatformReconciler) SetAssetLogFilter(names []string) {
+	if r.patcher != nil {
+		r.patcher.SetAssetLogFilter(names)
+	}
+}
+
+// SetWatchNamespaces widens reconciliation to HyperConverged CRs in the given namespaces.
+// The primary Namespace is always included. Passing AllNamespaces ("*") reconciles every
+// HCO in the cluster; each HCO gets its own render context and condition evaluation.
+// Shared objects, cluster-scoped or outside an HCO's namespace, are applied only by the
+// HCO in the primary Namespace (see engine.Patcher.SetSharedObjectOwner).
+func (r *PlatformReconciler) SetWatchNamespaces(namespaces []string) {
+	r.allNamespaces = false
+	r.watchNamespaces = nil
+	for _, ns := range namespaces {
+		ns = strings.TrimSpace(ns)
+		switch ns {
+		case "":
+			continue
+		case AllNamespaces:
+			r.allNamespaces = true
+		default:
+			if r.watchNamespaces == nil {
+				r.watchNamespaces = map[string]bool{r.Namespace: true}
+			}
+			r.watchNamespaces[ns] = true
+		}
+	}
+
+	if r.patcher != nil {
+		var owner types.NamespacedName
+		if r.allNamespaces || r.watchNamespaces != nil {
+			owner = types.NamespacedName{Namespace: r.Namespace, Name: pkgcontext.HCOName}
+		}
+		r.patcher.SetSharedObjectOwner(owner)
+	}
+}
+
+// isWatchedNamespace reports whether HCOs in namespace are in scope for this reconciler.
+func (r *PlatformReconciler) isWatchedNamespace(namespace string) bool {
+	if r.allNamespaces {
+		return true
+	}
+	if r.watchNamespaces == nil {
+		return namespace == r.Namespace
+	}
+	return r.watchNamespaces[namespace]
+}
+
+// hcoRequests returns one reconcile request per in-scope HCO.
+// In the default single-namespace mode this is the well-known HCO name in Namespace;
+// otherwise HCOs are listed from the cache so every tenant is re-evaluated.
+func (r *PlatformReconciler) hcoRequests(ctx context.Context) []reconcile.Request {
+	primary := []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      pkgcontext.HCOName,
+			Namespace: r.Namespace,
+		},
+	}}
+	if !r.allNamespaces && r.watchNamespaces == nil {
+		return primary
+	}
+
+	hcoList := &unstructured.UnstructuredList{}
+	hcoList.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := r.List(ctx, hcoList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list HCOs, enqueueing primary namespace only")
+		return primary
+	}
+
+	var requests []reconcile.Request
+	for i := range hcoList.Items {
+		hco := &hcoList.Items[i]
+		if !r.isWatchedNamespace(hco.GetNamespace()) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: hco.GetName(), Namespace: hco.GetNamespace()},
+		})
+	}
+	return requests
+}
+
+// countTriggers passes every event through, counting it under cause
+func countTriggers(cause string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(client.Object) bool {
+		observability.IncReconcileTrigger(cause)
+		return true
+	})
+}
+
+// enqueueHCOs adds a reconcile request for every in-scope HCO to q, counted under cause.
+func (r *PlatformReconciler) enqueueHCOs(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], cause string) {
+	observability.IncReconcileTrigger(cause)
+	for _, req := range r.hcoRequests(ctx) {
+		q.Add(req)
+	}
+}
+
 // SetShutdownFunc sets the shutdown function for graceful operator restart
 // This allows the reconciler to trigger graceful shutdown instead of os.Exit(0)
 func (r *PlatformReconciler) SetShutdownFunc(shutdownFunc context.CancelFunc) {
@@ -126,26 +478,72 @@ func (r *PlatformReconciler) triggerShutdown() {
 }
 
 // Reconcile reconciles the virt platform
-func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 
+	// Count the requeue this reconcile schedules; the final return refines the cause
+	requeueCause := observability.TriggerPeriodicResync
+	defer func() {
+		r.recordReconcileResult(ctx, req.NamespacedName, err)
+		switch {
+		case err != nil:
+			observability.IncReconcileTrigger(observability.TriggerErrorRetry)
+		case result.RequeueAfter > 0:
+			observability.IncReconcileTrigger(requeueCause)
+		}
+	}()
+
 	logger.Info("Reconciling virt platform",
 		"namespace", req.Namespace,
 		"name", req.Name,
 	)
 
+	if !r.isWatchedNamespace(req.Namespace) {
+		logger.V(1).Info("HCO is outside the watched namespaces, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	// Acting on a partially synced cache would recreate managed objects that are
+	// merely not listed yet, so nothing is applied before every watched type synced
+	if r.cacheWarmup != nil && !r.cacheWarmup.Synced() {
+		logger.V(1).Info("Informer caches not synced yet, deferring reconcile", "recheckIn", cacheWarmupRecheck)
+		requeueCause = observability.TriggerCacheWarmup
+		return ctrl.Result{RequeueAfter: cacheWarmupRecheck}, nil
+	}
+
+	r.reloadConfig(ctx)
+
+	r.catalogMu.RLock()
+	defer r.catalogMu.RUnlock()
+
+	// Support matrix gate: rendering against an HCO API the assets were not written
+	// for could apply stale assumptions, so drift is only reported until an upgrade
+	reportOnly, err := r.checkHCOAPI(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.patcher.SetReportOnly(reportOnly)
+
 	// Get the HyperConverged instance
 	hco := &unstructured.Unstructured{}
 	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
 
-	err := r.Get(ctx, req.NamespacedName, hco)
+	err = r.Get(ctx, req.NamespacedName, hco)
 	if err != nil {
+		if reportOnly != "" && meta.IsNoMatchError(err) {
+			logger.Info("Cannot read the HCO at an unsupported API version, keeping idle", "reason", reportOnly)
+			return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+		}
 		if errors.IsNotFound(err) {
 			logger.Info("HCO not found, skipping reconciliation")
+			observability.DeleteHCOGeneration(req.Namespace, req.Name)
+			observability.DeleteStatusConditions(req.Namespace, req.Name)
+			r.assetHealth.forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
+	observability.SetHCOGeneration(req.Namespace, req.Name, hco.GetGeneration())
 
 	// Opt-in gate: the autopilot is inactive in this early phase unless explicitly enabled.
 	// To activate, set annotation platform.kubevirt.io/autopilot on the HCO CR to either:
@@ -158,21 +556,33 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			"annotation", overrides.AnnotationAutopilotEnabled,
 			"value", "true or comma-separated asset names",
 		)
+		r.assetHealth.forget(req.NamespacedName)
+		observability.DeleteStatusConditions(req.Namespace, req.Name)
 		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
 	}
+	r.recordHCOAPISupport(ctx, hco, reportOnly)
+	if reportOnly != "" {
+		logger.Info("HCO API version not supported, reporting drift without applying", "reason", reportOnly)
+	}
 
-	// Step 0: Process tombstones FIRST (before HCO reconciliation)
-	logger.Info("Processing tombstones")
-	deletedCount, err := r.tombstoneReconciler.ReconcileTombstones(ctx, hco)
-	if err != nil {
-		// Log error but don't fail reconciliation - tombstone cleanup is best-effort
-		logger.Error(err, "Failed to process tombstones (continuing with reconciliation)")
-	} else if deletedCount > 0 {
-		logger.Info("Tombstone processing completed", "deleted", deletedCount)
+	// Step 0: Process tombstones FIRST (before HCO reconciliation); deletions are writes
+	// too, so report-only mode skips them. Tombstoned objects are shared between HCOs,
+	// so only the HCO owning shared objects deletes them.
+	if reportOnly == "" && r.patcher.OwnsSharedObjects(hco) {
+		logger.Info("Processing tombstones")
+		deletedCount, err := r.tombstoneReconciler.ReconcileTombstones(ctx, hco)
+		if err != nil {
+			// Log error but don't fail reconciliation - tombstone cleanup is best-effort
+			logger.Error(err, "Failed to process tombstones (continuing with reconciliation)")
+		} else if deletedCount > 0 {
+			logger.Info("Tombstone processing completed", "deleted", deletedCount)
+		}
 	}
 
 	// Step 1: Apply HCO golden config FIRST (reconcile_order: 0), unless explicitly excluded.
-	if allowlist == nil || allowlist["hco-golden-config"] {
+	if !r.shard.Owns(pkgcontext.HCOKind) {
+		logger.V(1).Info("Skipping HCO golden configuration (owned by another shard)")
+	} else if allowlist == nil || allowlist["hco-golden-config"] {
 		logger.Info("Applying HCO golden configuration")
 		if err := r.reconcileHCO(ctx, hco); err != nil {
 			logger.Error(err, "Failed to reconcile HCO golden config")
@@ -186,6 +596,7 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if err := r.Get(ctx, req.NamespacedName, hco); err != nil {
 		return ctrl.Result{}, err
 	}
+	observability.SetHCOGeneration(req.Namespace, req.Name, hco.GetGeneration())
 
 	// Step 2: Build RenderContext from effective HCO state
 	logger.Info("Building render context from HCO state")
@@ -195,18 +606,92 @@ func (r *PlatformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
-	// Update condition evaluator with current context
-	r.updateConditionEvaluator(hco, renderCtx)
+	// Owner references are a garbage-collection backstop: failing to set them does not fail the reconcile
+	if renderCtx.Owner, err = r.ensureAutopilotDeployment(ctx, hco); err != nil {
+		logger.Error(err, "Failed to ensure AutopilotDeployment, applying without owner references")
+	}
+
+	r.checkOverrideAssets(ctx, hco, renderCtx.OverridePatches)
+	nextExpiry := r.checkExpirations(ctx, hco, renderCtx, time.Now())
+
+	// Report an open maintenance window; the patcher holds corrections back during it
+	maintenanceRemaining := r.recordMaintenanceWindow(ctx, hco, time.Now())
+
+	// Condition evaluation is scoped to this HCO so multiple tenants never share state
+	evaluator := newConditionEvaluator(hco, renderCtx)
+	evaluator.Cluster = r.clusterConditionsFor(ctx, allowlist)
 
 	// Step 3: Reconcile all other assets in reconcile_order
 	logger.Info("Reconciling platform assets")
-	if err := r.reconcileAssets(ctx, renderCtx, allowlist); err != nil {
+	if err := r.reconcileAssets(ctx, renderCtx, evaluator, allowlist); err != nil {
 		logger.Error(err, "Failed to reconcile assets")
 		return ctrl.Result{}, err
 	}
 
 	logger.Info("Successfully reconciled virt platform")
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	// In report-only mode nothing was applied, so the generation was not reconciled
+	if reportOnly == "" {
+		r.recordObservedGeneration(ctx, hco)
+	}
+	after := requeueAfter(renderCtx.Hardware, time.Now())
+	if after < resyncPeriod {
+		requeueCause = observability.TriggerHardwareRelease
+	}
+	if r.patcher.HasDeferred() && deferredRecheckPeriod < after {
+		// Poll for the end of the upgrade so deferred assets land promptly
+		after = deferredRecheckPeriod
+		requeueCause = observability.TriggerDeferredRecheck
+	}
+	if r.patcher.CanaryInProgress() && canaryRecheckPeriod < after {
+		// Follow the canary pool so the other pools resume, or the batch is rolled back, promptly
+		after = canaryRecheckPeriod
+		requeueCause = observability.TriggerCanaryRecheck
+	}
+	if maintenanceRemaining > 0 && maintenanceRemaining < after {
+		// Resume drift correction as soon as the window ends
+		after = maintenanceRemaining
+		requeueCause = observability.TriggerMaintenanceEnd
+	}
+	if cooldown := r.patcher.RecreationCooldownRemaining(); cooldown > 0 && cooldown < after {
+		// Recreate an object left deleted as soon as its cool-down ends
+		after = cooldown
+		requeueCause = observability.TriggerRecreationEnd
+	}
+	if nextExpiry > 0 && nextExpiry < after {
+		// Resume managing what a temporary exclusion or override covered once it expires
+		after = nextExpiry
+		requeueCause = observability.TriggerOverrideExpiry
+	}
+	return ctrl.Result{RequeueAfter: after}, nil
+}
+
+const (
+	// resyncPeriod is the default interval between periodic reconciles
+	resyncPeriod = 5 * time.Minute
+
+	// deferredRecheckPeriod is how often the upgrade state is re-checked while
+	// reboot-triggering assets are deferred by upgrade safe-mode
+	deferredRecheckPeriod = time.Minute
+
+	// canaryRecheckPeriod is how often the canary MachineConfigPool is checked while a
+	// canary rollout or its rollback is in progress
+	canaryRecheckPeriod = time.Minute
+)
+
+// requeueAfter returns resyncPeriod, shortened so the next reconcile happens right
+// after the earliest hardware detector held by churn damping is released.
+func requeueAfter(hardware *pkgcontext.HardwareContext, now time.Time) time.Duration {
+	after := resyncPeriod
+	if hardware == nil {
+		return after
+	}
+	for _, deadline := range hardware.PendingRemoval {
+		// Small margin so the detector is past its deadline when we run
+		if until := deadline.Sub(now) + time.Second; until < after {
+			after = max(until, time.Second)
+		}
+	}
+	return after
 }
 
 // reconcileHCO applies the golden HCO configuration
@@ -225,9 +710,12 @@ func (r *PlatformReconciler) reconcileHCO(ctx context.Context, currentHCO *unstr
 		return fmt.Errorf("failed to build context for HCO: %w", err)
 	}
 
-	// Reconcile HCO using Patched Baseline algorithm
-	applied, err := r.patcher.ReconcileAsset(ctx, hcoAsset, minimalCtx)
+	// Reconcile HCO using Patched Baseline algorithm, bounded like every other asset
+	assetCtx, cancel := r.patcher.WithAssetTimeout(ctx)
+	defer cancel()
+	applied, err := r.patcher.ReconcileAsset(assetCtx, hcoAsset, minimalCtx)
 	if err != nil {
+		r.patcher.ReportAssetError(minimalCtx, hcoAsset.Name, err)
 		return fmt.Errorf("failed to reconcile HCO: %w", err)
 	}
 
@@ -289,19 +777,40 @@ func (r *PlatformReconciler) assetCRDsAvailable(ctx context.Context, asset *asse
 // reconcileAssets reconciles all non-HCO assets.
 // allowlist is nil when all assets are enabled, or a set of asset names to restrict reconciliation.
 // The allowlist is an additional filter on top of the existing opt-in/conditions logic.
-func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkgcontext.RenderContext, allowlist map[string]bool) error {
+func (r *PlatformReconciler) reconcileAssets(
+	ctx context.Context,
+	renderCtx *pkgcontext.RenderContext,
+	evaluator assets.ConditionEvaluator,
+	allowlist map[string]bool,
+) error {
 	logger := log.FromContext(ctx)
 
 	// Get all assets sorted by reconcile_order (HCO should be 0, others 1+)
 	allAssets := r.registry.ListAssetsByReconcileOrder()
 
+	phases, phasesErr := disabledPhases(renderCtx.HCO)
+	if phasesErr != nil {
+		logger.Error(phasesErr, "Invalid disabled-phases annotation, ignoring",
+			"annotation", DisabledPhasesAnnotation,
+		)
+	}
+
 	// Filter out HCO (already reconciled) and check conditions
 	var assetsToReconcile []assets.AssetMetadata
+	conditionFailures := 0
+	skippedPhases := make(map[int]int)
 	for i := range allAssets {
 		asset := &allAssets[i]
 
-		// Skip HCO (already reconciled in step 1)
-		if asset.ReconcileOrder == 0 {
+		// Skip HCO (already reconciled in step 1) and assets of other shards
+		if asset.ReconcileOrder == 0 || !r.shard.Owns(asset.Component) {
+			continue
+		}
+
+		// Disabled phases are skipped before anything of the asset is evaluated
+		if phases[asset.Phase] {
+			skippedPhases[asset.Phase]++
+			r.patcher.CleanupExcludedAsset(asset, renderCtx)
 			continue
 		}
 
@@ -316,11 +825,14 @@ func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkg
 		}
 
 		// Check if asset should be applied based on conditions
-		shouldApply, err := r.registry.ShouldApply(ctx, asset, r.conditionEvaluator)
+		shouldApply, err := r.registry.ShouldApply(ctx, asset, evaluator)
 		if err != nil {
+			err = &engine.ConditionError{Asset: asset.Name, Err: err}
 			logger.Error(err, "Failed to evaluate asset conditions, skipping",
 				"asset", asset.Name,
 			)
+			r.patcher.ReportAssetError(renderCtx, asset.Name, err)
+			conditionFailures++
 			continue
 		}
 
@@ -332,14 +844,36 @@ func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkg
 			continue
 		}
 
+		if asset.Deprecated {
+			observability.SetDeprecatedAsset(asset.Name, asset.ReplacedBy, asset.RemovalVersion, true)
+		}
 		assetsToReconcile = append(assetsToReconcile, *asset)
 	}
 
 	// Reconcile all applicable assets
 	appliedCount, err := r.patcher.ReconcileAssets(ctx, assetsToReconcile, renderCtx)
+	r.recordAssetTimeouts(ctx, renderCtx.HCO, err)
+	r.recordAssetHealth(client.ObjectKeyFromObject(renderCtx.HCO), len(assetsToReconcile), conditionFailures, err)
+	r.recordRebootImpact(ctx, renderCtx.HCO)
+	if r.managedResources != nil {
+		// The inventory is informational: failing to export it does not fail the reconcile
+		if exportErr := r.managedResources.flush(ctx, renderCtx.HCO); exportErr != nil {
+			logger.Error(exportErr, "Failed to export ManagedResources")
+		}
+	}
+	reports := r.assetStatus.take(renderCtx.HCO.GetNamespace())
+	if statusErr := r.updateAutopilotStatus(ctx, renderCtx, reports, skippedPhases); statusErr != nil {
+		logger.Error(statusErr, "Failed to update AutopilotStatus")
+	}
+	r.notifyReconcile(ctx, renderCtx.HCO, reports)
+	// Like the inventory, exclusion status is informational
+	if statusErr := r.updateExclusionStatus(ctx, renderCtx); statusErr != nil {
+		logger.Error(statusErr, "Failed to update AutopilotExclusion status")
+	}
 	logger.Info("Reconciled assets",
 		"total", len(assetsToReconcile),
 		"applied", appliedCount,
+		"skippedPhases", skippedPhases,
 	)
 
 	// Record reconciliation event
@@ -350,49 +884,25 @@ func (r *PlatformReconciler) reconcileAssets(ctx context.Context, renderCtx *pkg
 	return err
 }
 
-// updateConditionEvaluator updates the condition evaluator with current context
-func (r *PlatformReconciler) updateConditionEvaluator(hco *unstructured.Unstructured, ctx *pkgcontext.RenderContext) {
-	// Update hardware context
-	r.conditionEvaluator.HardwareContext = ctx.Hardware.AsMap()
-
-	// Extract feature gates from HCO
-	r.conditionEvaluator.FeatureGates = extractFeatureGates(hco)
-
-	// Extract annotations from HCO
-	r.conditionEvaluator.Annotations = hco.GetAnnotations()
-
-	// Pass through available container images
-	r.conditionEvaluator.Images = ctx.Images
-}
-
-// extractFeatureGates extracts feature gates from HCO v1 spec.
-// In v1 the field is an array of {name: string, state: "Enabled"|"Disabled"} objects.
-func extractFeatureGates(hco *unstructured.Unstructured) map[string]bool {
-	gates := make(map[string]bool)
-
-	featureGates, found, err := unstructured.NestedSlice(hco.Object, "spec", "featureGates")
-	if err != nil || !found {
-		return gates
+// newConditionEvaluator builds a condition evaluator for a single HCO and its render context
+func newConditionEvaluator(hco *unstructured.Unstructured, ctx *pkgcontext.RenderContext) *assets.DefaultConditionEvaluator {
+	return &assets.DefaultConditionEvaluator{
+		HardwareContext: ctx.Hardware.AsMap(),
+		FeatureGates:    ctx.FeatureGates,
+		Annotations:     hco.GetAnnotations(),
+		Images:          ctx.Images,
+		FIPS:            ctx.FIPS,
+		Storage:         ctx.Storage.AsMap(),
+		Network:         ctx.Network.AsMap(),
+		IPFamilies:      ctx.Network.IPFamilySet(),
+		Architectures:   ctx.Architecture.ArchitectureSet(),
 	}
-
-	for _, item := range featureGates {
-		gate, ok := item.(map[string]any)
-		if !ok {
-			continue
-		}
-		name, _ := gate["name"].(string)
-		state, _ := gate["state"].(string)
-		if name != "" {
-			gates[name] = (state != "Disabled")
-		}
-	}
-
-	return gates
 }
 
-// isManagedCRD checks if a CRD is required by at least one declared asset.
+// isManagedCRD checks if a CRD is required by at least one declared asset, or is the
+// AutopilotExclusion CRD, whose objects are watched as well.
 func (r *PlatformReconciler) isManagedCRD(crdName string) bool {
-	return r.registry.IsManagedCRD(crdName)
+	return crdName == overrides.AutopilotExclusionCRDName || r.registry.IsManagedCRD(crdName)
 }
 
 // isWatchedCRD safely checks if a CRD is currently being watched
@@ -432,12 +942,7 @@ func (r *PlatformReconciler) crdEventHandler(ctx context.Context) handler.EventH
 
 			// For non-managed CRDs, just invalidate cache and trigger reconciliation
 			r.crdChecker.InvalidateCache("")
-			q.Add(reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      pkgcontext.HCOName,
-					Namespace: r.Namespace,
-				},
-			})
+			r.enqueueHCOs(ctx, q, observability.TriggerCRDChange)
 		},
 		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 			crd, ok := e.Object.(*apiextensionsv1.CustomResourceDefinition)
@@ -455,12 +960,7 @@ func (r *PlatformReconciler) crdEventHandler(ctx context.Context) handler.EventH
 
 			// For non-managed CRDs, just invalidate cache and trigger reconciliation
 			r.crdChecker.InvalidateCache("")
-			q.Add(reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      pkgcontext.HCOName,
-					Namespace: r.Namespace,
-				},
-			})
+			r.enqueueHCOs(ctx, q, observability.TriggerCRDChange)
 		},
 		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 			crd, ok := e.ObjectNew.(*apiextensionsv1.CustomResourceDefinition)
@@ -473,12 +973,27 @@ func (r *PlatformReconciler) crdEventHandler(ctx context.Context) handler.EventH
 
 			// Invalidate cache and trigger reconciliation
 			r.crdChecker.InvalidateCache("")
-			q.Add(reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      pkgcontext.HCOName,
-					Namespace: r.Namespace,
-				},
-			})
+			r.enqueueHCOs(ctx, q, observability.TriggerCRDChange)
+		},
+	}
+}
+
+// managedObjectHandler reconciles every in-scope HCO on any change to a managed object,
+// and reports deletions to the patcher so the recreation they trigger is rate limited
+func (r *PlatformReconciler) managedObjectHandler() handler.EventHandler {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		observability.IncReconcileTrigger(observability.TriggerManagedResource)
+		return r.hcoRequests(ctx)
+	})
+	return handler.Funcs{
+		CreateFunc:  enqueue.Create,
+		UpdateFunc:  enqueue.Update,
+		GenericFunc: enqueue.Generic,
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if obj, ok := e.Object.(*unstructured.Unstructured); ok {
+				r.patcher.ObjectDeleted(obj)
+			}
+			enqueue.Delete(ctx, e, q)
 		},
 	}
 }
@@ -491,24 +1006,43 @@ func (r *PlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	hco := &unstructured.Unstructured{}
 	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
 
+	// Types held in the informer cache, waited for at startup and reported by the cache stats collector
+	cachedTypes := []cachedType{
+		unstructuredCachedType(pkgcontext.HCOGVK),
+		{gvk: crdGVK, list: &apiextensionsv1.CustomResourceDefinitionList{}},
+		{gvk: corev1.SchemeGroupVersion.WithKind("ConfigMap"), list: &corev1.ConfigMapList{}},
+	}
+
 	// Build controller with HCO watch
-	builder := ctrl.NewControllerManagedBy(mgr).
-		For(hco).
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(hco, builder.WithPredicates(countTriggers(observability.TriggerHCOChange))).
 		Watches(
 			&apiextensionsv1.CustomResourceDefinition{},
 			r.crdEventHandler(ctx),
 		).
+		// Overrides ConfigMaps are only seen in the HCO namespaces the manager caches unfiltered
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.overridesConfigMapRequests),
+		).
+		WithOptions(crcontroller.Options{RateLimiter: newRateLimiter(r.rateLimiter)}).
 		Named("platform")
 
+	if r.nodeEventDebounce > 0 {
+		bldr = bldr.Watches(&corev1.Node{}, newNodeEventDebouncer(r.nodeEventDebounce, r.hcoRequests).handler())
+		cachedTypes = append(cachedTypes, cachedType{gvk: corev1.SchemeGroupVersion.WithKind("Node"), list: &corev1.NodeList{}})
+	}
+
 	// Dynamically add watches for every CRD required by a declared asset.
 	// RequiredCRD is derived from the asset template at load time, so no separate
 	// mapping needs to be maintained when adding new asset files.
 	logger.Info("Discovering managed resource types to watch")
 
 	seenCRDs := make(map[string]bool)
+	var managedTypes []schema.GroupVersionKind
 	for _, asset := range r.registry.ListAssets(nil) {
 		crdName := asset.RequiredCRD
-		if crdName == "" || seenCRDs[crdName] {
+		if crdName == "" || seenCRDs[crdName] || !r.shard.Owns(asset.Component) {
 			continue
 		}
 		seenCRDs[crdName] = true
@@ -560,24 +1094,86 @@ func (r *PlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 		// Track that we're watching this CRD
 		r.markCRDAsWatched(crdName)
+		cachedTypes = append(cachedTypes, unstructuredCachedType(gvk))
+		managedTypes = append(managedTypes, gvk)
 
-		builder = builder.Watches(
-			obj,
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
-				// All managed resources trigger HCO reconciliation
-				return []reconcile.Request{
-					{
-						NamespacedName: types.NamespacedName{
-							Name:      pkgcontext.HCOName,
-							Namespace: r.Namespace,
-						},
-					},
-				}
-			}),
-		)
+		bldr = bldr.Watches(obj, r.managedObjectHandler())
+	}
+
+	exclusions, err := r.exclusionSource(ctx, mgr)
+	if err != nil {
+		logger.Error(err, "Failed to watch AutopilotExclusions, changes are picked up by the periodic resync")
+	} else if exclusions != nil {
+		logger.Info("Adding watch for AutopilotExclusions")
+		bldr = bldr.WatchesRawSource(exclusions)
+	}
+
+	syncTimeout := r.cacheSyncTimeout
+	if syncTimeout == 0 {
+		syncTimeout = DefaultCacheSyncTimeout
+	}
+	r.cacheWarmup = newCacheWarmup(mgr.GetCache(), mgr.GetScheme(), cachedTypes, syncTimeout)
+	if err := mgr.Add(r.cacheWarmup); err != nil {
+		return fmt.Errorf("failed to add cache warmup: %w", err)
+	}
+
+	if r.cacheStatsInterval > 0 {
+		if err := mgr.Add(newCacheStatsCollector(mgr.GetCache(), cachedTypes, r.cacheStatsInterval)); err != nil {
+			return fmt.Errorf("failed to add cache stats collector: %w", err)
+		}
+	}
+
+	if r.labelRepairInterval > 0 {
+		tombstones, err := r.loader.LoadTombstones()
+		if err != nil {
+			logger.Error(err, "Failed to load tombstones, label repair skips tombstoned objects")
+		}
+		repairer := newLabelRepairer(mgr.GetAPIReader(), mgr.GetClient(), managedTypes, tombstones,
+			r.labelRepairMode, r.labelRepairInterval, r.eventRecorder)
+		if err := mgr.Add(repairer); err != nil {
+			return fmt.Errorf("failed to add label repairer: %w", err)
+		}
+	}
+
+	if r.remoteCatalog != nil && r.catalogRefreshInterval > 0 {
+		refresher := &catalogRefresher{
+			reconciler:      r,
+			source:          r.remoteCatalog,
+			interval:        r.catalogRefreshInterval,
+			cacheNamespaces: r.registry.AssetNamespaces(),
+			digest:          r.remoteCatalogDigest,
+		}
+		if err := mgr.Add(refresher); err != nil {
+			return fmt.Errorf("failed to add catalog refresher: %w", err)
+		}
+		bldr = bldr.WatchesRawSource(source.Channel(r.catalogChanged,
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				observability.IncReconcileTrigger(observability.TriggerCatalogChange)
+				return r.hcoRequests(ctx)
+			})))
+	}
+
+	if r.notifier != nil {
+		if err := mgr.Add(manager.RunnableFunc(r.notifier.run)); err != nil {
+			return fmt.Errorf("failed to add notification sender: %w", err)
+		}
+	}
+
+	if r.asyncApply.Timeout > 0 {
+		r.applyRetries = make(chan event.GenericEvent, 1)
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return r.patcher.RunAsyncApplies(ctx, r.notifyApplyRetry)
+		})); err != nil {
+			return fmt.Errorf("failed to add async applier: %w", err)
+		}
+		bldr = bldr.WatchesRawSource(source.Channel(r.applyRetries,
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				observability.IncReconcileTrigger(observability.TriggerApplyRetry)
+				return r.hcoRequests(ctx)
+			})))
 	}
 
-	return builder.Complete(r)
+	return bldr.Complete(r)
 }
 
 // Ensure PlatformReconciler implements reconcile.Reconciler
@@ -17,146 +17,30 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
 )
 
-func TestExtractFeatureGates(t *testing.T) {
-	tests := []struct {
-		name string
-		hco  *unstructured.Unstructured
-		want map[string]bool
-	}{
-		{
-			name: "with feature gates",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{
-					"spec": map[string]any{
-						"featureGates": []any{
-							map[string]any{"name": "FeatureGate1"},
-							map[string]any{"name": "FeatureGate2"},
-							map[string]any{"name": "ExperimentalFeature"},
-						},
-					},
-				},
-			},
-			want: map[string]bool{
-				"FeatureGate1":        true,
-				"FeatureGate2":        true,
-				"ExperimentalFeature": true,
-			},
-		},
-		{
-			name: "disabled feature gate",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{
-					"spec": map[string]any{
-						"featureGates": []any{
-							map[string]any{"name": "EnabledGate"},
-							map[string]any{"name": "DisabledGate", "state": "Disabled"},
-						},
-					},
-				},
-			},
-			want: map[string]bool{
-				"EnabledGate":  true,
-				"DisabledGate": false,
-			},
-		},
-		{
-			name: "feature gate with no state defaults to enabled",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{
-					"spec": map[string]any{
-						"featureGates": []any{
-							map[string]any{"name": "NoStateGate"},
-						},
-					},
-				},
-			},
-			want: map[string]bool{
-				"NoStateGate": true,
-			},
-		},
-		{
-			name: "empty feature gates",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{
-					"spec": map[string]any{
-						"featureGates": []any{},
-					},
-				},
-			},
-			want: map[string]bool{},
-		},
-		{
-			name: "no feature gates field",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{
-					"spec": map[string]any{},
-				},
-			},
-			want: map[string]bool{},
-		},
-		{
-			name: "no spec field",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{},
-			},
-			want: map[string]bool{},
-		},
-		{
-			name: "single feature gate",
-			hco: &unstructured.Unstructured{
-				Object: map[string]any{
-					"spec": map[string]any{
-						"featureGates": []any{
-							map[string]any{"name": "SingleFeature"},
-						},
-					},
-				},
-			},
-			want: map[string]bool{
-				"SingleFeature": true,
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := extractFeatureGates(tt.hco)
-
-			if len(got) != len(tt.want) {
-				t.Errorf("extractFeatureGates() returned %d gates, want %d", len(got), len(tt.want))
-			}
-
-			for gate, enabled := range tt.want {
-				if gotEnabled, exists := got[gate]; !exists {
-					t.Errorf("extractFeatureGates() missing gate %q", gate)
-				} else if gotEnabled != enabled {
-					t.Errorf("extractFeatureGates()[%q] = %v, want %v", gate, gotEnabled, enabled)
-				}
-			}
-
-			for gate := range got {
-				if _, exists := tt.want[gate]; !exists {
-					t.Errorf("extractFeatureGates() has unexpected gate %q", gate)
-				}
-			}
-		})
-	}
-}
-
 func TestNewPlatformReconciler(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -200,6 +84,35 @@ func TestNewPlatformReconciler(t *testing.T) {
 	})
 }
 
+func TestRecordCatalogMetrics(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, fakeClient, "test-namespace")
+	if err != nil {
+		t.Fatalf("NewPlatformReconciler() error = %v", err)
+	}
+
+	want := make(map[[3]string]float64)
+	for _, asset := range reconciler.registry.ListAssets(nil) {
+		want[[3]string{asset.Component, string(asset.Install), strconv.Itoa(asset.Phase)}]++
+	}
+	for labels, count := range want {
+		if got := testutil.ToFloat64(observability.CatalogAssets.WithLabelValues(labels[:]...)); got != count {
+			t.Errorf("catalog_assets%v = %v, want %v", labels, got, count)
+		}
+	}
+	if series := testutil.CollectAndCount(observability.CatalogAssets); series != len(want) {
+		t.Errorf("catalog_assets series = %d, want %d", series, len(want))
+	}
+
+	version := observability.CatalogVersion.WithLabelValues(reconciler.registry.CatalogVersion(), reconciler.registry.CatalogDigest())
+	if got := testutil.ToFloat64(version); got != 1 {
+		t.Errorf("catalog_version = %v, want 1", got)
+	}
+	if count := testutil.CollectAndCount(observability.CatalogVersion); count != 1 {
+		t.Errorf("catalog_version series = %d, want 1", count)
+	}
+}
+
 func TestSetEventRecorder(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -656,3 +569,150 @@ func TestAssetSelectionWithAutopilotAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func newTestHCO(namespace string) *unstructured.Unstructured {
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(pkgcontext.HCOGVK)
+	hco.SetName(pkgcontext.HCOName)
+	hco.SetNamespace(namespace)
+	return hco
+}
+
+func TestWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		want       map[string]bool
+		// tenantShares is whether the HCO in tenant-a applies shared objects
+		tenantShares bool
+	}{
+		{
+			name:         "default watches only the primary namespace",
+			namespaces:   nil,
+			want:         map[string]bool{"openshift-cnv": true, "tenant-a": false, "tenant-b": false},
+			tenantShares: true,
+		},
+		{
+			name:       "explicit list includes the primary namespace",
+			namespaces: []string{"tenant-a", " ", ""},
+			want:       map[string]bool{"openshift-cnv": true, "tenant-a": true, "tenant-b": false},
+		},
+		{
+			name:       "wildcard watches every namespace",
+			namespaces: []string{AllNamespaces},
+			want:       map[string]bool{"openshift-cnv": true, "tenant-a": true, "tenant-b": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().Build()
+			reconciler, err := NewPlatformReconciler(fakeClient, fakeClient, "openshift-cnv")
+			if err != nil {
+				t.Fatalf("NewPlatformReconciler() error = %v", err)
+			}
+			reconciler.SetWatchNamespaces(tt.namespaces)
+
+			for ns, want := range tt.want {
+				if got := reconciler.isWatchedNamespace(ns); got != want {
+					t.Errorf("isWatchedNamespace(%q) = %v, want %v", ns, got, want)
+				}
+			}
+			if !reconciler.patcher.OwnsSharedObjects(newTestHCO("openshift-cnv")) {
+				t.Error("primary HCO does not apply shared objects")
+			}
+			if got := reconciler.patcher.OwnsSharedObjects(newTestHCO("tenant-a")); got != tt.tenantShares {
+				t.Errorf("tenant-a applies shared objects = %v, want %v", got, tt.tenantShares)
+			}
+		})
+	}
+}
+
+func TestHCORequests(t *testing.T) {
+	objs := []client.Object{
+		newTestHCO("openshift-cnv"),
+		newTestHCO("tenant-a"),
+		newTestHCO("tenant-b"),
+	}
+
+	tests := []struct {
+		name       string
+		namespaces []string
+		want       []string
+	}{
+		{
+			name: "single namespace mode enqueues the primary HCO without listing",
+			want: []string{"openshift-cnv"},
+		},
+		{
+			name:       "explicit list enqueues only watched HCOs",
+			namespaces: []string{"tenant-b"},
+			want:       []string{"openshift-cnv", "tenant-b"},
+		},
+		{
+			name:       "wildcard enqueues every HCO",
+			namespaces: []string{AllNamespaces},
+			want:       []string{"openshift-cnv", "tenant-a", "tenant-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
+			reconciler, err := NewPlatformReconciler(fakeClient, fakeClient, "openshift-cnv")
+			if err != nil {
+				t.Fatalf("NewPlatformReconciler() error = %v", err)
+			}
+			reconciler.SetWatchNamespaces(tt.namespaces)
+
+			requests := reconciler.hcoRequests(context.Background())
+			got := make([]string, 0, len(requests))
+			for _, req := range requests {
+				if req.Name != pkgcontext.HCOName {
+					t.Errorf("unexpected request name %q", req.Name)
+				}
+				got = append(got, req.Namespace)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hcoRequests() namespaces = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileTriggerCounting(t *testing.T) {
+	observability.ReconcileTriggersTotal.Reset()
+
+	pred := countTriggers(observability.TriggerHCOChange)
+	if !pred.Generic(event.GenericEvent{Object: newTestHCO("openshift-cnv")}) {
+		t.Error("countTriggers() predicate filtered an event, want all passed through")
+	}
+	if got := testutil.ToFloat64(observability.ReconcileTriggersTotal.WithLabelValues(observability.TriggerHCOChange)); got != 1 {
+		t.Errorf("hco_change triggers = %v, want 1", got)
+	}
+
+	// An HCO without the opt-in annotation is rechecked periodically
+	fakeClient := fake.NewClientBuilder().WithObjects(newTestHCO("openshift-cnv")).Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, fakeClient, "openshift-cnv")
+	if err != nil {
+		t.Fatalf("NewPlatformReconciler() error = %v", err)
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: pkgcontext.HCOName, Namespace: "openshift-cnv"}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := testutil.ToFloat64(observability.ReconcileTriggersTotal.WithLabelValues(observability.TriggerPeriodicResync)); got != 1 {
+		t.Errorf("periodic_resync triggers = %v, want 1", got)
+	}
+
+	// A deleted HCO schedules nothing
+	req.Namespace = "other"
+	reconciler.SetWatchNamespaces([]string{AllNamespaces})
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if count := testutil.CollectAndCount(observability.ReconcileTriggersTotal); count != 2 {
+		t.Errorf("reconcile_triggers_total series = %d, want 2", count)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// ConditionRebootImpact is the HCO status condition predicting which MachineConfigPools
+// the node-rebooting changes of the last pass roll out to
+const ConditionRebootImpact = "PlatformAutopilotRebootImpact"
+
+// SetMaxRebootNodes holds back the node-rebooting changes of a reconcile that are
+// predicted to reboot more nodes than n until acknowledged on the HCO; 0 disables the limit
+func (r *PlatformReconciler) SetMaxRebootNodes(n int) {
+	if r.patcher != nil {
+		r.patcher.SetMaxRebootNodes(n)
+	}
+}
+
+// recordRebootImpact reports the reboot impact of the last pass as ConditionRebootImpact
+func (r *PlatformReconciler) recordRebootImpact(ctx context.Context, hco *unstructured.Unstructured) {
+	key := types.NamespacedName{Namespace: hco.GetNamespace(), Name: hco.GetName()}
+	condition := rebootImpactCondition(r.patcher.RebootImpact())
+	if err := r.setHCOCondition(ctx, key, condition, true); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to update HCO reboot impact condition", "error", err.Error())
+	}
+}
+
+// rebootImpactCondition describes impact, nil when the pass changed nothing node-rebooting
+func rebootImpactCondition(impact *engine.RebootImpact) metav1.Condition {
+	if impact == nil {
+		return metav1.Condition{
+			Type:    ConditionRebootImpact,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NoRebootChanges",
+			Message: "The last reconcile had no node-rebooting changes",
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:   ConditionRebootImpact,
+		Status: metav1.ConditionTrue,
+		Reason: "RolloutStarted",
+		Message: fmt.Sprintf("%d node-rebooting change(s) applied, rolling out to %s: about %d node(s) drain and reboot",
+			impact.Changes, impact.Summary(), impact.Nodes),
+	}
+	if impact.Held {
+		condition.Reason = "AwaitingAcknowledgement"
+		condition.Message = fmt.Sprintf("%d node-rebooting change(s) held back, they would roll out to %s: about %d node(s) would drain and reboot. "+
+			"Set %s=%s on the HyperConverged to proceed",
+			impact.Changes, impact.Summary(), impact.Nodes, engine.BlastRadiusAckAnnotation, impact.Fingerprint)
+	}
+	if len(impact.Unmatched) > 0 {
+		condition.Message += fmt.Sprintf("; %d change(s) select no pool", len(impact.Unmatched))
+	}
+	return condition
+}
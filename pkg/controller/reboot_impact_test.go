@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func TestRebootImpactCondition(t *testing.T) {
+	pools := []engine.PoolImpact{{Pool: "worker", Nodes: 9}, {Pool: "infra", Nodes: 3}}
+
+	tests := []struct {
+		name         string
+		impact       *engine.RebootImpact
+		wantStatus   metav1.ConditionStatus
+		wantReason   string
+		wantContains []string
+	}{
+		{
+			name:       "no node-rebooting changes",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NoRebootChanges",
+		},
+		{
+			name:         "applied batch",
+			impact:       &engine.RebootImpact{Changes: 2, Pools: pools, Nodes: 12},
+			wantStatus:   metav1.ConditionTrue,
+			wantReason:   "RolloutStarted",
+			wantContains: []string{"worker (9 nodes), infra (3 nodes)", "about 12 node(s)"},
+		},
+		{
+			name:         "held batch names the acknowledgement",
+			impact:       &engine.RebootImpact{Changes: 2, Pools: pools, Nodes: 12, Fingerprint: "abc123", Held: true},
+			wantStatus:   metav1.ConditionTrue,
+			wantReason:   "AwaitingAcknowledgement",
+			wantContains: []string{"held back", engine.BlastRadiusAckAnnotation + "=abc123"},
+		},
+		{
+			name:         "changes selecting no pool",
+			impact:       &engine.RebootImpact{Changes: 1, Unmatched: []string{"MachineConfig/50-orphan"}},
+			wantStatus:   metav1.ConditionTrue,
+			wantReason:   "RolloutStarted",
+			wantContains: []string{"no MachineConfigPool", "1 change(s) select no pool"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := rebootImpactCondition(tt.impact)
+			if condition.Type != ConditionRebootImpact {
+				t.Errorf("Type = %q, want %q", condition.Type, ConditionRebootImpact)
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("condition = %s/%s, want %s/%s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(condition.Message, want) {
+					t.Errorf("message %q does not contain %q", condition.Message, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// catalogFetchTimeout bounds one fetch of the remote catalog
+const catalogFetchTimeout = time.Minute
+
+// LoadRemoteCatalog fetches the remote catalog and validates it the way the embedded
+// catalog is validated at startup, plus that every asset file it references exists
+// and parses. It returns the catalog's loader and registry and the archive checksum.
+func LoadRemoteCatalog(ctx context.Context, source *assets.RemoteCatalog) (*assets.Loader, *assets.Registry, string, error) {
+	loader, digest, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("invalid remote catalog %s: %w", digest, err)
+	}
+	if err := validateCatalogFiles(loader, registry); err != nil {
+		return nil, nil, "", fmt.Errorf("invalid remote catalog %s: %w", digest, err)
+	}
+	return loader, registry, digest, nil
+}
+
+// validateCatalogFiles checks that every asset file exists and parses, as a template
+// or as YAML. NewRegistry tolerates missing files, which is fine for the embedded
+// catalog (covered by tests) but not for an archive published out-of-band.
+func validateCatalogFiles(loader *assets.Loader, registry *assets.Registry) error {
+	renderer := engine.NewRenderer(loader)
+	for _, asset := range registry.ListAssets(nil) {
+		if asset.Path == "" {
+			continue
+		}
+		content, err := loader.LoadAsset(asset.Path)
+		if err != nil {
+			return fmt.Errorf("asset %s: %w", asset.Name, err)
+		}
+		if assets.IsTemplate(asset.Path) {
+			_, err = renderer.ParseTemplate(asset.Name, string(content))
+		} else {
+			_, err = assets.ParseMultiYAML(content)
+		}
+		if err != nil {
+			return fmt.Errorf("asset %s: %w", asset.Name, err)
+		}
+	}
+	if _, err := loader.LoadTombstones(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// catalogRefresher retries fetching the remote catalog when it could not be loaded at
+// startup, and installs it once it fetches and validates. The catalog content is
+// pinned, so nothing changes after that and the refresher stops. Until then, and on
+// every failure, the embedded catalog stays in use.
+type catalogRefresher struct {
+	reconciler *PlatformReconciler
+	source     *assets.RemoteCatalog
+	interval   time.Duration
+
+	// cacheNamespaces are the namespaces the informer cache watches per kind, fixed at startup
+	cacheNamespaces map[schema.GroupVersionKind][]string
+	// digest is the checksum of the remote catalog in use, "" while the embedded one is
+	digest string
+}
+
+// Start implements manager.Runnable: the catalog was fetched at startup, so the first
+// retry happens after one interval
+func (c *catalogRefresher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for c.digest == "" {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Every replica serves the catalog through its debug and API endpoints.
+func (c *catalogRefresher) NeedLeaderElection() bool {
+	return false
+}
+
+// refresh fetches the remote catalog once and installs it if it differs from the one in use
+func (c *catalogRefresher) refresh(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("catalog-refresh")
+
+	fetchCtx, cancel := context.WithTimeout(ctx, catalogFetchTimeout)
+	defer cancel()
+	loader, registry, digest, err := LoadRemoteCatalog(fetchCtx, c.source)
+	if err == nil {
+		if err = registry.CheckNamespacesWithin(c.cacheNamespaces); err != nil {
+			err = fmt.Errorf("remote catalog %s needs a restart to be used: %w", digest, err)
+		}
+	}
+	if err != nil {
+		observability.CatalogRefreshFailuresTotal.Inc()
+		if c.digest == "" {
+			logger.Error(err, "Remote catalog unavailable, keeping the embedded catalog", "url", c.source.URL)
+		} else {
+			logger.Error(err, "Remote catalog unavailable, keeping the last loaded remote catalog",
+				"url", c.source.URL, "checksum", c.digest)
+		}
+		return
+	}
+	if digest == c.digest {
+		return
+	}
+
+	c.reconciler.SetCatalog(loader, registry)
+	c.digest = digest
+	observability.SetCatalogRemote(digest != "")
+	logger.Info("Asset catalog replaced",
+		"remote", digest != "",
+		"checksum", digest,
+		"version", registry.CatalogVersion(),
+		"digest", registry.CatalogDigest(),
+	)
+	c.reconciler.notifyCatalogChanged()
+}
+
+// notifyCatalogChanged enqueues every HCO so the new catalog applies without waiting
+// for the periodic resync. A pending notification already covers this one.
+func (r *PlatformReconciler) notifyCatalogChanged() {
+	select {
+	case r.catalogChanged <- event.GenericEvent{Object: &unstructured.Unstructured{}}:
+	default:
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	embeddedassets "github.com/kubevirt/virt-platform-autopilot/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// catalogServer serves a zip of the (modified) embedded assets; a nil
+// archive makes it answer 404
+type catalogServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	archive []byte
+}
+
+func newCatalogServer(t *testing.T) *catalogServer {
+	s := &catalogServer{}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.archive == nil {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(s.archive)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *catalogServer) serve(t *testing.T, mutate func(files map[string][]byte)) {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := fs.WalkDir(embeddedassets.EmbeddedFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files[path], err = fs.ReadFile(embeddedassets.EmbeddedFS, path)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mutate != nil {
+		mutate(files)
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for path, data := range files {
+		f, err := w.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archive = buf.Bytes()
+}
+
+// source returns a remote catalog pinned to the archive served now
+func (s *catalogServer) source() *assets.RemoteCatalog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := sha256.Sum256(s.archive)
+	return &assets.RemoteCatalog{URL: s.URL + "/catalog.zip", SHA256: hex.EncodeToString(sum[:]), Client: s.Client()}
+}
+
+// withCatalogVersion sets the version of the served catalog
+func withCatalogVersion(version string) func(map[string][]byte) {
+	return func(files map[string][]byte) {
+		catalog := string(files[assets.CatalogPath])
+		lines := strings.Split(catalog, "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(line, "version:") {
+				lines[i] = "version: " + version
+			}
+		}
+		files[assets.CatalogPath] = []byte(strings.Join(lines, "\n"))
+	}
+}
+
+func TestLoadRemoteCatalog(t *testing.T) {
+	server := newCatalogServer(t)
+
+	t.Run("accepts a valid catalog", func(t *testing.T) {
+		server.serve(t, withCatalogVersion("hotfix-1"))
+		_, registry, digest, err := LoadRemoteCatalog(context.Background(), server.source())
+		if err != nil {
+			t.Fatalf("LoadRemoteCatalog() error = %v", err)
+		}
+		if registry.CatalogVersion() != "hotfix-1" || digest == "" {
+			t.Errorf("LoadRemoteCatalog() = version %q, checksum %q", registry.CatalogVersion(), digest)
+		}
+	})
+
+	t.Run("rejects a missing asset file", func(t *testing.T) {
+		server.serve(t, func(files map[string][]byte) {
+			delete(files, "active/metrics-exporter/metrics-exporter.yaml.tpl")
+		})
+		_, _, _, err := LoadRemoteCatalog(context.Background(), server.source())
+		if err == nil || !strings.Contains(err.Error(), "asset metrics-exporter:") {
+			t.Errorf("LoadRemoteCatalog() error = %v, want the missing asset named", err)
+		}
+	})
+
+	t.Run("rejects an unparsable template", func(t *testing.T) {
+		server.serve(t, func(files map[string][]byte) {
+			files["active/metrics-exporter/metrics-exporter.yaml.tpl"] = []byte("kind: {{ .HCO")
+		})
+		_, _, _, err := LoadRemoteCatalog(context.Background(), server.source())
+		if err == nil || !strings.Contains(err.Error(), "failed to parse template") {
+			t.Errorf("LoadRemoteCatalog() error = %v, want a template parse error", err)
+		}
+	})
+}
+
+func TestCatalogRefresher(t *testing.T) {
+	server := newCatalogServer(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, nil, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	embeddedVersion := reconciler.registry.CatalogVersion()
+	server.serve(t, withCatalogVersion("hotfix-1"))
+	reconciler.SetRemoteCatalog(server.source(), 0, "")
+	refresher := &catalogRefresher{
+		reconciler:      reconciler,
+		source:          reconciler.remoteCatalog,
+		cacheNamespaces: reconciler.registry.AssetNamespaces(),
+	}
+	_, registry := reconciler.Catalog()
+
+	// The remote catalog could not be fetched at startup: the embedded one stays
+	failures := testutil.ToFloat64(observability.CatalogRefreshFailuresTotal)
+	server.mu.Lock()
+	archive := server.archive
+	server.archive = nil
+	server.mu.Unlock()
+	refresher.refresh(context.Background())
+	if registry.CatalogVersion() != embeddedVersion {
+		t.Errorf("catalog version = %q after a failed fetch, want the embedded %q", registry.CatalogVersion(), embeddedVersion)
+	}
+	if got := testutil.ToFloat64(observability.CatalogRefreshFailuresTotal); got != failures+1 {
+		t.Errorf("catalog_refresh_failures_total = %v, want %v", got, failures+1)
+	}
+
+	// A retry installs it once it is served
+	server.mu.Lock()
+	server.archive = archive
+	server.mu.Unlock()
+	refresher.refresh(context.Background())
+	if registry.CatalogVersion() != "hotfix-1" {
+		t.Fatalf("catalog version = %q after refresh, want hotfix-1", registry.CatalogVersion())
+	}
+	if got := testutil.ToFloat64(observability.CatalogRemote); got != 1 {
+		t.Errorf("catalog_remote = %v, want 1", got)
+	}
+	select {
+	case <-reconciler.catalogChanged:
+	default:
+		t.Error("expected a reconcile to be triggered by the new catalog")
+	}
+
+	// Unchanged content is not installed again
+	refresher.refresh(context.Background())
+	if len(reconciler.catalogChanged) != 0 {
+		t.Error("expected no reconcile for an unchanged catalog")
+	}
+
+	// A fetch failure keeps the last loaded remote catalog, so rendered objects do not flip
+	server.mu.Lock()
+	server.archive = nil
+	server.mu.Unlock()
+	refresher.refresh(context.Background())
+	if registry.CatalogVersion() != "hotfix-1" {
+		t.Errorf("catalog version = %q after a failed fetch, want the last loaded hotfix-1", registry.CatalogVersion())
+	}
+	if got := testutil.ToFloat64(observability.CatalogRemote); got != 1 {
+		t.Errorf("catalog_remote = %v, want 1", got)
+	}
+
+	// So does content that does not match the pin
+	server.serve(t, withCatalogVersion("tampered"))
+	refresher.refresh(context.Background())
+	if registry.CatalogVersion() != "hotfix-1" {
+		t.Errorf("catalog version = %q after a checksum mismatch, want the last loaded hotfix-1", registry.CatalogVersion())
+	}
+	if len(reconciler.catalogChanged) != 0 {
+		t.Error("expected no reconcile after a failed refresh")
+	}
+
+	// The pinned content cannot change once loaded, so the refresher stops
+	refresher.interval = time.Hour
+	if err := refresher.Start(context.Background()); err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
+
+func TestCatalogRefresherNeedsRestart(t *testing.T) {
+	server := newCatalogServer(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	reconciler, err := NewPlatformReconciler(fakeClient, nil, "openshift-cnv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	embeddedVersion := reconciler.registry.CatalogVersion()
+
+	// A catalog rendering into a namespace the cache does not watch needs a restart
+	server.serve(t, func(files map[string][]byte) {
+		withCatalogVersion("hotfix-2")(files)
+		for path, data := range files {
+			if strings.HasPrefix(path, "active/") && bytes.Contains(data, []byte("namespace: kubevirt-metrics-exporter")) {
+				files[path] = bytes.ReplaceAll(data, []byte("namespace: kubevirt-metrics-exporter"), []byte("namespace: elsewhere"))
+			}
+		}
+	})
+	reconciler.SetRemoteCatalog(server.source(), 0, "")
+	refresher := &catalogRefresher{
+		reconciler:      reconciler,
+		source:          reconciler.remoteCatalog,
+		cacheNamespaces: reconciler.registry.AssetNamespaces(),
+	}
+	failures := testutil.ToFloat64(observability.CatalogRefreshFailuresTotal)
+	refresher.refresh(context.Background())
+	_, registry := reconciler.Catalog()
+	if registry.CatalogVersion() != embeddedVersion {
+		t.Errorf("catalog version = %q, want the embedded %q", registry.CatalogVersion(), embeddedVersion)
+	}
+	if got := testutil.ToFloat64(observability.CatalogRefreshFailuresTotal); got != failures+1 {
+		t.Errorf("catalog_refresh_failures_total = %v, want %v", got, failures+1)
+	}
+}
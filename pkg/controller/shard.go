@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// shardNamePattern keeps shard names usable in lease names and condition types
+var shardNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Shard restricts a controller to the assets of some components. Running one deployment
+// per shard isolates slow components, such as MachineConfig whose changes roll through
+// the pools, from fast ones: each shard has its own workqueue and retry backoff, its own
+// leader election and its own HCO conditions. The shards of a cluster must own disjoint
+// component sets that together cover every component; that is not checked, since each
+// shard only knows its own settings.
+type Shard struct {
+	// Name identifies the shard; "" is the unsharded controller that owns every component
+	Name string
+	// Components are the asset components (see AssetMetadata.Component) the shard owns,
+	// or, with Exclude, the ones it does not own
+	Components map[string]bool
+	Exclude    bool
+}
+
+// NewShard builds a shard from comma-separated component lists; at most one may be set
+func NewShard(name, components, excludeComponents string) (Shard, error) {
+	shard := Shard{Name: name}
+	if name == "" {
+		if components != "" || excludeComponents != "" {
+			return Shard{}, fmt.Errorf("component filters require a shard name")
+		}
+		return shard, nil
+	}
+	if !shardNamePattern.MatchString(name) {
+		return Shard{}, fmt.Errorf("invalid shard name %q: must be lowercase alphanumerics and '-'", name)
+	}
+
+	list := components
+	switch {
+	case components != "" && excludeComponents != "":
+		return Shard{}, fmt.Errorf("components and excluded components are mutually exclusive")
+	case components == "" && excludeComponents == "":
+		return Shard{}, fmt.Errorf("shard %s must list its components or the components it excludes", name)
+	case excludeComponents != "":
+		list = excludeComponents
+		shard.Exclude = true
+	}
+	shard.Components = make(map[string]bool)
+	for _, component := range strings.Split(list, ",") {
+		if component = strings.TrimSpace(component); component != "" {
+			shard.Components[component] = true
+		}
+	}
+	return shard, nil
+}
+
+// Owns reports whether the shard reconciles assets of component. Tombstones are matched
+// by the kind of the object they delete, which is the component of its former asset.
+func (s Shard) Owns(component string) bool {
+	if s.Components == nil {
+		return true
+	}
+	return s.Components[component] != s.Exclude
+}
+
+// ConditionType returns the HCO condition type the shard reports conditionType as,
+// so shards never overwrite each other's status
+func (s Shard) ConditionType(conditionType string) string {
+	if s.Name == "" {
+		return conditionType
+	}
+	return conditionType + "-" + s.Name
+}
+
+// LeaderElectionID returns the lease name of the shard, derived from the unsharded one
+func (s Shard) LeaderElectionID(id string) string {
+	if s.Name == "" {
+		return id
+	}
+	return s.Name + "." + id
+}
+
+// String describes the shard for logs
+func (s Shard) String() string {
+	if s.Name == "" {
+		return "all components"
+	}
+	components := make([]string, 0, len(s.Components))
+	for component := range s.Components {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	if s.Exclude {
+		return fmt.Sprintf("%s: all components except %s", s.Name, strings.Join(components, ","))
+	}
+	return fmt.Sprintf("%s: %s", s.Name, strings.Join(components, ","))
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestNewShard(t *testing.T) {
+	tests := []struct {
+		name, shard, components, exclude string
+		wantErr                          bool
+		owns, disowns                    []string
+	}{
+		{name: "unsharded", owns: []string{"MachineConfig", "HyperConverged"}},
+		{name: "components", shard: "machine-config", components: "MachineConfig, KubeletConfig",
+			owns: []string{"MachineConfig", "KubeletConfig"}, disowns: []string{"HyperConverged", "MetalLB"}},
+		{name: "excluded components", shard: "default", exclude: "MachineConfig,KubeletConfig",
+			owns: []string{"HyperConverged", "MetalLB"}, disowns: []string{"MachineConfig", "KubeletConfig"}},
+		{name: "filter without shard", components: "MachineConfig", wantErr: true},
+		{name: "shard without filter", shard: "default", wantErr: true},
+		{name: "both filters", shard: "default", components: "MachineConfig", exclude: "MetalLB", wantErr: true},
+		{name: "invalid name", shard: "Machine_Config", components: "MachineConfig", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shard, err := NewShard(tt.shard, tt.components, tt.exclude)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewShard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, component := range tt.owns {
+				if !shard.Owns(component) {
+					t.Errorf("shard %s does not own %s", shard, component)
+				}
+			}
+			for _, component := range tt.disowns {
+				if shard.Owns(component) {
+					t.Errorf("shard %s owns %s", shard, component)
+				}
+			}
+		})
+	}
+}
+
+func TestShardNames(t *testing.T) {
+	unsharded := Shard{}
+	if got := unsharded.ConditionType(ConditionReconciled); got != ConditionReconciled {
+		t.Errorf("ConditionType() = %s for the unsharded controller, want %s", got, ConditionReconciled)
+	}
+	if got := unsharded.LeaderElectionID("virt-platform-autopilot.kubevirt.io"); got != "virt-platform-autopilot.kubevirt.io" {
+		t.Errorf("LeaderElectionID() = %s for the unsharded controller", got)
+	}
+
+	shard := Shard{Name: "machine-config", Components: map[string]bool{"MachineConfig": true}}
+	if got := shard.ConditionType(ConditionReconciled); got != "PlatformAutopilotReconciled-machine-config" {
+		t.Errorf("ConditionType() = %s", got)
+	}
+	if got := shard.LeaderElectionID("virt-platform-autopilot.kubevirt.io"); got != "machine-config.virt-platform-autopilot.kubevirt.io" {
+		t.Errorf("LeaderElectionID() = %s", got)
+	}
+}
+
+func TestShardConditions(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetGeneration(2)
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).WithStatusSubresource(hco).Build()
+	ctx := context.Background()
+
+	for _, name := range []string{"machine-config", "default"} {
+		r := &PlatformReconciler{Client: fakeClient, shard: Shard{Name: name, Components: map[string]bool{}}}
+		r.recordObservedGeneration(ctx, hco)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(pkgcontext.HCOGVK)
+	if err := fakeClient.Get(ctx, types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}, live); err != nil {
+		t.Fatal(err)
+	}
+	conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+	found := make(map[any]bool)
+	for _, c := range conditions {
+		found[c.(map[string]any)["type"]] = true
+	}
+	for _, want := range []string{"PlatformAutopilotReconciled-machine-config", "PlatformAutopilotReconciled-default"} {
+		if !found[want] {
+			t.Errorf("conditions = %v, want %s", conditions, want)
+		}
+	}
+	if found[ConditionReconciled] {
+		t.Errorf("conditions = %v, want no unsharded %s", conditions, ConditionReconciled)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// ConditionAssetTimeout is the HCO status condition listing the assets whose
+// reconcile was cancelled by the apply timeouts in the last pass
+const ConditionAssetTimeout = "PlatformAutopilotAssetTimeout"
+
+// SetApplyTimeouts bounds the reconcile of each asset and of the whole asset pass
+func (r *PlatformReconciler) SetApplyTimeouts(timeouts engine.ApplyTimeouts) {
+	if r.patcher != nil {
+		r.patcher.SetApplyTimeouts(timeouts)
+	}
+}
+
+// recordAssetTimeouts reports the assets that timed out in the pass that returned err
+// (nil when the pass succeeded) as ConditionAssetTimeout on the HCO
+func (r *PlatformReconciler) recordAssetTimeouts(ctx context.Context, hco *unstructured.Unstructured, err error) {
+	key := types.NamespacedName{Namespace: hco.GetNamespace(), Name: hco.GetName()}
+
+	condition := metav1.Condition{
+		Type:    ConditionAssetTimeout,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoTimeouts",
+		Message: "All assets were reconciled within the apply timeouts",
+	}
+	var timeoutErr *engine.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AssetTimeout"
+		condition.Message = fmt.Sprintf("TIMEOUT: reconcile cancelled for %d asset(s): %s",
+			len(timeoutErr.Assets), strings.Join(timeoutErr.Assets, ", "))
+	}
+
+	// The message names the assets, so a different set of timeouts rewrites the condition
+	if err := r.setHCOCondition(ctx, key, condition, true); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to update HCO timeout condition", "error", err.Error())
+	}
+}
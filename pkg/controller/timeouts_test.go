@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func TestRecordAssetTimeouts(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).WithStatusSubresource(hco).Build()
+	r := &PlatformReconciler{Client: fakeClient}
+	key := types.NamespacedName{Namespace: "openshift-cnv", Name: "kubevirt-hyperconverged"}
+	ctx := context.Background()
+
+	condition := func() map[string]any {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(pkgcontext.HCOGVK)
+		if err := fakeClient.Get(ctx, key, live); err != nil {
+			t.Fatalf("failed to get HCO: %v", err)
+		}
+		conditions, _, _ := unstructured.NestedSlice(live.Object, "status", "conditions")
+		for _, c := range conditions {
+			if m := c.(map[string]any); m["type"] == ConditionAssetTimeout {
+				return m
+			}
+		}
+		return nil
+	}
+
+	// Plain failures and successes never add the condition
+	r.recordAssetTimeouts(ctx, hco, fmt.Errorf("failed to reconcile 1/3 assets"))
+	r.recordAssetTimeouts(ctx, hco, nil)
+	if c := condition(); c != nil {
+		t.Fatalf("condition = %v without timeouts, want none", c)
+	}
+
+	r.recordAssetTimeouts(ctx, hco, &engine.TimeoutError{Assets: []string{"psi-enable", "metrics-service"}})
+	c := condition()
+	if c == nil || c["status"] != "True" || c["reason"] != "AssetTimeout" {
+		t.Fatalf("condition = %v, want True/AssetTimeout", c)
+	}
+	if want := "TIMEOUT: reconcile cancelled for 2 asset(s): psi-enable, metrics-service"; c["message"] != want {
+		t.Errorf("message = %q, want %q", c["message"], want)
+	}
+
+	r.recordAssetTimeouts(ctx, hco, nil)
+	if c := condition(); c["status"] != "False" || c["reason"] != "NoTimeouts" {
+		t.Errorf("condition after a clean pass = %v, want False/NoTimeouts", c)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+// DriftEvent is a drift detection or correction the autopilot recorded on the HCO
+type DriftEvent struct {
+	Time metav1.Time `json:"time" yaml:"time"`
+	// Type is Warning for a detected drift and Normal for a correction
+	Type   string `json:"type" yaml:"type"`
+	Reason string `json:"reason" yaml:"reason"`
+	// Kind, Namespace and Name identify the drifted object
+	Kind      string `json:"kind" yaml:"kind"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string `json:"name" yaml:"name"`
+	Message   string `json:"message" yaml:"message"`
+	// Count is how often the event was recorded since it was first seen
+	Count int32 `json:"count" yaml:"count"`
+}
+
+// DriftEvents returns the drift events recorded on the HCO, newest first, at most limit
+// of them. Events only live for the API server's event TTL (one hour by default).
+func (s *Server) DriftEvents(ctx context.Context, limit int) ([]DriftEvent, error) {
+	renderCtx, err := s.getRenderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hco := renderCtx.HCO
+	// Filter before the limit: the dump's event list is capped and would hide older drifts
+	events, err := s.listEvents(ctx, hco.GetNamespace(), func(event *corev1.Event) bool {
+		if event.Reason != util.EventReasonDriftDetected && event.Reason != util.EventReasonDriftCorrected {
+			return false
+		}
+		// Corrections are also recorded on the object itself; the HCO's copy is enough
+		return event.InvolvedObject.Kind == hco.GetKind() && event.InvolvedObject.Name == hco.GetName()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+
+	drifts := make([]DriftEvent, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		drift := DriftEvent{
+			Time:    metav1.NewTime(eventTime(event)),
+			Type:    event.Type,
+			Reason:  event.Reason,
+			Message: event.Message,
+			Count:   max(event.Count, 1),
+		}
+		if event.Series != nil {
+			drift.Count = max(event.Series.Count, drift.Count)
+		}
+		// The action is "<reason> <kind>/<namespace>/<name>", see util.EventRecorder
+		if _, target, ok := strings.Cut(event.Action, " "); ok {
+			if parts := strings.SplitN(target, "/", 3); len(parts) == 3 {
+				drift.Kind, drift.Namespace, drift.Name = parts[0], parts[1], parts[2]
+			}
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
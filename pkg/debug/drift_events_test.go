@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+func TestDriftEvents(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	regarding := corev1.ObjectReference{Kind: hco.GetKind(), Name: hco.GetName(), Namespace: hco.GetNamespace()}
+	drift := func(name, reason, action string, age time.Duration, involved corev1.ObjectReference) *corev1.Event {
+		event := newEvent(name, engine.ManagedByValue, age)
+		event.Reason = reason
+		event.Action = action
+		event.InvolvedObject = involved
+		event.Message = name
+		return event
+	}
+
+	server := newDumpServer(t, hco,
+		drift("corrected", util.EventReasonDriftCorrected, "DriftCorrected MachineConfig//90-worker-swap", time.Hour, regarding),
+		drift("detected", util.EventReasonDriftDetected, "DriftDetected KubeDescheduler/openshift-kube-descheduler-operator/cluster",
+			time.Minute, regarding),
+		drift("on-object", util.EventReasonDriftCorrected, "DriftCorrected Service/openshift-cnv/metrics", time.Minute,
+			corev1.ObjectReference{Kind: "Service", Name: "metrics", Namespace: "openshift-cnv"}),
+		drift("applied", util.EventReasonAssetApplied, "AssetApplied MachineConfig//90-worker-swap", time.Minute, regarding),
+	)
+
+	events, err := server.DriftEvents(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2, "only drift events recorded on the HCO are returned")
+	assert.Equal(t, "detected", events[0].Message)
+	assert.Equal(t, "KubeDescheduler", events[0].Kind)
+	assert.Equal(t, "openshift-kube-descheduler-operator", events[0].Namespace)
+	assert.Equal(t, "cluster", events[0].Name)
+	assert.Equal(t, int32(1), events[0].Count)
+	assert.Equal(t, util.EventReasonDriftCorrected, events[1].Reason)
+	assert.Equal(t, "MachineConfig", events[1].Kind)
+	assert.Empty(t, events[1].Namespace)
+
+	events, err = server.DriftEvents(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "detected", events[0].Message)
+
+	// Drifts older than the dump's newest maxDumpEvents events are still returned
+	noisy := []client.Object{hco,
+		drift("old-drift", util.EventReasonDriftDetected, "DriftDetected Service/openshift-cnv/metrics", time.Hour, regarding)}
+	for i := range maxDumpEvents {
+		noisy = append(noisy, drift(fmt.Sprintf("applied-%d", i), util.EventReasonAssetApplied, "AssetApplied", time.Minute, regarding))
+	}
+	events, err = newDumpServer(t, noisy...).DriftEvents(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "old-drift", events[0].Message)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// DryRunResult is the /debug/reconcile-dry-run payload: what one reconcile of the
+// submitted HCO would apply and delete on the live cluster
+type DryRunResult struct {
+	HCO string `json:"hco" yaml:"hco"`
+	// Enabled is false when the HCO lacks the activation annotation, so nothing would be done
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Tombstones are processed first; objects already gone are not listed
+	Tombstones []engine.ObjectPlan `json:"tombstones" yaml:"tombstones"`
+	// Assets lists every asset in reconcile order
+	Assets  []engine.ObjectPlan       `json:"assets" yaml:"assets"`
+	Summary map[engine.PlanAction]int `json:"summary" yaml:"summary"`
+}
+
+// SetAPIReader makes /debug/reconcile-dry-run read live objects, and the event queries
+// list events, with reader. It should bypass the cache, so unlabeled objects the
+// controller would adopt are found and events, which are not cached, can be listed.
+func (s *Server) SetAPIReader(reader client.Reader) {
+	s.apiReader = reader
+}
+
+// reader returns the API reader if one is set, the client otherwise
+func (s *Server) reader() client.Reader {
+	if s.apiReader != nil {
+		return s.apiReader
+	}
+	return s.client
+}
+
+// SetMutators sets the source of the apply mutators /debug/reconcile-dry-run runs, as the
+// controller does. It is called per dry-run, so config reloads are followed.
+func (s *Server) SetMutators(mutators func() []engine.Mutator) {
+	s.mutators = mutators
+}
+
+// handleReconcileDryRun runs a whole reconcile of the HyperConverged posted in the
+// request body (YAML or JSON) in dry-run: inclusion is decided against the live
+// cluster, included assets are dry-run applied and tombstones are checked against
+// their live objects. Nothing is written. Unlike /debug/simulate, the live HCO need
+// not exist.
+func (s *Server) handleReconcileDryRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	hco, err := decodeSimulatedHCO(http.MaxBytesReader(w, r.Body, maxSimulateBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if hco.GetName() == "" || hco.GetNamespace() == "" {
+		http.Error(w, "metadata.name and metadata.namespace are required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.ReconcileDryRun(ctx, hco)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Dry-run failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	s.writeResponse(w, result, format)
+}
+
+// ReconcileDryRun plans a reconcile of hco against the live cluster
+func (s *Server) ReconcileDryRun(ctx context.Context, hco *unstructured.Unstructured) (*DryRunResult, error) {
+	result := &DryRunResult{
+		HCO:        hco.GetNamespace() + "/" + hco.GetName(),
+		Tombstones: []engine.ObjectPlan{},
+		Assets:     []engine.ObjectPlan{},
+		Summary:    map[engine.PlanAction]int{},
+	}
+
+	renderCtx, inclusions, err := controller.EvaluateInclusion(ctx, s.client, s.registry, hco)
+	if err != nil {
+		return nil, err
+	}
+	_, result.Enabled = overrides.ParseAutopilotScope(hco)
+
+	planner := engine.NewPlanner(s.client, s.reader(), s.loader)
+	if s.mutators != nil {
+		planner.SetMutators(s.mutators())
+	}
+
+	if result.Enabled {
+		result.Tombstones, err = planner.PlanTombstones(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, inclusion := range inclusions {
+		plan := engine.ObjectPlan{Asset: inclusion.Asset, Component: inclusion.Component, Action: engine.PlanSkip,
+			Reason: inclusion.Reason, Message: inclusion.Message}
+		if inclusion.Included {
+			assetMeta, err := s.registry.GetAsset(inclusion.Asset)
+			if err != nil {
+				return nil, err
+			}
+			plan = planner.PlanAsset(ctx, assetMeta, renderCtx)
+		}
+		result.Assets = append(result.Assets, plan)
+	}
+
+	for _, plans := range [][]engine.ObjectPlan{result.Tombstones, result.Assets} {
+		for _, plan := range plans {
+			result.Summary[plan.Action]++
+		}
+	}
+	return result, nil
+}
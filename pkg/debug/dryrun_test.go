@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// newDryRunServer serves an empty cluster. The fake client persists dry-run applies,
+// so the interceptor drops them as the API server would.
+func newDryRunServer(t *testing.T) (*Server, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				if slices.Contains(opts, client.ApplyOption(client.DryRunAll)) {
+					return nil
+				}
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+	return NewServer(fakeClient, loader, registry), fakeClient
+}
+
+func postDryRun(t *testing.T, server *Server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/debug/reconcile-dry-run?format=json", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.handleReconcileDryRun(w, req)
+	return w
+}
+
+func TestHandleReconcileDryRun(t *testing.T) {
+	server, c := newDryRunServer(t)
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetAnnotations(map[string]string{overrides.AnnotationAutopilotEnabled: "true"})
+	body, err := yaml.Marshal(hco.Object)
+	require.NoError(t, err)
+
+	w := postDryRun(t, server, string(body))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result DryRunResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "openshift-cnv/kubevirt-hyperconverged", result.HCO)
+	assert.True(t, result.Enabled)
+	assert.Len(t, result.Assets, len(server.registry.ListAssetsByReconcileOrder()))
+
+	plans := make(map[string]engine.ObjectPlan, len(result.Assets))
+	for _, plan := range result.Assets {
+		plans[plan.Asset] = plan
+	}
+	assert.Equal(t, engine.PlanCreate, plans["metrics-service"].Action)
+	assert.Equal(t, "Service", plans["metrics-service"].Kind)
+	assert.NotNil(t, plans["metrics-service"].Object)
+	// Assets gated on a CRD the cluster lacks are skipped with the gate that failed
+	assert.Equal(t, engine.PlanSkip, plans["swap-enable"].Action)
+	assert.Equal(t, engine.ReasonCRDMissing, plans["swap-enable"].Reason)
+	assert.Contains(t, plans["swap-enable"].Message, "not installed")
+	assert.Positive(t, result.Summary[engine.PlanCreate])
+
+	// Nothing was written
+	services := &unstructured.UnstructuredList{}
+	services.SetAPIVersion("v1")
+	services.SetKind("ServiceList")
+	require.NoError(t, c.List(context.Background(), services))
+	assert.Empty(t, services.Items)
+}
+
+func TestHandleReconcileDryRunDisabled(t *testing.T) {
+	server, _ := newDryRunServer(t)
+
+	body, err := yaml.Marshal(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv").Object)
+	require.NoError(t, err)
+
+	w := postDryRun(t, server, string(body))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result DryRunResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.False(t, result.Enabled)
+	assert.Empty(t, result.Tombstones)
+	assert.Equal(t, map[engine.PlanAction]int{engine.PlanSkip: len(result.Assets)}, result.Summary)
+}
+
+func TestHandleReconcileDryRunErrors(t *testing.T) {
+	server, _ := newDryRunServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/reconcile-dry-run", nil)
+	w := httptest.NewRecorder()
+	server.handleReconcileDryRun(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = postDryRun(t, server, "apiVersion: v1\nkind: ConfigMap\n")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postDryRun(t, server, "apiVersion: hco.kubevirt.io/v1\nkind: HyperConverged\nmetadata:\n  name: hco\n")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "metadata.namespace")
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+// Files written by Dump, relative to the dump root
+const (
+	DumpCatalogFile    = "catalog.yaml"
+	DumpRenderFile     = "render.yaml"
+	DumpExclusionsFile = "exclusions.yaml"
+	DumpTombstonesFile = "tombstones.yaml"
+	DumpInventoryFile  = "inventory.yaml"
+	DumpInventoryDir   = "inventory"
+	DumpHCOFile        = "status/hyperconverged.yaml"
+	DumpEventsFile     = "events.yaml"
+	DumpErrorsFile     = "errors.txt"
+)
+
+// maxDumpEvents caps the events written by Dump (newest first)
+const maxDumpEvents = 500
+
+// DumpWriter stores the files of a debug dump
+type DumpWriter interface {
+	WriteFile(name string, data []byte) error
+}
+
+// DirWriter writes dump files below a directory, creating subdirectories as needed
+type DirWriter string
+
+// WriteFile writes data to name below the directory
+func (d DirWriter) WriteFile(name string, data []byte) error {
+	path := filepath.Join(string(d), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// TarWriter streams dump files as a tar archive.
+// It is used where the dump cannot be copied out of a directory, e.g. through
+// `oc exec` into the distroless operator image, which has no tar binary for `oc cp`.
+type TarWriter struct {
+	tw  *tar.Writer
+	now time.Time
+}
+
+// NewTarWriter returns a TarWriter writing to w; Close must be called to finish the archive
+func NewTarWriter(w io.Writer) *TarWriter {
+	return &TarWriter{tw: tar.NewWriter(w), now: time.Now()}
+}
+
+// WriteFile adds name to the archive
+func (t *TarWriter) WriteFile(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: t.now,
+	}
+	if err := t.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := t.tw.Write(data)
+	return err
+}
+
+// Close writes the archive trailer
+func (t *TarWriter) Close() error {
+	return t.tw.Close()
+}
+
+// InventoryItem is the live state of one included asset's object
+type InventoryItem struct {
+	Asset           string `json:"asset" yaml:"asset"`
+	APIVersion      string `json:"apiVersion" yaml:"apiVersion"`
+	Kind            string `json:"kind" yaml:"kind"`
+	Namespace       string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name            string `json:"name" yaml:"name"`
+	Present         bool   `json:"present" yaml:"present"`
+	Managed         bool   `json:"managed" yaml:"managed"`
+	ResourceVersion string `json:"resourceVersion,omitempty" yaml:"resourceVersion,omitempty"`
+	// Created is the live object's creationTimestamp (nil when absent)
+	Created *metav1.Time `json:"created,omitempty" yaml:"created,omitempty"`
+	Error   string       `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Dump collects everything support needs to analyse the autopilot into w: the asset
+// catalog, every asset rendered against the live HCO, exclusions, tombstones, the live
+// state of every included asset, the HCO itself and the autopilot's recent events.
+//
+// Collection is best effort, as in must-gather: a section that cannot be collected
+// (missing HCO, RBAC, API errors) is recorded in errors.txt and the rest is still written.
+// Only a failure to write to w is returned.
+func (s *Server) Dump(ctx context.Context, w DumpWriter) error {
+	d := &dump{w: w}
+
+	if catalog, err := s.loader.LoadAsset("active/metadata.yaml"); err != nil {
+		d.fail(DumpCatalogFile, err)
+	} else {
+		d.write(DumpCatalogFile, catalog)
+	}
+
+	if tombstones, err := s.collectTombstones(); err != nil {
+		d.fail(DumpTombstonesFile, err)
+	} else {
+		d.writeYAML(DumpTombstonesFile, tombstones)
+	}
+
+	renderCtx, err := s.getRenderContext(ctx)
+	if err != nil {
+		d.fail("hyperconverged", err)
+		return d.finish()
+	}
+	hco := renderCtx.HCO
+
+	d.writeYAML(DumpHCOFile, stripManagedFields(hco).Object)
+
+	outputs := pkgrender.BuildOutputs(s.registry.ListAssetsByReconcileOrder(), s.renderer, renderCtx, true)
+	var buf bytes.Buffer
+	if err := pkgrender.WriteYAML(&buf, outputs); err != nil {
+		d.fail(DumpRenderFile, err)
+	} else {
+		d.write(DumpRenderFile, buf.Bytes())
+	}
+
+	d.writeYAML(DumpExclusionsFile, s.collectExclusions(renderCtx))
+	d.writeYAML(DumpInventoryFile, s.collectInventory(ctx, d, outputs))
+
+	if events, err := s.collectEvents(ctx, hco.GetNamespace()); err != nil {
+		d.fail(DumpEventsFile, err)
+	} else {
+		d.writeYAML(DumpEventsFile, events)
+	}
+
+	return d.finish()
+}
+
+// dump accumulates section errors and remembers the first write failure
+type dump struct {
+	w        DumpWriter
+	errs     []string
+	writeErr error
+}
+
+func (d *dump) write(name string, data []byte) {
+	if d.writeErr != nil {
+		return
+	}
+	if err := d.w.WriteFile(name, data); err != nil {
+		d.writeErr = fmt.Errorf("failed to write %s: %w", name, err)
+	}
+}
+
+func (d *dump) writeYAML(name string, v any) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		d.fail(name, err)
+		return
+	}
+	d.write(name, data)
+}
+
+func (d *dump) fail(section string, err error) {
+	d.errs = append(d.errs, fmt.Sprintf("%s: %v", section, err))
+}
+
+// finish writes errors.txt when any section failed
+func (d *dump) finish() error {
+	if len(d.errs) > 0 {
+		d.write(DumpErrorsFile, []byte(strings.Join(d.errs, "\n")+"\n"))
+	}
+	return d.writeErr
+}
+
+// Inventory renders every asset against the live HCO and returns the live state of
+// the object of each included asset
+func (s *Server) Inventory(ctx context.Context) ([]InventoryItem, error) {
+	renderCtx, err := s.getRenderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	outputs := pkgrender.BuildOutputs(s.registry.ListAssetsByReconcileOrder(), s.renderer, renderCtx, false)
+	return s.collectInventory(ctx, nil, outputs), nil
+}
+
+// collectInventory looks up the live object of every included output.
+// With a dump, objects that exist are also written to inventory/<asset>.yaml.
+func (s *Server) collectInventory(ctx context.Context, d *dump, outputs []pkgrender.RenderOutput) []InventoryItem {
+	items := []InventoryItem{}
+	for _, output := range outputs {
+		if output.Status != "INCLUDED" || output.Object == nil {
+			continue
+		}
+
+		item := InventoryItem{
+			Asset:      output.Asset,
+			APIVersion: output.Object.GetAPIVersion(),
+			Kind:       output.Object.GetKind(),
+			Namespace:  output.Object.GetNamespace(),
+			Name:       output.Object.GetName(),
+		}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(output.Object.GroupVersionKind())
+		err := s.client.Get(ctx, client.ObjectKeyFromObject(output.Object), live)
+		switch {
+		case errors.IsNotFound(err):
+		case err != nil:
+			item.Error = err.Error()
+		default:
+			item.Present = true
+			item.Managed = live.GetLabels()[engine.ManagedByLabel] == engine.ManagedByValue
+			item.ResourceVersion = live.GetResourceVersion()
+			if created := live.GetCreationTimestamp(); !created.IsZero() {
+				item.Created = &created
+			}
+			if d != nil {
+				d.writeYAML(DumpInventoryDir+"/"+output.Asset+".yaml", stripManagedFields(live).Object)
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// collectEvents returns the events the autopilot recorded in namespace, newest first
+func (s *Server) collectEvents(ctx context.Context, namespace string) (*corev1.EventList, error) {
+	items, err := s.listEvents(ctx, namespace, func(*corev1.Event) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	events := &corev1.EventList{Items: items}
+	events.APIVersion = "v1"
+	events.Kind = "EventList"
+	if len(events.Items) > maxDumpEvents {
+		events.Items = events.Items[:maxDumpEvents]
+	}
+	return events, nil
+}
+
+// listEvents returns the events the autopilot recorded in namespace that keep accepts,
+// newest first
+func (s *Server) listEvents(ctx context.Context, namespace string, keep func(*corev1.Event) bool) ([]corev1.Event, error) {
+	list := &corev1.EventList{}
+	if err := s.reader().List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var events []corev1.Event
+	for i := range list.Items {
+		event := &list.Items[i]
+		if event.ReportingController != engine.ManagedByValue && event.Source.Component != engine.ManagedByValue {
+			continue
+		}
+		if keep(event) {
+			events = append(events, *event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(&events[i]).After(eventTime(&events[j]))
+	})
+	return events, nil
+}
+
+// eventTime returns the most recent timestamp set on event
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// stripManagedFields returns a copy of obj without metadata.managedFields, which only adds noise to a dump
+func stripManagedFields(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	out := obj.DeepCopy()
+	out.SetManagedFields(nil)
+	return out
+}
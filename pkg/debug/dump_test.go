@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func newDumpServer(t *testing.T, objs ...client.Object) *Server {
+	t.Helper()
+
+	fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	return NewServer(fakeClient, loader, registry)
+}
+
+func newEvent(name, controller string, age time.Duration) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:          metav1.ObjectMeta{Name: name, Namespace: "openshift-cnv"},
+		ReportingController: controller,
+		Reason:              name,
+		LastTimestamp:       metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func TestDump(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	server := newDumpServer(t, hco,
+		newEvent("older", engine.ManagedByValue, time.Hour),
+		newEvent("newer", engine.ManagedByValue, time.Minute),
+		newEvent("other-controller", "kubevirt-hyperconverged", time.Minute),
+	)
+
+	dir := t.TempDir()
+	require.NoError(t, server.Dump(context.Background(), DirWriter(dir)))
+
+	for _, name := range []string{DumpCatalogFile, DumpRenderFile, DumpExclusionsFile, DumpTombstonesFile, DumpHCOFile} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+	assert.NoFileExists(t, filepath.Join(dir, DumpErrorsFile))
+
+	var events corev1.EventList
+	readYAML(t, filepath.Join(dir, DumpEventsFile), &events)
+	require.Len(t, events.Items, 2, "only events recorded by the autopilot are dumped")
+	assert.Equal(t, "newer", events.Items[0].Name)
+	assert.Equal(t, "older", events.Items[1].Name)
+
+	// The golden HCO config renders the HCO itself, the only object in the fake cluster
+	var inventory []InventoryItem
+	readYAML(t, filepath.Join(dir, DumpInventoryFile), &inventory)
+	require.NotEmpty(t, inventory)
+	var golden *InventoryItem
+	for i := range inventory {
+		if inventory[i].Asset == "hco-golden-config" {
+			golden = &inventory[i]
+		} else {
+			assert.False(t, inventory[i].Present, "%s should not exist in the fake cluster", inventory[i].Asset)
+		}
+	}
+	require.NotNil(t, golden)
+	assert.True(t, golden.Present)
+	assert.Equal(t, hco.GetLabels()[engine.ManagedByLabel] == engine.ManagedByValue, golden.Managed)
+	assert.NotEmpty(t, golden.ResourceVersion)
+	assert.FileExists(t, filepath.Join(dir, DumpInventoryDir, "hco-golden-config.yaml"))
+}
+
+func TestDumpWithoutHCO(t *testing.T) {
+	server := newDumpServer(t)
+
+	dir := t.TempDir()
+	require.NoError(t, server.Dump(context.Background(), DirWriter(dir)))
+
+	// Sections that do not need the HCO are still collected
+	assert.FileExists(t, filepath.Join(dir, DumpCatalogFile))
+	assert.FileExists(t, filepath.Join(dir, DumpTombstonesFile))
+	assert.NoFileExists(t, filepath.Join(dir, DumpRenderFile))
+
+	errs, err := os.ReadFile(filepath.Join(dir, DumpErrorsFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(errs), "no HyperConverged resources found")
+}
+
+func TestTarWriter(t *testing.T) {
+	var buf bytes.Buffer
+	archive := NewTarWriter(&buf)
+	require.NoError(t, archive.WriteFile("status/hyperconverged.yaml", []byte("kind: HyperConverged\n")))
+	require.NoError(t, archive.Close())
+
+	tr := tar.NewReader(&buf)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "status/hyperconverged.yaml", header.Name)
+	data, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, "kind: HyperConverged\n", string(data))
+	_, err = tr.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func readYAML(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(data, v))
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
 	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
 )
@@ -42,6 +44,18 @@ type Server struct {
 	loader   *assets.Loader
 	registry *assets.Registry
 	renderer *engine.Renderer
+	logLevel *LogLevel
+	history  *engine.RenderHistory
+
+	// Used by /debug/reconcile-dry-run, see SetAPIReader and SetMutators
+	apiReader client.Reader
+	mutators  func() []engine.Mutator
+
+	// Used by /debug/standby, see SetLeaderStatus
+	leaderStatus func() controller.LeaderStatus
+
+	// Used by /debug/hardware-history, see SetHardwareHistory
+	hardwareHistory func() []controller.HardwareSample
 }
 
 // NewServer creates a new debug server
@@ -54,13 +68,38 @@ func NewServer(c client.Client, loader *assets.Loader, registry *assets.Registry
 	}
 }
 
+// SetLogLevel enables the /debug/loglevel endpoint backed by level
+func (s *Server) SetLogLevel(level *LogLevel) {
+	s.logLevel = level
+}
+
+// SetRenderHistory enables the /debug/history endpoints backed by history
+func (s *Server) SetRenderHistory(history *engine.RenderHistory) {
+	s.history = history
+}
+
 // InstallHandlers registers debug HTTP handlers
 func (s *Server) InstallHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/render", s.handleRender)
 	mux.HandleFunc("/debug/render/", s.handleRenderAsset) // Trailing slash for path params
 	mux.HandleFunc("/debug/exclusions", s.handleExclusions)
 	mux.HandleFunc("/debug/tombstones", s.handleTombstones)
+	mux.HandleFunc("/debug/simulate", s.handleSimulate)
+	mux.HandleFunc("/debug/reconcile-dry-run", s.handleReconcileDryRun)
 	mux.HandleFunc("/debug/health", s.handleHealth)
+	if s.logLevel != nil {
+		mux.HandleFunc("/debug/loglevel", s.handleLogLevel)
+	}
+	if s.history != nil {
+		mux.HandleFunc("/debug/history", s.handleHistory)
+		mux.HandleFunc("/debug/history/", s.handleHistoryAsset)
+	}
+	if s.leaderStatus != nil {
+		mux.HandleFunc("/debug/standby", s.handleStandby)
+	}
+	if s.hardwareHistory != nil {
+		mux.HandleFunc("/debug/hardware-history", s.handleHardwareHistory)
+	}
 }
 
 // handleRender renders all assets and returns them
@@ -141,40 +180,56 @@ func (s *Server) handleRenderAsset(w http.ResponseWriter, r *http.Request) {
 		Path:       assetMeta.Path,
 		Component:  assetMeta.Component,
 		Conditions: assetMeta.Conditions,
+		Deprecated: assetMeta.DeprecationNotice(),
 	}
 
 	if !pkgrender.CheckConditions(assetMeta, renderCtx) {
 		output.Status = "EXCLUDED"
-		output.Reason = "Conditions not met"
+		output.Reason = engine.ReasonConditionsNotMet
 		s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
 		return
 	}
 
 	rendered, err := s.renderer.RenderAsset(assetMeta, renderCtx)
+	if reason, skipped := engine.SkipReason(err); skipped {
+		output.Status = "EXCLUDED"
+		output.Reason = engine.ReasonTemplateSkipped
+		output.Message = reason
+		s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
+		return
+	}
 	if err != nil {
 		output.Status = "ERROR"
-		output.Reason = err.Error()
+		output.Reason = engine.ReasonOf(err)
+		if output.Reason == "" {
+			output.Reason = engine.ReasonRenderFailed
+		}
+		output.Message = err.Error()
 		s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
 		return
 	}
 
 	if rendered == nil {
 		output.Status = "EXCLUDED"
-		output.Reason = "Conditional template rendered empty"
+		output.Reason = engine.ReasonRenderedEmpty
 		s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
 		return
 	}
 
 	// Check root exclusion; fail-open if the annotation cannot be parsed.
-	disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
-	if disabledAnnotation != "" {
-		rules, err := engine.ParseDisabledResources(disabledAnnotation)
-		if err == nil && engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
-			output.Status = "FILTERED"
-			output.Reason = "Root exclusion (disabled-resources annotation)"
-			s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
-			return
-		}
+	rules, err := engine.ExclusionRulesFromObject(renderCtx.HCO)
+	if err == nil && engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
+		output.Status = "FILTERED"
+		output.Reason = engine.ReasonDisabledResources
+		s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
+		return
+	}
+	if exclusion := engine.MatchingExclusion(renderCtx, assetMeta.Component, rendered, time.Now()); exclusion != nil {
+		output.Status = "FILTERED"
+		output.Reason = engine.ReasonAutopilotExclusion
+		output.Message = fmt.Sprintf("excluded by AutopilotExclusion %s", exclusion.Source)
+		s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
+		return
 	}
 
 	output.Status = "INCLUDED"
@@ -182,12 +237,23 @@ func (s *Server) handleRenderAsset(w http.ResponseWriter, r *http.Request) {
 	s.writeRenderResponse(w, []pkgrender.RenderOutput{output}, format)
 }
 
+// Render renders every asset against the live HCO. Excluded and filtered assets are
+// part of the result only with showExcluded.
+func (s *Server) Render(ctx context.Context, showExcluded bool) ([]pkgrender.RenderOutput, error) {
+	renderCtx, err := s.getRenderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pkgrender.BuildOutputs(s.registry.ListAssetsByReconcileOrder(), s.renderer, renderCtx, showExcluded), nil
+}
+
 // ExclusionInfo represents information about excluded assets
 type ExclusionInfo struct {
 	Asset     string                `json:"asset" yaml:"asset"`
 	Path      string                `json:"path" yaml:"path"`
 	Component string                `json:"component" yaml:"component"`
-	Reason    string                `json:"reason" yaml:"reason"`
+	Reason    engine.Reason         `json:"reason" yaml:"reason"`
+	Message   string                `json:"message,omitempty" yaml:"message,omitempty"`
 	Details   map[string]string     `json:"details,omitempty" yaml:"details,omitempty"`
 	Metadata  *assets.AssetMetadata `json:"-" yaml:"-"`
 }
@@ -213,16 +279,34 @@ func (s *Server) handleExclusions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.writeResponse(w, s.collectExclusions(renderCtx), format)
+}
+
+// Exclusions returns every asset excluded or filtered for the live HCO, with the reason
+func (s *Server) Exclusions(ctx context.Context) ([]ExclusionInfo, error) {
+	renderCtx, err := s.getRenderContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.collectExclusions(renderCtx), nil
+}
+
+// collectExclusions returns every asset that is excluded or filtered for renderCtx, with the reason
+func (s *Server) collectExclusions(renderCtx *pkgcontext.RenderContext) []ExclusionInfo {
 	exclusions := []ExclusionInfo{}
 	assetList := s.registry.ListAssetsByReconcileOrder()
 
+	// Parse root-exclusion rules once; a malformed annotation excludes nothing (fail-open).
+	disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
+	exclusionRules, _ := engine.ExclusionRulesFromObject(renderCtx.HCO)
+
 	for _, assetMeta := range assetList {
 		if !pkgrender.CheckConditions(&assetMeta, renderCtx) {
 			exclusions = append(exclusions, ExclusionInfo{
 				Asset:     assetMeta.Name,
 				Path:      assetMeta.Path,
 				Component: assetMeta.Component,
-				Reason:    "Conditions not met",
+				Reason:    engine.ReasonConditionsNotMet,
 				Details:   s.getConditionDetails(&assetMeta, renderCtx),
 				Metadata:  &assetMeta,
 			})
@@ -231,44 +315,65 @@ func (s *Server) handleExclusions(w http.ResponseWriter, r *http.Request) {
 
 		rendered, err := s.renderer.RenderAsset(&assetMeta, renderCtx)
 		if err != nil || rendered == nil {
-			reason := "Template rendered empty"
-			if err != nil {
-				reason = fmt.Sprintf("Render error: %v", err)
+			reason, message := engine.ReasonRenderedEmpty, ""
+			if skipReason, skipped := engine.SkipReason(err); skipped {
+				reason, message = engine.ReasonTemplateSkipped, skipReason
+			} else if err != nil {
+				if reason = engine.ReasonOf(err); reason == "" {
+					reason = engine.ReasonRenderFailed
+				}
+				message = err.Error()
 			}
 			exclusions = append(exclusions, ExclusionInfo{
 				Asset:     assetMeta.Name,
 				Path:      assetMeta.Path,
 				Component: assetMeta.Component,
 				Reason:    reason,
+				Message:   message,
 				Metadata:  &assetMeta,
 			})
 			continue
 		}
 
-		disabledAnnotation := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]
-		if disabledAnnotation != "" {
-			rules, err := engine.ParseDisabledResources(disabledAnnotation)
-			if err != nil {
-				continue
+		if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), exclusionRules) {
+			exclusions = append(exclusions, ExclusionInfo{
+				Asset:     assetMeta.Name,
+				Path:      assetMeta.Path,
+				Component: assetMeta.Component,
+				Reason:    engine.ReasonDisabledResources,
+				Details: map[string]string{
+					"annotation": engine.DisabledResourcesAnnotation,
+					"value":      disabledAnnotation,
+					"resource":   fmt.Sprintf("%s/%s/%s", rendered.GetKind(), rendered.GetNamespace(), rendered.GetName()),
+				},
+				Metadata: &assetMeta,
+			})
+			continue
+		}
+
+		if exclusion := engine.MatchingExclusion(renderCtx, assetMeta.Component, rendered, time.Now()); exclusion != nil {
+			details := map[string]string{
+				"autopilotExclusion": exclusion.Source,
+				"resource":           fmt.Sprintf("%s/%s/%s", rendered.GetKind(), rendered.GetNamespace(), rendered.GetName()),
+			}
+			if exclusion.Reason != "" {
+				details["reason"] = exclusion.Reason
 			}
-			if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), rules) {
-				exclusions = append(exclusions, ExclusionInfo{
-					Asset:     assetMeta.Name,
-					Path:      assetMeta.Path,
-					Component: assetMeta.Component,
-					Reason:    "Root exclusion",
-					Details: map[string]string{
-						"annotation": engine.DisabledResourcesAnnotation,
-						"value":      disabledAnnotation,
-						"resource":   fmt.Sprintf("%s/%s/%s", rendered.GetKind(), rendered.GetNamespace(), rendered.GetName()),
-					},
-					Metadata: &assetMeta,
-				})
+			if exclusion.ExpiresAt != nil {
+				details["expiresAt"] = exclusion.ExpiresAt.Format(time.RFC3339)
 			}
+			exclusions = append(exclusions, ExclusionInfo{
+				Asset:     assetMeta.Name,
+				Path:      assetMeta.Path,
+				Component: assetMeta.Component,
+				Reason:    engine.ReasonAutopilotExclusion,
+				Details:   details,
+				Metadata:  &assetMeta,
+			})
 		}
 	}
 
-	s.writeResponse(w, exclusions, format)
+	return exclusions
 }
 
 // TombstoneInfo represents information about tombstones
@@ -291,12 +396,22 @@ func (s *Server) handleTombstones(w http.ResponseWriter, r *http.Request) {
 		format = "yaml"
 	}
 
-	tombstones, err := s.loader.LoadTombstones()
+	infos, err := s.collectTombstones()
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to load tombstones: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	s.writeResponse(w, infos, format)
+}
+
+// collectTombstones lists the tombstones shipped in this binary
+func (s *Server) collectTombstones() ([]TombstoneInfo, error) {
+	tombstones, err := s.loader.LoadTombstones()
+	if err != nil {
+		return nil, err
+	}
+
 	infos := make([]TombstoneInfo, len(tombstones))
 	for i, ts := range tombstones {
 		infos[i] = TombstoneInfo{
@@ -306,8 +421,7 @@ func (s *Server) handleTombstones(w http.ResponseWriter, r *http.Request) {
 			Path:      ts.Path,
 		}
 	}
-
-	s.writeResponse(w, infos, format)
+	return infos, nil
 }
 
 // handleHealth is a simple health check endpoint
@@ -343,12 +457,23 @@ func (s *Server) getConditionDetails(assetMeta *assets.AssetMetadata, renderCtx
 			actual := renderCtx.HCO.GetAnnotations()[condition.Key]
 			details[condition.Key] = fmt.Sprintf("expected=%s, actual=%s", condition.Value, actual)
 		case assets.ConditionTypeFeatureGate:
-			featureGates := renderCtx.HCO.GetAnnotations()["platform.kubevirt.io/feature-gates"]
-			details["feature-gates"] = featureGates
 			details["required"] = condition.Value
+			details["enabled"] = strconv.FormatBool(renderCtx.FeatureGates[condition.Value])
 		case assets.ConditionTypeHardwareDetection:
 			details["detector"] = condition.Detector
-			details["status"] = "not checked (requires node access)"
+			details["detected"] = strconv.FormatBool(renderCtx.Hardware != nil && renderCtx.Hardware.AsMap()[condition.Detector])
+		case assets.ConditionTypeStorage:
+			details["detector"] = condition.Detector
+			details["detected"] = strconv.FormatBool(renderCtx.Storage.AsMap()[condition.Detector])
+		case assets.ConditionTypeNetwork:
+			details["detector"] = condition.Detector
+			details["detected"] = strconv.FormatBool(renderCtx.Network.AsMap()[condition.Detector])
+		case assets.ConditionTypeIPFamily:
+			details["ip-family"] = condition.Value
+			details["detected"] = strconv.FormatBool(renderCtx.Network.HasIPFamily(condition.Value))
+		case assets.ConditionTypeArchitecture:
+			details["architecture"] = condition.Value
+			details["detected"] = strconv.FormatBool(renderCtx.Architecture.Has(condition.Value))
 		}
 	}
 
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"net/http"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+)
+
+// SetHardwareHistory enables the /debug/hardware-history endpoint backed by history
+func (s *Server) SetHardwareHistory(history func() []controller.HardwareSample) {
+	s.hardwareHistory = history
+}
+
+// handleHardwareHistory returns the recent changes of the hardware detection
+// results, newest first
+func (s *Server) handleHardwareHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	samples := s.hardwareHistory()
+	if samples == nil {
+		samples = []controller.HardwareSample{}
+	}
+	s.writeResponse(w, samples, format)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+)
+
+func TestHandleHardwareHistory(t *testing.T) {
+	samples := []controller.HardwareSample{{
+		Time:     time.Date(2026, 10, 16, 9, 42, 10, 0, time.UTC),
+		Nodes:    6,
+		Detected: map[string]bool{"gpuPresent": false},
+		Held:     []string{"gpuPresent"},
+		Changed:  []string{"gpuPresent"},
+	}}
+	server := NewServer(nil, nil, nil)
+	server.SetHardwareHistory(func() []controller.HardwareSample { return samples })
+	mux := http.NewServeMux()
+	server.InstallHandlers(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/hardware-history?format=json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var got []controller.HardwareSample
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, samples, got)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/hardware-history", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "held:\n  - gpuPresent")
+
+	t.Run("empty history", func(t *testing.T) {
+		server := NewServer(nil, nil, nil)
+		server.SetHardwareHistory(func() []controller.HardwareSample { return nil })
+		mux := http.NewServeMux()
+		server.InstallHandlers(mux)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/hardware-history?format=json", nil))
+		assert.Equal(t, "[]", w.Body.String())
+	})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HistorySummary describes the recorded versions of one asset
+type HistorySummary struct {
+	Asset     string    `json:"asset"`
+	Revisions []int64   `json:"revisions"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// handleHistory lists the assets with recorded versions, newest revision first
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	summaries := []HistorySummary{}
+	for _, asset := range s.history.Assets() {
+		entries := s.history.Entries(asset)
+		if len(entries) == 0 {
+			continue
+		}
+		summary := HistorySummary{Asset: asset, AppliedAt: entries[0].AppliedAt.Time}
+		for _, entry := range entries {
+			summary.Revisions = append(summary.Revisions, entry.Revision)
+		}
+		summaries = append(summaries, summary)
+	}
+	s.writeResponse(w, summaries, format)
+}
+
+// handleHistoryAsset returns the recorded versions of an asset, newest first
+func (s *Server) handleHistoryAsset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract asset name from path: /debug/history/{asset}
+	assetName := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/debug/history/"))
+	if assetName == "" {
+		http.Error(w, "Asset name required", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	entries := s.history.Entries(assetName)
+	if len(entries) == 0 {
+		http.Error(w, fmt.Sprintf("No history recorded for asset %s", assetName), http.StatusNotFound)
+		return
+	}
+	s.writeResponse(w, entries, format)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+func TestHandleHistory(t *testing.T) {
+	history := engine.NewRenderHistory(engine.DefaultRenderHistorySize)
+	for _, value := range []string{"a", "b"} {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName("virt-settings")
+		_ = unstructured.SetNestedField(obj.Object, value, "data", "setting")
+		history.Record(context.Background(), "virt-settings", obj)
+	}
+
+	server := NewServer(nil, nil, nil)
+	server.SetRenderHistory(history)
+	mux := http.NewServeMux()
+	server.InstallHandlers(mux)
+
+	t.Run("summary", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/history?format=json", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var summaries []HistorySummary
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summaries))
+		require.Len(t, summaries, 1)
+		assert.Equal(t, "virt-settings", summaries[0].Asset)
+		assert.Equal(t, []int64{2, 1}, summaries[0].Revisions)
+	})
+
+	t.Run("asset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/history/virt-settings?format=json", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var entries []engine.HistoryEntry
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+		require.Len(t, entries, 2)
+		value, _, _ := unstructured.NestedString(entries[1].Object.Object, "data", "setting")
+		assert.Equal(t, "a", value)
+	})
+
+	t.Run("unknown asset", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/history/nope", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// verboseLevel is the level SIGHUP switches to: logr V(2) and below, which
+// covers all per-asset logging.
+const verboseLevel = zapcore.Level(-2)
+
+// LogLevel controls the process log level at runtime.
+// It wraps the zap AtomicLevel the logger was built with, so changes take effect
+// immediately for all loggers derived from it.
+type LogLevel struct {
+	level   zap.AtomicLevel
+	initial zapcore.Level
+}
+
+// LogLevelInfo is the /debug/loglevel payload.
+// Level is a zap level name ("debug", "info", "error", ...) and Verbosity the
+// equivalent logr V-level (0 for info, 1 for debug, 2+ for more verbose).
+type LogLevelInfo struct {
+	Level     string `json:"level"`
+	Verbosity int    `json:"verbosity"`
+}
+
+// NewLogLevel creates a LogLevel starting at initial.
+// Pass the returned Atomic() to the zap logger options.
+func NewLogLevel(initial zapcore.Level) *LogLevel {
+	return &LogLevel{
+		level:   zap.NewAtomicLevelAt(initial),
+		initial: initial,
+	}
+}
+
+// Atomic returns the underlying zap level for logger construction
+func (l *LogLevel) Atomic() zap.AtomicLevel {
+	return l.level
+}
+
+// Info returns the current level
+func (l *LogLevel) Info() LogLevelInfo {
+	current := l.level.Level()
+	verbosity := 0
+	if current < zapcore.InfoLevel {
+		verbosity = -int(current)
+	}
+	return LogLevelInfo{Level: current.String(), Verbosity: verbosity}
+}
+
+// Set parses level as a zap level name or a numeric logr verbosity and applies it
+func (l *LogLevel) Set(level string) error {
+	parsed, err := parseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(parsed)
+	return nil
+}
+
+// Toggle switches between the startup level and verboseLevel
+func (l *LogLevel) Toggle() {
+	if l.level.Level() == l.initial {
+		l.level.SetLevel(verboseLevel)
+		return
+	}
+	l.level.SetLevel(l.initial)
+}
+
+// WatchSIGHUP toggles verbose logging on every SIGHUP until ctx is done
+func (l *LogLevel) WatchSIGHUP(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("loglevel")
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigs)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigs:
+				l.Toggle()
+				// Logged at info on purpose so the change is visible at either level
+				logger.Info("Log level changed by SIGHUP", "level", l.Info().Level)
+			}
+		}
+	}()
+}
+
+// parseLogLevel accepts zap level names and non-negative logr verbosities
+func parseLogLevel(level string) (zapcore.Level, error) {
+	level = strings.TrimSpace(level)
+	if level == "" {
+		return 0, fmt.Errorf("level is required")
+	}
+
+	if v, err := strconv.Atoi(level); err == nil {
+		if v < 0 {
+			return 0, fmt.Errorf("verbosity must be >= 0, got %d", v)
+		}
+		return zapcore.Level(-v), nil
+	}
+
+	var parsed zapcore.Level
+	if err := parsed.UnmarshalText([]byte(strings.ToLower(level))); err != nil {
+		return 0, fmt.Errorf("invalid level %q: %w", level, err)
+	}
+	return parsed, nil
+}
+
+// handleLogLevel reports (GET) or changes (PUT) the log level.
+// PUT accepts ?level=<name|verbosity> or a JSON body {"level": "..."} / {"verbosity": N}.
+func (s *Server) handleLogLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		level := r.URL.Query().Get("level")
+		if level == "" {
+			var err error
+			level, err = levelFromBody(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := s.logLevel.Set(level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.FromContext(r.Context()).Info("Log level changed via debug endpoint", "level", s.logLevel.Info().Level)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.writeResponse(w, s.logLevel.Info(), "json")
+}
+
+// levelFromBody reads a LogLevelInfo-shaped JSON body. Level wins over verbosity.
+func levelFromBody(body io.Reader) (string, error) {
+	var req struct {
+		Level     string `json:"level"`
+		Verbosity *int   `json:"verbosity"`
+	}
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return "", fmt.Errorf("invalid request body: %w", err)
+	}
+	if req.Level != "" {
+		return req.Level, nil
+	}
+	if req.Verbosity != nil {
+		return strconv.Itoa(*req.Verbosity), nil
+	}
+	return "", fmt.Errorf("level or verbosity is required")
+}
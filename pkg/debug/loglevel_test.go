@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected zapcore.Level
+		errorMsg string
+	}{
+		{input: "info", expected: zapcore.InfoLevel},
+		{input: "DEBUG", expected: zapcore.DebugLevel},
+		{input: "error", expected: zapcore.ErrorLevel},
+		{input: "0", expected: zapcore.InfoLevel},
+		{input: "3", expected: zapcore.Level(-3)},
+		{input: "-1", errorMsg: "verbosity must be >= 0"},
+		{input: "loud", errorMsg: "invalid level"},
+		{input: " ", errorMsg: "level is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := parseLogLevel(tt.input)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestLogLevelToggle(t *testing.T) {
+	level := NewLogLevel(zapcore.InfoLevel)
+	assert.Equal(t, LogLevelInfo{Level: "info", Verbosity: 0}, level.Info())
+
+	level.Toggle()
+	assert.Equal(t, verboseLevel, level.Atomic().Level())
+	assert.Equal(t, 2, level.Info().Verbosity)
+
+	level.Toggle()
+	assert.Equal(t, zapcore.InfoLevel, level.Atomic().Level())
+
+	// From any non-initial level, toggling restores the startup level
+	require.NoError(t, level.Set("error"))
+	level.Toggle()
+	assert.Equal(t, zapcore.InfoLevel, level.Atomic().Level())
+}
+
+func TestHandleLogLevel(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	server := NewServer(nil, loader, registry)
+	server.SetLogLevel(NewLogLevel(zapcore.InfoLevel))
+	mux := http.NewServeMux()
+	server.InstallHandlers(mux)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expected       LogLevelInfo
+	}{
+		{
+			name:           "get current level",
+			method:         http.MethodGet,
+			url:            "/debug/loglevel",
+			expectedStatus: http.StatusOK,
+			expected:       LogLevelInfo{Level: "info", Verbosity: 0},
+		},
+		{
+			name:           "put level by query",
+			method:         http.MethodPut,
+			url:            "/debug/loglevel?level=debug",
+			expectedStatus: http.StatusOK,
+			expected:       LogLevelInfo{Level: "debug", Verbosity: 1},
+		},
+		{
+			name:           "put verbosity in body",
+			method:         http.MethodPut,
+			url:            "/debug/loglevel",
+			body:           `{"verbosity": 4}`,
+			expectedStatus: http.StatusOK,
+			expected:       LogLevelInfo{Level: "Level(-4)", Verbosity: 4},
+		},
+		{
+			name:           "put level in body",
+			method:         http.MethodPut,
+			url:            "/debug/loglevel",
+			body:           `{"level": "info"}`,
+			expectedStatus: http.StatusOK,
+			expected:       LogLevelInfo{Level: "info", Verbosity: 0},
+		},
+		{
+			name:           "invalid level",
+			method:         http.MethodPut,
+			url:            "/debug/loglevel?level=loud",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty body",
+			method:         http.MethodPut,
+			url:            "/debug/loglevel",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			url:            "/debug/loglevel",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var info LogLevelInfo
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+			assert.Equal(t, tt.expected, info)
+		})
+	}
+}
+
+func TestLogLevelEndpointRequiresLevel(t *testing.T) {
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	NewServer(nil, loader, registry).InstallHandlers(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+// maxSimulateBodySize bounds the HCO document accepted by /debug/simulate
+const maxSimulateBodySize = 1 << 20
+
+// SimulationResult is the /debug/simulate payload: how asset inclusion and rendering
+// would change if the live HCO were replaced by the submitted one.
+type SimulationResult struct {
+	HCO           string            `json:"hco" yaml:"hco"`
+	NewlyIncluded []StatusChange    `json:"newlyIncluded" yaml:"newlyIncluded"`
+	NewlyExcluded []StatusChange    `json:"newlyExcluded" yaml:"newlyExcluded"`
+	Changed       []SimulatedChange `json:"changed" yaml:"changed"`
+	Unchanged     int               `json:"unchanged" yaml:"unchanged"`
+}
+
+// StatusChange describes an asset whose render status flips between the live and simulated HCO
+type StatusChange struct {
+	Asset     string        `json:"asset" yaml:"asset"`
+	Component string        `json:"component" yaml:"component"`
+	From      string        `json:"from" yaml:"from"`
+	To        string        `json:"to" yaml:"to"`
+	Reason    engine.Reason `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message   string        `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// SimulatedChange describes an asset that is included either way but renders differently.
+// Fields lists the changed field paths (e.g. "spec.featureGates.foo").
+type SimulatedChange struct {
+	Asset     string                     `json:"asset" yaml:"asset"`
+	Component string                     `json:"component" yaml:"component"`
+	Fields    []string                   `json:"fields" yaml:"fields"`
+	Before    *unstructured.Unstructured `json:"before" yaml:"before"`
+	After     *unstructured.Unstructured `json:"after" yaml:"after"`
+}
+
+// handleSimulate renders all assets against the live HCO and against a modified HCO
+// posted in the request body (YAML or JSON), and returns the difference.
+// Nothing is applied; the live HCO is matched by the submitted name and namespace.
+func (s *Server) handleSimulate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	simulated, err := decodeSimulatedHCO(http.MaxBytesReader(w, r.Body, maxSimulateBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	live, err := s.getLiveHCO(ctx, simulated.GetName(), simulated.GetNamespace())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	assetList := s.registry.ListAssetsByReconcileOrder()
+	before := pkgrender.BuildOutputs(assetList, s.renderer, pkgcontext.NewRenderContext(live), true)
+	after := pkgrender.BuildOutputs(assetList, s.renderer, pkgcontext.NewRenderContext(simulated), true)
+
+	result := diffOutputs(before, after)
+	result.HCO = live.GetNamespace() + "/" + live.GetName()
+	s.writeResponse(w, result, format)
+}
+
+// decodeSimulatedHCO parses a single HyperConverged document
+func decodeSimulatedHCO(body io.Reader) (*unstructured.Unstructured, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("request body must contain a HyperConverged document")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+		return nil, fmt.Errorf("invalid HyperConverged document: %w", err)
+	}
+	if obj.GetKind() != pkgcontext.HCOKind {
+		return nil, fmt.Errorf("expected kind %s, got %q", pkgcontext.HCOKind, obj.GetKind())
+	}
+	return obj, nil
+}
+
+// getLiveHCO returns the HCO named name in namespace, or the first HCO when name is empty
+func (s *Server) getLiveHCO(ctx context.Context, name, namespace string) (*unstructured.Unstructured, error) {
+	hcoList := &unstructured.UnstructuredList{}
+	hcoList.SetGroupVersionKind(pkgcontext.HCOGVK)
+
+	if err := s.client.List(ctx, hcoList); err != nil {
+		return nil, fmt.Errorf("failed to list HCO: %w", err)
+	}
+
+	for i := range hcoList.Items {
+		item := &hcoList.Items[i]
+		if name == "" || (item.GetName() == name && (namespace == "" || item.GetNamespace() == namespace)) {
+			return item, nil
+		}
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("no HyperConverged resources found")
+	}
+	return nil, fmt.Errorf("HyperConverged %s/%s not found", namespace, name)
+}
+
+// diffOutputs compares two BuildOutputs results (rendered with showExcluded) asset by asset
+func diffOutputs(before, after []pkgrender.RenderOutput) SimulationResult {
+	result := SimulationResult{
+		NewlyIncluded: []StatusChange{},
+		NewlyExcluded: []StatusChange{},
+		Changed:       []SimulatedChange{},
+	}
+
+	previous := make(map[string]pkgrender.RenderOutput, len(before))
+	for _, output := range before {
+		previous[output.Asset] = output
+	}
+
+	for _, next := range after {
+		prev := previous[next.Asset]
+		wasIncluded := prev.Status == "INCLUDED"
+		isIncluded := next.Status == "INCLUDED"
+
+		switch {
+		case !wasIncluded && isIncluded:
+			result.NewlyIncluded = append(result.NewlyIncluded, StatusChange{
+				Asset: next.Asset, Component: next.Component, From: prev.Status, To: next.Status,
+			})
+		case wasIncluded && !isIncluded:
+			result.NewlyExcluded = append(result.NewlyExcluded, StatusChange{
+				Asset: next.Asset, Component: next.Component, From: prev.Status, To: next.Status, Reason: next.Reason, Message: next.Message,
+			})
+		case wasIncluded && isIncluded:
+			fields := changedFields(prev.Object.Object, next.Object.Object, "")
+			if len(fields) == 0 {
+				result.Unchanged++
+				continue
+			}
+			result.Changed = append(result.Changed, SimulatedChange{
+				Asset: next.Asset, Component: next.Component, Fields: fields, Before: prev.Object, After: next.Object,
+			})
+		default:
+			result.Unchanged++
+		}
+	}
+
+	return result
+}
+
+// changedFields returns the sorted dotted paths where a and b differ.
+// Nested maps are compared key by key; any other differing value is reported at its path.
+func changedFields(a, b map[string]any, prefix string) []string {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	var fields []string
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		av, bv := a[k], b[k]
+		am, aIsMap := av.(map[string]any)
+		bm, bIsMap := bv.(map[string]any)
+		if aIsMap && bIsMap {
+			fields = append(fields, changedFields(am, bm, path)...)
+			continue
+		}
+		if !reflect.DeepEqual(av, bv) {
+			fields = append(fields, path)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
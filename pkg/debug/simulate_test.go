@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+	pkgrender "github.com/kubevirt/virt-platform-autopilot/pkg/render"
+)
+
+func newSimulateServer(t *testing.T) *Server {
+	t.Helper()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	fakeClient := fake.NewClientBuilder().WithObjects(hco).Build()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	require.NoError(t, err)
+
+	return NewServer(fakeClient, loader, registry)
+}
+
+func TestHandleSimulate(t *testing.T) {
+	server := newSimulateServer(t)
+
+	simulated := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	simulated.SetAnnotations(map[string]string{"platform.kubevirt.io/enable-mtv": "true"})
+	body, err := yaml.Marshal(simulated.Object)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/debug/simulate?format=json", strings.NewReader(string(body)))
+	w := httptest.NewRecorder()
+	server.handleSimulate(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result SimulationResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "openshift-cnv/kubevirt-hyperconverged", result.HCO)
+	require.Len(t, result.NewlyIncluded, 1)
+	assert.Equal(t, "mtv-operator", result.NewlyIncluded[0].Asset)
+	assert.Equal(t, "EXCLUDED", result.NewlyIncluded[0].From)
+	assert.Equal(t, "INCLUDED", result.NewlyIncluded[0].To)
+	assert.Empty(t, result.NewlyExcluded)
+	assert.Positive(t, result.Unchanged)
+}
+
+func TestHandleSimulateErrors(t *testing.T) {
+	server := newSimulateServer(t)
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+	}{
+		{"GET not allowed", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"empty body", http.MethodPost, "", http.StatusBadRequest},
+		{"invalid document", http.MethodPost, "{not yaml", http.StatusBadRequest},
+		{"wrong kind", http.MethodPost, "apiVersion: v1\nkind: ConfigMap\n", http.StatusBadRequest},
+		{"unknown HCO", http.MethodPost,
+			"apiVersion: hco.kubevirt.io/v1beta1\nkind: HyperConverged\nmetadata:\n  name: other\n  namespace: openshift-cnv\n",
+			http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/debug/simulate", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			server.handleSimulate(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestDiffOutputs(t *testing.T) {
+	object := func(replicas int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"kind":     "Deployment",
+			"metadata": map[string]any{"name": "d"},
+			"spec":     map[string]any{"replicas": replicas, "paused": false},
+		}}
+	}
+
+	before := []pkgrender.RenderOutput{
+		{Asset: "same", Status: "INCLUDED", Object: object(1)},
+		{Asset: "changed", Status: "INCLUDED", Object: object(1)},
+		{Asset: "dropped", Status: "INCLUDED", Object: object(1)},
+		{Asset: "added", Status: "EXCLUDED"},
+		{Asset: "still-excluded", Status: "EXCLUDED"},
+	}
+	after := []pkgrender.RenderOutput{
+		{Asset: "same", Status: "INCLUDED", Object: object(1)},
+		{Asset: "changed", Status: "INCLUDED", Object: object(3)},
+		{Asset: "dropped", Status: "FILTERED", Reason: engine.ReasonDisabledResources},
+		{Asset: "added", Status: "INCLUDED", Object: object(1)},
+		{Asset: "still-excluded", Status: "EXCLUDED"},
+	}
+
+	result := diffOutputs(before, after)
+
+	require.Len(t, result.NewlyIncluded, 1)
+	assert.Equal(t, "added", result.NewlyIncluded[0].Asset)
+	require.Len(t, result.NewlyExcluded, 1)
+	assert.Equal(t, StatusChange{Asset: "dropped", From: "INCLUDED", To: "FILTERED", Reason: engine.ReasonDisabledResources}, result.NewlyExcluded[0])
+	require.Len(t, result.Changed, 1)
+	assert.Equal(t, "changed", result.Changed[0].Asset)
+	assert.Equal(t, []string{"spec.replicas"}, result.Changed[0].Fields)
+	assert.Equal(t, 2, result.Unchanged)
+}
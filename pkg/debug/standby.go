@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+)
+
+// SetLeaderStatus enables the /debug/standby endpoint backed by status
+func (s *Server) SetLeaderStatus(status func() controller.LeaderStatus) {
+	s.leaderStatus = status
+}
+
+// handleStandby returns the leader election state observed by this replica.
+// It answers 503 when no leader renewed the lease in time, so a probe against
+// any replica tells whether the HA deployment has a working leader.
+func (s *Server) handleStandby(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := s.leaderStatus()
+	output, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to marshal response: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if status.LeaderHealthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(output)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/controller"
+)
+
+func TestHandleStandby(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       controller.LeaderStatus
+		expectedCode int
+	}{
+		{
+			name:         "healthy leader",
+			status:       controller.LeaderStatus{Role: controller.RoleStandby, Holder: "autopilot-0_1234", LeaderHealthy: true},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "no healthy leader",
+			status:       controller.LeaderStatus{Role: controller.RoleStandby, Holder: "autopilot-0_1234"},
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(nil, nil, nil)
+			server.SetLeaderStatus(func() controller.LeaderStatus { return tt.status })
+			mux := http.NewServeMux()
+			server.InstallHandlers(mux)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/standby", nil))
+			require.Equal(t, tt.expectedCode, w.Code)
+
+			var status controller.LeaderStatus
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			assert.Equal(t, tt.status, status)
+		})
+	}
+
+	t.Run("not registered without leader election", func(t *testing.T) {
+		mux := http.NewServeMux()
+		NewServer(nil, nil, nil).InstallHandlers(mux)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/standby", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AbandonFieldManager is the field manager of the patch that abandons an object
+const AbandonFieldManager = FieldManager + "-cleanup"
+
+// AbandonOwnership removes what marks obj as managed by the autopilot, its managed-by
+// label and the autopilot's managedFields entries, and leaves the object as it is for
+// manual management: nothing is deleted and no other manager loses fields. It returns
+// false when obj carried neither.
+func AbandonOwnership(ctx context.Context, c client.Client, obj client.Object) (bool, error) {
+	entries := slices.DeleteFunc(slices.Clone(obj.GetManagedFields()), func(e metav1.ManagedFieldsEntry) bool {
+		return e.Manager == FieldManager
+	})
+	labeled := obj.GetLabels()[ManagedByLabel] == ManagedByValue
+	if !labeled && len(entries) == len(obj.GetManagedFields()) {
+		return false, nil
+	}
+
+	original, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return false, fmt.Errorf("cannot copy %T", obj)
+	}
+	if labeled {
+		labels := obj.GetLabels()
+		delete(labels, ManagedByLabel)
+		obj.SetLabels(labels)
+	}
+	if len(entries) == 0 {
+		// An empty list leaves managedFields unchanged; a single empty entry clears them
+		entries = []metav1.ManagedFieldsEntry{{}}
+	}
+	obj.SetManagedFields(entries)
+
+	// The optimistic lock keeps a concurrent apply from being dropped from managedFields
+	if err := c.Patch(ctx, obj, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}),
+		client.FieldOwner(AbandonFieldManager)); err != nil {
+		return false, fmt.Errorf("failed to abandon ownership: %w", err)
+	}
+	return true, nil
+}
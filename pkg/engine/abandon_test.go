@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAbandonOwnership(t *testing.T) {
+	ctx := context.Background()
+	apply := corev1ac.ConfigMap("virt-settings", "openshift-cnv").
+		WithLabels(map[string]string{ManagedByLabel: ManagedByValue}).
+		WithData(map[string]string{"setting": "value"})
+	key := client.ObjectKey{Namespace: "openshift-cnv", Name: "virt-settings"}
+
+	tests := []struct {
+		name      string
+		edit      func(*corev1.ConfigMap)
+		wantOther bool
+	}{
+		{name: "only the autopilot manages the object"},
+		{
+			name:      "another manager keeps its fields",
+			edit:      func(cm *corev1.ConfigMap) { cm.Data["other"] = "by-hand" },
+			wantOther: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithReturnManagedFields().Build()
+			if err := c.Apply(ctx, apply, client.ForceOwnership, client.FieldOwner(FieldManager)); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if tt.edit != nil {
+				cm := &corev1.ConfigMap{}
+				if err := c.Get(ctx, key, cm); err != nil {
+					t.Fatal(err)
+				}
+				tt.edit(cm)
+				if err := c.Update(ctx, cm, client.FieldOwner("kubectl-edit")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			obj := &metav1.PartialObjectMetadata{}
+			obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+			if err := c.Get(ctx, key, obj); err != nil {
+				t.Fatal(err)
+			}
+			abandoned, err := AbandonOwnership(ctx, c, obj)
+			if err != nil || !abandoned {
+				t.Fatalf("AbandonOwnership() = %v, %v; want abandoned", abandoned, err)
+			}
+
+			cm := &corev1.ConfigMap{}
+			if err := c.Get(ctx, key, cm); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := cm.Labels[ManagedByLabel]; ok {
+				t.Errorf("labels = %v, want the managed-by label removed", cm.Labels)
+			}
+			if AppliedByAutopilot(cm) {
+				t.Errorf("managedFields = %+v, want no entry of %s", cm.ManagedFields, FieldManager)
+			}
+			if cm.Data["setting"] != "value" {
+				t.Errorf("data = %v, want the applied values left in place", cm.Data)
+			}
+			hasOther := false
+			for _, entry := range cm.ManagedFields {
+				hasOther = hasOther || entry.Manager == "kubectl-edit"
+			}
+			if hasOther != tt.wantOther {
+				t.Errorf("managedFields = %+v, want kubectl-edit entry %v", cm.ManagedFields, tt.wantOther)
+			}
+
+			// Nothing left to abandon
+			if err := c.Get(ctx, key, obj); err != nil {
+				t.Fatal(err)
+			}
+			if abandoned, err := AbandonOwnership(ctx, c, obj); err != nil || abandoned {
+				t.Errorf("second AbandonOwnership() = %v, %v; want nothing to do", abandoned, err)
+			}
+		})
+	}
+}
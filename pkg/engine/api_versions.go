@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+// selectAPIVersion returns the apiVersion the asset's objects are rendered with: the
+// first of the asset's api_versions that its CRD serves. It returns "" to keep the
+// apiVersion of the template, when the asset has no candidates, the renderer has no
+// client (offline render) or the CRD is not installed, in which case the asset is
+// skipped before apply anyway.
+func (r *Renderer) selectAPIVersion(assetMeta *assets.AssetMetadata) (string, error) {
+	if len(assetMeta.APIVersions) == 0 || r.client == nil {
+		return "", nil
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := r.client.Get(context.Background(), types.NamespacedName{Name: assetMeta.RequiredCRD}, crd); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get CRD %s: %w", assetMeta.RequiredCRD, err)
+	}
+
+	served := servedVersions(crd)
+	for _, candidate := range assetMeta.APIVersions {
+		gv, err := schema.ParseGroupVersion(candidate)
+		if err != nil {
+			return "", err
+		}
+		if served[gv.Version] {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("CRD %s serves none of the api_versions of asset %s (%s)",
+		assetMeta.RequiredCRD, assetMeta.Name, strings.Join(assetMeta.APIVersions, ", "))
+}
+
+// servedVersions returns the versions of crd the API server currently serves
+func servedVersions(crd *apiextensionsv1.CustomResourceDefinition) map[string]bool {
+	served := make(map[string]bool, len(crd.Spec.Versions))
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			served[version.Name] = true
+		}
+	}
+	return served
+}
+
+// setAPIVersion rewrites the apiVersion of objs, if one was selected
+func setAPIVersion(apiVersion string, objs ...*unstructured.Unstructured) {
+	if apiVersion == "" {
+		return
+	}
+	for _, obj := range objs {
+		obj.SetAPIVersion(apiVersion)
+	}
+}
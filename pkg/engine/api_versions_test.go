@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+func TestSelectAPIVersion(t *testing.T) {
+	const crdName = "nodehealthchecks.remediation.medik8s.io"
+	candidates := []string{"remediation.medik8s.io/v1beta1", "remediation.medik8s.io/v1alpha1"}
+
+	crd := func(versions map[string]bool) *apiextensionsv1.CustomResourceDefinition {
+		obj := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crdName}}
+		for name, served := range versions {
+			obj.Spec.Versions = append(obj.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: name, Served: served})
+		}
+		return obj
+	}
+
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name       string
+		candidates []string
+		crd        *apiextensionsv1.CustomResourceDefinition
+		noClient   bool
+		want       string
+		wantErr    string
+	}{
+		{name: "preferred version served", candidates: candidates,
+			crd: crd(map[string]bool{"v1alpha1": true, "v1beta1": true}), want: "remediation.medik8s.io/v1beta1"},
+		{name: "falls back to older version", candidates: candidates,
+			crd: crd(map[string]bool{"v1alpha1": true}), want: "remediation.medik8s.io/v1alpha1"},
+		{name: "unserved version skipped", candidates: candidates,
+			crd: crd(map[string]bool{"v1alpha1": false, "v1beta1": true}), want: "remediation.medik8s.io/v1beta1"},
+		{name: "no candidate served", candidates: candidates,
+			crd: crd(map[string]bool{"v1": true}), wantErr: "serves none of the api_versions"},
+		{name: "CRD not installed keeps template version", candidates: candidates},
+		{name: "offline render keeps template version", candidates: candidates, noClient: true,
+			crd: crd(map[string]bool{"v1alpha1": true})},
+		{name: "no candidates", crd: crd(map[string]bool{"v1alpha1": true})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := NewRenderer(assets.NewLoader())
+			if !tt.noClient {
+				builder := fake.NewClientBuilder().WithScheme(scheme)
+				if tt.crd != nil {
+					builder = builder.WithObjects(tt.crd)
+				}
+				renderer.SetClient(builder.Build())
+			}
+
+			asset := &assets.AssetMetadata{Name: "node-health-check", RequiredCRD: crdName, APIVersions: tt.candidates}
+			got, err := renderer.selectAPIVersion(asset)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectAPIVersion() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectAPIVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("selectAPIVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -99,9 +100,12 @@ func (a *Applier) Apply(ctx context.Context, obj *unstructured.Unstructured, for
 	err := a.client.Apply(ctx, applyConfig, applyOptions...)
 	if err != nil {
 		if errors.IsConflict(err) {
-			return false, fmt.Errorf("field ownership conflict (another controller owns fields): %w", err)
+			return false, &ApplyConflictError{
+				Object: appliedObj.GetKind() + "/" + appliedObj.GetNamespace() + "/" + appliedObj.GetName(),
+				Err:    err,
+			}
 		}
-		return false, fmt.Errorf("failed to apply object: %w", err)
+		return false, fmt.Errorf("failed to apply object: %w", dependencyError(appliedObj.GroupVersionKind(), err))
 	}
 
 	logger.V(1).Info("Successfully applied object",
@@ -181,3 +185,15 @@ func HasManagedByLabel(obj *unstructured.Unstructured) bool {
 	}
 	return labels[ManagedByLabel] == ManagedByValue
 }
+
+// AppliedByAutopilot reports whether the autopilot has server-side applied the object,
+// i.e. its managedFields hold an Apply entry of FieldManager. Unlike the managed-by
+// label, this record cannot be removed by editing the object's metadata.
+func AppliedByAutopilot(obj metav1.Object) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+)
+
+const (
+	// applyRetryBaseDelay is the delay before the first retry when the API server suggests none
+	applyRetryBaseDelay = 10 * time.Second
+	// maxApplyAttempts bounds the attempts of one object before its failure is reported
+	// as such, so a webhook that stays down still degrades the status
+	maxApplyAttempts = 5
+)
+
+// AsyncApply configures the retries of objects whose admission webhook is unavailable or
+// too slow. Instead of failing the pass, such an object is retried when the API server
+// asks to (Retry-After) or after a backoff, and the retry runs in the background with its
+// own timeout, so a slow webhook neither fails nor holds up the other assets. A zero
+// Timeout disables it: the apply fails like any other.
+type AsyncApply struct {
+	// Timeout bounds a background apply; it may exceed the per-asset timeout
+	Timeout time.Duration
+	// MaxRetryDelay caps the backoff between attempts and the Retry-After honoured
+	MaxRetryDelay time.Duration
+}
+
+// DefaultAsyncApply returns the settings used by the controller unless configured
+func DefaultAsyncApply() AsyncApply {
+	return AsyncApply{
+		Timeout:       2 * time.Minute,
+		MaxRetryDelay: 5 * time.Minute,
+	}
+}
+
+// Validate rejects a negative timeout and, when enabled, a retry delay shorter than the first one
+func (a AsyncApply) Validate() error {
+	switch {
+	case a.Timeout < 0:
+		return fmt.Errorf("async apply timeout must not be negative, got %s", a.Timeout)
+	case a.Timeout > 0 && a.MaxRetryDelay < applyRetryBaseDelay:
+		return fmt.Errorf("apply retry max delay must be at least %s, got %s", applyRetryBaseDelay, a.MaxRetryDelay)
+	}
+	return nil
+}
+
+// AdmissionUnavailable reports whether err means the API server could not get the object
+// admitted in time: a webhook call that failed or timed out (surfaced as a 5xx naming the
+// webhook), a server timeout or overload, or the request itself running out of time.
+// These go away on their own, unlike a rejection by the webhook, which is a 4xx.
+func AdmissionUnavailable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	switch {
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err),
+		apierrors.IsServiceUnavailable(err), apierrors.IsTooManyRequests(err):
+		return true
+	case status.Status().Code >= 500:
+		return strings.Contains(status.Status().Message, "webhook")
+	}
+	return false
+}
+
+// asyncApplier tracks the objects whose admission was unavailable. While one waits for
+// its retry, passes leave it alone; once due, the pass that reaches its apply hands the
+// apply over to a goroutine and goes on. Every attempt starts from a pass, so it only
+// happens past every hold (maintenance window, upgrade gate, blast radius) and applies
+// the latest rendering.
+type asyncApplier struct {
+	mu       sync.Mutex
+	settings AsyncApply
+	ctx      context.Context // nil unless RunAsyncApplies is running
+	notify   func()
+	objects  map[string]*asyncApplyState // throttling.MakeResourceKey -> state
+}
+
+type asyncApplyState struct {
+	attempts int
+	// next is the earliest time of the next attempt
+	next time.Time
+	// running is set while a background apply is in flight
+	running bool
+	// failed is the error a background apply ended with, reported by the next pass
+	failed error
+}
+
+// SetAsyncApply configures the retries of objects whose admission is unavailable
+func (p *Patcher) SetAsyncApply(settings AsyncApply) {
+	p.async.mu.Lock()
+	defer p.async.mu.Unlock()
+	p.async.settings = settings
+}
+
+// RunAsyncApplies enables the retries until ctx is done; notify is called when an
+// object is due for a retry or a background apply ended, to start a pass. In-flight
+// applies are cancelled with ctx.
+func (p *Patcher) RunAsyncApplies(ctx context.Context, notify func()) error {
+	p.async.mu.Lock()
+	p.async.ctx = ctx
+	p.async.notify = notify
+	p.async.mu.Unlock()
+
+	<-ctx.Done()
+
+	p.async.mu.Lock()
+	defer p.async.mu.Unlock()
+	p.async.ctx = nil
+	p.async.objects = nil
+	return nil
+}
+
+// pending returns why an object waiting for a retry or a background apply is left alone
+// this pass, or the error its background apply failed with, once
+func (a *asyncApplier) pending(key string, now time.Time) (Reason, string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	state, ok := a.objects[key]
+	switch {
+	case !ok:
+		return "", "", nil
+	case state.failed != nil:
+		delete(a.objects, key)
+		return "", "", state.failed
+	case state.running:
+		return ReasonApplyingInBackground,
+			fmt.Sprintf("applying in the background (attempt %d of %d)", state.attempts+1, maxApplyAttempts), nil
+	case now.Before(state.next):
+		return ReasonAdmissionUnavailable, fmt.Sprintf("admission unavailable, next attempt at %s (attempt %d of %d)",
+			state.next.UTC().Format(time.RFC3339), state.attempts+1, maxApplyAttempts), nil
+	}
+	return "", "", nil
+}
+
+// retry schedules another attempt for an object whose admission failed with err,
+// returning the delay. It returns false when err is of another kind, the attempts are
+// exhausted or retries are off; the failure is then reported as usual.
+func (a *asyncApplier) retry(key string, err error, now time.Time) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.ctx == nil || a.settings.Timeout <= 0 || !AdmissionUnavailable(err) {
+		return 0, false
+	}
+	if a.objects == nil {
+		a.objects = make(map[string]*asyncApplyState)
+	}
+	state, ok := a.objects[key]
+	if !ok {
+		state = &asyncApplyState{}
+		a.objects[key] = state
+	}
+	state.running = false
+	state.attempts++
+	if state.attempts >= maxApplyAttempts {
+		delete(a.objects, key)
+		return 0, false
+	}
+	delay := a.retryDelay(err, state.attempts)
+	state.next = now.Add(delay)
+	time.AfterFunc(delay, a.notify)
+	return delay, true
+}
+
+// retryDelay honours the Retry-After of err, else backs off exponentially; both are
+// capped by MaxRetryDelay
+func (a *asyncApplier) retryDelay(err error, attempts int) time.Duration {
+	delay := applyRetryBaseDelay << (attempts - 1)
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	return min(delay, a.settings.MaxRetryDelay)
+}
+
+// queued reports whether the next apply of an object runs in the background
+func (a *asyncApplier) queued(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.objects[key]
+	return ok && a.ctx != nil
+}
+
+// forget drops an object that turned out to be in sync
+func (a *asyncApplier) forget(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.objects, key)
+}
+
+// start runs apply in the background with the async timeout, then calls notify so a
+// pass picks up the outcome
+func (a *asyncApplier) start(key string, apply func(context.Context) error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	state, ok := a.objects[key]
+	if !ok || a.ctx == nil {
+		return
+	}
+	state.running = true
+	ctx, cancel := context.WithTimeout(a.ctx, a.settings.Timeout)
+	notify := a.notify
+	go func() {
+		defer cancel()
+		a.finish(key, apply(ctx), time.Now())
+		notify()
+	}()
+}
+
+// finish records the outcome of a background apply: success forgets the object, an
+// admission failure schedules the next attempt and any other error, or the last
+// attempt's, is kept for the next pass to report
+func (a *asyncApplier) finish(key string, err error, now time.Time) {
+	if err == nil {
+		a.forget(key)
+		return
+	}
+	if _, ok := a.retry(key, err, now); ok {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.ctx == nil {
+		return
+	}
+	if a.objects == nil {
+		a.objects = make(map[string]*asyncApplyState)
+	}
+	a.objects[key] = &asyncApplyState{failed: err}
+}
+
+// deferApply schedules a retry of an object whose drift check or apply failed with err
+// for want of admission, and reports it pending. It returns false when err is not retried.
+func (p *Patcher) deferApply(
+	ctx context.Context,
+	assetMeta *assets.AssetMetadata,
+	desired *unstructured.Unstructured,
+	renderCtx *pkgcontext.RenderContext,
+	err error,
+) bool {
+	key := throttling.MakeResourceKey(desired.GetNamespace(), desired.GetName(), desired.GetKind())
+	delay, ok := p.async.retry(key, err, time.Now())
+	if !ok {
+		return false
+	}
+	log.FromContext(ctx).Info("Admission unavailable, retrying later",
+		"name", assetMeta.Name,
+		"kind", desired.GetKind(),
+		"retryIn", delay.String(),
+		"error", err.Error(),
+	)
+	observability.SetCompliance(desired, 0)
+	observability.IncApplyRetry(desired.GetKind(), "scheduled")
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.AdmissionUnavailable(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(),
+			delay.String(), err.Error())
+	}
+	p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonAdmissionUnavailable, "admission unavailable, retrying in "+delay.String())
+	return true
+}
+
+// applyInBackground hands the apply of an object whose admission failed before over to
+// the async applier and reports it pending; the pass triggered when it ends sees the result.
+// An existing object is checked for drift first, since the dry-run goes through the same
+// webhook, and left alone without drift.
+func (p *Patcher) applyInBackground(
+	ctx context.Context,
+	assetMeta *assets.AssetMetadata,
+	desired, live *unstructured.Unstructured,
+	renderCtx *pkgcontext.RenderContext,
+) {
+	logger := log.FromContext(ctx)
+	asset, hco := assetMeta.Name, renderCtx.HCO
+	kind, namespace, name := desired.GetKind(), desired.GetNamespace(), desired.GetName()
+	key := throttling.MakeResourceKey(namespace, name, kind)
+
+	logger.Info("Applying asset in the background", "name", asset, "kind", kind)
+	p.async.start(key, func(applyCtx context.Context) error {
+		applyCtx = log.IntoContext(applyCtx, logger)
+		if live != nil {
+			hasDrift, err := p.driftDetector.DetectDrift(applyCtx, desired, live)
+			if err != nil {
+				logger.Info("Background drift check failed", "name", asset, "kind", kind, "error", err.Error())
+				observability.IncApplyRetry(kind, "failed")
+				return fmt.Errorf("drift detection failed: %w", err)
+			}
+			if !hasDrift {
+				logger.V(1).Info("No drift detected, skipping background apply", "name", asset, "kind", kind)
+				return nil
+			}
+			if p.eventRecorder != nil && hco != nil {
+				p.eventRecorder.DriftDetected(hco, kind, namespace, name)
+			}
+		}
+		if _, err := p.applier.Apply(applyCtx, desired, true); err != nil {
+			logger.Info("Background apply failed", "name", asset, "kind", kind, "error", err.Error())
+			observability.IncApplyRetry(kind, "failed")
+			return fmt.Errorf("failed to apply asset %s: %w", asset, err)
+		}
+		logger.Info("Successfully applied asset in the background",
+			"name", asset,
+			"kind", kind,
+			"namespace", namespace,
+			"objectName", name,
+		)
+		observability.IncApplyRetry(kind, "succeeded")
+		observability.SetCompliance(desired, 1)
+		p.thrashingDetector.RecordSuccess(key)
+		if p.eventRecorder != nil && hco != nil {
+			p.eventRecorder.AssetApplied(hco, asset, kind, namespace, name)
+		}
+		if p.history != nil {
+			p.history.Record(applyCtx, asset, desired)
+		}
+		return nil
+	})
+	p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonApplyingInBackground, "applying in the background")
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+var metalLBResource = schema.GroupResource{Group: "metallb.io", Resource: "metallbs"}
+
+// webhookDown is what the API server returns when it cannot reach a webhook
+func webhookDown() error {
+	return apierrors.NewInternalError(errors.New(`failed calling webhook "metallbvalidationwebhook.metallb.io": ` +
+		`Post "https://metallb-webhook-service.metallb-system.svc:443/validate": context deadline exceeded`))
+}
+
+func TestAdmissionUnavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"webhook unreachable", webhookDown(), true},
+		{"wrapped webhook failure", fmt.Errorf("failed to apply object: %w", webhookDown()), true},
+		{"internal error without a webhook", apierrors.NewInternalError(errors.New("etcdserver: leader changed")), false},
+		{"service unavailable", apierrors.NewServiceUnavailable("the server is currently unable to handle the request"), true},
+		{"gateway timeout", apierrors.NewTimeoutError("request did not complete within 30s", 0), true},
+		{"server timeout", apierrors.NewServerTimeout(metalLBResource, "patch", 5), true},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 10), true},
+		{"request deadline", fmt.Errorf("failed to apply object: %w", context.DeadlineExceeded), true},
+		{"denied by the webhook", apierrors.NewForbidden(metalLBResource, "metallb",
+			errors.New(`admission webhook "metallbvalidationwebhook.metallb.io" denied the request`)), false},
+		{"invalid object", apierrors.NewBadRequest("spec.nodeSelector: invalid value"), false},
+		{"plain error", errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AdmissionUnavailable(tt.err); got != tt.want {
+				t.Errorf("AdmissionUnavailable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsyncApplyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings AsyncApply
+		wantErr  bool
+	}{
+		{"defaults", DefaultAsyncApply(), false},
+		{"disabled", AsyncApply{}, false},
+		{"negative timeout", AsyncApply{Timeout: -time.Second, MaxRetryDelay: time.Minute}, true},
+		{"max delay below the first retry", AsyncApply{Timeout: time.Minute, MaxRetryDelay: time.Second}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAsyncApplierRetry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	a := &asyncApplier{settings: AsyncApply{Timeout: time.Minute, MaxRetryDelay: time.Minute}, notify: func() {}}
+
+	if _, ok := a.retry("MetalLB/metallb-system/metallb", webhookDown(), now); ok {
+		t.Fatal("retry() scheduled a retry while RunAsyncApplies is not running")
+	}
+	a.ctx = context.Background()
+	if _, ok := a.retry("MetalLB/metallb-system/metallb", errors.New("denied"), now); ok {
+		t.Fatal("retry() scheduled a retry of an error admission does not explain")
+	}
+
+	// Backoff doubles from the base delay and is capped
+	for i, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute} {
+		delay, ok := a.retry("MetalLB/metallb-system/metallb", webhookDown(), now)
+		if !ok || delay != want {
+			t.Fatalf("attempt %d: retry() = (%s, %v), want (%s, true)", i+1, delay, ok, want)
+		}
+	}
+	if reason, message, err := a.pending("MetalLB/metallb-system/metallb", now.Add(59*time.Second)); err != nil ||
+		reason != ReasonAdmissionUnavailable || !strings.Contains(message, "attempt 5 of 5") {
+		t.Errorf("pending() before the retry = (%q, %v), want the next attempt", reason, err)
+	}
+	if reason, _, err := a.pending("MetalLB/metallb-system/metallb", now.Add(time.Minute)); reason != "" || err != nil {
+		t.Errorf("pending() once due = (%q, %v), want nothing", reason, err)
+	}
+	// The last attempt fails as usual and starts over
+	if _, ok := a.retry("MetalLB/metallb-system/metallb", webhookDown(), now); ok {
+		t.Error("retry() scheduled a retry after the last attempt")
+	}
+	if a.queued("MetalLB/metallb-system/metallb") {
+		t.Error("object still queued after its last attempt")
+	}
+
+	// The API server's Retry-After wins over the backoff
+	if delay, _ := a.retry("Forklift/openshift-mtv/forklift", apierrors.NewTooManyRequests("slow down", 30), now); delay != 30*time.Second {
+		t.Errorf("retry() with Retry-After 30 = %s, want 30s", delay)
+	}
+}
+
+// startAsyncApplies runs p.RunAsyncApplies until ctx is done and returns its notifications
+func startAsyncApplies(ctx context.Context, t *testing.T, p *Patcher) <-chan struct{} {
+	t.Helper()
+	notified := make(chan struct{}, 10)
+	go func() {
+		_ = p.RunAsyncApplies(ctx, func() { notified <- struct{}{} })
+	}()
+	// RunAsyncApplies has started once a retry can be scheduled
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		p.async.mu.Lock()
+		started := p.async.ctx != nil
+		p.async.mu.Unlock()
+		if started {
+			return notified
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("RunAsyncApplies did not start")
+		}
+	}
+}
+
+func waitForBackgroundApply(t *testing.T, notified <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("background apply did not end")
+	}
+}
+
+func TestAdmissionUnavailableRetriedInBackground(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+
+	var webhookFailures atomic.Int32
+	webhookFailures.Store(2)
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			if webhookFailures.Add(-1) >= 0 {
+				return webhookDown()
+			}
+			return c.Apply(ctx, obj, opts...)
+		},
+	}).Build()
+	rec := eventtest.NewRecorder()
+	drift := &switchableDriftChecker{drift: true}
+	p := &Patcher{
+		renderer:          renderer,
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     drift,
+		throttle:          throttling.NewTokenBucketWithSettings(100, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetAsyncApply(DefaultAsyncApply())
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+
+	notified := startAsyncApplies(ctx, t, p)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := throttling.MakeResourceKey(desired.GetNamespace(), desired.GetName(), desired.GetKind())
+	scheduled := testutil.ToFloat64(observability.ApplyRetriesTotal.WithLabelValues(desired.GetKind(), "scheduled"))
+	failed := testutil.ToFloat64(observability.ApplyRetriesTotal.WithLabelValues(desired.GetKind(), "failed"))
+	makeDue := func() {
+		p.async.mu.Lock()
+		p.async.objects[key].next = time.Now()
+		p.async.mu.Unlock()
+	}
+
+	// The synchronous apply fails: the object waits for its retry instead of failing the pass
+	if applied, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil || applied {
+		t.Fatalf("first pass: applied=%v err=%v, want a retry", applied, err)
+	}
+	if got := sink.reports[assetMeta.Name]; got.State != ObjectPending || got.Reason != ReasonAdmissionUnavailable ||
+		!strings.Contains(got.Message, "retrying in 10s") {
+		t.Errorf("inventory report = %+v, want Pending with the retry", got)
+	}
+	rec.ExpectEvent(t, util.EventReasonAdmissionUnavailable, "", "")
+	if _, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.reports[assetMeta.Name]; !strings.Contains(got.Message, "next attempt at") {
+		t.Errorf("inventory report before the retry = %+v, want the next attempt", got)
+	}
+
+	// Once due, the apply runs in the background; its failure schedules the next retry
+	makeDue()
+	if applied, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil || applied {
+		t.Fatalf("second attempt: applied=%v err=%v, want it in the background", applied, err)
+	}
+	if got := sink.reports[assetMeta.Name]; got.Message != "applying in the background" {
+		t.Errorf("inventory report = %+v, want the background apply", got)
+	}
+	waitForBackgroundApply(t, notified)
+	if got := testutil.ToFloat64(observability.ApplyRetriesTotal.WithLabelValues(desired.GetKind(), "scheduled")); got != scheduled+1 {
+		t.Errorf("apply_retries_total{scheduled} = %v, want %v", got, scheduled+1)
+	}
+	if got := testutil.ToFloat64(observability.ApplyRetriesTotal.WithLabelValues(desired.GetKind(), "failed")); got != failed+1 {
+		t.Errorf("apply_retries_total{failed} = %v, want %v", got, failed+1)
+	}
+
+	// The third attempt succeeds in the background
+	makeDue()
+	if _, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil {
+		t.Fatal(err)
+	}
+	waitForBackgroundApply(t, notified)
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+		t.Fatalf("object not created by the background apply: %v", err)
+	}
+	rec.ExpectEvent(t, util.EventReasonAssetApplied, "", "")
+	if p.async.queued(key) {
+		t.Error("object still queued after a successful apply")
+	}
+
+	// The pass it triggers finds the object in sync
+	drift.drift = false
+	if _, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.reports[assetMeta.Name]; got.State != ObjectInSync {
+		t.Errorf("inventory report = %+v, want InSync", got)
+	}
+}
+
+func TestBackgroundApplyFailureIsReported(t *testing.T) {
+	a := &asyncApplier{settings: DefaultAsyncApply(), notify: func() {}, ctx: context.Background()}
+	now := time.Now()
+	if _, ok := a.retry("MetalLB/metallb-system/metallb", webhookDown(), now); !ok {
+		t.Fatal("retry() did not schedule a retry")
+	}
+	denied := apierrors.NewForbidden(metalLBResource, "metallb", errors.New("denied"))
+	a.finish("MetalLB/metallb-system/metallb", denied, now)
+	if _, _, err := a.pending("MetalLB/metallb-system/metallb", now); !errors.Is(err, denied) {
+		t.Errorf("pending() error = %v, want the background failure", err)
+	}
+	if _, _, err := a.pending("MetalLB/metallb-system/metallb", now); err != nil {
+		t.Errorf("pending() reported the background failure twice: %v", err)
+	}
+}
+
+// slowDriftChecker blocks the dry-run until released, like a slow admission webhook
+type slowDriftChecker struct {
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *slowDriftChecker) DetectDrift(ctx context.Context, _, _ *unstructured.Unstructured) (bool, error) {
+	s.calls.Add(1)
+	select {
+	case <-s.release:
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func TestQueuedDriftCheckRunsInBackground(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(desired.DeepCopy()).Build()
+	rec := eventtest.NewRecorder()
+	drift := &slowDriftChecker{release: make(chan struct{})}
+	p := &Patcher{
+		renderer:          renderer,
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     drift,
+		throttle:          throttling.NewTokenBucketWithSettings(100, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetAsyncApply(DefaultAsyncApply())
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+	notified := startAsyncApplies(ctx, t, p)
+
+	// The object is due for a retry after its admission failed
+	key := throttling.MakeResourceKey(desired.GetNamespace(), desired.GetName(), desired.GetKind())
+	if _, ok := p.async.retry(key, webhookDown(), time.Now().Add(-time.Hour)); !ok {
+		t.Fatal("retry() did not schedule a retry")
+	}
+
+	// The pass does not wait for the dry-run
+	passCtx, passCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer passCancel()
+	if applied, err := p.ReconcileAsset(passCtx, assetMeta, renderCtx); err != nil || applied {
+		t.Fatalf("ReconcileAsset() = (%v, %v), want the drift check in the background", applied, err)
+	}
+	if got := sink.reports[assetMeta.Name]; got.Reason != ReasonApplyingInBackground {
+		t.Errorf("inventory report = %+v, want the background apply", got)
+	}
+
+	close(drift.release)
+	waitForBackgroundApply(t, notified)
+	if got := drift.calls.Load(); got != 1 {
+		t.Errorf("drift checks = %d, want 1 in the background", got)
+	}
+	rec.ExpectEvent(t, util.EventReasonDriftDetected, "", "")
+	rec.ExpectEvent(t, util.EventReasonAssetApplied, "", "")
+	if p.async.queued(key) {
+		t.Error("object still queued after a successful apply")
+	}
+}
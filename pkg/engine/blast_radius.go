@@ -0,0 +1,348 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// BlastRadiusAckAnnotation on the HCO approves batches held back by the blast radius guard.
+// The value is the fingerprint from the BlastRadiusExceeded event; several may be comma-separated.
+const BlastRadiusAckAnnotation = "platform.kubevirt.io/blast-radius-ack"
+
+// Operations limited by the blast radius guard ("operation" label of blast_radius_held)
+const (
+	BlastRadiusReboot = "reboot"
+	BlastRadiusDelete = "delete"
+)
+
+// Default blast radius limits
+const (
+	// DefaultMaxRebootChanges is how many existing reboot-triggering objects a reconcile may modify
+	DefaultMaxRebootChanges = 3
+	// DefaultMaxDeletions is how many tombstoned resources a reconcile may delete
+	DefaultMaxDeletions = 10
+)
+
+// blastRadiusLimit caps how many changes of one kind a single reconcile may make.
+//
+// A catalog bug (a template change that touches every MachineConfig, a tombstone
+// list that matches live resources) would otherwise reboot every node or delete a
+// whole component in one pass. Batches over the limit are held back until someone
+// acknowledges that exact batch on the HCO.
+type blastRadiusLimit struct {
+	mu       sync.Mutex
+	max      int // 0 = unlimited
+	reported map[string]bool
+}
+
+func (l *blastRadiusLimit) setMax(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.max = n
+}
+
+func (l *blastRadiusLimit) enabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.max > 0
+}
+
+// exceeded reports whether count is over the limit, returning the limit
+func (l *blastRadiusLimit) exceeded(count int) (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.max > 0 && count > l.max, l.max
+}
+
+// firstReport reports whether the batch with this fingerprint has not been reported yet.
+// Only the latest batch is remembered, as it replaced the earlier ones.
+func (l *blastRadiusLimit) firstReport(fingerprint string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.reported[fingerprint] {
+		return false
+	}
+	l.reported = map[string]bool{fingerprint: true}
+	return true
+}
+
+// blastRadiusGuard holds the reboot-triggering changes of one ReconcileAssets pass.
+// Besides the number of changes, it can limit the number of nodes they are predicted
+// to reboot (see PredictRebootImpact).
+type blastRadiusGuard struct {
+	blastRadiusLimit
+	heldMu   sync.Mutex
+	held     []heldChange
+	maxNodes int           // 0 = unlimited; guarded by heldMu
+	batchAll bool          // Batch even without limits, for canary rollouts; guarded by heldMu
+	impact   *RebootImpact // Prediction for the last pass's batch; guarded by heldMu
+}
+
+// heldChange is an apply postponed until the end of the pass
+type heldChange struct {
+	assetMeta  assets.AssetMetadata
+	desired    *unstructured.Unstructured
+	live       *unstructured.Unstructured
+	liveExists bool
+}
+
+// rolloutRank orders reboot-triggering kinds so a pass rolls out to a pool once: the MCO
+// turns KubeletConfig and ContainerRuntimeConfig into generated MachineConfigs, so they go
+// first and the plain MachineConfigs of the same pass land in the same rendered config
+// rather than the pool starting on a config that carries only the MachineConfig half.
+func rolloutRank(obj *unstructured.Unstructured) int {
+	switch obj.GetKind() {
+	case "KubeletConfig":
+		return 0
+	case "ContainerRuntimeConfig":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// SetMaxRebootChanges limits how many existing MachineConfig, KubeletConfig and ContainerRuntimeConfig
+// objects a single reconcile may modify; creations are not counted. 0 disables the limit
+func (p *Patcher) SetMaxRebootChanges(n int) {
+	p.blastRadius.setMax(n)
+}
+
+// SetMaxRebootNodes limits how many nodes the reboot-triggering changes of a single
+// reconcile may be predicted to reboot; 0 disables the limit
+func (p *Patcher) SetMaxRebootNodes(n int) {
+	p.blastRadius.heldMu.Lock()
+	defer p.blastRadius.heldMu.Unlock()
+	p.blastRadius.maxNodes = n
+}
+
+// RebootImpact returns the predicted impact of the reboot-triggering changes of the
+// last pass, or nil when it had none
+func (p *Patcher) RebootImpact() *RebootImpact {
+	p.blastRadius.heldMu.Lock()
+	defer p.blastRadius.heldMu.Unlock()
+	return p.blastRadius.impact
+}
+
+// holding reports whether changes are batched: either limit is set or canary rollouts are on
+func (g *blastRadiusGuard) holding() bool {
+	g.heldMu.Lock()
+	batch := g.maxNodes > 0 || g.batchAll
+	g.heldMu.Unlock()
+	return batch || g.enabled()
+}
+
+// nodesExceeded reports whether nodes is over the node limit, returning the limit
+func (g *blastRadiusGuard) nodesExceeded(nodes int) (bool, int) {
+	g.heldMu.Lock()
+	defer g.heldMu.Unlock()
+	return g.maxNodes > 0 && nodes > g.maxNodes, g.maxNodes
+}
+
+func (g *blastRadiusGuard) setImpact(impact *RebootImpact) {
+	g.heldMu.Lock()
+	defer g.heldMu.Unlock()
+	g.impact = impact
+}
+
+// hold postpones a reboot-triggering change to the end of the pass and reports whether it did
+func (g *blastRadiusGuard) hold(assetMeta *assets.AssetMetadata, desired, live *unstructured.Unstructured, liveExists bool) bool {
+	if !triggersReboot(desired) || !g.holding() {
+		return false
+	}
+	g.heldMu.Lock()
+	defer g.heldMu.Unlock()
+	g.held = append(g.held, heldChange{assetMeta: *assetMeta, desired: desired, live: live, liveExists: liveExists})
+	return true
+}
+
+// releaseHeld returns the changes held during this pass that may be applied now:
+// all of them when within the limits or acknowledged, none otherwise.
+// Released changes are ordered by rolloutRank.
+func (p *Patcher) releaseHeld(ctx context.Context, renderCtx *pkgcontext.RenderContext) []heldChange {
+	p.blastRadius.heldMu.Lock()
+	held := p.blastRadius.held
+	p.blastRadius.held = nil
+	p.blastRadius.heldMu.Unlock()
+
+	if len(held) == 0 {
+		observability.SetBlastRadiusHeld(BlastRadiusReboot, 0)
+		observability.SetRebootImpactNodes(0)
+		p.blastRadius.setImpact(nil)
+		return nil
+	}
+	sort.SliceStable(held, func(i, j int) bool {
+		return rolloutRank(held[i].desired) < rolloutRank(held[j].desired)
+	})
+
+	entries := make([]string, len(held))
+	resources := make([]string, len(held))
+	changes := make([]*unstructured.Unstructured, len(held))
+	for i, h := range held {
+		data, _ := json.Marshal(h.desired.Object)
+		resources[i] = objectRef(h.desired)
+		entries[i] = resources[i] + " " + string(data)
+		changes[i] = h.desired
+	}
+	fingerprint := blastRadiusFingerprint(entries)
+
+	var pools []pkgcontext.MachineConfigPool
+	if renderCtx.Upgrade != nil {
+		pools = renderCtx.Upgrade.Pools
+	}
+	impact := PredictRebootImpact(changes, pools)
+	impact.Fingerprint = fingerprint
+	observability.SetRebootImpactNodes(impact.Nodes)
+
+	over, limit := p.blastRadius.exceeded(countUpdates(held))
+	nodesOver, nodeLimit := p.blastRadius.nodesExceeded(impact.Nodes)
+	if (!over && !nodesOver) || blastRadiusAcknowledged(renderCtx.HCO, fingerprint) {
+		observability.SetBlastRadiusHeld(BlastRadiusReboot, 0)
+		p.blastRadius.setImpact(impact)
+		// Keyed apart from the held report, so a batch released by an ack is announced too
+		if p.blastRadius.firstReport("impact " + fingerprint) {
+			log.FromContext(ctx).Info("Applying reboot-triggering changes",
+				"changes", len(held),
+				"nodes", impact.Nodes,
+				"pools", impact.Summary(),
+				"unmatched", impact.Unmatched,
+			)
+			if p.eventRecorder != nil && renderCtx.HCO != nil {
+				p.eventRecorder.RebootImpactPredicted(renderCtx.HCO, len(held), impact.Nodes, impact.Summary(), fingerprint)
+			}
+		}
+		return held
+	}
+
+	impact.Held = true
+	p.blastRadius.setImpact(impact)
+	observability.SetBlastRadiusHeld(BlastRadiusReboot, len(held))
+	if p.blastRadius.firstReport(fingerprint) {
+		change := fmt.Sprintf("modify %d node-rebooting resources", countUpdates(held))
+		if !over {
+			change = fmt.Sprintf("reboot %d nodes", impact.Nodes)
+			limit = nodeLimit
+		}
+		log.FromContext(ctx).Info("Blast radius exceeded, holding back reboot-triggering changes",
+			"changes", len(held),
+			"nodes", impact.Nodes,
+			"limit", limit,
+			"fingerprint", fingerprint,
+			"resources", resources,
+		)
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.BlastRadiusExceeded(renderCtx.HCO, change, limit,
+				strings.Join(resources, ", ")+"; rolls out to "+impact.Summary(), BlastRadiusAckAnnotation, fingerprint)
+		}
+	}
+	return nil
+}
+
+// countUpdates returns how many held changes modify an existing object. The first apply
+// of an asset creates its object and is not counted: the always-on reboot assets alone
+// (swap, PSI, kubelet settings) plus any opt-in one would otherwise exceed the default
+// limit on every fresh install.
+func countUpdates(held []heldChange) int {
+	n := 0
+	for _, h := range held {
+		if h.liveExists {
+			n++
+		}
+	}
+	return n
+}
+
+// SetMaxDeletions limits how many tombstoned resources a single reconcile may delete; 0 disables the limit
+func (r *TombstoneReconciler) SetMaxDeletions(n int) {
+	r.blastRadius.setMax(n)
+}
+
+// checkBlastRadius returns an error when candidates exceed the deletion limit and
+// the batch has not been acknowledged on the HCO
+func (r *TombstoneReconciler) checkBlastRadius(ctx context.Context, hco *unstructured.Unstructured, candidates []tombstoneCandidate) error {
+	over, limit := r.blastRadius.exceeded(len(candidates))
+	if !over {
+		observability.SetBlastRadiusHeld(BlastRadiusDelete, 0)
+		return nil
+	}
+
+	entries := make([]string, len(candidates))
+	resources := make([]string, len(candidates))
+	for i, c := range candidates {
+		resources[i] = objectRef(c.live)
+		entries[i] = resources[i] + " " + string(c.live.GetUID())
+	}
+	fingerprint := blastRadiusFingerprint(entries)
+
+	if blastRadiusAcknowledged(hco, fingerprint) {
+		observability.SetBlastRadiusHeld(BlastRadiusDelete, 0)
+		return nil
+	}
+
+	observability.SetBlastRadiusHeld(BlastRadiusDelete, len(candidates))
+	if r.blastRadius.firstReport(fingerprint) && r.eventRecorder != nil {
+		r.eventRecorder.BlastRadiusExceeded(hco, fmt.Sprintf("delete %d tombstoned resources", len(candidates)), limit,
+			strings.Join(resources, ", "), BlastRadiusAckAnnotation, fingerprint)
+	}
+	return fmt.Errorf("blast radius exceeded: %d tombstoned resources to delete (limit %d), "+
+		"set %s=%s on the HyperConverged to proceed", len(candidates), limit, BlastRadiusAckAnnotation, fingerprint)
+}
+
+// objectRef formats obj as kind/namespace/name, or kind/name when cluster-scoped
+func objectRef(obj *unstructured.Unstructured) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return obj.GetKind() + "/" + ns + "/" + obj.GetName()
+	}
+	return obj.GetKind() + "/" + obj.GetName()
+}
+
+// blastRadiusFingerprint identifies a batch of changes independent of their order.
+// A different batch (other objects or other content) gets a new fingerprint, so an
+// acknowledgement never approves more than what was reported.
+func blastRadiusFingerprint(entries []string) string {
+	sorted := append([]string(nil), entries...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// blastRadiusAcknowledged reports whether the HCO acknowledges the batch with fingerprint
+func blastRadiusAcknowledged(hco *unstructured.Unstructured, fingerprint string) bool {
+	if hco == nil {
+		return false
+	}
+	for _, ack := range strings.Split(hco.GetAnnotations()[BlastRadiusAckAnnotation], ",") {
+		if strings.TrimSpace(ack) == fingerprint {
+			return true
+		}
+	}
+	return false
+}
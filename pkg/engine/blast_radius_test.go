@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func newTestMachineConfig(name string) *unstructured.Unstructured {
+	mc := &unstructured.Unstructured{}
+	mc.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	mc.SetKind("MachineConfig")
+	mc.SetName(name)
+	return mc
+}
+
+// TestBlastRadiusHoldsBatchOverLimit verifies that a pass with more reboot-triggering
+// changes than allowed applies none of them, reports the batch once, and releases it
+// when the HCO carries the fingerprint from the event.
+func TestBlastRadiusHoldsBatchOverLimit(t *testing.T) {
+	observability.BlastRadiusHeld.Reset()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	rec := eventtest.NewRecorder()
+
+	p := &Patcher{}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetMaxRebootChanges(2)
+
+	holdAll := func() []string {
+		var entries []string
+		for i := 0; i < 3; i++ {
+			mc := newTestMachineConfig(fmt.Sprintf("50-mc-%d", i))
+			if !p.blastRadius.hold(&pkgassets.AssetMetadata{Name: mc.GetName()}, mc, mc, true) {
+				t.Fatalf("hold(%s) = false, want true", mc.GetName())
+			}
+			entries = append(entries, objectRef(mc)+" "+`{"apiVersion":"machineconfiguration.openshift.io/v1","kind":"MachineConfig","metadata":{"name":"`+mc.GetName()+`"}}`)
+		}
+		return entries
+	}
+
+	for i := 0; i < 2; i++ {
+		holdAll()
+		if released := p.releaseHeld(context.Background(), renderCtx); released != nil {
+			t.Fatalf("pass %d: released %d changes, want none", i+1, len(released))
+		}
+	}
+	if got := rec.Count(util.EventReasonBlastRadiusExceeded); got != 1 {
+		t.Errorf("BlastRadiusExceeded event count = %d, want 1", got)
+	}
+	if val := testutil.ToFloat64(observability.BlastRadiusHeld.WithLabelValues(BlastRadiusReboot)); val != 3 {
+		t.Errorf("blast_radius_held{operation=reboot} = %v, want 3", val)
+	}
+
+	fingerprint := blastRadiusFingerprint(holdAll())
+	hco.SetAnnotations(map[string]string{BlastRadiusAckAnnotation: "other, " + fingerprint})
+	if released := p.releaseHeld(context.Background(), renderCtx); len(released) != 3 {
+		t.Fatalf("released %d acknowledged changes, want 3", len(released))
+	}
+	if val := testutil.ToFloat64(observability.BlastRadiusHeld.WithLabelValues(BlastRadiusReboot)); val != 0 {
+		t.Errorf("blast_radius_held{operation=reboot} = %v after ack, want 0", val)
+	}
+}
+
+// TestBlastRadiusAppliesWithinLimit verifies that a held MachineConfig is applied at
+// the end of ReconcileAssets when the batch is within the limit.
+func TestBlastRadiusAppliesWithinLimit(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	fakeClient := fake.NewClientBuilder().Build()
+	p := &Patcher{
+		renderer:          renderer,
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     &switchableDriftChecker{drift: true},
+		throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetMaxRebootChanges(1)
+
+	// The single-asset path only holds; the apply happens when the pass completes
+	applied, err := p.ReconcileAsset(context.Background(), &assetMeta, renderCtx)
+	if err != nil || applied {
+		t.Fatalf("ReconcileAsset() = %v, %v; want held (false, nil)", applied, err)
+	}
+	released := p.releaseHeld(context.Background(), renderCtx)
+	if len(released) != 1 || released[0].desired.GetName() != "99-openshift-machineconfig-worker-psi-karg" {
+		t.Fatalf("releaseHeld() returned %d changes, want the psi-enable MachineConfig", len(released))
+	}
+
+	count, err := p.ReconcileAssets(context.Background(), []pkgassets.AssetMetadata{assetMeta}, renderCtx)
+	if err != nil {
+		t.Fatalf("ReconcileAssets() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("ReconcileAssets() applied %d, want 1", count)
+	}
+}
+
+// TestBlastRadiusDefaultLimitFreshInstall verifies that the first apply of every
+// reboot-triggering asset, including the opt-in KSM and memory overcommit ones, is not
+// held by the default limit: creations do not count, only updates of existing objects.
+func TestBlastRadiusDefaultLimitFreshInstall(t *testing.T) {
+	observability.BlastRadiusHeld.Reset()
+
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+	registry, err := pkgassets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	var rebootAssets []pkgassets.AssetMetadata
+	for _, name := range []string{"swap-enable", "psi-enable", "kubelet-perf-settings", "kubelet-memory-overcommit", "ksm-tuning"} {
+		assetMeta, err := registry.GetAsset(name)
+		if err != nil {
+			t.Fatalf("GetAsset(%s) error = %v", name, err)
+		}
+		rebootAssets = append(rebootAssets, *assetMeta)
+	}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	_ = unstructured.SetNestedMap(hco.Object, map[string]interface{}{}, "spec", "ksmConfiguration", "nodeLabelSelector")
+	_ = unstructured.SetNestedField(hco.Object, int64(150), "spec", "higherWorkloadDensity", "memoryOvercommitPercentage")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	fakeClient := fake.NewClientBuilder().Build()
+	p := &Patcher{
+		renderer:          renderer,
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     &switchableDriftChecker{drift: true},
+		throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetMaxRebootChanges(DefaultMaxRebootChanges)
+
+	count, err := p.ReconcileAssets(context.Background(), rebootAssets, renderCtx)
+	if err != nil {
+		t.Fatalf("ReconcileAssets() error = %v", err)
+	}
+	if count != len(rebootAssets) {
+		t.Errorf("ReconcileAssets() applied %d, want %d", count, len(rebootAssets))
+	}
+	if val := testutil.ToFloat64(observability.BlastRadiusHeld.WithLabelValues(BlastRadiusReboot)); val != 0 {
+		t.Errorf("blast_radius_held{operation=reboot} = %v, want 0", val)
+	}
+}
+
+// TestBlastRadiusReleasesKubeletConfigFirst verifies that held changes are released
+// KubeletConfig, then ContainerRuntimeConfig, then MachineConfig, keeping hold order per kind.
+func TestBlastRadiusReleasesKubeletConfigFirst(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	p := &Patcher{}
+	p.SetMaxRebootChanges(10)
+
+	held := []*unstructured.Unstructured{
+		newTestMachineConfig("90-worker-ksm-tuning"),
+		newTestMachineConfig("99-psi"),
+		newTestMachineConfig("virt-container-runtime"),
+		newTestMachineConfig("virt-memory-overcommit"),
+	}
+	held[2].SetKind("ContainerRuntimeConfig")
+	held[3].SetKind("KubeletConfig")
+	for _, obj := range held {
+		p.blastRadius.hold(&pkgassets.AssetMetadata{Name: obj.GetName()}, obj, nil, false)
+	}
+
+	var order []string
+	for _, h := range p.releaseHeld(context.Background(), renderCtx) {
+		order = append(order, h.desired.GetName())
+	}
+	want := []string{"virt-memory-overcommit", "virt-container-runtime", "90-worker-ksm-tuning", "99-psi"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("release order = %v, want %v", order, want)
+	}
+}
+
+// TestTombstoneBlastRadius verifies that deletions over the limit are refused as a
+// batch until acknowledged, and that the fingerprint follows the live object's UID.
+func TestTombstoneBlastRadius(t *testing.T) {
+	observability.BlastRadiusHeld.Reset()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	rec := eventtest.NewRecorder()
+
+	r := NewTombstoneReconciler(fake.NewClientBuilder().Build(), nil)
+	r.SetEventRecorder(util.NewEventRecorder(rec))
+	r.SetMaxDeletions(1)
+
+	var candidates []tombstoneCandidate
+	var entries []string
+	for i := 0; i < 2; i++ {
+		live := newTestMachineConfig(fmt.Sprintf("old-%d", i))
+		live.SetUID(types.UID(fmt.Sprintf("uid-%d", i)))
+		candidates = append(candidates, tombstoneCandidate{live: live})
+		entries = append(entries, objectRef(live)+" "+string(live.GetUID()))
+	}
+
+	err := r.checkBlastRadius(context.Background(), hco, candidates)
+	if err == nil || !strings.Contains(err.Error(), BlastRadiusAckAnnotation) {
+		t.Fatalf("checkBlastRadius() error = %v, want one naming %s", err, BlastRadiusAckAnnotation)
+	}
+	if got := rec.Count(util.EventReasonBlastRadiusExceeded); got != 1 {
+		t.Errorf("BlastRadiusExceeded event count = %d, want 1", got)
+	}
+	if val := testutil.ToFloat64(observability.BlastRadiusHeld.WithLabelValues(BlastRadiusDelete)); val != 2 {
+		t.Errorf("blast_radius_held{operation=delete} = %v, want 2", val)
+	}
+
+	hco.SetAnnotations(map[string]string{BlastRadiusAckAnnotation: blastRadiusFingerprint(entries)})
+	if err := r.checkBlastRadius(context.Background(), hco, candidates); err != nil {
+		t.Errorf("checkBlastRadius() after ack error = %v, want nil", err)
+	}
+
+	// Recreated objects have new UIDs: the old acknowledgement no longer applies
+	candidates[0].live.SetUID("uid-recreated")
+	if err := r.checkBlastRadius(context.Background(), hco, candidates); err == nil {
+		t.Error("checkBlastRadius() accepted a batch that differs from the acknowledged one")
+	}
+}
+
+func TestBlastRadiusFingerprint(t *testing.T) {
+	a := blastRadiusFingerprint([]string{"MachineConfig/a {}", "MachineConfig/b {}"})
+	b := blastRadiusFingerprint([]string{"MachineConfig/b {}", "MachineConfig/a {}"})
+	if a != b {
+		t.Errorf("fingerprint depends on order: %s != %s", a, b)
+	}
+	if len(a) != 12 {
+		t.Errorf("fingerprint %q has length %d, want 12", a, len(a))
+	}
+	if c := blastRadiusFingerprint([]string{"MachineConfig/a {}", "MachineConfig/b {\"x\":1}"}); c == a {
+		t.Error("fingerprint did not change with the content of the batch")
+	}
+}
+
+func TestBlastRadiusFirstReport(t *testing.T) {
+	l := &blastRadiusLimit{}
+	for i, tc := range []struct {
+		fingerprint string
+		want        bool
+	}{
+		{"aaa", true},
+		{"aaa", false},
+		{"bbb", true},
+		{"bbb", false},
+		// A batch that came back after another one is reported again
+		{"aaa", true},
+	} {
+		if got := l.firstReport(tc.fingerprint); got != tc.want {
+			t.Errorf("report %d: firstReport(%s) = %v, want %v", i+1, tc.fingerprint, got, tc.want)
+		}
+	}
+	if len(l.reported) != 1 {
+		t.Errorf("reported = %v, want only the latest batch", l.reported)
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// CanaryPausedAnnotation marks a MachineConfigPool paused by a canary rollout; the value
+// is the fingerprint of the batch. It lets a restarted operator resume the pools of an
+// interrupted rollout, and keeps pools paused by someone else out of the rollout.
+const CanaryPausedAnnotation = "platform.kubevirt.io/canary-paused"
+
+var machineConfigPoolGVK = schema.GroupVersionKind{Group: machineConfigGroup, Version: "v1", Kind: "MachineConfigPool"}
+
+// DefaultCanaryTimeout is how long the canary pool may take to update unless configured
+const DefaultCanaryTimeout = time.Hour
+
+// canaryNoopGrace is how long a canary pool that stays Updated on the same rendered
+// config is waited for before the batch is taken to change nothing on the nodes (e.g.
+// a label-only change), rather than to be not yet picked up by the Machine Config Operator
+const canaryNoopGrace = 5 * time.Minute
+
+// Canary rollout phases (canary_rollout_phase metric)
+const (
+	CanaryIdle        = 0
+	CanaryUpdating    = 1
+	CanaryRollingBack = 2
+)
+
+// Canary rollout results (canary_rollouts_total "result" label)
+const (
+	CanarySucceeded  = "succeeded"
+	CanaryRolledBack = "rolled_back"
+)
+
+// CanaryRollout configures rolling node-rebooting changes out to one MachineConfigPool first
+type CanaryRollout struct {
+	// Pool is the canary MachineConfigPool; empty disables canary rollouts
+	Pool string
+	// Timeout bounds how long the canary pool may take to update before the batch is rolled back
+	Timeout time.Duration
+}
+
+// Validate rejects a canary pool without a positive timeout
+func (c CanaryRollout) Validate() error {
+	if c.Pool != "" && c.Timeout <= 0 {
+		return fmt.Errorf("canary timeout must be positive, got %s", c.Timeout)
+	}
+	return nil
+}
+
+// canaryGuard rolls the reboot-triggering batch of a pass out to the canary pool first.
+//
+// The other pools the batch targets are paused before it is applied, the way a canary
+// rollout is done by hand on OpenShift: the Machine Config Operator still renders their
+// new config but does not roll it out. Once the canary pool is updated they are resumed.
+// If the canary pool degrades or does not update in time, the objects are re-applied as
+// they were before the batch (created ones are deleted) and the pools are only resumed
+// once the canary pool is back on its previous config, so they never see the bad one.
+type canaryGuard struct {
+	mu     sync.Mutex
+	config CanaryRollout
+	active *canaryState
+	failed map[string]bool // Fingerprints of rolled back batches, not retried until the batch changes
+}
+
+// canaryState is the rollout in progress. Only ReconcileAssets reads and updates it,
+// one pass at a time.
+type canaryState struct {
+	fingerprint   string
+	started       time.Time
+	configuration string   // Rendered config of the canary pool when the batch was applied
+	paused        []string // Pools paused for the rollout
+	previous      []*unstructured.Unstructured
+	created       []*unstructured.Unstructured
+	rollbackAt    time.Time // Zero until the rollout failed
+}
+
+// SetCanaryRollout enables canary rollouts of reboot-triggering changes. The changes of a
+// pass are then always batched, as with the blast radius limits.
+func (p *Patcher) SetCanaryRollout(canary CanaryRollout) {
+	p.canary.mu.Lock()
+	p.canary.config = canary
+	p.canary.mu.Unlock()
+
+	p.blastRadius.heldMu.Lock()
+	p.blastRadius.batchAll = canary.Pool != ""
+	p.blastRadius.heldMu.Unlock()
+}
+
+// CanaryInProgress reports whether a canary rollout or its rollback is underway, so the
+// controller can poll the canary pool
+func (p *Patcher) CanaryInProgress() bool {
+	p.canary.mu.Lock()
+	defer p.canary.mu.Unlock()
+	return p.canary.active != nil
+}
+
+func (g *canaryGuard) snapshot() (CanaryRollout, *canaryState) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.config, g.active
+}
+
+func (g *canaryGuard) setActive(state *canaryState) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active = state
+}
+
+func (g *canaryGuard) hasFailed(fingerprint string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.failed[fingerprint]
+}
+
+// markFailed remembers a rolled back batch; earlier ones are forgotten, as a newer batch replaced them
+func (g *canaryGuard) markFailed(fingerprint string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failed = map[string]bool{fingerprint: true}
+}
+
+// canaryRelease returns the released changes of this pass that may be applied now. With
+// canary rollouts disabled that is all of them; otherwise it advances the rollout in
+// progress, holds new changes back until it ends, and starts a rollout for a new batch.
+func (p *Patcher) canaryRelease(ctx context.Context, renderCtx *pkgcontext.RenderContext, released []heldChange) []heldChange {
+	config, active := p.canary.snapshot()
+	if config.Pool == "" {
+		return released
+	}
+	logger := log.FromContext(ctx).WithValues("canaryPool", config.Pool)
+
+	var pools []pkgcontext.MachineConfigPool
+	if renderCtx.Upgrade != nil {
+		pools = renderCtx.Upgrade.Pools
+	}
+	now := time.Now()
+
+	if active != nil && !p.progressCanary(ctx, renderCtx, config, active, pools, now) {
+		p.reportHeld(renderCtx, released, fmt.Sprintf("canary rollout to pool %s in progress", config.Pool))
+		return nil
+	}
+	if active == nil && len(released) == 0 {
+		p.resumeInterruptedCanary(ctx, pools)
+	}
+	if len(released) == 0 {
+		return nil
+	}
+
+	impact := p.RebootImpact()
+	if impact == nil {
+		return released
+	}
+	if p.canary.hasFailed(impact.Fingerprint) {
+		p.reportHeld(renderCtx, released, fmt.Sprintf("rolled back after a failed canary rollout to pool %s", config.Pool))
+		return nil
+	}
+
+	canary, found := findPool(pools, config.Pool)
+	targets := make([]string, 0, len(impact.Pools))
+	for _, pool := range impact.Pools {
+		targets = append(targets, pool.Pool)
+	}
+	switch {
+	case !found:
+		logger.Info("Canary pool not found, applying reboot-triggering changes without a canary rollout")
+		return released
+	case !slices.Contains(targets, config.Pool) || len(targets) == 1:
+		logger.V(1).Info("Reboot-triggering changes do not roll out beyond the canary pool, applying them directly",
+			"pools", targets)
+		return released
+	}
+
+	state := &canaryState{
+		fingerprint:   impact.Fingerprint,
+		started:       now,
+		configuration: canary.Configuration,
+	}
+	for _, name := range targets {
+		pool, _ := findPool(pools, name)
+		if name == config.Pool || pool.Paused {
+			// A pool paused by someone else is left to them
+			continue
+		}
+		if err := p.setPoolPaused(ctx, name, true, impact.Fingerprint); err != nil {
+			logger.Error(err, "Failed to pause MachineConfigPool, holding back the canary rollout", "pool", name)
+			p.resumePools(ctx, state.paused)
+			p.reportHeld(renderCtx, released, fmt.Sprintf("failed to pause pool %s for the canary rollout", name))
+			return nil
+		}
+		state.paused = append(state.paused, name)
+	}
+	for _, h := range released {
+		if h.liveExists {
+			state.previous = append(state.previous, &unstructured.Unstructured{Object: sanitizeObject(h.live)})
+		} else {
+			state.created = append(state.created, h.desired.DeepCopy())
+		}
+	}
+	p.canary.setActive(state)
+	observability.SetCanaryRolloutPhase(config.Pool, CanaryUpdating)
+
+	logger.Info("Starting canary rollout of reboot-triggering changes",
+		"changes", len(released),
+		"paused", state.paused,
+		"fingerprint", impact.Fingerprint,
+	)
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.CanaryRolloutStarted(renderCtx.HCO, config.Pool, len(released), poolList(state.paused), impact.Fingerprint)
+	}
+	return released
+}
+
+// progressCanary checks the canary pool and finishes, fails or completes the rollback of
+// the rollout in progress. It reports whether the rollout has ended.
+func (p *Patcher) progressCanary(ctx context.Context, renderCtx *pkgcontext.RenderContext, config CanaryRollout,
+	state *canaryState, pools []pkgcontext.MachineConfigPool, now time.Time) bool {
+	logger := log.FromContext(ctx).WithValues("canaryPool", config.Pool, "fingerprint", state.fingerprint)
+	canary, found := findPool(pools, config.Pool)
+
+	if !state.rollbackAt.IsZero() {
+		// The paused pools must not be resumed while the bad config is still rendered for them
+		if !found || !canary.Updated || canary.Degraded || canary.Configuration != state.configuration {
+			return false
+		}
+		p.resumePools(ctx, state.paused)
+		p.canary.setActive(nil)
+		observability.SetCanaryRolloutPhase(config.Pool, CanaryIdle)
+		logger.Info("Canary pool back on its previous config, resumed paused pools", "resumed", state.paused)
+		return true
+	}
+
+	var reason string
+	switch {
+	case !found:
+		reason = "the canary pool no longer exists"
+	case canary.Degraded:
+		reason = "the canary pool is degraded"
+	case canary.Updated && (canary.Configuration != state.configuration || now.Sub(state.started) >= canaryNoopGrace):
+		p.resumePools(ctx, state.paused)
+		p.canary.setActive(nil)
+		observability.SetCanaryRolloutPhase(config.Pool, CanaryIdle)
+		observability.IncCanaryRollout(CanarySucceeded)
+		logger.Info("Canary pool updated, resumed paused pools", "resumed", state.paused)
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.CanaryRolloutSucceeded(renderCtx.HCO, config.Pool, poolList(state.paused), state.fingerprint)
+		}
+		return true
+	case now.Sub(state.started) >= config.Timeout:
+		reason = fmt.Sprintf("the canary pool did not finish updating within %s", config.Timeout)
+	default:
+		return false
+	}
+
+	p.rollbackCanary(ctx, renderCtx, config, state, reason, now)
+	return false
+}
+
+// rollbackCanary re-applies the objects of a failed rollout as they were before it and
+// deletes the ones it created. The paused pools stay paused until the canary pool is back.
+func (p *Patcher) rollbackCanary(ctx context.Context, renderCtx *pkgcontext.RenderContext, config CanaryRollout,
+	state *canaryState, reason string, now time.Time) {
+	logger := log.FromContext(ctx).WithValues("canaryPool", config.Pool, "fingerprint", state.fingerprint)
+	logger.Info("Canary rollout failed, rolling back reboot-triggering changes", "reason", reason)
+
+	for _, obj := range state.previous {
+		if _, err := p.applier.Apply(ctx, obj, true); err != nil {
+			logger.Error(err, "Failed to roll back object", "kind", obj.GetKind(), "name", obj.GetName())
+		}
+	}
+	for _, obj := range state.created {
+		cancel := p.ExpectDeletion(obj)
+		if err := p.applier.Delete(ctx, obj); err != nil {
+			cancel()
+			logger.Error(err, "Failed to delete object created by the canary rollout", "kind", obj.GetKind(), "name", obj.GetName())
+		}
+	}
+
+	state.rollbackAt = now
+	p.canary.markFailed(state.fingerprint)
+	observability.SetCanaryRolloutPhase(config.Pool, CanaryRollingBack)
+	observability.IncCanaryRollout(CanaryRolledBack)
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.CanaryRolloutFailed(renderCtx.HCO, config.Pool, reason,
+			len(state.previous)+len(state.created), poolList(state.paused), state.fingerprint)
+	}
+}
+
+// resumeInterruptedCanary resumes pools left paused by a rollout this process does not
+// know about, i.e. one interrupted by an operator restart
+func (p *Patcher) resumeInterruptedCanary(ctx context.Context, pools []pkgcontext.MachineConfigPool) {
+	var interrupted []string
+	for _, pool := range pools {
+		if !pool.Paused {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(machineConfigPoolGVK)
+		if err := p.applier.GetDirect(ctx, client.ObjectKey{Name: pool.Name}, obj); err != nil {
+			continue
+		}
+		if _, ok := obj.GetAnnotations()[CanaryPausedAnnotation]; ok {
+			interrupted = append(interrupted, pool.Name)
+		}
+	}
+	if len(interrupted) > 0 {
+		log.FromContext(ctx).Info("Resuming MachineConfigPools paused by an interrupted canary rollout", "pools", interrupted)
+		p.resumePools(ctx, interrupted)
+	}
+}
+
+// resumePools unpauses pools paused by a canary rollout; failures are logged and retried
+// on the next pass through resumeInterruptedCanary
+func (p *Patcher) resumePools(ctx context.Context, names []string) {
+	for _, name := range names {
+		if err := p.setPoolPaused(ctx, name, false, ""); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to resume MachineConfigPool", "pool", name)
+		}
+	}
+}
+
+// setPoolPaused pauses or resumes a MachineConfigPool with a merge patch, setting or
+// removing CanaryPausedAnnotation along with spec.paused
+func (p *Patcher) setPoolPaused(ctx context.Context, name string, paused bool, fingerprint string) error {
+	var annotation any
+	if paused {
+		annotation = fingerprint
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{CanaryPausedAnnotation: annotation}},
+		"spec":     map[string]any{"paused": paused},
+	})
+	if err != nil {
+		return err
+	}
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(machineConfigPoolGVK)
+	pool.SetName(name)
+	return p.client.Patch(ctx, pool, client.RawPatch(types.MergePatchType, patch))
+}
+
+// reportHeld marks changes held back by the canary rollout as pending in the inventory
+func (p *Patcher) reportHeld(renderCtx *pkgcontext.RenderContext, held []heldChange, message string) {
+	for i := range held {
+		p.reportObject(renderCtx, &held[i].assetMeta, held[i].desired, ObjectPending, ReasonCanaryRollout, message)
+	}
+}
+
+func findPool(pools []pkgcontext.MachineConfigPool, name string) (pkgcontext.MachineConfigPool, bool) {
+	for _, pool := range pools {
+		if pool.Name == name {
+			return pool, true
+		}
+	}
+	return pkgcontext.MachineConfigPool{}, false
+}
+
+// poolList names pools in events, e.g. "pools worker, infra"
+func poolList(pools []string) string {
+	if len(pools) == 0 {
+		return "no other pool"
+	}
+	return "pools " + strings.Join(pools, ", ")
+}
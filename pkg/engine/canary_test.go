@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func newTestPoolObject(name string) *unstructured.Unstructured {
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(machineConfigPoolGVK)
+	pool.SetName(name)
+	return pool
+}
+
+// canaryTestSetup returns a patcher rolling out to the infra pool of testPools first, and
+// a render context whose pools are all updated on rendered-<pool>-1
+func canaryTestSetup(t *testing.T) (*Patcher, client.Client, *pkgcontext.RenderContext, *eventtest.Recorder) {
+	t.Helper()
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		newTestPoolObject("infra"), newTestPoolObject("master"), newTestPoolObject("worker"),
+	).Build()
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	pools := testPools()
+	for i := range pools {
+		pools[i].Updated = true
+		pools[i].Configuration = "rendered-" + pools[i].Name + "-1"
+	}
+	renderCtx.Upgrade = &pkgcontext.UpgradeContext{Pools: pools}
+
+	rec := eventtest.NewRecorder()
+	p := &Patcher{applier: NewApplier(fakeClient, nil), client: fakeClient}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetCanaryRollout(CanaryRollout{Pool: "infra", Timeout: time.Hour})
+	return p, fakeClient, renderCtx, rec
+}
+
+// runCanaryPass holds changes like a pass of ReconcileAssets and returns what it applies
+func runCanaryPass(t *testing.T, p *Patcher, renderCtx *pkgcontext.RenderContext, changes ...heldChange) []heldChange {
+	t.Helper()
+	for _, h := range changes {
+		if !p.blastRadius.hold(&h.assetMeta, h.desired, h.live, h.liveExists) {
+			t.Fatalf("hold(%s) = false, want changes batched with a canary pool", h.desired.GetName())
+		}
+	}
+	return p.canaryRelease(context.Background(), renderCtx, p.releaseHeld(context.Background(), renderCtx))
+}
+
+func poolPaused(t *testing.T, c client.Client, name string) bool {
+	t.Helper()
+	pool := newTestPoolObject(name)
+	if err := c.Get(context.Background(), client.ObjectKey{Name: name}, pool); err != nil {
+		t.Fatal(err)
+	}
+	paused, _, _ := unstructured.NestedBool(pool.Object, "spec", "paused")
+	_, annotated := pool.GetAnnotations()[CanaryPausedAnnotation]
+	if paused != annotated {
+		t.Errorf("pool %s paused = %v but annotated = %v", name, paused, annotated)
+	}
+	return paused
+}
+
+func setCanaryPool(renderCtx *pkgcontext.RenderContext, updated, degraded bool, configuration string) {
+	for i := range renderCtx.Upgrade.Pools {
+		if pool := &renderCtx.Upgrade.Pools[i]; pool.Name == "infra" {
+			pool.Updated, pool.Degraded, pool.Configuration = updated, degraded, configuration
+		}
+	}
+}
+
+// TestCanaryRolloutSucceeds verifies that a batch targeting the canary and another pool
+// is applied with the other pool paused, later changes wait, and the pool is resumed
+// once the canary pool is updated on a new config
+func TestCanaryRolloutSucceeds(t *testing.T) {
+	p, c, renderCtx, rec := canaryTestSetup(t)
+	change := heldChange{assetMeta: pkgassets.AssetMetadata{Name: "swap"}, desired: newTestRoleMachineConfig("50-swap", "worker")}
+
+	if released := runCanaryPass(t, p, renderCtx, change); len(released) != 1 {
+		t.Fatalf("released %d changes, want the batch applied to the canary pool", len(released))
+	}
+	if !poolPaused(t, c, "worker") || poolPaused(t, c, "infra") || poolPaused(t, c, "master") {
+		t.Error("want only the worker pool paused")
+	}
+	rec.ExpectEvent(t, util.EventReasonCanaryStarted, "", "")
+	if !p.CanaryInProgress() {
+		t.Fatal("CanaryInProgress() = false after the rollout started")
+	}
+
+	// The canary pool is updating: a new change waits
+	setCanaryPool(renderCtx, false, false, "rendered-infra-1")
+	next := heldChange{assetMeta: pkgassets.AssetMetadata{Name: "kubelet"}, desired: newTestKubeletConfig("kubelet", "worker")}
+	if released := runCanaryPass(t, p, renderCtx, next); released != nil {
+		t.Fatalf("released %d changes during the canary rollout, want none", len(released))
+	}
+
+	setCanaryPool(renderCtx, true, false, "rendered-infra-2")
+	if released := runCanaryPass(t, p, renderCtx); released != nil {
+		t.Fatalf("released %d changes without held changes", len(released))
+	}
+	if poolPaused(t, c, "worker") {
+		t.Error("worker pool still paused after the canary pool updated")
+	}
+	if p.CanaryInProgress() {
+		t.Error("CanaryInProgress() = true after the canary pool updated")
+	}
+	rec.ExpectEvent(t, util.EventReasonCanarySucceeded, "", "")
+}
+
+// TestCanaryRolloutRollsBack verifies that a degraded canary pool rolls the batch back,
+// keeps the other pools paused until the canary pool is back on its previous config, and
+// does not retry the same batch
+func TestCanaryRolloutRollsBack(t *testing.T) {
+	p, c, renderCtx, rec := canaryTestSetup(t)
+	ctx := context.Background()
+
+	previous := newTestRoleMachineConfig("50-swap", "worker")
+	previous.Object["spec"] = map[string]any{"kernelArguments": []any{"swap=off"}}
+	if _, err := p.applier.Apply(ctx, previous, true); err != nil {
+		t.Fatal(err)
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(previous.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKey{Name: "50-swap"}, live); err != nil {
+		t.Fatal(err)
+	}
+
+	desired := newTestRoleMachineConfig("50-swap", "worker")
+	desired.Object["spec"] = map[string]any{"kernelArguments": []any{"swap=on"}}
+	changes := []heldChange{
+		{assetMeta: pkgassets.AssetMetadata{Name: "swap"}, desired: desired, live: live, liveExists: true},
+		{assetMeta: pkgassets.AssetMetadata{Name: "new"}, desired: newTestRoleMachineConfig("50-new", "worker")},
+	}
+	for _, h := range runCanaryPass(t, p, renderCtx, changes...) {
+		if _, err := p.applier.Apply(ctx, h.desired, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setCanaryPool(renderCtx, false, true, "rendered-infra-1")
+	runCanaryPass(t, p, renderCtx)
+	rec.ExpectEvent(t, util.EventReasonCanaryFailed, "", "")
+
+	rolledBack := &unstructured.Unstructured{}
+	rolledBack.SetGroupVersionKind(previous.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKey{Name: "50-swap"}, rolledBack); err != nil {
+		t.Fatal(err)
+	}
+	if args, _, _ := unstructured.NestedStringSlice(rolledBack.Object, "spec", "kernelArguments"); len(args) != 1 || args[0] != "swap=off" {
+		t.Errorf("kernelArguments after rollback = %v, want [swap=off]", args)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "50-new"}, &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "machineconfiguration.openshift.io/v1", "kind": "MachineConfig",
+	}}); err == nil {
+		t.Error("MachineConfig created by the failed rollout was not deleted")
+	}
+
+	// Still rendering the bad config: the worker pool stays paused
+	setCanaryPool(renderCtx, false, false, "rendered-infra-2")
+	runCanaryPass(t, p, renderCtx)
+	if !poolPaused(t, c, "worker") || !p.CanaryInProgress() {
+		t.Fatal("worker pool resumed before the canary pool was back on its previous config")
+	}
+
+	setCanaryPool(renderCtx, true, false, "rendered-infra-1")
+	if released := runCanaryPass(t, p, renderCtx, changes...); released != nil {
+		t.Fatalf("released %d changes of the rolled back batch, want none", len(released))
+	}
+	if poolPaused(t, c, "worker") || p.CanaryInProgress() {
+		t.Error("worker pool not resumed once the canary pool was back on its previous config")
+	}
+}
+
+// TestCanaryRolloutResumesInterrupted verifies that pools paused by a rollout the operator
+// no longer knows about are resumed, and pools paused by someone else are left alone
+func TestCanaryRolloutResumesInterrupted(t *testing.T) {
+	p, c, renderCtx, _ := canaryTestSetup(t)
+	ctx := context.Background()
+
+	if err := p.setPoolPaused(ctx, "worker", true, "abc"); err != nil {
+		t.Fatal(err)
+	}
+	master := newTestPoolObject("master")
+	if err := c.Get(ctx, client.ObjectKey{Name: "master"}, master); err != nil {
+		t.Fatal(err)
+	}
+	_ = unstructured.SetNestedField(master.Object, true, "spec", "paused")
+	if err := c.Update(ctx, master); err != nil {
+		t.Fatal(err)
+	}
+	for i := range renderCtx.Upgrade.Pools {
+		renderCtx.Upgrade.Pools[i].Paused = renderCtx.Upgrade.Pools[i].Name != "infra"
+	}
+
+	runCanaryPass(t, p, renderCtx)
+	if poolPaused(t, c, "worker") {
+		t.Error("worker pool paused by an interrupted rollout was not resumed")
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "master"}, master); err != nil {
+		t.Fatal(err)
+	}
+	if paused, _, _ := unstructured.NestedBool(master.Object, "spec", "paused"); !paused {
+		t.Error("master pool paused by someone else was resumed")
+	}
+}
+
+// TestCanaryRolloutSkipsSinglePool verifies that a batch rolling out to the canary pool
+// only is applied without pausing anything
+func TestCanaryRolloutSkipsSinglePool(t *testing.T) {
+	p, c, renderCtx, _ := canaryTestSetup(t)
+	change := heldChange{assetMeta: pkgassets.AssetMetadata{Name: "kubelet"}, desired: newTestKubeletConfig("kubelet", "infra")}
+
+	if released := runCanaryPass(t, p, renderCtx, change); len(released) != 1 {
+		t.Fatalf("released %d changes, want the batch applied directly", len(released))
+	}
+	if p.CanaryInProgress() || poolPaused(t, c, "worker") {
+		t.Error("canary rollout started for a batch that only targets the canary pool")
+	}
+}
+
+func TestCanaryRolloutValidate(t *testing.T) {
+	if err := (CanaryRollout{}).Validate(); err != nil {
+		t.Errorf("Validate() of a disabled canary = %v", err)
+	}
+	if err := (CanaryRollout{Pool: "infra"}).Validate(); err == nil {
+		t.Error("Validate() = nil for a canary pool without a timeout")
+	}
+}
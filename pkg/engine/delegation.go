@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// delegateOwnership hands the autopilot's fields of live over to manager, so the GitOps
+// tool named by the delegate-to annotation owns them from now on and can change or drop
+// them without conflicts. It returns false when the autopilot owned no fields anymore.
+func (p *Patcher) delegateOwnership(ctx context.Context, live *unstructured.Unstructured, manager string) (bool, error) {
+	entries, changed, err := delegatedManagedFields(live.GetManagedFields(), manager)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	original := live.DeepCopy()
+	live.SetManagedFields(entries)
+	// The optimistic lock keeps a concurrent apply from being dropped from managedFields
+	if err := p.client.Patch(ctx, live, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return false, fmt.Errorf("failed to transfer field ownership to %s: %w", manager, err)
+	}
+	return true, nil
+}
+
+// delegatedManagedFields returns entries with the autopilot's entries renamed to manager,
+// and whether there were any. An entry that meets one of manager for the same operation,
+// API version and subresource is merged into it, since the API server rejects duplicates.
+func delegatedManagedFields(entries []metav1.ManagedFieldsEntry, manager string) ([]metav1.ManagedFieldsEntry, bool, error) {
+	if manager == FieldManager || !slices.ContainsFunc(entries, func(e metav1.ManagedFieldsEntry) bool { return e.Manager == FieldManager }) {
+		return entries, false, nil
+	}
+
+	var out, ours []metav1.ManagedFieldsEntry
+	for _, entry := range entries {
+		if entry.Manager == FieldManager {
+			ours = append(ours, entry)
+		} else {
+			out = append(out, entry)
+		}
+	}
+
+	for _, entry := range ours {
+		entry.Manager = manager
+		i := slices.IndexFunc(out, func(e metav1.ManagedFieldsEntry) bool {
+			return e.Manager == manager && e.Operation == entry.Operation &&
+				e.APIVersion == entry.APIVersion && e.Subresource == entry.Subresource
+		})
+		if i < 0 {
+			out = append(out, entry)
+			continue
+		}
+
+		fields, err := unionFields(out[i].FieldsV1, entry.FieldsV1)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to merge managed fields of %s into %s: %w", FieldManager, manager, err)
+		}
+		out[i].FieldsV1 = fields
+		if entry.Time != nil && (out[i].Time == nil || out[i].Time.Before(entry.Time)) {
+			out[i].Time = entry.Time
+		}
+	}
+	return out, true, nil
+}
+
+// unionFields returns the union of two managedFields field sets
+func unionFields(a, b *metav1.FieldsV1) (*metav1.FieldsV1, error) {
+	if a == nil || len(a.Raw) == 0 {
+		return b, nil
+	}
+	if b == nil || len(b.Raw) == 0 {
+		return a, nil
+	}
+
+	setA, setB := &fieldpath.Set{}, &fieldpath.Set{}
+	if err := setA.FromJSON(bytes.NewReader(a.Raw)); err != nil {
+		return nil, err
+	}
+	if err := setB.FromJSON(bytes.NewReader(b.Raw)); err != nil {
+		return nil, err
+	}
+	raw, err := setA.Union(setB).ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.FieldsV1{Raw: raw}, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func fieldsEntry(manager, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: "v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestDelegatedManagedFields(t *testing.T) {
+	t.Run("renames the autopilot's entries", func(t *testing.T) {
+		entries := []metav1.ManagedFieldsEntry{
+			fieldsEntry(FieldManager, `{"f:data":{"f:a":{}}}`),
+			fieldsEntry("kubectl", `{"f:data":{"f:b":{}}}`),
+		}
+		out, changed, err := delegatedManagedFields(entries, "argocd-controller")
+		if err != nil || !changed {
+			t.Fatalf("delegatedManagedFields() = %v, %v, want a change", changed, err)
+		}
+		if len(out) != 2 || out[0].Manager != "kubectl" || out[1].Manager != "argocd-controller" {
+			t.Errorf("managers = %+v, want kubectl and argocd-controller", out)
+		}
+		if entries[0].Manager != FieldManager {
+			t.Error("input entries were modified")
+		}
+	})
+
+	t.Run("merges into an entry of the delegate", func(t *testing.T) {
+		entries := []metav1.ManagedFieldsEntry{
+			fieldsEntry(FieldManager, `{"f:data":{"f:a":{}}}`),
+			fieldsEntry("argocd-controller", `{"f:data":{"f:b":{}}}`),
+		}
+		out, _, err := delegatedManagedFields(entries, "argocd-controller")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out) != 1 {
+			t.Fatalf("entries = %+v, want one merged entry", out)
+		}
+		if got, want := string(out[0].FieldsV1.Raw), `{"f:data":{"f:a":{},"f:b":{}}}`; got != want {
+			t.Errorf("fields = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("nothing to transfer", func(t *testing.T) {
+		entries := []metav1.ManagedFieldsEntry{fieldsEntry("argocd-controller", `{"f:data":{}}`)}
+		if _, changed, err := delegatedManagedFields(entries, "argocd-controller"); err != nil || changed {
+			t.Errorf("delegatedManagedFields() = %v, %v, want no change", changed, err)
+		}
+	})
+}
+
+func TestReconcileAssetDelegated(t *testing.T) {
+	ctx := context.Background()
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-cnv"}}
+	c := fake.NewClientBuilder().
+		WithObjects(namespace).
+		WithInterceptorFuncs(dropDryRunApplies).
+		WithReturnManagedFields().
+		Build()
+	planner := NewPlanner(c, c, pkgassets.NewLoader())
+	created := planner.PlanAsset(ctx, &planTestAsset, renderCtx)
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(created.Object.DeepCopy()),
+		client.ForceOwnership, client.FieldOwner(FieldManager)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	key := client.ObjectKeyFromObject(created.Object)
+	getLive := func() *unstructured.Unstructured {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(created.Object.GroupVersionKind())
+		if err := c.Get(ctx, key, live); err != nil {
+			t.Fatal(err)
+		}
+		return live
+	}
+
+	live := getLive()
+	if !AppliedByAutopilot(live) {
+		t.Fatalf("managedFields = %+v, want an apply entry of %s", live.GetManagedFields(), FieldManager)
+	}
+	live.SetAnnotations(map[string]string{overrides.AnnotationDelegateTo: "argocd"})
+	live.SetLabels(map[string]string{"edited": "by-hand"})
+	if err := c.Update(ctx, live); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := eventtest.NewRecorder()
+	p := NewPatcher(c, c, pkgassets.NewLoader())
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+
+	for range 2 {
+		applied, err := p.ReconcileAsset(ctx, &planTestAsset, renderCtx)
+		if err != nil || applied {
+			t.Fatalf("ReconcileAsset() = %v, %v, want skipped", applied, err)
+		}
+	}
+
+	live = getLive()
+	managers := fieldManagers(live)
+	if slices.Contains(managers, FieldManager) || !slices.Contains(managers, "argocd-controller") {
+		t.Errorf("field managers = %v, want %s's fields handed to argocd-controller", managers, FieldManager)
+	}
+	if live.GetLabels()["edited"] != "by-hand" {
+		t.Error("delegated object was reconciled")
+	}
+	if report := sink.reports[planTestAsset.Name]; report.State != ObjectDelegated || report.Reason != ReasonDelegated {
+		t.Errorf("report = %+v, want %s (%s)", report, ObjectDelegated, ReasonDelegated)
+	}
+	if n := rec.Count(util.EventReasonOwnershipDelegated); n != 1 {
+		t.Errorf("got %d %s events, want one for the transfer", n, util.EventReasonOwnershipDelegated)
+	}
+}
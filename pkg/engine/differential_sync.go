@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DesiredHashAnnotation records, on every applied object, a hash of the desired state
+// the autopilot applied. A restarted operator compares it with the hash of what it
+// renders now and skips the drift check of objects whose desired state is unchanged.
+const DesiredHashAnnotation = "platform.kubevirt.io/desired-hash"
+
+// DesiredHash returns a short hash of the desired object, leaving out the annotations
+// that record it and its rendered fields
+func DesiredHash(desired *unstructured.Unstructured) string {
+	obj := desired.DeepCopy()
+	annotations := obj.GetAnnotations()
+	delete(annotations, DesiredHashAnnotation)
+	delete(annotations, RenderedFieldsAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	// Map keys are marshalled sorted, so equal objects hash equally
+	data, _ := json.Marshal(obj.Object)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// SetDesiredHash records the DesiredHash of obj on obj
+func SetDesiredHash(obj *unstructured.Unstructured) {
+	hash := DesiredHash(obj)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[DesiredHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+}
+
+// differentialSync remembers the objects reconciled since the operator started, so
+// only the first pass over each object can skip its drift check
+type differentialSync struct {
+	mu      sync.Mutex
+	enabled bool
+	seen    map[string]bool
+}
+
+// SetDifferentialSync enables skipping, in the first pass after the operator starts, the
+// drift check of objects whose recorded desired hash matches the current rendering, so an
+// operator restart or upgrade only re-applies the assets whose desired state changed.
+// Edits made to such objects while the operator was down are corrected by the next pass.
+func (p *Patcher) SetDifferentialSync(enabled bool) {
+	p.differentialSync.mu.Lock()
+	defer p.differentialSync.mu.Unlock()
+	p.differentialSync.enabled = enabled
+}
+
+// unchangedSinceStart reports whether this is the first pass over desired since the
+// operator started and live records the hash of desired, i.e. the previous operator
+// applied exactly this desired state
+func (s *differentialSync) unchangedSinceStart(desired, live *unstructured.Unstructured) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled {
+		return false
+	}
+	key := objectRef(desired)
+	if s.seen[key] {
+		return false
+	}
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[key] = true
+
+	recorded := live.GetAnnotations()[DesiredHashAnnotation]
+	return recorded != "" && recorded == DesiredHash(desired)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestDesiredHash(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("config")
+	hash := DesiredHash(obj)
+
+	recorded := obj.DeepCopy()
+	SetRenderedFields(recorded, []string{"/data/key"})
+	SetDesiredHash(recorded)
+	if got := DesiredHash(recorded); got != hash {
+		t.Errorf("DesiredHash() with its own records = %s, want %s", got, hash)
+	}
+	if got := recorded.GetAnnotations()[DesiredHashAnnotation]; got != hash {
+		t.Errorf("recorded hash = %s, want %s", got, hash)
+	}
+
+	changed := obj.DeepCopy()
+	_ = unstructured.SetNestedField(changed.Object, "value", "data", "key")
+	if DesiredHash(changed) == hash {
+		t.Error("DesiredHash() did not change with the object")
+	}
+}
+
+func TestDifferentialSync(t *testing.T) {
+	ctx := context.Background()
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+
+	var dryRuns atomic.Int32
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-cnv"}}
+	c := fake.NewClientBuilder().
+		WithObjects(namespace).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				if slices.Contains(opts, client.ApplyOption(client.DryRunAll)) {
+					dryRuns.Add(1)
+					return nil
+				}
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	// The previous operator creates the object and records the hash
+	if _, err := NewPatcher(c, c, pkgassets.NewLoader()).ReconcileAsset(ctx, &planTestAsset, renderCtx); err != nil {
+		t.Fatalf("ReconcileAsset() error = %v", err)
+	}
+
+	// After a restart, the first pass trusts the hash and the next one checks drift
+	p := NewPatcher(c, c, pkgassets.NewLoader())
+	p.SetDifferentialSync(true)
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+
+	dryRuns.Store(0)
+	if applied, err := p.ReconcileAsset(ctx, &planTestAsset, renderCtx); err != nil || applied {
+		t.Fatalf("first pass: ReconcileAsset() = %v, %v, want skipped", applied, err)
+	}
+	if n := dryRuns.Load(); n != 0 {
+		t.Errorf("first pass: %d dry-run applies, want none", n)
+	}
+	if report := sink.reports[planTestAsset.Name]; report.State != ObjectInSync {
+		t.Errorf("first pass: report = %+v, want %s", report, ObjectInSync)
+	}
+
+	if _, err := p.ReconcileAsset(ctx, &planTestAsset, renderCtx); err != nil {
+		t.Fatalf("second pass: ReconcileAsset() error = %v", err)
+	}
+	if n := dryRuns.Load(); n != 1 {
+		t.Errorf("second pass: %d dry-run applies, want one", n)
+	}
+
+	// A desired state that changed since the last apply is checked right away
+	changedCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+	changedCtx.Network.IPFamilies = []string{"IPv4", "IPv6"}
+	restarted := NewPatcher(c, c, pkgassets.NewLoader())
+	restarted.SetDifferentialSync(true)
+	dryRuns.Store(0)
+	if _, err := restarted.ReconcileAsset(ctx, &planTestAsset, changedCtx); err != nil {
+		t.Fatalf("changed: ReconcileAsset() error = %v", err)
+	}
+	if n := dryRuns.Load(); n != 1 {
+		t.Errorf("changed: %d dry-run applies, want one", n)
+	}
+}
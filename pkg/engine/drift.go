@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -54,20 +55,9 @@ func (d *DriftDetector) DetectDrift(ctx context.Context, desired, live *unstruct
 	}
 
 	// Perform SSA dry-run to see what would change
-	dryRunObj := desired.DeepCopy()
-
-	// Use modern Apply() API with dry-run for drift detection
-	applyOptions := []client.ApplyOption{
-		client.DryRunAll,
-		client.ForceOwnership,
-		client.FieldOwner(FieldManager),
-	}
-
-	// Convert unstructured to ApplyConfiguration
-	applyConfig := client.ApplyConfigurationFromUnstructured(dryRunObj)
-	err := d.client.Apply(ctx, applyConfig, applyOptions...)
+	dryRunObj, err := d.DryRunApply(ctx, desired)
 	if err != nil {
-		return false, fmt.Errorf("failed to perform dry-run apply: %w", err)
+		return false, err
 	}
 
 	// Sanitize both objects for comparison (remove runtime fields)
@@ -89,6 +79,51 @@ func (d *DriftDetector) DetectDrift(ctx context.Context, desired, live *unstruct
 	return hasDrift, nil
 }
 
+// DryRunApply returns desired as the API server would store it after a server-side
+// apply with the autopilot's field manager. Nothing is persisted.
+func (d *DriftDetector) DryRunApply(ctx context.Context, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	dryRunObj := desired.DeepCopy()
+
+	// Use modern Apply() API with dry-run for drift detection
+	applyOptions := []client.ApplyOption{
+		client.DryRunAll,
+		client.ForceOwnership,
+		client.FieldOwner(FieldManager),
+	}
+
+	// Convert unstructured to ApplyConfiguration
+	applyConfig := client.ApplyConfigurationFromUnstructured(dryRunObj)
+	if err := d.client.Apply(ctx, applyConfig, applyOptions...); err != nil {
+		return nil, fmt.Errorf("failed to perform dry-run apply: %w", err)
+	}
+	return dryRunObj, nil
+}
+
+// DriftedFields returns the sorted dotted paths where desired and live differ, ignoring
+// the fields drift detection ignores (status, resourceVersion, managedFields, ...)
+func DriftedFields(desired, live *unstructured.Unstructured) []string {
+	return driftPaths(sanitizeObject(live), sanitizeObject(desired), "")
+}
+
+func driftPaths(live, desired map[string]any, prefix string) []string {
+	var paths []string
+	for k := range structuredDiff(live, desired) {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		liveMap, liveIsMap := live[k].(map[string]any)
+		desiredMap, desiredIsMap := desired[k].(map[string]any)
+		if liveIsMap && desiredIsMap {
+			paths = append(paths, driftPaths(liveMap, desiredMap, path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // SimpleDriftCheck performs a simple comparison without SSA dry-run
 // This is faster but less accurate than DetectDrift
 func (d *DriftDetector) SimpleDriftCheck(desired, live *unstructured.Unstructured) bool {
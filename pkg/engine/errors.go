@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+)
+
+// ErrorReason classifies why an asset failed to reconcile. It is the reason of the
+// failure event, the reason label of kubevirt_autopilot_asset_errors_total, the
+// ManagedResource status reason and the HCO ReconcileFailing condition reason, so
+// failures can be alerted on and aggregated without parsing messages. The failure
+// reasons are the Reasons of failed assets.
+type ErrorReason = Reason
+
+const (
+	// ReasonRenderFailed means the template, an input it consumes or a mutator failed
+	ReasonRenderFailed ErrorReason = util.EventReasonRenderFailed
+	// ReasonConditionFailed means the asset's conditions could not be evaluated
+	ReasonConditionFailed ErrorReason = util.EventReasonConditionFailed
+	// ReasonApplyConflict means another field manager owns fields the apply sets
+	ReasonApplyConflict ErrorReason = util.EventReasonApplyConflict
+	// ReasonDependencyMissing means an API the asset needs is not served (e.g. its CRD
+	// was removed after the availability check)
+	ReasonDependencyMissing ErrorReason = util.EventReasonDependencyMissing
+	// ReasonApplyTimeout means the asset was cancelled by the apply timeouts
+	ReasonApplyTimeout ErrorReason = util.EventReasonApplyTimeout
+	// ReasonApplyFailed covers every other failure to read, compare or apply the object
+	ReasonApplyFailed ErrorReason = util.EventReasonApplyFailed
+	// ReasonMultipleFailures is the reason of a pass whose assets failed for different reasons
+	ReasonMultipleFailures ErrorReason = "MultipleFailures"
+)
+
+// errAssetTimeout marks the errors of assets cancelled by the apply timeouts
+var errAssetTimeout = errors.New("TIMEOUT")
+
+// RenderError is returned when an asset could not be rendered
+type RenderError struct {
+	Asset string
+	Err   error
+}
+
+func (e *RenderError) Error() string {
+	return fmt.Sprintf("failed to render asset %s: %v", e.Asset, e.Err)
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// ConditionError is returned when the conditions of an asset could not be evaluated
+type ConditionError struct {
+	Asset string
+	Err   error
+}
+
+func (e *ConditionError) Error() string {
+	return fmt.Sprintf("failed to evaluate conditions of asset %s: %v", e.Asset, e.Err)
+}
+
+func (e *ConditionError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyConflictError is returned when Server-Side Apply reports fields owned by
+// another field manager
+type ApplyConflictError struct {
+	// Object is the kind/namespace/name of the conflicting object
+	Object string
+	Err    error
+}
+
+func (e *ApplyConflictError) Error() string {
+	return fmt.Sprintf("field ownership conflict (another controller owns fields) on %s: %v", e.Object, e.Err)
+}
+
+func (e *ApplyConflictError) Unwrap() error {
+	return e.Err
+}
+
+// DependencyMissingError is returned when the API server does not serve a kind an
+// asset reads or applies
+type DependencyMissingError struct {
+	// Dependency is the missing group/version/kind
+	Dependency string
+	Err        error
+}
+
+func (e *DependencyMissingError) Error() string {
+	return fmt.Sprintf("dependency %s is not installed: %v", e.Dependency, e.Err)
+}
+
+func (e *DependencyMissingError) Unwrap() error {
+	return e.Err
+}
+
+// dependencyError returns err as a *DependencyMissingError when it reports that gvk
+// is not served, and unchanged otherwise
+func dependencyError(gvk schema.GroupVersionKind, err error) error {
+	if !apimeta.IsNoMatchError(err) {
+		return err
+	}
+	return &DependencyMissingError{Dependency: gvk.String(), Err: err}
+}
+
+// AssetFailure is one failed asset of a ReconcileAssets pass
+type AssetFailure struct {
+	Asset  string
+	Reason ErrorReason
+	Err    error
+}
+
+// AssetErrors is returned by ReconcileAssets when at least one asset failed
+type AssetErrors struct {
+	Failures []AssetFailure
+	// Total is the number of assets of the pass
+	Total int
+}
+
+func (e *AssetErrors) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("[%s: %v]", f.Asset, f.Err))
+	}
+	return fmt.Sprintf("failed to reconcile %d/%d assets: %s", len(e.Failures), e.Total, strings.Join(msgs, "; "))
+}
+
+func (e *AssetErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// Reason returns the reason shared by every failure, or ReasonMultipleFailures
+func (e *AssetErrors) Reason() ErrorReason {
+	if len(e.Failures) == 0 {
+		return ""
+	}
+	reason := e.Failures[0].Reason
+	for _, f := range e.Failures[1:] {
+		if f.Reason != reason {
+			return ReasonMultipleFailures
+		}
+	}
+	return reason
+}
+
+// ReasonOf classifies err, returning "" for errors outside the taxonomy.
+// A timeout takes precedence over the error it cancelled, and a missing dependency or
+// a conflict over the render or apply step that ran into it.
+func ReasonOf(err error) ErrorReason {
+	var assetErrs *AssetErrors
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &assetErrs):
+		return assetErrs.Reason()
+	case errors.Is(err, errAssetTimeout):
+		return ReasonApplyTimeout
+	case errors.As(err, new(*DependencyMissingError)):
+		return ReasonDependencyMissing
+	case errors.As(err, new(*ApplyConflictError)):
+		return ReasonApplyConflict
+	case errors.As(err, new(*ConditionError)):
+		return ReasonConditionFailed
+	case errors.As(err, new(*RenderError)):
+		return ReasonRenderFailed
+	}
+	return ""
+}
+
+// AssetErrorReason classifies the error an asset failed with. Failures outside the
+// taxonomy happened while reading, comparing or applying the object.
+func AssetErrorReason(err error) ErrorReason {
+	if reason := ReasonOf(err); reason != "" {
+		return reason
+	}
+	return ReasonApplyFailed
+}
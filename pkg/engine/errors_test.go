@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func TestReasonOf(t *testing.T) {
+	noMatch := &apimeta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "nmstate.io", Kind: "NMState"}}
+	gvk := schema.GroupVersionKind{Group: "nmstate.io", Version: "v1", Kind: "NMState"}
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorReason
+	}{
+		{"nil", nil, ""},
+		{"unclassified", errors.New("connection refused"), ""},
+		{"render", &RenderError{Asset: "a", Err: errors.New("bad template")}, ReasonRenderFailed},
+		{"condition", &ConditionError{Asset: "a", Err: errors.New("bad selector")}, ReasonConditionFailed},
+		{"conflict", fmt.Errorf("failed to apply asset a: %w", &ApplyConflictError{Object: "x", Err: errors.New("conflict")}), ReasonApplyConflict},
+		{"dependency", dependencyError(gvk, noMatch), ReasonDependencyMissing},
+		{"not a dependency", dependencyError(gvk, errors.New("forbidden")), ""},
+		{"dependency inside render", &RenderError{Asset: "a", Err: dependencyError(gvk, noMatch)}, ReasonDependencyMissing},
+		{"timeout over cause", fmt.Errorf("%w: apply cancelled: %w", errAssetTimeout, &RenderError{Asset: "a"}), ReasonApplyTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReasonOf(tt.err); got != tt.want {
+				t.Errorf("ReasonOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := AssetErrorReason(errors.New("connection refused")); got != ReasonApplyFailed {
+		t.Errorf("AssetErrorReason() of an unclassified error = %q, want %q", got, ReasonApplyFailed)
+	}
+}
+
+func TestAssetErrorsReason(t *testing.T) {
+	same := &AssetErrors{Total: 3, Failures: []AssetFailure{
+		{Asset: "a", Reason: ReasonRenderFailed, Err: errors.New("x")},
+		{Asset: "b", Reason: ReasonRenderFailed, Err: errors.New("y")},
+	}}
+	if got := ReasonOf(&TimeoutError{err: same}); got != ReasonRenderFailed {
+		t.Errorf("ReasonOf() = %q, want the shared reason %q", got, ReasonRenderFailed)
+	}
+	if got := same.Error(); got != "failed to reconcile 2/3 assets: [a: x]; [b: y]" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	mixed := &AssetErrors{Total: 2, Failures: []AssetFailure{
+		{Asset: "a", Reason: ReasonRenderFailed, Err: errors.New("x")},
+		{Asset: "b", Reason: ReasonApplyConflict, Err: &ApplyConflictError{Err: errors.New("y")}},
+	}}
+	if got := ReasonOf(mixed); got != ReasonMultipleFailures {
+		t.Errorf("ReasonOf() = %q, want %q", got, ReasonMultipleFailures)
+	}
+	if !errors.As(mixed, new(*ApplyConflictError)) {
+		t.Error("expected the failures to be reachable through errors.As")
+	}
+}
+
+// TestReconcileAssetsClassifiesFailures verifies that each failed asset is reported
+// with its reason through the error metric and the HCO event
+func TestReconcileAssetsClassifiesFailures(t *testing.T) {
+	observability.AssetErrorsTotal.Reset()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kubevirt-hyperconverged"}}
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(namespace).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				u, _ := obj.(interface{ GetKind() string })
+				switch u.GetKind() {
+				case "MachineConfig":
+					return apierrors.NewConflict(schema.GroupResource{Resource: "machineconfigs"}, "psi", errors.New("spec owned by another manager"))
+				case "Service":
+					return &apimeta.NoKindMatchError{GroupKind: schema.GroupKind{Kind: "Service"}}
+				}
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	rec := eventtest.NewRecorder()
+	p := &Patcher{
+		renderer:          NewRenderer(pkgassets.NewLoader()),
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     &switchableDriftChecker{drift: true},
+		throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged"))
+
+	_, err := p.ReconcileAssets(context.Background(), timeoutTestAssets, renderCtx)
+
+	var assetErrs *AssetErrors
+	if !errors.As(err, &assetErrs) || len(assetErrs.Failures) != 2 {
+		t.Fatalf("ReconcileAssets() error = %v, want *AssetErrors with 2 failures", err)
+	}
+	if got := ReasonOf(err); got != ReasonMultipleFailures {
+		t.Errorf("ReasonOf() = %q, want %q", got, ReasonMultipleFailures)
+	}
+
+	for asset, reason := range map[string]ErrorReason{
+		"psi-enable":      ReasonApplyConflict,
+		"metrics-service": ReasonDependencyMissing,
+	} {
+		if val := testutil.ToFloat64(observability.AssetErrorsTotal.WithLabelValues(asset, string(reason))); val != 1 {
+			t.Errorf("asset_errors_total{asset=%s,reason=%s} = %v, want 1", asset, reason, val)
+		}
+	}
+	if got := rec.Count(util.EventReasonApplyConflict); got != 1 {
+		t.Errorf("ApplyConflict event count = %d, want 1", got)
+	}
+	if got := rec.Count(util.EventReasonDependencyMissing); got != 1 {
+		t.Errorf("DependencyMissing event count = %d, want 1", got)
+	}
+	if got := rec.Count(util.EventReasonApplyFailed); got != 0 {
+		t.Errorf("ApplyFailed event count = %d, want 0 for classified failures", got)
+	}
+}
@@ -20,9 +20,13 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 )
 
 const (
@@ -30,11 +34,50 @@ const (
 	DisabledResourcesAnnotation = "platform.kubevirt.io/disabled-resources"
 )
 
-// ExclusionRule defines a single resource exclusion rule
+// ExclusionRule defines a single resource exclusion rule.
+// Namespace and Name accept shell-style glob patterns (see path/filepath.Match).
 type ExclusionRule struct {
-	Kind      string `yaml:"kind"`      // Required: Resource kind (e.g., "ConfigMap")
-	Namespace string `yaml:"namespace"` // Optional: Namespace (empty = all namespaces, supports wildcards)
-	Name      string `yaml:"name"`      // Required: Resource name (supports wildcards)
+	Kind      string     `json:"kind" yaml:"kind"`                               // Required: Resource kind (e.g., "ConfigMap")
+	Namespace string     `json:"namespace,omitempty" yaml:"namespace,omitempty"` // Optional: Namespace (empty = all namespaces, supports wildcards)
+	Name      string     `json:"name" yaml:"name"`                               // Required: Resource name (supports wildcards)
+	Expires   *time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`     // Optional: RFC 3339 time after which the rule no longer applies
+}
+
+// Expired reports whether the rule has an expiry that is not after now
+func (r ExclusionRule) Expired(now time.Time) bool {
+	return r.Expires != nil && !now.Before(*r.Expires)
+}
+
+// String identifies the rule in logs and events
+func (r ExclusionRule) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// Matches reports whether the rule selects the resource identified by kind, namespace and name.
+// Kind is matched exactly (case-sensitive). An empty rule namespace matches any namespace,
+// including cluster-scoped resources; a rule namespace, even "*", matches only namespaced
+// ones. Invalid patterns never match (fail-open).
+func (r ExclusionRule) Matches(kind, namespace, name string) bool {
+	if r.Kind != kind {
+		return false
+	}
+
+	if r.Namespace != "" {
+		// filepath.Match("*", "") is true, so a cluster-scoped resource is rejected first
+		if namespace == "" {
+			return false
+		}
+		matched, err := filepath.Match(r.Namespace, namespace)
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	matched, err := filepath.Match(r.Name, name)
+	return err == nil && matched
 }
 
 // ParseDisabledResources parses the disabled-resources annotation as YAML
@@ -59,42 +102,51 @@ func ParseDisabledResources(annotation string) ([]ExclusionRule, error) {
 		if rule.Name == "" {
 			return nil, fmt.Errorf("rule %d: name is required", i)
 		}
+		// Reject malformed patterns up front so a typo is reported instead of silently never matching
+		if _, err := filepath.Match(rule.Name, ""); err != nil {
+			return nil, fmt.Errorf("rule %d: invalid name pattern %q: %w", i, rule.Name, err)
+		}
+		if _, err := filepath.Match(rule.Namespace, ""); err != nil {
+			return nil, fmt.Errorf("rule %d: invalid namespace pattern %q: %w", i, rule.Namespace, err)
+		}
 	}
 
 	return rules, nil
 }
 
-// IsResourceExcluded checks if a specific resource matches any exclusion rule
+// ExclusionRulesFromObject parses the disabled-resources annotation of obj (normally the HCO).
+// Returns nil rules when obj is nil or the annotation is absent.
+func ExclusionRulesFromObject(obj *unstructured.Unstructured) ([]ExclusionRule, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	return ParseDisabledResources(obj.GetAnnotations()[DisabledResourcesAnnotation])
+}
+
+// IsResourceExcluded checks if a specific resource matches any unexpired exclusion rule
 func IsResourceExcluded(kind, namespace, name string, rules []ExclusionRule) bool {
+	now := time.Now()
 	for _, rule := range rules {
-		// Check kind (exact match, case-sensitive)
-		if rule.Kind != kind {
-			continue
-		}
-
-		// Check namespace
-		if rule.Namespace != "" {
-			// Rule has namespace specified - must match
-			matched, _ := filepath.Match(rule.Namespace, namespace)
-			if !matched {
-				continue
-			}
-		}
-		// If rule.Namespace is empty, it matches any namespace
-
-		// Check name with wildcard support
-		matched, err := filepath.Match(rule.Name, name)
-		if err != nil {
-			// Invalid pattern - skip this rule
-			continue
+		if !rule.Expired(now) && rule.Matches(kind, namespace, name) {
+			return true
 		}
+	}
+	return false
+}
 
-		if matched {
-			return true // Exclusion matched
+// MatchingExclusion returns the first unexpired AutopilotExclusion of renderCtx that
+// selects obj, rendered from an asset of component, or nil when none does
+func MatchingExclusion(renderCtx *pkgcontext.RenderContext, component string, obj *unstructured.Unstructured, now time.Time) *overrides.Exclusion {
+	if renderCtx == nil {
+		return nil
+	}
+	for i := range renderCtx.Exclusions {
+		exclusion := &renderCtx.Exclusions[i]
+		if !exclusion.Expired(now) && exclusion.Matches(obj.GetKind(), obj.GetNamespace(), obj.GetName(), component) {
+			return exclusion
 		}
 	}
-
-	return false
+	return nil
 }
 
 // FilterExcludedAssets removes disabled resources from asset list
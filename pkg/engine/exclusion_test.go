@@ -17,9 +17,14 @@ limitations under the License.
 package engine
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 )
 
 var _ = Describe("Root Exclusion", func() {
@@ -98,6 +103,53 @@ var _ = Describe("Root Exclusion", func() {
 			Expect(result).To(BeNil())
 		})
 
+		It("should return error for invalid name pattern", func() {
+			yaml := `
+- kind: ConfigMap
+  name: "test["
+`
+			result, err := ParseDisabledResources(yaml)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid name pattern"))
+			Expect(result).To(BeNil())
+		})
+
+		It("should return error for invalid namespace pattern", func() {
+			yaml := `
+- kind: ConfigMap
+  namespace: "prod-["
+  name: my-config
+`
+			result, err := ParseDisabledResources(yaml)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid namespace pattern"))
+			Expect(result).To(BeNil())
+		})
+
+		It("should parse an expiry", func() {
+			yaml := `
+- kind: KubeDescheduler
+  name: cluster
+  expires: 2026-12-01T00:00:00Z
+`
+			result, err := ParseDisabledResources(yaml)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Expires).ToNot(BeNil())
+			Expect(*result[0].Expires).To(BeTemporally("==", time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should return error for an invalid expiry", func() {
+			yaml := `
+- kind: KubeDescheduler
+  name: cluster
+  expires: next week
+`
+			result, err := ParseDisabledResources(yaml)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
 		It("should handle whitespace correctly", func() {
 			yaml := `
 - kind: ConfigMap
@@ -111,6 +163,67 @@ var _ = Describe("Root Exclusion", func() {
 		})
 	})
 
+	Describe("ExclusionRulesFromObject", func() {
+		It("should return nil for nil object", func() {
+			result, err := ExclusionRulesFromObject(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("should return nil when annotation is absent", func() {
+			result, err := ExclusionRulesFromObject(createTestAsset("HyperConverged", "openshift-cnv", "kubevirt-hyperconverged"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("should parse namespace-scoped rules from the annotation", func() {
+			hco := createTestAsset("HyperConverged", "openshift-cnv", "kubevirt-hyperconverged")
+			hco.SetAnnotations(map[string]string{
+				DisabledResourcesAnnotation: `
+- kind: Service
+  namespace: openshift-cnv
+  name: virt-*
+`,
+			})
+			result, err := ExclusionRulesFromObject(hco)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal([]ExclusionRule{{Kind: "Service", Namespace: "openshift-cnv", Name: "virt-*"}}))
+		})
+	})
+
+	Describe("ExclusionRule.Matches", func() {
+		It("should not match cluster-scoped resources when namespace is set", func() {
+			rule := ExclusionRule{Kind: "KubeDescheduler", Namespace: "openshift-cnv", Name: "cluster"}
+			Expect(rule.Matches("KubeDescheduler", "", "cluster")).To(BeFalse())
+			Expect(rule.Matches("KubeDescheduler", "openshift-cnv", "cluster")).To(BeTrue())
+		})
+
+		It("should not match cluster-scoped resources with a wildcard namespace", func() {
+			rule := ExclusionRule{Kind: "MachineConfig", Namespace: "*", Name: "*"}
+			Expect(rule.Matches("MachineConfig", "", "50-swap")).To(BeFalse())
+			Expect(ExclusionRule{Kind: "MachineConfig", Name: "*"}.Matches("MachineConfig", "", "50-swap")).To(BeTrue())
+			Expect(ExclusionRule{Kind: "ConfigMap", Namespace: "*", Name: "*"}.Matches("ConfigMap", "openshift-cnv", "tuning")).To(BeTrue())
+		})
+
+		It("should fail open for invalid namespace patterns", func() {
+			rule := ExclusionRule{Kind: "ConfigMap", Namespace: "prod-[", Name: "*"}
+			Expect(rule.Matches("ConfigMap", "prod-[", "test")).To(BeFalse())
+		})
+	})
+
+	Describe("ExclusionRule.Expired", func() {
+		It("should never expire without an expiry", func() {
+			Expect(ExclusionRule{Kind: "ConfigMap", Name: "a"}.Expired(time.Now())).To(BeFalse())
+		})
+
+		It("should expire at the expiry", func() {
+			expires := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+			rule := ExclusionRule{Kind: "ConfigMap", Name: "a", Expires: &expires}
+			Expect(rule.Expired(expires.Add(-time.Second))).To(BeFalse())
+			Expect(rule.Expired(expires)).To(BeTrue())
+		})
+	})
+
 	Describe("IsResourceExcluded", func() {
 		It("should return false for empty rules", func() {
 			var rules []ExclusionRule
@@ -200,6 +313,17 @@ var _ = Describe("Root Exclusion", func() {
 			Expect(IsResourceExcluded("Deployment", "default", "test", rules)).To(BeFalse())
 		})
 
+		It("should skip expired rules", func() {
+			past := time.Now().Add(-time.Hour)
+			future := time.Now().Add(time.Hour)
+			rules := []ExclusionRule{
+				{Kind: "ConfigMap", Name: "virt-handler", Expires: &past},
+				{Kind: "Secret", Name: "credentials", Expires: &future},
+			}
+			Expect(IsResourceExcluded("ConfigMap", "openshift-cnv", "virt-handler", rules)).To(BeFalse())
+			Expect(IsResourceExcluded("Secret", "openshift-cnv", "credentials", rules)).To(BeTrue())
+		})
+
 		It("should match first matching rule", func() {
 			rules := []ExclusionRule{
 				{Kind: "ConfigMap", Namespace: "openshift-cnv", Name: "virt-*"},
@@ -303,6 +427,36 @@ var _ = Describe("Root Exclusion", func() {
 			}
 		})
 	})
+
+	Describe("MatchingExclusion", func() {
+		now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		expired := now.Add(-time.Hour)
+		machineConfig := createTestAsset("MachineConfig", "", "50-swap")
+
+		It("should return nil without a render context or exclusions", func() {
+			Expect(MatchingExclusion(nil, "MachineConfig", machineConfig, now)).To(BeNil())
+			Expect(MatchingExclusion(&pkgcontext.RenderContext{}, "MachineConfig", machineConfig, now)).To(BeNil())
+		})
+
+		It("should return the first unexpired exclusion selecting the object", func() {
+			renderCtx := &pkgcontext.RenderContext{Exclusions: []overrides.Exclusion{
+				{Source: "old", Kind: "MachineConfig", ExpiresAt: &expired},
+				{Source: "other-kind", Kind: "KubeletConfig"},
+				{Source: "by-component", Component: "MachineConfig"},
+				{Source: "by-name", Kind: "MachineConfig", Name: "50-*"},
+			}}
+			exclusion := MatchingExclusion(renderCtx, "MachineConfig", machineConfig, now)
+			Expect(exclusion).NotTo(BeNil())
+			Expect(exclusion.Source).To(Equal("by-component"))
+		})
+
+		It("should ignore expired exclusions", func() {
+			renderCtx := &pkgcontext.RenderContext{Exclusions: []overrides.Exclusion{
+				{Source: "old", Kind: "MachineConfig", ExpiresAt: &expired},
+			}}
+			Expect(MatchingExclusion(renderCtx, "MachineConfig", machineConfig, now)).To(BeNil())
+		})
+	})
 })
 
 // createTestAsset creates a test unstructured object
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// ResourceGuardrailsMutatorName is the registered name of the built-in resource guardrails mutator
+const ResourceGuardrailsMutatorName = "resource-guardrails"
+
+// Guardrail modes
+const (
+	// GuardrailModeInject fills in missing requests, limits and priorityClassName
+	GuardrailModeInject = "inject"
+	// GuardrailModeValidate fails the asset when a value is missing or above the policy
+	GuardrailModeValidate = "validate"
+)
+
+// forkliftComponents are the ForkliftController components whose container resources
+// the operator reads from flat <component>_container_<requests|limits>_<cpu|memory> fields
+var forkliftComponents = []string{"api", "controller", "inventory", "ui", "validation"}
+
+// forkliftResources are the resources a ForkliftController can bound
+var forkliftResources = map[string]bool{"cpu": true, "memory": true}
+
+// resourceGuardrailsConfig is the config block accepted by the resource-guardrails mutator:
+//
+//	mode: inject            # or validate
+//	priorityClassName: openshift-user-critical
+//	requests: {cpu: 10m, memory: 64Mi}
+//	limits: {memory: 1Gi}
+//
+// In inject mode, containers without a request or limit for a listed resource get the
+// policy value, and pod specs without a priorityClassName get the policy's. In validate
+// mode such gaps fail the asset, and so do limits above the policy's limits and a
+// different priorityClassName. Values the template or user already set are never changed.
+type resourceGuardrailsConfig struct {
+	Mode              string            `json:"mode,omitempty"`
+	PriorityClassName string            `json:"priorityClassName,omitempty"`
+	Requests          map[string]string `json:"requests,omitempty"`
+	Limits            map[string]string `json:"limits,omitempty"`
+}
+
+// resourceGuardrails is the parsed resource-guardrails policy
+type resourceGuardrails struct {
+	validate          bool
+	priorityClassName string
+	requests          map[string]resource.Quantity
+	limits            map[string]resource.Quantity
+}
+
+func init() {
+	RegisterMutator(ResourceGuardrailsMutatorName, newResourceGuardrailsMutator)
+}
+
+// newResourceGuardrailsMutator bounds the resources of the workloads the autopilot creates:
+// pod spec kinds (Deployment, DaemonSet, ...) and ForkliftController. Other kinds, including
+// UIPlugin whose API has no resource fields, are untouched.
+func newResourceGuardrailsMutator(raw json.RawMessage) (Mutator, error) {
+	cfg := resourceGuardrailsConfig{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = GuardrailModeInject
+	}
+	if cfg.Mode != GuardrailModeInject && cfg.Mode != GuardrailModeValidate {
+		return nil, fmt.Errorf("unknown mode %q, expected %s or %s", cfg.Mode, GuardrailModeInject, GuardrailModeValidate)
+	}
+	if cfg.PriorityClassName == "" && len(cfg.Requests) == 0 && len(cfg.Limits) == 0 {
+		return nil, fmt.Errorf("at least one of priorityClassName, requests or limits is required")
+	}
+
+	g := &resourceGuardrails{validate: cfg.Mode == GuardrailModeValidate, priorityClassName: cfg.PriorityClassName}
+	var err error
+	if g.requests, err = parseQuantities("requests", cfg.Requests); err != nil {
+		return nil, err
+	}
+	if g.limits, err = parseQuantities("limits", cfg.Limits); err != nil {
+		return nil, err
+	}
+	for name, request := range g.requests {
+		if limit, ok := g.limits[name]; ok && request.Cmp(limit) > 0 {
+			return nil, fmt.Errorf("requests.%s %s exceeds limits.%s %s", name, request.String(), name, limit.String())
+		}
+	}
+	return MutatorFunc(g.mutate), nil
+}
+
+func parseQuantities(field string, values map[string]string) (map[string]resource.Quantity, error) {
+	quantities := make(map[string]resource.Quantity, len(values))
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s.%s %q: %w", field, name, value, err)
+		}
+		quantities[name] = quantity
+	}
+	return quantities, nil
+}
+
+func (g *resourceGuardrails) mutate(_ context.Context, _ *assets.AssetMetadata, desired *unstructured.Unstructured, _ *pkgcontext.RenderContext) error {
+	if desired.GetKind() == "ForkliftController" {
+		return g.guardForklift(desired)
+	}
+	if path := podSpecPath(desired.GetKind()); path != nil {
+		return g.guardPodSpec(desired, path)
+	}
+	return nil
+}
+
+// guardPodSpec applies the policy to the priorityClassName and every container of a pod spec
+func (g *resourceGuardrails) guardPodSpec(desired *unstructured.Unstructured, path []string) error {
+	podSpec, found, err := unstructured.NestedMap(desired.Object, path...)
+	if err != nil || !found {
+		return err
+	}
+
+	var violations []string
+	if g.priorityClassName != "" {
+		current, _ := podSpec["priorityClassName"].(string)
+		switch {
+		case current == "" && !g.validate:
+			podSpec["priorityClassName"] = g.priorityClassName
+		case current != g.priorityClassName && g.validate:
+			violations = append(violations, fmt.Sprintf("priorityClassName is %q, policy requires %q", current, g.priorityClassName))
+		}
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _ := podSpec[field].([]any)
+		for _, item := range containers {
+			container, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := container["name"].(string)
+			resources, _ := container["resources"].(map[string]any)
+			if resources == nil {
+				resources = map[string]any{}
+			}
+			for _, kind := range []string{"requests", "limits"} {
+				values, _ := resources[kind].(map[string]any)
+				if values == nil {
+					values = map[string]any{}
+				}
+				for _, violation := range g.guardValues(kind, values, nil) {
+					violations = append(violations, fmt.Sprintf("container %s: %s", name, violation))
+				}
+				if len(values) > 0 {
+					resources[kind] = values
+				}
+			}
+			if len(resources) > 0 {
+				container["resources"] = resources
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return guardrailError(desired, violations)
+	}
+	return unstructured.SetNestedMap(desired.Object, podSpec, path...)
+}
+
+// guardForklift applies the policy to the per-component resource fields of a ForkliftController
+func (g *resourceGuardrails) guardForklift(desired *unstructured.Unstructured) error {
+	spec, _, err := unstructured.NestedMap(desired.Object, "spec")
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		spec = map[string]any{}
+	}
+
+	var violations []string
+	for _, component := range forkliftComponents {
+		for _, kind := range []string{"requests", "limits"} {
+			values := map[string]any{}
+			for name := range forkliftResources {
+				if value, ok := spec[forkliftField(component, kind, name)]; ok {
+					values[name] = value
+				}
+			}
+			for _, violation := range g.guardValues(kind, values, forkliftResources) {
+				violations = append(violations, fmt.Sprintf("%s: %s", component, violation))
+			}
+			for name, value := range values {
+				spec[forkliftField(component, kind, name)] = value
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return guardrailError(desired, violations)
+	}
+	return unstructured.SetNestedMap(desired.Object, spec, "spec")
+}
+
+func forkliftField(component, kind, name string) string {
+	return fmt.Sprintf("%s_container_%s_%s", component, kind, name)
+}
+
+// guardValues fills in (inject) or checks (validate) the policy's values of kind
+// ("requests" or "limits") in values and returns the violations found. When supported
+// is set, policy resources outside it are ignored.
+func (g *resourceGuardrails) guardValues(kind string, values map[string]any, supported map[string]bool) []string {
+	policy := g.requests
+	if kind == "limits" {
+		policy = g.limits
+	}
+
+	names := make([]string, 0, len(policy))
+	for name := range policy {
+		if supported != nil && !supported[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []string
+	for _, name := range names {
+		bound := policy[name]
+		current, present := values[name]
+		if !present {
+			if g.validate {
+				violations = append(violations, fmt.Sprintf("no %s.%s", kind, name))
+			} else {
+				values[name] = bound.String()
+			}
+			continue
+		}
+		if !g.validate || kind != "limits" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(fmt.Sprint(current))
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid %s.%s %v", kind, name, current))
+			continue
+		}
+		if quantity.Cmp(bound) > 0 {
+			violations = append(violations, fmt.Sprintf("%s.%s %s exceeds policy %s", kind, name, quantity.String(), bound.String()))
+		}
+	}
+	return violations
+}
+
+func guardrailError(desired *unstructured.Unstructured, violations []string) error {
+	return fmt.Errorf("%s %s violates resource guardrails: %s", desired.GetKind(), desired.GetName(), strings.Join(violations, "; "))
+}
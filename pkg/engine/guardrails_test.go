@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newGuardrails(t *testing.T, raw string) Mutator {
+	t.Helper()
+	m, err := newResourceGuardrailsMutator(json.RawMessage(raw))
+	if err != nil {
+		t.Fatalf("newResourceGuardrailsMutator() error = %v", err)
+	}
+	return m
+}
+
+func guardedDaemonSet(containers ...any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "DaemonSet",
+		"metadata":   map[string]any{"name": "exporter", "namespace": "openshift-cnv"},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{"containers": containers},
+			},
+		},
+	}}
+}
+
+func TestResourceGuardrailsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{name: "empty policy", raw: `{}`, wantErr: "at least one of priorityClassName, requests or limits is required"},
+		{name: "unknown mode", raw: `{"mode":"clamp","limits":{"memory":"1Gi"}}`, wantErr: `unknown mode "clamp"`},
+		{name: "invalid quantity", raw: `{"limits":{"memory":"lots"}}`, wantErr: `invalid limits.memory "lots"`},
+		{name: "request above limit", raw: `{"requests":{"memory":"2Gi"},"limits":{"memory":"1Gi"}}`, wantErr: "requests.memory 2Gi exceeds limits.memory 1Gi"},
+		{name: "valid", raw: `{"mode":"validate","priorityClassName":"openshift-user-critical"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newResourceGuardrailsMutator(json.RawMessage(tt.raw))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceGuardrailsInjectPodSpec(t *testing.T) {
+	m := newGuardrails(t, `{"priorityClassName":"openshift-user-critical","requests":{"cpu":"10m","memory":"64Mi"},"limits":{"memory":"512Mi"}}`)
+
+	desired := guardedDaemonSet(
+		map[string]any{"name": "bare"},
+		map[string]any{"name": "sized", "resources": map[string]any{
+			"requests": map[string]any{"memory": "128Mi"},
+			"limits":   map[string]any{"memory": "2Gi"},
+		}},
+	)
+	if err := m.Mutate(context.Background(), nil, desired, nil); err != nil {
+		t.Fatalf("Mutate() error = %v", err)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(desired.Object, "spec", "template", "spec", "containers")
+	want := []any{
+		map[string]any{"name": "bare", "resources": map[string]any{
+			"requests": map[string]any{"cpu": "10m", "memory": "64Mi"},
+			"limits":   map[string]any{"memory": "512Mi"},
+		}},
+		// Values set by the template are kept, even above the policy
+		map[string]any{"name": "sized", "resources": map[string]any{
+			"requests": map[string]any{"cpu": "10m", "memory": "128Mi"},
+			"limits":   map[string]any{"memory": "2Gi"},
+		}},
+	}
+	if !reflect.DeepEqual(containers, want) {
+		t.Errorf("containers = %v, want %v", containers, want)
+	}
+	priority, _, _ := unstructured.NestedString(desired.Object, "spec", "template", "spec", "priorityClassName")
+	if priority != "openshift-user-critical" {
+		t.Errorf("priorityClassName = %q, want openshift-user-critical", priority)
+	}
+}
+
+func TestResourceGuardrailsValidatePodSpec(t *testing.T) {
+	m := newGuardrails(t, `{"mode":"validate","priorityClassName":"openshift-user-critical","requests":{"memory":"64Mi"},"limits":{"memory":"512Mi"}}`)
+
+	desired := guardedDaemonSet(
+		map[string]any{"name": "bare"},
+		map[string]any{"name": "large", "resources": map[string]any{
+			"requests": map[string]any{"memory": "128Mi"},
+			"limits":   map[string]any{"memory": "1Gi"},
+		}},
+	)
+	before := desired.DeepCopy()
+	err := m.Mutate(context.Background(), nil, desired, nil)
+	if err == nil {
+		t.Fatal("expected a guardrail violation")
+	}
+	for _, want := range []string{
+		"DaemonSet exporter violates resource guardrails",
+		`priorityClassName is "", policy requires "openshift-user-critical"`,
+		"container bare: no requests.memory",
+		"container bare: no limits.memory",
+		"container large: limits.memory 1Gi exceeds policy 512Mi",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+	if !reflect.DeepEqual(desired, before) {
+		t.Error("validate mode must not modify the object")
+	}
+
+	compliant := guardedDaemonSet(map[string]any{"name": "ok", "resources": map[string]any{
+		"requests": map[string]any{"memory": "64Mi"},
+		"limits":   map[string]any{"memory": "256Mi"},
+	}})
+	if err := unstructured.SetNestedField(compliant.Object, "openshift-user-critical", "spec", "template", "spec", "priorityClassName"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Mutate(context.Background(), nil, compliant, nil); err != nil {
+		t.Errorf("compliant object rejected: %v", err)
+	}
+}
+
+func TestResourceGuardrailsForklift(t *testing.T) {
+	forklift := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "forklift.konveyor.io/v1beta1",
+			"kind":       "ForkliftController",
+			"metadata":   map[string]any{"name": "forklift-controller", "namespace": "openshift-mtv"},
+			"spec": map[string]any{
+				"feature_ui":                        true,
+				"inventory_container_limits_memory": "4Gi",
+			},
+		}}
+	}
+
+	inject := newGuardrails(t, `{"limits":{"memory":"1Gi","ephemeral-storage":"1Gi"}}`)
+	desired := forklift()
+	if err := inject.Mutate(context.Background(), nil, desired, nil); err != nil {
+		t.Fatalf("Mutate() error = %v", err)
+	}
+	spec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	if spec["controller_container_limits_memory"] != "1Gi" || spec["validation_container_limits_memory"] != "1Gi" {
+		t.Errorf("memory limits not injected: %v", spec)
+	}
+	if spec["inventory_container_limits_memory"] != "4Gi" {
+		t.Errorf("existing limit changed: %v", spec["inventory_container_limits_memory"])
+	}
+	if _, ok := spec["controller_container_limits_ephemeral-storage"]; ok {
+		t.Error("resources the ForkliftController cannot bound must be ignored")
+	}
+
+	validate := newGuardrails(t, `{"mode":"validate","limits":{"memory":"8Gi"}}`)
+	err := validate.Mutate(context.Background(), nil, forklift(), nil)
+	if err == nil || !strings.Contains(err.Error(), "controller: no limits.memory") || strings.Contains(err.Error(), "inventory:") {
+		t.Errorf("unexpected validation result: %v", err)
+	}
+}
+
+func TestResourceGuardrailsIgnoresOtherKinds(t *testing.T) {
+	m := newGuardrails(t, `{"mode":"validate","limits":{"memory":"1Gi"}}`)
+	uiPlugin := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "observability.openshift.io/v1alpha1",
+		"kind":       "UIPlugin",
+		"metadata":   map[string]any{"name": "monitoring"},
+		"spec":       map[string]any{"type": "Monitoring"},
+	}}
+	if err := m.Mutate(context.Background(), nil, uiPlugin, nil); err != nil {
+		t.Errorf("UIPlugin has no resource fields and must be skipped: %v", err)
+	}
+}
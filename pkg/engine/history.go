@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultRenderHistorySize is how many applied versions are kept per asset
+	DefaultRenderHistorySize = 5
+	// DefaultRenderHistoryConfigMap is the conventional name of the ConfigMap the
+	// history is persisted in, and the one the rollback command reads by default
+	DefaultRenderHistoryConfigMap = "virt-platform-autopilot-render-history"
+	// historyKeySuffix is appended to the asset name to form its ConfigMap key
+	historyKeySuffix = ".yaml"
+)
+
+// HistoryEntry is one version of an asset as the autopilot applied it
+type HistoryEntry struct {
+	// Revision increases with every distinct version of the asset
+	Revision  int64                      `json:"revision"`
+	AppliedAt metav1.Time                `json:"appliedAt"`
+	Object    *unstructured.Unstructured `json:"object"`
+}
+
+// RenderHistory keeps the last versions of every asset the autopilot applied, so a
+// bad template rollout can be reverted by hand without downgrading the operator.
+// Re-applying an unchanged version (drift correction) adds no entry.
+//
+// The history lives in memory and, when a ConfigMap is configured, is mirrored to
+// one key per asset so it survives restarts and is readable by the rollback command.
+type RenderHistory struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string][]HistoryEntry // per asset, oldest first
+
+	writer    client.Client
+	reader    client.Reader
+	configMap client.ObjectKey
+}
+
+// NewRenderHistory returns an in-memory history keeping size versions per asset
+func NewRenderHistory(size int) *RenderHistory {
+	return &RenderHistory{
+		size:    size,
+		entries: make(map[string][]HistoryEntry),
+	}
+}
+
+// SetConfigMap mirrors the history to the named ConfigMap. The reader should bypass
+// the cache, since the ConfigMap is read before the cache is started.
+func (h *RenderHistory) SetConfigMap(writer client.Client, reader client.Reader, namespace, name string) {
+	h.writer = writer
+	h.reader = reader
+	h.configMap = client.ObjectKey{Namespace: namespace, Name: name}
+}
+
+// Load replaces the in-memory history with the one persisted in the ConfigMap.
+// A missing ConfigMap is not an error: it is created on the first apply.
+func (h *RenderHistory) Load(ctx context.Context) error {
+	if h.reader == nil {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := h.reader.Get(ctx, h.configMap, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read render history ConfigMap %s: %w", h.configMap, err)
+	}
+	entries, err := ParseRenderHistory(cm)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for asset, list := range entries {
+		if len(list) > h.size {
+			list = list[len(list)-h.size:]
+		}
+		h.entries[asset] = list
+	}
+	return nil
+}
+
+// Record adds obj as the newest version of asset unless it equals the newest one.
+// A failure to persist the entry is logged: it never fails the apply it records.
+func (h *RenderHistory) Record(ctx context.Context, asset string, obj *unstructured.Unstructured) {
+	if h.size <= 0 {
+		return
+	}
+	object := &unstructured.Unstructured{Object: sanitizeObject(obj)}
+
+	h.mu.Lock()
+	list := h.entries[asset]
+	revision := int64(1)
+	if n := len(list); n > 0 {
+		if equality.Semantic.DeepEqual(list[n-1].Object.Object, object.Object) {
+			h.mu.Unlock()
+			return
+		}
+		revision = list[n-1].Revision + 1
+	}
+	list = append(list, HistoryEntry{Revision: revision, AppliedAt: metav1.NewTime(time.Now()), Object: object})
+	if len(list) > h.size {
+		list = list[len(list)-h.size:]
+	}
+	h.entries[asset] = list
+	persisted := append([]HistoryEntry(nil), list...)
+	h.mu.Unlock()
+
+	if h.writer == nil {
+		return
+	}
+	if err := h.persist(ctx, asset, persisted); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to persist render history", "asset", asset, "configMap", h.configMap.String())
+	}
+}
+
+// Entries returns the recorded versions of asset, newest first
+func (h *RenderHistory) Entries(asset string) []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	list := h.entries[asset]
+	out := make([]HistoryEntry, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		out = append(out, list[i])
+	}
+	return out
+}
+
+// Assets returns the sorted names of the assets with a recorded version
+func (h *RenderHistory) Assets() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	names := make([]string, 0, len(h.entries))
+	for name := range h.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// persist writes the versions of asset to its ConfigMap key
+func (h *RenderHistory) persist(ctx context.Context, asset string, entries []HistoryEntry) error {
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history of %s: %w", asset, err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := h.reader.Get(ctx, h.configMap, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: h.configMap.Namespace, Name: h.configMap.Name},
+			Data:       map[string]string{asset + historyKeySuffix: string(data)},
+		}
+		return h.writer.Create(ctx, cm)
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[asset+historyKeySuffix] = string(data)
+	return h.writer.Update(ctx, cm)
+}
+
+// ParseRenderHistory decodes the per-asset versions stored in a render history
+// ConfigMap, oldest first
+func ParseRenderHistory(cm *corev1.ConfigMap) (map[string][]HistoryEntry, error) {
+	entries := make(map[string][]HistoryEntry, len(cm.Data))
+	for key, value := range cm.Data {
+		asset, ok := strings.CutSuffix(key, historyKeySuffix)
+		if !ok {
+			continue
+		}
+		var list []HistoryEntry
+		if err := yaml.Unmarshal([]byte(value), &list); err != nil {
+			return nil, fmt.Errorf("render history ConfigMap %s/%s: key %s: %w", cm.Namespace, cm.Name, key, err)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Revision < list[j].Revision })
+		entries[asset] = list
+	}
+	return entries, nil
+}
+
+// SetRenderHistory records every applied version of an asset in history
+func (p *Patcher) SetRenderHistory(history *RenderHistory) {
+	p.history = history
+}
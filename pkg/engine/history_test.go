@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newHistoryObject(maxPods int64) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	obj.SetKind("KubeletConfig")
+	obj.SetName("virt-kubelet")
+	obj.SetResourceVersion("42")
+	_ = unstructured.SetNestedField(obj.Object, maxPods, "spec", "kubeletConfig", "maxPods")
+	return obj
+}
+
+func TestRenderHistoryRecord(t *testing.T) {
+	ctx := context.Background()
+	h := NewRenderHistory(2)
+
+	h.Record(ctx, "kubelet", newHistoryObject(250))
+	h.Record(ctx, "kubelet", newHistoryObject(250)) // drift correction: same version
+	if got := len(h.Entries("kubelet")); got != 1 {
+		t.Fatalf("expected re-applying the same version to add no entry, got %d entries", got)
+	}
+
+	h.Record(ctx, "kubelet", newHistoryObject(300))
+	h.Record(ctx, "kubelet", newHistoryObject(350))
+
+	entries := h.Entries("kubelet")
+	if len(entries) != 2 {
+		t.Fatalf("expected the history to be bounded to 2 entries, got %d", len(entries))
+	}
+	if entries[0].Revision != 3 || entries[1].Revision != 2 {
+		t.Errorf("expected revisions 3, 2 newest first, got %d, %d", entries[0].Revision, entries[1].Revision)
+	}
+	maxPods, _, _ := unstructured.NestedInt64(entries[0].Object.Object, "spec", "kubeletConfig", "maxPods")
+	if maxPods != 350 {
+		t.Errorf("expected the newest entry to hold maxPods 350, got %d", maxPods)
+	}
+	if entries[0].Object.GetResourceVersion() != "" {
+		t.Error("expected server-populated metadata to be dropped from recorded objects")
+	}
+	if assets := h.Assets(); len(assets) != 1 || assets[0] != "kubelet" {
+		t.Errorf("expected assets [kubelet], got %v", assets)
+	}
+}
+
+func TestRenderHistoryDisabled(t *testing.T) {
+	h := NewRenderHistory(0)
+	h.Record(context.Background(), "kubelet", newHistoryObject(250))
+	if len(h.Assets()) != 0 {
+		t.Error("expected a zero-size history to record nothing")
+	}
+}
+
+func TestRenderHistoryConfigMap(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+
+	h := NewRenderHistory(5)
+	h.SetConfigMap(c, c, "openshift-cnv", DefaultRenderHistoryConfigMap)
+	if err := h.Load(ctx); err != nil {
+		t.Fatalf("expected a missing ConfigMap to load as an empty history, got %v", err)
+	}
+	h.Record(ctx, "kubelet", newHistoryObject(250))
+	h.Record(ctx, "kubelet", newHistoryObject(300))
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "openshift-cnv", Name: DefaultRenderHistoryConfigMap}, cm); err != nil {
+		t.Fatalf("expected the history ConfigMap to be created: %v", err)
+	}
+	if _, ok := cm.Data["kubelet.yaml"]; !ok {
+		t.Fatalf("expected key kubelet.yaml, got %v", cm.Data)
+	}
+
+	// A restarted controller continues from the persisted revisions
+	restarted := NewRenderHistory(5)
+	restarted.SetConfigMap(c, c, "openshift-cnv", DefaultRenderHistoryConfigMap)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("failed to load history: %v", err)
+	}
+	restarted.Record(ctx, "kubelet", newHistoryObject(350))
+
+	entries := restarted.Entries("kubelet")
+	if len(entries) != 3 || entries[0].Revision != 3 {
+		t.Fatalf("expected 3 entries with revision 3 newest, got %+v", entries)
+	}
+}
+
+func TestParseRenderHistoryInvalid(t *testing.T) {
+	cm := &corev1.ConfigMap{Data: map[string]string{
+		"kubelet.yaml": "not: [a list",
+		"README":       "ignored",
+	}}
+	if _, err := ParseRenderHistory(cm); err == nil {
+		t.Error("expected a malformed history key to be rejected")
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// imageLookupTimeout bounds a single ImageStream lookup during rendering
+const imageLookupTimeout = 10 * time.Second
+
+// imageStreamListGVK is the OpenShift ImageStream list kind
+var imageStreamListGVK = schema.GroupVersionKind{Group: "image.openshift.io", Version: "v1", Kind: "ImageStreamList"}
+
+// ImageResolver pins image references to digests at render time
+type ImageResolver interface {
+	// Resolve returns the digest reference (repository@sha256:...) for ref,
+	// or "" when the resolver does not know ref.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// PinnedImage is one image field of a rendered object.
+// Digest is empty when the reference could not be pinned.
+type PinnedImage struct {
+	Path   string `json:"path" yaml:"path"`
+	Source string `json:"source" yaml:"source"`
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
+}
+
+// ImageMapping is a static tag-to-digest mapping, e.g.
+//
+//	quay.io/kubevirt/metrics-exporter:v0.3: quay.io/kubevirt/metrics-exporter@sha256:...
+type ImageMapping map[string]string
+
+// LoadImageMapping reads an ImageMapping from a YAML or JSON file.
+// Every value must be a digest reference.
+func LoadImageMapping(path string) (ImageMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image mapping: %w", err)
+	}
+
+	mapping := ImageMapping{}
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse image mapping %s: %w", path, err)
+	}
+	for source, pinned := range mapping {
+		if !isDigestReference(pinned) {
+			return nil, fmt.Errorf("image mapping %s: %q maps to %q, which is not a digest reference", path, source, pinned)
+		}
+	}
+	return mapping, nil
+}
+
+// Resolve implements ImageResolver
+func (m ImageMapping) Resolve(_ context.Context, ref string) (string, error) {
+	return m[ref], nil
+}
+
+// ImageStreamResolver pins references that an ImageStream in namespace tracks:
+// a spec tag importing ref from DockerImage is resolved to the digest of its latest
+// imported item, keeping the original repository so the result can be mirrored.
+type ImageStreamResolver struct {
+	client    client.Reader
+	namespace string
+}
+
+// NewImageStreamResolver creates a resolver that looks up ImageStreams in namespace
+func NewImageStreamResolver(c client.Reader, namespace string) *ImageStreamResolver {
+	return &ImageStreamResolver{client: c, namespace: namespace}
+}
+
+// Resolve implements ImageResolver
+func (r *ImageStreamResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(imageStreamListGVK)
+	if err := r.client.List(ctx, list, client.InNamespace(r.namespace)); err != nil {
+		return "", fmt.Errorf("failed to list ImageStreams in %s: %w", r.namespace, err)
+	}
+
+	for i := range list.Items {
+		stream := &list.Items[i]
+		specTags, _, _ := unstructured.NestedSlice(stream.Object, "spec", "tags")
+		for _, specTag := range specTags {
+			tag, _ := specTag.(map[string]any)
+			kind, _, _ := unstructured.NestedString(tag, "from", "kind")
+			name, _, _ := unstructured.NestedString(tag, "from", "name")
+			if kind != "DockerImage" || name != ref {
+				continue
+			}
+			tagName, _, _ := unstructured.NestedString(tag, "name")
+			if digest := latestImportedDigest(stream, tagName); digest != "" {
+				return imageRepository(ref) + "@" + digest, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// latestImportedDigest returns the image digest of the newest status item of tag
+func latestImportedDigest(stream *unstructured.Unstructured, tag string) string {
+	statusTags, _, _ := unstructured.NestedSlice(stream.Object, "status", "tags")
+	for _, statusTag := range statusTags {
+		st, _ := statusTag.(map[string]any)
+		if st["tag"] != tag {
+			continue
+		}
+		items, _, _ := unstructured.NestedSlice(st, "items")
+		if len(items) == 0 {
+			return ""
+		}
+		item, _ := items[0].(map[string]any)
+		digest, _ := item["image"].(string)
+		return digest
+	}
+	return ""
+}
+
+// ImageResolvers tries each resolver in order and returns the first digest found
+type ImageResolvers []ImageResolver
+
+// Resolve implements ImageResolver
+func (rs ImageResolvers) Resolve(ctx context.Context, ref string) (string, error) {
+	for _, r := range rs {
+		pinned, err := r.Resolve(ctx, ref)
+		if err != nil || pinned != "" {
+			return pinned, err
+		}
+	}
+	return "", nil
+}
+
+// imagePinner rewrites image fields of rendered objects and remembers what it pinned,
+// so callers can report the source of every digest in the output
+type imagePinner struct {
+	resolver ImageResolver
+	mu       sync.Mutex
+	sources  map[string]string // pinned reference -> source reference
+}
+
+// SetImageResolver enables digest pinning: every "image" field of a rendered object
+// whose reference the resolver knows is replaced by its digest reference.
+// Unknown references are left as rendered.
+func (r *Renderer) SetImageResolver(resolver ImageResolver) {
+	r.images = &imagePinner{resolver: resolver, sources: make(map[string]string)}
+}
+
+// SetImageResolver enables digest pinning on the patcher's renderer
+func (p *Patcher) SetImageResolver(resolver ImageResolver) {
+	p.renderer.SetImageResolver(resolver)
+}
+
+// pinImages rewrites the image fields of obj in place
+func (p *imagePinner) pinImages(obj *unstructured.Unstructured) error {
+	if p == nil || obj == nil {
+		return nil
+	}
+
+	var walkErr error
+	walkImageFields(obj.Object, "", func(_ string, fields map[string]any, key, ref string) {
+		if walkErr != nil || isDigestReference(ref) {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), imageLookupTimeout)
+		defer cancel()
+		pinned, err := p.resolver.Resolve(ctx, ref)
+		if err != nil {
+			walkErr = fmt.Errorf("failed to resolve image %s: %w", ref, err)
+			return
+		}
+		if pinned == "" {
+			return
+		}
+		fields[key] = pinned
+		p.mu.Lock()
+		p.sources[pinned] = ref
+		p.mu.Unlock()
+	})
+	return walkErr
+}
+
+// PinnedImages lists the image fields of a rendered object, sorted by path, with the
+// reference each one was rendered from. Digest is set for pinned and already-digest
+// references, so the result is a complete list of what a mirror has to carry.
+func (r *Renderer) PinnedImages(obj *unstructured.Unstructured) []PinnedImage {
+	if obj == nil {
+		return nil
+	}
+
+	var images []PinnedImage
+	walkImageFields(obj.Object, "", func(path string, _ map[string]any, _, ref string) {
+		image := PinnedImage{Path: path, Source: ref}
+		if isDigestReference(ref) {
+			image.Digest = ref
+			if r.images != nil {
+				r.images.mu.Lock()
+				if source, ok := r.images.sources[ref]; ok {
+					image.Source = source
+				}
+				r.images.mu.Unlock()
+			}
+		}
+		images = append(images, image)
+	})
+
+	sort.Slice(images, func(i, j int) bool { return images[i].Path < images[j].Path })
+	return images
+}
+
+// walkImageFields calls fn for every string field named "image" in obj, which covers
+// pod templates (containers, initContainers) as well as CR fields such as spec.image
+func walkImageFields(obj map[string]any, path string, fn func(path string, fields map[string]any, key, ref string)) {
+	for key, value := range obj {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			if key == "image" && v != "" {
+				fn(fieldPath, obj, key, v)
+			}
+		case map[string]any:
+			walkImageFields(v, fieldPath, fn)
+		case []any:
+			for i, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					walkImageFields(m, fmt.Sprintf("%s[%d]", fieldPath, i), fn)
+				}
+			}
+		}
+	}
+}
+
+// isDigestReference reports whether ref is pinned by digest (repository@algorithm:hex)
+func isDigestReference(ref string) bool {
+	_, digest, ok := strings.Cut(ref, "@")
+	return ok && strings.Contains(digest, ":")
+}
+
+// imageRepository strips the tag from ref ("quay.io/org/img:v1" -> "quay.io/org/img").
+// A colon before the last slash is a registry port, not a tag.
+func imageRepository(ref string) string {
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i]
+	}
+	return ref
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const (
+	testExporterTag    = "quay.io/kubevirt/metrics-exporter:v0.3"
+	testExporterDigest = "quay.io/kubevirt/metrics-exporter@sha256:0123456789abcdef"
+)
+
+func TestLoadImageMapping(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte(testExporterTag+": "+testExporterDigest+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := LoadImageMapping(valid)
+	if err != nil {
+		t.Fatalf("LoadImageMapping() error = %v", err)
+	}
+	if got, _ := mapping.Resolve(context.Background(), testExporterTag); got != testExporterDigest {
+		t.Errorf("Resolve() = %q, want %q", got, testExporterDigest)
+	}
+
+	tagOnly := filepath.Join(dir, "tag.yaml")
+	if err := os.WriteFile(tagOnly, []byte(testExporterTag+": quay.io/kubevirt/metrics-exporter:latest\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadImageMapping(tagOnly); err == nil || !strings.Contains(err.Error(), "not a digest reference") {
+		t.Errorf("LoadImageMapping() error = %v, want a digest reference error", err)
+	}
+}
+
+// TestRenderAssetPinsImages verifies that a rendered DaemonSet is pinned by digest
+// and that PinnedImages reports the tag it was rendered with.
+func TestRenderAssetPinsImages(t *testing.T) {
+	renderer := NewRenderer(pkgassets.NewLoader())
+	renderer.SetImageResolver(ImageMapping{testExporterTag: testExporterDigest})
+
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+	renderCtx.Images["kubevirt-metrics-exporter"] = testExporterTag
+
+	obj, err := renderer.RenderAsset(&pkgassets.AssetMetadata{
+		Name: "metrics-exporter",
+		Path: "active/metrics-exporter/metrics-exporter.yaml.tpl",
+	}, renderCtx)
+	if err != nil {
+		t.Fatalf("RenderAsset() error = %v", err)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if image := containers[0].(map[string]any)["image"]; image != testExporterDigest {
+		t.Errorf("container image = %v, want %s", image, testExporterDigest)
+	}
+
+	images := renderer.PinnedImages(obj)
+	want := PinnedImage{Path: "spec.template.spec.containers[0].image", Source: testExporterTag, Digest: testExporterDigest}
+	if len(images) != 1 || images[0] != want {
+		t.Errorf("PinnedImages() = %+v, want [%+v]", images, want)
+	}
+}
+
+func TestPinnedImagesWithoutResolver(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"image": "registry.example.com:5000/app@sha256:abc",
+			"initContainers": []any{
+				map[string]any{"name": "init", "image": "registry.example.com:5000/init:v1"},
+			},
+		},
+	}}
+
+	images := NewRenderer(pkgassets.NewLoader()).PinnedImages(obj)
+	want := []PinnedImage{
+		{Path: "spec.image", Source: "registry.example.com:5000/app@sha256:abc", Digest: "registry.example.com:5000/app@sha256:abc"},
+		{Path: "spec.initContainers[0].image", Source: "registry.example.com:5000/init:v1"},
+	}
+	if len(images) != len(want) {
+		t.Fatalf("PinnedImages() = %+v, want %+v", images, want)
+	}
+	for i := range want {
+		if images[i] != want[i] {
+			t.Errorf("PinnedImages()[%d] = %+v, want %+v", i, images[i], want[i])
+		}
+	}
+}
+
+func TestImageStreamResolver(t *testing.T) {
+	stream := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "image.openshift.io/v1",
+		"kind":       "ImageStream",
+		"metadata":   map[string]any{"name": "metrics-exporter", "namespace": "openshift-cnv"},
+		"spec": map[string]any{
+			"tags": []any{
+				map[string]any{"name": "v0.3", "from": map[string]any{"kind": "DockerImage", "name": testExporterTag}},
+			},
+		},
+		"status": map[string]any{
+			"tags": []any{
+				map[string]any{"tag": "v0.3", "items": []any{
+					map[string]any{"image": "sha256:0123456789abcdef"},
+					map[string]any{"image": "sha256:older"},
+				}},
+			},
+		},
+	}}
+	resolver := NewImageStreamResolver(fake.NewClientBuilder().WithObjects(stream).Build(), "openshift-cnv")
+
+	got, err := resolver.Resolve(context.Background(), testExporterTag)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got != testExporterDigest {
+		t.Errorf("Resolve() = %q, want %q", got, testExporterDigest)
+	}
+
+	if got, _ := resolver.Resolve(context.Background(), "quay.io/other:v1"); got != "" {
+		t.Errorf("Resolve() of an untracked image = %q, want empty", got)
+	}
+}
+
+func TestImageRepository(t *testing.T) {
+	tests := map[string]string{
+		"quay.io/org/img:v1":               "quay.io/org/img",
+		"registry.example.com:5000/img:v1": "registry.example.com:5000/img",
+		"registry.example.com:5000/img":    "registry.example.com:5000/img",
+		"img":                              "img",
+	}
+	for ref, want := range tests {
+		if got := imageRepository(ref); got != want {
+			t.Errorf("imageRepository(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
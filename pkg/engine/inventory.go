@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+// ObjectState is the outcome of the last reconcile of an asset's object
+type ObjectState string
+
+const (
+	// ObjectInSync means the live object matches the desired state
+	ObjectInSync ObjectState = "InSync"
+	// ObjectApplied means the object was created or its drift corrected
+	ObjectApplied ObjectState = "Applied"
+	// ObjectPending means a needed apply was held back: maintenance window, upgrade
+	// safe-mode, blast radius guard or a missing target namespace
+	ObjectPending ObjectState = "Pending"
+	// ObjectUnmanaged means the object opted out with mode: unmanaged
+	ObjectUnmanaged ObjectState = "Unmanaged"
+	// ObjectPaused means reconciliation was paused after an edit war
+	ObjectPaused ObjectState = "Paused"
+	// ObjectDelegated means the object was handed over to a GitOps tool with delegate-to
+	ObjectDelegated ObjectState = "Delegated"
+	// ObjectExcluded means the HCO's disabled-resources annotation or an AutopilotExclusion
+	// excludes the object, or another HCO applies it
+	ObjectExcluded ObjectState = "Excluded"
+	// ObjectFailed means the asset failed to reconcile
+	ObjectFailed ObjectState = "Failed"
+)
+
+// ObjectReport is the outcome of reconciling the object of one asset
+type ObjectReport struct {
+	Asset      string
+	Component  string
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	State      ObjectState
+	// Reason says why the object is in State: the failure reason of an ObjectFailed
+	// report, or why it is excluded, left alone or pending; empty when there is
+	// nothing more to say
+	Reason  Reason
+	Message string
+	// Modifiers are the field managers whose changes an ObjectApplied report reverted
+	Modifiers []FieldModifier
+}
+
+// InventorySink receives the outcome of every asset of a ReconcileAssets pass.
+// An asset that renders to an object is reported through ObjectReconciled, possibly
+// several times as the pass progresses; the last report wins. AssetFailed follows
+// when the asset's reconcile returned an error, also for assets that failed before
+// their object was known.
+type InventorySink interface {
+	ObjectReconciled(hco *unstructured.Unstructured, report ObjectReport)
+	AssetFailed(hco *unstructured.Unstructured, asset string, err error)
+}
+
+// SetInventorySink sets the sink the outcome of every reconciled asset is reported to
+func (p *Patcher) SetInventorySink(sink InventorySink) {
+	p.inventory = sink
+}
+
+// reportObject reports the state of desired, the object of assetMeta, to the sink
+func (p *Patcher) reportObject(renderCtx *pkgcontext.RenderContext, assetMeta *assets.AssetMetadata,
+	desired *unstructured.Unstructured, state ObjectState, reason Reason, message string) {
+	p.reportCorrection(renderCtx, assetMeta, desired, state, reason, message, nil)
+}
+
+// reportCorrection is reportObject for an apply that reverted the changes of modifiers
+func (p *Patcher) reportCorrection(renderCtx *pkgcontext.RenderContext, assetMeta *assets.AssetMetadata,
+	desired *unstructured.Unstructured, state ObjectState, reason Reason, message string, modifiers []FieldModifier) {
+	if p.inventory == nil || renderCtx.HCO == nil {
+		return
+	}
+	p.inventory.ObjectReconciled(renderCtx.HCO, ObjectReport{
+		Asset:      assetMeta.Name,
+		Component:  assetMeta.Component,
+		APIVersion: desired.GetAPIVersion(),
+		Kind:       desired.GetKind(),
+		Namespace:  desired.GetNamespace(),
+		Name:       desired.GetName(),
+		State:      state,
+		Reason:     reason,
+		Message:    message,
+		Modifiers:  modifiers,
+	})
+}
+
+// ReportAssetError classifies the error asset failed to reconcile with and reports it
+// through the error metric, an event on the HCO and the inventory sink
+func (p *Patcher) ReportAssetError(renderCtx *pkgcontext.RenderContext, asset string, err error) ErrorReason {
+	reason := AssetErrorReason(err)
+	observability.IncAssetError(asset, string(reason))
+	if renderCtx.HCO == nil {
+		return reason
+	}
+	if p.eventRecorder != nil {
+		p.eventRecorder.AssetFailed(renderCtx.HCO, asset, string(reason), err.Error())
+	}
+	if p.inventory != nil {
+		p.inventory.AssetFailed(renderCtx.HCO, asset, err)
+	}
+	return reason
+}
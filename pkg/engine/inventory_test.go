@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+// recordingSink keeps the last report of every asset, as the controller's exporter does
+type recordingSink struct {
+	reports map[string]ObjectReport
+}
+
+func (s *recordingSink) ObjectReconciled(_ *unstructured.Unstructured, report ObjectReport) {
+	s.reports[report.Asset] = report
+}
+
+func (s *recordingSink) AssetFailed(_ *unstructured.Unstructured, asset string, err error) {
+	report := s.reports[asset]
+	report.Asset = asset
+	report.State = ObjectFailed
+	report.Message = err.Error()
+	s.reports[asset] = report
+}
+
+func TestInventorySink(t *testing.T) {
+	rec := eventtest.NewRecorder()
+	p := newHangingPatcher(rec)
+	p.SetApplyTimeouts(ApplyTimeouts{PerAsset: 50 * time.Millisecond, Total: time.Minute})
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged"))
+
+	_, _ = p.ReconcileAssets(context.Background(), timeoutTestAssets, renderCtx)
+
+	service := sink.reports["metrics-service"]
+	if service.State != ObjectApplied || service.Kind != "Service" || service.Component != "Service" {
+		t.Errorf("metrics-service report = %+v, want an applied Service", service)
+	}
+	psi := sink.reports["psi-enable"]
+	if psi.State != ObjectFailed || psi.Kind != "MachineConfig" || psi.Name == "" {
+		t.Errorf("psi-enable report = %+v, want a failed MachineConfig with its target", psi)
+	}
+	if psi.Message == "" {
+		t.Error("psi-enable report has no message, want the TIMEOUT error")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+// Log keys attached to every line emitted while an asset is rendered and applied.
+// "asset" follows one asset across reconciles; "assetID" isolates a single pass.
+const (
+	LogKeyReconcileID = "reconcileID"
+	LogKeyAsset       = "asset"
+	LogKeyAssetID     = "assetID"
+)
+
+// SetAssetLogFilter restricts per-asset informational logging to the named assets.
+// Names are trimmed and blank ones ignored. Errors are always logged. An empty list
+// disables the filter.
+func (p *Patcher) SetAssetLogFilter(names []string) {
+	p.assetLogFilter = nil
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if p.assetLogFilter == nil {
+			p.assetLogFilter = make(map[string]bool, len(names))
+		}
+		p.assetLogFilter[name] = true
+	}
+}
+
+// withAssetLogger returns ctx carrying a logger tagged with the asset's correlation IDs.
+// controller-runtime already tags reconciles with reconcileID; callers outside a
+// reconcile (tests, one-off tools) get a fresh one so the key is always present.
+func (p *Patcher) withAssetLogger(ctx context.Context, assetMeta *assets.AssetMetadata) (context.Context, logr.Logger) {
+	logger := log.FromContext(ctx)
+	if controller.ReconcileIDFromContext(ctx) == "" {
+		logger = logger.WithValues(LogKeyReconcileID, uuid.NewUUID())
+	}
+	logger = logger.WithValues(LogKeyAsset, assetMeta.Name, LogKeyAssetID, uuid.NewUUID())
+
+	if p.assetLogFilter != nil && !p.assetLogFilter[assetMeta.Name] && logger.GetSink() != nil {
+		logger = logr.New(errorOnlySink{logger.GetSink()})
+	}
+
+	return log.IntoContext(ctx, logger), logger
+}
+
+// errorOnlySink drops Info lines and forwards errors, used for assets outside the log filter
+type errorOnlySink struct {
+	logr.LogSink
+}
+
+// Init is a no-op: the wrapped sink was already initialized by its own logger
+func (s errorOnlySink) Init(logr.RuntimeInfo) {}
+
+func (s errorOnlySink) Enabled(int) bool {
+	return false
+}
+
+func (s errorOnlySink) Info(int, string, ...any) {}
+
+func (s errorOnlySink) WithValues(keysAndValues ...any) logr.LogSink {
+	return errorOnlySink{s.LogSink.WithValues(keysAndValues...)}
+}
+
+func (s errorOnlySink) WithName(name string) logr.LogSink {
+	return errorOnlySink{s.LogSink.WithName(name)}
+}
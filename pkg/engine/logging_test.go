@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+)
+
+// captureLogs returns a context whose logger appends every formatted line to lines
+func captureLogs(lines *[]string) context.Context {
+	logger := funcr.New(func(prefix, args string) {
+		*lines = append(*lines, args)
+	}, funcr.Options{Verbosity: 1})
+	return log.IntoContext(context.Background(), logger)
+}
+
+func TestWithAssetLogger(t *testing.T) {
+	var lines []string
+	p := &Patcher{}
+	asset := &pkgassets.AssetMetadata{Name: "swap-enable"}
+
+	ctx, logger := p.withAssetLogger(captureLogs(&lines), asset)
+	logger.Info("direct")
+	log.FromContext(ctx).V(1).Info("from context")
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %v", len(lines), lines)
+	}
+	for _, line := range lines {
+		for _, key := range []string{`"asset"="swap-enable"`, `"assetID"=`, `"reconcileID"=`} {
+			if !strings.Contains(line, key) {
+				t.Errorf("log line %q missing %s", line, key)
+			}
+		}
+	}
+
+	// Each pass gets its own assetID
+	_, second := p.withAssetLogger(captureLogs(&lines), asset)
+	second.Info("second pass")
+	if assetIDOf(lines[0]) == assetIDOf(lines[2]) {
+		t.Errorf("expected distinct assetIDs per pass, got %s twice", assetIDOf(lines[0]))
+	}
+}
+
+func TestAssetLogFilter(t *testing.T) {
+	p := &Patcher{}
+	p.SetAssetLogFilter([]string{" swap-enable", ""})
+
+	tests := []struct {
+		asset         string
+		expectedLines int
+	}{
+		{asset: "swap-enable", expectedLines: 2},
+		// Filtered assets keep errors only
+		{asset: "pci-passthrough", expectedLines: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.asset, func(t *testing.T) {
+			var lines []string
+			ctx, _ := p.withAssetLogger(captureLogs(&lines), &pkgassets.AssetMetadata{Name: tt.asset})
+			logger := log.FromContext(ctx).WithValues("extra", "value")
+			logger.Info("info line")
+			logger.Error(errors.New("boom"), "error line")
+
+			if len(lines) != tt.expectedLines {
+				t.Fatalf("expected %d log lines, got %d: %v", tt.expectedLines, len(lines), lines)
+			}
+			if !strings.Contains(lines[len(lines)-1], `"error"="boom"`) {
+				t.Errorf("expected error line to be kept, got %q", lines[len(lines)-1])
+			}
+		})
+	}
+
+	// Clearing the filter, or leaving only blank names in it, logs every asset again
+	for _, names := range [][]string{nil, {"", " "}} {
+		p.SetAssetLogFilter(names)
+		var lines []string
+		_, logger := p.withAssetLogger(captureLogs(&lines), &pkgassets.AssetMetadata{Name: "pci-passthrough"})
+		logger.Info("info line")
+		if len(lines) != 1 {
+			t.Errorf("expected info line after setting filter %q, got %v", names, lines)
+		}
+	}
+}
+
+// assetIDOf extracts the assetID value from a funcr-formatted line
+func assetIDOf(line string) string {
+	_, rest, _ := strings.Cut(line, `"assetID"="`)
+	id, _, _ := strings.Cut(rest, `"`)
+	return id
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// ManagedMetadataMutatorName is the registered name of the built-in managed metadata mutator
+const ManagedMetadataMutatorName = "managed-metadata"
+
+// reservedMetadataPrefix is the key prefix of the autopilot's own labels and annotations,
+// which a metadata policy may not set
+const reservedMetadataPrefix = "platform.kubevirt.io/"
+
+// managedMetadataConfig is the config block accepted by the managed-metadata mutator:
+//
+//	labels: {cost-center: "4711"}
+//	annotations: {example.com/team: virt-platform}
+//
+// The labels and annotations are stamped on every managed object. Keys the template
+// already sets keep the template's value.
+type managedMetadataConfig struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func init() {
+	RegisterMutator(ManagedMetadataMutatorName, newManagedMetadataMutator)
+}
+
+// newManagedMetadataMutator stamps the configured labels and annotations on every managed object
+func newManagedMetadataMutator(raw json.RawMessage) (Mutator, error) {
+	cfg := managedMetadataConfig{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.Labels) == 0 && len(cfg.Annotations) == 0 {
+		return nil, fmt.Errorf("at least one label or annotation is required")
+	}
+
+	for key, value := range cfg.Labels {
+		if err := validateMetadataKey("labels", key); err != nil {
+			return nil, err
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid labels.%s value %q: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+	for key := range cfg.Annotations {
+		if err := validateMetadataKey("annotations", key); err != nil {
+			return nil, err
+		}
+	}
+
+	return MutatorFunc(func(_ context.Context, _ *assets.AssetMetadata, desired *unstructured.Unstructured, _ *pkgcontext.RenderContext) error {
+		if labels := stampMetadata(desired.GetLabels(), cfg.Labels); labels != nil {
+			desired.SetLabels(labels)
+		}
+		if annotations := stampMetadata(desired.GetAnnotations(), cfg.Annotations); annotations != nil {
+			desired.SetAnnotations(annotations)
+		}
+		return nil
+	}), nil
+}
+
+// validateMetadataKey checks that key is a valid label or annotation key outside the autopilot's prefix
+func validateMetadataKey(field, key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid %s key %q: %s", field, key, strings.Join(errs, "; "))
+	}
+	if strings.HasPrefix(key, reservedMetadataPrefix) {
+		return fmt.Errorf("%s key %q is reserved for the autopilot", field, key)
+	}
+	return nil
+}
+
+// stampMetadata adds the policy entries missing from current and returns the result,
+// or nil when the policy is empty
+func stampMetadata(current, policy map[string]string) map[string]string {
+	if len(policy) == 0 {
+		return nil
+	}
+	if current == nil {
+		current = make(map[string]string, len(policy))
+	}
+	for key, value := range policy {
+		if _, set := current[key]; !set {
+			current[key] = value
+		}
+	}
+	return current
+}
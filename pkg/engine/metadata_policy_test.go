@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestManagedMetadataConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{name: "empty policy", raw: `{}`, wantErr: "at least one label or annotation is required"},
+		{name: "invalid label key", raw: `{"labels":{"cost center":"4711"}}`, wantErr: `invalid labels key "cost center"`},
+		{name: "invalid label value", raw: `{"labels":{"team":"virt platform"}}`, wantErr: `invalid labels.team value "virt platform"`},
+		{name: "reserved label", raw: `{"labels":{"platform.kubevirt.io/managed-by":"someone"}}`, wantErr: "is reserved for the autopilot"},
+		{name: "reserved annotation", raw: `{"annotations":{"platform.kubevirt.io/mode":"unmanaged"}}`, wantErr: "is reserved for the autopilot"},
+		{name: "annotation values are free-form", raw: `{"annotations":{"example.com/owner":"Virt Platform <virt@example.com>"}}`},
+		{name: "valid", raw: `{"labels":{"cost-center":"4711"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newManagedMetadataMutator(json.RawMessage(tt.raw))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManagedMetadataMutator(t *testing.T) {
+	m, err := newManagedMetadataMutator(json.RawMessage(
+		`{"labels":{"cost-center":"4711","team":"policy"},"annotations":{"example.com/owner":"virt"}}`))
+	if err != nil {
+		t.Fatalf("newManagedMetadataMutator() error = %v", err)
+	}
+
+	t.Run("stamps every kind", func(t *testing.T) {
+		desired := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "machineconfiguration.openshift.io/v1",
+			"kind":       "MachineConfig",
+			"metadata":   map[string]any{"name": "50-worker-kubevirt-hugepages"},
+		}}
+		if err := m.Mutate(context.Background(), nil, desired, nil); err != nil {
+			t.Fatalf("Mutate() error = %v", err)
+		}
+		wantLabels := map[string]string{"cost-center": "4711", "team": "policy"}
+		if got := desired.GetLabels(); !reflect.DeepEqual(got, wantLabels) {
+			t.Errorf("labels = %v, want %v", got, wantLabels)
+		}
+		wantAnnotations := map[string]string{"example.com/owner": "virt"}
+		if got := desired.GetAnnotations(); !reflect.DeepEqual(got, wantAnnotations) {
+			t.Errorf("annotations = %v, want %v", got, wantAnnotations)
+		}
+	})
+
+	t.Run("keeps the values the template sets", func(t *testing.T) {
+		desired := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "DaemonSet",
+			"metadata": map[string]any{
+				"name":   "exporter",
+				"labels": map[string]any{"team": "template", "app": "exporter"},
+			},
+			"spec": map[string]any{"template": map[string]any{
+				"metadata": map[string]any{"labels": map[string]any{"app": "exporter"}},
+			}},
+		}}
+		if err := m.Mutate(context.Background(), nil, desired, nil); err != nil {
+			t.Fatalf("Mutate() error = %v", err)
+		}
+		wantLabels := map[string]string{"cost-center": "4711", "team": "template", "app": "exporter"}
+		if got := desired.GetLabels(); !reflect.DeepEqual(got, wantLabels) {
+			t.Errorf("labels = %v, want %v", got, wantLabels)
+		}
+		podLabels, _, _ := unstructured.NestedStringMap(desired.Object, "spec", "template", "metadata", "labels")
+		if !reflect.DeepEqual(podLabels, map[string]string{"app": "exporter"}) {
+			t.Errorf("pod template labels = %v, want only the template's", podLabels)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// UnknownModifier stands for drift no field manager is recorded for, e.g. a field that
+// was removed, or a change made before managedFields were tracked
+const UnknownModifier = "unknown"
+
+// FieldModifier is a field manager, other than the autopilot, that owns fields of a
+// managed object where it differs from the desired state
+type FieldModifier struct {
+	Manager   string `json:"manager"`
+	Operation string `json:"operation,omitempty"`
+	// Time is when the manager last changed the object, as recorded in managedFields
+	Time *metav1.Time `json:"time,omitempty"`
+	// Fields are the dotted paths of the drifted fields it owns; list items are []
+	Fields []string `json:"fields"`
+}
+
+// DriftModifiers attributes the fields where live differs from desired to the field
+// managers of live's managedFields, sorted by manager. Only fields desired sets count:
+// additions desired does not mention are not drift server-side apply would revert.
+// Drifted fields no manager owns are attributed to UnknownModifier; those the autopilot
+// still owns are left out, as they changed with the desired state.
+func DriftModifiers(desired, live *unstructured.Unstructured) []FieldModifier {
+	drifted := driftedDesiredPaths(sanitizeObject(desired), sanitizeObject(live), "")
+	if len(drifted) == 0 {
+		return nil
+	}
+
+	attributed := make(map[string]bool)
+	var ours []string
+	var modifiers []FieldModifier
+	for _, entry := range live.GetManagedFields() {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		owned := ownedPaths(entry.FieldsV1)
+		if entry.Manager == FieldManager {
+			ours = append(ours, owned...)
+			continue
+		}
+		var fields []string
+		for _, path := range drifted {
+			if ownsPath(owned, path) {
+				fields = append(fields, path)
+				attributed[path] = true
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		modifiers = append(modifiers, FieldModifier{
+			Manager:   entry.Manager,
+			Operation: string(entry.Operation),
+			Time:      entry.Time,
+			Fields:    fields,
+		})
+	}
+
+	// A field still owned by the autopilot alone holds what it applied last: the
+	// desired state changed, nobody else did
+	var unknown []string
+	for _, path := range drifted {
+		if !attributed[path] && !ownsPath(ours, path) {
+			unknown = append(unknown, path)
+		}
+	}
+	if len(unknown) > 0 {
+		modifiers = append(modifiers, FieldModifier{Manager: UnknownModifier, Fields: unknown})
+	}
+
+	sort.SliceStable(modifiers, func(i, j int) bool { return modifiers[i].Manager < modifiers[j].Manager })
+	return modifiers
+}
+
+// FormatModifiers renders modifiers for an event message, e.g.
+// "kubectl-edit (spec.replicas), unknown (metadata.labels.app)"
+func FormatModifiers(modifiers []FieldModifier) string {
+	parts := make([]string, 0, len(modifiers))
+	for _, m := range modifiers {
+		parts = append(parts, fmt.Sprintf("%s (%s)", m.Manager, strings.Join(m.Fields, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// driftedDesiredPaths returns the sorted dotted paths of the fields desired sets that
+// live lacks or holds a different value for. Lists are compared as a whole.
+func driftedDesiredPaths(desired, live map[string]any, prefix string) []string {
+	var paths []string
+	for key, desiredVal := range desired {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		liveVal, ok := live[key]
+		desiredMap, desiredIsMap := desiredVal.(map[string]any)
+		liveMap, liveIsMap := liveVal.(map[string]any)
+		switch {
+		case ok && desiredIsMap && liveIsMap:
+			paths = append(paths, driftedDesiredPaths(desiredMap, liveMap, path)...)
+		case !ok || !equality.Semantic.DeepEqual(desiredVal, liveVal):
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ownedPaths returns the fields of a managedFields entry as dotted paths, in the
+// notation of driftedDesiredPaths. Undecodable entries own nothing.
+func ownedPaths(fields *metav1.FieldsV1) []string {
+	set := &fieldpath.Set{}
+	if err := set.FromJSON(bytes.NewReader(fields.Raw)); err != nil {
+		return nil
+	}
+	var paths []string
+	set.Iterate(func(p fieldpath.Path) {
+		var b strings.Builder
+		for _, element := range p {
+			if element.FieldName == nil {
+				b.WriteString("[]")
+				continue
+			}
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(*element.FieldName)
+		}
+		paths = append(paths, b.String())
+	})
+	return paths
+}
+
+// ownsPath reports whether one of owned is path, lies below it, or contains it
+func ownsPath(owned []string, path string) bool {
+	for _, o := range owned {
+		if isPathPrefix(o, path) || isPathPrefix(path, o) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPathPrefix reports whether prefix is path or one of its ancestors
+func isPathPrefix(prefix, path string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || path[len(prefix)] == '.' || path[len(prefix)] == '['
+}
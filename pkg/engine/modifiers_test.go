@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func modifierTestObject(data map[string]any, entries ...metav1.ManagedFieldsEntry) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "cm", "namespace": "openshift-cnv"},
+		"data":       data,
+	}}
+	obj.SetManagedFields(entries)
+	return obj
+}
+
+func TestDriftModifiers(t *testing.T) {
+	desired := modifierTestObject(map[string]any{"a": "1", "b": "2", "c": "3", "d": "4"})
+
+	tests := []struct {
+		name string
+		live *unstructured.Unstructured
+		want []FieldModifier
+	}{
+		{
+			name: "no drift",
+			live: modifierTestObject(map[string]any{"a": "1", "b": "2", "c": "3", "d": "4"}),
+		},
+		{
+			name: "changes are attributed to the managers owning the fields",
+			live: modifierTestObject(map[string]any{"a": "x", "b": "y", "c": "3", "d": "4", "extra": "ignored"},
+				fieldsEntry(FieldManager, `{"f:data":{"f:c":{},"f:d":{}}}`),
+				fieldsEntry("kubectl-edit", `{"f:data":{"f:a":{},"f:extra":{}}}`),
+				fieldsEntry("argocd-controller", `{"f:data":{"f:b":{}}}`),
+			),
+			want: []FieldModifier{
+				{Manager: "argocd-controller", Operation: "Apply", Fields: []string{"data.b"}},
+				{Manager: "kubectl-edit", Operation: "Apply", Fields: []string{"data.a"}},
+			},
+		},
+		{
+			name: "removed fields have no owner",
+			live: modifierTestObject(map[string]any{"a": "1", "b": "2"},
+				fieldsEntry(FieldManager, `{"f:data":{"f:a":{},"f:b":{}}}`),
+			),
+			want: []FieldModifier{{Manager: UnknownModifier, Fields: []string{"data.c", "data.d"}}},
+		},
+		{
+			name: "fields the autopilot still owns changed with the desired state",
+			live: modifierTestObject(map[string]any{"a": "old", "b": "2", "c": "3", "d": "4"},
+				fieldsEntry(FieldManager, `{"f:data":{"f:a":{},"f:b":{},"f:c":{},"f:d":{}}}`),
+			),
+		},
+		{
+			name: "an owner of the parent map owns its keys",
+			live: modifierTestObject(map[string]any{"a": "x", "b": "2", "c": "3", "d": "4"},
+				fieldsEntry("helm", `{"f:data":{".":{}}}`),
+			),
+			want: []FieldModifier{{Manager: "helm", Operation: "Apply", Fields: []string{"data.a"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DriftModifiers(desired, tt.live)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DriftModifiers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnedPathsOfListItems(t *testing.T) {
+	fields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:ports":{"k:{\"port\":8080,\"protocol\":\"TCP\"}":{"f:targetPort":{}}}}}`)}
+	owned := ownedPaths(fields)
+	if !ownsPath(owned, "spec.ports") {
+		t.Errorf("owned paths %v do not cover spec.ports", owned)
+	}
+	if ownsPath(owned, "spec.selector") {
+		t.Errorf("owned paths %v cover spec.selector", owned)
+	}
+}
+
+func TestFormatModifiers(t *testing.T) {
+	got := FormatModifiers([]FieldModifier{
+		{Manager: "kubectl-edit", Fields: []string{"data.a", "data.b"}},
+		{Manager: UnknownModifier, Fields: []string{"data.c"}},
+	})
+	if want := "kubectl-edit (data.a, data.b), unknown (data.c)"; got != want {
+		t.Errorf("FormatModifiers() = %q, want %q", got, want)
+	}
+}
+
+func TestReconcileAssetReportsDriftModifiers(t *testing.T) {
+	ctx := context.Background()
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-cnv"}}
+	c := fake.NewClientBuilder().
+		WithObjects(namespace).
+		WithInterceptorFuncs(dropDryRunApplies).
+		WithReturnManagedFields().
+		Build()
+	planner := NewPlanner(c, c, pkgassets.NewLoader())
+	created := planner.PlanAsset(ctx, &planTestAsset, renderCtx)
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(created.Object.DeepCopy()),
+		client.ForceOwnership, client.FieldOwner(FieldManager)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	// Someone else points the selector elsewhere
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(created.Object.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(created.Object), live); err != nil {
+		t.Fatal(err)
+	}
+	if err := unstructured.SetNestedField(live.Object, "other", "spec", "selector", "app"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Update(ctx, live, client.FieldOwner("kubectl-edit")); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := eventtest.NewRecorder()
+	p := NewPatcher(c, c, pkgassets.NewLoader())
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+	observability.DriftCorrectionsTotal.Reset()
+
+	applied, err := p.ReconcileAsset(ctx, &planTestAsset, renderCtx)
+	if err != nil || !applied {
+		t.Fatalf("ReconcileAsset() = %v, %v, want the drift corrected", applied, err)
+	}
+
+	report := sink.reports[planTestAsset.Name]
+	if len(report.Modifiers) != 1 || report.Modifiers[0].Manager != "kubectl-edit" ||
+		!reflect.DeepEqual(report.Modifiers[0].Fields, []string{"spec.selector.app"}) {
+		t.Errorf("report modifiers = %+v, want kubectl-edit on spec.selector.app", report.Modifiers)
+	}
+	event := rec.ExpectEvent(t, util.EventReasonDriftCorrected, "", "")
+	if !strings.Contains(event.Message, "made by kubectl-edit (spec.selector.app)") {
+		t.Errorf("event note = %q, want the modifier named", event.Message)
+	}
+	counter := observability.DriftCorrectionsTotal.WithLabelValues("Service", created.Object.GetName(), "openshift-cnv", "kubectl-edit")
+	if got := testutil.ToFloat64(counter); got != 1 {
+		t.Errorf("drift_corrections_total{manager=kubectl-edit} = %v, want 1", got)
+	}
+}
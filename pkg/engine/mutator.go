@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/config"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// Mutator modifies a rendered desired object according to cluster-wide policy
+// (e.g. injecting proxy env vars, imagePullSecrets or tolerations).
+//
+// Mutators run after the unmanaged check and before the user's JSON patch, so
+// user overrides and ignore-fields still take precedence over policy. They must
+// be deterministic: the mutated object is what drift detection compares.
+type Mutator interface {
+	Mutate(ctx context.Context, assetMeta *assets.AssetMetadata, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) error
+}
+
+// MutatorFunc adapts a plain function to the Mutator interface
+type MutatorFunc func(ctx context.Context, assetMeta *assets.AssetMetadata, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) error
+
+// Mutate calls f
+func (f MutatorFunc) Mutate(ctx context.Context, assetMeta *assets.AssetMetadata, desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) error {
+	return f(ctx, assetMeta, desired, renderCtx)
+}
+
+// MutatorFactory builds a Mutator from the plugin-specific config block of a MutatorSpec.
+// raw is nil when the spec has no config.
+type MutatorFactory func(raw json.RawMessage) (Mutator, error)
+
+var (
+	mutatorFactoriesMu sync.RWMutex
+	mutatorFactories   = map[string]MutatorFactory{}
+)
+
+// RegisterMutator makes a mutator plugin available under name.
+// It is intended to be called from init() and panics on duplicate or empty names.
+func RegisterMutator(name string, factory MutatorFactory) {
+	mutatorFactoriesMu.Lock()
+	defer mutatorFactoriesMu.Unlock()
+
+	if name == "" || factory == nil {
+		panic("engine: RegisterMutator requires a name and a factory")
+	}
+	if _, exists := mutatorFactories[name]; exists {
+		panic(fmt.Sprintf("engine: mutator %q registered twice", name))
+	}
+	mutatorFactories[name] = factory
+}
+
+// RegisteredMutators returns the sorted names of all registered mutator plugins
+func RegisteredMutators() []string {
+	mutatorFactoriesMu.RLock()
+	defer mutatorFactoriesMu.RUnlock()
+
+	names := make([]string, 0, len(mutatorFactories))
+	for name := range mutatorFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuildMutators instantiates the mutators listed in cfg, preserving order.
+// Unknown plugin names and invalid plugin config are startup errors.
+func BuildMutators(cfg *config.AutopilotConfig) ([]Mutator, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	mutatorFactoriesMu.RLock()
+	defer mutatorFactoriesMu.RUnlock()
+
+	mutators := make([]Mutator, 0, len(cfg.Mutators))
+	for _, spec := range cfg.Mutators {
+		factory, ok := mutatorFactories[spec.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown mutator %q", spec.Name)
+		}
+		m, err := factory(spec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config for mutator %q: %w", spec.Name, err)
+		}
+		mutators = append(mutators, m)
+	}
+	return mutators, nil
+}
+
+// ImagePullSecretsMutatorName is the registered name of the built-in imagePullSecrets mutator
+const ImagePullSecretsMutatorName = "image-pull-secrets"
+
+// imagePullSecretsConfig is the config block accepted by the image-pull-secrets mutator
+type imagePullSecretsConfig struct {
+	Secrets []string `json:"secrets"`
+}
+
+func init() {
+	RegisterMutator(ImagePullSecretsMutatorName, newImagePullSecretsMutator)
+}
+
+// newImagePullSecretsMutator appends the configured secrets to the pod spec of
+// workload objects (Pod, Deployment, DaemonSet, StatefulSet, Job). Other kinds are untouched.
+func newImagePullSecretsMutator(raw json.RawMessage) (Mutator, error) {
+	cfg := imagePullSecretsConfig{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.Secrets) == 0 {
+		return nil, fmt.Errorf("at least one secret is required")
+	}
+
+	return MutatorFunc(func(_ context.Context, _ *assets.AssetMetadata, desired *unstructured.Unstructured, _ *pkgcontext.RenderContext) error {
+		podSpecPath := podSpecPath(desired.GetKind())
+		if podSpecPath == nil {
+			return nil
+		}
+
+		existing, _, err := unstructured.NestedSlice(desired.Object, append(podSpecPath, "imagePullSecrets")...)
+		if err != nil {
+			return fmt.Errorf("invalid imagePullSecrets: %w", err)
+		}
+
+		present := make(map[string]bool, len(existing))
+		for _, item := range existing {
+			if ref, ok := item.(map[string]any); ok {
+				if name, ok := ref["name"].(string); ok {
+					present[name] = true
+				}
+			}
+		}
+		for _, name := range cfg.Secrets {
+			if !present[name] {
+				existing = append(existing, map[string]any{"name": name})
+				present[name] = true
+			}
+		}
+
+		return unstructured.SetNestedSlice(desired.Object, existing, append(podSpecPath, "imagePullSecrets")...)
+	}), nil
+}
+
+// podSpecPath returns the field path of the pod spec for workload kinds, or nil
+func podSpecPath(kind string) []string {
+	switch kind {
+	case "Pod":
+		return []string{"spec"}
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "Job":
+		return []string{"spec", "template", "spec"}
+	default:
+		return nil
+	}
+}
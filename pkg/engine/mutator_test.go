@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/config"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+)
+
+// capturingDriftChecker records the desired object it was asked to compare and reports no drift.
+type capturingDriftChecker struct {
+	desired *unstructured.Unstructured
+}
+
+func (c *capturingDriftChecker) DetectDrift(_ context.Context, desired, _ *unstructured.Unstructured) (bool, error) {
+	c.desired = desired.DeepCopy()
+	return false, nil
+}
+
+func TestBuildMutators(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.AutopilotConfig
+		wantLen int
+		wantErr string
+	}{
+		{
+			name:    "nil config builds nothing",
+			cfg:     nil,
+			wantLen: 0,
+		},
+		{
+			name: "built-in mutator with valid config",
+			cfg: &config.AutopilotConfig{Mutators: []config.MutatorSpec{
+				{Name: ImagePullSecretsMutatorName, Config: json.RawMessage(`{"secrets":["mirror-pull"]}`)},
+			}},
+			wantLen: 1,
+		},
+		{
+			name: "unknown mutator is rejected",
+			cfg: &config.AutopilotConfig{Mutators: []config.MutatorSpec{
+				{Name: "does-not-exist"},
+			}},
+			wantErr: `unknown mutator "does-not-exist"`,
+		},
+		{
+			name: "invalid plugin config is rejected",
+			cfg: &config.AutopilotConfig{Mutators: []config.MutatorSpec{
+				{Name: ImagePullSecretsMutatorName},
+			}},
+			wantErr: "at least one secret is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mutators, err := BuildMutators(tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BuildMutators() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildMutators() unexpected error: %v", err)
+			}
+			if len(mutators) != tt.wantLen {
+				t.Errorf("BuildMutators() returned %d mutators, want %d", len(mutators), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestRegisterMutatorDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected RegisterMutator to panic on duplicate name")
+		}
+	}()
+	RegisterMutator(ImagePullSecretsMutatorName, newImagePullSecretsMutator)
+}
+
+func TestImagePullSecretsMutator(t *testing.T) {
+	m, err := newImagePullSecretsMutator(json.RawMessage(`{"secrets":["mirror-pull","existing"]}`))
+	if err != nil {
+		t.Fatalf("newImagePullSecretsMutator() error = %v", err)
+	}
+
+	deployment := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "plugin", "namespace": "openshift-cnv"},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"imagePullSecrets": []any{map[string]any{"name": "existing"}},
+				},
+			},
+		},
+	}}
+	if err := m.Mutate(context.Background(), nil, deployment, nil); err != nil {
+		t.Fatalf("Mutate() error = %v", err)
+	}
+	got, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "imagePullSecrets")
+	want := []any{map[string]any{"name": "existing"}, map[string]any{"name": "mirror-pull"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("imagePullSecrets = %v, want %v", got, want)
+	}
+
+	configMap := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "cfg"},
+	}}
+	before := configMap.DeepCopy()
+	if err := m.Mutate(context.Background(), nil, configMap, nil); err != nil {
+		t.Fatalf("Mutate() error = %v", err)
+	}
+	if !reflect.DeepEqual(configMap, before) {
+		t.Errorf("non-workload object was modified: %v", configMap.Object)
+	}
+}
+
+// TestMutatorsRunBeforeDriftDetection verifies that policy mutations are part of the
+// desired state compared during drift detection, and that a mutator error fails the asset.
+func TestMutatorsRunBeforeDriftDetection(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(desired.DeepCopy()).Build()
+
+	newPatcher := func(drift driftChecker, mutators ...Mutator) *Patcher {
+		return &Patcher{
+			renderer:          renderer,
+			applier:           NewApplier(fakeClient, nil),
+			driftDetector:     drift,
+			throttle:          throttling.NewTokenBucket(),
+			thrashingDetector: throttling.NewThrashingDetector(),
+			client:            fakeClient,
+			mutators:          mutators,
+		}
+	}
+
+	labelMutator := MutatorFunc(func(_ context.Context, _ *pkgassets.AssetMetadata, obj *unstructured.Unstructured, _ *pkgcontext.RenderContext) error {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["policy.example.com/team"] = "virt"
+		obj.SetLabels(labels)
+		return nil
+	})
+
+	drift := &capturingDriftChecker{}
+	if _, err := newPatcher(drift, labelMutator).ReconcileAsset(context.Background(), assetMeta, renderCtx); err != nil {
+		t.Fatalf("ReconcileAsset() error = %v", err)
+	}
+	if drift.desired == nil {
+		t.Fatal("drift detection was not reached")
+	}
+	if got := drift.desired.GetLabels()["policy.example.com/team"]; got != "virt" {
+		t.Errorf("mutated label not visible to drift detection, got %q", got)
+	}
+
+	failing := MutatorFunc(func(context.Context, *pkgassets.AssetMetadata, *unstructured.Unstructured, *pkgcontext.RenderContext) error {
+		return fmt.Errorf("policy unavailable")
+	})
+	_, err = newPatcher(&capturingDriftChecker{}, failing).ReconcileAsset(context.Background(), assetMeta, renderCtx)
+	if err == nil || !strings.Contains(err.Error(), "policy unavailable") {
+		t.Errorf("ReconcileAsset() error = %v, want mutator failure", err)
+	}
+}
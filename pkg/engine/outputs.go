@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// resolveInputs makes sure ctx holds the outputs of every asset assetMeta consumes.
+// Producers not rendered yet with ctx are rendered now, recursively; the catalog
+// rejects cycles, so this terminates. A producer that renders nothing or skips
+// provides no outputs, which templates see as missing keys.
+func (r *Renderer) resolveInputs(assetMeta *assets.AssetMetadata, ctx *pkgcontext.RenderContext) error {
+	for _, input := range assetMeta.Inputs {
+		if _, done := ctx.Outputs[input]; done {
+			continue
+		}
+		producer, err := r.catalogAsset(input)
+		if err != nil {
+			return fmt.Errorf("asset %s: %w", assetMeta.Name, err)
+		}
+		if _, err := r.RenderMultiAsset(producer, ctx); err != nil {
+			if _, skipped := SkipReason(err); !skipped {
+				return fmt.Errorf("failed to render input %s of asset %s: %w", input, assetMeta.Name, err)
+			}
+			setOutputs(ctx, input, map[string]any{})
+		}
+	}
+	return nil
+}
+
+// recordOutputs stores the declared outputs of assetMeta, read from the first rendered
+// object, in ctx. A declared path missing from a rendered object is a template bug.
+func recordOutputs(assetMeta *assets.AssetMetadata, ctx *pkgcontext.RenderContext, objs []*unstructured.Unstructured) error {
+	if len(assetMeta.Outputs) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(assetMeta.Outputs))
+	if len(objs) > 0 {
+		obj := objs[0]
+		for name, path := range assetMeta.Outputs {
+			value, found, err := unstructured.NestedFieldCopy(obj.Object, assets.OutputPath(path)...)
+			if err != nil || !found {
+				return fmt.Errorf("asset %s output %s: field %s not found in rendered %s %s",
+					assetMeta.Name, name, path, obj.GetKind(), obj.GetName())
+			}
+			values[name] = value
+		}
+	}
+	setOutputs(ctx, assetMeta.Name, values)
+	return nil
+}
+
+func setOutputs(ctx *pkgcontext.RenderContext, asset string, values map[string]any) {
+	if ctx.Outputs == nil {
+		ctx.Outputs = make(map[string]map[string]any)
+	}
+	ctx.Outputs[asset] = values
+}
+
+// catalogAsset returns the catalog entry of an input producer
+func (r *Renderer) catalogAsset(name string) (*assets.AssetMetadata, error) {
+	catalog, err := r.loader.Catalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load asset catalog: %w", err)
+	}
+	for i := range catalog.Assets {
+		if catalog.Assets[i].Name == name {
+			return &catalog.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("input asset %s not found", name)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func metricsExporterContext() *pkgcontext.RenderContext {
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+	renderCtx.Images["kubevirt-metrics-exporter"] = "quay.io/kubevirt/metrics-exporter:latest"
+	return renderCtx
+}
+
+func renderCatalogAsset(t *testing.T, name string, renderCtx *pkgcontext.RenderContext) *unstructured.Unstructured {
+	t.Helper()
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	asset, err := registry.GetAsset(name)
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+	rendered, err := NewRenderer(loader).RenderAsset(asset, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render %s: %v", name, err)
+	}
+	return rendered
+}
+
+func TestRenderResolvesInputs(t *testing.T) {
+	renderCtx := metricsExporterContext()
+	rendered := renderCatalogAsset(t, "metrics-exporter", renderCtx)
+
+	if got := renderCtx.Outputs["metrics-exporter-serviceaccount"]["name"]; got != "kubevirt-metrics-exporter" {
+		t.Errorf("expected the ServiceAccount name output, got %v", got)
+	}
+	sa, _, _ := unstructured.NestedString(rendered.Object, "spec", "template", "spec", "serviceAccountName")
+	if sa != "kubevirt-metrics-exporter" {
+		t.Errorf("serviceAccountName = %q, want the producer's output", sa)
+	}
+}
+
+func TestRenderReusesRecordedOutputs(t *testing.T) {
+	renderCtx := metricsExporterContext()
+	renderCtx.Outputs["metrics-exporter-serviceaccount"] = map[string]any{"name": "already-rendered"}
+
+	rendered := renderCatalogAsset(t, "metrics-exporter", renderCtx)
+	sa, _, _ := unstructured.NestedString(rendered.Object, "spec", "template", "spec", "serviceAccountName")
+	if sa != "already-rendered" {
+		t.Errorf("serviceAccountName = %q, want the recorded output", sa)
+	}
+}
+
+func TestRecordOutputs(t *testing.T) {
+	meta := &assets.AssetMetadata{Name: "producer", Outputs: map[string]string{
+		"name": "metadata.name",
+		"pool": "spec.pool",
+	}}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"kind":     "ConfigMap",
+		"metadata": map[string]any{"name": "cm"},
+		"spec":     map[string]any{"pool": "virt"},
+	}}
+
+	renderCtx := &pkgcontext.RenderContext{}
+	if err := recordOutputs(meta, renderCtx, []*unstructured.Unstructured{obj}); err != nil {
+		t.Fatalf("recordOutputs() error = %v", err)
+	}
+	if got := renderCtx.Outputs["producer"]; got["name"] != "cm" || got["pool"] != "virt" {
+		t.Errorf("unexpected outputs %v", got)
+	}
+
+	// Rendering nothing records no values, so consumers see missing keys
+	if err := recordOutputs(meta, renderCtx, nil); err != nil {
+		t.Fatalf("recordOutputs() error = %v", err)
+	}
+	if got, ok := renderCtx.Outputs["producer"]; !ok || len(got) != 0 {
+		t.Errorf("expected empty outputs, got %v", got)
+	}
+
+	unstructured.RemoveNestedField(obj.Object, "spec", "pool")
+	err := recordOutputs(meta, renderCtx, []*unstructured.Unstructured{obj})
+	if err == nil || !strings.Contains(err.Error(), "spec.pool not found") {
+		t.Errorf("recordOutputs() error = %v, want missing field error", err)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// addOwnerReference appends owner, a namespaced object in ownerNamespace, to the owner
+// references of desired where Kubernetes garbage collection honors it: only objects in
+// the same namespace may have a namespaced owner, so cluster-scoped objects and objects
+// in other namespaces are left alone. The HyperConverged itself is skipped, as it owns
+// the owner. Returns whether the reference was added.
+func addOwnerReference(desired *unstructured.Unstructured, owner *metav1.OwnerReference, ownerNamespace string) bool {
+	if owner == nil || ownerNamespace == "" || desired.GetNamespace() != ownerNamespace {
+		return false
+	}
+	if desired.GetKind() == pkgcontext.HCOKind || (desired.GetKind() == owner.Kind && desired.GetName() == owner.Name) {
+		return false
+	}
+	refs := desired.GetOwnerReferences()
+	for _, ref := range refs {
+		if ref.UID == owner.UID {
+			return false
+		}
+	}
+	desired.SetOwnerReferences(append(refs, *owner))
+	return true
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestAddOwnerReference(t *testing.T) {
+	owner := &metav1.OwnerReference{
+		APIVersion: "platform.kubevirt.io/v1alpha1",
+		Kind:       "AutopilotDeployment",
+		Name:       "kubevirt-hyperconverged",
+		UID:        "owner-uid",
+		Controller: ptr.To(false),
+	}
+	object := func(kind, namespace string, refs ...metav1.OwnerReference) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName("kubevirt-hyperconverged")
+		obj.SetOwnerReferences(refs)
+		return obj
+	}
+	other := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "other", UID: "other-uid"}
+
+	tests := []struct {
+		name     string
+		desired  *unstructured.Unstructured
+		owner    *metav1.OwnerReference
+		wantAdd  bool
+		wantRefs int
+	}{
+		{name: "object in the owner namespace", desired: object("ConfigMap", "openshift-cnv"), owner: owner, wantAdd: true, wantRefs: 1},
+		{name: "references of the template are kept", desired: object("ConfigMap", "openshift-cnv", other), owner: owner, wantAdd: true, wantRefs: 2},
+		{name: "already owned", desired: object("ConfigMap", "openshift-cnv", *owner), owner: owner, wantRefs: 1},
+		{name: "no owner", desired: object("ConfigMap", "openshift-cnv"), wantRefs: 0},
+		{name: "other namespace", desired: object("ConfigMap", "openshift-nmstate"), owner: owner, wantRefs: 0},
+		{name: "cluster-scoped object", desired: object("KubeletConfig", ""), owner: owner, wantRefs: 0},
+		{name: "the HyperConverged owning the owner", desired: object(pkgcontext.HCOKind, "openshift-cnv"), owner: owner, wantRefs: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if added := addOwnerReference(tt.desired, tt.owner, "openshift-cnv"); added != tt.wantAdd {
+				t.Errorf("addOwnerReference() = %v, want %v", added, tt.wantAdd)
+			}
+			if refs := tt.desired.GetOwnerReferences(); len(refs) != tt.wantRefs {
+				t.Errorf("got %d owner references, want %d: %v", len(refs), tt.wantRefs, refs)
+			}
+		})
+	}
+}
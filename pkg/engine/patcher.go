@@ -21,9 +21,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -49,6 +51,19 @@ type Patcher struct {
 	thrashingDetector *throttling.ThrashingDetector
 	client            client.Client
 	eventRecorder     *util.EventRecorder
+	mutators          []Mutator
+	assetLogFilter    map[string]bool // nil = log all assets
+	upgradeGate       upgradeGate
+	blastRadius       blastRadiusGuard
+	canary            canaryGuard
+	recreation        recreationGuard
+	async             asyncApplier
+	timeouts          ApplyTimeouts
+	inventory         InventorySink
+	history           *RenderHistory
+	differentialSync  differentialSync
+	reportOnly        string               // non-empty: why drift is reported but nothing is applied
+	sharedOwner       types.NamespacedName // HCO applying shared objects; zero = every HCO
 }
 
 // NewPatcher creates a new patcher
@@ -73,16 +88,32 @@ func (p *Patcher) SetEventRecorder(recorder *util.EventRecorder) {
 	p.eventRecorder = recorder
 }
 
+// SetMutators sets the policy mutators applied to every desired object before apply
+func (p *Patcher) SetMutators(mutators []Mutator) {
+	p.mutators = mutators
+}
+
+// SetReportOnly switches the patcher to report-only mode for reason, or back to
+// applying when reason is empty. In report-only mode drift is detected and reported,
+// but no object is created or updated.
+func (p *Patcher) SetReportOnly(reason string) {
+	p.reportOnly = reason
+}
+
 // CleanupExcludedAsset deletes per-asset Prometheus metrics for an asset that is no
 // longer in the active set (allowlist narrowed, CRD removed, condition no longer met).
 // It renders the template to discover the resource's kind/name/namespace, then calls
 // DeleteAssetMetrics. Silently returns if rendering fails or yields nothing — the metric
 // series either never existed or the template cannot resolve, both are safe to ignore.
 func (p *Patcher) CleanupExcludedAsset(assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) {
+	if assetMeta.Deprecated {
+		observability.SetDeprecatedAsset(assetMeta.Name, assetMeta.ReplacedBy, assetMeta.RemovalVersion, false)
+	}
 	desired, err := p.renderer.RenderAsset(assetMeta, renderCtx)
 	if err != nil || desired == nil {
 		return
 	}
+	p.upgradeGate.clearDeferred(desired.GetKind(), desired.GetName(), desired.GetNamespace())
 	observability.DeleteAssetMetrics(desired.GetKind(), desired.GetName(), desired.GetNamespace())
 }
 
@@ -91,7 +122,7 @@ func (p *Patcher) CleanupExcludedAsset(assetMeta *assets.AssetMetadata, renderCt
 //
 //nolint:gocognit // This function implements the 7-step Patched Baseline Algorithm which is inherently complex
 func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) (bool, error) {
-	logger := log.FromContext(ctx)
+	ctx, logger := p.withAssetLogger(ctx, assetMeta)
 
 	logger.V(1).Info("Reconciling asset",
 		"name", assetMeta.Name,
@@ -101,8 +132,24 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 
 	// Step 1: Render asset template → Opinionated State
 	desired, err := p.renderer.RenderAsset(assetMeta, renderCtx)
+	for _, warning := range renderCtx.Warnings(assetMeta.Name) {
+		logger.Info("Asset template reported a warning", "name", assetMeta.Name, "warning", warning)
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.RenderWarning(renderCtx.HCO, assetMeta.Name, warning)
+		}
+	}
+	if reason, skipped := SkipReason(err); skipped {
+		logger.V(1).Info("Asset skipped by template",
+			"name", assetMeta.Name,
+			"reason", reason,
+		)
+		if p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.AssetSkipped(renderCtx.HCO, assetMeta.Name, reason)
+		}
+		return false, nil
+	}
 	if err != nil {
-		return false, fmt.Errorf("failed to render asset %s: %w", assetMeta.Name, err)
+		return false, &RenderError{Asset: assetMeta.Name, Err: err}
 	}
 
 	// Handle conditional assets that don't apply (template rendered empty)
@@ -112,24 +159,53 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		)
 		return false, nil
 	}
+	p.reportObject(renderCtx, assetMeta, desired, ObjectPending, "", "")
+	// Taken before mutators and patches, so only template edits show up as dropped fields
+	rendered := RenderedFields(desired)
 
 	// Root Exclusion: Check if this resource is explicitly disabled via annotation
-	disabledAnnotation := renderCtx.HCO.GetAnnotations()[DisabledResourcesAnnotation]
-	if disabledAnnotation != "" {
-		rules, err := ParseDisabledResources(disabledAnnotation)
-		if err != nil {
-			logger.Error(err, "Invalid disabled-resources annotation, ignoring",
-				"annotation", disabledAnnotation,
-			)
-		} else if IsResourceExcluded(desired.GetKind(), desired.GetNamespace(), desired.GetName(), rules) {
-			logger.Info("Skipping resource due to Root Exclusion",
-				"kind", desired.GetKind(),
-				"namespace", desired.GetNamespace(),
-				"name", desired.GetName(),
-				"annotation", DisabledResourcesAnnotation,
-			)
-			return false, nil
-		}
+	if rules, err := ExclusionRulesFromObject(renderCtx.HCO); err != nil {
+		logger.Error(err, "Invalid disabled-resources annotation, ignoring",
+			"annotation", DisabledResourcesAnnotation,
+		)
+	} else if IsResourceExcluded(desired.GetKind(), desired.GetNamespace(), desired.GetName(), rules) {
+		logger.Info("Skipping resource due to Root Exclusion",
+			"kind", desired.GetKind(),
+			"namespace", desired.GetNamespace(),
+			"name", desired.GetName(),
+			"annotation", DisabledResourcesAnnotation,
+		)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectExcluded, ReasonDisabledResources, "excluded by "+DisabledResourcesAnnotation)
+		return false, nil
+	}
+	if exclusion := MatchingExclusion(renderCtx, assetMeta.Component, desired, time.Now()); exclusion != nil {
+		logger.Info("Skipping resource excluded by an AutopilotExclusion",
+			"kind", desired.GetKind(),
+			"namespace", desired.GetNamespace(),
+			"name", desired.GetName(),
+			"exclusion", exclusion.Source,
+			"reason", exclusion.Reason,
+		)
+		renderCtx.RecordExclusionMatch(exclusion.Source, overrides.ExcludedObject{
+			Asset:     assetMeta.Name,
+			Kind:      desired.GetKind(),
+			Namespace: desired.GetNamespace(),
+			Name:      desired.GetName(),
+		})
+		p.reportObject(renderCtx, assetMeta, desired, ObjectExcluded, ReasonAutopilotExclusion, "excluded by AutopilotExclusion "+exclusion.Source)
+		return false, nil
+	}
+
+	if renderCtx.HCO != nil && isSharedObject(renderCtx.HCO, desired) && !p.OwnsSharedObjects(renderCtx.HCO) {
+		logger.V(1).Info("Skipping shared resource, applied by the owning HyperConverged",
+			"kind", desired.GetKind(),
+			"namespace", desired.GetNamespace(),
+			"name", desired.GetName(),
+			"owner", p.sharedOwner.String(),
+		)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectExcluded, ReasonSharedObjectOwner,
+			"shared with other HyperConvergeds, applied by "+p.sharedOwner.String())
+		return false, nil
 	}
 
 	// Start reconciliation duration timer (will be observed at function exit)
@@ -167,12 +243,12 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 			liveExists = true
 		} else if !errors.IsNotFound(directErr) {
 			// Some other error occurred
-			return false, fmt.Errorf("failed to get live object: %w", directErr)
+			return false, fmt.Errorf("failed to get live object: %w", dependencyError(desired.GroupVersionKind(), directErr))
 		}
 		// If directErr is NotFound, object truly doesn't exist
 	} else if err != nil {
 		// Some other error occurred during cached Get
-		return false, fmt.Errorf("failed to get live object: %w", err)
+		return false, fmt.Errorf("failed to get live object: %w", dependencyError(desired.GroupVersionKind(), err))
 	}
 
 	// Log if we need to re-label an existing object
@@ -194,6 +270,7 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		)
 		// Don't emit metrics or events repeatedly - annotation is self-documenting
 		// User must remove annotation to resume reconciliation
+		p.reportObject(renderCtx, assetMeta, desired, ObjectPaused, ReasonReconcilePaused, "paused after an edit war; remove "+overrides.AnnotationReconcilePaused+" to resume")
 		return false, nil
 	}
 
@@ -233,23 +310,74 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		if p.eventRecorder != nil && renderCtx.HCO != nil {
 			p.eventRecorder.UnmanagedMode(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName())
 		}
+		p.reportObject(renderCtx, assetMeta, desired, ObjectUnmanaged, ReasonUnmanaged, "")
 		return false, nil
 	}
 
+	// Step 2.1: Check delegation to a GitOps tool (delegate-to). The autopilot hands its
+	// field ownership over once, then leaves the object alone like an unmanaged one.
+	if liveExists {
+		manager, delegated, err := overrides.DelegatedTo(live)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s annotation: %w", overrides.AnnotationDelegateTo, err)
+		}
+		if delegated {
+			// The transfer is a write too, so report-only mode leaves it for later
+			transferred := false
+			if p.reportOnly == "" {
+				if transferred, err = p.delegateOwnership(ctx, live, manager); err != nil {
+					return false, err
+				}
+			}
+			observability.SetCustomization(desired, "delegated")
+			if transferred {
+				logger.Info("Transferred field ownership to delegate, reconciliation stopped",
+					"name", assetMeta.Name,
+					"kind", desired.GetKind(),
+					"namespace", desired.GetNamespace(),
+					"objectName", desired.GetName(),
+					"fieldManager", manager,
+				)
+				if p.eventRecorder != nil && renderCtx.HCO != nil {
+					p.eventRecorder.OwnershipDelegated(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), manager)
+				}
+			}
+			p.reportObject(renderCtx, assetMeta, desired, ObjectDelegated, ReasonDelegated, "delegated to "+manager)
+			return false, nil
+		}
+	}
+
+	// Step 2.5: Apply cluster-wide policy mutators (AutopilotConfig).
+	// These run before user overrides so a JSON patch or ignore-fields can still win.
+	for _, m := range p.mutators {
+		if err := m.Mutate(ctx, assetMeta, desired, renderCtx); err != nil {
+			return false, &RenderError{Asset: assetMeta.Name, Err: fmt.Errorf("mutator failed: %w", err)}
+		}
+	}
+
+	// Step 2.6: Hang objects in the HCO namespace off its AutopilotDeployment, so garbage
+	// collection removes them with the HyperConverged even if cleanup never runs
+	if renderCtx.Owner != nil && renderCtx.HCO != nil {
+		addOwnerReference(desired, renderCtx.Owner, renderCtx.HCO.GetNamespace())
+	}
+
 	// Step 3: Apply user patch (in-memory) → Modified State
-	// Copy patch annotation from live to desired, then apply it
+	// Copy patch and patch-type annotations from live to desired, then apply it
 	if liveExists {
 		liveAnnotations := live.GetAnnotations()
 		if patchStr, exists := liveAnnotations[overrides.PatchAnnotation]; exists && patchStr != "" {
 			// Track patch customization
 			observability.SetCustomization(desired, "patch")
 
-			// Copy patch annotation to desired temporarily
+			// Copy patch annotations to desired temporarily
 			desiredAnnotations := desired.GetAnnotations()
 			if desiredAnnotations == nil {
 				desiredAnnotations = make(map[string]string)
 			}
 			desiredAnnotations[overrides.PatchAnnotation] = patchStr
+			if patchType, ok := liveAnnotations[overrides.PatchTypeAnnotation]; ok {
+				desiredAnnotations[overrides.PatchTypeAnnotation] = patchType
+			}
 			desired.SetAnnotations(desiredAnnotations)
 
 			// Validate patch security before applying
@@ -262,14 +390,16 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 				if p.eventRecorder != nil && renderCtx.HCO != nil {
 					p.eventRecorder.InvalidPatch(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), err.Error())
 				}
-				// Remove invalid patch annotation and continue with unpatched desired
+				// Remove invalid patch annotations and continue with unpatched desired
 				delete(desiredAnnotations, overrides.PatchAnnotation)
+				delete(desiredAnnotations, overrides.PatchTypeAnnotation)
 				desired.SetAnnotations(desiredAnnotations)
 			} else {
 				// Apply the patch (modifies desired in-place)
-				patched, err := overrides.ApplyJSONPatch(desired)
+				patched, err := overrides.ApplyPatch(desired)
 				if err != nil {
-					logger.Error(err, "Failed to apply JSON patch, using desired without patch",
+					logger.Error(err, "Failed to apply patch, using desired without patch",
+						"patchType", overrides.PatchType(desired),
 						"name", assetMeta.Name,
 					)
 					// Record event about invalid patch
@@ -280,13 +410,36 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 				} else if patched && p.eventRecorder != nil && renderCtx.HCO != nil {
 					// Record successful patch application
 					// Count operations in the patch string
-					operations := countJSONPatchOperations(patchStr)
+					operations := countPatchOperations(overrides.PatchType(desired), patchStr)
 					p.eventRecorder.PatchApplied(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), operations)
 				}
 			}
 		}
 	}
 
+	// Step 3b: Apply the asset's patch from the HCO's overrides ConfigMap, unless the live
+	// object carries its own patch annotation, which is more specific and wins. It does not
+	// live on the object, so it also applies when the object is created. An expired patch is
+	// dropped, so the next apply restores the rendered values.
+	if patch, ok := renderCtx.OverridePatches[assetMeta.Name]; ok && !patch.Expired(time.Now()) &&
+		(!liveExists || live.GetAnnotations()[overrides.PatchAnnotation] == "") {
+		if err := overrides.ApplyAssetPatch(desired, patch); err != nil {
+			logger.Error(err, "Failed to apply overrides ConfigMap patch, using desired without patch",
+				"name", assetMeta.Name,
+				"patchType", patch.Type,
+			)
+			if p.eventRecorder != nil && renderCtx.HCO != nil {
+				p.eventRecorder.InvalidPatch(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), err.Error())
+			}
+		} else {
+			observability.SetCustomization(desired, "patch")
+			if p.eventRecorder != nil && renderCtx.HCO != nil {
+				p.eventRecorder.PatchApplied(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(),
+					countPatchOperations(patch.Type, patch.Patch))
+			}
+		}
+	}
+
 	// Step 4: Mask ignored fields → Effective Desired State
 	if liveExists {
 		// Check if ignore-fields annotation exists
@@ -311,16 +464,57 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 	// Without this the label would always appear as a spurious diff.
 	ensureManagedByLabel(desired)
 
+	// Differential sync: right after a restart, an object still carrying the hash of
+	// this desired state was applied by the previous operator and is left to the next pass
+	if liveExists && p.differentialSync.unchangedSinceStart(desired, live) {
+		logger.V(1).Info("Desired state unchanged since the last apply, skipping drift check until the next pass",
+			"name", assetMeta.Name,
+		)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectInSync, ReasonUnchangedSinceLastApply, "unchanged since the last apply")
+		return false, nil
+	}
+
+	// Objects applied before the rendered-fields and desired-hash records existed receive
+	// them with their next change; adding them alone must not update them, e.g. past the
+	// blast radius guard
+	if _, recorded := recordedFields(live); !liveExists || recorded {
+		SetRenderedFields(desired, rendered)
+	}
+	if !liveExists || live.GetAnnotations()[DesiredHashAnnotation] != "" {
+		SetDesiredHash(desired)
+	}
+
+	// An object whose admission failed waits for its retry or its background apply
+	key := throttling.MakeResourceKey(desired.GetNamespace(), desired.GetName(), desired.GetKind())
+	if reason, message, err := p.async.pending(key, time.Now()); err != nil {
+		observability.SetCompliance(desired, 0)
+		return false, err
+	} else if reason != "" {
+		p.reportObject(renderCtx, assetMeta, desired, ObjectPending, reason, message)
+		return false, nil
+	}
+
 	hasDrift := false
-	if liveExists {
+	// The dry-run of an object waiting on its admission goes through the same slow
+	// webhook, so its background apply checks for drift instead of the pass
+	queued := p.async.queued(key)
+	switch {
+	case liveExists && queued:
+		hasDrift = true
+	case liveExists:
 		hasDrift, err = p.driftDetector.DetectDrift(ctx, desired, live)
 		if err != nil {
+			// The dry-run goes through the admission webhooks too: one that is down or
+			// slow is retried later without failing the pass
+			if p.deferApply(ctx, assetMeta, desired, renderCtx, err) {
+				return false, nil
+			}
 			// SSA dry-run failed due to an infrastructure error (e.g. webhook TLS issue,
 			// admission webhook unreachable). Do NOT fall back to SimpleDriftCheck here:
 			// SimpleDriftCheck compares the minimal rendered template against the fully
 			// webhook-defaulted live object, so it would always report drift and cause a
 			// false-positive cascade into the thrashing detector.
-			// Propagate the error so controller-runtime retries with proper backoff.
+			// Propagate any other error so controller-runtime retries with proper backoff.
 			logger.Info("SSA dry-run failed, skipping reconciliation until resolved",
 				"error", err.Error(),
 			)
@@ -330,7 +524,7 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 			observability.SetCompliance(desired, 0)
 			return false, fmt.Errorf("drift detection failed: %w", err)
 		}
-	} else {
+	default:
 		// Object doesn't exist - needs creation
 		hasDrift = true
 	}
@@ -339,16 +533,108 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		logger.V(1).Info("No drift detected, skipping apply",
 			"name", assetMeta.Name,
 		)
+		p.upgradeGate.clearDeferred(desired.GetKind(), desired.GetName(), desired.GetNamespace())
+		p.async.forget(key)
 		observability.SetCompliance(desired, 1)
 		observability.SetPaused(desired, false)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectInSync, "", "")
 		return false, nil
 	}
 
+	SetRenderedFields(desired, rendered)
+	SetDesiredHash(desired)
+
 	// Record drift detection (only when drift is found)
-	if liveExists && p.eventRecorder != nil && renderCtx.HCO != nil {
+	if liveExists && !queued && p.eventRecorder != nil && renderCtx.HCO != nil {
 		p.eventRecorder.DriftDetected(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName())
 	}
 
+	// Report-only mode: the controller does not trust its assets against this cluster
+	if p.reportOnly != "" {
+		logger.Info("Report-only mode, not applying",
+			"name", assetMeta.Name,
+			"kind", desired.GetKind(),
+			"reason", p.reportOnly,
+		)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonReportOnly, "report-only: "+p.reportOnly)
+		return false, nil
+	}
+
+	// Maintenance window: drift is reported above but not corrected, and node-rebooting
+	// objects are not created either. Other new objects are still created.
+	if (liveExists || triggersReboot(desired)) && overrides.InMaintenance(renderCtx.HCO, time.Now()) {
+		logger.Info("Maintenance window open, not applying",
+			"name", assetMeta.Name,
+			"kind", desired.GetKind(),
+		)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonMaintenanceWindow, "maintenance window open")
+		return false, nil
+	}
+
+	// Upgrade safe-mode: hold back node-rebooting changes until the cluster is stable.
+	// Deferral happens before the rate limiter so waiting never counts as thrashing.
+	if reason := p.upgradeGate.deferReason(desired, renderCtx.Upgrade); reason != "" {
+		if p.upgradeGate.markDeferred(desired) {
+			logger.Info("Deferring reboot-triggering asset until the cluster is stable",
+				"name", assetMeta.Name,
+				"kind", desired.GetKind(),
+				"reason", reason,
+			)
+			if p.eventRecorder != nil && renderCtx.HCO != nil {
+				p.eventRecorder.ApplyDeferred(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), reason)
+			}
+		}
+		p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonUpgradeInProgress, reason)
+		return false, nil
+	}
+	p.upgradeGate.clearDeferred(desired.GetKind(), desired.GetName(), desired.GetNamespace())
+
+	// Recreation limit: an object someone keeps deleting is left deleted for a while
+	if !liveExists {
+		if reason, started := p.recreation.hold(desired, time.Now()); reason != "" {
+			if started {
+				logger.Info("Managed object keeps being deleted, pausing its recreation",
+					"name", assetMeta.Name,
+					"kind", desired.GetKind(),
+					"reason", reason,
+				)
+				observability.IncRecreationCooldown(desired)
+				if p.eventRecorder != nil && renderCtx.HCO != nil {
+					p.eventRecorder.RecreationCooldown(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), reason)
+				}
+			}
+			p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonRecreationCooldown, reason)
+			return false, nil
+		}
+	}
+
+	// Blast radius guard: node-rebooting changes are collected and applied together at
+	// the end of ReconcileAssets, once the size of the batch is known.
+	if p.blastRadius.hold(assetMeta, desired, live, liveExists) {
+		logger.V(1).Info("Holding reboot-triggering change until the batch size is known",
+			"name", assetMeta.Name,
+			"kind", desired.GetKind(),
+		)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonBlastRadius, "held by the blast radius guard")
+		return false, nil
+	}
+
+	return p.applyDesired(ctx, assetMeta, desired, live, liveExists, renderCtx)
+}
+
+// applyDesired runs the final steps of the Patched Baseline algorithm for an object
+// with drift: namespace check, anti-thrashing gate and Server-Side Apply.
+//
+//nolint:gocognit // Throttling, pause and event handling are inherently branchy
+func (p *Patcher) applyDesired(
+	ctx context.Context,
+	assetMeta *assets.AssetMetadata,
+	desired, live *unstructured.Unstructured,
+	liveExists bool,
+	renderCtx *pkgcontext.RenderContext,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
 	// Pre-Step 6: verify the target namespace exists before consuming a rate-limit token.
 	if ns := desired.GetNamespace(); ns != "" {
 		nsObj := &unstructured.Unstructured{}
@@ -360,6 +646,7 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 					"name", assetMeta.Name,
 					"namespace", ns,
 				)
+				p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonNamespaceMissing, "target namespace "+ns+" not found")
 				return false, nil
 			}
 			return false, fmt.Errorf("failed to verify target namespace %s: %w", ns, nsErr)
@@ -434,7 +721,15 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		return false, err
 	}
 
-	// Step 7: Apply via Server-Side Apply
+	// Step 7: Apply via Server-Side Apply, in the background for an object whose
+	// admission failed before
+	if p.async.queued(resourceKey) {
+		if !liveExists {
+			live = nil
+		}
+		p.applyInBackground(ctx, assetMeta, desired, live, renderCtx)
+		return false, nil
+	}
 	applied, err := p.applier.Apply(ctx, desired, true)
 	if err != nil {
 		// If the target namespace doesn't exist, the operator is not installed —
@@ -444,26 +739,45 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 				"name", assetMeta.Name,
 				"namespace", desired.GetNamespace(),
 			)
+			p.reportObject(renderCtx, assetMeta, desired, ObjectPending, ReasonNamespaceMissing, "target namespace "+desired.GetNamespace()+" not found")
+			return false, nil
+		}
+		if p.deferApply(ctx, assetMeta, desired, renderCtx, err) {
 			return false, nil
 		}
 
 		// Set compliance status to failed (0)
 		observability.SetCompliance(desired, 0)
 
-		// Record apply failure event
-		if p.eventRecorder != nil && renderCtx.HCO != nil {
-			p.eventRecorder.ApplyFailed(renderCtx.HCO, assetMeta.Name, err.Error())
+		// Record the failure on the object; the HCO event follows from ReportAssetError
+		if liveExists && p.eventRecorder != nil && renderCtx.HCO != nil {
+			p.eventRecorder.ObjectApplyFailed(live, renderCtx.HCO, assetMeta.Name, err.Error())
 		}
 		return false, fmt.Errorf("failed to apply asset %s: %w", assetMeta.Name, err)
 	}
 
 	if applied {
+		// Whoever changed the fields just reverted, so edit wars can be traced to their
+		// source. Objects the autopilot never applied are adopted, not corrected.
+		var modifiers []FieldModifier
+		if liveExists && AppliedByAutopilot(live) {
+			modifiers = DriftModifiers(desired, live)
+		}
 		logger.Info("Successfully applied asset",
 			"name", assetMeta.Name,
 			"kind", desired.GetKind(),
 			"namespace", desired.GetNamespace(),
 			"objectName", desired.GetName(),
 		)
+		if len(modifiers) > 0 {
+			logger.Info("Reverted changes of other field managers",
+				"name", assetMeta.Name,
+				"modifiedBy", FormatModifiers(modifiers),
+			)
+		}
+		for _, m := range modifiers {
+			observability.IncDriftCorrection(desired, m.Manager)
+		}
 		// Set compliance status to synced (1)
 		observability.SetCompliance(desired, 1)
 
@@ -477,13 +791,38 @@ func (p *Patcher) ReconcileAsset(ctx context.Context, assetMeta *assets.AssetMet
 		if p.eventRecorder != nil && renderCtx.HCO != nil {
 			p.eventRecorder.AssetApplied(renderCtx.HCO, assetMeta.Name, desired.GetKind(), desired.GetNamespace(), desired.GetName())
 		}
-		// Also record drift correction since we just fixed it
+		// Also record drift correction since we just fixed it, on the HCO and on the object.
+		// An object without our label was created by someone else and is adopted instead.
 		if liveExists && p.eventRecorder != nil && renderCtx.HCO != nil {
-			p.eventRecorder.DriftCorrected(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName())
+			modifiedBy := FormatModifiers(modifiers)
+			p.eventRecorder.DriftCorrected(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), modifiedBy)
+			if HasManagedByLabel(live) {
+				p.eventRecorder.ObjectDriftCorrected(live, renderCtx.HCO, assetMeta.Name, modifiedBy)
+			} else {
+				p.eventRecorder.ObjectAdopted(live, renderCtx.HCO, assetMeta.Name)
+			}
+		}
+		if liveExists {
+			p.reportDroppedFields(ctx, assetMeta, desired, live, renderCtx)
 		}
+		if !liveExists {
+			p.recordRecreation(ctx, assetMeta, desired, renderCtx)
+		}
+		if p.history != nil {
+			p.history.Record(ctx, assetMeta.Name, desired)
+		}
+		// Deprecated assets warn on every apply so the removal plan stays visible
+		if notice := assetMeta.DeprecationNotice(); notice != "" {
+			logger.Info("Applied deprecated asset", "notice", notice)
+			if p.eventRecorder != nil && renderCtx.HCO != nil {
+				p.eventRecorder.DeprecatedAsset(renderCtx.HCO, assetMeta.Name, notice)
+			}
+		}
+		p.reportCorrection(renderCtx, assetMeta, desired, ObjectApplied, "", "", modifiers)
 	} else {
 		// No drift detected or skipped - still compliant
 		observability.SetCompliance(desired, 1)
+		p.reportObject(renderCtx, assetMeta, desired, ObjectInSync, "", "")
 	}
 
 	return applied, nil
@@ -494,24 +833,23 @@ func (p *Patcher) ReconcileAssets(ctx context.Context, assetMetas []assets.Asset
 	// Opportunistically clean up stale throttle bucket entries (prevents memory leak)
 	// This runs once per reconciliation loop to remove entries for deleted resources
 	p.throttle.CleanupStale(throttling.DefaultTTL)
+	p.recreation.cleanup(time.Now())
 
 	appliedCount := 0
-	var failedAssets []string
-	var errors []error
+	assetErrs := &AssetErrors{Total: len(assetMetas)}
 
-	for i := range assetMetas {
-		applied, err := p.ReconcileAsset(ctx, &assetMetas[i], renderCtx)
+	record := func(name string, applied bool, err error) {
 		if err != nil {
-			// Collect error and failed asset name
-			errors = append(errors, err)
-			failedAssets = append(failedAssets, assetMetas[i].Name)
+			// Collect the failure, classified, and continue with other assets
+			reason := p.ReportAssetError(renderCtx, name, err)
+			assetErrs.Failures = append(assetErrs.Failures, AssetFailure{Asset: name, Reason: reason, Err: err})
 
-			// Continue with other assets even if one fails
 			log.FromContext(ctx).Error(err, "Failed to reconcile asset, continuing with others",
-				"asset", assetMetas[i].Name,
-				"failedSoFar", len(failedAssets),
+				"asset", name,
+				"reason", reason,
+				"failedSoFar", len(assetErrs.Failures),
 			)
-			continue
+			return
 		}
 
 		if applied {
@@ -519,20 +857,55 @@ func (p *Patcher) ReconcileAssets(ctx context.Context, assetMetas []assets.Asset
 		}
 	}
 
+	// Every asset runs under its own timeout, and all of them under the pass timeout,
+	// so a hung API call fails that asset instead of blocking the ones after it
+	passCtx := ctx
+	if p.timeouts.Total > 0 {
+		var cancel context.CancelFunc
+		passCtx, cancel = context.WithTimeout(ctx, p.timeouts.Total)
+		defer cancel()
+	}
+	var timedOut []string
+	reconcileWithTimeout := func(name string, reconcileFn func(context.Context) (bool, error)) {
+		if passCtx.Err() != nil {
+			timedOut = append(timedOut, name)
+			record(name, false, fmt.Errorf("%w: not attempted, reconcile timeout of %s reached", errAssetTimeout, p.timeouts.Total))
+			return
+		}
+		assetCtx, cancel := p.WithAssetTimeout(passCtx)
+		defer cancel()
+		applied, err := reconcileFn(assetCtx)
+		if timeoutErr := p.assetTimeoutError(passCtx, assetCtx, err); timeoutErr != nil {
+			timedOut = append(timedOut, name)
+			observability.IncApplyTimeout(name)
+			err = timeoutErr
+		}
+		record(name, applied, err)
+	}
+
+	for i := range assetMetas {
+		assetMeta := &assetMetas[i]
+		reconcileWithTimeout(assetMeta.Name, func(assetCtx context.Context) (bool, error) {
+			return p.ReconcileAsset(assetCtx, assetMeta, renderCtx)
+		})
+	}
+
+	// Apply the reboot-triggering changes held back during the pass, unless there are too
+	// many or a canary rollout holds them back
+	for _, h := range p.canaryRelease(passCtx, renderCtx, p.releaseHeld(passCtx, renderCtx)) {
+		reconcileWithTimeout(h.assetMeta.Name, func(assetCtx context.Context) (bool, error) {
+			assetCtx, _ = p.withAssetLogger(assetCtx, &h.assetMeta)
+			return p.applyDesired(assetCtx, &h.assetMeta, h.desired, h.live, h.liveExists, renderCtx)
+		})
+	}
+
 	// Return aggregated error if any assets failed
 	// This ensures reconciliation fails and retries, but only after attempting all assets
-	if len(errors) > 0 {
-		// Build detailed error message with all failures
-		var errMsgs []string
-		for i, err := range errors {
-			errMsgs = append(errMsgs, fmt.Sprintf("[%s: %v]", failedAssets[i], err))
+	if len(assetErrs.Failures) > 0 {
+		if len(timedOut) > 0 {
+			return appliedCount, &TimeoutError{Assets: timedOut, err: assetErrs}
 		}
-
-		return appliedCount, fmt.Errorf("failed to reconcile %d/%d assets: %s",
-			len(failedAssets),
-			len(assetMetas),
-			strings.Join(errMsgs, "; "),
-		)
+		return appliedCount, assetErrs
 	}
 
 	return appliedCount, nil
@@ -586,3 +959,29 @@ func countJSONPatchOperations(patchStr string) int {
 	}
 	return len(patch)
 }
+
+// countPatchOperations counts the operations of a patch in the given format. Each leaf
+// of a merge or strategic merge patch sets or deletes one field, so it counts as one.
+func countPatchOperations(patchType, patchStr string) int {
+	if patchType == overrides.PatchTypeJSON {
+		return countJSONPatchOperations(patchStr)
+	}
+	var patch map[string]any
+	if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+		return 0
+	}
+	return countMergePatchLeaves(patch)
+}
+
+// countMergePatchLeaves counts the non-object values below a merge patch object
+func countMergePatchLeaves(patch map[string]any) int {
+	count := 0
+	for _, value := range patch {
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			count += countMergePatchLeaves(nested)
+			continue
+		}
+		count++
+	}
+	return count
+}
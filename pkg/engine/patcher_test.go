@@ -26,7 +26,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -34,8 +33,10 @@ import (
 	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
 )
 
 // failingDriftChecker always returns an error simulating a broken webhook or TLS failure.
@@ -52,15 +53,6 @@ func (a *alwaysDriftChecker) DetectDrift(_ context.Context, _, _ *unstructured.U
 	return true, nil
 }
 
-// countingRecorder implements events.EventRecorder and counts calls by reason.
-type countingRecorder struct {
-	counts map[string]int
-}
-
-func (r *countingRecorder) Eventf(_ runtime.Object, _ runtime.Object, _, reason, _, _ string, _ ...any) {
-	r.counts[reason]++
-}
-
 // TestDriftDetectionFailureSetsComplianceToZero verifies that when the SSA dry-run used
 // for drift detection fails persistently (e.g. webhook down), compliance_status is set to 0
 // so that VirtPlatformSyncFailed can fire. Before the fix, the error path returned without
@@ -255,7 +247,7 @@ func TestThrashingEventEmittedOnlyOnce(t *testing.T) {
 	live := desired.DeepCopy()
 	fakeClient := fake.NewClientBuilder().WithObjects(live).Build()
 
-	rec := &countingRecorder{counts: make(map[string]int)}
+	rec := eventtest.NewRecorder()
 
 	// Token bucket of capacity 1, long window so no refill during the test.
 	// Call 1 consumes the only token; calls 2+ are all throttled.
@@ -277,7 +269,7 @@ func TestThrashingEventEmittedOnlyOnce(t *testing.T) {
 		p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
 	}
 
-	if got := rec.counts[util.EventReasonThrashingDetected]; got != 1 {
+	if got := rec.Count(util.EventReasonThrashingDetected); got != 1 {
 		t.Errorf("ThrashingDetected event count = %d after %d calls, want 1 (must fire only once per episode)",
 			got, totalCalls)
 	}
@@ -405,7 +397,7 @@ func TestInvalidIgnoreFieldsEmitsEvent(t *testing.T) {
 	live.SetAnnotations(annotations)
 
 	fakeClient := fake.NewClientBuilder().WithObjects(live).Build()
-	rec := &countingRecorder{counts: make(map[string]int)}
+	rec := eventtest.NewRecorder()
 
 	p := &Patcher{
 		renderer:          renderer,
@@ -424,7 +416,7 @@ func TestInvalidIgnoreFieldsEmitsEvent(t *testing.T) {
 	if !strings.Contains(reconcileErr.Error(), "must start with /") {
 		t.Errorf("unexpected error: %v", reconcileErr)
 	}
-	if got := rec.counts[util.EventReasonInvalidIgnoreFields]; got != 1 {
+	if got := rec.Count(util.EventReasonInvalidIgnoreFields); got != 1 {
 		t.Errorf("InvalidIgnoreFields event count = %d, want 1", got)
 	}
 }
@@ -544,3 +536,298 @@ func TestCountJSONPatchOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestCountPatchOperations(t *testing.T) {
+	tests := []struct {
+		name      string
+		patchType string
+		patchStr  string
+		want      int
+	}{
+		{"JSON Patch", overrides.PatchTypeJSON, `[{"op": "remove", "path": "/a"}, {"op": "remove", "path": "/b"}]`, 2},
+		{"merge leaves", overrides.PatchTypeMerge, `{"spec": {"replicas": 3, "paused": null}, "metadata": {"labels": {"a": "b"}}}`, 3},
+		{"strategic list counts once", overrides.PatchTypeStrategic, `{"spec": {"containers": [{"name": "a"}, {"name": "b"}]}}`, 1},
+		{"empty object set", overrides.PatchTypeMerge, `{"spec": {"selector": {}}}`, 1},
+		{"invalid merge patch", overrides.PatchTypeMerge, `[]`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countPatchOperations(tt.patchType, tt.patchStr); got != tt.want {
+				t.Errorf("countPatchOperations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestManagedObjectEvents verifies that correcting a managed object records DriftCorrected
+// on the object itself, and that taking over an unlabeled object records Adopted instead.
+func TestManagedObjectEvents(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+	name := desired.GetName()
+
+	tests := []struct {
+		name       string
+		labeled    bool
+		wantReason string
+	}{
+		{"managed object", true, util.EventReasonDriftCorrected},
+		{"unlabeled object", false, util.EventReasonAdopted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := desired.DeepCopy()
+			if !tt.labeled {
+				live.SetLabels(map[string]string{"machineconfiguration.openshift.io/role": "worker"})
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(live).Build()
+			rec := eventtest.NewRecorder()
+
+			p := &Patcher{
+				renderer:          renderer,
+				applier:           NewApplier(fakeClient, nil),
+				driftDetector:     &alwaysDriftChecker{},
+				throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+				thrashingDetector: throttling.NewThrashingDetector(),
+				client:            fakeClient,
+			}
+			p.SetEventRecorder(util.NewEventRecorder(rec))
+
+			applied, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
+			if err != nil || !applied {
+				t.Fatalf("ReconcileAsset() = %v, %v; want applied", applied, err)
+			}
+
+			// The HCO keeps its DriftCorrected event; the object gets its own
+			rec.ExpectEvent(t, util.EventReasonDriftCorrected, hco.GetKind(), hco.GetName())
+			rec.ExpectEvent(t, tt.wantReason, desired.GetKind(), name)
+			if !tt.labeled {
+				rec.ExpectNoEvent(t, util.EventReasonDriftCorrected, desired.GetKind(), name)
+			}
+		})
+	}
+}
+
+// TestMaintenanceWindowSuspendsCorrection verifies that drift is still detected but neither
+// corrected nor rebooting objects created while maintenance-until is in the future.
+func TestMaintenanceWindowSuspendsCorrection(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	hco.SetAnnotations(map[string]string{
+		overrides.AnnotationMaintenanceUntil: time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		live []client.Object
+	}{
+		{"drifted object", []client.Object{desired.DeepCopy()}},
+		{"missing MachineConfig", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.live...).Build()
+			rec := eventtest.NewRecorder()
+			p := &Patcher{
+				renderer:          renderer,
+				applier:           NewApplier(fakeClient, nil),
+				driftDetector:     &alwaysDriftChecker{},
+				throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+				thrashingDetector: throttling.NewThrashingDetector(),
+				client:            fakeClient,
+			}
+			p.SetEventRecorder(util.NewEventRecorder(rec))
+
+			applied, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
+			if err != nil || applied {
+				t.Fatalf("ReconcileAsset() in maintenance = %v, %v; want not applied", applied, err)
+			}
+			if len(tt.live) > 0 && rec.Count(util.EventReasonDriftDetected) != 1 {
+				t.Errorf("DriftDetected events = %d, want 1", rec.Count(util.EventReasonDriftDetected))
+			}
+			if rec.Count(util.EventReasonDriftCorrected) != 0 {
+				t.Error("drift corrected during the maintenance window")
+			}
+
+			// An expired window no longer holds anything back
+			expired := renderCtx.HCO.DeepCopy()
+			expired.SetAnnotations(map[string]string{
+				overrides.AnnotationMaintenanceUntil: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			})
+			expiredCtx := pkgcontext.NewRenderContext(expired)
+			applied, err = p.ReconcileAsset(context.Background(), assetMeta, expiredCtx)
+			if err != nil || !applied {
+				t.Fatalf("ReconcileAsset() after expiry = %v, %v; want applied", applied, err)
+			}
+		})
+	}
+}
+
+// TestReportOnlySuspendsApply verifies that report-only mode detects drift but neither
+// corrects it nor creates missing objects, and that leaving it applies again.
+func TestReportOnlySuspendsApply(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged"))
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		live []client.Object
+	}{
+		{"drifted object", []client.Object{desired.DeepCopy()}},
+		{"missing object", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.live...).Build()
+			rec := eventtest.NewRecorder()
+			p := &Patcher{
+				renderer:          renderer,
+				applier:           NewApplier(fakeClient, nil),
+				driftDetector:     &alwaysDriftChecker{},
+				throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+				thrashingDetector: throttling.NewThrashingDetector(),
+				client:            fakeClient,
+			}
+			p.SetEventRecorder(util.NewEventRecorder(rec))
+			p.SetReportOnly("HyperConverged is served at v2, newer than the supported versions v1, v1beta1")
+
+			applied, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
+			if err != nil || applied {
+				t.Fatalf("ReconcileAsset() in report-only mode = %v, %v; want not applied", applied, err)
+			}
+			if len(tt.live) > 0 && rec.Count(util.EventReasonDriftDetected) != 1 {
+				t.Errorf("DriftDetected events = %d, want 1", rec.Count(util.EventReasonDriftDetected))
+			}
+			if rec.Count(util.EventReasonDriftCorrected) != 0 {
+				t.Error("drift corrected in report-only mode")
+			}
+
+			p.SetReportOnly("")
+			applied, err = p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
+			if err != nil || !applied {
+				t.Fatalf("ReconcileAsset() after report-only mode = %v, %v; want applied", applied, err)
+			}
+		})
+	}
+}
+
+// TestOverridesConfigMapPatch verifies that an asset's patch from the overrides ConfigMap
+// applies on creation without being written to the object, and that a patch annotation
+// on the live object takes precedence.
+func TestOverridesConfigMapPatch(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "metrics-service",
+		Path:      "active/observability/metrics-service.yaml.tpl",
+		Component: "Service",
+	}
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	renderCtx.OverridePatches = map[string]overrides.AssetPatch{
+		"metrics-service": {Type: overrides.PatchTypeMerge, Patch: `{"metadata":{"labels":{"team":"virt"}}}`},
+	}
+
+	namespace := &unstructured.Unstructured{}
+	namespace.SetAPIVersion("v1")
+	namespace.SetKind("Namespace")
+	namespace.SetName(hco.GetNamespace())
+
+	newPatcher := func(objects ...client.Object) (*Patcher, client.Client) {
+		fakeClient := fake.NewClientBuilder().WithObjects(append(objects, namespace)...).Build()
+		return &Patcher{
+			renderer:          renderer,
+			applier:           NewApplier(fakeClient, nil),
+			driftDetector:     &alwaysDriftChecker{},
+			throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+			thrashingDetector: throttling.NewThrashingDetector(),
+			client:            fakeClient,
+		}, fakeClient
+	}
+	getService := func(t *testing.T, c client.Client, desired *unstructured.Unstructured) *unstructured.Unstructured {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(desired), live); err != nil {
+			t.Fatalf("failed to get %s: %v", desired.GetName(), err)
+		}
+		return live
+	}
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+
+	t.Run("applied on creation", func(t *testing.T) {
+		p, c := newPatcher()
+		if applied, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx); err != nil || !applied {
+			t.Fatalf("ReconcileAsset() = %v, %v; want applied", applied, err)
+		}
+		live := getService(t, c, desired)
+		if live.GetLabels()["team"] != "virt" {
+			t.Errorf("labels = %v, want the ConfigMap patch applied", live.GetLabels())
+		}
+		if _, ok := live.GetAnnotations()[overrides.PatchAnnotation]; ok {
+			t.Error("ConfigMap patch was written to the object as an annotation")
+		}
+	})
+
+	t.Run("patch annotation wins", func(t *testing.T) {
+		existing := desired.DeepCopy()
+		existing.SetAnnotations(map[string]string{
+			overrides.PatchAnnotation: `[{"op": "add", "path": "/metadata/labels/owner", "value": "me"}]`,
+		})
+		p, c := newPatcher(existing)
+		if _, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx); err != nil {
+			t.Fatalf("ReconcileAsset() error = %v", err)
+		}
+		labels := getService(t, c, desired).GetLabels()
+		if labels["owner"] != "me" || labels["team"] != "" {
+			t.Errorf("labels = %v, want only the annotation patch", labels)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+// PlanAction is what a reconcile would do with one object
+type PlanAction string
+
+const (
+	// PlanCreate means the object does not exist and would be created
+	PlanCreate PlanAction = "create"
+	// PlanUpdate means the object drifted and would be updated
+	PlanUpdate PlanAction = "update"
+	// PlanUnchanged means the object is in sync
+	PlanUnchanged PlanAction = "unchanged"
+	// PlanSkip means the asset or object is left alone; Reason says why
+	PlanSkip PlanAction = "skip"
+	// PlanDelete means a tombstoned object would be deleted
+	PlanDelete PlanAction = "delete"
+	// PlanError means the reconcile would fail for this asset or tombstone
+	PlanError PlanAction = "error"
+)
+
+// ObjectPlan is the planned action for the object of an asset or a tombstone
+type ObjectPlan struct {
+	Asset     string     `json:"asset,omitempty" yaml:"asset,omitempty"`
+	Component string     `json:"component,omitempty" yaml:"component,omitempty"`
+	Tombstone string     `json:"tombstone,omitempty" yaml:"tombstone,omitempty"`
+	Kind      string     `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Namespace string     `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string     `json:"name,omitempty" yaml:"name,omitempty"`
+	Action    PlanAction `json:"action" yaml:"action"`
+	// Reason says why an object is skipped or, classifying the failure, why it fails
+	Reason Reason `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Message details the reason for humans, e.g. the error
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Warnings are problems the reconcile would report without failing, e.g. an invalid patch
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	// Fields lists the field paths an update changes
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	// Object is the object as the API server would store it after the apply
+	Object *unstructured.Unstructured `json:"object,omitempty" yaml:"object,omitempty"`
+}
+
+// Planner computes what ReconcileAsset and ReconcileTombstones would do, step by step
+// the same way, without writing anything: applies are server-side dry-runs, and no
+// events, metrics or throttling state are recorded. Holds depending on the controller's
+// state (upgrade safe-mode, blast radius guard, canary rollout, anti-thrashing) are not
+// predicted; the maintenance window is.
+type Planner struct {
+	reader     client.Reader
+	renderer   *Renderer
+	drift      *DriftDetector
+	tombstones *TombstoneReconciler
+	mutators   []Mutator
+}
+
+// NewPlanner creates a planner reading live objects with reader and dry-running
+// applies with c. Pass an uncached reader so unlabeled objects, which the controller
+// adopts, are found.
+func NewPlanner(c client.Client, reader client.Reader, loader *assets.Loader) *Planner {
+	return &Planner{
+		reader:     reader,
+		renderer:   NewRenderer(loader),
+		drift:      NewDriftDetector(c),
+		tombstones: NewTombstoneReconciler(c, loader),
+	}
+}
+
+// SetMutators sets the cluster-wide apply mutators the controller runs
+func (p *Planner) SetMutators(mutators []Mutator) {
+	p.mutators = mutators
+}
+
+// PlanAsset plans the reconcile of an included asset
+//
+//nolint:gocognit // Mirrors the steps of ReconcileAsset
+func (p *Planner) PlanAsset(ctx context.Context, assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.RenderContext) ObjectPlan {
+	plan := ObjectPlan{Asset: assetMeta.Name, Component: assetMeta.Component}
+	fail := func(err error) ObjectPlan {
+		plan.Action = PlanError
+		plan.Reason = AssetErrorReason(err)
+		plan.Message = err.Error()
+		return plan
+	}
+	skip := func(reason Reason, message string) ObjectPlan {
+		plan.Action = PlanSkip
+		plan.Reason = reason
+		plan.Message = message
+		return plan
+	}
+
+	desired, err := p.renderer.RenderAsset(assetMeta, renderCtx)
+	plan.Warnings = renderCtx.Warnings(assetMeta.Name)
+	if reason, skipped := SkipReason(err); skipped {
+		return skip(ReasonTemplateSkipped, reason)
+	}
+	if err != nil {
+		return fail(&RenderError{Asset: assetMeta.Name, Err: err})
+	}
+	if desired == nil {
+		return skip(ReasonRenderedEmpty, "conditional template rendered empty")
+	}
+	plan.Kind, plan.Namespace, plan.Name = desired.GetKind(), desired.GetNamespace(), desired.GetName()
+	rendered := RenderedFields(desired)
+
+	if rules, err := ExclusionRulesFromObject(renderCtx.HCO); err != nil {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("invalid %s annotation ignored: %v", DisabledResourcesAnnotation, err))
+	} else if IsResourceExcluded(desired.GetKind(), desired.GetNamespace(), desired.GetName(), rules) {
+		return skip(ReasonDisabledResources, "excluded by "+DisabledResourcesAnnotation)
+	}
+	if exclusion := MatchingExclusion(renderCtx, assetMeta.Component, desired, time.Now()); exclusion != nil {
+		return skip(ReasonAutopilotExclusion, "excluded by AutopilotExclusion "+exclusion.Source)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	err = p.reader.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	liveExists := err == nil
+	if err != nil && !errors.IsNotFound(err) {
+		return fail(fmt.Errorf("failed to get live object: %w", dependencyError(desired.GroupVersionKind(), err)))
+	}
+
+	if liveExists && overrides.IsPaused(live) {
+		return skip(ReasonReconcilePaused, "paused after an edit war; remove "+overrides.AnnotationReconcilePaused+" to resume")
+	}
+	if liveExists && overrides.IsUnmanaged(live) {
+		return skip(ReasonUnmanaged, "")
+	}
+	if liveExists {
+		if manager, delegated, err := overrides.DelegatedTo(live); err != nil {
+			return fail(fmt.Errorf("invalid %s annotation: %w", overrides.AnnotationDelegateTo, err))
+		} else if delegated {
+			return skip(ReasonDelegated, "delegated to "+manager)
+		}
+	}
+
+	for _, m := range p.mutators {
+		if err := m.Mutate(ctx, assetMeta, desired, renderCtx); err != nil {
+			return fail(&RenderError{Asset: assetMeta.Name, Err: fmt.Errorf("mutator failed: %w", err)})
+		}
+	}
+
+	patchStr := ""
+	if liveExists {
+		patchStr = live.GetAnnotations()[overrides.PatchAnnotation]
+	}
+	if patchStr != "" {
+		patched := desired.DeepCopy()
+		annotations := patched.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[overrides.PatchAnnotation] = patchStr
+		if patchType, ok := live.GetAnnotations()[overrides.PatchTypeAnnotation]; ok {
+			annotations[overrides.PatchTypeAnnotation] = patchType
+		}
+		patched.SetAnnotations(annotations)
+
+		// Like the reconcile, an invalid patch is reported and the object applied without it
+		if err := overrides.ValidateAnnotations(patched); err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("invalid %s annotation ignored: %v", overrides.PatchAnnotation, err))
+		} else if _, err := overrides.ApplyPatch(patched); err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("failed to apply %s annotation: %v", overrides.PatchAnnotation, err))
+		} else {
+			desired = patched
+		}
+	}
+	if patch, ok := renderCtx.OverridePatches[assetMeta.Name]; ok && !patch.Expired(time.Now()) && patchStr == "" {
+		if err := overrides.ApplyAssetPatch(desired, patch); err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("failed to apply overrides ConfigMap patch: %v", err))
+		}
+	}
+
+	if liveExists {
+		desired, err = overrides.MaskIgnoredFields(desired, live)
+		if err != nil {
+			return fail(fmt.Errorf("failed to mask ignored fields: %w", err))
+		}
+	}
+
+	ensureManagedByLabel(desired)
+	if _, recorded := recordedFields(live); !liveExists || recorded {
+		SetRenderedFields(desired, rendered)
+	}
+	if !liveExists || live.GetAnnotations()[DesiredHashAnnotation] != "" {
+		SetDesiredHash(desired)
+	}
+
+	applied, err := p.drift.DryRunApply(ctx, desired)
+	if err != nil {
+		return fail(dependencyError(desired.GroupVersionKind(), err))
+	}
+
+	plan.Action = PlanCreate
+	if liveExists {
+		plan.Fields = DriftedFields(applied, live)
+		if len(plan.Fields) == 0 {
+			plan.Action = PlanUnchanged
+			return plan
+		}
+		plan.Action = PlanUpdate
+	}
+	plan.Object = applied
+
+	// Drift is not corrected during a maintenance window, and node-rebooting objects
+	// are not created either; the pending change is still shown
+	if (liveExists || triggersReboot(desired)) && overrides.InMaintenance(renderCtx.HCO, time.Now()) {
+		plan.Action = PlanSkip
+		plan.Reason = ReasonMaintenanceWindow
+		plan.Message = "maintenance window open"
+	}
+	return plan
+}
+
+// PlanTombstones plans the tombstone cleanup: which tombstoned objects exist and
+// would be deleted, and which are kept because they lack the management label.
+// Tombstones whose object is already gone are not listed.
+func (p *Planner) PlanTombstones(ctx context.Context) ([]ObjectPlan, error) {
+	tombstones, err := p.tombstones.loader.LoadTombstones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tombstones: %w", err)
+	}
+
+	var plans []ObjectPlan
+	for _, ts := range tombstones {
+		plan := ObjectPlan{Tombstone: ts.Path, Kind: ts.GVK.Kind, Namespace: ts.Namespace, Name: ts.Name}
+		live, err := p.tombstones.lookupTombstone(ctx, ts)
+		switch {
+		case err != nil:
+			plan.Action = PlanError
+			plan.Reason = AssetErrorReason(err)
+			plan.Message = fmt.Sprintf("failed to get resource: %v", err)
+		case live == nil:
+			continue
+		case !tombstoneLabeled(live):
+			plan.Action = PlanSkip
+			plan.Reason = ReasonLabelMismatch
+			plan.Message = fmt.Sprintf("label mismatch - resource not managed by virt-platform-autopilot (%s=%q)",
+				assets.TombstoneLabel, live.GetLabels()[assets.TombstoneLabel])
+		default:
+			plan.Action = PlanDelete
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
+)
+
+var planTestAsset = pkgassets.AssetMetadata{
+	Name: "metrics-service", Path: "active/observability/metrics-service.yaml.tpl", Component: "Service",
+}
+
+// dropDryRunApplies drops dry-run applies, which the fake client persists, as the API
+// server would
+var dropDryRunApplies = interceptor.Funcs{
+	Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+		if slices.Contains(opts, client.ApplyOption(client.DryRunAll)) {
+			return nil
+		}
+		return c.Apply(ctx, obj, opts...)
+	},
+}
+
+// newTestPlanner returns a planner backed by a fake client
+func newTestPlanner(objects ...client.Object) (*Planner, client.Client) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-cnv"}}
+	c := fake.NewClientBuilder().
+		WithObjects(append(objects, namespace)...).
+		WithInterceptorFuncs(dropDryRunApplies).
+		Build()
+	return NewPlanner(c, c, pkgassets.NewLoader()), c
+}
+
+func TestPlanAssetCreateDoesNotWrite(t *testing.T) {
+	planner, c := newTestPlanner()
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+
+	plan := planner.PlanAsset(context.Background(), &planTestAsset, renderCtx)
+	if plan.Action != PlanCreate {
+		t.Fatalf("action = %s (%s), want %s", plan.Action, plan.Reason, PlanCreate)
+	}
+	if plan.Kind != "Service" || plan.Object == nil {
+		t.Fatalf("plan = %+v, want the Service to create", plan)
+	}
+	if !HasManagedByLabel(plan.Object) {
+		t.Error("planned object lacks the managed-by label the apply adds")
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(plan.Object.GroupVersionKind())
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(plan.Object), live); !errors.IsNotFound(err) {
+		t.Errorf("Get() error = %v, want NotFound: planning must not create the object", err)
+	}
+}
+
+func TestPlanAssetLiveObject(t *testing.T) {
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+
+	// Apply what the planner would create, so the live object is in sync
+	planner, c := newTestPlanner()
+	created := planner.PlanAsset(context.Background(), &planTestAsset, renderCtx)
+	if err := c.Apply(context.Background(), client.ApplyConfigurationFromUnstructured(created.Object.DeepCopy()),
+		client.ForceOwnership, client.FieldOwner(FieldManager)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if plan := planner.PlanAsset(context.Background(), &planTestAsset, renderCtx); plan.Action != PlanUnchanged {
+		t.Errorf("in sync: action = %s, fields %v, want %s", plan.Action, plan.Fields, PlanUnchanged)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(created.Object.GroupVersionKind())
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(created.Object), live); err != nil {
+		t.Fatal(err)
+	}
+	live.SetAnnotations(map[string]string{overrides.AnnotationMode: overrides.ModeUnmanaged})
+	if err := c.Update(context.Background(), live); err != nil {
+		t.Fatal(err)
+	}
+	if plan := planner.PlanAsset(context.Background(), &planTestAsset, renderCtx); plan.Action != PlanSkip || plan.Reason != ReasonUnmanaged {
+		t.Errorf("unmanaged: action = %s (%s), want skip (%s)", plan.Action, plan.Reason, ReasonUnmanaged)
+	}
+}
+
+func TestPlanAssetRenderError(t *testing.T) {
+	planner, _ := newTestPlanner()
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv"))
+	missing := pkgassets.AssetMetadata{Name: "missing", Path: "active/does-not-exist.yaml.tpl", Component: "Service"}
+
+	plan := planner.PlanAsset(context.Background(), &missing, renderCtx)
+	if plan.Action != PlanError || plan.Reason != ReasonRenderFailed || plan.Message == "" {
+		t.Errorf("plan = %s/%s (%q), want error/%s with the error", plan.Action, plan.Reason, plan.Message, ReasonRenderFailed)
+	}
+}
+
+func TestPlanTombstones(t *testing.T) {
+	labeled := &unstructured.Unstructured{}
+	labeled.SetAPIVersion("observability.openshift.io/v1alpha1")
+	labeled.SetKind("UIPlugin")
+	labeled.SetName("kubevirt-plugin")
+	labeled.SetLabels(map[string]string{pkgassets.TombstoneLabel: pkgassets.TombstoneLabelValue})
+
+	planner, c := newTestPlanner(labeled)
+	plans, err := planner.PlanTombstones(context.Background())
+	if err != nil {
+		t.Fatalf("PlanTombstones() error = %v", err)
+	}
+	if len(plans) != 1 || plans[0].Action != PlanDelete || plans[0].Name != "kubevirt-plugin" {
+		t.Fatalf("plans = %+v, want only the UIPlugin deleted", plans)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(labeled), labeled.DeepCopy()); err != nil {
+		t.Errorf("planning must not delete: %v", err)
+	}
+
+	labeled.SetLabels(nil)
+	if err := c.Update(context.Background(), labeled); err != nil {
+		t.Fatal(err)
+	}
+	plans, err = planner.PlanTombstones(context.Background())
+	if err != nil {
+		t.Fatalf("PlanTombstones() error = %v", err)
+	}
+	if len(plans) != 1 || plans[0].Action != PlanSkip {
+		t.Errorf("plans = %+v, want the unlabeled UIPlugin skipped", plans)
+	}
+}
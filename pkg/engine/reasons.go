@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import "github.com/kubevirt/virt-platform-autopilot/pkg/util"
+
+// Reason is a stable, machine-parseable code saying why an asset or object is in its
+// state: why it was excluded, held back or skipped, or why it failed (ErrorReason). It
+// is the reason of render outputs, reconcile plans, asset inclusion results and the
+// ManagedResource status. Codes are CamelCase like Kubernetes event and condition
+// reasons, and where an event reports the same situation they share its reason. They
+// never change once released, so UIs can localize and categorize them; the message
+// next to a reason is free-form English for humans and carries no contract.
+type Reason string
+
+// Reasons an asset is not rendered or its object not applied
+const (
+	// ReasonAutopilotDisabled means the HCO does not opt in to the autopilot
+	ReasonAutopilotDisabled Reason = "AutopilotDisabled"
+	// ReasonPhaseDisabled means the asset's phase is in the HCO's disabled-phases annotation
+	ReasonPhaseDisabled Reason = "PhaseDisabled"
+	// ReasonNotInAllowlist means the HCO's asset allowlist does not name the asset or its group
+	ReasonNotInAllowlist Reason = "NotInAllowlist"
+	// ReasonCRDMissing means a CRD the asset requires or is gated on is not installed
+	ReasonCRDMissing Reason = util.EventReasonCRDMissing
+	// ReasonConditionsNotMet means the asset's conditions are not satisfied
+	ReasonConditionsNotMet Reason = "ConditionsNotMet"
+	// ReasonTemplateSkipped means the template rendered the skip sentinel; the message
+	// holds the template's own reason
+	ReasonTemplateSkipped Reason = "TemplateSkipped"
+	// ReasonRenderedEmpty means a conditional template rendered nothing
+	ReasonRenderedEmpty Reason = "RenderedEmpty"
+	// ReasonDisabledResources means the HCO's disabled-resources annotation excludes the object
+	ReasonDisabledResources Reason = "DisabledResources"
+	// ReasonAutopilotExclusion means an AutopilotExclusion excludes the object
+	ReasonAutopilotExclusion Reason = "AutopilotExclusion"
+	// ReasonSharedObjectOwner means the object is cluster-scoped or outside the HCO's
+	// namespace, and another HCO is the one that applies shared objects
+	ReasonSharedObjectOwner Reason = "SharedObjectOwner"
+	// ReasonNotServedDuringInstallation means the object's API is not available while the
+	// cluster installs, so render bootstrap leaves it to the controller
+	ReasonNotServedDuringInstallation Reason = "NotServedDuringInstallation"
+)
+
+// Reasons the live object is left alone
+const (
+	// ReasonUnmanaged means the object opted out with mode: unmanaged
+	ReasonUnmanaged Reason = util.EventReasonUnmanagedMode
+	// ReasonReconcilePaused means reconciliation was paused after an edit war
+	ReasonReconcilePaused Reason = "ReconcilePaused"
+	// ReasonDelegated means the object was handed over to a GitOps tool with delegate-to
+	ReasonDelegated Reason = util.EventReasonOwnershipDelegated
+	// ReasonLabelMismatch means a tombstoned object lacks the management label and is kept
+	ReasonLabelMismatch Reason = "LabelMismatch"
+	// ReasonUnchangedSinceLastApply means differential sync skipped the drift check of an
+	// object whose desired state did not change since the previous operator applied it
+	ReasonUnchangedSinceLastApply Reason = "UnchangedSinceLastApply"
+)
+
+// Reasons a needed apply is held back
+const (
+	// ReasonReportOnly means the controller runs in report-only mode
+	ReasonReportOnly Reason = "ReportOnly"
+	// ReasonMaintenanceWindow means the HCO's maintenance window is open
+	ReasonMaintenanceWindow Reason = "MaintenanceWindow"
+	// ReasonUpgradeInProgress means upgrade safe-mode defers a node-rebooting change
+	ReasonUpgradeInProgress Reason = util.EventReasonApplyDeferred
+	// ReasonRecreationCooldown means an object that keeps being deleted is left deleted for a while
+	ReasonRecreationCooldown Reason = util.EventReasonRecreationCooldown
+	// ReasonBlastRadius means a node-rebooting change waits for the blast radius guard
+	ReasonBlastRadius Reason = util.EventReasonBlastRadiusExceeded
+	// ReasonCanaryRollout means a node-rebooting change waits for, or was rolled back by,
+	// the canary rollout
+	ReasonCanaryRollout Reason = "CanaryRollout"
+	// ReasonNamespaceMissing means the object's target namespace does not exist yet
+	ReasonNamespaceMissing Reason = "NamespaceMissing"
+	// ReasonAdmissionUnavailable means the apply is retried because admission was unavailable
+	ReasonAdmissionUnavailable Reason = util.EventReasonAdmissionUnavailable
+	// ReasonApplyingInBackground means the apply runs in the background with a longer timeout
+	ReasonApplyingInBackground Reason = "ApplyingInBackground"
+)
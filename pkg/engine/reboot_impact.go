@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// PoolImpact is the rollout a batch of changes causes in one MachineConfigPool
+type PoolImpact struct {
+	Pool  string
+	Nodes int
+	// Resources are the changes that roll out to the pool, as kind/name
+	Resources []string
+}
+
+// RebootImpact predicts which MachineConfigPools a batch of reboot-triggering changes
+// updates, i.e. how many nodes drain and reboot once it is applied
+type RebootImpact struct {
+	// Changes is the number of reboot-triggering objects in the batch
+	Changes int
+	// Pools are the pools the batch rolls out to, sorted by name
+	Pools []PoolImpact
+	// Nodes is the total number of nodes of those pools
+	Nodes int
+	// Unmatched lists changes that select no pool; they reboot nothing until a pool selects them
+	Unmatched []string
+	// Fingerprint identifies the batch, as in the BlastRadiusExceeded event
+	Fingerprint string
+	// Held is true when the batch was held back by the blast radius guard
+	Held bool
+}
+
+// PredictRebootImpact matches every change against the pools the way the Machine
+// Config Operator does: a MachineConfig rolls out to the pools whose
+// machineConfigSelector matches its labels, a KubeletConfig or ContainerRuntimeConfig
+// to the pools its machineConfigPoolSelector matches. A nil or invalid selector
+// matches nothing. A node belongs to a single pool, so node counts add up.
+func PredictRebootImpact(changes []*unstructured.Unstructured, pools []pkgcontext.MachineConfigPool) *RebootImpact {
+	impact := &RebootImpact{Changes: len(changes)}
+	byPool := make(map[string]*PoolImpact)
+
+	for _, change := range changes {
+		ref := change.GetKind() + "/" + change.GetName()
+		matched := false
+		for _, pool := range pools {
+			if !changeTargetsPool(change, pool) {
+				continue
+			}
+			matched = true
+			entry, ok := byPool[pool.Name]
+			if !ok {
+				entry = &PoolImpact{Pool: pool.Name, Nodes: pool.MachineCount}
+				byPool[pool.Name] = entry
+			}
+			entry.Resources = append(entry.Resources, ref)
+		}
+		if !matched {
+			impact.Unmatched = append(impact.Unmatched, ref)
+		}
+	}
+
+	for _, entry := range byPool {
+		impact.Pools = append(impact.Pools, *entry)
+		impact.Nodes += entry.Nodes
+	}
+	sort.Slice(impact.Pools, func(i, j int) bool { return impact.Pools[i].Pool < impact.Pools[j].Pool })
+	return impact
+}
+
+// changeTargetsPool reports whether applying change updates pool
+func changeTargetsPool(change *unstructured.Unstructured, pool pkgcontext.MachineConfigPool) bool {
+	if change.GetKind() == "MachineConfig" {
+		return selectorMatches(pool.MachineConfigSelector, change.GetLabels())
+	}
+	raw, found, _ := unstructured.NestedMap(change.Object, "spec", "machineConfigPoolSelector")
+	if !found {
+		return false
+	}
+	selector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, selector); err != nil {
+		return false
+	}
+	return selectorMatches(selector, pool.Labels)
+}
+
+// selectorMatches applies a label selector; nil selects nothing, as in the MCO
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string) bool {
+	if selector == nil {
+		return false
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
+
+// Summary describes the impact in one line, e.g. "worker (12 nodes), infra (3 nodes)"
+func (i *RebootImpact) Summary() string {
+	if len(i.Pools) == 0 {
+		return "no MachineConfigPool"
+	}
+	parts := make([]string, len(i.Pools))
+	for j, pool := range i.Pools {
+		parts[j] = fmt.Sprintf("%s (%d nodes)", pool.Pool, pool.Nodes)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+const roleLabel = "machineconfiguration.openshift.io/role"
+
+// testPools are the default master and worker pools plus a custom infra pool, which
+// like the MCO documentation's example also renders the worker MachineConfigs
+func testPools() []pkgcontext.MachineConfigPool {
+	rolePool := func(name string, nodes int, roles ...string) pkgcontext.MachineConfigPool {
+		return pkgcontext.MachineConfigPool{
+			Name:   name,
+			Labels: map[string]string{"pools.operator.machineconfiguration.openshift.io/" + name: ""},
+			MachineConfigSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: roleLabel, Operator: metav1.LabelSelectorOpIn, Values: roles},
+			}},
+			MachineCount: nodes,
+		}
+	}
+	return []pkgcontext.MachineConfigPool{
+		rolePool("infra", 3, "worker", "infra"),
+		rolePool("master", 3, "master"),
+		rolePool("worker", 9, "worker"),
+	}
+}
+
+func newTestRoleMachineConfig(name, role string) *unstructured.Unstructured {
+	mc := newTestMachineConfig(name)
+	if role != "" {
+		mc.SetLabels(map[string]string{roleLabel: role})
+	}
+	return mc
+}
+
+func newTestKubeletConfig(name, pool string) *unstructured.Unstructured {
+	kc := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "KubeletConfig",
+		"metadata":   map[string]any{"name": name},
+		"spec": map[string]any{
+			"machineConfigPoolSelector": map[string]any{
+				"matchLabels": map[string]any{"pools.operator.machineconfiguration.openshift.io/" + pool: ""},
+			},
+		},
+	}}
+	return kc
+}
+
+func TestPredictRebootImpact(t *testing.T) {
+	tests := []struct {
+		name          string
+		changes       []*unstructured.Unstructured
+		wantPools     []string
+		wantNodes     int
+		wantUnmatched []string
+	}{
+		{
+			name:      "worker MachineConfig also rolls out to pools rendering the worker role",
+			changes:   []*unstructured.Unstructured{newTestRoleMachineConfig("50-worker", "worker")},
+			wantPools: []string{"infra", "worker"},
+			wantNodes: 12,
+		},
+		{
+			name:      "master MachineConfig",
+			changes:   []*unstructured.Unstructured{newTestRoleMachineConfig("50-master", "master")},
+			wantPools: []string{"master"},
+			wantNodes: 3,
+		},
+		{
+			name:      "KubeletConfig selects pools by their labels",
+			changes:   []*unstructured.Unstructured{newTestKubeletConfig("kubelet", "worker")},
+			wantPools: []string{"worker"},
+			wantNodes: 9,
+		},
+		{
+			name: "a pool hit by several changes counts once",
+			changes: []*unstructured.Unstructured{
+				newTestRoleMachineConfig("50-worker", "worker"),
+				newTestKubeletConfig("kubelet", "worker"),
+			},
+			wantPools: []string{"infra", "worker"},
+			wantNodes: 12,
+		},
+		{
+			name:          "MachineConfig without a role selects no pool",
+			changes:       []*unstructured.Unstructured{newTestRoleMachineConfig("50-orphan", "")},
+			wantNodes:     0,
+			wantUnmatched: []string{"MachineConfig/50-orphan"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impact := PredictRebootImpact(tt.changes, testPools())
+			var pools []string
+			for _, pool := range impact.Pools {
+				pools = append(pools, pool.Pool)
+			}
+			if !reflect.DeepEqual(pools, tt.wantPools) {
+				t.Errorf("pools = %v, want %v", pools, tt.wantPools)
+			}
+			if impact.Nodes != tt.wantNodes {
+				t.Errorf("Nodes = %d, want %d", impact.Nodes, tt.wantNodes)
+			}
+			if !reflect.DeepEqual(impact.Unmatched, tt.wantUnmatched) {
+				t.Errorf("Unmatched = %v, want %v", impact.Unmatched, tt.wantUnmatched)
+			}
+			if impact.Changes != len(tt.changes) {
+				t.Errorf("Changes = %d, want %d", impact.Changes, len(tt.changes))
+			}
+		})
+	}
+}
+
+// TestRebootNodeLimit verifies that a batch within the change limit is still held when
+// it reboots more nodes than allowed, and that every batch is announced before it is applied
+func TestRebootNodeLimit(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	renderCtx.Upgrade = &pkgcontext.UpgradeContext{Pools: testPools()}
+	rec := eventtest.NewRecorder()
+
+	p := &Patcher{}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetMaxRebootNodes(10)
+
+	hold := func(changes ...*unstructured.Unstructured) {
+		for _, change := range changes {
+			if !p.blastRadius.hold(&pkgassets.AssetMetadata{Name: change.GetName()}, change, nil, false) {
+				t.Fatalf("hold(%s) = false, want true with only a node limit", change.GetName())
+			}
+		}
+	}
+
+	hold(newTestRoleMachineConfig("50-master", "master"))
+	if released := p.releaseHeld(context.Background(), renderCtx); len(released) != 1 {
+		t.Fatalf("released %d changes rebooting 3 nodes, want 1", len(released))
+	}
+	if got := rec.Count(util.EventReasonRebootImpact); got != 1 {
+		t.Errorf("RebootImpactPredicted event count = %d, want 1", got)
+	}
+	if impact := p.RebootImpact(); impact == nil || impact.Held || impact.Nodes != 3 {
+		t.Errorf("RebootImpact() = %+v, want 3 nodes, released", impact)
+	}
+
+	hold(newTestRoleMachineConfig("50-worker", "worker"))
+	if released := p.releaseHeld(context.Background(), renderCtx); released != nil {
+		t.Fatalf("released %d changes rebooting 12 nodes, want none", len(released))
+	}
+	impact := p.RebootImpact()
+	if impact == nil || !impact.Held || impact.Nodes != 12 {
+		t.Fatalf("RebootImpact() = %+v, want 12 nodes, held", impact)
+	}
+	if got := rec.Count(util.EventReasonBlastRadiusExceeded); got != 1 {
+		t.Errorf("BlastRadiusExceeded event count = %d, want 1", got)
+	}
+	if val := testutil.ToFloat64(observability.RebootImpactNodes); val != 12 {
+		t.Errorf("reboot_impact_nodes = %v, want 12", val)
+	}
+
+	// The acknowledged batch is released and announced
+	hco.SetAnnotations(map[string]string{BlastRadiusAckAnnotation: impact.Fingerprint})
+	hold(newTestRoleMachineConfig("50-worker", "worker"))
+	if released := p.releaseHeld(context.Background(), renderCtx); len(released) != 1 {
+		t.Fatalf("released %d acknowledged changes, want 1", len(released))
+	}
+	if got := rec.Count(util.EventReasonRebootImpact); got != 2 {
+		t.Errorf("RebootImpactPredicted event count = %d, want 2", got)
+	}
+
+	// A pass without reboot-triggering changes clears the prediction
+	p.releaseHeld(context.Background(), renderCtx)
+	if impact := p.RebootImpact(); impact != nil {
+		t.Errorf("RebootImpact() = %+v after an empty pass, want nil", impact)
+	}
+}
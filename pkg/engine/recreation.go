@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+)
+
+// RecreationLimits bound how often a managed object deleted by someone else is recreated.
+// The watch reports the deletion, so the reconcile it triggers recreates the object right
+// away; after Max recreations within Window the object stays deleted for Cooldown, so
+// the autopilot does not fight a user or controller that keeps deleting it.
+type RecreationLimits struct {
+	// Max recreations of one object within Window; 0 recreates without limit
+	Max int
+	// Window is how far back recreations are counted
+	Window time.Duration
+	// Cooldown is how long the object is then left deleted
+	Cooldown time.Duration
+}
+
+// DefaultRecreationLimits returns the limits used by the controller unless configured
+func DefaultRecreationLimits() RecreationLimits {
+	return RecreationLimits{
+		Max:      3,
+		Window:   30 * time.Minute,
+		Cooldown: time.Hour,
+	}
+}
+
+// Validate rejects a negative limit and, with a limit, a window or cool-down that is not positive
+func (l RecreationLimits) Validate() error {
+	switch {
+	case l.Max < 0:
+		return fmt.Errorf("recreation limit must not be negative, got %d", l.Max)
+	case l.Max > 0 && l.Window <= 0:
+		return fmt.Errorf("recreation window must be positive, got %s", l.Window)
+	case l.Max > 0 && l.Cooldown <= 0:
+		return fmt.Errorf("recreation cool-down must be positive, got %s", l.Cooldown)
+	}
+	return nil
+}
+
+// ownDeletionTTL is how long a deletion the autopilot made itself waits for the watch
+// to report it; one the watch never reports (the object was already gone) is then dropped
+const ownDeletionTTL = 10 * time.Minute
+
+// recreationGuard remembers managed objects the watch saw deleted and counts their
+// recreations. Objects deleted while the operator was down are simply created again.
+type recreationGuard struct {
+	mu      sync.Mutex
+	limits  RecreationLimits
+	objects map[string]*recreationRecord // throttling.MakeResourceKey -> record
+	// own are the deletions the autopilot made itself (canary rollbacks, tombstones),
+	// which the watch reports like any other and which must not count as recreations
+	own map[string]ownDeletion // throttling.MakeResourceKey -> deletion
+}
+
+// ownDeletion is a deletion the autopilot made itself
+type ownDeletion struct {
+	uid types.UID // empty when the deleted object was not read first
+	at  time.Time
+}
+
+type recreationRecord struct {
+	// deletedAt is when the watch saw the object deleted; zero once it was recreated
+	deletedAt time.Time
+	// deletedBy hints at who removed the object, see lastModifier
+	deletedBy     string
+	recreations   []time.Time // within the window
+	cooldownUntil time.Time
+}
+
+// SetRecreationLimits sets how often deleted managed objects are recreated
+func (p *Patcher) SetRecreationLimits(limits RecreationLimits) {
+	p.recreation.mu.Lock()
+	defer p.recreation.mu.Unlock()
+	p.recreation.limits = limits
+}
+
+// ExpectDeletion records that the autopilot itself is deleting obj, so ObjectDeleted
+// does not count the deletion the watch reports next toward the recreation limits nor
+// blame it on the object's last modifier. Call cancel when the delete fails.
+func (p *Patcher) ExpectDeletion(obj *unstructured.Unstructured) (cancel func()) {
+	g := &p.recreation
+	key := recreationKey(obj)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.own == nil {
+		g.own = make(map[string]ownDeletion)
+	}
+	deletion := ownDeletion{uid: obj.GetUID(), at: time.Now()}
+	g.own[key] = deletion
+	return func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.own[key] == deletion {
+			delete(g.own, key)
+		}
+	}
+}
+
+// ObjectDeleted records that obj, as last seen by the watch, was deleted. Only objects
+// carrying the managed-by label are tracked, and deletions announced with ExpectDeletion
+// are skipped.
+func (p *Patcher) ObjectDeleted(obj *unstructured.Unstructured) {
+	if !HasManagedByLabel(obj) {
+		return
+	}
+	g := &p.recreation
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := recreationKey(obj)
+	if own, ok := g.own[key]; ok && (own.uid == "" || own.uid == obj.GetUID()) {
+		delete(g.own, key)
+		return
+	}
+	if g.objects == nil {
+		g.objects = make(map[string]*recreationRecord)
+	}
+	record, ok := g.objects[key]
+	if !ok {
+		record = &recreationRecord{}
+		g.objects[key] = record
+	}
+	record.deletedAt = time.Now()
+	record.deletedBy = lastModifier(obj)
+}
+
+// RecreationCooldownRemaining returns the time until the first recreation cool-down
+// ends, or 0 when no deleted object is waiting for one
+func (p *Patcher) RecreationCooldownRemaining() time.Duration {
+	g := &p.recreation
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := time.Now()
+	var remaining time.Duration
+	for _, record := range g.objects {
+		if record.deletedAt.IsZero() || !now.Before(record.cooldownUntil) {
+			continue
+		}
+		if left := record.cooldownUntil.Sub(now); remaining == 0 || left < remaining {
+			remaining = left
+		}
+	}
+	return remaining
+}
+
+// hold returns why the recreation of desired has to wait, or "" when it may go ahead.
+// started is true when this call began the cool-down.
+func (g *recreationGuard) hold(desired *unstructured.Unstructured, now time.Time) (reason string, started bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	record := g.objects[recreationKey(desired)]
+	if record == nil || record.deletedAt.IsZero() || g.limits.Max == 0 {
+		return "", false
+	}
+	if !record.cooldownUntil.IsZero() && !now.Before(record.cooldownUntil) {
+		// The cool-down is over: start counting afresh
+		record.cooldownUntil = time.Time{}
+		record.recreations = nil
+	}
+	record.recreations = recentRecreations(record.recreations, now.Add(-g.limits.Window))
+	if record.cooldownUntil.IsZero() && len(record.recreations) >= g.limits.Max {
+		record.cooldownUntil = now.Add(g.limits.Cooldown)
+		started = true
+	}
+	if record.cooldownUntil.IsZero() {
+		return "", false
+	}
+	return fmt.Sprintf("recreated %d times within %s, left deleted until %s",
+		len(record.recreations), g.limits.Window, record.cooldownUntil.UTC().Format(time.RFC3339)), started
+}
+
+// recreated records that desired was created again. ok is false when the watch did not
+// see it deleted, e.g. on its first creation; otherwise count is the recreations within
+// the window including this one, and deletedBy the hint recorded with the deletion.
+func (g *recreationGuard) recreated(desired *unstructured.Unstructured, now time.Time) (count int, deletedBy string, ok bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	record := g.objects[recreationKey(desired)]
+	if record == nil || record.deletedAt.IsZero() {
+		return 0, "", false
+	}
+	record.deletedAt = time.Time{}
+	if g.limits.Max > 0 {
+		record.recreations = recentRecreations(record.recreations, now.Add(-g.limits.Window))
+	}
+	record.recreations = append(record.recreations, now)
+	return len(record.recreations), record.deletedBy, true
+}
+
+// cleanup forgets objects with nothing left to count: no cool-down, no recreation within
+// the window and no deletion that is still to be recreated. A deleted object no asset
+// renders anymore, e.g. a tombstoned one, is forgotten once the window passed. Own
+// deletions the watch did not report within ownDeletionTTL are dropped too.
+func (g *recreationGuard) cleanup(now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key, own := range g.own {
+		if now.Sub(own.at) > ownDeletionTTL {
+			delete(g.own, key)
+		}
+	}
+	window := g.limits.Window
+	if g.limits.Max == 0 {
+		window = 0
+	}
+	since := now.Add(-window)
+	for key, record := range g.objects {
+		if now.Before(record.cooldownUntil) {
+			continue
+		}
+		if !record.deletedAt.IsZero() && record.deletedAt.After(since) {
+			continue
+		}
+		if len(recentRecreations(record.recreations, since)) > 0 {
+			continue
+		}
+		delete(g.objects, key)
+	}
+}
+
+// recentRecreations drops the recreations before since; the slice is in time order
+func recentRecreations(recreations []time.Time, since time.Time) []time.Time {
+	for i, t := range recreations {
+		if t.After(since) {
+			return recreations[i:]
+		}
+	}
+	return nil
+}
+
+func recreationKey(obj *unstructured.Unstructured) string {
+	return throttling.MakeResourceKey(obj.GetNamespace(), obj.GetName(), obj.GetKind())
+}
+
+// lastModifier names the field manager other than the autopilot that last changed obj,
+// e.g. "kubectl-edit (Update) at 2026-10-16T09:12:44Z". The API server records the
+// deleting user only in its audit log, so this is a hint: it is frequently whoever set
+// the finalizers or edited the object before deleting it. Empty when nobody else did.
+func lastModifier(obj *unstructured.Unstructured) string {
+	var manager, operation string
+	var last time.Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == FieldManager || entry.Manager == AbandonFieldManager || entry.Time == nil {
+			continue
+		}
+		if entry.Time.After(last) || manager == "" {
+			manager, operation, last = entry.Manager, string(entry.Operation), entry.Time.Time
+		}
+	}
+	if manager == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s) at %s", manager, operation, last.UTC().Format(time.RFC3339))
+}
+
+// recordRecreation reports the creation of desired when the watch saw it deleted before
+func (p *Patcher) recordRecreation(ctx context.Context, assetMeta *assets.AssetMetadata,
+	desired *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) {
+	count, deletedBy, ok := p.recreation.recreated(desired, time.Now())
+	if !ok {
+		return
+	}
+	p.recreation.mu.Lock()
+	limits := p.recreation.limits
+	p.recreation.mu.Unlock()
+
+	detail := fmt.Sprintf("recreation %d", count)
+	if limits.Max > 0 {
+		detail = fmt.Sprintf("recreation %d of %d within %s", count, limits.Max, limits.Window)
+	}
+	if deletedBy != "" {
+		detail += "; last changed by " + deletedBy
+	}
+	log.FromContext(ctx).Info("Recreated deleted managed object",
+		"name", assetMeta.Name,
+		"kind", desired.GetKind(),
+		"namespace", desired.GetNamespace(),
+		"objectName", desired.GetName(),
+		"detail", detail,
+	)
+	observability.IncRecreation(desired)
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.ObjectRecreated(renderCtx.HCO, desired.GetKind(), desired.GetNamespace(), desired.GetName(), detail)
+	}
+}
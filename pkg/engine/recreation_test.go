@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func TestRecreationLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  RecreationLimits
+		wantErr bool
+	}{
+		{name: "defaults", limits: DefaultRecreationLimits()},
+		{name: "unlimited", limits: RecreationLimits{}},
+		{name: "negative limit", limits: RecreationLimits{Max: -1}, wantErr: true},
+		{name: "limit without window", limits: RecreationLimits{Max: 3, Cooldown: time.Hour}, wantErr: true},
+		{name: "limit without cool-down", limits: RecreationLimits{Max: 3, Window: time.Hour}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecreationGuard(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName("tuning")
+	obj.SetLabels(map[string]string{ManagedByLabel: ManagedByValue})
+
+	p := &Patcher{}
+	p.SetRecreationLimits(RecreationLimits{Max: 2, Window: 10 * time.Minute, Cooldown: time.Hour})
+	g := &p.recreation
+	start := time.Now()
+
+	if reason, _ := g.hold(obj, start); reason != "" {
+		t.Fatalf("hold() = %q for an object never seen deleted", reason)
+	}
+	if _, _, ok := g.recreated(obj, start); ok {
+		t.Fatal("recreated() counted the first creation")
+	}
+
+	// Two deletions are recreated
+	for i := 1; i <= 2; i++ {
+		now := start.Add(time.Duration(i) * time.Minute)
+		p.ObjectDeleted(obj)
+		if reason, _ := g.hold(obj, now); reason != "" {
+			t.Fatalf("deletion %d: hold() = %q, want recreation", i, reason)
+		}
+		if count, _, ok := g.recreated(obj, now); !ok || count != i {
+			t.Fatalf("deletion %d: recreated() = %d, %v", i, count, ok)
+		}
+	}
+
+	// The third one within the window starts the cool-down, reported once
+	p.ObjectDeleted(obj)
+	now := start.Add(3 * time.Minute)
+	reason, started := g.hold(obj, now)
+	if !started || !strings.Contains(reason, "recreated 2 times within 10m0s") {
+		t.Fatalf("hold() = %q, %v, want the cool-down to start", reason, started)
+	}
+	if reason, started = g.hold(obj, now.Add(time.Minute)); reason == "" || started {
+		t.Errorf("hold() during the cool-down = %q, %v, want held without a new start", reason, started)
+	}
+	if remaining := p.RecreationCooldownRemaining(); remaining <= 0 || remaining > 2*time.Hour {
+		t.Errorf("RecreationCooldownRemaining() = %s", remaining)
+	}
+
+	// After the cool-down the count starts afresh
+	after := now.Add(time.Hour)
+	if reason, _ := g.hold(obj, after); reason != "" {
+		t.Fatalf("hold() after the cool-down = %q", reason)
+	}
+	if count, _, _ := g.recreated(obj, after); count != 1 {
+		t.Errorf("recreated() after the cool-down = %d, want 1", count)
+	}
+
+	g.cleanup(after.Add(11 * time.Minute))
+	if len(g.objects) != 0 {
+		t.Errorf("cleanup() kept %d records past the window", len(g.objects))
+	}
+}
+
+func TestObjectDeletedIgnoresUnmanagedObjects(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetKind("ConfigMap")
+	obj.SetName("user-owned")
+
+	p := &Patcher{}
+	p.ObjectDeleted(obj)
+	if len(p.recreation.objects) != 0 {
+		t.Error("ObjectDeleted() tracked an object without the managed-by label")
+	}
+}
+
+func TestLastModifier(t *testing.T) {
+	at := func(minute int) *metav1.Time {
+		t := metav1.NewTime(time.Date(2026, 10, 16, 9, minute, 0, 0, time.UTC))
+		return &t
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply, Time: at(30)},
+		{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: at(10)},
+		{Manager: "cleanup-script", Operation: metav1.ManagedFieldsOperationUpdate, Time: at(20)},
+	})
+	if got, want := lastModifier(obj), "cleanup-script (Update) at 2026-10-16T09:20:00Z"; got != want {
+		t.Errorf("lastModifier() = %q, want %q", got, want)
+	}
+
+	obj.SetManagedFields(obj.GetManagedFields()[:1])
+	if got := lastModifier(obj); got != "" {
+		t.Errorf("lastModifier() = %q for an object only the autopilot changed", got)
+	}
+}
+
+// TestRecreationCooldownLeavesObjectDeleted drives the patcher through repeated deletions
+// of a managed object and checks the events, the cool-down and the inventory state.
+func TestRecreationCooldownLeavesObjectDeleted(t *testing.T) {
+	ctx := context.Background()
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	fakeClient := fake.NewClientBuilder().Build()
+	rec := eventtest.NewRecorder()
+	p := &Patcher{
+		renderer:          renderer,
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     &switchableDriftChecker{drift: true},
+		throttle:          throttling.NewTokenBucketWithSettings(100, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetRecreationLimits(RecreationLimits{Max: 1, Window: time.Hour, Cooldown: time.Hour})
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteLive := func() {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+			t.Fatal(err)
+		}
+		if err := fakeClient.Delete(ctx, live); err != nil {
+			t.Fatal(err)
+		}
+		p.ObjectDeleted(live)
+	}
+	cooldowns := testutil.ToFloat64(observability.RecreationCooldownsTotal.WithLabelValues(desired.GetKind(), desired.GetName(), ""))
+
+	if applied, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil || !applied {
+		t.Fatalf("first creation: applied=%v err=%v", applied, err)
+	}
+	rec.ExpectNoEvent(t, util.EventReasonObjectRecreated, desired.GetKind(), desired.GetName())
+
+	deleteLive()
+	if applied, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil || !applied {
+		t.Fatalf("recreation: applied=%v err=%v", applied, err)
+	}
+	if got := rec.Count(util.EventReasonObjectRecreated); got != 1 {
+		t.Errorf("ObjectRecreated events = %d, want 1", got)
+	}
+
+	deleteLive()
+	for i := 0; i < 2; i++ {
+		if applied, err := p.ReconcileAsset(ctx, assetMeta, renderCtx); err != nil || applied {
+			t.Fatalf("during the cool-down: applied=%v err=%v, want held", applied, err)
+		}
+	}
+	if got := rec.Count(util.EventReasonRecreationCooldown); got != 1 {
+		t.Errorf("RecreationCooldown events = %d, want 1", got)
+	}
+	if got := sink.reports[assetMeta.Name]; got.State != ObjectPending || got.Reason != ReasonRecreationCooldown ||
+		!strings.Contains(got.Message, "left deleted until") {
+		t.Errorf("inventory report = %+v, want Pending with the cool-down", got)
+	}
+	if got := testutil.ToFloat64(observability.RecreationCooldownsTotal.WithLabelValues(desired.GetKind(), desired.GetName(), "")); got != cooldowns+1 {
+		t.Errorf("recreation_cooldowns_total = %v, want %v", got, cooldowns+1)
+	}
+	if p.RecreationCooldownRemaining() <= 0 {
+		t.Error("RecreationCooldownRemaining() = 0 during the cool-down")
+	}
+}
+
+// TestCanaryRollbackIsNotARecreation verifies that the deletion of an object a failed
+// canary rollout created is not counted toward the recreation limit, while a deletion
+// by someone else still is.
+func TestCanaryRollbackIsNotARecreation(t *testing.T) {
+	p, c, renderCtx, _ := canaryTestSetup(t)
+	p.SetRecreationLimits(RecreationLimits{Max: 1, Window: time.Hour, Cooldown: time.Hour})
+	ctx := context.Background()
+
+	created := newTestRoleMachineConfig("50-new", "worker")
+	created.SetLabels(map[string]string{roleLabel: "worker", ManagedByLabel: ManagedByValue})
+	for _, h := range runCanaryPass(t, p, renderCtx, heldChange{assetMeta: pkgassets.AssetMetadata{Name: "new"}, desired: created}) {
+		if _, err := p.applier.Apply(ctx, h.desired, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(created.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(created), live); err != nil {
+		t.Fatal(err)
+	}
+
+	setCanaryPool(renderCtx, false, true, "rendered-infra-1")
+	runCanaryPass(t, p, renderCtx)
+	// The watch reports the rollback's deletion
+	p.ObjectDeleted(live)
+	now := time.Now()
+	if _, _, ok := p.recreation.recreated(created, now); ok {
+		t.Fatal("recreated() counted the object deleted by the canary rollback")
+	}
+
+	p.ObjectDeleted(live)
+	if reason, _ := p.recreation.hold(created, now); reason != "" {
+		t.Fatalf("hold() = %q for the first deletion by someone else", reason)
+	}
+	if count, _, ok := p.recreation.recreated(created, now); !ok || count != 1 {
+		t.Errorf("recreated() = %d, %v after a deletion by someone else, want 1, true", count, ok)
+	}
+}
+
+func TestExpectDeletion(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName("tuning")
+	obj.SetUID("uid-1")
+	obj.SetLabels(map[string]string{ManagedByLabel: ManagedByValue})
+
+	p := &Patcher{}
+	g := &p.recreation
+
+	// A failed delete is withdrawn and the next deletion counts
+	cancel := p.ExpectDeletion(obj)
+	cancel()
+	p.ObjectDeleted(obj)
+	if len(g.objects) != 1 {
+		t.Fatal("ObjectDeleted() skipped a deletion whose expectation was cancelled")
+	}
+
+	// An object recreated under the same name gets a new UID: its deletion counts
+	g.objects = nil
+	p.ExpectDeletion(obj)
+	recreated := obj.DeepCopy()
+	recreated.SetUID("uid-2")
+	p.ObjectDeleted(recreated)
+	if len(g.objects) != 1 {
+		t.Error("ObjectDeleted() skipped the deletion of another object with the same name")
+	}
+
+	// An expectation the watch never confirms expires
+	g.cleanup(time.Now().Add(ownDeletionTTL + time.Minute))
+	if len(g.own) != 0 {
+		t.Errorf("cleanup() kept %d expired own deletions", len(g.own))
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+)
+
+const (
+	// RenderedFieldsAnnotation records, on every applied object, the fields its asset
+	// template rendered last time, as comma-separated RFC 6901 JSON Pointers (the format
+	// of platform.kubevirt.io/ignore-fields). Comparing it with the next rendering shows
+	// which fields a template edit drops.
+	RenderedFieldsAnnotation = "platform.kubevirt.io/rendered-fields"
+
+	// maxRenderedFields bounds the annotation value. Objects rendering more fields are
+	// applied without it, and dropped fields go unreported for them.
+	maxRenderedFields = 32 * 1024
+)
+
+// RenderedFields returns JSON Pointers to the leaf fields of a rendered object, sorted.
+// Lists are leaves: server-side apply owns them as a whole or per element key, and a
+// shrinking list is not a dropped field. Identity (apiVersion, kind, name, namespace)
+// and status are left out, as are metadata fields other than labels and annotations.
+func RenderedFields(obj *unstructured.Unstructured) []string {
+	var fields []string
+	var walk func(pointer string, value any)
+	walk = func(pointer string, value any) {
+		m, ok := value.(map[string]any)
+		if !ok || len(m) == 0 {
+			fields = append(fields, pointer)
+			return
+		}
+		for key, child := range m {
+			walk(pointer+"/"+escapePointerToken(key), child)
+		}
+	}
+
+	for key, value := range obj.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			metadata, _ := value.(map[string]any)
+			for _, section := range []string{"labels", "annotations"} {
+				entries, _ := metadata[section].(map[string]any)
+				for name, v := range entries {
+					if section == "annotations" && name == RenderedFieldsAnnotation {
+						continue
+					}
+					walk("/metadata/"+section+"/"+escapePointerToken(name), v)
+				}
+			}
+		default:
+			walk("/"+escapePointerToken(key), value)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// DroppedFields returns the fields recorded on the live object that rendered no longer
+// sets, sorted. A field counts as still rendered when rendered sets it, a parent of it
+// (e.g. a map became a list) or a child of it (e.g. a scalar became a map).
+// It returns nil when live carries no record.
+func DroppedFields(live *unstructured.Unstructured, rendered []string) []string {
+	previous, ok := recordedFields(live)
+	if !ok {
+		return nil
+	}
+
+	var dropped []string
+	for _, field := range previous {
+		kept := false
+		for _, current := range rendered {
+			if current == field || strings.HasPrefix(field, current+"/") || strings.HasPrefix(current, field+"/") {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			dropped = append(dropped, field)
+		}
+	}
+	return dropped
+}
+
+// SetRenderedFields records fields on obj in RenderedFieldsAnnotation, unless the
+// value would exceed maxRenderedFields
+func SetRenderedFields(obj *unstructured.Unstructured, fields []string) {
+	value := strings.Join(fields, ",")
+	if len(value) > maxRenderedFields {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[RenderedFieldsAnnotation] = value
+	obj.SetAnnotations(annotations)
+}
+
+// reportDroppedFields reports the fields desired, just applied over live, no longer
+// renders. Server-side apply removed those only the autopilot owned; fields another
+// manager also set keep their value.
+func (p *Patcher) reportDroppedFields(ctx context.Context, assetMeta *assets.AssetMetadata,
+	desired, live *unstructured.Unstructured, renderCtx *pkgcontext.RenderContext) {
+	current, ok := recordedFields(desired)
+	if !ok {
+		return
+	}
+	dropped := DroppedFields(live, current)
+	if len(dropped) == 0 {
+		return
+	}
+
+	log.FromContext(ctx).Info("Asset template no longer renders fields of the applied object",
+		"name", assetMeta.Name,
+		"kind", desired.GetKind(),
+		"objectName", desired.GetName(),
+		"fields", dropped,
+	)
+	observability.AddDroppedFields(assetMeta.Name, len(dropped))
+	if p.eventRecorder != nil && renderCtx.HCO != nil {
+		p.eventRecorder.RenderedFieldsDropped(renderCtx.HCO, assetMeta.Name, desired.GetKind(), desired.GetNamespace(), desired.GetName(), dropped)
+	}
+}
+
+// recordedFields returns the fields recorded on obj and whether it carries a record
+func recordedFields(obj *unstructured.Unstructured) ([]string, bool) {
+	value, ok := obj.GetAnnotations()[RenderedFieldsAnnotation]
+	if !ok {
+		return nil, false
+	}
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields, true
+}
+
+// escapePointerToken escapes a map key for use in a JSON Pointer, per RFC 6901
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
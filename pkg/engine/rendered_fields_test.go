@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func TestRenderedFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "cfg",
+			"namespace": "default",
+			"labels":    map[string]any{"app.kubernetes.io/name": "cfg"},
+			"annotations": map[string]any{
+				"note":                   "x",
+				RenderedFieldsAnnotation: "/data/old",
+			},
+		},
+		"data":   map[string]any{"a~b": "1", "empty": map[string]any{}},
+		"spec":   map[string]any{"list": []any{map[string]any{"name": "x"}}},
+		"status": map[string]any{"ready": true},
+	}}
+
+	want := []string{
+		"/data/a~0b",
+		"/data/empty",
+		"/metadata/annotations/note",
+		"/metadata/labels/app.kubernetes.io~1name",
+		"/spec/list",
+	}
+	if got := RenderedFields(obj); !reflect.DeepEqual(got, want) {
+		t.Errorf("RenderedFields() = %v, want %v", got, want)
+	}
+}
+
+func TestDroppedFields(t *testing.T) {
+	live := &unstructured.Unstructured{}
+	live.SetAnnotations(map[string]string{
+		RenderedFieldsAnnotation: "/spec/a/b,/spec/c,/spec/d,/spec/removed,/metadata/labels/tier",
+	})
+
+	rendered := []string{
+		"/spec/a",   // b's parent, e.g. a map that became a list
+		"/spec/c/x", // a child of c, e.g. a scalar that became a map
+		"/spec/d",
+	}
+	want := []string{"/spec/removed", "/metadata/labels/tier"}
+	if got := DroppedFields(live, rendered); !reflect.DeepEqual(got, want) {
+		t.Errorf("DroppedFields() = %v, want %v", got, want)
+	}
+
+	if got := DroppedFields(&unstructured.Unstructured{}, rendered); got != nil {
+		t.Errorf("DroppedFields() without a record = %v, want nil", got)
+	}
+}
+
+func TestSetRenderedFieldsLimit(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	SetRenderedFields(obj, []string{"/spec/" + strings.Repeat("x", maxRenderedFields)})
+	if _, ok := obj.GetAnnotations()[RenderedFieldsAnnotation]; ok {
+		t.Error("oversized rendered-fields record was set")
+	}
+}
+
+// TestReconcileReportsDroppedFields verifies that applying a rendering that no longer sets
+// a recorded field reports it, and that the record follows the new rendering
+func TestReconcileReportsDroppedFields(t *testing.T) {
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+	rendered := RenderedFields(desired)
+
+	tests := []struct {
+		name        string
+		record      []string
+		wantDropped int
+	}{
+		{"previous rendering set an extra field", append([]string{"/spec/extensions"}, rendered...), 1},
+		{"same rendering", rendered, 0},
+		{"object applied before the record existed", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := desired.DeepCopy()
+			live.SetLabels(map[string]string{ManagedByLabel: ManagedByValue})
+			if tt.record != nil {
+				SetRenderedFields(live, tt.record)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(live).Build()
+			rec := eventtest.NewRecorder()
+
+			p := &Patcher{
+				renderer:          renderer,
+				applier:           NewApplier(fakeClient, nil),
+				driftDetector:     &alwaysDriftChecker{},
+				throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+				thrashingDetector: throttling.NewThrashingDetector(),
+				client:            fakeClient,
+			}
+			p.SetEventRecorder(util.NewEventRecorder(rec))
+
+			applied, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
+			if err != nil || !applied {
+				t.Fatalf("ReconcileAsset() = %v, %v; want applied", applied, err)
+			}
+			if got := rec.Count(util.EventReasonFieldsDropped); got != tt.wantDropped {
+				t.Errorf("RenderedFieldsDropped events = %d, want %d", got, tt.wantDropped)
+			}
+
+			updated := &unstructured.Unstructured{}
+			updated.SetGroupVersionKind(desired.GroupVersionKind())
+			if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(desired), updated); err != nil {
+				t.Fatalf("failed to get applied object: %v", err)
+			}
+			if got, _ := recordedFields(updated); !reflect.DeepEqual(got, rendered) {
+				t.Errorf("recorded fields after apply = %v, want %v", got, rendered)
+			}
+		})
+	}
+}
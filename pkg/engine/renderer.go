@@ -38,6 +38,7 @@ import (
 type Renderer struct {
 	loader *assets.Loader
 	client client.Reader // Optional: for CRD introspection and object queries
+	images *imagePinner  // Optional: digest pinning, see SetImageResolver
 }
 
 // NewRenderer creates a new template renderer
@@ -53,12 +54,39 @@ func (r *Renderer) SetClient(c client.Reader) {
 }
 
 // RenderAsset renders an asset template with the given context
-// Returns nil if template conditions evaluate to empty (e.g., hardware not present)
+// Returns nil if template conditions evaluate to empty (e.g., hardware not present),
+// or a *SkipError if the template rendered the skip sentinel (see SkipSentinel).
+// The assets listed in its inputs are rendered first unless ctx already holds their outputs.
 func (r *Renderer) RenderAsset(assetMeta *assets.AssetMetadata, ctx *pkgcontext.RenderContext) (*unstructured.Unstructured, error) {
+	if err := r.resolveInputs(assetMeta, ctx); err != nil {
+		return nil, err
+	}
+	obj, err := r.renderAsset(assetMeta, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var objs []*unstructured.Unstructured
+	if obj != nil {
+		objs = append(objs, obj)
+	}
+	if err := recordOutputs(assetMeta, ctx, objs); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (r *Renderer) renderAsset(assetMeta *assets.AssetMetadata, ctx *pkgcontext.RenderContext) (*unstructured.Unstructured, error) {
 	// Check if this is a template file
 	if !assets.IsTemplate(assetMeta.Path) {
 		// Load as static YAML
-		return r.loader.LoadAssetAsUnstructured(assetMeta.Path)
+		obj, err := r.loader.LoadAssetAsUnstructured(assetMeta.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.postProcess(assetMeta, obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
 	}
 
 	// Load template content
@@ -73,6 +101,11 @@ func (r *Renderer) RenderAsset(assetMeta *assets.AssetMetadata, ctx *pkgcontext.
 		return nil, fmt.Errorf("failed to render template %s: %w", assetMeta.Path, err)
 	}
 
+	// Explicit opt-out via the skip sentinel
+	if err := checkSkipSentinel(assetMeta.Name, rendered); err != nil {
+		return nil, err
+	}
+
 	// Handle empty rendering (conditional templates that don't apply)
 	if len(bytes.TrimSpace(rendered)) == 0 {
 		return nil, nil
@@ -84,11 +117,30 @@ func (r *Renderer) RenderAsset(assetMeta *assets.AssetMetadata, ctx *pkgcontext.
 		return nil, fmt.Errorf("failed to parse rendered template %s: %w", assetMeta.Path, err)
 	}
 
+	if err := r.postProcess(assetMeta, obj); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
-// RenderMultiAsset renders a template that may contain multiple YAML documents
+// RenderMultiAsset renders a template that may contain multiple YAML documents.
+// Inputs and outputs are handled as in RenderAsset; outputs are read from the first document.
 func (r *Renderer) RenderMultiAsset(assetMeta *assets.AssetMetadata, ctx *pkgcontext.RenderContext) ([]*unstructured.Unstructured, error) {
+	if err := r.resolveInputs(assetMeta, ctx); err != nil {
+		return nil, err
+	}
+	objs, err := r.renderMultiAsset(assetMeta, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordOutputs(assetMeta, ctx, objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func (r *Renderer) renderMultiAsset(assetMeta *assets.AssetMetadata, ctx *pkgcontext.RenderContext) ([]*unstructured.Unstructured, error) {
 	// Check if this is a template file
 	if !assets.IsTemplate(assetMeta.Path) {
 		// Load as static YAML (may be multi-doc)
@@ -96,7 +148,14 @@ func (r *Renderer) RenderMultiAsset(assetMeta *assets.AssetMetadata, ctx *pkgcon
 		if err != nil {
 			return nil, err
 		}
-		return assets.ParseMultiYAML(data)
+		objs, err := assets.ParseMultiYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.postProcess(assetMeta, objs...); err != nil {
+			return nil, err
+		}
+		return objs, nil
 	}
 
 	// Load template content
@@ -111,6 +170,10 @@ func (r *Renderer) RenderMultiAsset(assetMeta *assets.AssetMetadata, ctx *pkgcon
 		return nil, fmt.Errorf("failed to render template %s: %w", assetMeta.Path, err)
 	}
 
+	if err := checkSkipSentinel(assetMeta.Name, rendered); err != nil {
+		return nil, err
+	}
+
 	// Handle empty rendering
 	if len(bytes.TrimSpace(rendered)) == 0 {
 		return nil, nil
@@ -122,11 +185,36 @@ func (r *Renderer) RenderMultiAsset(assetMeta *assets.AssetMetadata, ctx *pkgcon
 		return nil, fmt.Errorf("failed to parse rendered template %s: %w", assetMeta.Path, err)
 	}
 
+	if err := r.postProcess(assetMeta, objs...); err != nil {
+		return nil, err
+	}
+
 	return objs, nil
 }
 
-// renderTemplate renders a template string with the given context
-func (r *Renderer) renderTemplate(name, templateContent string, ctx *pkgcontext.RenderContext) ([]byte, error) {
+// postProcess checks rendered objects against the asset's declared scope, so a
+// mis-scoped asset fails here with its name instead of later in server-side apply,
+// switches them to the apiVersion the cluster serves and applies digest pinning
+func (r *Renderer) postProcess(assetMeta *assets.AssetMetadata, objs ...*unstructured.Unstructured) error {
+	apiVersion, err := r.selectAPIVersion(assetMeta)
+	if err != nil {
+		return err
+	}
+	setAPIVersion(apiVersion, objs...)
+	for _, obj := range objs {
+		if err := assetMeta.CheckScope(obj); err != nil {
+			return err
+		}
+		if err := r.images.pinImages(obj); err != nil {
+			return fmt.Errorf("failed to pin images in %s: %w", assetMeta.Path, err)
+		}
+	}
+	return nil
+}
+
+// ParseTemplate parses template content with the renderer's function map.
+// References to functions outside the allowlist fail here, before any context is applied.
+func (r *Renderer) ParseTemplate(name, templateContent string) (*template.Template, error) {
 	// Create template with safe functions only (not all of Sprig)
 	tmpl, err := template.New(name).
 		Funcs(safeFuncMap()).
@@ -135,6 +223,18 @@ func (r *Renderer) renderTemplate(name, templateContent string, ctx *pkgcontext.
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
+	return tmpl, nil
+}
+
+// renderTemplate renders a template string with the given context and records the
+// warnings it emitted (see WarningSentinel) in ctx under name
+func (r *Renderer) renderTemplate(name, templateContent string, ctx *pkgcontext.RenderContext) ([]byte, error) {
+	ctx.SetWarnings(name, nil)
+
+	tmpl, err := r.ParseTemplate(name, templateContent)
+	if err != nil {
+		return nil, err
+	}
 
 	// Render template
 	var buf bytes.Buffer
@@ -142,6 +242,7 @@ func (r *Renderer) renderTemplate(name, templateContent string, ctx *pkgcontext.
 		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
+	ctx.SetWarnings(name, extractWarnings(buf.Bytes()))
 	return buf.Bytes(), nil
 }
 
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func renderWithPlacement(t *testing.T, assetName string, spec map[string]any) *unstructured.Unstructured {
+	t.Helper()
+
+	loader := assets.NewLoader()
+	registry, err := assets.NewRegistry(loader)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+	asset, err := registry.GetAsset(assetName)
+	if err != nil {
+		t.Fatalf("Failed to get asset: %v", err)
+	}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	if err := unstructured.SetNestedField(hco.Object, spec, "spec"); err != nil {
+		t.Fatalf("Failed to set HCO spec: %v", err)
+	}
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	renderCtx.Images["kubevirt-metrics-exporter"] = "quay.io/kubevirt/metrics-exporter:latest"
+
+	rendered, err := NewRenderer(loader).RenderAsset(asset, renderCtx)
+	if err != nil {
+		t.Fatalf("Failed to render %s: %v", assetName, err)
+	}
+	return rendered
+}
+
+var placementSpec = map[string]any{
+	"infra": map[string]any{"nodePlacement": map[string]any{
+		"nodeSelector": map[string]any{"node-role.kubernetes.io/infra": ""},
+		"tolerations":  []any{map[string]any{"key": "infra", "operator": "Exists"}},
+	}},
+	"workloads": map[string]any{"nodePlacement": map[string]any{
+		"nodeSelector": map[string]any{"virt": "true"},
+		"affinity": map[string]any{"nodeAffinity": map[string]any{
+			"preferredDuringSchedulingIgnoredDuringExecution": []any{},
+		}},
+	}},
+}
+
+func TestMetalLBPlacement(t *testing.T) {
+	t.Run("no placement", func(t *testing.T) {
+		rendered := renderWithPlacement(t, "metallb-operator", map[string]any{})
+		spec, _, _ := unstructured.NestedMap(rendered.Object, "spec")
+		if len(spec) != 0 {
+			t.Errorf("expected an empty spec, got %v", spec)
+		}
+	})
+
+	t.Run("infra and workloads", func(t *testing.T) {
+		rendered := renderWithPlacement(t, "metallb-operator", placementSpec)
+
+		controller, _, _ := unstructured.NestedStringMap(rendered.Object, "spec", "controllerNodeSelector")
+		if _, ok := controller["node-role.kubernetes.io/infra"]; !ok {
+			t.Errorf("controller should follow infra placement, got %v", controller)
+		}
+		tolerations, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "controllerTolerations")
+		if len(tolerations) != 1 {
+			t.Errorf("expected 1 controller toleration, got %v", tolerations)
+		}
+
+		speaker, _, _ := unstructured.NestedStringMap(rendered.Object, "spec", "nodeSelector")
+		if speaker["virt"] != "true" {
+			t.Errorf("speakers should follow workloads placement, got %v", speaker)
+		}
+		if _, found, _ := unstructured.NestedMap(rendered.Object, "spec", "speakerConfig", "affinity", "nodeAffinity"); !found {
+			t.Error("speakers should get the workloads affinity")
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(rendered.Object, "spec", "speakerTolerations"); found {
+			t.Error("speakerTolerations should be unset without workloads tolerations")
+		}
+	})
+}
+
+func TestMetricsExporterPlacement(t *testing.T) {
+	selectorPath := []string{"spec", "template", "spec", "nodeSelector"}
+	affinityPath := []string{"spec", "template", "spec", "affinity"}
+
+	rendered := renderWithPlacement(t, "metrics-exporter", map[string]any{})
+	selector, _, _ := unstructured.NestedStringMap(rendered.Object, selectorPath...)
+	if _, ok := selector["node-role.kubernetes.io/worker"]; !ok || len(selector) != 1 {
+		t.Errorf("expected the worker role selector by default, got %v", selector)
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(rendered.Object, affinityPath...); found {
+		t.Error("affinity should be unset without workloads placement")
+	}
+
+	rendered = renderWithPlacement(t, "metrics-exporter", placementSpec)
+	selector, _, _ = unstructured.NestedStringMap(rendered.Object, selectorPath...)
+	if selector["virt"] != "true" || len(selector) != 1 {
+		t.Errorf("expected the workloads selector, got %v", selector)
+	}
+	if _, found, _ := unstructured.NestedMap(rendered.Object, affinityPath...); !found {
+		t.Error("expected the workloads affinity")
+	}
+}
+
+func TestPlacementWithoutContext(t *testing.T) {
+	rendered, _, _ := renderHCOAsset(t, "metallb-operator")
+	if spec, _, _ := unstructured.NestedMap(rendered.Object, "spec"); len(spec) != 0 {
+		t.Errorf("expected an empty spec without a placement context, got %v", spec)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package engine
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -402,6 +403,32 @@ func TestRenderTemplate(t *testing.T) {
 		}
 	})
 
+	t.Run("handles proxy context", func(t *testing.T) {
+		template := "env:{{ range .Proxy.EnvVars }}\n- name: {{ .name }}\n  value: {{ .value | quote }}{{ else }} []{{ end }}"
+
+		ctx := &pkgcontext.RenderContext{
+			HCO:   &unstructured.Unstructured{Object: map[string]any{}},
+			Proxy: &pkgcontext.ProxyContext{HTTPSProxy: "http://proxy:3128"},
+		}
+		rendered, err := renderer.renderTemplate("test", template, ctx)
+		if err != nil {
+			t.Fatalf("renderTemplate() error = %v", err)
+		}
+		if !strings.Contains(string(rendered), "name: HTTPS_PROXY") || !strings.Contains(string(rendered), `value: "http://proxy:3128"`) {
+			t.Errorf("renderTemplate() did not render proxy env: %s", string(rendered))
+		}
+
+		// A context built without proxy detection must still render
+		ctx.Proxy = nil
+		rendered, err = renderer.renderTemplate("test", template, ctx)
+		if err != nil {
+			t.Fatalf("renderTemplate() with nil Proxy error = %v", err)
+		}
+		if strings.TrimSpace(string(rendered)) != "env: []" {
+			t.Errorf("renderTemplate() with nil Proxy = %q", string(rendered))
+		}
+	})
+
 	t.Run("returns error for invalid template", func(t *testing.T) {
 		ctx := &pkgcontext.RenderContext{
 			HCO: &unstructured.Unstructured{
@@ -482,6 +509,95 @@ func TestRenderAsset(t *testing.T) {
 			t.Errorf("Expected asset loading error, got: %v", err)
 		}
 	})
+
+	t.Run("rejects objects that contradict the declared scope", func(t *testing.T) {
+		renderer := NewRenderer(assets.NewLoader())
+
+		// The psi-enable MachineConfig is cluster-scoped
+		assetMeta := &assets.AssetMetadata{
+			Name:  "psi-enable",
+			Path:  "active/machine-config/04-psi-enable.yaml",
+			Scope: assets.ScopeNamespaced,
+		}
+
+		_, err := renderer.RenderAsset(assetMeta, pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("hco", "ns")))
+		if err == nil || !strings.Contains(err.Error(), "has no metadata.namespace") {
+			t.Errorf("Expected scope mismatch error, got: %v", err)
+		}
+	})
+
+	t.Run("targets arch-specific kernel arguments at the role of the matching pool", func(t *testing.T) {
+		renderer := NewRenderer(assets.NewLoader())
+		assetMeta := &assets.AssetMetadata{
+			Name:  "pci-passthrough",
+			Path:  "active/machine-config/02-pci-passthrough.yaml.tpl",
+			Scope: assets.ScopeCluster,
+		}
+
+		ctx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("hco", "ns"))
+		ctx.Hardware.PCIDevicesPresent = true
+		ctx.Architecture = &pkgcontext.ArchitectureContext{
+			Architectures: []string{"amd64", "arm64"},
+			Roles:         map[string][]string{"worker": {"amd64", "arm64"}, "worker-amd64": {"amd64"}},
+		}
+
+		obj, err := renderer.RenderAsset(assetMeta, ctx)
+		if err != nil {
+			t.Fatalf("RenderAsset() error = %v", err)
+		}
+		if role := obj.GetLabels()["machineconfiguration.openshift.io/role"]; role != "worker-amd64" {
+			t.Errorf("role = %q, want worker-amd64 so the arm64 workers do not get intel_iommu", role)
+		}
+
+		// Without a pool of amd64 nodes only, the kernel arguments would reach arm64 nodes
+		ctx.Architecture.Roles = map[string][]string{"worker": {"amd64", "arm64"}}
+		if _, err := renderer.RenderAsset(assetMeta, ctx); err == nil {
+			t.Error("RenderAsset() rendered for a worker role reaching arm64 nodes, want a skip")
+		} else if _, skipped := SkipReason(err); !skipped {
+			t.Errorf("RenderAsset() error = %v, want a skip", err)
+		}
+	})
+
+	t.Run("renders the hugepages MachineConfig with warnings for pools that cannot hold them", func(t *testing.T) {
+		renderer := NewRenderer(assets.NewLoader())
+		assetMeta := &assets.AssetMetadata{
+			Name:  "hugepages",
+			Path:  "active/machine-config/06-hugepages.yaml.tpl",
+			Scope: assets.ScopeCluster,
+		}
+
+		ctx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("hco", "ns"))
+		ctx.HugePages = &pkgcontext.HugePagesContext{
+			Ready: true, PageSize: "1Gi", PageSizeKB: 1 << 20, VMsPerNode: 4, PagesPerVM: 8, MaxPercent: 80, Role: "worker",
+			Pools: []pkgcontext.HugePagesPool{
+				{Name: "small", NUMANodes: 1, Reason: "node small-0 has 16Gi per NUMA node"},
+				{Name: "worker", NUMANodes: 2, PagesPerNUMANode: 16, Pages: 32, Ready: true},
+			},
+		}
+
+		obj, err := renderer.RenderAsset(assetMeta, ctx)
+		if err != nil {
+			t.Fatalf("RenderAsset() error = %v", err)
+		}
+		if obj.GetName() != "50-worker-kubevirt-hugepages" || obj.GetLabels()["machineconfiguration.openshift.io/role"] != "worker" {
+			t.Errorf("rendered %s with labels %v, want the worker MachineConfig", obj.GetName(), obj.GetLabels())
+		}
+		units, _, _ := unstructured.NestedSlice(obj.Object, "spec", "config", "systemd", "units")
+		contents := units[0].(map[string]any)["contents"].(string)
+		if want := "ExecStart=/usr/local/bin/kubevirt-hugepages.sh 4 8 1048576 80"; !strings.Contains(contents, want) {
+			t.Errorf("unit does not run %q:\n%s", want, contents)
+		}
+		if want := []string{"no hugepages for pool small: node small-0 has 16Gi per NUMA node"}; !slices.Equal(ctx.Warnings("hugepages"), want) {
+			t.Errorf("Warnings() = %q, want %q", ctx.Warnings("hugepages"), want)
+		}
+
+		ctx.HugePages = &pkgcontext.HugePagesContext{Reason: "no hugepage density hints on the HyperConverged"}
+		if _, err := renderer.RenderAsset(assetMeta, ctx); err == nil {
+			t.Error("RenderAsset() rendered without density hints, want a skip")
+		} else if reason, _ := SkipReason(err); reason != ctx.HugePages.Reason {
+			t.Errorf("skip reason = %q, want %q", reason, ctx.HugePages.Reason)
+		}
+	})
 }
 
 func TestRenderMultiAsset(t *testing.T) {
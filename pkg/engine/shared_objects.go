@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SetSharedObjectOwner makes owner the only HCO that applies shared objects when several
+// HCOs are reconciled: cluster-scoped objects and objects outside the reconciled HCO's
+// namespace. Other HCOs leave them to the owner, so tenants with different settings do
+// not overwrite each other's MachineConfigs or operator configs. A zero owner, the
+// single-HCO default, lets every HCO apply every object.
+func (p *Patcher) SetSharedObjectOwner(owner types.NamespacedName) {
+	p.sharedOwner = owner
+}
+
+// OwnsSharedObjects reports whether hco applies shared objects
+func (p *Patcher) OwnsSharedObjects(hco *unstructured.Unstructured) bool {
+	if p.sharedOwner == (types.NamespacedName{}) || hco == nil {
+		return true
+	}
+	return hco.GetNamespace() == p.sharedOwner.Namespace && hco.GetName() == p.sharedOwner.Name
+}
+
+// isSharedObject reports whether obj is shared between HCOs: cluster-scoped or in
+// another namespace than hco
+func isSharedObject(hco, obj *unstructured.Unstructured) bool {
+	return obj.GetNamespace() == "" || obj.GetNamespace() != hco.GetNamespace()
+}
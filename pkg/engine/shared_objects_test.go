@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// TestSharedObjectOwner verifies that an HCO other than the owner applies the objects
+// in its own namespace but leaves cluster-scoped ones to the owner, reporting why.
+func TestSharedObjectOwner(t *testing.T) {
+	ctx := context.Background()
+	psiAsset := pkgassets.AssetMetadata{
+		Name: "psi-enable", Path: "active/machine-config/04-psi-enable.yaml", Component: "MachineConfig",
+	}
+	c := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-cnv"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}},
+		).
+		Build()
+
+	p := NewPatcher(c, c, pkgassets.NewLoader())
+	p.SetSharedObjectOwner(types.NamespacedName{Namespace: "openshift-cnv", Name: pkgcontext.HCOName})
+	sink := &recordingSink{reports: make(map[string]ObjectReport)}
+	p.SetInventorySink(sink)
+
+	tenant := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO(pkgcontext.HCOName, "tenant-a"))
+	if p.OwnsSharedObjects(tenant.HCO) {
+		t.Fatal("OwnsSharedObjects(tenant-a) = true, want false")
+	}
+	if applied, err := p.ReconcileAsset(ctx, &psiAsset, tenant); err != nil || applied {
+		t.Fatalf("tenant: ReconcileAsset(%s) = %v, %v; want skipped", psiAsset.Name, applied, err)
+	}
+	if report := sink.reports[psiAsset.Name]; report.State != ObjectExcluded || report.Reason != ReasonSharedObjectOwner {
+		t.Errorf("tenant: report = %+v, want %s/%s", report, ObjectExcluded, ReasonSharedObjectOwner)
+	}
+	if applied, err := p.ReconcileAsset(ctx, &planTestAsset, tenant); err != nil || !applied {
+		t.Errorf("tenant: ReconcileAsset(%s) = %v, %v; want applied in its own namespace", planTestAsset.Name, applied, err)
+	}
+
+	owner := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO(pkgcontext.HCOName, "openshift-cnv"))
+	if applied, err := p.ReconcileAsset(ctx, &psiAsset, owner); err != nil || !applied {
+		t.Errorf("owner: ReconcileAsset(%s) = %v, %v; want applied", psiAsset.Name, applied, err)
+	}
+
+	// Without an owner every HCO applies everything
+	p.SetSharedObjectOwner(types.NamespacedName{})
+	if !p.OwnsSharedObjects(tenant.HCO) {
+		t.Error("OwnsSharedObjects(tenant-a) = false without an owner, want true")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SkipSentinel is the comment a template renders to opt out of being applied.
+// An optional reason is carried into render output and events:
+//
+//	{{- if not .Hardware.GPUPresent }}
+//	# autopilot:skip reason=no GPU nodes detected
+//	{{- else }}
+//	...
+//	{{- end }}
+const SkipSentinel = "# autopilot:skip"
+
+// defaultSkipReason is used when the sentinel carries no reason
+const defaultSkipReason = "Template rendered skip sentinel"
+
+var skipSentinelPattern = regexp.MustCompile(`(?m)^[ \t]*#[ \t]*autopilot:skip(?:[ \t]+reason=(.*?))?[ \t]*$`)
+
+// SkipError is returned by the renderer when a template rendered the skip sentinel.
+// It is not a failure: callers treat the asset as excluded with Reason.
+type SkipError struct {
+	Asset  string
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	return fmt.Sprintf("asset %s skipped: %s", e.Asset, e.Reason)
+}
+
+// SkipReason reports whether err is (or wraps) a *SkipError and returns its reason
+func SkipReason(err error) (string, bool) {
+	var skipErr *SkipError
+	if errors.As(err, &skipErr) {
+		return skipErr.Reason, true
+	}
+	return "", false
+}
+
+// checkSkipSentinel returns a *SkipError when rendered contains the skip sentinel.
+// A sentinel next to actual YAML content is a template bug and returned as a plain error.
+func checkSkipSentinel(asset string, rendered []byte) error {
+	match := skipSentinelPattern.FindSubmatchIndex(rendered)
+	if match == nil {
+		return nil
+	}
+
+	reason := defaultSkipReason
+	if match[2] >= 0 {
+		if r := strings.Trim(strings.TrimSpace(string(rendered[match[2]:match[3]])), `"'`); r != "" {
+			reason = r
+		}
+	}
+
+	rest := skipSentinelPattern.ReplaceAll(rendered, nil)
+	for _, line := range bytes.Split(rest, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' || string(line) == "---" {
+			continue
+		}
+		return fmt.Errorf("template for asset %s rendered both %q and content", asset, SkipSentinel)
+	}
+
+	return &SkipError{Asset: asset, Reason: reason}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheckSkipSentinel(t *testing.T) {
+	tests := []struct {
+		name       string
+		rendered   string
+		wantSkip   bool
+		wantReason string
+		wantErr    bool
+	}{
+		{name: "no sentinel", rendered: "apiVersion: v1\nkind: ConfigMap\n"},
+		{name: "empty output", rendered: "\n\n"},
+		{name: "sentinel with reason", rendered: "\n# autopilot:skip reason=no GPU nodes detected\n",
+			wantSkip: true, wantReason: "no GPU nodes detected"},
+		{name: "quoted reason", rendered: `# autopilot:skip reason="HCP cluster"`,
+			wantSkip: true, wantReason: "HCP cluster"},
+		{name: "sentinel without reason", rendered: "  #autopilot:skip\n",
+			wantSkip: true, wantReason: defaultSkipReason},
+		{name: "sentinel among comments", rendered: "# generated\n---\n# autopilot:skip reason=disabled\n",
+			wantSkip: true, wantReason: "disabled"},
+		{name: "sentinel next to content", rendered: "# autopilot:skip reason=x\napiVersion: v1\nkind: ConfigMap\n",
+			wantErr: true},
+		{name: "sentinel text inside a value is ignored", rendered: "data:\n  note: \"# autopilot:skip\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSkipSentinel("test-asset", []byte(tt.rendered))
+			reason, skipped := SkipReason(err)
+			if skipped != tt.wantSkip {
+				t.Fatalf("skipped = %v, want %v (err = %v)", skipped, tt.wantSkip, err)
+			}
+			if skipped && reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tt.wantReason)
+			}
+			if !skipped && (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSkipReasonWrapped(t *testing.T) {
+	err := fmt.Errorf("failed to render asset: %w", &SkipError{Asset: "a", Reason: "not needed"})
+	reason, ok := SkipReason(err)
+	if !ok || reason != "not needed" {
+		t.Errorf("SkipReason() = %q, %v; want \"not needed\", true", reason, ok)
+	}
+	if !strings.Contains(err.Error(), "asset a skipped: not needed") {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+	if _, ok := SkipReason(fmt.Errorf("boom")); ok {
+		t.Error("SkipReason() matched a plain error")
+	}
+}
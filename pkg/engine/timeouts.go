@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ApplyTimeouts bound how long reconciling assets may take, so one hung API call
+// (e.g. an admission webhook backed by a dead service) cannot stall the whole loop.
+// A zero value disables the corresponding bound.
+type ApplyTimeouts struct {
+	// PerAsset bounds rendering, drift detection and apply of a single asset
+	PerAsset time.Duration
+	// Total bounds a whole ReconcileAssets pass; assets not reached in time are not attempted
+	Total time.Duration
+}
+
+// DefaultApplyTimeouts returns the timeouts used by the controller unless configured
+func DefaultApplyTimeouts() ApplyTimeouts {
+	return ApplyTimeouts{
+		PerAsset: 30 * time.Second,
+		Total:    5 * time.Minute,
+	}
+}
+
+// Validate rejects negative timeouts and a per-asset timeout longer than the total
+func (t ApplyTimeouts) Validate() error {
+	switch {
+	case t.PerAsset < 0:
+		return fmt.Errorf("asset apply timeout must not be negative, got %s", t.PerAsset)
+	case t.Total < 0:
+		return fmt.Errorf("reconcile timeout must not be negative, got %s", t.Total)
+	case t.PerAsset > 0 && t.Total > 0 && t.PerAsset > t.Total:
+		return fmt.Errorf("asset apply timeout %s exceeds the reconcile timeout %s", t.PerAsset, t.Total)
+	}
+	return nil
+}
+
+// TimeoutError is returned by ReconcileAssets when at least one asset timed out.
+// It wraps the aggregated error of the pass, so its message lists every failure.
+type TimeoutError struct {
+	// Assets are the assets whose apply was cancelled or never attempted
+	Assets []string
+	err    error
+}
+
+func (e *TimeoutError) Error() string {
+	return e.err.Error()
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.err
+}
+
+// SetApplyTimeouts sets the per-asset and per-pass timeouts
+func (p *Patcher) SetApplyTimeouts(timeouts ApplyTimeouts) {
+	p.timeouts = timeouts
+}
+
+// WithAssetTimeout derives the context a single asset is reconciled with
+func (p *Patcher) WithAssetTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.timeouts.PerAsset <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.timeouts.PerAsset)
+}
+
+// assetTimeoutError replaces the error of an asset whose context expired with one
+// saying which bound was hit. It returns nil when the asset did not time out.
+func (p *Patcher) assetTimeoutError(passCtx, assetCtx context.Context, err error) error {
+	if err == nil || !errors.Is(assetCtx.Err(), context.DeadlineExceeded) {
+		return nil
+	}
+	limit := p.timeouts.PerAsset
+	if passCtx.Err() != nil {
+		limit = p.timeouts.Total
+	}
+	return fmt.Errorf("%w: apply cancelled after %s: %w", errAssetTimeout, limit, err)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+// newHangingPatcher returns a patcher whose MachineConfig applies block until their
+// context ends, like an apply stuck on an admission webhook whose service is gone
+func newHangingPatcher(rec *eventtest.Recorder) *Patcher {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kubevirt-hyperconverged"}}
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(namespace).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				if u, ok := obj.(interface{ GetKind() string }); ok && u.GetKind() == "MachineConfig" {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	p := &Patcher{
+		renderer:          NewRenderer(pkgassets.NewLoader()),
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     &switchableDriftChecker{drift: true},
+		throttle:          throttling.NewTokenBucketWithSettings(10, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	return p
+}
+
+var timeoutTestAssets = []pkgassets.AssetMetadata{
+	{Name: "psi-enable", Path: "active/machine-config/04-psi-enable.yaml", Component: "MachineConfig"},
+	{Name: "metrics-service", Path: "active/observability/metrics-service.yaml.tpl", Component: "Service"},
+}
+
+// TestPerAssetTimeout verifies that a hung apply is cancelled and reported as TIMEOUT
+// while the assets after it are still reconciled.
+func TestPerAssetTimeout(t *testing.T) {
+	observability.ApplyTimeoutsTotal.Reset()
+
+	rec := eventtest.NewRecorder()
+	p := newHangingPatcher(rec)
+	p.SetApplyTimeouts(ApplyTimeouts{PerAsset: 50 * time.Millisecond, Total: time.Minute})
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged"))
+
+	count, err := p.ReconcileAssets(context.Background(), timeoutTestAssets, renderCtx)
+	if count != 1 {
+		t.Errorf("ReconcileAssets() applied %d, want 1 (metrics-service)", count)
+	}
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("ReconcileAssets() error = %v, want a *TimeoutError", err)
+	}
+	if !slices.Equal(timeoutErr.Assets, []string{"psi-enable"}) {
+		t.Errorf("timed out assets = %v, want [psi-enable]", timeoutErr.Assets)
+	}
+	if !strings.Contains(err.Error(), "[psi-enable: TIMEOUT: apply cancelled after 50ms") {
+		t.Errorf("error = %q, want the psi-enable TIMEOUT", err)
+	}
+	if got := rec.Count(util.EventReasonApplyTimeout); got != 1 {
+		t.Errorf("ApplyTimeout event count = %d, want 1", got)
+	}
+	if val := testutil.ToFloat64(observability.ApplyTimeoutsTotal.WithLabelValues("psi-enable")); val != 1 {
+		t.Errorf("apply_timeouts_total{asset=psi-enable} = %v, want 1", val)
+	}
+}
+
+// TestReconcileTimeoutSkipsRemainingAssets verifies that once the pass timeout is
+// spent the remaining assets are reported as TIMEOUT without being attempted.
+func TestReconcileTimeoutSkipsRemainingAssets(t *testing.T) {
+	rec := eventtest.NewRecorder()
+	p := newHangingPatcher(rec)
+	p.SetApplyTimeouts(ApplyTimeouts{Total: 50 * time.Millisecond})
+	renderCtx := pkgcontext.NewRenderContext(pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged"))
+
+	count, err := p.ReconcileAssets(context.Background(), timeoutTestAssets, renderCtx)
+	if count != 0 {
+		t.Errorf("ReconcileAssets() applied %d, want 0", count)
+	}
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("ReconcileAssets() error = %v, want a *TimeoutError", err)
+	}
+	if !slices.Equal(timeoutErr.Assets, []string{"psi-enable", "metrics-service"}) {
+		t.Errorf("timed out assets = %v, want [psi-enable metrics-service]", timeoutErr.Assets)
+	}
+	if !strings.Contains(err.Error(), "[metrics-service: TIMEOUT: not attempted") {
+		t.Errorf("error = %q, want metrics-service not attempted", err)
+	}
+}
+
+func TestApplyTimeoutsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts ApplyTimeouts
+		wantErr  bool
+	}{
+		{name: "defaults", timeouts: DefaultApplyTimeouts()},
+		{name: "disabled", timeouts: ApplyTimeouts{}},
+		{name: "per-asset only", timeouts: ApplyTimeouts{PerAsset: time.Minute}},
+		{name: "negative per-asset", timeouts: ApplyTimeouts{PerAsset: -time.Second}, wantErr: true},
+		{name: "negative total", timeouts: ApplyTimeouts{Total: -time.Second}, wantErr: true},
+		{name: "per-asset above total", timeouts: ApplyTimeouts{PerAsset: time.Hour, Total: time.Minute}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.timeouts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -36,6 +38,10 @@ type TombstoneReconciler struct {
 	client        client.Client
 	loader        *assets.Loader
 	eventRecorder *util.EventRecorder
+	blastRadius   blastRadiusLimit
+	ownsKind      func(kind string) bool // nil = every tombstone
+	// expectDeletion announces a deletion to the recreation guard; nil = not announced
+	expectDeletion func(obj *unstructured.Unstructured) (cancel func())
 }
 
 // NewTombstoneReconciler creates a new tombstone reconciler
@@ -46,6 +52,19 @@ func NewTombstoneReconciler(client client.Client, loader *assets.Loader) *Tombst
 	}
 }
 
+// SetKindFilter limits tombstone processing to the kinds owns accepts, e.g. those of a
+// controller shard; the other tombstones are left to whoever owns their kind
+func (r *TombstoneReconciler) SetKindFilter(owns func(kind string) bool) {
+	r.ownsKind = owns
+}
+
+// SetDeletionRecorder makes every deletion be announced through expect first, e.g.
+// Patcher.ExpectDeletion, so tombstone pruning does not count as someone deleting a
+// managed object
+func (r *TombstoneReconciler) SetDeletionRecorder(expect func(obj *unstructured.Unstructured) (cancel func())) {
+	r.expectDeletion = expect
+}
+
 // SetEventRecorder sets the event recorder for tombstone events
 func (r *TombstoneReconciler) SetEventRecorder(recorder *util.EventRecorder) {
 	r.eventRecorder = recorder
@@ -72,24 +91,43 @@ func (r *TombstoneReconciler) ReconcileTombstones(ctx context.Context, hco *unst
 
 	deletedCount := 0
 	var aggregatedErrors []error
+	logFailure := func(ts assets.TombstoneMetadata, err error) {
+		// Log error but continue processing remaining tombstones (best-effort)
+		logger.Error(err, "Failed to process tombstone",
+			"kind", ts.GVK.Kind,
+			"name", ts.Name,
+			"namespace", ts.Namespace,
+			"path", ts.Path)
+		aggregatedErrors = append(aggregatedErrors, err)
+	}
 
-	// Process each tombstone
+	// Find every resource that would be deleted before deleting any of them,
+	// so the blast radius guard can judge the whole batch
+	var candidates []tombstoneCandidate
 	for _, ts := range tombstones {
-		deleted, err := r.reconcileTombstone(ctx, ts, hco)
+		if r.ownsKind != nil && !r.ownsKind(ts.GVK.Kind) {
+			continue
+		}
+		live, err := r.deletionCandidate(ctx, ts, hco)
 		if err != nil {
-			// Log error but continue processing remaining tombstones (best-effort)
-			logger.Error(err, "Failed to process tombstone",
-				"kind", ts.GVK.Kind,
-				"name", ts.Name,
-				"namespace", ts.Namespace,
-				"path", ts.Path)
-			aggregatedErrors = append(aggregatedErrors, err)
+			logFailure(ts, err)
 			continue
 		}
+		if live != nil {
+			candidates = append(candidates, tombstoneCandidate{ts: ts, live: live})
+		}
+	}
+
+	if err := r.checkBlastRadius(ctx, hco, candidates); err != nil {
+		return 0, err
+	}
 
-		if deleted {
-			deletedCount++
+	for _, c := range candidates {
+		if err := r.deleteTombstone(ctx, c.ts, c.live, hco); err != nil {
+			logFailure(c.ts, err)
+			continue
 		}
+		deletedCount++
 	}
 
 	// Return aggregated errors if any occurred
@@ -101,35 +139,32 @@ func (r *TombstoneReconciler) ReconcileTombstones(ctx context.Context, hco *unst
 	return deletedCount, nil
 }
 
+// tombstoneCandidate is a tombstoned resource that exists and carries our management label
+type tombstoneCandidate struct {
+	ts   assets.TombstoneMetadata
+	live *unstructured.Unstructured
+}
+
 // reconcileTombstone processes a single tombstone and attempts deletion
 // Returns true if the resource was deleted, false if skipped (NotFound or label mismatch)
 func (r *TombstoneReconciler) reconcileTombstone(ctx context.Context, ts assets.TombstoneMetadata, hco *unstructured.Unstructured) (bool, error) {
-	logger := log.FromContext(ctx)
-
-	// Create object key for lookup
-	objKey := client.ObjectKey{
-		Name:      ts.Name,
-		Namespace: ts.Namespace,
+	live, err := r.deletionCandidate(ctx, ts, hco)
+	if err != nil || live == nil {
+		return false, err
+	}
+	if err := r.deleteTombstone(ctx, ts, live, hco); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	// Get current state from cluster
-	live := &unstructured.Unstructured{}
-	live.SetGroupVersionKind(ts.GVK)
+// deletionCandidate returns the live resource of a tombstone if it should be deleted,
+// or nil when there is nothing to do (NotFound, CRD absent or label mismatch)
+func (r *TombstoneReconciler) deletionCandidate(ctx context.Context, ts assets.TombstoneMetadata, hco *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	logger := log.FromContext(ctx)
 
-	err := r.client.Get(ctx, objKey, live)
+	live, err := r.lookupTombstone(ctx, ts)
 	if err != nil {
-		if errors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
-			// Resource already deleted, or its CRD is not installed — either way nothing to do.
-			logger.V(1).Info("Tombstone resource already deleted or CRD absent",
-				"kind", ts.GVK.Kind,
-				"name", ts.Name,
-				"namespace", ts.Namespace)
-
-			observability.SetTombstoneStatus(ts.Object, observability.TombstoneDeleted)
-
-			return false, nil
-		}
-
 		// Other error (permission, API, etc.)
 		observability.SetTombstoneStatus(ts.Object, observability.TombstoneError)
 
@@ -138,30 +173,79 @@ func (r *TombstoneReconciler) reconcileTombstone(ctx context.Context, ts assets.
 				fmt.Sprintf("Failed to get resource: %v", err))
 		}
 
-		return false, fmt.Errorf("failed to get resource: %w", err)
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+	if live == nil {
+		// Resource already deleted, or its CRD is not installed — either way nothing to do.
+		logger.V(1).Info("Tombstone resource already deleted or CRD absent",
+			"kind", ts.GVK.Kind,
+			"name", ts.Name,
+			"namespace", ts.Namespace)
+
+		observability.SetTombstoneStatus(ts.Object, observability.TombstoneDeleted)
+		observability.SetTombstoneSkippedOwners(ts.Object, nil)
+
+		return nil, nil
 	}
 
 	// SAFETY CHECK: Verify ownership label
-	labels := live.GetLabels()
-	if labels == nil || labels[assets.TombstoneLabel] != assets.TombstoneLabelValue {
-		// Resource exists but doesn't have our management label - skip deletion
+	if !tombstoneLabeled(live) {
+		labels := live.GetLabels()
+		// Resource exists but doesn't have our management label - skip deletion.
+		// Record who owns it so operators can tell why cleanup didn't happen.
+		managers := fieldManagers(live)
 		logger.Info("Skipping tombstone deletion - label mismatch (safety check)",
 			"kind", ts.GVK.Kind,
 			"name", ts.Name,
 			"namespace", ts.Namespace,
 			"expected_label", fmt.Sprintf("%s=%s", assets.TombstoneLabel, assets.TombstoneLabelValue),
-			"actual_labels", labels)
+			"actual_labels", labels,
+			"field_managers", managers)
 
 		// Set metric to skipped
 		observability.SetTombstoneStatus(ts.Object, observability.TombstoneSkipped)
+		observability.SetTombstoneSkippedOwners(ts.Object, managers)
 
 		if r.eventRecorder != nil {
+			owners := "none recorded"
+			if len(managers) > 0 {
+				owners = strings.Join(managers, ", ")
+			}
 			r.eventRecorder.TombstoneSkipped(hco, ts.GVK.Kind, ts.Namespace, ts.Name,
-				"Label mismatch - resource not managed by virt-platform-autopilot")
+				fmt.Sprintf("Label mismatch - resource not managed by virt-platform-autopilot (%s=%q, field managers: %s)",
+					assets.TombstoneLabel, labels[assets.TombstoneLabel], owners))
 		}
 
-		return false, nil
+		return nil, nil
+	}
+
+	return live, nil
+}
+
+// lookupTombstone reads the live resource of a tombstone. It returns nil when the
+// resource or its CRD does not exist.
+func (r *TombstoneReconciler) lookupTombstone(ctx context.Context, ts assets.TombstoneMetadata) (*unstructured.Unstructured, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(ts.GVK)
+
+	err := r.client.Get(ctx, client.ObjectKey{Name: ts.Name, Namespace: ts.Namespace}, live)
+	if errors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
 	}
+	return live, nil
+}
+
+// tombstoneLabeled reports whether live carries the label that allows deleting it
+func tombstoneLabeled(live *unstructured.Unstructured) bool {
+	return live.GetLabels()[assets.TombstoneLabel] == assets.TombstoneLabelValue
+}
+
+// deleteTombstone deletes the live resource of a tombstone
+func (r *TombstoneReconciler) deleteTombstone(ctx context.Context, ts assets.TombstoneMetadata, live, hco *unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
 
 	// Delete the resource
 	logger.Info("Deleting tombstoned resource",
@@ -170,8 +254,12 @@ func (r *TombstoneReconciler) reconcileTombstone(ctx context.Context, ts assets.
 		"namespace", ts.Namespace,
 		"path", ts.Path)
 
-	err = r.client.Delete(ctx, live)
-	if err != nil {
+	cancel := func() {}
+	if r.expectDeletion != nil {
+		cancel = r.expectDeletion(live)
+	}
+	if err := r.client.Delete(ctx, live); err != nil {
+		cancel()
 		// Deletion failed
 		observability.SetTombstoneStatus(ts.Object, observability.TombstoneError)
 
@@ -180,11 +268,12 @@ func (r *TombstoneReconciler) reconcileTombstone(ctx context.Context, ts assets.
 				fmt.Sprintf("Failed to delete: %v", err))
 		}
 
-		return false, fmt.Errorf("failed to delete resource: %w", err)
+		return fmt.Errorf("failed to delete resource: %w", err)
 	}
 
 	// Deletion succeeded
 	observability.SetTombstoneStatus(ts.Object, observability.TombstoneDeleted)
+	observability.SetTombstoneSkippedOwners(ts.Object, nil)
 
 	if r.eventRecorder != nil {
 		r.eventRecorder.TombstoneDeleted(hco, ts.GVK.Kind, ts.Namespace, ts.Name, ts.Path)
@@ -195,5 +284,21 @@ func (r *TombstoneReconciler) reconcileTombstone(ctx context.Context, ts assets.
 		"name", ts.Name,
 		"namespace", ts.Namespace)
 
-	return true, nil
+	return nil
+}
+
+// fieldManagers returns the sorted, de-duplicated field manager names recorded in
+// the object's managedFields.
+func fieldManagers(obj *unstructured.Unstructured) []string {
+	seen := make(map[string]bool)
+	var managers []string
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == "" || seen[entry.Manager] {
+			continue
+		}
+		seen[entry.Manager] = true
+		managers = append(managers, entry.Manager)
+	}
+	sort.Strings(managers)
+	return managers
 }
@@ -21,7 +21,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -31,6 +33,7 @@ import (
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
 )
 
 var _ = Describe("Tombstone Reconciler", func() {
@@ -272,6 +275,42 @@ var _ = Describe("Tombstone Reconciler", func() {
 
 			// Metric should be set to TombstoneSkipped
 		})
+
+		It("should record owning field managers when skipping for label mismatch", func() {
+			observability.TombstoneSkippedOwnerInfo.Reset()
+			recorder := eventtest.NewRecorder()
+
+			resource := &unstructured.Unstructured{}
+			resource.SetAPIVersion("v1")
+			resource.SetKind("ConfigMap")
+			resource.SetName("test-config")
+			resource.SetNamespace("default")
+			resource.SetManagedFields([]metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)}},
+				{Manager: "argocd-controller", Operation: metav1.ManagedFieldsOperationApply, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)}},
+				{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)}},
+			})
+			fakeClient = fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(resource).WithReturnManagedFields().Build()
+			reconciler = NewTombstoneReconciler(fakeClient, loader)
+			reconciler.SetEventRecorder(util.NewEventRecorder(recorder))
+
+			deleted, err := reconciler.reconcileTombstone(ctx, tombstone, hco)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			Expect(testutil.ToFloat64(observability.TombstoneSkippedOwnerInfo.WithLabelValues(
+				"ConfigMap", "test-config", "default", "argocd-controller"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(observability.TombstoneSkippedOwnerInfo.WithLabelValues(
+				"ConfigMap", "test-config", "default", "kubectl-edit"))).To(Equal(1.0))
+			skipped := recorder.ExpectEvent(GinkgoT(), util.EventReasonTombstoneSkipped, "", "")
+			Expect(skipped.Message).To(ContainSubstring("field managers: argocd-controller, kubectl-edit"))
+
+			// Once the resource is gone the owner series are cleared
+			Expect(fakeClient.Delete(ctx, resource)).To(Succeed())
+			_, err = reconciler.reconcileTombstone(ctx, tombstone, hco)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testutil.CollectAndCount(observability.TombstoneSkippedOwnerInfo)).To(Equal(0))
+		})
 	})
 
 	Describe("ReconcileTombstones", func() {
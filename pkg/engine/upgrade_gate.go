@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+)
+
+// machineConfigGroup is the API group of the Machine Config Operator
+const machineConfigGroup = "machineconfiguration.openshift.io"
+
+// rebootingKinds are the Machine Config Operator kinds whose changes roll out to
+// nodes through a MachineConfigPool update, i.e. drain and reboot every node in the pool.
+var rebootingKinds = map[string]bool{
+	"MachineConfig":          true,
+	"KubeletConfig":          true,
+	"ContainerRuntimeConfig": true,
+}
+
+// upgradeGate holds back reboot-triggering applies while the cluster is upgrading.
+//
+// A MachineConfig change during an OpenShift upgrade makes the MCO render a new
+// config mid-rollout, so nodes reboot twice and the upgrade stalls behind the extra
+// drain. Deferred resources are remembered so the controller can requeue sooner and
+// apply them as soon as the cluster is stable again.
+type upgradeGate struct {
+	mu       sync.Mutex
+	enabled  bool
+	deferred map[string]bool
+}
+
+// SetDeferRebootsDuringUpgrade enables upgrade safe-mode: MachineConfig, KubeletConfig
+// and ContainerRuntimeConfig changes are not applied while a ClusterVersion upgrade or
+// a MachineConfigPool rollout is in progress.
+func (p *Patcher) SetDeferRebootsDuringUpgrade(enabled bool) {
+	p.upgradeGate.mu.Lock()
+	defer p.upgradeGate.mu.Unlock()
+	p.upgradeGate.enabled = enabled
+}
+
+// HasDeferred reports whether any resource is currently waiting for the cluster to be stable
+func (p *Patcher) HasDeferred() bool {
+	p.upgradeGate.mu.Lock()
+	defer p.upgradeGate.mu.Unlock()
+	return len(p.upgradeGate.deferred) > 0
+}
+
+// triggersReboot reports whether applying obj rolls out to nodes through an MCP update
+func triggersReboot(obj *unstructured.Unstructured) bool {
+	return obj.GroupVersionKind().Group == machineConfigGroup && rebootingKinds[obj.GetKind()]
+}
+
+// deferReason explains why desired must wait, or returns "" when it can be applied now
+func (g *upgradeGate) deferReason(desired *unstructured.Unstructured, upgrade *pkgcontext.UpgradeContext) string {
+	g.mu.Lock()
+	enabled := g.enabled
+	g.mu.Unlock()
+
+	if !enabled || !upgrade.InProgress() || !triggersReboot(desired) {
+		return ""
+	}
+
+	var reasons []string
+	if upgrade.ClusterVersionProgressing {
+		if upgrade.TargetVersion != "" {
+			reasons = append(reasons, fmt.Sprintf("cluster upgrade to %s in progress", upgrade.TargetVersion))
+		} else {
+			reasons = append(reasons, "cluster upgrade in progress")
+		}
+	}
+	if len(upgrade.UpdatingPools) > 0 {
+		reasons = append(reasons, fmt.Sprintf("MachineConfigPools updating: %s", strings.Join(upgrade.UpdatingPools, ", ")))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// markDeferred records desired as deferred and reports whether it was newly deferred
+func (g *upgradeGate) markDeferred(desired *unstructured.Unstructured) bool {
+	key := throttling.MakeResourceKey(desired.GetNamespace(), desired.GetName(), desired.GetKind())
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	observability.SetDeferred(desired, true)
+	if g.deferred[key] {
+		return false
+	}
+	if g.deferred == nil {
+		g.deferred = make(map[string]bool)
+	}
+	g.deferred[key] = true
+	return true
+}
+
+// clearDeferred forgets a deferral once the resource is applied, in sync or no longer managed
+func (g *upgradeGate) clearDeferred(kind, name, namespace string) {
+	key := throttling.MakeResourceKey(namespace, name, kind)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.deferred[key] {
+		delete(g.deferred, key)
+		observability.DeferredResources.DeleteLabelValues(kind, name, namespace)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgassets "github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/throttling"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+// switchableDriftChecker reports whatever drift is currently set to
+type switchableDriftChecker struct{ drift bool }
+
+func (s *switchableDriftChecker) DetectDrift(_ context.Context, _, _ *unstructured.Unstructured) (bool, error) {
+	return s.drift, nil
+}
+
+func TestDeferReason(t *testing.T) {
+	machineConfig := &unstructured.Unstructured{}
+	machineConfig.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	machineConfig.SetKind("MachineConfig")
+
+	kubeletConfig := &unstructured.Unstructured{}
+	kubeletConfig.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	kubeletConfig.SetKind("KubeletConfig")
+
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+
+	upgrading := &pkgcontext.UpgradeContext{ClusterVersionProgressing: true, TargetVersion: "4.19.0"}
+	rollingOut := &pkgcontext.UpgradeContext{UpdatingPools: []string{"worker"}}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		desired    *unstructured.Unstructured
+		upgrade    *pkgcontext.UpgradeContext
+		wantReason string
+	}{
+		{"MachineConfig during upgrade", true, machineConfig, upgrading, "cluster upgrade to 4.19.0 in progress"},
+		{"KubeletConfig during pool rollout", true, kubeletConfig, rollingOut, "MachineConfigPools updating: worker"},
+		{"MachineConfig on stable cluster", true, machineConfig, &pkgcontext.UpgradeContext{}, ""},
+		{"MachineConfig with unknown upgrade state", true, machineConfig, nil, ""},
+		{"ConfigMap during upgrade", true, configMap, upgrading, ""},
+		{"safe-mode disabled", false, machineConfig, upgrading, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gate := &upgradeGate{enabled: tt.enabled}
+			if got := gate.deferReason(tt.desired, tt.upgrade); got != tt.wantReason {
+				t.Errorf("deferReason() = %q, want %q", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+// TestUpgradeGateDefersUntilStable verifies that a drifted MachineConfig is held back
+// while a pool is updating, reported once, and released once the object is in sync.
+func TestUpgradeGateDefersUntilStable(t *testing.T) {
+	observability.DeferredResources.Reset()
+
+	loader := pkgassets.NewLoader()
+	renderer := NewRenderer(loader)
+
+	assetMeta := &pkgassets.AssetMetadata{
+		Name:      "psi-enable",
+		Path:      "active/machine-config/04-psi-enable.yaml",
+		Component: "MachineConfig",
+	}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "kubevirt-hyperconverged")
+	renderCtx := pkgcontext.NewRenderContext(hco)
+	renderCtx.Upgrade = &pkgcontext.UpgradeContext{UpdatingPools: []string{"worker"}}
+
+	desired, err := renderer.RenderAsset(assetMeta, renderCtx)
+	if err != nil {
+		t.Fatalf("failed to render asset: %v", err)
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(desired.DeepCopy()).Build()
+	rec := eventtest.NewRecorder()
+	checker := &switchableDriftChecker{drift: true}
+
+	// Capacity 1: a deferral that consumed tokens would throttle the second call
+	p := &Patcher{
+		renderer:          renderer,
+		applier:           NewApplier(fakeClient, nil),
+		driftDetector:     checker,
+		throttle:          throttling.NewTokenBucketWithSettings(1, time.Hour),
+		thrashingDetector: throttling.NewThrashingDetector(),
+		client:            fakeClient,
+	}
+	p.SetEventRecorder(util.NewEventRecorder(rec))
+	p.SetDeferRebootsDuringUpgrade(true)
+
+	for i := 0; i < 3; i++ {
+		applied, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx)
+		if err != nil {
+			t.Fatalf("call %d: unexpected error %v", i+1, err)
+		}
+		if applied {
+			t.Fatalf("call %d: applied=true, want deferred", i+1)
+		}
+	}
+
+	if !p.HasDeferred() {
+		t.Error("HasDeferred() = false while a MachineConfig is deferred")
+	}
+	if got := rec.Count(util.EventReasonApplyDeferred); got != 1 {
+		t.Errorf("ApplyDeferred event count = %d, want 1", got)
+	}
+	gauge := observability.DeferredResources.WithLabelValues(desired.GetKind(), desired.GetName(), desired.GetNamespace())
+	if val := testutil.ToFloat64(gauge); val != 1 {
+		t.Errorf("deferred_resources = %v, want 1", val)
+	}
+
+	// Pool finished and the live object now matches: the deferral is released
+	renderCtx.Upgrade = &pkgcontext.UpgradeContext{}
+	checker.drift = false
+	if _, err := p.ReconcileAsset(context.Background(), assetMeta, renderCtx); err != nil {
+		t.Fatalf("unexpected error after upgrade: %v", err)
+	}
+	if p.HasDeferred() {
+		t.Error("HasDeferred() = true after the resource is in sync")
+	}
+	if count := testutil.CollectAndCount(observability.DeferredResources); count != 0 {
+		t.Errorf("deferred_resources series = %d, want 0", count)
+	}
+}
+
+func TestUpgradeGateDeferReasonCombinesCauses(t *testing.T) {
+	mc := &unstructured.Unstructured{}
+	mc.SetAPIVersion("machineconfiguration.openshift.io/v1")
+	mc.SetKind("ContainerRuntimeConfig")
+
+	gate := &upgradeGate{enabled: true}
+	reason := gate.deferReason(mc, &pkgcontext.UpgradeContext{
+		ClusterVersionProgressing: true,
+		UpdatingPools:             []string{"master", "worker"},
+	})
+	if !strings.Contains(reason, "cluster upgrade in progress") || !strings.Contains(reason, "master, worker") {
+		t.Errorf("deferReason() = %q, want both the upgrade and the updating pools", reason)
+	}
+}
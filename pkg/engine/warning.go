@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WarningSentinel is the comment a template renders to report a problem that does not
+// stop the asset from being applied, such as a user setting it had to ignore. The
+// message is carried into render output and events:
+//
+//	{{- range .MetalLB.Conflicts }}
+//	# autopilot:warning message={{ . }}
+//	{{- end }}
+//
+// Being a comment, it can appear next to the resource and next to the skip sentinel.
+const WarningSentinel = "# autopilot:warning"
+
+var warningSentinelPattern = regexp.MustCompile(`(?m)^[ \t]*#[ \t]*autopilot:warning[ \t]+message=(.*?)[ \t]*$`)
+
+// extractWarnings returns the messages of the warning sentinels in rendered, in order
+func extractWarnings(rendered []byte) []string {
+	var warnings []string
+	for _, match := range warningSentinelPattern.FindAllSubmatch(rendered, -1) {
+		if message := strings.Trim(strings.TrimSpace(string(match[1])), `"'`); message != "" {
+			warnings = append(warnings, message)
+		}
+	}
+	return warnings
+}
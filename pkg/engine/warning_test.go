@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func TestExtractWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		rendered string
+		want     []string
+	}{
+		{name: "no sentinel", rendered: "apiVersion: v1\nkind: ConfigMap\n"},
+		{name: "several warnings in order",
+			rendered: "# autopilot:warning message=first\n  #autopilot:warning message=\"second\"\napiVersion: v1\n",
+			want:     []string{"first", "second"}},
+		{name: "next to the skip sentinel",
+			rendered: "# autopilot:warning message=range overlaps a host\n# autopilot:skip reason=x\n",
+			want:     []string{"range overlaps a host"}},
+		{name: "empty message is dropped", rendered: "# autopilot:warning message=\n"},
+		{name: "sentinel text inside a value is ignored", rendered: "data:\n  note: \"# autopilot:warning message=x\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractWarnings([]byte(tt.rendered)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractWarnings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderAssetRecordsWarnings(t *testing.T) {
+	renderer := NewRenderer(assets.NewLoader())
+	assetMeta := &assets.AssetMetadata{
+		Name:  "metallb-address-pool",
+		Path:  "active/operators/metallb-address-pool.yaml.tpl",
+		Scope: assets.ScopeNamespaced,
+	}
+
+	hco := pkgcontext.NewMockHCO("hco", "ns")
+	hco.SetAnnotations(map[string]string{pkgcontext.MetalLBAddressPoolsAnnotation: "192.168.111.10-192.168.111.20"})
+	ctx := pkgcontext.NewRenderContext(hco)
+	ctx.MetalLB = pkgcontext.NewMetalLBContext(hco, &pkgcontext.MetalLBInventory{
+		MachineNetworks: []string{"192.168.111.0/24"},
+		Reserved:        map[string]string{"192.168.111.12": "host worker-0"},
+	})
+
+	rendered, err := renderer.RenderAsset(assetMeta, ctx)
+	if err != nil {
+		t.Fatalf("RenderAsset() error = %v", err)
+	}
+	if rendered.GetKind() != "IPAddressPool" {
+		t.Errorf("rendered kind = %q, want IPAddressPool", rendered.GetKind())
+	}
+	want := []string{"address pool 192.168.111.10-192.168.111.20 contains 192.168.111.12, the address of host worker-0"}
+	if got := ctx.Warnings(assetMeta.Name); !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings() = %q, want %q", got, want)
+	}
+
+	// A render without conflicts clears the previous warnings, and no pool skips the asset
+	ctx.MetalLB = &pkgcontext.MetalLBContext{}
+	if _, err := renderer.RenderAsset(assetMeta, ctx); err == nil {
+		t.Error("RenderAsset() rendered a pool without addresses, want a skip")
+	} else if _, skipped := SkipReason(err); !skipped {
+		t.Errorf("RenderAsset() error = %v, want a skip", err)
+	}
+	if got := ctx.Warnings(assetMeta.Name); got != nil {
+		t.Errorf("Warnings() = %q after a render without conflicts, want none", got)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hugepages sizes a NUMA-aware hugepage allocation for each MachineConfigPool
+// that runs VMs, from the VM density hints on the HyperConverged.
+package hugepages
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/perfprofile"
+)
+
+const (
+	// VMsPerNodeAnnotation on the HCO is the number of hugepage-backed VMs each node
+	// should be able to host.
+	VMsPerNodeAnnotation = "platform.kubevirt.io/hugepages-vms-per-node"
+
+	// VMMemoryAnnotation on the HCO is the guest memory of those VMs, e.g. "16Gi".
+	VMMemoryAnnotation = "platform.kubevirt.io/hugepages-vm-memory"
+
+	// PageSizeAnnotation on the HCO selects the hugepage size: "1Gi" (default) or "2Mi".
+	PageSizeAnnotation = "platform.kubevirt.io/hugepages-page-size"
+
+	// maxPercent of a NUMA node's memory can go to hugepages; the rest is left for the
+	// kernel, kubelet and VMs without hugepages.
+	maxPercent = 80
+
+	workerRoleLabel = "node-role.kubernetes.io/worker"
+
+	// numaLabel is published by Node Feature Discovery
+	numaLabel = "feature.node.kubernetes.io/memory-numa"
+)
+
+// pageSizes are the hugepage sizes x86_64 and aarch64 (4k granule) both support
+var pageSizes = map[string]int64{
+	"1Gi": 1 << 30,
+	"2Mi": 2 << 20,
+}
+
+// Recommend sizes the hugepages for the MachineConfigPools that have worker nodes. Each
+// node gets enough pages for VMsPerNodeAnnotation VMs of VMMemoryAnnotation, spread
+// evenly over its NUMA nodes so that no VM has to span two of them. One MachineConfig
+// carries the allocation, rendered into the worker role (the master role on compact
+// clusters); a pool that does not render that role, or whose smallest node cannot hold
+// the pages, is not Ready. The context is Ready if any pool is.
+func Recommend(nodes []corev1.Node, pools []pkgcontext.MachineConfigPool, hco *unstructured.Unstructured) *pkgcontext.HugePagesContext {
+	result := &pkgcontext.HugePagesContext{}
+
+	annotations := hco.GetAnnotations()
+	rawVMs, hasVMs := annotations[VMsPerNodeAnnotation]
+	rawMemory, hasMemory := annotations[VMMemoryAnnotation]
+	if !hasVMs && !hasMemory {
+		result.Reason = "no hugepage density hints on the HyperConverged"
+		return result
+	}
+	if _, ok := annotations[perfprofile.HugePagesPercentAnnotation]; ok {
+		result.Reason = fmt.Sprintf("%s is set too: hugepages are allocated by the PerformanceProfile",
+			perfprofile.HugePagesPercentAnnotation)
+		return result
+	}
+
+	vms, err := strconv.Atoi(rawVMs)
+	if err != nil || vms <= 0 {
+		result.Reason = fmt.Sprintf("invalid %s %q: must be a positive integer", VMsPerNodeAnnotation, rawVMs)
+		return result
+	}
+	memory, err := resource.ParseQuantity(rawMemory)
+	if err != nil || memory.Sign() <= 0 {
+		result.Reason = fmt.Sprintf("invalid %s %q: must be a positive quantity such as 16Gi", VMMemoryAnnotation, rawMemory)
+		return result
+	}
+	pageSize := annotations[PageSizeAnnotation]
+	if pageSize == "" {
+		pageSize = "1Gi"
+	}
+	pageBytes, ok := pageSizes[pageSize]
+	if !ok {
+		result.Reason = fmt.Sprintf("invalid %s %q: must be 1Gi or 2Mi", PageSizeAnnotation, pageSize)
+		return result
+	}
+
+	result.PageSize = pageSize
+	result.PageSizeKB = pageBytes >> 10
+	result.VMsPerNode = vms
+	result.PagesPerVM = (memory.Value() + pageBytes - 1) / pageBytes
+	result.MaxPercent = maxPercent
+
+	members := pkgcontext.AssignPoolNodes(nodes, pools)
+	for _, pool := range pools {
+		if !slices.ContainsFunc(members[pool.Name], isWorker) {
+			continue
+		}
+		// VMs run on the workers, or on the schedulable masters of a compact cluster
+		if result.Role == "" || pool.Name == pkgcontext.WorkerPool {
+			result.Role = pool.Role()
+		}
+	}
+	for _, pool := range pools {
+		if !slices.ContainsFunc(members[pool.Name], isWorker) {
+			continue
+		}
+		entry := size(pool.Name, members[pool.Name], vms, result.PagesPerVM, pageBytes)
+		if !pool.Renders(result.Role) {
+			entry.Ready = false
+			entry.Reason = fmt.Sprintf("pool %s does not render %s MachineConfigs", pool.Name, result.Role)
+		}
+		if entry.Ready {
+			result.Ready = true
+		}
+		result.Pools = append(result.Pools, entry)
+	}
+	slices.SortFunc(result.Pools, func(a, b pkgcontext.HugePagesPool) int { return cmp.Compare(a.Name, b.Name) })
+
+	if len(result.Pools) == 0 {
+		result.Reason = "no MachineConfigPool has worker nodes"
+	} else if !result.Ready {
+		result.Reason = "no MachineConfigPool can hold the requested hugepages"
+	}
+	return result
+}
+
+func isWorker(node *corev1.Node) bool {
+	_, ok := node.Labels[workerRoleLabel]
+	return ok
+}
+
+// size computes the allocation for the nodes of one pool and checks it against the
+// smallest of them. The node itself splits the pages over the NUMA nodes it finds at
+// boot; here the NUMA layout is only known from NFD, so this is an estimate.
+func size(pool string, nodes []*corev1.Node, vms int, pagesPerVM, pageBytes int64) pkgcontext.HugePagesPool {
+	entry := pkgcontext.HugePagesPool{Name: pool, Nodes: len(nodes), NUMANodes: 1}
+
+	var smallest *corev1.Node
+	for _, node := range nodes {
+		if node.Labels[numaLabel] == "true" {
+			// NFD only reports whether there is more than one NUMA node
+			entry.NUMANodes = 2
+		}
+		if smallest == nil || node.Status.Capacity.Memory().Cmp(*smallest.Status.Capacity.Memory()) < 0 {
+			smallest = node
+		}
+	}
+
+	vmsPerNUMANode := (vms + entry.NUMANodes - 1) / entry.NUMANodes
+	entry.PagesPerNUMANode = int64(vmsPerNUMANode) * pagesPerVM
+	entry.Pages = entry.PagesPerNUMANode * int64(entry.NUMANodes)
+
+	perNUMANode := smallest.Status.Capacity.Memory().Value() / int64(entry.NUMANodes)
+	requested := entry.PagesPerNUMANode * pageBytes
+	if requested > perNUMANode*maxPercent/100 {
+		entry.Reason = fmt.Sprintf("node %s has %s per NUMA node, %s of hugepages requested (at most %d%%)",
+			smallest.Name, formatBytes(perNUMANode), formatBytes(requested), maxPercent)
+		return entry
+	}
+	entry.Ready = true
+	return entry
+}
+
+// formatBytes renders bytes as a binary quantity, e.g. "48Gi"
+func formatBytes(bytes int64) string {
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepages
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/perfprofile"
+)
+
+func node(name, memory string, labels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{Capacity: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+}
+
+func pool(name string, nodeSelector, machineConfigSelector *metav1.LabelSelector) pkgcontext.MachineConfigPool {
+	return pkgcontext.MachineConfigPool{
+		Name:                  name,
+		NodeSelector:          nodeSelector,
+		MachineConfigSelector: machineConfigSelector,
+	}
+}
+
+// openshiftPools are the default master and worker pools and a custom "numa" pool that
+// takes the workers labeled node-role.kubernetes.io/numa out of the worker pool
+func openshiftPools() []pkgcontext.MachineConfigPool {
+	return []pkgcontext.MachineConfigPool{
+		pool("worker",
+			&metav1.LabelSelector{MatchLabels: map[string]string{workerRoleLabel: ""}},
+			&metav1.LabelSelector{MatchLabels: map[string]string{pkgcontext.MachineConfigRoleLabel: "worker"}}),
+		pool("master",
+			&metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/master": ""}},
+			&metav1.LabelSelector{MatchLabels: map[string]string{pkgcontext.MachineConfigRoleLabel: "master"}}),
+		pool("numa",
+			&metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/numa": ""}},
+			&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key: pkgcontext.MachineConfigRoleLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"worker", "numa"},
+			}}}),
+	}
+}
+
+func hcoWith(annotations map[string]string) *unstructured.Unstructured {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetAnnotations(annotations)
+	return hco
+}
+
+func TestRecommend(t *testing.T) {
+	nodes := []corev1.Node{
+		node("master-0", "32Gi", map[string]string{"node-role.kubernetes.io/master": ""}),
+		node("worker-0", "256Gi", map[string]string{workerRoleLabel: ""}),
+		node("worker-1", "192Gi", map[string]string{workerRoleLabel: ""}),
+		node("numa-0", "512Gi", map[string]string{workerRoleLabel: "", "node-role.kubernetes.io/numa": "", numaLabel: "true"}),
+	}
+	hco := hcoWith(map[string]string{VMsPerNodeAnnotation: "5", VMMemoryAnnotation: "15.5Gi"})
+
+	got := Recommend(nodes, openshiftPools(), hco)
+
+	want := &pkgcontext.HugePagesContext{
+		Ready:      true,
+		PageSize:   "1Gi",
+		PageSizeKB: 1 << 20,
+		VMsPerNode: 5,
+		PagesPerVM: 16, // 15.5Gi rounded up to whole pages
+		MaxPercent: 80,
+		Role:       "worker",
+		Pools: []pkgcontext.HugePagesPool{
+			// 3 of the 5 VMs on each NUMA node, so none has to span two
+			{Name: "numa", Nodes: 1, NUMANodes: 2, PagesPerNUMANode: 48, Pages: 96, Ready: true},
+			// the master pool has no workers; numa-0 is not counted as a worker pool node
+			{Name: "worker", Nodes: 2, NUMANodes: 1, PagesPerNUMANode: 80, Pages: 80, Ready: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Recommend() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecommendValidatesNodeMemory(t *testing.T) {
+	nodes := []corev1.Node{
+		node("worker-0", "256Gi", map[string]string{workerRoleLabel: ""}),
+		node("worker-1", "96Gi", map[string]string{workerRoleLabel: ""}),
+	}
+	hco := hcoWith(map[string]string{VMsPerNodeAnnotation: "5", VMMemoryAnnotation: "16Gi"})
+
+	got := Recommend(nodes, openshiftPools(), hco)
+
+	if got.Ready {
+		t.Fatalf("Recommend() is Ready with 80Gi of hugepages on a 96Gi node")
+	}
+	if got.Reason != "no MachineConfigPool can hold the requested hugepages" {
+		t.Errorf("Reason = %q", got.Reason)
+	}
+	want := "node worker-1 has 96Gi per NUMA node, 80Gi of hugepages requested (at most 80%)"
+	if len(got.Pools) != 1 || got.Pools[0].Reason != want {
+		t.Errorf("Pools = %+v, want one pool with Reason %q", got.Pools, want)
+	}
+}
+
+func TestRecommendCompactCluster(t *testing.T) {
+	// Schedulable masters run VMs, so the master pool is sized
+	schedulable := map[string]string{"node-role.kubernetes.io/master": "", workerRoleLabel: ""}
+	nodes := []corev1.Node{
+		node("master-0", "128Gi", schedulable),
+		node("master-1", "128Gi", schedulable),
+	}
+	hco := hcoWith(map[string]string{VMsPerNodeAnnotation: "2", VMMemoryAnnotation: "8Gi", PageSizeAnnotation: "2Mi"})
+
+	got := Recommend(nodes, openshiftPools(), hco)
+
+	if !got.Ready || got.Role != "master" || len(got.Pools) != 1 {
+		t.Fatalf("Recommend() = %+v, want the master role and pool only", got)
+	}
+	if pool := got.Pools[0]; pool.Name != "master" || pool.Pages != 8192 || got.PageSizeKB != 2048 {
+		t.Errorf("Pools[0] = %+v (PageSizeKB %d), want 8192 2Mi pages for master", pool, got.PageSizeKB)
+	}
+}
+
+func TestRecommendRejectsPoolWithoutWorkerRole(t *testing.T) {
+	pools := append(openshiftPools(), pool("gpu",
+		&metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/gpu": ""}},
+		&metav1.LabelSelector{MatchLabels: map[string]string{pkgcontext.MachineConfigRoleLabel: "gpu"}}))
+	nodes := []corev1.Node{
+		node("gpu-0", "256Gi", map[string]string{workerRoleLabel: "", "node-role.kubernetes.io/gpu": ""}),
+		node("worker-0", "256Gi", map[string]string{workerRoleLabel: ""}),
+	}
+	hco := hcoWith(map[string]string{VMsPerNodeAnnotation: "1", VMMemoryAnnotation: "8Gi"})
+
+	got := Recommend(nodes, pools, hco)
+
+	if !got.Ready || len(got.Pools) != 2 {
+		t.Fatalf("Recommend() = %+v, want the gpu and worker pools", got)
+	}
+	if gpu := got.Pools[0]; gpu.Ready || gpu.Reason != "pool gpu does not render worker MachineConfigs" {
+		t.Errorf("gpu pool = %+v, want it rejected", gpu)
+	}
+}
+
+func TestRecommendInvalidHints(t *testing.T) {
+	nodes := []corev1.Node{node("worker-0", "256Gi", map[string]string{workerRoleLabel: ""})}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantReason  string
+	}{
+		{name: "no hints", wantReason: "no hugepage density hints"},
+		{name: "missing VM memory",
+			annotations: map[string]string{VMsPerNodeAnnotation: "4"},
+			wantReason:  "invalid " + VMMemoryAnnotation},
+		{name: "non-numeric density",
+			annotations: map[string]string{VMsPerNodeAnnotation: "many", VMMemoryAnnotation: "8Gi"},
+			wantReason:  "invalid " + VMsPerNodeAnnotation},
+		{name: "unsupported page size",
+			annotations: map[string]string{VMsPerNodeAnnotation: "4", VMMemoryAnnotation: "8Gi", PageSizeAnnotation: "16Gi"},
+			wantReason:  "invalid " + PageSizeAnnotation},
+		{name: "PerformanceProfile hugepages too",
+			annotations: map[string]string{VMsPerNodeAnnotation: "4", VMMemoryAnnotation: "8Gi", perfprofile.HugePagesPercentAnnotation: "50"},
+			wantReason:  "allocated by the PerformanceProfile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Recommend(nodes, openshiftPools(), hcoWith(tt.annotations))
+			if got.Ready || !strings.Contains(got.Reason, tt.wantReason) {
+				t.Errorf("Recommend() = %+v, want not Ready with Reason containing %q", got, tt.wantReason)
+			}
+		})
+	}
+}
@@ -56,6 +56,53 @@ var (
 		[]string{"kind", "name", "namespace"},
 	)
 
+	// DriftCorrectionsTotal counts drift corrections by the field manager that caused the
+	// drift, so the controller or user fighting the autopilot can be identified
+	DriftCorrectionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "drift_corrections_total",
+			Help:      "Total number of drift corrections, by the field manager whose changes were reverted",
+		},
+		[]string{"kind", "name", "namespace", "manager"},
+	)
+
+	// RecreationsTotal counts managed objects recreated after the watch saw them deleted
+	RecreationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "recreations_total",
+			Help:      "Total number of managed objects recreated after being deleted outside the autopilot",
+		},
+		[]string{"kind", "name", "namespace"},
+	)
+
+	// RecreationCooldownsTotal counts objects left deleted after too many recreations
+	RecreationCooldownsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "recreation_cooldowns_total",
+			Help:      "Total number of recreation cool-downs of managed objects that kept being deleted",
+		},
+		[]string{"kind", "name", "namespace"},
+	)
+
+	// ApplyRetriesTotal counts the retries of objects whose admission webhook was unavailable
+	// or too slow: "scheduled" when a pass defers an object, "succeeded" and "failed" per
+	// background apply
+	ApplyRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "apply_retries_total",
+			Help:      "Total number of apply retries of objects whose admission was unavailable, by result",
+		},
+		[]string{"kind", "result"},
+	)
+
 	// PausedResources tracks resources currently paused due to edit wars.
 	// 1 = paused (reconcile-paused annotation set), 0 = active (annotation removed)
 	// This gauge provides a stable signal for alerting on ongoing edit wars.
@@ -77,7 +124,7 @@ var (
 			Namespace: namespace,
 			Subsystem: subsystem,
 			Name:      "customization_info",
-			Help:      "Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged",
+			Help:      "Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated",
 		},
 		[]string{"kind", "name", "namespace", "type"},
 	)
@@ -121,6 +168,454 @@ var (
 		},
 		[]string{"kind", "name", "namespace"},
 	)
+
+	// HardwarePendingRemoval records hardware detectors that are no longer detected on
+	// any node but are held true by churn damping (--hardware-removal-grace-period).
+	// The value is the Unix time at which the detector will be released; the series is
+	// removed once the hardware returns or the grace period ends.
+	HardwarePendingRemoval = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hardware_pending_removal_timestamp_seconds",
+			Help:      "Unix time at which a hardware detector held by churn damping will be released",
+		},
+		[]string{"detector"},
+	)
+
+	// HardwareDetected records the result of each hardware detector's node scan:
+	// 1 when a node matches it, 0 when none does. Unlike the render context, it is
+	// not held by churn damping, so the raw flapping shows in its time series.
+	HardwareDetected = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hardware_detected",
+			Help:      "Whether a node matches the hardware detector (1=detected, 0=not detected), before churn damping",
+		},
+		[]string{"detector"},
+	)
+
+	// DeferredResources tracks reboot-triggering resources (MachineConfig, KubeletConfig, ...)
+	// whose apply is held back by upgrade safe-mode while the cluster is upgrading or a
+	// MachineConfigPool is rolling out. Always 1 when present; removed once applied or in sync.
+	DeferredResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "deferred_resources",
+			Help:      "Reboot-triggering resources deferred until the cluster upgrade completes (always 1 when present)",
+		},
+		[]string{"kind", "name", "namespace"},
+	)
+
+	// DeprecatedAssetInfo flags deprecated catalog assets that are still being applied.
+	// Always 1 while the asset is active; removed once it is excluded or tombstoned.
+	// Lets operators plan for an asset's removal before the tombstone lands.
+	DeprecatedAssetInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "deprecated_asset_info",
+			Help:      "Deprecated assets still being applied (always 1 when present)",
+		},
+		[]string{"asset", "replaced_by", "removal_version"},
+	)
+
+	// CatalogAssets counts the assets of the active catalog by component, install mode and phase.
+	// Static for the life of the process unless a remote catalog replaces the embedded one.
+	CatalogAssets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "catalog_assets",
+			Help:      "Number of assets in the active asset catalog by component, install mode and phase",
+		},
+		[]string{"component", "install_mode", "phase"},
+	)
+
+	// CatalogVersion identifies the active catalog (always 1). version is the hand-maintained
+	// catalog version; digest changes with any catalog or asset content change, bumped or not.
+	CatalogVersion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "catalog_version",
+			Help:      "Version and content digest of the active asset catalog (always 1)",
+		},
+		[]string{"version", "digest"},
+	)
+
+	// CatalogRemote is 1 while the catalog fetched from --catalog-url is in use and 0 while
+	// the embedded catalog is, because none is configured or the remote one was rolled back
+	CatalogRemote = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "catalog_remote",
+			Help:      "Whether the asset catalog fetched from the remote catalog URL is in use (1) or the embedded one (0)",
+		},
+	)
+
+	// CatalogRefreshFailuresTotal counts remote catalog fetches or validations that failed
+	CatalogRefreshFailuresTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "catalog_refresh_failures_total",
+			Help:      "Total number of failed remote asset catalog fetches or validations",
+		},
+	)
+
+	// CacheObjects tracks how many objects the controller-runtime cache holds per watched type.
+	// Label-filtered types should stay close to the number of assets we manage; the
+	// unfiltered ByObject exemptions (HyperConverged, CustomResourceDefinition) scale with the cluster.
+	CacheObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_objects",
+			Help:      "Number of objects held in the informer cache per watched type",
+		},
+		[]string{"group", "version", "kind"},
+	)
+
+	// CacheEstimatedBytes estimates informer memory per watched type as the summed JSON size
+	// of the cached objects. Go heap overhead makes the real footprint larger, but the value
+	// tracks growth and shows which type dominates. Used by the VirtPlatformCacheMemoryHigh alert.
+	CacheEstimatedBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_estimated_bytes",
+			Help:      "Estimated informer cache memory per watched type (serialized object size)",
+		},
+		[]string{"group", "version", "kind"},
+	)
+
+	// InformerSyncDuration is how long after startup the informer of each watched type
+	// completed its initial list. Large types dominate startup time and memory alike.
+	InformerSyncDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "informer_sync_duration_seconds",
+			Help:      "Seconds from startup until the informer cache of a watched type synced",
+		},
+		[]string{"group", "version", "kind"},
+	)
+
+	// CacheSynced is 1 once every watched type's informer synced; reconciles wait for it
+	CacheSynced = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_synced",
+			Help:      "Whether the informer caches of all watched types synced (1) or reconciles are still held back (0)",
+		},
+	)
+
+	// ReconcileTriggersTotal counts what drives reconcile load, by cause (see the Trigger* constants).
+	// Watch-driven causes are counted per event; requeue causes when the requeue is scheduled.
+	// The work queue deduplicates requests, so this can exceed the number of reconciles run.
+	ReconcileTriggersTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "reconcile_triggers_total",
+			Help:      "Total number of reconcile triggers by cause (watch events and scheduled requeues)",
+		},
+		[]string{"cause"},
+	)
+
+	// BlastRadiusHeld is the number of changes held back by the blast radius guard, by
+	// operation ("reboot" or "delete"); 0 once the batch is applied or acknowledged.
+	BlastRadiusHeld = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "blast_radius_held",
+			Help:      "Number of changes held back because a single reconcile would exceed the blast radius limit",
+		},
+		[]string{"operation"},
+	)
+
+	// RebootImpactNodes is the number of nodes the reboot-triggering changes of the last
+	// reconcile are predicted to drain and reboot, held back or not; 0 without such changes.
+	RebootImpactNodes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "reboot_impact_nodes",
+			Help:      "Nodes predicted to drain and reboot for the node-rebooting changes of the last reconcile",
+		},
+	)
+
+	// CanaryRolloutPhase is the phase of the canary rollout to a pool: 0 idle, 1 while the
+	// canary pool updates, 2 while it is rolled back.
+	CanaryRolloutPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "canary_rollout_phase",
+			Help:      "Canary rollout phase of node-rebooting changes (0 = idle, 1 = canary updating, 2 = rolling back)",
+		},
+		[]string{"pool"},
+	)
+
+	// CanaryRolloutsTotal counts finished canary rollouts by result ("succeeded" or "rolled_back")
+	CanaryRolloutsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "canary_rollouts_total",
+			Help:      "Total number of finished canary rollouts of node-rebooting changes by result",
+		},
+		[]string{"result"},
+	)
+
+	// ReconcileConsecutiveFailures is the number of reconciles of an HCO that failed in a
+	// row; the series is removed on the next successful reconcile.
+	ReconcileConsecutiveFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "reconcile_consecutive_failures",
+			Help:      "Number of consecutive failed reconciles per HyperConverged CR",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ApplyTimeoutsTotal counts asset reconciles cancelled by the per-asset or reconcile timeout
+	ApplyTimeoutsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "apply_timeouts_total",
+			Help:      "Total number of asset reconciles cancelled because they exceeded the apply timeout",
+		},
+		[]string{"asset"},
+	)
+
+	// AssetErrorsTotal counts failed asset reconciles by the reason they failed with
+	AssetErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "asset_errors_total",
+			Help:      "Total number of failed asset reconciles by reason (RenderFailed, ConditionFailed, ApplyConflict, DependencyMissing, ApplyTimeout, ApplyFailed)",
+		},
+		[]string{"asset", "reason"},
+	)
+
+	// ConditionEvaluationDuration tracks how long conditions that query the cluster take
+	// to evaluate, so slow lookups can be told apart from slow applies
+	ConditionEvaluationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "condition_evaluation_duration_seconds",
+			Help:      "Duration of evaluating asset conditions that query the cluster (crd, operator, storage-class)",
+			Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0},
+		},
+		[]string{"type"},
+	)
+
+	// LabelRepairsTotal counts managed-by labels restored on objects the autopilot applied
+	LabelRepairsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "label_repairs_total",
+			Help:      "Total number of managed-by labels restored on objects applied by the autopilot",
+		},
+		[]string{"kind"},
+	)
+
+	// DroppedFieldsTotal counts fields an asset template stopped rendering, as found on apply
+	DroppedFieldsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dropped_fields_total",
+			Help:      "Total number of fields applied objects lost because their asset template no longer renders them",
+		},
+		[]string{"asset"},
+	)
+
+	// NodeEventsSuppressedTotal counts node events coalesced into an already scheduled
+	// reconcile by the node watch debounce
+	NodeEventsSuppressedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "node_events_suppressed_total",
+			Help:      "Total number of node events coalesced into a reconcile already scheduled by the node watch debounce",
+		},
+	)
+
+	// UnlabeledObjects is the number of objects applied by the autopilot that lacked the
+	// managed-by label in the last label repair pass and were not repaired
+	UnlabeledObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "unlabeled_objects",
+			Help:      "Objects applied by the autopilot that lack the managed-by label and were left unrepaired",
+		},
+		[]string{"kind"},
+	)
+
+	// MaintenanceWindowRemaining is the time left in the HCO's maintenance window; the
+	// series is removed when no window is open.
+	MaintenanceWindowRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "maintenance_window_remaining_seconds",
+			Help:      "Seconds until the maintenance window of a HyperConverged CR ends (drift is not corrected meanwhile)",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// HCOGeneration is the metadata.generation of the HCO last seen by a reconcile
+	HCOGeneration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hco_generation",
+			Help:      "metadata.generation of the HyperConverged CR as last seen by the autopilot",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// HCOObservedGeneration is the HCO generation the last successful reconcile acted on;
+	// it trails HCOGeneration while an edit is not yet fully reconciled.
+	HCOObservedGeneration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hco_observed_generation",
+			Help:      "Generation of the HyperConverged CR the autopilot last fully reconciled",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// StatusConditions mirrors the ClusterOperator conditions (Available, Progressing,
+	// Degraded) of an HCO's AutopilotStatus: 1 when True, 0 when False, one reason each
+	StatusConditions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "status_conditions",
+			Help:      "ClusterOperator-style conditions of the autopilot for a HyperConverged CR (1 = True, 0 = False)",
+		},
+		[]string{"namespace", "name", "condition", "reason"},
+	)
+
+	// NotificationsTotal counts reconcile summaries posted to the notification webhook
+	// by result ("delivered", "failed", or "dropped" when the delivery queue was full)
+	NotificationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "notifications_total",
+			Help:      "Total number of reconcile summaries posted to the notification webhook by result",
+		},
+		[]string{"result"},
+	)
+
+	// HCOAPISupported reports the newest API version the HyperConverged CRD serves and
+	// whether the autopilot supports it; while it is 0 the autopilot only reports drift
+	HCOAPISupported = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hco_api_supported",
+			Help:      "Whether the newest served HyperConverged API version is supported (1) or the autopilot is in report-only mode (0)",
+		},
+		[]string{"version"},
+	)
+
+	// LeaderElectionIsLeader is 1 on the replica holding the leader election lease and 0 on
+	// standby replicas; only exported when leader election is enabled
+	LeaderElectionIsLeader = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "leader_election_is_leader",
+			Help:      "Whether this replica is the leader (1) or a standby (0)",
+		},
+	)
+
+	// LeaderElectionLeaderHealthy is 1 while the lease, as observed by this replica, has a
+	// holder that renewed it within the lease duration
+	LeaderElectionLeaderHealthy = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "leader_election_leader_healthy",
+			Help:      "Whether this replica observes a leader renewing the leader election lease in time (1) or not (0)",
+		},
+	)
+
+	// LeaderElectionTransitions is the number of leadership changes recorded in the lease
+	LeaderElectionTransitions = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "leader_election_lease_transitions",
+			Help:      "Leadership changes recorded in the leader election lease",
+		},
+	)
+
+	// LeaderElectionLastTransition is when the current leader acquired the lease
+	LeaderElectionLastTransition = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "leader_election_last_transition_timestamp_seconds",
+			Help:      "Unix time the current leader acquired the leader election lease",
+		},
+	)
+
+	// TombstoneSkippedOwnerInfo records the field managers owning a tombstoned resource
+	// whose deletion was skipped because it lacks our management label.
+	// Always 1 per (resource, manager); cleared once the tombstone resolves.
+	// Lets operators see who took over a resource without reading managedFields.
+	TombstoneSkippedOwnerInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "tombstone_skipped_owner_info",
+			Help:      "Field managers owning a tombstoned resource skipped due to label mismatch (always 1 when present)",
+		},
+		[]string{"kind", "name", "namespace", "manager"},
+	)
+)
+
+// Reconcile trigger causes (ReconcileTriggersTotal "cause" label)
+const (
+	// Watch events
+	TriggerHCOChange       = "hco_change"
+	TriggerManagedResource = "managed_resource_change"
+	TriggerCRDChange       = "crd_change"
+	TriggerOverridesChange = "overrides_change"
+	TriggerExclusionChange = "exclusion_change"
+	TriggerCatalogChange   = "catalog_change"
+	TriggerNodeChange      = "node_change"
+	TriggerApplyRetry      = "apply_retry"
+
+	// Scheduled requeues
+	TriggerPeriodicResync  = "periodic_resync"
+	TriggerDeferredRecheck = "deferred_recheck"
+	TriggerHardwareRelease = "hardware_release"
+	TriggerMaintenanceEnd  = "maintenance_end"
+	TriggerOverrideExpiry  = "override_expiry"
+	TriggerCanaryRecheck   = "canary_recheck"
+	TriggerRecreationEnd   = "recreation_cooldown_end"
+	TriggerErrorRetry      = "error_retry"
+	TriggerCacheWarmup     = "cache_warmup"
 )
 
 const (
@@ -137,11 +632,51 @@ func init() {
 	metrics.Registry.MustRegister(
 		ComplianceStatus,
 		ThrashingTotal,
+		DriftCorrectionsTotal,
+		RecreationsTotal,
+		RecreationCooldownsTotal,
+		ApplyRetriesTotal,
 		PausedResources,
 		CustomizationInfo,
 		MissingDependency,
 		ReconcileDuration,
 		TombstoneStatus,
+		TombstoneSkippedOwnerInfo,
+		HardwarePendingRemoval,
+		HardwareDetected,
+		DeferredResources,
+		DeprecatedAssetInfo,
+		CatalogAssets,
+		CatalogVersion,
+		CatalogRemote,
+		CatalogRefreshFailuresTotal,
+		CacheObjects,
+		CacheEstimatedBytes,
+		InformerSyncDuration,
+		CacheSynced,
+		ReconcileTriggersTotal,
+		BlastRadiusHeld,
+		RebootImpactNodes,
+		CanaryRolloutPhase,
+		CanaryRolloutsTotal,
+		ReconcileConsecutiveFailures,
+		MaintenanceWindowRemaining,
+		ApplyTimeoutsTotal,
+		AssetErrorsTotal,
+		ConditionEvaluationDuration,
+		LabelRepairsTotal,
+		DroppedFieldsTotal,
+		NodeEventsSuppressedTotal,
+		UnlabeledObjects,
+		HCOGeneration,
+		HCOObservedGeneration,
+		StatusConditions,
+		NotificationsTotal,
+		HCOAPISupported,
+		LeaderElectionIsLeader,
+		LeaderElectionLeaderHealthy,
+		LeaderElectionTransitions,
+		LeaderElectionLastTransition,
 	)
 }
 
@@ -165,8 +700,33 @@ func IncThrashing(obj *unstructured.Unstructured) {
 	).Inc()
 }
 
+// IncDriftCorrection counts one correction of drift manager caused on obj
+func IncDriftCorrection(obj *unstructured.Unstructured, manager string) {
+	DriftCorrectionsTotal.WithLabelValues(
+		obj.GetKind(),
+		obj.GetName(),
+		obj.GetNamespace(),
+		manager,
+	).Inc()
+}
+
+// IncRecreation counts the recreation of a deleted managed object
+func IncRecreation(obj *unstructured.Unstructured) {
+	RecreationsTotal.WithLabelValues(obj.GetKind(), obj.GetName(), obj.GetNamespace()).Inc()
+}
+
+// IncRecreationCooldown counts a recreation cool-down of a managed object
+func IncRecreationCooldown(obj *unstructured.Unstructured) {
+	RecreationCooldownsTotal.WithLabelValues(obj.GetKind(), obj.GetName(), obj.GetNamespace()).Inc()
+}
+
+// IncApplyRetry counts a retry of an object of kind whose admission was unavailable
+func IncApplyRetry(kind, result string) {
+	ApplyRetriesTotal.WithLabelValues(kind, result).Inc()
+}
+
 // SetCustomization records an intentional customization on a managed resource.
-// customizationType: "patch", "ignore", or "unmanaged"
+// customizationType: "patch", "ignore", "unmanaged" or "delegated"
 func SetCustomization(obj *unstructured.Unstructured, customizationType string) {
 	CustomizationInfo.WithLabelValues(
 		obj.GetKind(),
@@ -229,6 +789,43 @@ func SetTombstoneStatus(obj *unstructured.Unstructured, status float64) {
 	).Set(status)
 }
 
+// SetTombstoneSkippedOwners replaces the recorded field managers of a skipped tombstone.
+// Passing no managers clears all series for the resource (tombstone deleted or gone).
+func SetTombstoneSkippedOwners(obj *unstructured.Unstructured, managers []string) {
+	TombstoneSkippedOwnerInfo.DeletePartialMatch(prometheus.Labels{
+		"kind":      obj.GetKind(),
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	})
+	for _, manager := range managers {
+		TombstoneSkippedOwnerInfo.WithLabelValues(
+			obj.GetKind(),
+			obj.GetName(),
+			obj.GetNamespace(),
+			manager,
+		).Set(1)
+	}
+}
+
+// SetHardwarePendingRemoval records when a damped hardware detector will be released.
+// A zero deadline clears the series.
+func SetHardwarePendingRemoval(detector string, deadline time.Time) {
+	if deadline.IsZero() {
+		HardwarePendingRemoval.DeleteLabelValues(detector)
+		return
+	}
+	HardwarePendingRemoval.WithLabelValues(detector).Set(float64(deadline.Unix()))
+}
+
+// SetHardwareDetected records the node scan result of a hardware detector
+func SetHardwareDetected(detector string, detected bool) {
+	value := 0.0
+	if detected {
+		value = 1.0
+	}
+	HardwareDetected.WithLabelValues(detector).Set(value)
+}
+
 // SetPaused sets the paused state for a resource.
 // Called when edit war is detected (paused=true) or when annotation is removed (paused=false).
 func SetPaused(obj *unstructured.Unstructured, paused bool) {
@@ -243,14 +840,219 @@ func SetPaused(obj *unstructured.Unstructured, paused bool) {
 	).Set(value)
 }
 
+// SetDeferred records whether a resource's apply is deferred by upgrade safe-mode.
+// The series is removed when deferred is false.
+func SetDeferred(obj *unstructured.Unstructured, deferred bool) {
+	if !deferred {
+		DeferredResources.DeleteLabelValues(obj.GetKind(), obj.GetName(), obj.GetNamespace())
+		return
+	}
+	DeferredResources.WithLabelValues(
+		obj.GetKind(),
+		obj.GetName(),
+		obj.GetNamespace(),
+	).Set(1)
+}
+
+// SetDeprecatedAsset records whether a deprecated asset is still active
+func SetDeprecatedAsset(asset, replacedBy, removalVersion string, active bool) {
+	if !active {
+		DeprecatedAssetInfo.DeleteLabelValues(asset, replacedBy, removalVersion)
+		return
+	}
+	DeprecatedAssetInfo.WithLabelValues(asset, replacedBy, removalVersion).Set(1)
+}
+
+// SetCacheStats records the object count and estimated size of a cached type
+func SetCacheStats(group, version, kind string, objects int, estimatedBytes int64) {
+	CacheObjects.WithLabelValues(group, version, kind).Set(float64(objects))
+	CacheEstimatedBytes.WithLabelValues(group, version, kind).Set(float64(estimatedBytes))
+}
+
+// SetInformerSyncDuration records how long the informer of a watched type took to sync
+func SetInformerSyncDuration(group, version, kind string, d time.Duration) {
+	InformerSyncDuration.WithLabelValues(group, version, kind).Set(d.Seconds())
+}
+
+// SetCacheSynced records whether all informer caches synced
+func SetCacheSynced(synced bool) {
+	value := 0.0
+	if synced {
+		value = 1
+	}
+	CacheSynced.Set(value)
+}
+
+// SetHCOAPISupport records the newest served HyperConverged API version, replacing any previous value
+func SetHCOAPISupport(version string, supported bool) {
+	HCOAPISupported.Reset()
+	HCOAPISupported.WithLabelValues(version).Set(boolValue(supported))
+}
+
+// SetLeaderElection records the leader election state observed by this replica; a zero
+// lastTransition removes the timestamp
+func SetLeaderElection(isLeader, leaderHealthy bool, transitions int32, lastTransition time.Time) {
+	LeaderElectionIsLeader.Set(boolValue(isLeader))
+	LeaderElectionLeaderHealthy.Set(boolValue(leaderHealthy))
+	LeaderElectionTransitions.Set(float64(transitions))
+	if lastTransition.IsZero() {
+		LeaderElectionLastTransition.Set(0)
+		return
+	}
+	LeaderElectionLastTransition.Set(float64(lastTransition.Unix()))
+}
+
+// boolValue maps a boolean to a gauge value
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// IncReconcileTrigger counts one reconcile trigger for cause
+func IncReconcileTrigger(cause string) {
+	ReconcileTriggersTotal.WithLabelValues(cause).Inc()
+}
+
+// IncApplyTimeout counts one timed-out reconcile of asset
+func IncApplyTimeout(asset string) {
+	ApplyTimeoutsTotal.WithLabelValues(asset).Inc()
+}
+
+// IncAssetError counts one failed reconcile of asset
+func IncAssetError(asset, reason string) {
+	AssetErrorsTotal.WithLabelValues(asset, reason).Inc()
+}
+
+// ObserveConditionEvaluation records how long evaluating a condition of conditionType took
+func ObserveConditionEvaluation(conditionType string, duration time.Duration) {
+	ConditionEvaluationDuration.WithLabelValues(conditionType).Observe(duration.Seconds())
+}
+
+// IncLabelRepair counts one managed-by label restored on an object of kind
+func IncLabelRepair(kind string) {
+	LabelRepairsTotal.WithLabelValues(kind).Inc()
+}
+
+// AddDroppedFields counts fields the template of asset stopped rendering
+func AddDroppedFields(asset string, fields int) {
+	DroppedFieldsTotal.WithLabelValues(asset).Add(float64(fields))
+}
+
+// IncNodeEventSuppressed counts one node event absorbed by the node watch debounce
+func IncNodeEventSuppressed() {
+	NodeEventsSuppressedTotal.Inc()
+}
+
+// SetUnlabeledObjects replaces the per-kind counts of unrepaired unlabeled objects
+func SetUnlabeledObjects(counts map[string]int) {
+	UnlabeledObjects.Reset()
+	for kind, count := range counts {
+		UnlabeledObjects.WithLabelValues(kind).Set(float64(count))
+	}
+}
+
+// SetBlastRadiusHeld records how many changes of operation are held back by the blast radius guard
+func SetBlastRadiusHeld(operation string, count int) {
+	BlastRadiusHeld.WithLabelValues(operation).Set(float64(count))
+}
+
+// SetCanaryRolloutPhase records the canary rollout phase of pool
+func SetCanaryRolloutPhase(pool string, phase int) {
+	CanaryRolloutPhase.WithLabelValues(pool).Set(float64(phase))
+}
+
+// IncCanaryRollout counts a finished canary rollout
+func IncCanaryRollout(result string) {
+	CanaryRolloutsTotal.WithLabelValues(result).Inc()
+}
+
+// SetRebootImpactNodes records how many nodes the last batch of node-rebooting changes reboots
+func SetRebootImpactNodes(nodes int) {
+	RebootImpactNodes.Set(float64(nodes))
+}
+
 // DeleteAssetMetrics removes all per-asset metric series for a resource.
 // Called when an asset is removed from the active set (allowlist change, CRD absent,
 // condition no longer met) so stale series no longer appear in /metrics.
 func DeleteAssetMetrics(kind, name, namespace string) {
 	ComplianceStatus.DeleteLabelValues(kind, name, namespace)
 	PausedResources.DeleteLabelValues(kind, name, namespace)
+	DeferredResources.DeleteLabelValues(kind, name, namespace)
 	ReconcileDuration.DeleteLabelValues(kind, name, namespace)
-	for _, customizationType := range []string{"patch", "ignore", "unmanaged"} {
+	for _, customizationType := range []string{"patch", "ignore", "unmanaged", "delegated"} {
 		CustomizationInfo.DeleteLabelValues(kind, name, namespace, customizationType)
 	}
 }
+
+// SetReconcileFailures records the consecutive failure count of an HCO; 0 removes the series
+func SetReconcileFailures(namespace, name string, count int) {
+	if count == 0 {
+		ReconcileConsecutiveFailures.DeleteLabelValues(namespace, name)
+		return
+	}
+	ReconcileConsecutiveFailures.WithLabelValues(namespace, name).Set(float64(count))
+}
+
+// SetMaintenanceRemaining records the time left in an HCO's maintenance window,
+// removing the series when the window is closed
+func SetMaintenanceRemaining(namespace, name string, remaining time.Duration) {
+	if remaining <= 0 {
+		MaintenanceWindowRemaining.DeleteLabelValues(namespace, name)
+		return
+	}
+	MaintenanceWindowRemaining.WithLabelValues(namespace, name).Set(remaining.Seconds())
+}
+
+// SetHCOGeneration records the generation of an HCO seen by a reconcile
+func SetHCOGeneration(namespace, name string, generation int64) {
+	HCOGeneration.WithLabelValues(namespace, name).Set(float64(generation))
+}
+
+// SetHCOObservedGeneration records the generation of an HCO a reconcile fully acted on
+func SetHCOObservedGeneration(namespace, name string, generation int64) {
+	HCOObservedGeneration.WithLabelValues(namespace, name).Set(float64(generation))
+}
+
+// DeleteHCOGeneration removes the generation series of a deleted HCO
+func DeleteHCOGeneration(namespace, name string) {
+	HCOGeneration.DeleteLabelValues(namespace, name)
+	HCOObservedGeneration.DeleteLabelValues(namespace, name)
+}
+
+// SetStatusCondition records a ClusterOperator-style condition of an HCO, replacing the
+// series of its previous reason
+func SetStatusCondition(namespace, name, condition, reason string, status bool) {
+	StatusConditions.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name, "condition": condition})
+	value := 0.0
+	if status {
+		value = 1
+	}
+	StatusConditions.WithLabelValues(namespace, name, condition, reason).Set(value)
+}
+
+// DeleteStatusConditions removes the condition series of an HCO that is gone or no longer managed
+func DeleteStatusConditions(namespace, name string) {
+	StatusConditions.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+}
+
+// IncNotification counts a reconcile summary posted to the notification webhook
+func IncNotification(result string) {
+	NotificationsTotal.WithLabelValues(result).Inc()
+}
+
+// SetCatalogInfo records the active catalog version and digest, replacing any previous value
+func SetCatalogInfo(version, digest string) {
+	CatalogVersion.Reset()
+	CatalogVersion.WithLabelValues(version, digest).Set(1)
+}
+
+// SetCatalogRemote records whether the remote catalog (true) or the embedded one is in use
+func SetCatalogRemote(remote bool) {
+	if remote {
+		CatalogRemote.Set(1)
+		return
+	}
+	CatalogRemote.Set(0)
+}
@@ -135,7 +135,7 @@ func TestSetCustomization(t *testing.T) {
 	SetCustomization(obj, "patch")
 
 	expected := `
-		# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+		# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 		# TYPE kubevirt_autopilot_customization_info gauge
 		kubevirt_autopilot_customization_info{kind="HyperConverged",name="kubevirt-hyperconverged",namespace="kubevirt-hyperconverged",type="patch"} 1
 	`
@@ -179,7 +179,7 @@ func TestMultipleCustomizationTypes(t *testing.T) {
 	SetCustomization(obj, "ignore")
 
 	expected := `
-		# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+		# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 		# TYPE kubevirt_autopilot_customization_info gauge
 		kubevirt_autopilot_customization_info{kind="HyperConverged",name="kubevirt-hyperconverged",namespace="kubevirt-hyperconverged",type="ignore"} 1
 		kubevirt_autopilot_customization_info{kind="HyperConverged",name="kubevirt-hyperconverged",namespace="kubevirt-hyperconverged",type="patch"} 1
@@ -315,3 +315,29 @@ func TestClusterScopedResources(t *testing.T) {
 		t.Errorf("unexpected metric value for cluster-scoped resource: %v", err)
 	}
 }
+
+func TestSetTombstoneSkippedOwners(t *testing.T) {
+	TombstoneSkippedOwnerInfo.Reset()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetKind("MachineConfig")
+	obj.SetName("50-old-thing")
+
+	SetTombstoneSkippedOwners(obj, []string{"kubectl-edit", "machine-config-operator"})
+	// A later skip replaces the previous owners rather than accumulating them
+	SetTombstoneSkippedOwners(obj, []string{"kubectl-edit"})
+
+	expected := `
+		# HELP kubevirt_autopilot_tombstone_skipped_owner_info Field managers owning a tombstoned resource skipped due to label mismatch (always 1 when present)
+		# TYPE kubevirt_autopilot_tombstone_skipped_owner_info gauge
+		kubevirt_autopilot_tombstone_skipped_owner_info{kind="MachineConfig",manager="kubectl-edit",name="50-old-thing",namespace=""} 1
+	`
+	if err := testutil.CollectAndCompare(TombstoneSkippedOwnerInfo, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected metric value: %v", err)
+	}
+
+	SetTombstoneSkippedOwners(obj, nil)
+	if count := testutil.CollectAndCount(TombstoneSkippedOwnerInfo); count != 0 {
+		t.Errorf("expected 0 series after clear, got %d", count)
+	}
+}
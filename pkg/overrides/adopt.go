@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// adoptionOperation is one operation of a generated adoption patch. Value is
+// marshaled even when it is false, zero or null, so remove carries no value field.
+type adoptionOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// MarshalJSON drops the value of remove operations
+func (o adoptionOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(map[string]string{"op": o.Op, "path": o.Path})
+	}
+	type plain adoptionOperation
+	return json.Marshal(plain(o))
+}
+
+// AdoptionPatch returns the RFC 6902 JSON Patch that turns the rendered desired object
+// into live for every field the template renders, together with the pointers it touches.
+// Applied through the patch annotation, it keeps the live values when the autopilot
+// takes over an object that was configured before, so adoption loses no customization.
+//
+// Only rendered fields are compared: fields set on live alone (user additions or
+// API server defaults) are left alone by server-side apply and need no patch. Lists
+// of equal length are compared item by item; lists of different length are replaced
+// as a whole. Identity, status and metadata other than labels and annotations are
+// never patched, and fields masked by platform.kubevirt.io/ignore-fields on live are
+// already kept. An empty patch means live matches the rendering.
+func AdoptionPatch(desired, live *unstructured.Unstructured) (string, []string, error) {
+	if desired == nil || live == nil {
+		return "", nil, fmt.Errorf("object is nil")
+	}
+	if kind := desired.GetKind(); sensitiveKinds[kind] {
+		return "", nil, fmt.Errorf("patches are not allowed on sensitive resource kind %s; use %s or %s: %s to keep the live values",
+			kind, AnnotationIgnoreFields, AnnotationMode, ModeUnmanaged)
+	}
+
+	d := &adoptionDiff{masked: parsePointers(live.GetAnnotations()[AnnotationIgnoreFields])}
+	keys := make([]string, 0, len(desired.Object))
+	for key := range desired.Object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			for _, field := range []string{"labels", "annotations"} {
+				if value, found, _ := unstructured.NestedFieldNoCopy(desired.Object, "metadata", field); found {
+					liveValue, liveFound, _ := unstructured.NestedFieldNoCopy(live.Object, "metadata", field)
+					d.compare("/metadata/"+field, value, liveValue, liveFound)
+				}
+			}
+		default:
+			liveValue, liveFound := live.Object[key]
+			d.compare("/"+escapeToken(key), desired.Object[key], liveValue, liveFound)
+		}
+	}
+
+	if len(d.ops) == 0 {
+		return "", nil, nil
+	}
+	data, err := json.Marshal(d.ops)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal adoption patch: %w", err)
+	}
+	paths := make([]string, 0, len(d.ops))
+	for _, op := range d.ops {
+		paths = append(paths, op.Path)
+	}
+	return string(data), paths, nil
+}
+
+// adoptionDiff collects the operations of an adoption patch in document order
+type adoptionDiff struct {
+	masked []string
+	ops    []adoptionOperation
+}
+
+// compare records the operations that turn desired into live at path
+func (d *adoptionDiff) compare(path string, desired, live any, liveFound bool) {
+	if d.isMasked(path) {
+		return
+	}
+	if !liveFound {
+		d.ops = append(d.ops, adoptionOperation{Op: "remove", Path: path})
+		return
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		liveMap, ok := live.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(desiredValue))
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			liveValue, found := liveMap[key]
+			d.compare(path+"/"+escapeToken(key), desiredValue[key], liveValue, found)
+		}
+		return
+	case []any:
+		liveList, ok := live.([]any)
+		if !ok || len(liveList) != len(desiredValue) {
+			break
+		}
+		for i := range desiredValue {
+			d.compare(fmt.Sprintf("%s/%d", path, i), desiredValue[i], liveList[i], true)
+		}
+		return
+	}
+
+	if !jsonEqual(desired, live) {
+		d.ops = append(d.ops, adoptionOperation{Op: "replace", Path: path, Value: live})
+	}
+}
+
+// isMasked reports whether path is at or below one of the ignore-fields pointers
+func (d *adoptionDiff) isMasked(path string) bool {
+	return slices.ContainsFunc(d.masked, func(pointer string) bool {
+		return path == pointer || strings.HasPrefix(path, pointer+"/")
+	})
+}
+
+// jsonEqual compares two values as JSON, so int64 and float64 holding the same
+// number are equal as they are for the API server
+func jsonEqual(a, b any) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
+
+// escapeToken escapes a map key for use as an RFC 6901 reference token
+func escapeToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
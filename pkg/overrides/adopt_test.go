@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newAdoptionObject(kind string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetAPIVersion("hco.kubevirt.io/v1beta1")
+	obj.SetKind(kind)
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName("kubevirt-hyperconverged")
+	return obj
+}
+
+func TestAdoptionPatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		desired   map[string]any
+		live      map[string]any
+		liveAnnot map[string]string
+		wantPaths []string
+	}{
+		{
+			name:    "identical objects need no patch",
+			desired: map[string]any{"replicas": int64(3)},
+			live:    map[string]any{"replicas": int64(3)},
+		},
+		{
+			name:    "fields set only on live are kept by apply",
+			desired: map[string]any{"replicas": int64(3)},
+			live:    map[string]any{"replicas": int64(3), "paused": true},
+		},
+		{
+			name:      "changed and missing leaves",
+			desired:   map[string]any{"config": map[string]any{"a": "x", "b": int64(1), "c": false}},
+			live:      map[string]any{"config": map[string]any{"a": "y", "b": float64(1)}},
+			wantPaths: []string{"/spec/config/a", "/spec/config/c"},
+		},
+		{
+			name: "list items of equal length are compared one by one",
+			desired: map[string]any{"containers": []any{
+				map[string]any{"name": "manager", "image": "quay.io/a:1"},
+			}},
+			live: map[string]any{"containers": []any{
+				map[string]any{"name": "manager", "image": "quay.io/a:1", "imagePullPolicy": "IfNotPresent"},
+			}},
+		},
+		{
+			name:      "lists of different length are replaced",
+			desired:   map[string]any{"args": []any{"--a"}},
+			live:      map[string]any{"args": []any{"--a", "--b"}},
+			wantPaths: []string{"/spec/args"},
+		},
+		{
+			name:      "keys are escaped",
+			desired:   map[string]any{"selector": map[string]any{"kubernetes.io/os": "linux", "a~b": "x"}},
+			live:      map[string]any{"selector": map[string]any{"kubernetes.io/os": "windows", "a~b": "y"}},
+			wantPaths: []string{"/spec/selector/a~0b", "/spec/selector/kubernetes.io~1os"},
+		},
+		{
+			name:      "masked fields are skipped",
+			desired:   map[string]any{"replicas": int64(3), "config": map[string]any{"a": "x"}},
+			live:      map[string]any{"replicas": int64(5), "config": map[string]any{"a": "y"}},
+			liveAnnot: map[string]string{AnnotationIgnoreFields: "/spec/config"},
+			wantPaths: []string{"/spec/replicas"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := newAdoptionObject("HyperConverged", tt.desired)
+			live := newAdoptionObject("HyperConverged", tt.live)
+			live.SetAnnotations(tt.liveAnnot)
+			live.SetResourceVersion("42")
+			_ = unstructured.SetNestedField(live.Object, "Available", "status", "phase")
+
+			patch, paths, err := AdoptionPatch(desired, live)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Fatalf("paths = %v, want %v", paths, tt.wantPaths)
+			}
+			if len(tt.wantPaths) == 0 {
+				if patch != "" {
+					t.Errorf("expected no patch, got %s", patch)
+				}
+				return
+			}
+
+			// The patch must pass the annotation checks and reproduce live
+			annotated := desired.DeepCopy()
+			annotated.SetAnnotations(map[string]string{PatchAnnotation: patch})
+			if err := ValidateAnnotations(annotated); err != nil {
+				t.Fatalf("generated patch is invalid: %v", err)
+			}
+			if _, err := ApplyPatch(annotated); err != nil {
+				t.Fatalf("failed to apply generated patch: %v", err)
+			}
+			annotated.SetAnnotations(tt.liveAnnot)
+			if _, remaining, _ := AdoptionPatch(annotated, live); len(remaining) != 0 {
+				t.Errorf("patched rendering still differs from live at %v", remaining)
+			}
+		})
+	}
+}
+
+func TestAdoptionPatchMetadata(t *testing.T) {
+	desired := newAdoptionObject("HyperConverged", map[string]any{})
+	desired.SetLabels(map[string]string{"app": "autopilot"})
+	live := newAdoptionObject("HyperConverged", map[string]any{})
+	live.SetName("other-name")
+	live.SetLabels(map[string]string{"app": "custom", "team": "virt"})
+
+	_, paths, err := AdoptionPatch(desired, live)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(paths, []string{"/metadata/labels/app"}) {
+		t.Errorf("paths = %v, want only the rendered label", paths)
+	}
+}
+
+func TestAdoptionPatchSensitiveKind(t *testing.T) {
+	desired := newAdoptionObject("MachineConfig", map[string]any{"kernelArguments": []any{"a"}})
+	live := newAdoptionObject("MachineConfig", map[string]any{"kernelArguments": []any{"b"}})
+
+	_, _, err := AdoptionPatch(desired, live)
+	if err == nil || !strings.Contains(err.Error(), AnnotationIgnoreFields) {
+		t.Errorf("expected a sensitive kind error suggesting %s, got %v", AnnotationIgnoreFields, err)
+	}
+}
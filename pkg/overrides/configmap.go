@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AnnotationOverridesConfigMap on the HCO names a ConfigMap in the HCO namespace whose
+	// keys are asset names and values are patches for the objects of that asset. It holds
+	// patches too long for the platform.kubevirt.io/patch annotation.
+	AnnotationOverridesConfigMap = "platform.kubevirt.io/overrides-configmap"
+
+	// PatchTypeKeySuffix marks the overrides ConfigMap key selecting the format of an
+	// asset's patch: "<asset>.patch-type" takes the values of PatchTypeAnnotation.
+	PatchTypeKeySuffix = ".patch-type"
+
+	// ExpiresKeySuffix marks the overrides ConfigMap key holding the RFC 3339 time after
+	// which an asset's patch is no longer applied: "<asset>.expires".
+	ExpiresKeySuffix = ".expires"
+)
+
+// AssetPatch is a patch from the overrides ConfigMap for the objects of one asset
+type AssetPatch struct {
+	// Type is PatchTypeJSON, PatchTypeMerge or PatchTypeStrategic
+	Type string
+	// Patch is the patch document, converted to JSON
+	Patch string
+	// Expires is when the patch stops being applied; nil means never
+	Expires *time.Time
+}
+
+// Expired reports whether the patch has an expiry that is not after now
+func (p AssetPatch) Expired(now time.Time) bool {
+	return p.Expires != nil && !now.Before(*p.Expires)
+}
+
+// LoadOverridePatches reads the asset patches from the ConfigMap named by the HCO's
+// overrides annotation, in the HCO namespace. Returns no patches when the annotation is unset.
+func LoadOverridePatches(ctx context.Context, reader client.Reader, hco *unstructured.Unstructured) (map[string]AssetPatch, error) {
+	name := hco.GetAnnotations()[AnnotationOverridesConfigMap]
+	if name == "" {
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: hco.GetNamespace(), Name: name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get overrides ConfigMap %s: %w", name, err)
+	}
+	return ParseOverridesConfigMap(configMap.Data)
+}
+
+// ParseOverridesConfigMap reads asset patches from the data of an overrides ConfigMap.
+// Values may be written in JSON or YAML. Invalid entries are left out and reported
+// together in the returned error, so one bad key does not disable the others.
+func ParseOverridesConfigMap(data map[string]string) (map[string]AssetPatch, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	patches := make(map[string]AssetPatch)
+	var errs []error
+	for _, key := range keys {
+		if asset, ok := cutSettingSuffix(key); ok {
+			if _, hasPatch := data[asset]; !hasPatch {
+				errs = append(errs, fmt.Errorf("key %s: no patch for asset %s", key, asset))
+			}
+			continue
+		}
+
+		patchType := PatchTypeJSON
+		if t := strings.TrimSpace(data[key+PatchTypeKeySuffix]); t != "" {
+			patchType = t
+		}
+		patch, err := parseAssetPatch(patchType, data[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key %s: %w", key, err))
+			continue
+		}
+		if expires := strings.TrimSpace(data[key+ExpiresKeySuffix]); expires != "" {
+			t, err := time.Parse(time.RFC3339, expires)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("key %s%s: expected an RFC 3339 time: %w", key, ExpiresKeySuffix, err))
+				continue
+			}
+			patch.Expires = &t
+		}
+		patches[key] = patch
+	}
+	return patches, errors.Join(errs...)
+}
+
+// cutSettingSuffix returns the asset a per-asset setting key such as "<asset>.patch-type" belongs to
+func cutSettingSuffix(key string) (string, bool) {
+	for _, suffix := range []string{PatchTypeKeySuffix, ExpiresKeySuffix} {
+		if asset, ok := strings.CutSuffix(key, suffix); ok {
+			return asset, true
+		}
+	}
+	return "", false
+}
+
+// parseAssetPatch converts a YAML or JSON patch document to JSON and validates its format
+// and paths. Checks that depend on the patched kind run in ApplyAssetPatch.
+func parseAssetPatch(patchType, document string) (AssetPatch, error) {
+	patchJSON, err := yaml.YAMLToJSON([]byte(document))
+	if err != nil {
+		return AssetPatch{}, fmt.Errorf("invalid patch document: %w", err)
+	}
+	patch := AssetPatch{Type: patchType, Patch: string(patchJSON)}
+
+	switch patchType {
+	case PatchTypeJSON:
+		if err := ValidateJSONPatch(patch.Patch); err != nil {
+			return AssetPatch{}, err
+		}
+		err = ValidatePatchPaths(patch.Patch)
+	case PatchTypeMerge, PatchTypeStrategic:
+		if err := ValidateMergePatch(patch.Patch); err != nil {
+			return AssetPatch{}, err
+		}
+		err = ValidateMergePatchPaths(patch.Patch)
+	default:
+		return AssetPatch{}, fmt.Errorf("unknown patch type %q (must be %s, %s or %s)",
+			patchType, PatchTypeJSON, PatchTypeMerge, PatchTypeStrategic)
+	}
+	if err != nil {
+		return AssetPatch{}, fmt.Errorf("patch security violation: %w", err)
+	}
+	return patch, nil
+}
+
+// ApplyAssetPatch applies a patch from the overrides ConfigMap to obj in-memory.
+// Unlike ApplyPatch it leaves the object's annotations alone, so nothing about the
+// patch is written to the cluster.
+func ApplyAssetPatch(obj *unstructured.Unstructured, patch AssetPatch) error {
+	if obj == nil {
+		return fmt.Errorf("object is nil")
+	}
+	if sensitiveKinds[obj.GetKind()] {
+		return fmt.Errorf("patches are not allowed on sensitive resource kind: %s", obj.GetKind())
+	}
+	return applyPatchDocument(obj, patch.Type, patch.Patch)
+}
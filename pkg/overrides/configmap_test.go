@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseOverridesConfigMap(t *testing.T) {
+	data := map[string]string{
+		"json-asset":                       `[{"op": "replace", "path": "/spec/replicas", "value": 2}]`,
+		"yaml-asset":                       "spec:\n  template:\n    spec:\n      containers:\n      - name: app\n        image: app:v2\n",
+		"yaml-asset" + PatchTypeKeySuffix:  PatchTypeStrategic,
+		"merge-asset":                      `{"data": {"key": "value"}}`,
+		"merge-asset" + PatchTypeKeySuffix: " merge\n",
+		"renamer":                          `[{"op": "replace", "path": "/metadata/name", "value": "other"}]`,
+		"bad-type":                         `{}`,
+		"bad-type" + PatchTypeKeySuffix:    "xml",
+		"not-json":                         `{"spec": `,
+		"retainer":                         `{"$retainKeys": ["spec", "data"], "data": {"a": "b"}}`,
+		"retainer" + PatchTypeKeySuffix:    PatchTypeStrategic,
+		"replacer":                         `{"metadata": {"$patch": "replace", "labels": {"a": "b"}}}`,
+		"replacer" + PatchTypeKeySuffix:    PatchTypeMerge,
+		"orphan" + PatchTypeKeySuffix:      PatchTypeMerge,
+		"temporary":                        `{"data": {"key": "value"}}`,
+		"temporary" + PatchTypeKeySuffix:   PatchTypeMerge,
+		"temporary" + ExpiresKeySuffix:     "2026-12-01T00:00:00Z",
+		"bad-expiry":                       `{"data": {"key": "value"}}`,
+		"bad-expiry" + PatchTypeKeySuffix:  PatchTypeMerge,
+		"bad-expiry" + ExpiresKeySuffix:    "tomorrow",
+		"orphan" + ExpiresKeySuffix:        "2026-12-01T00:00:00Z",
+	}
+
+	patches, err := ParseOverridesConfigMap(data)
+	if err == nil {
+		t.Fatal("ParseOverridesConfigMap() error = nil, want the invalid entries reported")
+	}
+	for _, key := range []string{"renamer", "bad-type", "not-json", "retainer", "replacer", "orphan.patch-type", "bad-expiry.expires", "orphan.expires"} {
+		if !strings.Contains(err.Error(), "key "+key+":") {
+			t.Errorf("error %q does not report key %s", err, key)
+		}
+	}
+
+	want := map[string]AssetPatch{
+		"json-asset":  {Type: PatchTypeJSON, Patch: `[{"op":"replace","path":"/spec/replicas","value":2}]`},
+		"yaml-asset":  {Type: PatchTypeStrategic, Patch: `{"spec":{"template":{"spec":{"containers":[{"image":"app:v2","name":"app"}]}}}}`},
+		"merge-asset": {Type: PatchTypeMerge, Patch: `{"data":{"key":"value"}}`},
+	}
+	temporary, ok := patches["temporary"]
+	if !ok || temporary.Expires == nil || !temporary.Expires.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("patches[temporary] = %+v, want it to expire at 2026-12-01T00:00:00Z", temporary)
+	}
+	delete(patches, "temporary")
+	if len(patches) != len(want) {
+		t.Fatalf("patches = %v, want %v", patches, want)
+	}
+	for asset, patch := range want {
+		if patches[asset] != patch {
+			t.Errorf("patches[%s] = %+v, want %+v", asset, patches[asset], patch)
+		}
+	}
+}
+
+func TestApplyAssetPatch(t *testing.T) {
+	configMap := func(kind string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata":   map[string]any{"name": "test"},
+			"data":       map[string]any{"a": "1"},
+		}}
+		return obj
+	}
+
+	obj := configMap("ConfigMap")
+	if err := ApplyAssetPatch(obj, AssetPatch{Type: PatchTypeMerge, Patch: `{"data": {"b": "2"}}`}); err != nil {
+		t.Fatalf("ApplyAssetPatch() error = %v", err)
+	}
+	if data, _, _ := unstructured.NestedStringMap(obj.Object, "data"); data["a"] != "1" || data["b"] != "2" {
+		t.Errorf("data = %v, want a and b", data)
+	}
+	if len(obj.GetAnnotations()) != 0 {
+		t.Errorf("annotations = %v, want none written", obj.GetAnnotations())
+	}
+
+	renamed := configMap("ConfigMap")
+	if err := ApplyAssetPatch(renamed, AssetPatch{Type: PatchTypeStrategic, Patch: `{"metadata": {"$patch": "replace", "labels": {"a": "b"}}}`}); err == nil {
+		t.Error("ApplyAssetPatch() clearing the name error = nil, want rejection")
+	}
+	if renamed.GetName() != "test" {
+		t.Errorf("name = %q, want the object left unchanged", renamed.GetName())
+	}
+
+	sensitive := configMap("ClusterRole")
+	if err := ApplyAssetPatch(sensitive, AssetPatch{Type: PatchTypeMerge, Patch: `{"data": {}}`}); err == nil {
+		t.Error("ApplyAssetPatch() on a sensitive kind error = nil, want rejection")
+	}
+}
+
+func TestLoadOverridePatches(t *testing.T) {
+	hco := &unstructured.Unstructured{}
+	hco.SetNamespace("openshift-cnv")
+
+	c := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "autopilot-overrides", Namespace: "openshift-cnv"},
+		Data:       map[string]string{"asset": `[]`},
+	}).Build()
+	ctx := context.Background()
+
+	patches, err := LoadOverridePatches(ctx, c, hco)
+	if err != nil || patches != nil {
+		t.Errorf("without annotation = %v, %v; want nothing", patches, err)
+	}
+
+	hco.SetAnnotations(map[string]string{AnnotationOverridesConfigMap: "autopilot-overrides"})
+	patches, err = LoadOverridePatches(ctx, c, hco)
+	if err != nil || len(patches) != 1 {
+		t.Errorf("LoadOverridePatches() = %v, %v; want the asset patch", patches, err)
+	}
+
+	hco.SetAnnotations(map[string]string{AnnotationOverridesConfigMap: "missing"})
+	if _, err := LoadOverridePatches(ctx, c, hco); err == nil {
+		t.Error("missing ConfigMap error = nil, want not found")
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AutopilotExclusionCRDName is the CRD of AutopilotExclusion objects
+const AutopilotExclusionCRDName = "autopilotexclusions.platform.kubevirt.io"
+
+// AutopilotExclusionGVK is the kind of the structured exclusions users create next to
+// the HCO. They complement the disabled-resources annotation, which is limited in size
+// and easily mangled when edited by hand.
+var AutopilotExclusionGVK = schema.GroupVersionKind{Group: "platform.kubevirt.io", Version: "v1alpha1", Kind: "AutopilotExclusion"}
+
+// Exclusion is the spec of one AutopilotExclusion. Empty selector fields match
+// anything; Namespace and Name accept shell-style glob patterns (see path/filepath.Match).
+type Exclusion struct {
+	// Source is the name of the AutopilotExclusion, in the HCO namespace
+	Source string `json:"-"`
+
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Component selects every object of the assets of one component, e.g. KubeDescheduler
+	Component string `json:"component,omitempty"`
+
+	// ExpiresAt ends the exclusion; the autopilot manages the objects again afterwards.
+	// Nil never expires.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
+// ExcludedObject is an object an exclusion kept the autopilot from applying
+type ExcludedObject struct {
+	Asset     string `json:"asset"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Matches reports whether the exclusion selects the object of component identified by
+// kind, namespace and name. Expiry is not considered, see Expired.
+func (e Exclusion) Matches(kind, namespace, name, component string) bool {
+	if e.Kind != "" && e.Kind != kind {
+		return false
+	}
+	if e.Component != "" && e.Component != component {
+		return false
+	}
+	for _, field := range []struct{ pattern, value string }{{e.Namespace, namespace}, {e.Name, name}} {
+		if field.pattern == "" {
+			continue
+		}
+		if matched, err := filepath.Match(field.pattern, field.value); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Expired reports whether the exclusion no longer applies at now
+func (e Exclusion) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+// ParseExclusion reads the spec of an AutopilotExclusion. An exclusion must select
+// by kind or component, so a stray empty object does not stop the whole autopilot.
+func ParseExclusion(obj *unstructured.Unstructured) (Exclusion, error) {
+	exclusion := Exclusion{Source: obj.GetName()}
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+
+	for _, field := range []struct {
+		name   string
+		target *string
+	}{
+		{"kind", &exclusion.Kind},
+		{"namespace", &exclusion.Namespace},
+		{"name", &exclusion.Name},
+		{"component", &exclusion.Component},
+		{"reason", &exclusion.Reason},
+	} {
+		value, found, err := unstructured.NestedString(spec, field.name)
+		if err != nil {
+			return Exclusion{}, fmt.Errorf("spec.%s: %w", field.name, err)
+		}
+		if found {
+			*field.target = value
+		}
+	}
+
+	if exclusion.Kind == "" && exclusion.Component == "" {
+		return Exclusion{}, fmt.Errorf("spec.kind or spec.component is required")
+	}
+	// Reject malformed patterns up front so a typo is reported instead of silently never matching
+	if _, err := filepath.Match(exclusion.Name, ""); err != nil {
+		return Exclusion{}, fmt.Errorf("spec.name: invalid pattern %q: %w", exclusion.Name, err)
+	}
+	if _, err := filepath.Match(exclusion.Namespace, ""); err != nil {
+		return Exclusion{}, fmt.Errorf("spec.namespace: invalid pattern %q: %w", exclusion.Namespace, err)
+	}
+
+	expiresAt, found, err := unstructured.NestedString(spec, "expiresAt")
+	if err != nil {
+		return Exclusion{}, fmt.Errorf("spec.expiresAt: %w", err)
+	}
+	if found && expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return Exclusion{}, fmt.Errorf("spec.expiresAt: %q is not an RFC3339 time", expiresAt)
+		}
+		exclusion.ExpiresAt = &t
+	}
+	return exclusion, nil
+}
+
+// LoadExclusions reads the AutopilotExclusions of namespace. Returns none when the CRD
+// is not installed. Invalid exclusions are left out and reported together in the
+// returned error, so one bad object does not disable the others.
+func LoadExclusions(ctx context.Context, reader client.Reader, namespace string) ([]Exclusion, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(AutopilotExclusionGVK.GroupVersion().WithKind(AutopilotExclusionGVK.Kind + "List"))
+	if err := reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list AutopilotExclusions: %w", err)
+	}
+
+	var exclusions []Exclusion
+	var errs []error
+	for i := range list.Items {
+		exclusion, err := ParseExclusion(&list.Items[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("AutopilotExclusion %s: %w", list.Items[i].GetName(), err))
+			continue
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	return exclusions, errors.Join(errs...)
+}
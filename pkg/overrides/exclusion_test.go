@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newExclusionObject(name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetGroupVersionKind(AutopilotExclusionGVK)
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName(name)
+	return obj
+}
+
+func TestParseExclusion(t *testing.T) {
+	expires := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		spec    map[string]any
+		want    Exclusion
+		wantErr bool
+	}{
+		{
+			name: "all fields",
+			spec: map[string]any{
+				"kind": "MachineConfig", "name": "50-*", "namespace": "", "component": "MachineConfig",
+				"expiresAt": "2026-10-20T08:00:00Z", "reason": "vendor kernel args",
+			},
+			want: Exclusion{
+				Source: "test", Kind: "MachineConfig", Name: "50-*", Component: "MachineConfig",
+				ExpiresAt: &expires, Reason: "vendor kernel args",
+			},
+		},
+		{
+			name: "component only",
+			spec: map[string]any{"component": "KubeDescheduler"},
+			want: Exclusion{Source: "test", Component: "KubeDescheduler"},
+		},
+		{name: "no kind or component", spec: map[string]any{"name": "foo"}, wantErr: true},
+		{name: "no spec", wantErr: true},
+		{name: "invalid name pattern", spec: map[string]any{"kind": "ConfigMap", "name": "[abc"}, wantErr: true},
+		{name: "invalid namespace pattern", spec: map[string]any{"kind": "ConfigMap", "namespace": "[abc"}, wantErr: true},
+		{name: "expiresAt not RFC3339", spec: map[string]any{"kind": "ConfigMap", "expiresAt": "next week"}, wantErr: true},
+		{name: "kind not a string", spec: map[string]any{"kind": int64(1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExclusion(newExclusionObject("test", tt.spec))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExclusion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ExpiresAt != nil && tt.want.ExpiresAt != nil && got.ExpiresAt.Equal(*tt.want.ExpiresAt) {
+				got.ExpiresAt = tt.want.ExpiresAt
+			}
+			if got != tt.want {
+				t.Errorf("ParseExclusion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExclusionMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		exclusion Exclusion
+		kind      string
+		namespace string
+		objName   string
+		component string
+		want      bool
+	}{
+		{"kind only", Exclusion{Kind: "MachineConfig"}, "MachineConfig", "", "50-swap", "MachineConfig", true},
+		{"other kind", Exclusion{Kind: "MachineConfig"}, "KubeletConfig", "", "50-swap", "MachineConfig", false},
+		{"name glob", Exclusion{Kind: "MachineConfig", Name: "50-*"}, "MachineConfig", "", "50-swap", "", true},
+		{"name glob mismatch", Exclusion{Kind: "MachineConfig", Name: "50-*"}, "MachineConfig", "", "99-swap", "", false},
+		{"namespace glob", Exclusion{Kind: "ConfigMap", Namespace: "openshift-*"}, "ConfigMap", "openshift-cnv", "x", "", true},
+		{"namespace set, cluster-scoped object", Exclusion{Kind: "ConfigMap", Namespace: "openshift-*"}, "ConfigMap", "", "x", "", false},
+		{"component", Exclusion{Component: "KubeDescheduler"}, "KubeDescheduler", "openshift-kube-descheduler-operator", "cluster", "KubeDescheduler", true},
+		{"other component", Exclusion{Component: "KubeDescheduler"}, "MachineConfig", "", "50-swap", "MachineConfig", false},
+		{"kind and component both required", Exclusion{Kind: "ConfigMap", Component: "KubeDescheduler"}, "ConfigMap", "ns", "x", "MachineConfig", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.exclusion.Matches(tt.kind, tt.namespace, tt.objName, tt.component); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExclusionExpired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	if (Exclusion{}).Expired(now) {
+		t.Error("exclusion without expiresAt should never expire")
+	}
+	if !(Exclusion{ExpiresAt: &past}).Expired(now) {
+		t.Error("exclusion should have expired")
+	}
+	if !(Exclusion{ExpiresAt: &now}).Expired(now) {
+		t.Error("exclusion should expire at expiresAt")
+	}
+	if (Exclusion{ExpiresAt: &future}).Expired(now) {
+		t.Error("exclusion should not have expired yet")
+	}
+}
+
+func TestLoadExclusions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	valid := newExclusionObject("swap", map[string]any{"kind": "MachineConfig", "name": "50-swap"})
+	invalid := newExclusionObject("broken", map[string]any{"name": "foo"})
+	otherNamespace := newExclusionObject("elsewhere", map[string]any{"kind": "ConfigMap"})
+	otherNamespace.SetNamespace("tenant-a")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(valid, invalid, otherNamespace).Build()
+
+	exclusions, err := LoadExclusions(context.Background(), c, "openshift-cnv")
+	if err == nil {
+		t.Error("expected the invalid exclusion to be reported")
+	}
+	if len(exclusions) != 1 || exclusions[0].Source != "swap" {
+		t.Errorf("LoadExclusions() = %+v, want only the valid exclusion of the namespace", exclusions)
+	}
+}
@@ -22,17 +22,41 @@ import (
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 )
 
 const (
-	// PatchAnnotation is the annotation key for RFC 6902 JSON Patch
+	// PatchAnnotation is the annotation key for the user patch, RFC 6902 JSON Patch by default
 	PatchAnnotation = "platform.kubevirt.io/patch"
+
+	// PatchTypeAnnotation selects the format of the patch annotation
+	PatchTypeAnnotation = "platform.kubevirt.io/patch-type"
+
+	// PatchTypeJSON is a RFC 6902 JSON Patch (list of operations), the default
+	PatchTypeJSON = "json"
+	// PatchTypeMerge is a RFC 7386 JSON Merge Patch: lists are replaced as a whole
+	PatchTypeMerge = "merge"
+	// PatchTypeStrategic is a Kubernetes strategic merge patch: list items are merged
+	// by their key (e.g. containers by name). Only built-in Kubernetes kinds carry the
+	// merge keys, so custom resources must use merge or json.
+	PatchTypeStrategic = "strategic"
 )
 
-// ApplyJSONPatch applies a RFC 6902 JSON Patch from the object's annotation
-// The patch is applied in-memory to the provided object
+// PatchType returns the patch format selected on obj, PatchTypeJSON when unset
+func PatchType(obj *unstructured.Unstructured) string {
+	if patchType := obj.GetAnnotations()[PatchTypeAnnotation]; patchType != "" {
+		return patchType
+	}
+	return PatchTypeJSON
+}
+
+// ApplyPatch applies the patch from the object's annotation in the format selected by
+// the patch-type annotation. The patch is applied in-memory to the provided object.
 // Returns true if a patch was applied, false if no patch annotation exists
-func ApplyJSONPatch(obj *unstructured.Unstructured) (bool, error) {
+func ApplyPatch(obj *unstructured.Unstructured) (bool, error) {
 	if obj == nil {
 		return false, fmt.Errorf("object is nil")
 	}
@@ -47,34 +71,96 @@ func ApplyJSONPatch(obj *unstructured.Unstructured) (bool, error) {
 		return false, nil
 	}
 
-	// Marshal current object to JSON
-	originalJSON, err := json.Marshal(obj.Object)
-	if err != nil {
-		return false, fmt.Errorf("failed to marshal object to JSON: %w", err)
+	if err := applyPatchDocument(obj, PatchType(obj), patchStr); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	// Parse the patch
-	patch, err := jsonpatch.DecodePatch([]byte(patchStr))
+// applyPatchDocument applies patchStr in the given format to obj in-place
+func applyPatchDocument(obj *unstructured.Unstructured, patchType, patchStr string) error {
+	// Marshal current object to JSON
+	originalJSON, err := json.Marshal(obj.Object)
 	if err != nil {
-		return false, fmt.Errorf("invalid JSON Patch in annotation: %w", err)
+		return fmt.Errorf("failed to marshal object to JSON: %w", err)
 	}
 
-	// Apply the patch
-	patchedJSON, err := patch.Apply(originalJSON)
-	if err != nil {
-		return false, fmt.Errorf("failed to apply JSON Patch: %w", err)
+	var patchedJSON []byte
+	switch patchType {
+	case PatchTypeJSON:
+		patch, err := jsonpatch.DecodePatch([]byte(patchStr))
+		if err != nil {
+			return fmt.Errorf("invalid JSON Patch: %w", err)
+		}
+		if patchedJSON, err = patch.Apply(originalJSON); err != nil {
+			return fmt.Errorf("failed to apply JSON Patch: %w", err)
+		}
+	case PatchTypeMerge:
+		if patchedJSON, err = jsonpatch.MergePatch(originalJSON, []byte(patchStr)); err != nil {
+			return fmt.Errorf("failed to apply merge patch: %w", err)
+		}
+	case PatchTypeStrategic:
+		dataStruct, err := strategicPatchSchema(obj.GroupVersionKind())
+		if err != nil {
+			return err
+		}
+		if patchedJSON, err = strategicpatch.StrategicMergePatch(originalJSON, []byte(patchStr), dataStruct); err != nil {
+			return fmt.Errorf("failed to apply strategic merge patch: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown patch type %q", patchType)
 	}
 
 	// Unmarshal back into the object
 	var patchedObj map[string]any
 	if err := json.Unmarshal(patchedJSON, &patchedObj); err != nil {
-		return false, fmt.Errorf("failed to unmarshal patched JSON: %w", err)
+		return fmt.Errorf("failed to unmarshal patched JSON: %w", err)
+	}
+	patched := &unstructured.Unstructured{Object: patchedObj}
+	if err := checkIdentityUnchanged(obj, patched); err != nil {
+		return err
 	}
 
 	// Update the object in-place
 	obj.Object = patchedObj
 
-	return true, nil
+	return nil
+}
+
+// checkIdentityUnchanged rejects a patch that changed the apiVersion, kind, name or
+// namespace of the object, whichever way it got past the path validation
+func checkIdentityUnchanged(original, patched *unstructured.Unstructured) error {
+	for _, field := range []struct{ name, before, after string }{
+		{"apiVersion", original.GetAPIVersion(), patched.GetAPIVersion()},
+		{"kind", original.GetKind(), patched.GetKind()},
+		{"metadata.name", original.GetName(), patched.GetName()},
+		{"metadata.namespace", original.GetNamespace(), patched.GetNamespace()},
+	} {
+		if field.before != field.after {
+			return fmt.Errorf("patch security violation: patch changes %s from %q to %q", field.name, field.before, field.after)
+		}
+	}
+	return nil
+}
+
+// ValidatePatch validates the patch annotation against the format selected on obj
+func ValidatePatch(obj *unstructured.Unstructured) error {
+	patchStr := obj.GetAnnotations()[PatchAnnotation]
+
+	switch patchType := PatchType(obj); patchType {
+	case PatchTypeJSON:
+		return ValidateJSONPatch(patchStr)
+	case PatchTypeMerge, PatchTypeStrategic:
+		if patchType == PatchTypeStrategic {
+			if _, err := strategicPatchSchema(obj.GroupVersionKind()); err != nil {
+				return err
+			}
+		}
+		return ValidateMergePatch(patchStr)
+	default:
+		return fmt.Errorf("unknown patch type %q (must be %s, %s or %s)",
+			patchType, PatchTypeJSON, PatchTypeMerge, PatchTypeStrategic)
+	}
 }
 
 // ValidateJSONPatch validates that a JSON Patch string is valid RFC 6902 format
@@ -90,3 +176,28 @@ func ValidateJSONPatch(patchStr string) error {
 
 	return nil
 }
+
+// ValidateMergePatch validates that a merge or strategic merge patch is a JSON object
+func ValidateMergePatch(patchStr string) error {
+	if patchStr == "" {
+		return nil
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+		return fmt.Errorf("invalid merge patch: must be a JSON object: %w", err)
+	}
+
+	return nil
+}
+
+// strategicPatchSchema returns the typed struct whose field tags carry the list merge
+// keys of gvk. Only kinds compiled into client-go have one.
+func strategicPatchSchema(gvk schema.GroupVersionKind) (runtime.Object, error) {
+	dataStruct, err := clientgoscheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("strategic merge patch is not supported for %s (not a built-in Kubernetes kind), use %s: %s",
+			gvk.Kind, PatchTypeAnnotation, PatchTypeMerge)
+	}
+	return dataStruct, nil
+}
@@ -17,12 +17,13 @@ limitations under the License.
 package overrides
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func TestApplyJSONPatch(t *testing.T) {
+func TestApplyPatch(t *testing.T) {
 	tests := []struct {
 		name          string
 		obj           *unstructured.Unstructured
@@ -209,15 +210,15 @@ func TestApplyJSONPatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			applied, err := ApplyJSONPatch(tt.obj)
+			applied, err := ApplyPatch(tt.obj)
 
 			if (err != nil) != tt.expectError {
-				t.Errorf("ApplyJSONPatch() error = %v, expectError %v", err, tt.expectError)
+				t.Errorf("ApplyPatch() error = %v, expectError %v", err, tt.expectError)
 				return
 			}
 
 			if applied != tt.expectApplied {
-				t.Errorf("ApplyJSONPatch() applied = %v, expectApplied %v", applied, tt.expectApplied)
+				t.Errorf("ApplyPatch() applied = %v, expectApplied %v", applied, tt.expectApplied)
 				return
 			}
 
@@ -275,3 +276,132 @@ func TestValidateJSONPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyPatchTypes(t *testing.T) {
+	deployment := func(patchType, patch string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name": "web",
+				"annotations": map[string]any{
+					PatchAnnotation:     patch,
+					PatchTypeAnnotation: patchType,
+				},
+			},
+			"spec": map[string]any{
+				"replicas": int64(1),
+				"template": map[string]any{"spec": map[string]any{"containers": []any{
+					map[string]any{"name": "app", "image": "app:v1"},
+					map[string]any{"name": "sidecar", "image": "sidecar:v1"},
+				}}},
+			},
+		}}
+	}
+	containers := func(obj *unstructured.Unstructured) []any {
+		c, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+		return c
+	}
+
+	t.Run("merge replaces lists", func(t *testing.T) {
+		obj := deployment(PatchTypeMerge,
+			`{"spec":{"replicas":3,"template":{"spec":{"containers":[{"name":"app","image":"app:v2"}]}}}}`)
+		if _, err := ApplyPatch(obj); err != nil {
+			t.Fatalf("ApplyPatch() error = %v", err)
+		}
+		if replicas, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "replicas"); replicas != float64(3) {
+			t.Errorf("replicas = %v, want 3", replicas)
+		}
+		if got := containers(obj); len(got) != 1 {
+			t.Errorf("containers = %v, want the patch list only", got)
+		}
+	})
+
+	t.Run("strategic merges list items by key", func(t *testing.T) {
+		obj := deployment(PatchTypeStrategic,
+			`{"spec":{"template":{"spec":{"containers":[{"name":"sidecar","image":"sidecar:v2"}]}}}}`)
+		if _, err := ApplyPatch(obj); err != nil {
+			t.Fatalf("ApplyPatch() error = %v", err)
+		}
+		got := containers(obj)
+		if len(got) != 2 {
+			t.Fatalf("containers = %v, want both kept", got)
+		}
+		if image := got[1].(map[string]any)["image"]; image != "sidecar:v2" {
+			t.Errorf("sidecar image = %v, want sidecar:v2", image)
+		}
+		if image := got[0].(map[string]any)["image"]; image != "app:v1" {
+			t.Errorf("app image = %v, want unchanged app:v1", image)
+		}
+	})
+
+	t.Run("strategic on a custom resource", func(t *testing.T) {
+		obj := deployment(PatchTypeStrategic, `{"spec":{"replicas":3}}`)
+		obj.SetAPIVersion("hco.kubevirt.io/v1beta1")
+		obj.SetKind("HyperConverged")
+		if _, err := ApplyPatch(obj); err == nil || !strings.Contains(err.Error(), "not a built-in Kubernetes kind") {
+			t.Errorf("ApplyPatch() error = %v, want unsupported kind", err)
+		}
+	})
+
+	t.Run("identity changes are rejected", func(t *testing.T) {
+		for _, tc := range []struct{ patchType, patch string }{
+			{PatchTypeStrategic, `{"$retainKeys":["spec"],"spec":{"replicas":3}}`},
+			{PatchTypeStrategic, `{"metadata":{"$patch":"replace","labels":{"a":"b"}}}`},
+			{PatchTypeJSON, `[{"op":"replace","path":"","value":{"kind":"Secret"}}]`},
+		} {
+			obj := deployment(tc.patchType, tc.patch)
+			if _, err := ApplyPatch(obj); err == nil || !strings.Contains(err.Error(), "patch security violation") {
+				t.Errorf("ApplyPatch(%s) error = %v, want security violation", tc.patch, err)
+			}
+			if obj.GetName() != "web" || obj.GetKind() != "Deployment" {
+				t.Errorf("ApplyPatch(%s) modified the object identity: %s %s", tc.patch, obj.GetKind(), obj.GetName())
+			}
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		if _, err := ApplyPatch(deployment("yaml", `{}`)); err == nil {
+			t.Error("ApplyPatch() error = nil, want unknown patch type")
+		}
+	})
+}
+
+func TestValidatePatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        string
+		patchType   string
+		patch       string
+		expectError bool
+	}{
+		{"default is JSON Patch", "ConfigMap", "", `[{"op": "add", "path": "/data/a", "value": "b"}]`, false},
+		{"object as JSON Patch", "ConfigMap", PatchTypeJSON, `{"data": {"a": "b"}}`, true},
+		{"merge object", "ConfigMap", PatchTypeMerge, `{"data": {"a": "b"}}`, false},
+		{"merge list", "ConfigMap", PatchTypeMerge, `[{"op": "add", "path": "/data/a", "value": "b"}]`, true},
+		{"strategic built-in kind", "ConfigMap", PatchTypeStrategic, `{"data": {"a": "b"}}`, false},
+		{"strategic custom resource", "HyperConverged", PatchTypeStrategic, `{"spec": {}}`, true},
+		{"unknown type", "ConfigMap", "xml", `{}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("v1")
+			if tt.kind == "HyperConverged" {
+				obj.SetAPIVersion("hco.kubevirt.io/v1beta1")
+			}
+			obj.SetKind(tt.kind)
+			annotations := map[string]string{PatchAnnotation: tt.patch}
+			if tt.patchType != "" {
+				annotations[PatchTypeAnnotation] = tt.patchType
+			}
+			obj.SetAnnotations(annotations)
+
+			err := ValidatePatch(obj)
+			if (err != nil) != tt.expectError {
+				t.Errorf("ValidatePatch() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
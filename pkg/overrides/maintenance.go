@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AnnotationMaintenanceUntil on the HCO opens a maintenance window ending at the given
+// RFC3339 time. Until then drift is detected but not corrected, and MachineConfig,
+// KubeletConfig and ContainerRuntimeConfig changes are not applied.
+const AnnotationMaintenanceUntil = "platform.kubevirt.io/maintenance-until"
+
+// MaintenanceUntil returns the end of the maintenance window set on the HCO and whether
+// it is still open at now. A malformed value opens no window and is returned as an error.
+func MaintenanceUntil(hco *unstructured.Unstructured, now time.Time) (time.Time, bool, error) {
+	if hco == nil {
+		return time.Time{}, false, nil
+	}
+	value, ok := hco.GetAnnotations()[AnnotationMaintenanceUntil]
+	if !ok || value == "" {
+		return time.Time{}, false, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s annotation %q: must be an RFC3339 time", AnnotationMaintenanceUntil, value)
+	}
+	return until, now.Before(until), nil
+}
+
+// InMaintenance reports whether the HCO's maintenance window is open at now
+func InMaintenance(hco *unstructured.Unstructured, now time.Time) bool {
+	_, active, _ := MaintenanceUntil(hco, now)
+	return active
+}
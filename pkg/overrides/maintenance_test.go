@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMaintenanceUntil(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		value      *string
+		wantActive bool
+		wantErr    bool
+	}{
+		{name: "no annotation"},
+		{name: "window open", value: ptr("2026-10-16T14:00:00Z"), wantActive: true},
+		{name: "expired window with offset", value: ptr("2026-10-16T13:30:00+02:00"), wantActive: false},
+		{name: "window expired", value: ptr("2026-10-16T11:59:59Z")},
+		{name: "empty value", value: ptr("")},
+		{name: "not RFC3339", value: ptr("tomorrow"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hco := &unstructured.Unstructured{}
+			if tt.value != nil {
+				hco.SetAnnotations(map[string]string{AnnotationMaintenanceUntil: *tt.value})
+			}
+
+			_, active, err := MaintenanceUntil(hco, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaintenanceUntil() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if active != tt.wantActive {
+				t.Errorf("MaintenanceUntil() active = %v, want %v", active, tt.wantActive)
+			}
+			if InMaintenance(hco, now) != tt.wantActive {
+				t.Errorf("InMaintenance() = %v, want %v", !tt.wantActive, tt.wantActive)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string { return &s }
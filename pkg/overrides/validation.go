@@ -39,9 +39,22 @@ const (
 	// AnnotationReconcilePaused is set when an edit war is detected
 	// The operator will skip reconciliation while this annotation is present
 	AnnotationReconcilePaused = "platform.kubevirt.io/reconcile-paused"
+
+	// AnnotationDelegateTo hands a resource over to a GitOps tool: the autopilot transfers
+	// its field ownership to the tool's field manager and stops reconciling the resource
+	AnnotationDelegateTo = "platform.kubevirt.io/delegate-to"
+
+	// maxFieldManagerLength is the API server's limit on field manager names
+	maxFieldManagerLength = 128
 )
 
 var (
+	// delegateFieldManagers maps well-known GitOps tools to the field manager they apply with
+	delegateFieldManagers = map[string]string{
+		"argocd": "argocd-controller",
+		"flux":   "kustomize-controller",
+	}
+
 	// sensitiveKinds defines resource kinds where JSON patches are blocked for security
 	// These resources have elevated privileges or control cluster security
 	sensitiveKinds = map[string]bool{
@@ -131,6 +144,58 @@ func ValidatePatchPaths(patchStr string) error {
 	return nil
 }
 
+// ValidateMergePatchPaths checks that a merge or strategic merge patch sets or deletes
+// no forbidden path. Every key is checked at its JSON pointer, so {"metadata":{"name":null}}
+// is rejected like a JSON Patch remove of /metadata/name. Keys starting with "$" are
+// rejected at any depth: strategic merge directives such as $retainKeys or $patch: replace
+// drop fields without naming them, and a merge patch would copy them into the object.
+func ValidateMergePatchPaths(patchStr string) error {
+	if patchStr == "" {
+		return nil
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal([]byte(patchStr), &patch); err != nil {
+		return fmt.Errorf("failed to parse merge patch: %w", err)
+	}
+
+	return validateMergePatchKeys(patch, "")
+}
+
+// validateMergePatchKeys walks the nested objects of a merge patch below prefix,
+// including the objects in lists
+func validateMergePatchKeys(patch map[string]any, prefix string) error {
+	for key, value := range patch {
+		// Escape per RFC 6901 section 3 so "a/b" cannot pass for two segments
+		path := prefix + "/" + strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+		if strings.HasPrefix(key, "$") {
+			return fmt.Errorf("patch directive %q at %q is forbidden", key, path)
+		}
+		if forbidden, matched := isForbiddenPatchPath(path); forbidden {
+			return fmt.Errorf("patch path %q is forbidden: targets protected prefix %q", path, matched)
+		}
+		if err := validateMergePatchValue(value, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMergePatchValue checks the objects within value, the patch value at path
+func validateMergePatchValue(value any, path string) error {
+	switch v := value.(type) {
+	case map[string]any:
+		return validateMergePatchKeys(v, path)
+	case []any:
+		for i, item := range v {
+			if err := validateMergePatchValue(item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // isForbiddenPatchPath checks whether path matches any forbidden prefix using
 // segment-aware comparison: "/metadata/name" is blocked but "/metadata/names-custom" is not.
 func isForbiddenPatchPath(path string) (bool, string) {
@@ -157,6 +222,40 @@ func IsUnmanaged(obj *unstructured.Unstructured) bool {
 	return exists && mode == ModeUnmanaged
 }
 
+// DelegatedTo returns the field manager named by the delegate-to annotation, and whether
+// the resource is delegated at all. Well-known tools (argocd, flux) map to the field manager
+// they apply with; any other value is taken as the field manager name itself.
+func DelegatedTo(obj *unstructured.Unstructured) (string, bool, error) {
+	if obj == nil {
+		return "", false, nil
+	}
+
+	delegate, exists := obj.GetAnnotations()[AnnotationDelegateTo]
+	if !exists {
+		return "", false, nil
+	}
+	manager, err := DelegateFieldManager(delegate)
+	if err != nil {
+		return "", true, err
+	}
+	return manager, true, nil
+}
+
+// DelegateFieldManager resolves a delegate-to annotation value to a field manager name
+func DelegateFieldManager(delegate string) (string, error) {
+	delegate = strings.TrimSpace(delegate)
+	if manager, ok := delegateFieldManagers[strings.ToLower(delegate)]; ok {
+		return manager, nil
+	}
+	if delegate == "" {
+		return "", fmt.Errorf("delegate must not be empty")
+	}
+	if len(delegate) > maxFieldManagerLength {
+		return "", fmt.Errorf("delegate %q is longer than %d characters", delegate, maxFieldManagerLength)
+	}
+	return delegate, nil
+}
+
 // IsPaused checks if a resource has the reconcile-paused annotation
 // This annotation is set when an edit war is detected
 func IsPaused(obj *unstructured.Unstructured) bool {
@@ -244,7 +343,7 @@ func ValidateAnnotations(obj *unstructured.Unstructured) error {
 
 	// Validate patch annotation
 	if patchStr, exists := annotations[PatchAnnotation]; exists {
-		if err := ValidateJSONPatch(patchStr); err != nil {
+		if err := ValidatePatch(obj); err != nil {
 			return fmt.Errorf("invalid patch annotation: %w", err)
 		}
 
@@ -254,7 +353,11 @@ func ValidateAnnotations(obj *unstructured.Unstructured) error {
 		}
 
 		// Check path-level restrictions
-		if err := ValidatePatchPaths(patchStr); err != nil {
+		validatePaths := ValidatePatchPaths
+		if PatchType(obj) != PatchTypeJSON {
+			validatePaths = ValidateMergePatchPaths
+		}
+		if err := validatePaths(patchStr); err != nil {
 			return fmt.Errorf("patch security violation: %w", err)
 		}
 	}
@@ -273,5 +376,12 @@ func ValidateAnnotations(obj *unstructured.Unstructured) error {
 		}
 	}
 
+	// Validate delegate-to annotation
+	if delegate, exists := annotations[AnnotationDelegateTo]; exists {
+		if _, err := DelegateFieldManager(delegate); err != nil {
+			return fmt.Errorf("invalid delegate-to annotation: %w", err)
+		}
+	}
+
 	return nil
 }
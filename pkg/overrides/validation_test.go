@@ -239,6 +239,44 @@ func TestValidatePatchPaths(t *testing.T) {
 	}
 }
 
+func TestValidateMergePatchPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "empty patch", patch: ""},
+		{name: "allowed spec field", patch: `{"spec": {"replicas": 3}}`},
+		{name: "allowed label", patch: `{"metadata": {"labels": {"app": "web"}}}`},
+		{name: "allowed field named like a protected one", patch: `{"spec": {"status": "x", "kind": "y"}}`},
+		{name: "rename", patch: `{"metadata": {"name": "other"}}`, wantErr: true, errMsg: "/metadata/name"},
+		{name: "delete namespace", patch: `{"metadata": {"namespace": null}}`, wantErr: true, errMsg: "/metadata/namespace"},
+		{name: "status subfield", patch: `{"status": {"ready": true}}`, wantErr: true, errMsg: "/status"},
+		{name: "kind", patch: `{"kind": "Secret"}`, wantErr: true, errMsg: "/kind"},
+		{name: "escaped key is not a path", patch: `{"metadata/name": "x"}`},
+		{name: "retainKeys directive", patch: `{"$retainKeys": ["spec", "data"], "data": {"a": "b"}}`, wantErr: true, errMsg: "$retainKeys"},
+		{name: "replace directive", patch: `{"metadata": {"$patch": "replace", "labels": {"a": "b"}}}`, wantErr: true, errMsg: "/metadata/$patch"},
+		{name: "directive in list item", patch: `{"spec": {"containers": [{"name": "app", "$patch": "delete"}]}}`, wantErr: true, errMsg: "/spec/containers/0/$patch"},
+		{name: "element order directive", patch: `{"spec": {"$setElementOrder/containers": [{"name": "app"}]}}`, wantErr: true, errMsg: "$setElementOrder"},
+		{name: "protected key in list item", patch: `{"spec": {"items": [{"status": "x"}]}}`},
+		{name: "not an object", patch: `[1]`, wantErr: true, errMsg: "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMergePatchPaths(tt.patch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMergePatchPaths() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ValidateMergePatchPaths() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
 func TestIsUnmanaged(t *testing.T) {
 	tests := []struct {
 		name string
@@ -308,6 +346,42 @@ func TestIsUnmanaged(t *testing.T) {
 	}
 }
 
+func TestDelegatedTo(t *testing.T) {
+	withDelegate := func(delegate string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{}}
+		obj.SetAnnotations(map[string]string{AnnotationDelegateTo: delegate})
+		return obj
+	}
+
+	tests := []struct {
+		name          string
+		obj           *unstructured.Unstructured
+		wantManager   string
+		wantDelegated bool
+		wantErr       bool
+	}{
+		{name: "argocd", obj: withDelegate("argocd"), wantManager: "argocd-controller", wantDelegated: true},
+		{name: "flux, case-insensitive", obj: withDelegate(" Flux "), wantManager: "kustomize-controller", wantDelegated: true},
+		{name: "custom field manager", obj: withDelegate("my-gitops"), wantManager: "my-gitops", wantDelegated: true},
+		{name: "empty value", obj: withDelegate(" "), wantDelegated: true, wantErr: true},
+		{name: "too long", obj: withDelegate(strings.Repeat("x", 129)), wantDelegated: true, wantErr: true},
+		{name: "no annotation", obj: &unstructured.Unstructured{Object: map[string]any{}}},
+		{name: "nil object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, delegated, err := DelegatedTo(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DelegatedTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if manager != tt.wantManager || delegated != tt.wantDelegated {
+				t.Errorf("DelegatedTo() = (%q, %v), want (%q, %v)", manager, delegated, tt.wantManager, tt.wantDelegated)
+			}
+		})
+	}
+}
+
 func TestIsAutopilotEnabled(t *testing.T) {
 	tests := []struct {
 		name string
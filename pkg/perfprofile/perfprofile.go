@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package perfprofile computes a Node Tuning Operator PerformanceProfile for
+// virtualization hosts from the CPU, memory and NUMA layout of the worker nodes.
+package perfprofile
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const (
+	// HugePagesPercentAnnotation on the HCO sets the share of worker memory, in percent,
+	// to allocate as 1Gi hugepages when the workers have none yet.
+	HugePagesPercentAnnotation = "platform.kubevirt.io/performance-profile-hugepages-percent"
+
+	// maxHugePagesPercent leaves room for the kernel, kubelet and non-hugepage VMs
+	maxHugePagesPercent = 80
+
+	workerRoleLabel = "node-role.kubernetes.io/worker"
+
+	// smtLabel and numaLabel are published by Node Feature Discovery
+	smtLabel  = "feature.node.kubernetes.io/cpu-hardware_multithreading"
+	numaLabel = "feature.node.kubernetes.io/memory-numa"
+
+	hugePageSize     = "1G"
+	hugePageResource = corev1.ResourceName("hugepages-1Gi")
+
+	// minCPUs is the smallest worker worth partitioning: below it the isolated set
+	// would be too small to pin a VM after housekeeping is reserved.
+	minCPUs = 8
+
+	// minReservedCPUs covers kubelet, CRI-O, OVS and virt-handler on a busy host
+	minReservedCPUs = 4
+)
+
+// Recommend returns PerformanceProfile parameters for the worker nodes among nodes.
+// All workers share one profile, so they must have the same CPU count and SMT setting;
+// otherwise the result is not Ready and Reason explains the mismatch.
+func Recommend(nodes []corev1.Node, hco *unstructured.Unstructured) *pkgcontext.PerformanceProfileContext {
+	profile := &pkgcontext.PerformanceProfileContext{}
+
+	var workers []*corev1.Node
+	for i := range nodes {
+		if _, ok := nodes[i].Labels[workerRoleLabel]; ok {
+			workers = append(workers, &nodes[i])
+		}
+	}
+	if len(workers) == 0 {
+		profile.Reason = "no worker nodes detected"
+		return profile
+	}
+	profile.Workers = len(workers)
+
+	cpus := workers[0].Status.Capacity.Cpu().Value()
+	smt := workers[0].Labels[smtLabel] == "true"
+	profile.NUMANodes = 1
+	for _, node := range workers {
+		if got := node.Status.Capacity.Cpu().Value(); got != cpus {
+			profile.Reason = fmt.Sprintf("worker nodes have different CPU counts (%d on %s, %d on %s)",
+				cpus, workers[0].Name, got, node.Name)
+			return profile
+		}
+		if (node.Labels[smtLabel] == "true") != smt {
+			profile.Reason = fmt.Sprintf("worker nodes %s and %s differ in SMT", workers[0].Name, node.Name)
+			return profile
+		}
+		if node.Labels[numaLabel] == "true" {
+			// NFD only reports whether there is more than one NUMA node
+			profile.NUMANodes = 2
+		}
+	}
+	if cpus < minCPUs {
+		profile.Reason = fmt.Sprintf("worker nodes have %d CPUs, at least %d are needed", cpus, minCPUs)
+		return profile
+	}
+	profile.CPUs = cpus
+
+	profile.ReservedCPUs, profile.IsolatedCPUs = partitionCPUs(int(cpus), reservedCount(int(cpus), smt, profile.NUMANodes), smt)
+
+	profile.TopologyPolicy = "best-effort"
+	if profile.NUMANodes > 1 {
+		// restricted keeps dedicated-CPU VMs NUMA-aligned without rejecting VMs
+		// that only fit across nodes, unlike single-numa-node
+		profile.TopologyPolicy = "restricted"
+	}
+
+	pages, err := hugePages(workers, hco)
+	if err != nil {
+		profile.Reason = err.Error()
+		return profile
+	}
+	if pages > 0 {
+		profile.HugePageSize = hugePageSize
+		profile.HugePages = pages
+	}
+
+	profile.Ready = true
+	return profile
+}
+
+// reservedCount returns how many CPUs to reserve for housekeeping: one in sixteen,
+// at least minReservedCPUs, rounded up to whole cores with SMT and to at least one
+// per NUMA node.
+func reservedCount(cpus int, smt bool, numaNodes int) int {
+	reserved := max(minReservedCPUs, (cpus+15)/16, numaNodes)
+	if smt && reserved%2 != 0 {
+		reserved++
+	}
+	return reserved
+}
+
+// partitionCPUs splits CPU IDs 0..cpus-1 into reserved and isolated cpusets. With SMT the
+// kernel numbers the second thread of core i as i+cpus/2, so whole cores are reserved by
+// taking the lowest IDs from both halves.
+func partitionCPUs(cpus, reserved int, smt bool) (string, string) {
+	var reservedIDs, isolatedIDs []int
+	for id := 0; id < cpus; id++ {
+		isReserved := id < reserved
+		if smt {
+			half := cpus / 2
+			isReserved = id%half < reserved/2
+		}
+		if isReserved {
+			reservedIDs = append(reservedIDs, id)
+		} else {
+			isolatedIDs = append(isolatedIDs, id)
+		}
+	}
+	return formatCPUSet(reservedIDs), formatCPUSet(isolatedIDs)
+}
+
+// hugePages returns the number of 1Gi hugepages per worker. Hugepages the workers
+// already have are kept, so enabling the profile does not resize them; otherwise
+// HugePagesPercentAnnotation of the smallest worker's memory is used (none if unset).
+func hugePages(workers []*corev1.Node, hco *unstructured.Unstructured) (int64, error) {
+	existing := int64(-1)
+	minMemory := int64(-1)
+	for _, node := range workers {
+		quantity := node.Status.Capacity[hugePageResource]
+		pages := quantity.Value() >> 30
+		if existing < 0 || pages < existing {
+			existing = pages
+		}
+		if memory := node.Status.Capacity.Memory().Value(); minMemory < 0 || memory < minMemory {
+			minMemory = memory
+		}
+	}
+	if existing > 0 {
+		return existing, nil
+	}
+
+	raw, ok := hco.GetAnnotations()[HugePagesPercentAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	percent, err := strconv.Atoi(raw)
+	if err != nil || percent < 0 || percent > maxHugePagesPercent {
+		return 0, fmt.Errorf("invalid %s %q: must be an integer between 0 and %d",
+			HugePagesPercentAnnotation, raw, maxHugePagesPercent)
+	}
+	return (minMemory >> 30) * int64(percent) / 100, nil
+}
+
+// formatCPUSet renders sorted CPU IDs in cpuset list format, e.g. "0-3,8"
+func formatCPUSet(ids []int) string {
+	slices.Sort(ids)
+	var ranges []string
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(ids[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", ids[i], ids[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
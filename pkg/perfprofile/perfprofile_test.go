@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package perfprofile
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+func worker(name, cpus, memory string, labels map[string]string) corev1.Node {
+	nodeLabels := map[string]string{workerRoleLabel: ""}
+	for k, v := range labels {
+		nodeLabels[k] = v
+	}
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Status: corev1.NodeStatus{Capacity: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpus),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	smtNUMA := map[string]string{smtLabel: "true", numaLabel: "true"}
+	master := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "master-0",
+		Labels: map[string]string{"node-role.kubernetes.io/master": ""},
+	}}
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetAnnotations(map[string]string{HugePagesPercentAnnotation: "50"})
+
+	profile := Recommend([]corev1.Node{
+		master,
+		worker("worker-0", "64", "256Gi", smtNUMA),
+		worker("worker-1", "64", "128Gi", smtNUMA),
+	}, hco)
+
+	want := &pkgcontext.PerformanceProfileContext{
+		Ready:          true,
+		ReservedCPUs:   "0-1,32-33",
+		IsolatedCPUs:   "2-31,34-63",
+		HugePageSize:   "1G",
+		HugePages:      64, // half of the smallest worker
+		TopologyPolicy: "restricted",
+		CPUs:           64,
+		NUMANodes:      2,
+		Workers:        2,
+	}
+	if *profile != *want {
+		t.Errorf("Recommend() = %+v, want %+v", profile, want)
+	}
+}
+
+func TestRecommendKeepsExistingHugePages(t *testing.T) {
+	node := worker("worker-0", "16", "64Gi", nil)
+	node.Status.Capacity[hugePageResource] = resource.MustParse("8Gi")
+
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	hco.SetAnnotations(map[string]string{HugePagesPercentAnnotation: "50"})
+
+	profile := Recommend([]corev1.Node{node}, hco)
+	if !profile.Ready || profile.HugePages != 8 {
+		t.Errorf("Recommend() = %+v, want 8 hugepages kept", profile)
+	}
+	if profile.ReservedCPUs != "0-3" || profile.IsolatedCPUs != "4-15" || profile.TopologyPolicy != "best-effort" {
+		t.Errorf("Recommend() = %+v, want 4 reserved CPUs without SMT and best-effort", profile)
+	}
+}
+
+func TestRecommendNotReady(t *testing.T) {
+	hco := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	badPercent := pkgcontext.NewMockHCO("kubevirt-hyperconverged", "openshift-cnv")
+	badPercent.SetAnnotations(map[string]string{HugePagesPercentAnnotation: "95"})
+
+	tests := []struct {
+		name       string
+		nodes      []corev1.Node
+		wantReason string
+	}{
+		{"no workers", nil, "no worker nodes"},
+		{"mixed CPU counts", []corev1.Node{worker("a", "32", "64Gi", nil), worker("b", "64", "64Gi", nil)}, "different CPU counts"},
+		{"mixed SMT", []corev1.Node{worker("a", "32", "64Gi", map[string]string{smtLabel: "true"}), worker("b", "32", "64Gi", nil)}, "differ in SMT"},
+		{"too small", []corev1.Node{worker("a", "4", "16Gi", nil)}, "at least 8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := Recommend(tt.nodes, hco)
+			if profile.Ready || !strings.Contains(profile.Reason, tt.wantReason) {
+				t.Errorf("Recommend() = %+v, want not ready with reason containing %q", profile, tt.wantReason)
+			}
+		})
+	}
+
+	t.Run("invalid hugepages percent", func(t *testing.T) {
+		profile := Recommend([]corev1.Node{worker("a", "32", "64Gi", nil)}, badPercent)
+		if profile.Ready || !strings.Contains(profile.Reason, HugePagesPercentAnnotation) {
+			t.Errorf("Recommend() = %+v, want not ready because of the annotation", profile)
+		}
+	})
+}
+
+func TestReservedCount(t *testing.T) {
+	tests := []struct {
+		cpus      int
+		smt       bool
+		numaNodes int
+		want      int
+	}{
+		{8, false, 1, 4},
+		{64, true, 2, 4},
+		{96, true, 2, 6},
+		{80, true, 2, 6}, // 5 rounded up to a whole core
+		{128, false, 1, 8},
+	}
+	for _, tt := range tests {
+		if got := reservedCount(tt.cpus, tt.smt, tt.numaNodes); got != tt.want {
+			t.Errorf("reservedCount(%d, %v, %d) = %d, want %d", tt.cpus, tt.smt, tt.numaNodes, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCPUSet(t *testing.T) {
+	tests := []struct {
+		ids  []int
+		want string
+	}{
+		{nil, ""},
+		{[]int{0}, "0"},
+		{[]int{3, 0, 1, 2}, "0-3"},
+		{[]int{0, 1, 4, 6, 7, 8}, "0-1,4,6-8"},
+	}
+	for _, tt := range tests {
+		if got := formatCPUSet(tt.ids); got != tt.want {
+			t.Errorf("formatCPUSet(%v) = %q, want %q", tt.ids, got, tt.want)
+		}
+	}
+}
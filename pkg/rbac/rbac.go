@@ -39,6 +39,7 @@ type Resource struct {
 	APIVersion  string
 	Kind        string
 	NeedsDelete bool // true if found in tombstones (requires delete verb)
+	Namespaced  bool // from the asset's catalog scope, or metadata.namespace when not in the catalog
 }
 
 // Rule represents a single ClusterRole policy rule.
@@ -46,8 +47,17 @@ type Rule struct {
 	APIGroups []string
 	Resources []string
 	Verbs     []string
+	// Scope is "Cluster" or "Namespaced" for dynamic rules, which are split by the
+	// scope of the managed resources; empty for static and transitive rules.
+	Scope string
 }
 
+// Resource scopes, matching the catalog's scope field
+const (
+	scopeCluster    = "Cluster"
+	scopeNamespaced = "Namespaced"
+)
+
 // StaticRules returns the fixed infrastructure RBAC rules that every release of
 // virt-platform-autopilot requires, regardless of which asset templates are active.
 // The order is stable; the comment formatter in cmd/rbac-gen writes each rule by index.
@@ -59,11 +69,11 @@ func StaticRules() []Rule {
 			Resources: []string{"nodes"},
 			Verbs:     []string{"get", "list", "watch"},
 		},
-		// Rule 1: Events (for observability - legacy core/v1 API)
+		// Rule 1: Events (for observability - legacy core/v1 API; list for `debug dump`)
 		{
 			APIGroups: []string{""},
 			Resources: []string{"events"},
-			Verbs:     []string{"create", "patch"},
+			Verbs:     []string{"create", "list", "patch"},
 		},
 		// Rule 2: Events (for observability - modern events.k8s.io/v1 API)
 		{
@@ -83,12 +93,13 @@ func StaticRules() []Rule {
 			Resources: []string{"customresourcedefinitions"},
 			Verbs:     []string{"get", "list", "watch"},
 		},
-		// Rule 5: OpenShift Infrastructure CR (for cluster topology detection: HCP, compact)
-		// The Infrastructure CR is a singleton (name="cluster") and is non-sensitive read-only.
-		// Gracefully absent on non-OpenShift clusters — the operator handles NotFound.
+		// Rule 5: OpenShift Infrastructure, Proxy, ClusterVersion and Network CRs (for cluster topology
+		// detection, proxy/trusted-CA propagation, upgrade safe-mode and network detection), plus
+		// ImageDigestMirrorSets (for image mirror rewriting). All read-only. Gracefully absent on non-OpenShift
+		// clusters — the operator handles NotFound.
 		{
 			APIGroups: []string{"config.openshift.io"},
-			Resources: []string{"infrastructures"},
+			Resources: []string{"clusterversions", "imagedigestmirrorsets", "infrastructures", "networks", "proxies"},
 			Verbs:     []string{"get", "list", "watch"},
 		},
 		// Rule 6: Namespaces (for pre-apply guard: verify the target namespace exists before
@@ -99,6 +110,94 @@ func StaticRules() []Rule {
 			Resources: []string{"namespaces"},
 			Verbs:     []string{"get"},
 		},
+		// Rule 7: MachineConfigPools (for upgrade safe-mode: reboot-triggering assets are
+		// deferred while any pool is rolling out; patch pauses and resumes the non-canary
+		// pools during a --canary-pool rollout). Absent on non-OpenShift clusters.
+		{
+			APIGroups: []string{"machineconfiguration.openshift.io"},
+			Resources: []string{"machineconfigpools"},
+			Verbs:     []string{"get", "list", "watch", "patch"},
+		},
+		// Rule 8: ImageContentSourcePolicies (deprecated predecessor of ImageDigestMirrorSet,
+		// still honoured for image mirror rewriting). Read-only; absent on non-OpenShift clusters.
+		{
+			APIGroups: []string{"operator.openshift.io"},
+			Resources: []string{"imagecontentsourcepolicies"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		// Rule 9: HyperConverged status (to report repeated reconcile failures as a condition;
+		// the HCO operator's own conditions are left untouched).
+		{
+			APIGroups: []string{"hco.kubevirt.io"},
+			Resources: []string{"hyperconvergeds/status"},
+			Verbs:     []string{"get", "patch", "update"},
+		},
+		// Rule 10: StorageClasses, CDI StorageProfiles, VolumeSnapshotClasses and ODF
+		// StorageClusters (for storage capability detection: RWX, snapshots, ODF/Ceph).
+		// Read-only; kinds that are not installed are skipped.
+		{
+			APIGroups: []string{"storage.k8s.io", "cdi.kubevirt.io", "snapshot.storage.k8s.io", "ocs.openshift.io"},
+			Resources: []string{"storageclasses", "storageprofiles", "volumesnapshotclasses", "storageclusters"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		// Rule 11: Cluster Network Operator config and NMState instances (for network detection:
+		// whether Multus is disabled and whether the NMState handler is deployed). Read-only;
+		// absent on non-OpenShift clusters or without the NMState operator.
+		{
+			APIGroups: []string{"operator.openshift.io", "nmstate.io"},
+			Resources: []string{"networks", "nmstates"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		// Rule 12: ConfigMaps (for the user overrides ConfigMap referenced from the HCO,
+		// read and watched for changes, and for the render history ConfigMap, which is
+		// written when --render-history-configmap is set).
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch", "create", "update"},
+		},
+		// Rule 13: TokenReviews and SubjectAccessReviews (to authenticate and authorize
+		// callers of the external API listener).
+		{
+			APIGroups: []string{"authentication.k8s.io", "authorization.k8s.io"},
+			Resources: []string{"tokenreviews", "subjectaccessreviews"},
+			Verbs:     []string{"create"},
+		},
+		// Rule 14: ManagedResources, AutopilotStatuses and AutopilotDeployments (the inventory
+		// of applied objects, its aggregate status and the owner of the applied objects,
+		// written to the HCO's namespace when their optional CRDs are installed).
+		{
+			APIGroups: []string{"platform.kubevirt.io"},
+			Resources: []string{"managedresources", "autopilotstatuses", "autopilotdeployments"},
+			Verbs:     []string{"create", "delete", "get", "list", "update"},
+		},
+		// Rule 15: BareMetalHosts (for the MetalLB inventory: the host NIC addresses a
+		// derived address pool must not contain). Read-only; absent outside bare metal.
+		{
+			APIGroups: []string{"metal3.io"},
+			Resources: []string{"baremetalhosts"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		// Rule 16: AutopilotExclusions (structured exclusions users create next to the HCO).
+		// Read-only: the spec belongs to the user.
+		{
+			APIGroups: []string{"platform.kubevirt.io"},
+			Resources: []string{"autopilotexclusions"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+		// Rule 17: AutopilotExclusion status (reports what each exclusion matched).
+		{
+			APIGroups: []string{"platform.kubevirt.io"},
+			Resources: []string{"autopilotexclusions/status"},
+			Verbs:     []string{"update"},
+		},
+		// Rule 18: OLM Subscriptions (for operator conditions: whether an operator package is
+		// installed). Read-only and listed on demand; absent without OLM.
+		{
+			APIGroups: []string{"operators.coreos.com"},
+			Resources: []string{"subscriptions"},
+			Verbs:     []string{"get", "list"},
+		},
 	}
 }
 
@@ -307,7 +406,9 @@ func preprocessTemplate(content []byte) []byte {
 }
 
 // processAssetFile extracts Kubernetes GVKs from YAML content (supports multi-doc files).
-func processAssetFile(content []byte, seen map[string]bool, resources *[]Resource, needsDelete bool) {
+// scope is the catalog scope of the file; when empty, a resource is namespaced if it sets
+// metadata.namespace. The same kind declared with both scopes is an error.
+func processAssetFile(content []byte, seen map[string]bool, resources *[]Resource, needsDelete bool, scope string) error {
 	docs := strings.Split(string(content), "\n---\n")
 	for _, docStr := range docs {
 		docStr = strings.TrimSpace(docStr)
@@ -331,6 +432,12 @@ func processAssetFile(content []byte, seen map[string]bool, resources *[]Resourc
 			continue
 		}
 
+		namespaced := scope == scopeNamespaced
+		if scope == "" {
+			metadata, _ := doc["metadata"].(map[string]any)
+			_, namespaced = metadata["namespace"]
+		}
+
 		key := apiVersion + "/" + kind
 		if !seen[key] {
 			seen[key] = true
@@ -338,21 +445,56 @@ func processAssetFile(content []byte, seen map[string]bool, resources *[]Resourc
 				APIVersion:  apiVersion,
 				Kind:        kind,
 				NeedsDelete: needsDelete,
+				Namespaced:  namespaced,
 			})
-		} else if needsDelete {
-			// Upgrade an already-seen resource to require delete permissions
-			for i := range *resources {
-				if (*resources)[i].APIVersion == apiVersion && (*resources)[i].Kind == kind {
-					(*resources)[i].NeedsDelete = true
-					break
-				}
+			continue
+		}
+		for i := range *resources {
+			res := &(*resources)[i]
+			if res.APIVersion != apiVersion || res.Kind != kind {
+				continue
+			}
+			if res.Namespaced != namespaced {
+				return fmt.Errorf("%s is declared both cluster-scoped and namespaced", key)
 			}
+			// Upgrade an already-seen resource to require delete permissions
+			res.NeedsDelete = res.NeedsDelete || needsDelete
+			break
 		}
 	}
+	return nil
+}
+
+// catalogScopes maps asset paths to their scope from active/metadata.yaml.
+// A missing catalog yields an empty map.
+func catalogScopes(fsys fs.FS) (map[string]string, error) {
+	data, err := fs.ReadFile(fsys, "active/metadata.yaml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset catalog: %w", err)
+	}
+
+	var catalog struct {
+		Assets []struct {
+			Path  string `json:"path"`
+			Scope string `json:"scope"`
+		} `json:"assets"`
+	}
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse asset catalog: %w", err)
+	}
+
+	scopes := make(map[string]string, len(catalog.Assets))
+	for _, asset := range catalog.Assets {
+		scopes[asset.Path] = asset.Scope
+	}
+	return scopes, nil
 }
 
 // scanDirectory walks dir inside fsys and calls processAssetFile for every .yaml / .yaml.tpl.
-func scanDirectory(fsys fs.FS, dir string, seen map[string]bool, resources *[]Resource, needsDelete bool) error {
+func scanDirectory(fsys fs.FS, dir string, scopes map[string]string, seen map[string]bool, resources *[]Resource, needsDelete bool) error {
 	return fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -373,7 +515,12 @@ func scanDirectory(fsys fs.FS, dir string, seen map[string]bool, resources *[]Re
 			content = preprocessTemplate(content)
 		}
 
-		processAssetFile(content, seen, resources, needsDelete)
+		if path == "active/metadata.yaml" {
+			return nil
+		}
+		if err := processAssetFile(content, seen, resources, needsDelete, scopes[path]); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
 		return nil
 	})
 }
@@ -383,11 +530,16 @@ func extractResources(fsys fs.FS) ([]Resource, error) {
 	var resources []Resource
 	seen := make(map[string]bool)
 
-	if err := scanDirectory(fsys, "active", seen, &resources, false); err != nil {
+	scopes, err := catalogScopes(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scanDirectory(fsys, "active", scopes, seen, &resources, false); err != nil {
 		return nil, fmt.Errorf("failed to scan active directory: %w", err)
 	}
 
-	if err := scanDirectory(fsys, "tombstones", seen, &resources, true); err != nil {
+	if err := scanDirectory(fsys, "tombstones", scopes, seen, &resources, true); err != nil {
 		// Tolerate a missing tombstones directory (it may be empty or absent)
 		if !errors.Is(err, fs.ErrNotExist) {
 			return nil, fmt.Errorf("failed to scan tombstones directory: %w", err)
@@ -400,33 +552,46 @@ func extractResources(fsys fs.FS) ([]Resource, error) {
 // generateDynamicRules groups discovered resources by API group and produces
 // deterministically ordered RBAC rules.
 func generateDynamicRules(resources []Resource) []Rule {
+	type groupKey struct {
+		group string
+		scope string
+	}
 	type groupInfo struct {
 		resources   []string
 		needsDelete bool
 	}
-	grouped := make(map[string]*groupInfo)
+	grouped := make(map[groupKey]*groupInfo)
 
 	for _, res := range resources {
 		group, _, resource := parseGVK(res.APIVersion, res.Kind)
-		if grouped[group] == nil {
-			grouped[group] = &groupInfo{}
+		key := groupKey{group: group, scope: scopeCluster}
+		if res.Namespaced {
+			key.scope = scopeNamespaced
+		}
+		if grouped[key] == nil {
+			grouped[key] = &groupInfo{}
 		}
-		grouped[group].resources = append(grouped[group].resources, resource)
+		grouped[key].resources = append(grouped[key].resources, resource)
 		if res.NeedsDelete {
-			grouped[group].needsDelete = true
+			grouped[key].needsDelete = true
 		}
 	}
 
-	// Sort groups alphabetically for deterministic output
-	var groups []string
-	for g := range grouped {
-		groups = append(groups, g)
+	// Sort by group, then scope (Cluster before Namespaced), for deterministic output
+	var keys []groupKey
+	for k := range grouped {
+		keys = append(keys, k)
 	}
-	sort.Strings(groups)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].group != keys[j].group {
+			return keys[i].group < keys[j].group
+		}
+		return keys[i].scope < keys[j].scope
+	})
 
 	var rules []Rule
-	for _, group := range groups {
-		info := grouped[group]
+	for _, key := range keys {
+		info := grouped[key]
 
 		// Deduplicate and sort resources
 		resourceSet := make(map[string]bool)
@@ -446,9 +611,10 @@ func generateDynamicRules(resources []Resource) []Rule {
 		}
 
 		rules = append(rules, Rule{
-			APIGroups: []string{group},
+			APIGroups: []string{key.group},
 			Resources: unique,
 			Verbs:     verbs,
+			Scope:     key.scope,
 		})
 	}
 
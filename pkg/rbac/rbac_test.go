@@ -25,8 +25,8 @@ import (
 
 func TestStaticRules_Count(t *testing.T) {
 	rules := StaticRules()
-	if len(rules) != 7 {
-		t.Errorf("expected 7 static rules, got %d", len(rules))
+	if len(rules) != 19 {
+		t.Errorf("expected 19 static rules, got %d", len(rules))
 	}
 }
 
@@ -409,3 +409,84 @@ rules:
 		t.Errorf("expected transitive rule with apiGroup 'apps' after static rules, got %q", transitiveRule.APIGroups[0])
 	}
 }
+
+// ---- DynamicRules ----
+
+func TestDynamicRules_SplitsByScope(t *testing.T) {
+	fsys := makeFS(map[string]string{
+		"active/metadata.yaml": `
+assets:
+  - name: sa
+    path: active/comp/sa.yaml
+    scope: Namespaced
+  - name: ns
+    path: active/comp/ns.yaml
+    scope: Cluster
+`,
+		"active/comp/sa.yaml": `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+  namespace: "{{ .Namespace }}"
+`,
+		"active/comp/ns.yaml": `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+`,
+	})
+	rules, err := DynamicRules(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules (cluster-scoped and namespaced core), got %d: %+v", len(rules), rules)
+	}
+	if rules[0].Scope != "Cluster" || rules[0].Resources[0] != "namespaces" {
+		t.Errorf("expected cluster-scoped namespaces rule first, got %+v", rules[0])
+	}
+	if rules[1].Scope != "Namespaced" || rules[1].Resources[0] != "serviceaccounts" {
+		t.Errorf("expected namespaced serviceaccounts rule second, got %+v", rules[1])
+	}
+}
+
+func TestDynamicRules_ConflictingScope(t *testing.T) {
+	fsys := makeFS(map[string]string{
+		"active/metadata.yaml": `
+assets:
+  - name: a
+    path: active/comp/a.yaml
+    scope: Namespaced
+  - name: b
+    path: active/comp/b.yaml
+    scope: Cluster
+`,
+		"active/comp/a.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: x\n",
+		"active/comp/b.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n",
+	})
+	if _, err := DynamicRules(fsys); err == nil {
+		t.Error("expected an error for a kind declared with both scopes")
+	}
+}
+
+func TestDynamicRules_TombstoneScopeFromNamespace(t *testing.T) {
+	fsys := makeFS(map[string]string{
+		"active/.keep": "",
+		"tombstones/old/cm.yaml": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: old
+  namespace: x
+`,
+	})
+	rules, err := DynamicRules(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Scope != "Namespaced" {
+		t.Errorf("expected one namespaced rule, got %+v", rules)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// volatileMetadataFields are set by the API server, never by a template. They show up when
+// an object read from a cluster is written out and differ between clusters and over time.
+var volatileMetadataFields = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// volatileAnnotations are written when an object is applied rather than rendered: the
+// autopilot's own apply records and kubectl's client-side apply copy
+var volatileAnnotations = []string{
+	engine.DesiredHashAnnotation,
+	engine.RenderedFieldsAnnotation,
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// NormalizeObject returns a copy of obj without status, server-populated metadata and
+// apply-time annotations, and without labels or annotations left empty, so writing the
+// same rendering twice yields the same bytes. Map keys need no sorting: the YAML and
+// JSON encoders write them in order.
+func NormalizeObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj == nil {
+		return nil
+	}
+	normalized := obj.DeepCopy()
+
+	delete(normalized.Object, "status")
+	for _, field := range volatileMetadataFields {
+		unstructured.RemoveNestedField(normalized.Object, "metadata", field)
+	}
+	for _, key := range volatileAnnotations {
+		unstructured.RemoveNestedField(normalized.Object, "metadata", "annotations", key)
+	}
+	for _, field := range []string{"labels", "annotations"} {
+		if values, found, _ := unstructured.NestedFieldNoCopy(normalized.Object, "metadata", field); found {
+			if m, ok := values.(map[string]any); values == nil || (ok && len(m) == 0) {
+				unstructured.RemoveNestedField(normalized.Object, "metadata", field)
+			}
+		}
+	}
+	return normalized
+}
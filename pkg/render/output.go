@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
@@ -34,13 +35,28 @@ import (
 
 // RenderOutput represents the rendering result for a single asset.
 type RenderOutput struct {
-	Asset      string                     `json:"asset" yaml:"asset"`
-	Path       string                     `json:"path" yaml:"path"`
-	Component  string                     `json:"component" yaml:"component"`
-	Status     string                     `json:"status" yaml:"status"`
-	Reason     string                     `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Asset     string `json:"asset" yaml:"asset"`
+	Path      string `json:"path" yaml:"path"`
+	Component string `json:"component" yaml:"component"`
+	Status    string `json:"status" yaml:"status"`
+	// Reason is the reason code of an EXCLUDED, FILTERED or ERROR output, e.g. ConditionsNotMet
+	// or, classifying the failure, RenderFailed
+	Reason engine.Reason `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Message details the reason for humans, e.g. the error; it is not meant to be parsed
+	Message    string                     `json:"message,omitempty" yaml:"message,omitempty"`
 	Conditions []assets.AssetCondition    `json:"conditions,omitempty" yaml:"conditions,omitempty"`
 	Object     *unstructured.Unstructured `json:"object,omitempty" yaml:"object,omitempty"`
+	// Drifted is set by the render CLI's --fail-on=drift check when the live object differs
+	Drifted bool `json:"drifted,omitempty" yaml:"drifted,omitempty"`
+	// DroppedFields are set by the same check: the fields (JSON Pointers) the live object got from
+	// the previous rendering that this one no longer sets, so applying it removes them
+	DroppedFields []string `json:"droppedFields,omitempty" yaml:"droppedFields,omitempty"`
+	// Deprecated is the asset's deprecation notice, empty unless the catalog marks it deprecated
+	Deprecated string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	// Images lists the image references of the rendered object and the digest each was pinned to
+	Images []engine.PinnedImage `json:"images,omitempty" yaml:"images,omitempty"`
+	// Warnings are the messages of the template's warning sentinels
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 }
 
 // CheckConditions reports whether all of an asset's conditions are satisfied.
@@ -58,12 +74,36 @@ func CheckConditions(assetMeta *assets.AssetMetadata, renderCtx *pkgcontext.Rend
 				return false
 			}
 		case assets.ConditionTypeFeatureGate:
-			featureGates := renderCtx.HCO.GetAnnotations()["platform.kubevirt.io/feature-gates"]
-			if !strings.Contains(featureGates, condition.Value) {
+			if !renderCtx.FeatureGates[condition.Value] {
 				return false
 			}
 		case assets.ConditionTypeHardwareDetection:
-			// Hardware detection requires node access which is not available here.
+			// Hardware detection requires node access; offline the context only
+			// carries hardware when a profile supplies it (render bootstrap).
+			if renderCtx.Hardware == nil || !renderCtx.Hardware.AsMap()[condition.Detector] {
+				return false
+			}
+		case assets.ConditionTypeStorage:
+			// Empty offline; populated when the context is built from a cluster.
+			if !renderCtx.Storage.AsMap()[condition.Detector] {
+				return false
+			}
+		case assets.ConditionTypeNetwork:
+			if !renderCtx.Network.AsMap()[condition.Detector] {
+				return false
+			}
+		case assets.ConditionTypeIPFamily:
+			// Offline the families are unknown, which counts as IPv4 single-stack.
+			if !renderCtx.Network.HasIPFamily(condition.Value) {
+				return false
+			}
+		case assets.ConditionTypeArchitecture:
+			// Offline the architectures are unknown, which counts as amd64 only.
+			if !renderCtx.Architecture.Has(condition.Value) {
+				return false
+			}
+		case assets.ConditionTypeCRD, assets.ConditionTypeOperator, assets.ConditionTypeStorageClass:
+			// These query the cluster when evaluated, which the render context cannot answer.
 			return false
 		}
 	}
@@ -83,13 +123,8 @@ func BuildOutputs(
 	showExcluded bool,
 ) []RenderOutput {
 	// Parse root-exclusion rules once before iterating.
-	var exclusionRules []engine.ExclusionRule
-	if ann := renderCtx.HCO.GetAnnotations()[engine.DisabledResourcesAnnotation]; ann != "" {
-		if rules, err := engine.ParseDisabledResources(ann); err == nil {
-			exclusionRules = rules
-		}
-		// On parse error leave exclusionRules nil → no resources excluded (fail-open).
-	}
+	// On parse error exclusionRules is nil → no resources excluded (fail-open).
+	exclusionRules, _ := engine.ExclusionRulesFromObject(renderCtx.HCO)
 
 	outputs := make([]RenderOutput, 0, len(assetList))
 	for _, assetMeta := range assetList {
@@ -98,11 +133,12 @@ func BuildOutputs(
 			Path:       assetMeta.Path,
 			Component:  assetMeta.Component,
 			Conditions: assetMeta.Conditions,
+			Deprecated: assetMeta.DeprecationNotice(),
 		}
 
 		if !CheckConditions(&assetMeta, renderCtx) {
 			output.Status = "EXCLUDED"
-			output.Reason = "Conditions not met"
+			output.Reason = engine.ReasonConditionsNotMet
 			if showExcluded {
 				outputs = append(outputs, output)
 			}
@@ -110,16 +146,30 @@ func BuildOutputs(
 		}
 
 		rendered, err := renderer.RenderAsset(&assetMeta, renderCtx)
+		output.Warnings = renderCtx.Warnings(assetMeta.Name)
+		if reason, skipped := engine.SkipReason(err); skipped {
+			output.Status = "EXCLUDED"
+			output.Reason = engine.ReasonTemplateSkipped
+			output.Message = reason
+			if showExcluded {
+				outputs = append(outputs, output)
+			}
+			continue
+		}
 		if err != nil {
 			output.Status = "ERROR"
-			output.Reason = err.Error()
+			output.Reason = engine.ReasonOf(err)
+			if output.Reason == "" {
+				output.Reason = engine.ReasonRenderFailed
+			}
+			output.Message = err.Error()
 			outputs = append(outputs, output)
 			continue
 		}
 
 		if rendered == nil {
 			output.Status = "EXCLUDED"
-			output.Reason = "Conditional template rendered empty"
+			output.Reason = engine.ReasonRenderedEmpty
 			if showExcluded {
 				outputs = append(outputs, output)
 			}
@@ -128,7 +178,16 @@ func BuildOutputs(
 
 		if engine.IsResourceExcluded(rendered.GetKind(), rendered.GetNamespace(), rendered.GetName(), exclusionRules) {
 			output.Status = "FILTERED"
-			output.Reason = "Root exclusion (disabled-resources annotation)"
+			output.Reason = engine.ReasonDisabledResources
+			if showExcluded {
+				outputs = append(outputs, output)
+			}
+			continue
+		}
+		if exclusion := engine.MatchingExclusion(renderCtx, assetMeta.Component, rendered, time.Now()); exclusion != nil {
+			output.Status = "FILTERED"
+			output.Reason = engine.ReasonAutopilotExclusion
+			output.Message = fmt.Sprintf("excluded by AutopilotExclusion %s", exclusion.Source)
 			if showExcluded {
 				outputs = append(outputs, output)
 			}
@@ -137,6 +196,7 @@ func BuildOutputs(
 
 		output.Status = "INCLUDED"
 		output.Object = rendered
+		output.Images = renderer.PinnedImages(rendered)
 		outputs = append(outputs, output)
 	}
 
@@ -145,6 +205,11 @@ func BuildOutputs(
 
 // WriteYAML writes outputs as multi-document YAML with comment headers to w.
 // The result is directly usable with kubectl apply.
+//
+// Documents follow the order of outputs, which BuildOutputs keeps from its asset list
+// (reconcile order for every caller), and objects are written normalized (see
+// NormalizeObject). Unchanged inputs therefore produce byte-identical output, which
+// GitOps pipelines alerting on diffs rely on.
 func WriteYAML(w io.Writer, outputs []RenderOutput) error {
 	for _, output := range outputs {
 		fmt.Fprintf(w, "# Asset: %s\n", output.Asset)
@@ -154,8 +219,30 @@ func WriteYAML(w io.Writer, outputs []RenderOutput) error {
 		if output.Reason != "" {
 			fmt.Fprintf(w, "# Reason: %s\n", output.Reason)
 		}
+		if output.Message != "" {
+			fmt.Fprintf(w, "# Message: %s\n", output.Message)
+		}
+		if output.Drifted {
+			fmt.Fprintln(w, "# Drifted: true")
+		}
+		if len(output.DroppedFields) > 0 {
+			fmt.Fprintf(w, "# Dropped fields: %s\n", strings.Join(output.DroppedFields, ", "))
+		}
+		if output.Deprecated != "" {
+			fmt.Fprintf(w, "# Deprecated: %s\n", output.Deprecated)
+		}
+		for _, warning := range output.Warnings {
+			fmt.Fprintf(w, "# Warning: %s\n", warning)
+		}
+		for _, image := range output.Images {
+			if image.Digest != "" && image.Digest != image.Source {
+				fmt.Fprintf(w, "# Image: %s -> %s\n", image.Source, image.Digest)
+			} else {
+				fmt.Fprintf(w, "# Image: %s\n", image.Source)
+			}
+		}
 		if output.Object != nil {
-			data, err := yaml.Marshal(output.Object.Object)
+			data, err := yaml.Marshal(NormalizeObject(output.Object).Object)
 			if err != nil {
 				return fmt.Errorf("failed to marshal %s: %w", output.Asset, err)
 			}
@@ -166,9 +253,14 @@ func WriteYAML(w io.Writer, outputs []RenderOutput) error {
 	return nil
 }
 
-// WriteJSON writes outputs as a JSON array to w.
+// WriteJSON writes outputs as a JSON array to w, with objects normalized as in WriteYAML.
 func WriteJSON(w io.Writer, outputs []RenderOutput) error {
-	data, err := json.MarshalIndent(outputs, "", "  ")
+	normalized := make([]RenderOutput, len(outputs))
+	for i, output := range outputs {
+		normalized[i] = output
+		normalized[i].Object = NormalizeObject(output.Object)
+	}
+	data, err := json.MarshalIndent(normalized, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rendertest renders single assets for focused unit tests of their templates.
+// It needs neither a registry nor a client: the asset is looked up in the catalog and
+// rendered against a mock HCO shaped by options, and assertions address the rendered
+// object with RFC 6901 JSON Pointers, like the ignore-fields annotation does.
+//
+//	func TestLokiStackSizing(t *testing.T) {
+//		obj := rendertest.RenderAssetForTest(t, "logging-lokistack",
+//			rendertest.WithTopology(&pkgcontext.TopologyContext{TotalNodeCount: 1}))
+//		obj.AssertField("/spec/size", "1x.pico")
+//	}
+package rendertest
+
+import (
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
+)
+
+// config collects the options of a render
+type config struct {
+	loader   *assets.Loader
+	hco      *unstructured.Unstructured
+	hcoErr   error
+	contexts []func(*pkgcontext.RenderContext)
+}
+
+// Option shapes the render context of a test render
+type Option func(*config)
+
+// WithAssets renders from fsys, laid out like the assets directory, instead of the
+// embedded assets, e.g. an fstest.MapFS holding a template under development
+func WithAssets(fsys fs.FS) Option {
+	return func(c *config) {
+		c.loader = assets.NewLoaderFromFS(fsys)
+	}
+}
+
+// WithHCOAnnotations sets annotations on the mock HCO
+func WithHCOAnnotations(annotations map[string]string) Option {
+	return func(c *config) {
+		merged := c.hco.GetAnnotations()
+		if merged == nil {
+			merged = make(map[string]string, len(annotations))
+		}
+		for key, value := range annotations {
+			merged[key] = value
+		}
+		c.hco.SetAnnotations(merged)
+	}
+}
+
+// WithHCOField sets a field of the mock HCO, e.g. WithHCOField("highBurst", "spec",
+// "virtualization", "tuningPolicy"). The contexts derived from the HCO (placement, tuning
+// policy, live migration, ...) see the field. value may be any JSON-serializable Go value.
+func WithHCOField(value any, fields ...string) Option {
+	return func(c *config) {
+		normalized, err := normalize(value)
+		if err == nil {
+			err = unstructured.SetNestedField(c.hco.Object, normalized, fields...)
+		}
+		if err != nil && c.hcoErr == nil {
+			c.hcoErr = fmt.Errorf("failed to set HCO field %s: %w", strings.Join(fields, "."), err)
+		}
+	}
+}
+
+// WithTopology sets the cluster topology of the render context
+func WithTopology(topology *pkgcontext.TopologyContext) Option {
+	return WithContext(func(ctx *pkgcontext.RenderContext) {
+		ctx.Topology = topology
+	})
+}
+
+// WithHardware sets the detected hardware of the render context
+func WithHardware(hardware *pkgcontext.HardwareContext) Option {
+	return WithContext(func(ctx *pkgcontext.RenderContext) {
+		ctx.Hardware = hardware
+	})
+}
+
+// WithContext edits the render context after it was built from the mock HCO, for the
+// fields no dedicated option covers
+func WithContext(edit func(*pkgcontext.RenderContext)) Option {
+	return func(c *config) {
+		c.contexts = append(c.contexts, edit)
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{hco: pkgcontext.NewMockHCO(pkgcontext.HCOName, pkgcontext.DefaultHCONamespace)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.loader == nil {
+		c.loader = assets.NewLoader()
+	}
+	return c
+}
+
+// NewRenderContext returns the render context the options describe: the context of a
+// mock HCO in the default namespace with nothing detected, unless options say otherwise
+func NewRenderContext(opts ...Option) (*pkgcontext.RenderContext, error) {
+	return newConfig(opts).renderContext()
+}
+
+func (c *config) renderContext() (*pkgcontext.RenderContext, error) {
+	if c.hcoErr != nil {
+		return nil, c.hcoErr
+	}
+	ctx := pkgcontext.NewRenderContext(c.hco)
+	for _, edit := range c.contexts {
+		edit(ctx)
+	}
+	return ctx, nil
+}
+
+// Render renders the named catalog asset. It returns a nil object when the template
+// renders nothing, and the engine's *SkipError when it renders the skip sentinel.
+// The assets it takes inputs from are rendered first, as the engine does.
+func Render(asset string, opts ...Option) (*unstructured.Unstructured, error) {
+	c := newConfig(opts)
+	ctx, err := c.renderContext()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := c.loader.Catalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load asset catalog: %w", err)
+	}
+	for i := range catalog.Assets {
+		if catalog.Assets[i].Name == asset {
+			return engine.NewRenderer(c.loader).RenderAsset(&catalog.Assets[i], ctx)
+		}
+	}
+	return nil, fmt.Errorf("asset %s not found in the catalog", asset)
+}
+
+// RenderAssetForTest renders the named catalog asset and fails the test unless the
+// template rendered an object
+func RenderAssetForTest(t testing.TB, asset string, opts ...Option) *Rendered {
+	t.Helper()
+
+	obj, err := Render(asset, opts...)
+	if reason, skipped := engine.SkipReason(err); skipped {
+		t.Fatalf("asset %s was skipped: %s", asset, reason)
+	}
+	if err != nil {
+		t.Fatalf("failed to render asset %s: %v", asset, err)
+	}
+	if obj == nil {
+		t.Fatalf("asset %s rendered nothing", asset)
+	}
+	return &Rendered{Object: obj, t: t}
+}
+
+// AssertNotRendered fails the test unless the named asset renders nothing or is skipped
+func AssertNotRendered(t testing.TB, asset string, opts ...Option) {
+	t.Helper()
+
+	obj, err := Render(asset, opts...)
+	if _, skipped := engine.SkipReason(err); skipped {
+		return
+	}
+	if err != nil {
+		t.Fatalf("failed to render asset %s: %v", asset, err)
+	}
+	if obj != nil {
+		t.Fatalf("asset %s rendered %s %s, want nothing", asset, obj.GetKind(), obj.GetName())
+	}
+}
+
+// Rendered is the object an asset rendered, with assertions on its fields
+type Rendered struct {
+	Object *unstructured.Unstructured
+	t      testing.TB
+}
+
+// Field returns the value at pointer, and whether it is set
+func (r *Rendered) Field(pointer string) (any, bool) {
+	r.t.Helper()
+
+	value, found, err := Lookup(r.Object, pointer)
+	if err != nil {
+		r.t.Fatalf("%s: %v", pointer, err)
+	}
+	return value, found
+}
+
+// AssertField fails the test unless the value at pointer equals want. Numbers compare
+// by value, so an int matches the int64 a template renders.
+func (r *Rendered) AssertField(pointer string, want any) {
+	r.t.Helper()
+
+	got, found := r.Field(pointer)
+	if !found {
+		r.t.Errorf("%s is not set, want %v", pointer, want)
+		return
+	}
+	normalized, err := normalize(want)
+	if err != nil {
+		r.t.Fatalf("%s: cannot compare with %#v: %v", pointer, want, err)
+	}
+	if !reflect.DeepEqual(got, normalized) {
+		r.t.Errorf("%s = %v, want %v", pointer, got, want)
+	}
+}
+
+// AssertFieldContains fails the test unless the value at pointer is a string holding substr
+func (r *Rendered) AssertFieldContains(pointer, substr string) {
+	r.t.Helper()
+
+	got, found := r.Field(pointer)
+	if !found {
+		r.t.Errorf("%s is not set, want a string containing %q", pointer, substr)
+		return
+	}
+	s, ok := got.(string)
+	if !ok || !strings.Contains(s, substr) {
+		r.t.Errorf("%s = %v, want a string containing %q", pointer, got, substr)
+	}
+}
+
+// AssertFieldAbsent fails the test if pointer is set
+func (r *Rendered) AssertFieldAbsent(pointer string) {
+	r.t.Helper()
+
+	if got, found := r.Field(pointer); found {
+		r.t.Errorf("%s = %v, want it unset", pointer, got)
+	}
+}
+
+// Lookup returns the value at an RFC 6901 JSON Pointer in obj, and whether it is set.
+// Array elements are addressed by index, e.g. /spec/containers/0/image.
+func Lookup(obj *unstructured.Unstructured, pointer string) (any, bool, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false, fmt.Errorf("JSON pointer must start with /: %s", pointer)
+	}
+
+	var current any = obj.Object
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, false, nil
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid array index %q", token)
+			}
+			if index < 0 || index >= len(node) {
+				return nil, false, nil
+			}
+			current = node[index]
+		default:
+			return nil, false, nil
+		}
+	}
+	return current, true, nil
+}
+
+// normalize converts value to the form rendered objects hold: maps, slices, strings,
+// bools, int64 for whole numbers and float64 otherwise
+func normalize(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rendertest
+
+import (
+	"testing"
+	"testing/fstest"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+const testCatalog = `assets:
+  - name: tuned-config
+    path: active/tuned-config.yaml.tpl
+    phase: 1
+    install: always
+    component: ConfigMap
+    scope: Namespaced
+    reconcile_order: 1
+  - name: hcp-only
+    path: active/hcp-only.yaml.tpl
+    phase: 1
+    install: always
+    component: ConfigMap
+    scope: Namespaced
+    reconcile_order: 2
+`
+
+const testTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: tuned
+  namespace: {{ .HCO.GetNamespace }}
+  annotations:
+    example.io/tuning-policy: "{{ .TuningPolicy }}"
+data:
+  mode: {{ index .HCO.GetAnnotations "example.io/mode" | default "default" | quote }}
+spec:
+  replicas: 3
+  items: [a, b]
+`
+
+func testAssets() Option {
+	return WithAssets(fstest.MapFS{
+		"active/metadata.yaml":         {Data: []byte(testCatalog)},
+		"active/tuned-config.yaml.tpl": {Data: []byte(testTemplate)},
+		"active/hcp-only.yaml.tpl": {Data: []byte(`{{- if .Topology.IsHCP }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: hcp
+  namespace: {{ .HCO.GetNamespace }}
+{{- end }}
+`)},
+	})
+}
+
+func TestRenderAssetForTest(t *testing.T) {
+	obj := RenderAssetForTest(t, "tuned-config", testAssets(),
+		WithHCOField("highBurst", "spec", "virtualization", "tuningPolicy"),
+		WithHCOAnnotations(map[string]string{"example.io/mode": "fast"}))
+
+	obj.AssertField("/metadata/namespace", pkgcontext.DefaultHCONamespace)
+	obj.AssertField("/metadata/annotations/example.io~1tuning-policy", "highBurst")
+	obj.AssertField("/data/mode", "fast")
+	obj.AssertField("/spec/replicas", 3)
+	obj.AssertField("/spec/items", []string{"a", "b"})
+	obj.AssertField("/spec/items/1", "b")
+	obj.AssertFieldContains("/metadata/annotations/example.io~1tuning-policy", "Burst")
+	obj.AssertFieldAbsent("/spec/items/2")
+	obj.AssertFieldAbsent("/spec/replicas/count")
+}
+
+func TestRenderEmbeddedAsset(t *testing.T) {
+	obj := RenderAssetForTest(t, "metrics-service", WithContext(func(ctx *pkgcontext.RenderContext) {
+		ctx.Network.IPFamilies = []string{"IPv4", "IPv6"}
+	}))
+	obj.AssertField("/kind", "Service")
+	obj.AssertField("/spec/ipFamilyPolicy", "PreferDualStack")
+	obj.AssertField("/spec/ports/0/port", 8080)
+
+	RenderAssetForTest(t, "metrics-service").AssertFieldAbsent("/spec/ipFamilyPolicy")
+}
+
+func TestAssertNotRendered(t *testing.T) {
+	AssertNotRendered(t, "hcp-only", testAssets())
+	RenderAssetForTest(t, "hcp-only", testAssets(), WithTopology(&pkgcontext.TopologyContext{IsHCP: true}))
+}
+
+func TestRenderErrors(t *testing.T) {
+	if _, err := Render("missing", testAssets()); err == nil {
+		t.Error("Render() of an asset missing from the catalog succeeded")
+	}
+	if _, err := Render("tuned-config", testAssets(), WithHCOField(func() {}, "spec", "x")); err == nil {
+		t.Error("Render() with an HCO field that is not JSON succeeded")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	obj := RenderAssetForTest(t, "tuned-config", testAssets()).Object
+	if _, _, err := Lookup(obj, "spec/replicas"); err == nil {
+		t.Error("Lookup() of a pointer without a leading / succeeded")
+	}
+	if _, _, err := Lookup(obj, "/spec/items/first"); err == nil {
+		t.Error("Lookup() of a non-numeric array index succeeded")
+	}
+	if value, found, err := Lookup(obj, "/data/mode"); err != nil || !found || value != "default" {
+		t.Errorf("Lookup(/data/mode) = %v, %v, %v, want default", value, found, err)
+	}
+}
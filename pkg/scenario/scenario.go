@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scenario loads declarative cluster fixtures: an HCO, synthetic nodes,
+// the installed CRDs and any other cluster objects the detectors read. A scenario
+// is served by an in-memory client, so detection and condition evaluation run
+// exactly as on a cluster, for the simulate CLI, tests and documentation examples.
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
+)
+
+// Scenario is a synthetic cluster
+type Scenario struct {
+	// Name identifies the scenario in output; defaults to the file name
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// HCO is the HyperConverged the autopilot reconciles
+	HCO *unstructured.Unstructured `json:"hco"`
+
+	// Nodes feed hardware, topology and PerformanceProfile detection
+	Nodes []corev1.Node `json:"nodes,omitempty"`
+
+	// CRDs names the installed CustomResourceDefinitions, e.g. machineconfigs.machineconfiguration.openshift.io
+	CRDs []string `json:"crds,omitempty"`
+
+	// Objects are further cluster objects the detectors read, such as the
+	// Infrastructure, Proxy or StorageClasses
+	Objects []unstructured.Unstructured `json:"objects,omitempty"`
+
+	// Expect optionally lists assets that must be included or excluded
+	Expect *Expectations `json:"expect,omitempty"`
+}
+
+// Expectations are the inclusion results a scenario asserts
+type Expectations struct {
+	Included []string `json:"included,omitempty"`
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// Load reads a scenario from a YAML or JSON file
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return s, nil
+}
+
+// Parse decodes a scenario document. Unknown top-level fields are rejected so a
+// misspelled section does not silently describe a different cluster.
+func Parse(data []byte) (*Scenario, error) {
+	s := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, s); err != nil {
+		return nil, err
+	}
+	if s.HCO == nil {
+		return nil, fmt.Errorf("hco is required")
+	}
+	if s.HCO.GetKind() != pkgcontext.HCOKind {
+		return nil, fmt.Errorf("hco: expected kind %s, got %q", pkgcontext.HCOKind, s.HCO.GetKind())
+	}
+	for i, obj := range s.Objects {
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("objects[%d]: apiVersion and kind are required", i)
+		}
+	}
+	return s, nil
+}
+
+// Client returns an in-memory client serving the scenario's objects
+func (s *Scenario) Client() (client.Client, error) {
+	builder, err := s.ClientBuilder()
+	if err != nil {
+		return nil, err
+	}
+	return builder.Build(), nil
+}
+
+// ClientBuilder returns a fake client builder seeded with the scenario's objects, for
+// callers that add interceptors or further objects before building
+func (s *Scenario) ClientBuilder() (*fake.ClientBuilder, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	objects := []client.Object{s.HCO.DeepCopy()}
+	for i := range s.Nodes {
+		objects = append(objects, s.Nodes[i].DeepCopy())
+	}
+	for _, name := range s.CRDs {
+		objects = append(objects, &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	for i := range s.Objects {
+		objects = append(objects, s.Objects[i].DeepCopy())
+	}
+
+	// The HyperConverged has a status subresource, as on a cluster, so condition updates persist
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(s.HCO.DeepCopy()), nil
+}
+
+// Check compares inclusion results (asset name to included) against the
+// expectations and returns one message per mismatch
+func (s *Scenario) Check(included map[string]bool) []string {
+	if s.Expect == nil {
+		return nil
+	}
+
+	var failures []string
+	for _, name := range s.Expect.Included {
+		value, known := included[name]
+		switch {
+		case !known:
+			failures = append(failures, fmt.Sprintf("expected %s to be included, but no such asset exists", name))
+		case !value:
+			failures = append(failures, fmt.Sprintf("expected %s to be included, but it is excluded", name))
+		}
+	}
+	for _, name := range s.Expect.Excluded {
+		value, known := included[name]
+		switch {
+		case !known:
+			failures = append(failures, fmt.Sprintf("expected %s to be excluded, but no such asset exists", name))
+		case value:
+			failures = append(failures, fmt.Sprintf("expected %s to be excluded, but it is included", name))
+		}
+	}
+	return failures
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testScenario = `
+description: two nodes and one CRD
+hco:
+  apiVersion: hco.kubevirt.io/v1beta1
+  kind: HyperConverged
+  metadata: {name: kubevirt-hyperconverged, namespace: openshift-cnv}
+nodes:
+  - metadata: {name: worker-0, labels: {node-role.kubernetes.io/worker: ""}}
+  - metadata: {name: worker-1}
+crds: [machineconfigs.machineconfiguration.openshift.io]
+objects:
+  - apiVersion: config.openshift.io/v1
+    kind: Infrastructure
+    metadata: {name: cluster}
+    status: {controlPlaneTopology: External}
+`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "two-workers.yaml")
+	if err := os.WriteFile(path, []byte(testScenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if s.Name != "two-workers" {
+		t.Errorf("Name = %q, want the file name", s.Name)
+	}
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatalf("Client() error = %v", err)
+	}
+	ctx := context.Background()
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil || len(nodes.Items) != 2 {
+		t.Errorf("List(nodes) = %d items, %v; want 2", len(nodes.Items), err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "machineconfigs.machineconfiguration.openshift.io"}, crd); err != nil {
+		t.Errorf("Get(crd) error = %v", err)
+	}
+	infra := &unstructured.Unstructured{}
+	infra.SetAPIVersion("config.openshift.io/v1")
+	infra.SetKind("Infrastructure")
+	if err := c.Get(ctx, types.NamespacedName{Name: "cluster"}, infra); err != nil {
+		t.Errorf("Get(infrastructure) error = %v", err)
+	}
+	hco := &unstructured.Unstructured{}
+	hco.SetGroupVersionKind(s.HCO.GroupVersionKind())
+	if err := c.Get(ctx, types.NamespacedName{Name: "kubevirt-hyperconverged", Namespace: "openshift-cnv"}, hco); err != nil {
+		t.Errorf("Get(hco) error = %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"missing hco", "crds: [a.b.c]\n", "hco is required"},
+		{"wrong hco kind", "hco: {apiVersion: v1, kind: ConfigMap}\n", "expected kind HyperConverged"},
+		{"unknown section", "hco: {apiVersion: hco.kubevirt.io/v1beta1, kind: HyperConverged}\nnode: []\n", "unknown field"},
+		{"object without apiVersion", "hco: {apiVersion: hco.kubevirt.io/v1beta1, kind: HyperConverged}\nobjects: [{kind: Infrastructure}]\n", "objects[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s := &Scenario{Expect: &Expectations{
+		Included: []string{"swap-enable", "pci-passthrough", "missing"},
+		Excluded: []string{"metallb-operator", "descheduler-loadaware"},
+	}}
+
+	got := s.Check(map[string]bool{
+		"swap-enable":           true,
+		"pci-passthrough":       false,
+		"metallb-operator":      false,
+		"descheduler-loadaware": true,
+	})
+	want := []string{
+		"expected pci-passthrough to be included, but it is excluded",
+		"expected missing to be included, but no such asset exists",
+		"expected descheduler-loadaware to be excluded, but it is included",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %q, want %q", got, want)
+	}
+
+	if got := (&Scenario{}).Check(map[string]bool{"swap-enable": false}); got != nil {
+		t.Errorf("Check() without expectations = %q, want nil", got)
+	}
+}
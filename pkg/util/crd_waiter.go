@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultCRDWaitInterval is how often CRDWaiter re-checks a CRD that is not yet established
+const defaultCRDWaitInterval = 5 * time.Second
+
+// CRDWaiter is a manager.Runnable that blocks until a CRD is established and then
+// runs a callback once, typically to set up the controllers that depend on it.
+//
+// It lets the manager start (and serve health probes) before OLM has installed a
+// required CRD, instead of exiting and racing the install in CrashLoopBackOff.
+type CRDWaiter struct {
+	client        client.Reader
+	crdName       string
+	interval      time.Duration
+	onEstablished func() error
+	ready         atomic.Bool
+}
+
+// NewCRDWaiter creates a waiter for crdName. onEstablished runs once the CRD reports
+// Established=True; if it fails, Start returns the error and the manager stops.
+func NewCRDWaiter(c client.Reader, crdName string, onEstablished func() error) *CRDWaiter {
+	return &CRDWaiter{
+		client:        c,
+		crdName:       crdName,
+		interval:      defaultCRDWaitInterval,
+		onEstablished: onEstablished,
+	}
+}
+
+// Start implements manager.Runnable
+func (w *CRDWaiter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("crd-waiter").WithValues("crd", w.crdName)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		established, err := w.isEstablished(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to check CRD, retrying")
+		}
+		if established {
+			logger.Info("CRD established")
+			if err := w.onEstablished(); err != nil {
+				return fmt.Errorf("failed to start after CRD %s was established: %w", w.crdName, err)
+			}
+			w.ready.Store(true)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Controllers registered by the callback handle leader election themselves.
+func (w *CRDWaiter) NeedLeaderElection() bool {
+	return false
+}
+
+// Ready reports whether the CRD was established and the callback succeeded
+func (w *CRDWaiter) Ready() bool {
+	return w.ready.Load()
+}
+
+// ReadyzCheck is a healthz.Checker that fails until Ready
+func (w *CRDWaiter) ReadyzCheck(_ *http.Request) error {
+	if !w.Ready() {
+		return fmt.Errorf("waiting for CRD %s to be established", w.crdName)
+	}
+	return nil
+}
+
+// isEstablished reads the CRD directly (bypassing any cache) and checks its Established condition
+func (w *CRDWaiter) isEstablished(ctx context.Context) (bool, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := w.client.Get(ctx, types.NamespacedName{Name: w.crdName}, crd); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1.Established {
+			return cond.Status == apiextensionsv1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
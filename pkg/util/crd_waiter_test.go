@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestCRD(name string, established apiextensionsv1.ConditionStatus) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: established},
+			},
+		},
+	}
+}
+
+func TestCRDWaiter_WaitsForEstablished(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	called := make(chan struct{})
+	waiter := NewCRDWaiter(fakeClient, "foos.example.com", func() error {
+		close(called)
+		return nil
+	})
+	waiter.interval = 10 * time.Millisecond
+
+	if err := waiter.ReadyzCheck(nil); err == nil {
+		t.Error("ReadyzCheck() = nil before the CRD exists, want error")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- waiter.Start(context.Background()) }()
+
+	// Created but not yet established: still waiting
+	crd := newTestCRD("foos.example.com", apiextensionsv1.ConditionFalse)
+	if err := fakeClient.Create(context.Background(), crd); err != nil {
+		t.Fatalf("failed to create CRD: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if waiter.Ready() {
+		t.Fatal("Ready() = true while the CRD is not established")
+	}
+
+	crd.Status.Conditions[0].Status = apiextensionsv1.ConditionTrue
+	if err := fakeClient.Status().Update(context.Background(), crd); err != nil {
+		t.Fatalf("failed to update CRD: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Start() = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() did not return after the CRD was established")
+	}
+	select {
+	case <-called:
+	default:
+		t.Error("onEstablished was not called")
+	}
+	if err := waiter.ReadyzCheck(nil); err != nil {
+		t.Errorf("ReadyzCheck() = %v after the CRD was established, want nil", err)
+	}
+}
+
+func TestCRDWaiter_CallbackError(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newTestCRD("foos.example.com", apiextensionsv1.ConditionTrue)).Build()
+
+	waiter := NewCRDWaiter(fakeClient, "foos.example.com", func() error {
+		return errors.New("setup failed")
+	})
+
+	if err := waiter.Start(context.Background()); err == nil {
+		t.Error("Start() = nil, want the callback error")
+	}
+	if waiter.Ready() {
+		t.Error("Ready() = true after the callback failed")
+	}
+}
+
+func TestCRDWaiter_StopsOnCancel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	waiter := NewCRDWaiter(fakeClient, "foos.example.com", func() error {
+		t.Error("onEstablished called for a missing CRD")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := waiter.Start(ctx); err != nil {
+		t.Errorf("Start() = %v on cancellation, want nil", err)
+	}
+}
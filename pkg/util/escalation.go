@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// FailureEscalationThreshold is the number of failures in a streak from which a
+	// failure is recorded as a Warning event; earlier ones are Normal
+	FailureEscalationThreshold = 2
+
+	// FailureEscalationWindow ends a failure streak when no failure repeats within it.
+	// It outlasts the longest retry backoff and the periodic resync, so a failure that
+	// persists across retries keeps its streak.
+	FailureEscalationWindow = 30 * time.Minute
+)
+
+// failureStreak tracks repeated failures of one action on one object
+type failureStreak struct {
+	count int
+	first time.Time
+	last  time.Time
+}
+
+// failureStreaks escalates failure events: a one-off failure, often fixed by the next
+// retry, is informational, while one that keeps happening deserves a Warning that
+// alerts can fire on. It is safe for concurrent use and its zero value is ready.
+type failureStreaks struct {
+	mu      sync.Mutex
+	streaks map[string]*failureStreak
+	now     func() time.Time // for tests
+}
+
+// record counts a failure and returns the event type to use and a suffix for the
+// event note, which summarises the streak once it escalates
+func (f *failureStreaks) record(object runtime.Object, action string) (string, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.now != nil {
+		now = f.now()
+	}
+	if f.streaks == nil {
+		f.streaks = make(map[string]*failureStreak)
+	}
+	// Drop streaks that ended, so the map only holds failures still going on
+	for key, streak := range f.streaks {
+		if now.Sub(streak.last) > FailureEscalationWindow {
+			delete(f.streaks, key)
+		}
+	}
+
+	key := streakKey(object, action)
+	streak, ok := f.streaks[key]
+	if !ok {
+		streak = &failureStreak{first: now}
+		f.streaks[key] = streak
+	}
+	streak.count++
+	streak.last = now
+
+	if streak.count < FailureEscalationThreshold {
+		return EventTypeNormal, ""
+	}
+	return EventTypeWarning, fmt.Sprintf(" (failed %d times since %s)", streak.count, streak.first.UTC().Format(time.RFC3339))
+}
+
+// reset ends the streaks of actions on object after they succeeded
+func (f *failureStreaks) reset(object runtime.Object, actions ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, action := range actions {
+		delete(f.streaks, streakKey(object, action))
+	}
+}
+
+// streakKey identifies an action on an object; the action already names the asset or resource
+func streakKey(object runtime.Object, action string) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return action
+	}
+	return fmt.Sprintf("%s/%s/%s|%s", object.GetObjectKind().GroupVersionKind().Kind, accessor.GetNamespace(), accessor.GetName(), action)
+}
+
+// failuref records a failure event whose type escalates with repetition; the note
+// always carries the most recent error
+func (e *EventRecorder) failuref(object, related runtime.Object, reason, action, note string, args ...any) {
+	eventType, streak := e.failures.record(object, action)
+	e.recorder.Eventf(object, related, eventType, reason, action, note+"%s", append(args, streak)...)
+}
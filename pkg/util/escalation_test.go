@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
+
+func newEscalationRecorder(now *time.Time) (*EventRecorder, *eventtest.Recorder) {
+	fake := eventtest.NewRecorder()
+	recorder := NewEventRecorder(fake)
+	recorder.failures.now = func() time.Time { return *now }
+	return recorder, fake
+}
+
+func escalationObject(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetKind("HyperConverged")
+	obj.SetNamespace("openshift-cnv")
+	obj.SetName(name)
+	return obj
+}
+
+func TestFailureEscalation_RepeatedFailureBecomesWarning(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recorder, fake := newEscalationRecorder(&now)
+	hco := escalationObject("kubevirt-hyperconverged")
+
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+	if event := fake.LastEvent(); event.EventType != EventTypeNormal || strings.Contains(event.Message, "failed 1 times") {
+		t.Errorf("Expected a plain normal event for the first failure, got %+v", event)
+	}
+
+	now = now.Add(time.Minute)
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+	now = now.Add(time.Minute)
+	recorder.ApplyFailed(hco, "swap-enable", "connection refused")
+
+	event := fake.LastEvent()
+	if event.EventType != EventTypeWarning {
+		t.Fatalf("Expected a warning event, got %s", event.EventType)
+	}
+	want := "Failed to apply asset swap-enable: connection refused (failed 3 times since 2026-01-01T12:00:00Z)"
+	if event.Message != want {
+		t.Errorf("Expected message %q, got %q", want, event.Message)
+	}
+}
+
+func TestFailureEscalation_StreaksAreIndependent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recorder, fake := newEscalationRecorder(&now)
+	hco := escalationObject("kubevirt-hyperconverged")
+
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+	recorder.ApplyFailed(hco, "kubelet-config", "webhook denied")
+	recorder.RenderFailed(hco, "swap-enable", "template error")
+	recorder.ApplyFailed(escalationObject("other"), "swap-enable", "webhook denied")
+
+	for _, event := range fake.Events() {
+		if event.EventType != EventTypeNormal {
+			t.Errorf("Expected first failures of distinct streaks to be normal, got %+v", event)
+		}
+	}
+}
+
+func TestFailureEscalation_StreakEndsAfterWindow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recorder, fake := newEscalationRecorder(&now)
+	hco := escalationObject("kubevirt-hyperconverged")
+
+	recorder.ApplyTimedOut(hco, "swap-enable", "deadline exceeded")
+	now = now.Add(FailureEscalationWindow + time.Second)
+	recorder.ApplyTimedOut(hco, "swap-enable", "deadline exceeded")
+
+	if event := fake.LastEvent(); event.EventType != EventTypeNormal {
+		t.Errorf("Expected a failure after the window to start a new streak, got %s", event.EventType)
+	}
+}
+
+func TestFailureEscalation_SuccessEndsStreak(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recorder, fake := newEscalationRecorder(&now)
+	hco := escalationObject("kubevirt-hyperconverged")
+
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+	recorder.RenderFailed(hco, "swap-enable", "template error")
+	recorder.AssetApplied(hco, "swap-enable", "MachineConfig", "", "50-swap-enable")
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+	recorder.RenderFailed(hco, "swap-enable", "template error")
+
+	for _, event := range fake.Events() {
+		if event.EventType != EventTypeNormal {
+			t.Errorf("Expected failures after a success to start new streaks, got %+v", event)
+		}
+	}
+}
+
+func TestFailureEscalation_ZeroValueRecorder(t *testing.T) {
+	fake := eventtest.NewRecorder()
+	recorder := &EventRecorder{recorder: fake}
+	hco := escalationObject("kubevirt-hyperconverged")
+
+	recorder.AssetApplied(hco, "swap-enable", "MachineConfig", "", "50-swap-enable")
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+	recorder.ApplyFailed(hco, "swap-enable", "webhook denied")
+
+	if event := fake.LastEvent(); event.EventType != EventTypeWarning {
+		t.Errorf("Expected a warning event, got %s", event.EventType)
+	}
+}
@@ -18,6 +18,8 @@ package util
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -41,13 +43,24 @@ const (
 	EventReasonPatchApplied       = "PatchApplied"
 	EventReasonReconcileSucceeded = "ReconcileSucceeded"
 	EventReasonCRDDiscovered      = "CRDDiscovered"
+	EventReasonAdopted            = "Adopted"
+	EventReasonLabelRepaired      = "LabelRepaired"
+	EventReasonCanarySucceeded    = "CanaryRolloutSucceeded"
+	EventReasonObjectRecreated    = "ObjectRecreated"
 
 	// Informational events
-	EventReasonAssetSkipped    = "AssetSkipped"
-	EventReasonNoDriftDetected = "NoDriftDetected"
-	EventReasonUnmanagedMode   = "UnmanagedMode"
-
-	// Warning events
+	EventReasonAssetSkipped           = "AssetSkipped"
+	EventReasonNoDriftDetected        = "NoDriftDetected"
+	EventReasonUnmanagedMode          = "UnmanagedMode"
+	EventReasonOwnershipDelegated     = "OwnershipDelegated"
+	EventReasonHardwarePendingRemoval = "HardwarePendingRemoval"
+	EventReasonApplyDeferred          = "ApplyDeferred"
+	EventReasonRebootImpact           = "RebootImpactPredicted"
+	EventReasonFieldsDropped          = "RenderedFieldsDropped"
+	EventReasonOverrideExpired        = "OverrideExpired"
+	EventReasonCanaryStarted          = "CanaryRolloutStarted"
+
+	// Warning events; the failure reasons are Normal until the failure repeats
 	EventReasonDriftDetected           = "DriftDetected"
 	EventReasonThrottled               = "Throttled"
 	EventReasonThrashingDetected       = "ThrashingDetected"
@@ -57,6 +70,17 @@ const (
 	EventReasonApplyFailed             = "ApplyFailed"
 	EventReasonRenderFailed            = "RenderFailed"
 	EventReasonHardwareDetectionFailed = "HardwareDetectionFailed"
+	EventReasonDeprecatedAsset         = "DeprecatedAsset"
+	EventReasonRenderWarning           = "RenderWarning"
+	EventReasonBlastRadiusExceeded     = "BlastRadiusExceeded"
+	EventReasonApplyTimeout            = "ApplyTimeout"
+	EventReasonLabelMissing            = "LabelMissing"
+	EventReasonCanaryFailed            = "CanaryRolloutFailed"
+	EventReasonConditionFailed         = "ConditionFailed"
+	EventReasonApplyConflict           = "ApplyConflict"
+	EventReasonDependencyMissing       = "DependencyMissing"
+	EventReasonRecreationCooldown      = "RecreationCooldown"
+	EventReasonAdmissionUnavailable    = "AdmissionUnavailable"
 
 	// Tombstone events
 	EventReasonTombstoneDeleted = "TombstoneDeleted"
@@ -64,9 +88,12 @@ const (
 	EventReasonTombstoneSkipped = "TombstoneSkipped"
 )
 
-// EventRecorder wraps the Kubernetes event recorder with helper methods
+// EventRecorder wraps the Kubernetes event recorder with helper methods.
+// Failure events start as Normal and escalate to Warning when the failure repeats
+// (see FailureEscalationThreshold), so Warning events are worth alerting on.
 type EventRecorder struct {
 	recorder events.EventRecorder
+	failures failureStreaks
 }
 
 // NewEventRecorder creates a new event recorder
@@ -88,16 +115,40 @@ func assetNameAction(reason, assetName string) string {
 	return fmt.Sprintf("%s %s", reason, assetName)
 }
 
+// assetFailureReasons are the reasons an asset failure is recorded with (see AssetFailed)
+var assetFailureReasons = []string{
+	EventReasonRenderFailed,
+	EventReasonConditionFailed,
+	EventReasonApplyConflict,
+	EventReasonDependencyMissing,
+	EventReasonApplyTimeout,
+	EventReasonApplyFailed,
+}
+
 // AssetApplied records that an asset was successfully applied
 func (e *EventRecorder) AssetApplied(object runtime.Object, assetName, kind, namespace, name string) {
+	actions := make([]string, 0, len(assetFailureReasons))
+	for _, reason := range assetFailureReasons {
+		actions = append(actions, assetNameAction(reason, assetName))
+	}
+	e.failures.reset(object, actions...)
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonAssetApplied, assetAction(EventReasonAssetApplied, kind, namespace, name),
 		"Applied asset %s: %s/%s/%s", assetName, kind, namespace, name)
 }
 
-// DriftCorrected records that drift was detected and corrected
-func (e *EventRecorder) DriftCorrected(object runtime.Object, kind, namespace, name string) {
+// DriftCorrected records that drift was detected and corrected. modifiedBy names the
+// field managers that changed the object, or is empty when none is known.
+func (e *EventRecorder) DriftCorrected(object runtime.Object, kind, namespace, name, modifiedBy string) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonDriftCorrected, assetAction(EventReasonDriftCorrected, kind, namespace, name),
-		"Corrected drift for %s/%s/%s", kind, namespace, name)
+		"Corrected drift for %s/%s/%s%s", kind, namespace, name, modifiedBySuffix(modifiedBy))
+}
+
+// modifiedBySuffix is the message suffix naming who caused a drift
+func modifiedBySuffix(modifiedBy string) string {
+	if modifiedBy == "" {
+		return ""
+	}
+	return " made by " + modifiedBy
 }
 
 // DriftDetected records that drift was detected (warning)
@@ -106,16 +157,16 @@ func (e *EventRecorder) DriftDetected(object runtime.Object, kind, namespace, na
 		"Drift detected for %s/%s/%s", kind, namespace, name)
 }
 
-// PatchApplied records that a user JSON patch was applied
+// PatchApplied records that a user patch was applied
 func (e *EventRecorder) PatchApplied(object runtime.Object, kind, namespace, name string, operations int) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonPatchApplied, assetAction(EventReasonPatchApplied, kind, namespace, name),
-		"Applied %d JSON patch operation(s) to %s/%s/%s", operations, kind, namespace, name)
+		"Applied %d patch operation(s) to %s/%s/%s", operations, kind, namespace, name)
 }
 
-// InvalidPatch records that a user's JSON patch was invalid
+// InvalidPatch records that a user's patch was invalid
 func (e *EventRecorder) InvalidPatch(object runtime.Object, kind, namespace, name, reason string) {
 	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonInvalidPatch, assetAction(EventReasonInvalidPatch, kind, namespace, name),
-		"Invalid JSON patch for %s/%s/%s: %s", kind, namespace, name, reason)
+		"Invalid patch for %s/%s/%s: %s", kind, namespace, name, reason)
 }
 
 // InvalidIgnoreFields records that ignore-fields annotation was invalid
@@ -142,18 +193,55 @@ func (e *EventRecorder) ThrashingDetected(object runtime.Object, kind, namespace
 		"platform.kubevirt.io/mode")
 }
 
+// ObjectRecreated records that a managed object deleted outside the autopilot was
+// created again; detail counts the recreations and hints at who changed it last
+func (e *EventRecorder) ObjectRecreated(object runtime.Object, kind, namespace, name, detail string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonObjectRecreated, assetAction(EventReasonObjectRecreated, kind, namespace, name),
+		"Recreated %s/%s/%s after it was deleted (%s). The deleting user is recorded in the API server audit log.",
+		kind, namespace, name, detail)
+}
+
+// RecreationCooldown records that a managed object keeps being deleted and is left
+// deleted until its recreation cool-down ends
+func (e *EventRecorder) RecreationCooldown(object runtime.Object, kind, namespace, name, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonRecreationCooldown, assetAction(EventReasonRecreationCooldown, kind, namespace, name),
+		"%s/%s/%s keeps being deleted: %s. If it is meant to stay deleted, exclude it with an AutopilotExclusion "+
+			"or the '%s' annotation.",
+		kind, namespace, name, reason, "platform.kubevirt.io/disabled-resources")
+}
+
+// AdmissionUnavailable records that the API server could not get an object admitted,
+// typically because of a down or slow admission webhook, and when it is retried
+func (e *EventRecorder) AdmissionUnavailable(object runtime.Object, kind, namespace, name, retryIn, message string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonAdmissionUnavailable, assetAction(EventReasonAdmissionUnavailable, kind, namespace, name),
+		"Admission of %s/%s/%s unavailable, retrying in %s: %s", kind, namespace, name, retryIn, message)
+}
+
 // AssetSkipped records that an asset was skipped (conditions not met)
 func (e *EventRecorder) AssetSkipped(object runtime.Object, assetName, reason string) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonAssetSkipped, assetNameAction(EventReasonAssetSkipped, assetName),
 		"Skipped asset %s: %s", assetName, reason)
 }
 
+// RenderWarning records a warning the template of an asset emitted while rendering
+func (e *EventRecorder) RenderWarning(object runtime.Object, assetName, message string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonRenderWarning, assetNameAction(EventReasonRenderWarning, assetName),
+		"Asset %s: %s", assetName, message)
+}
+
 // UnmanagedMode records that a resource is in unmanaged mode
 func (e *EventRecorder) UnmanagedMode(object runtime.Object, kind, namespace, name string) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonUnmanagedMode, assetAction(EventReasonUnmanagedMode, kind, namespace, name),
 		"Resource %s/%s/%s is in unmanaged mode, skipping reconciliation", kind, namespace, name)
 }
 
+// OwnershipDelegated records that the autopilot handed its fields of a resource over to
+// another field manager and stopped reconciling it
+func (e *EventRecorder) OwnershipDelegated(object runtime.Object, kind, namespace, name, manager string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonOwnershipDelegated, assetAction(EventReasonOwnershipDelegated, kind, namespace, name),
+		"Resource %s/%s/%s delegated, field ownership transferred to %s", kind, namespace, name, manager)
+}
+
 // CRDMissing records that a required CRD is missing (soft dependency)
 func (e *EventRecorder) CRDMissing(object runtime.Object, component, crdName string) {
 	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonCRDMissing, assetNameAction(EventReasonCRDMissing, crdName),
@@ -168,13 +256,21 @@ func (e *EventRecorder) CRDDiscovered(object runtime.Object, component, crdName
 
 // ApplyFailed records that applying an asset failed
 func (e *EventRecorder) ApplyFailed(object runtime.Object, assetName, reason string) {
-	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonApplyFailed, assetNameAction(EventReasonApplyFailed, assetName),
+	e.failuref(object, nil, EventReasonApplyFailed, assetNameAction(EventReasonApplyFailed, assetName),
 		"Failed to apply asset %s: %s", assetName, reason)
 }
 
+// AssetFailed records that reconciling an asset failed. reason classifies the failure
+// (RenderFailed, ConditionFailed, ApplyConflict, DependencyMissing, ApplyTimeout or
+// ApplyFailed), so events of one kind of failure can be selected across assets.
+func (e *EventRecorder) AssetFailed(object runtime.Object, assetName, reason, message string) {
+	e.failuref(object, nil, reason, assetNameAction(reason, assetName),
+		"Failed to reconcile asset %s (%s): %s", assetName, reason, message)
+}
+
 // RenderFailed records that rendering an asset template failed
 func (e *EventRecorder) RenderFailed(object runtime.Object, assetName, reason string) {
-	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonRenderFailed, assetNameAction(EventReasonRenderFailed, assetName),
+	e.failuref(object, nil, EventReasonRenderFailed, assetNameAction(EventReasonRenderFailed, assetName),
 		"Failed to render asset %s: %s", assetName, reason)
 }
 
@@ -192,10 +288,135 @@ func (e *EventRecorder) NoDriftDetected(object runtime.Object, kind, namespace,
 
 // HardwareDetectionFailed records that hardware detection failed (using defaults)
 func (e *EventRecorder) HardwareDetectionFailed(object runtime.Object, reason string) {
-	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonHardwareDetectionFailed, "HardwareDetectionFailed",
+	e.failuref(object, nil, EventReasonHardwareDetectionFailed, "HardwareDetectionFailed",
 		"Hardware detection failed, using defaults: %s", reason)
 }
 
+// HardwarePendingRemoval records that a hardware detector is no longer satisfied by any
+// node but its assets are kept until the churn damping grace period ends
+func (e *EventRecorder) HardwarePendingRemoval(object runtime.Object, detector string, until time.Time) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonHardwarePendingRemoval, assetNameAction(EventReasonHardwarePendingRemoval, detector),
+		"Hardware %s no longer detected; keeping dependent assets until %s", detector, until.UTC().Format(time.RFC3339))
+}
+
+// OverrideExpired records that a temporary exclusion or patch passed its expiry, so the
+// autopilot manages the resources it covered again
+func (e *EventRecorder) OverrideExpired(object runtime.Object, source, entry string, expired time.Time) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonOverrideExpired, assetNameAction(EventReasonOverrideExpired, source+" "+entry),
+		"%s %s expired at %s; its resources are managed again", source, entry, expired.UTC().Format(time.RFC3339))
+}
+
+// ApplyDeferred records that applying a reboot-triggering resource is held back until
+// the cluster upgrade or MachineConfigPool rollout completes
+func (e *EventRecorder) ApplyDeferred(object runtime.Object, kind, namespace, name, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonApplyDeferred, assetAction(EventReasonApplyDeferred, kind, namespace, name),
+		"Deferred %s/%s/%s until the cluster is stable: %s", kind, namespace, name, reason)
+}
+
+// RebootImpactPredicted records which MachineConfigPools a batch of reboot-triggering
+// changes is about to roll out to, before it is applied
+func (e *EventRecorder) RebootImpactPredicted(object runtime.Object, changes, nodes int, pools, fingerprint string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonRebootImpact, assetNameAction(EventReasonRebootImpact, fingerprint),
+		"Applying %d node-rebooting change(s) rolls out to %s: about %d node(s) will drain and reboot", changes, pools, nodes)
+}
+
+// BlastRadiusExceeded records that a reconcile would have made more changes of one kind
+// than allowed (e.g. "modify 6 node-rebooting resources"), and that the whole batch is
+// held back until acknowledged on the HCO
+func (e *EventRecorder) BlastRadiusExceeded(object runtime.Object, change string, limit int, resources, ackAnnotation, fingerprint string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonBlastRadiusExceeded, assetNameAction(EventReasonBlastRadiusExceeded, fingerprint),
+		"CRITICAL: reconcile would %s (limit %d): %s. Nothing was changed. "+
+			"If this is intended, annotate the HyperConverged with %s=%s to proceed.",
+		change, limit, resources, ackAnnotation, fingerprint)
+}
+
+// CanaryRolloutStarted records that a batch of reboot-triggering changes is applied while
+// every pool it rolls out to but the canary pool is paused
+func (e *EventRecorder) CanaryRolloutStarted(object runtime.Object, pool string, changes int, paused, fingerprint string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonCanaryStarted, assetNameAction(EventReasonCanaryStarted, fingerprint),
+		"Rolling out %d node-rebooting change(s) to canary pool %s first; paused %s until it is updated", changes, pool, paused)
+}
+
+// CanaryRolloutSucceeded records that the canary pool updated and the paused pools were resumed
+func (e *EventRecorder) CanaryRolloutSucceeded(object runtime.Object, pool, resumed, fingerprint string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonCanarySucceeded, assetNameAction(EventReasonCanarySucceeded, fingerprint),
+		"Canary pool %s updated; resumed %s", pool, resumed)
+}
+
+// CanaryRolloutFailed records that a canary rollout failed and its changes were rolled back.
+// The paused pools stay paused until the canary pool is back on its previous config.
+func (e *EventRecorder) CanaryRolloutFailed(object runtime.Object, pool, reason string, changes int, paused, fingerprint string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonCanaryFailed, assetNameAction(EventReasonCanaryFailed, fingerprint),
+		"Canary rollout to pool %s failed: %s. Rolled back %d change(s); %s stay paused until %s is back on its previous config. "+
+			"The batch is not retried until it changes.", pool, reason, changes, paused, pool)
+}
+
+// ApplyTimedOut records that reconciling an asset was cancelled by the apply timeouts
+func (e *EventRecorder) ApplyTimedOut(object runtime.Object, assetName, reason string) {
+	e.failuref(object, nil, EventReasonApplyTimeout, assetNameAction(EventReasonApplyTimeout, assetName),
+		"Reconcile of asset %s timed out: %s", assetName, reason)
+}
+
+// RenderedFieldsDropped records that an apply stopped setting fields the asset template
+// used to render (JSON Pointers); server-side apply removes those the autopilot owned alone
+func (e *EventRecorder) RenderedFieldsDropped(object runtime.Object, assetName, kind, namespace, name string, fields []string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonFieldsDropped, assetAction(EventReasonFieldsDropped, kind, namespace, name),
+		"Asset %s no longer renders %d field(s) of %s/%s/%s: %s", assetName, len(fields), kind, namespace, name, fieldList(fields))
+}
+
+// maxListedFields bounds the fields named in one event message
+const maxListedFields = 10
+
+// fieldList joins the first maxListedFields fields and counts the rest
+func fieldList(fields []string) string {
+	if len(fields) <= maxListedFields {
+		return strings.Join(fields, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(fields[:maxListedFields], ", "), len(fields)-maxListedFields)
+}
+
+// DeprecatedAsset records that a deprecated asset was applied
+func (e *EventRecorder) DeprecatedAsset(object runtime.Object, assetName, notice string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonDeprecatedAsset, assetNameAction(EventReasonDeprecatedAsset, assetName),
+		"Applied deprecated asset: %s", notice)
+}
+
+// The Object* methods record events on the managed resource itself, with the HCO as the
+// related object, so `oc describe` on e.g. a MachineConfig shows what the autopilot did to it.
+
+// ObjectDriftCorrected records on a managed resource that its drift was reverted
+func (e *EventRecorder) ObjectDriftCorrected(object, hco runtime.Object, assetName, modifiedBy string) {
+	e.failures.reset(object, EventReasonApplyFailed)
+	e.recorder.Eventf(object, hco, EventTypeNormal, EventReasonDriftCorrected, EventReasonDriftCorrected,
+		"virt-platform-autopilot reverted drift%s from asset %s", modifiedBySuffix(modifiedBy), assetName)
+}
+
+// ObjectApplyFailed records on a managed resource that applying its asset failed
+func (e *EventRecorder) ObjectApplyFailed(object, hco runtime.Object, assetName, reason string) {
+	e.failuref(object, hco, EventReasonApplyFailed, EventReasonApplyFailed,
+		"virt-platform-autopilot failed to apply asset %s: %s", assetName, reason)
+}
+
+// ObjectLabelRepaired records on a resource the autopilot applied that its stripped
+// managed-by label was restored
+func (e *EventRecorder) ObjectLabelRepaired(object runtime.Object, label string) {
+	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonLabelRepaired, EventReasonLabelRepaired,
+		"Restored the %s label removed from this resource", label)
+}
+
+// ObjectLabelMissing records on a resource the autopilot applied that it lacks the
+// managed-by label and was left as is
+func (e *EventRecorder) ObjectLabelMissing(object runtime.Object, label, reason string) {
+	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonLabelMissing, EventReasonLabelMissing,
+		"Resource applied by virt-platform-autopilot lacks the %s label: %s", label, reason)
+}
+
+// ObjectAdopted records on a pre-existing resource that it is now managed by the autopilot
+func (e *EventRecorder) ObjectAdopted(object, hco runtime.Object, assetName string) {
+	e.recorder.Eventf(object, hco, EventTypeNormal, EventReasonAdopted, EventReasonAdopted,
+		"virt-platform-autopilot adopted this resource for asset %s", assetName)
+}
+
 // TombstoneDeleted records that a tombstoned resource was successfully deleted
 func (e *EventRecorder) TombstoneDeleted(object runtime.Object, kind, namespace, name, path string) {
 	e.recorder.Eventf(object, nil, EventTypeNormal, EventReasonTombstoneDeleted, assetAction(EventReasonTombstoneDeleted, kind, namespace, name),
@@ -204,7 +425,7 @@ func (e *EventRecorder) TombstoneDeleted(object runtime.Object, kind, namespace,
 
 // TombstoneFailed records that tombstone deletion failed
 func (e *EventRecorder) TombstoneFailed(object runtime.Object, kind, namespace, name, reason string) {
-	e.recorder.Eventf(object, nil, EventTypeWarning, EventReasonTombstoneFailed, assetAction(EventReasonTombstoneFailed, kind, namespace, name),
+	e.failuref(object, nil, EventReasonTombstoneFailed, assetAction(EventReasonTombstoneFailed, kind, namespace, name),
 		"Failed to delete tombstoned resource %s/%s/%s: %s", kind, namespace, name, reason)
 }
 
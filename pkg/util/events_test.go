@@ -17,58 +17,16 @@ limitations under the License.
 package util
 
 import (
-	"fmt"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/events"
-	"k8s.io/klog/v2"
-)
-
-// FakeRecorder implements events.EventRecorder for testing
-type FakeRecorder struct {
-	Events []Event
-}
-
-type Event struct {
-	EventType string
-	Reason    string
-	Action    string
-	Message   string
-}
-
-func (f *FakeRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...any) {
-	message := fmt.Sprintf(note, args...)
-	f.Events = append(f.Events, Event{
-		EventType: eventtype,
-		Reason:    reason,
-		Action:    action,
-		Message:   message,
-	})
-}
-
-func (f *FakeRecorder) Reset() {
-	f.Events = []Event{}
-}
-
-func (f *FakeRecorder) LastEvent() *Event {
-	if len(f.Events) == 0 {
-		return nil
-	}
-	return &f.Events[len(f.Events)-1]
-}
 
-// WithLogger returns the same recorder (not needed for testing)
-func (f *FakeRecorder) WithLogger(logger klog.Logger) events.EventRecorderLogger {
-	return f
-}
-
-// Ensure FakeRecorder implements EventRecorderLogger interface
-var _ events.EventRecorderLogger = &FakeRecorder{}
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
+)
 
 func TestEventRecorder_AssetApplied(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -76,11 +34,11 @@ func TestEventRecorder_AssetApplied(t *testing.T) {
 
 	recorder.AssetApplied(obj, "test-asset", "ConfigMap", "default", "my-config")
 
-	if len(fake.Events) != 1 {
-		t.Fatalf("Expected 1 event, got %d", len(fake.Events))
+	if len(fake.Events()) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(fake.Events()))
 	}
 
-	event := fake.Events[0]
+	event := fake.Events()[0]
 	if event.EventType != EventTypeNormal {
 		t.Errorf("Expected EventType=%s, got %s", EventTypeNormal, event.EventType)
 	}
@@ -96,7 +54,7 @@ func TestEventRecorder_AssetApplied(t *testing.T) {
 }
 
 func TestEventRecorder_DriftDetected(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -119,11 +77,11 @@ func TestEventRecorder_DriftDetected(t *testing.T) {
 }
 
 func TestEventRecorder_DriftCorrected(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
-	recorder.DriftCorrected(obj, "ConfigMap", "default", "config")
+	recorder.DriftCorrected(obj, "ConfigMap", "default", "config", "")
 
 	event := fake.LastEvent()
 	if event == nil {
@@ -142,7 +100,7 @@ func TestEventRecorder_DriftCorrected(t *testing.T) {
 }
 
 func TestEventRecorder_PatchApplied(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -165,7 +123,7 @@ func TestEventRecorder_PatchApplied(t *testing.T) {
 }
 
 func TestEventRecorder_InvalidPatch(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -188,7 +146,7 @@ func TestEventRecorder_InvalidPatch(t *testing.T) {
 }
 
 func TestEventRecorder_Throttled(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -211,7 +169,7 @@ func TestEventRecorder_Throttled(t *testing.T) {
 }
 
 func TestEventRecorder_UnmanagedMode(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -233,8 +191,31 @@ func TestEventRecorder_UnmanagedMode(t *testing.T) {
 	}
 }
 
+func TestEventRecorder_OwnershipDelegated(t *testing.T) {
+	fake := eventtest.NewRecorder()
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.OwnershipDelegated(obj, "ConfigMap", "default", "config", "argocd-controller")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+
+	if event.EventType != EventTypeNormal {
+		t.Errorf("Expected normal event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonOwnershipDelegated {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonOwnershipDelegated, event.Reason)
+	}
+	if !strings.Contains(event.Message, "argocd-controller") {
+		t.Errorf("Expected message to name the new field manager, got %s", event.Message)
+	}
+}
+
 func TestEventRecorder_CRDMissing(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -257,7 +238,7 @@ func TestEventRecorder_CRDMissing(t *testing.T) {
 }
 
 func TestEventRecorder_CRDDiscovered(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -280,19 +261,19 @@ func TestEventRecorder_CRDDiscovered(t *testing.T) {
 }
 
 func TestEventRecorder_ApplyFailed(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
 	recorder.ApplyFailed(obj, "test-asset", "validation error")
-
-	event := fake.LastEvent()
-	if event == nil {
-		t.Fatal("Expected event to be recorded")
+	if event := fake.LastEvent(); event == nil || event.EventType != EventTypeNormal {
+		t.Fatalf("Expected a first failure to be a normal event, got %+v", event)
 	}
+	recorder.ApplyFailed(obj, "test-asset", "validation error")
 
+	event := fake.LastEvent()
 	if event.EventType != EventTypeWarning {
-		t.Errorf("Expected warning event, got %s", event.EventType)
+		t.Errorf("Expected a repeated failure to be a warning event, got %s", event.EventType)
 	}
 	if event.Reason != EventReasonApplyFailed {
 		t.Errorf("Expected Reason=%s, got %s", EventReasonApplyFailed, event.Reason)
@@ -302,20 +283,52 @@ func TestEventRecorder_ApplyFailed(t *testing.T) {
 	}
 }
 
-func TestEventRecorder_RenderFailed(t *testing.T) {
-	fake := &FakeRecorder{}
+func TestEventRecorder_AssetFailed(t *testing.T) {
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
-	recorder.RenderFailed(obj, "test-asset", "template error")
+	recorder.AssetFailed(obj, "test-asset", EventReasonApplyConflict, "field owned by another manager")
+	recorder.AssetFailed(obj, "test-asset", EventReasonDependencyMissing, "no matches for kind NMState")
 
+	// Each reason is its own streak
+	if got := fake.Count(EventReasonApplyConflict); got != 1 {
+		t.Errorf("Expected 1 %s event, got %d", EventReasonApplyConflict, got)
+	}
 	event := fake.LastEvent()
-	if event == nil {
-		t.Fatal("Expected event to be recorded")
+	if event.EventType != EventTypeNormal {
+		t.Errorf("Expected the first failure of a reason to be a normal event, got %s", event.EventType)
+	}
+	if expected := "DependencyMissing test-asset"; event.Action != expected {
+		t.Errorf("Expected Action=%s, got %s", expected, event.Action)
+	}
+
+	// A successful apply ends the streaks of every reason
+	recorder.AssetFailed(obj, "test-asset", EventReasonApplyConflict, "field owned by another manager")
+	if event := fake.LastEvent(); event.EventType != EventTypeWarning {
+		t.Errorf("Expected a repeated failure to be a warning event, got %s", event.EventType)
+	}
+	recorder.AssetApplied(obj, "test-asset", "NMState", "", "instance")
+	recorder.AssetFailed(obj, "test-asset", EventReasonApplyConflict, "field owned by another manager")
+	if event := fake.LastEvent(); event.EventType != EventTypeNormal {
+		t.Errorf("Expected a failure after a successful apply to be a normal event, got %s", event.EventType)
+	}
+}
+
+func TestEventRecorder_RenderFailed(t *testing.T) {
+	fake := eventtest.NewRecorder()
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.RenderFailed(obj, "test-asset", "template error")
+	if event := fake.LastEvent(); event == nil || event.EventType != EventTypeNormal {
+		t.Fatalf("Expected a first failure to be a normal event, got %+v", event)
 	}
+	recorder.RenderFailed(obj, "test-asset", "template error")
 
+	event := fake.LastEvent()
 	if event.EventType != EventTypeWarning {
-		t.Errorf("Expected warning event, got %s", event.EventType)
+		t.Errorf("Expected a repeated failure to be a warning event, got %s", event.EventType)
 	}
 	if event.Reason != EventReasonRenderFailed {
 		t.Errorf("Expected Reason=%s, got %s", EventReasonRenderFailed, event.Reason)
@@ -326,7 +339,7 @@ func TestEventRecorder_RenderFailed(t *testing.T) {
 }
 
 func TestEventRecorder_ReconcileSucceeded(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -346,7 +359,7 @@ func TestEventRecorder_ReconcileSucceeded(t *testing.T) {
 }
 
 func TestEventRecorder_AssetSkipped(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -368,8 +381,30 @@ func TestEventRecorder_AssetSkipped(t *testing.T) {
 	}
 }
 
+func TestEventRecorder_RenderWarning(t *testing.T) {
+	fake := eventtest.NewRecorder()
+	recorder := NewEventRecorder(fake)
+
+	obj := &unstructured.Unstructured{}
+	recorder.RenderWarning(obj, "metallb-address-pool", "address pool 10.0.0.0/28 is outside the machine networks 192.168.111.0/24")
+
+	event := fake.LastEvent()
+	if event == nil {
+		t.Fatal("Expected event to be recorded")
+	}
+	if event.EventType != EventTypeWarning {
+		t.Errorf("Expected warning event, got %s", event.EventType)
+	}
+	if event.Reason != EventReasonRenderWarning {
+		t.Errorf("Expected Reason=%s, got %s", EventReasonRenderWarning, event.Reason)
+	}
+	if !strings.Contains(event.Message, "outside the machine networks") {
+		t.Errorf("Expected message to carry the warning, got %q", event.Message)
+	}
+}
+
 func TestEventRecorder_MultipleEvents(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -377,26 +412,26 @@ func TestEventRecorder_MultipleEvents(t *testing.T) {
 	// Record multiple events
 	recorder.DriftDetected(obj, "ConfigMap", "default", "config")
 	recorder.AssetApplied(obj, "asset1", "ConfigMap", "default", "config")
-	recorder.DriftCorrected(obj, "ConfigMap", "default", "config")
+	recorder.DriftCorrected(obj, "ConfigMap", "default", "config", "")
 
-	if len(fake.Events) != 3 {
-		t.Fatalf("Expected 3 events, got %d", len(fake.Events))
+	if len(fake.Events()) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(fake.Events()))
 	}
 
 	// Verify event order
-	if fake.Events[0].Reason != EventReasonDriftDetected {
-		t.Errorf("First event should be DriftDetected, got %s", fake.Events[0].Reason)
+	if fake.Events()[0].Reason != EventReasonDriftDetected {
+		t.Errorf("First event should be DriftDetected, got %s", fake.Events()[0].Reason)
 	}
-	if fake.Events[1].Reason != EventReasonAssetApplied {
-		t.Errorf("Second event should be AssetApplied, got %s", fake.Events[1].Reason)
+	if fake.Events()[1].Reason != EventReasonAssetApplied {
+		t.Errorf("Second event should be AssetApplied, got %s", fake.Events()[1].Reason)
 	}
-	if fake.Events[2].Reason != EventReasonDriftCorrected {
-		t.Errorf("Third event should be DriftCorrected, got %s", fake.Events[2].Reason)
+	if fake.Events()[2].Reason != EventReasonDriftCorrected {
+		t.Errorf("Third event should be DriftCorrected, got %s", fake.Events()[2].Reason)
 	}
 }
 
 func TestEventRecorder_InvalidIgnoreFields(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -404,11 +439,11 @@ func TestEventRecorder_InvalidIgnoreFields(t *testing.T) {
 
 	recorder.InvalidIgnoreFields(obj, "ConfigMap", "default", "my-config", "invalid pointer")
 
-	if len(fake.Events) != 1 {
-		t.Fatalf("Expected 1 event, got %d", len(fake.Events))
+	if len(fake.Events()) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(fake.Events()))
 	}
 
-	event := fake.Events[0]
+	event := fake.Events()[0]
 	if event.EventType != EventTypeWarning {
 		t.Errorf("Expected EventType=%s, got %s", EventTypeWarning, event.EventType)
 	}
@@ -421,7 +456,7 @@ func TestEventRecorder_InvalidIgnoreFields(t *testing.T) {
 }
 
 func TestEventRecorder_NoDriftDetected(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 
 	obj := &unstructured.Unstructured{}
@@ -429,11 +464,11 @@ func TestEventRecorder_NoDriftDetected(t *testing.T) {
 
 	recorder.NoDriftDetected(obj, "ConfigMap", "default", "my-config")
 
-	if len(fake.Events) != 1 {
-		t.Fatalf("Expected 1 event, got %d", len(fake.Events))
+	if len(fake.Events()) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(fake.Events()))
 	}
 
-	event := fake.Events[0]
+	event := fake.Events()[0]
 	if event.EventType != EventTypeNormal {
 		t.Errorf("Expected EventType=%s, got %s", EventTypeNormal, event.EventType)
 	}
@@ -462,22 +497,60 @@ func TestAssetNameAction(t *testing.T) {
 }
 
 func TestEventRecorder_DeduplicationKeys(t *testing.T) {
-	fake := &FakeRecorder{}
+	fake := eventtest.NewRecorder()
 	recorder := NewEventRecorder(fake)
 	obj := &unstructured.Unstructured{}
 
 	recorder.DriftDetected(obj, "ConfigMap", "default", "config-a")
 	recorder.DriftDetected(obj, "ConfigMap", "default", "config-b")
 
-	if len(fake.Events) != 2 {
-		t.Fatalf("Expected 2 events, got %d", len(fake.Events))
+	if len(fake.Events()) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(fake.Events()))
 	}
 
-	if fake.Events[0].Action == fake.Events[1].Action {
-		t.Errorf("Actions should differ for different assets: both are %q", fake.Events[0].Action)
+	if fake.Events()[0].Action == fake.Events()[1].Action {
+		t.Errorf("Actions should differ for different assets: both are %q", fake.Events()[0].Action)
 	}
 
-	if fake.Events[0].Reason != fake.Events[1].Reason {
-		t.Errorf("Reasons should be the same: got %q and %q", fake.Events[0].Reason, fake.Events[1].Reason)
+	if fake.Events()[0].Reason != fake.Events()[1].Reason {
+		t.Errorf("Reasons should be the same: got %q and %q", fake.Events()[0].Reason, fake.Events()[1].Reason)
+	}
+}
+
+func TestEventRecorder_ObjectEvents(t *testing.T) {
+	fake := eventtest.NewRecorder()
+	recorder := NewEventRecorder(fake)
+
+	hco := &unstructured.Unstructured{}
+	hco.SetName("kubevirt-hyperconverged")
+	mc := &unstructured.Unstructured{}
+	mc.SetName("90-worker-swap-online")
+
+	recorder.ObjectDriftCorrected(mc, hco, "swap-enable", "")
+	recorder.ObjectDriftCorrected(mc, hco, "swap-enable", "kubectl-edit (spec.config)")
+	recorder.ObjectAdopted(mc, hco, "swap-enable")
+	recorder.ObjectApplyFailed(mc, hco, "swap-enable", "webhook denied")
+
+	want := []struct {
+		eventType string
+		reason    string
+		message   string
+	}{
+		{EventTypeNormal, EventReasonDriftCorrected, "virt-platform-autopilot reverted drift from asset swap-enable"},
+		{EventTypeNormal, EventReasonDriftCorrected, "virt-platform-autopilot reverted drift made by kubectl-edit (spec.config) from asset swap-enable"},
+		{EventTypeNormal, EventReasonAdopted, "virt-platform-autopilot adopted this resource for asset swap-enable"},
+		{EventTypeNormal, EventReasonApplyFailed, "virt-platform-autopilot failed to apply asset swap-enable: webhook denied"},
+	}
+	if len(fake.Events()) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(fake.Events()))
+	}
+	for i, w := range want {
+		event := fake.Events()[i]
+		if event.EventType != w.eventType || event.Reason != w.reason || event.Message != w.message {
+			t.Errorf("event %d = %s/%s %q, want %s/%s %q", i, event.EventType, event.Reason, event.Message, w.eventType, w.reason, w.message)
+		}
+		if event.Regarding != mc || event.Related != hco {
+			t.Errorf("event %d should regard the managed object and relate to the HCO", i)
+		}
 	}
 }
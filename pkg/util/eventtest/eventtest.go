@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventtest provides an in-memory events.EventRecorder and assertions on the
+// events it captured, for unit and integration tests of code that records events
+// through util.EventRecorder.
+package eventtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+)
+
+// Event is one captured event
+type Event struct {
+	EventType string
+	Reason    string
+	Action    string
+	Message   string
+	Regarding runtime.Object
+	Related   runtime.Object
+}
+
+// Kind returns the kind of the object the event is regarding
+func (e Event) Kind() string {
+	if e.Regarding == nil {
+		return ""
+	}
+	return e.Regarding.GetObjectKind().GroupVersionKind().Kind
+}
+
+// Name returns the name of the object the event is regarding
+func (e Event) Name() string {
+	if e.Regarding == nil {
+		return ""
+	}
+	accessor, err := meta.Accessor(e.Regarding)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetName()
+}
+
+// Matches reports whether the event has the reason and regards an object of the kind
+// and name; an empty kind or name matches any
+func (e Event) Matches(reason, kind, name string) bool {
+	return e.Reason == reason && (kind == "" || e.Kind() == kind) && (name == "" || e.Name() == name)
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("%s %s %s/%s: %s", e.EventType, e.Reason, e.Kind(), e.Name(), e.Message)
+}
+
+// Recorder captures events in memory. It is safe for concurrent use, so it can be
+// handed to a running controller.
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+var _ events.EventRecorderLogger = &Recorder{}
+
+// NewRecorder returns an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Eventf implements events.EventRecorder
+func (r *Recorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, Event{
+		EventType: eventtype,
+		Reason:    reason,
+		Action:    action,
+		Message:   fmt.Sprintf(note, args...),
+		Regarding: regarding,
+		Related:   related,
+	})
+}
+
+// WithLogger implements events.EventRecorderLogger; the logger is not used
+func (r *Recorder) WithLogger(klog.Logger) events.EventRecorderLogger {
+	return r
+}
+
+// Events returns a copy of the captured events, oldest first
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// LastEvent returns the most recent event, or nil when none was recorded
+func (r *Recorder) LastEvent() *Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.events) == 0 {
+		return nil
+	}
+	event := r.events[len(r.events)-1]
+	return &event
+}
+
+// Reset drops the captured events
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Count returns how many events have the reason
+func (r *Recorder) Count(reason string) int {
+	return len(r.Find(reason, "", ""))
+}
+
+// Find returns the events matching reason, kind and name, oldest first. An empty kind
+// or name matches any.
+func (r *Recorder) Find(reason, kind, name string) []Event {
+	var found []Event
+	for _, event := range r.Events() {
+		if event.Matches(reason, kind, name) {
+			found = append(found, event)
+		}
+	}
+	return found
+}
+
+// TestingT is the part of testing.TB the assertions use; GinkgoT() satisfies it too
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// ExpectEvent fails t unless an event matches reason, kind and name, and returns the
+// most recent match. An empty kind or name matches any.
+func (r *Recorder) ExpectEvent(t TestingT, reason, kind, name string) Event {
+	t.Helper()
+	found := r.Find(reason, kind, name)
+	if len(found) == 0 {
+		t.Errorf("expected a %s event regarding %s, recorded:\n%s", reason, describe(kind, name), r.dump())
+		return Event{}
+	}
+	return found[len(found)-1]
+}
+
+// ExpectNoEvent fails t if any event matches reason, kind and name
+func (r *Recorder) ExpectNoEvent(t TestingT, reason, kind, name string) {
+	t.Helper()
+	if found := r.Find(reason, kind, name); len(found) > 0 {
+		t.Errorf("expected no %s event regarding %s, got:\n%s", reason, describe(kind, name), dump(found))
+	}
+}
+
+func describe(kind, name string) string {
+	if kind == "" {
+		kind = "any kind"
+	}
+	if name == "" {
+		name = "any name"
+	}
+	return kind + " " + name
+}
+
+func (r *Recorder) dump() string {
+	return dump(r.Events())
+}
+
+func dump(events []Event) string {
+	if len(events) == 0 {
+		return "  (none)"
+	}
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		lines = append(lines, "  "+event.String())
+	}
+	return strings.Join(lines, "\n")
+}
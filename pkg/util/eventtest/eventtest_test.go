@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// recordingT captures assertion failures instead of failing the test
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorderAssertions(t *testing.T) {
+	hco := &unstructured.Unstructured{}
+	hco.SetKind("HyperConverged")
+	hco.SetName("kubevirt-hyperconverged")
+	node := &corev1.Node{TypeMeta: metav1.TypeMeta{Kind: "Node"}, ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}}
+
+	rec := NewRecorder()
+	rec.Eventf(hco, nil, corev1.EventTypeNormal, "AssetApplied", "AssetApplied a", "Applied %s", "a")
+	rec.Eventf(hco, nil, corev1.EventTypeNormal, "AssetApplied", "AssetApplied b", "Applied %s", "b")
+	rec.Eventf(node, hco, corev1.EventTypeWarning, "DriftCorrected", "DriftCorrected", "Corrected")
+
+	if got := rec.Count("AssetApplied"); got != 2 {
+		t.Errorf("Count(AssetApplied) = %d, want 2", got)
+	}
+	if got := rec.LastEvent(); got == nil || got.Name() != "worker-0" || got.Kind() != "Node" {
+		t.Errorf("LastEvent() = %v, want the Node event", got)
+	}
+
+	ok := &recordingT{}
+	if event := rec.ExpectEvent(ok, "AssetApplied", "HyperConverged", ""); event.Message != "Applied b" {
+		t.Errorf("ExpectEvent() = %q, want the most recent match", event.Message)
+	}
+	rec.ExpectEvent(ok, "DriftCorrected", "Node", "worker-0")
+	rec.ExpectNoEvent(ok, "DriftCorrected", "HyperConverged", "")
+	if len(ok.errors) != 0 {
+		t.Errorf("assertions on matching events failed: %v", ok.errors)
+	}
+
+	failing := &recordingT{}
+	rec.ExpectEvent(failing, "DriftCorrected", "Node", "worker-1")
+	rec.ExpectNoEvent(failing, "AssetApplied", "", "")
+	if len(failing.errors) != 2 {
+		t.Fatalf("got %d failures, want 2: %v", len(failing.errors), failing.errors)
+	}
+	if !strings.Contains(failing.errors[0], "Warning DriftCorrected Node/worker-0: Corrected") {
+		t.Errorf("failure does not list the recorded events: %s", failing.errors[0])
+	}
+
+	rec.Reset()
+	if events := rec.Events(); len(events) != 0 {
+		t.Errorf("Events() after Reset() = %v, want none", events)
+	}
+}
+
+func TestRecorderConcurrentUse(t *testing.T) {
+	rec := NewRecorder()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec.Eventf(&corev1.Node{}, nil, corev1.EventTypeNormal, "Reason", "Action", "note")
+			_ = rec.Count("Reason")
+		}()
+	}
+	wg.Wait()
+	if got := rec.Count("Reason"); got != 10 {
+		t.Errorf("Count() = %d, want 10", got)
+	}
+}
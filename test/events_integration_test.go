@@ -17,58 +17,29 @@ limitations under the License.
 package test
 
 import (
-	"fmt"
-
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/events"
-	"k8s.io/klog/v2"
 
 	"github.com/kubevirt/virt-platform-autopilot/pkg/assets"
 	pkgcontext "github.com/kubevirt/virt-platform-autopilot/pkg/context"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/overrides"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
 )
 
-// FakeEventRecorder captures events for testing
-type FakeEventRecorder struct {
-	Events []RecordedEvent
-}
-
-type RecordedEvent struct {
-	EventType string
-	Reason    string
-	Action    string
-	Message   string
-}
-
-func (f *FakeEventRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...any) {
-	message := fmt.Sprintf(note, args...)
-	f.Events = append(f.Events, RecordedEvent{
-		EventType: eventtype,
-		Reason:    reason,
-		Action:    action,
-		Message:   message,
-	})
-}
-
-func (f *FakeEventRecorder) WithLogger(logger klog.Logger) events.EventRecorderLogger {
-	return f
-}
-
-func (f *FakeEventRecorder) Reset() {
-	f.Events = []RecordedEvent{}
-}
+const (
+	hcoKind = "HyperConverged"
+	hcoName = "kubevirt-hyperconverged"
+)
 
 var _ = Describe("Event Recording Integration", func() {
 	var (
 		testNs        string
 		patcher       *engine.Patcher
 		eventRecorder *util.EventRecorder
-		fakeRecorder  *FakeEventRecorder
+		fakeRecorder  *eventtest.Recorder
 		renderCtx     *pkgcontext.RenderContext
 	)
 
@@ -90,7 +61,7 @@ var _ = Describe("Event Recording Integration", func() {
 		patcher = engine.NewPatcher(k8sClient, apiReader, loader)
 
 		// Use fake recorder to capture events
-		fakeRecorder = &FakeEventRecorder{}
+		fakeRecorder = eventtest.NewRecorder()
 		eventRecorder = util.NewEventRecorder(fakeRecorder)
 		patcher.SetEventRecorder(eventRecorder)
 
@@ -99,9 +70,9 @@ var _ = Describe("Event Recording Integration", func() {
 			HCO: &unstructured.Unstructured{
 				Object: map[string]any{
 					"apiVersion": "hco.kubevirt.io/v1",
-					"kind":       "HyperConverged",
+					"kind":       hcoKind,
 					"metadata": map[string]any{
-						"name":      "kubevirt-hyperconverged",
+						"name":      hcoName,
 						"namespace": testNs,
 					},
 				},
@@ -162,27 +133,14 @@ var _ = Describe("Event Recording Integration", func() {
 			// since we're not using ReconcileAsset
 			eventRecorder.DriftDetected(renderCtx.HCO, "ConfigMap", testNs, "event-test")
 			eventRecorder.AssetApplied(renderCtx.HCO, "event-test", "ConfigMap", testNs, "event-test")
-			eventRecorder.DriftCorrected(renderCtx.HCO, "ConfigMap", testNs, "event-test")
+			eventRecorder.DriftCorrected(renderCtx.HCO, "ConfigMap", testNs, "event-test", "")
 
 			// Verify events were recorded
-			events := fakeRecorder.Events
-			Expect(events).To(HaveLen(3), "Should have 3 events")
-
-			// Find AssetApplied event
-			foundAssetApplied := false
-			foundDriftCorrected := false
-			for _, event := range events {
-				if event.Reason == util.EventReasonAssetApplied {
-					foundAssetApplied = true
-					Expect(event.EventType).To(Equal(util.EventTypeNormal))
-				}
-				if event.Reason == util.EventReasonDriftCorrected {
-					foundDriftCorrected = true
-					Expect(event.EventType).To(Equal(util.EventTypeNormal))
-				}
-			}
-			Expect(foundAssetApplied).To(BeTrue(), "Should emit AssetApplied event")
-			Expect(foundDriftCorrected).To(BeTrue(), "Should emit DriftCorrected event")
+			Expect(fakeRecorder.Events()).To(HaveLen(3), "Should have 3 events")
+			applied := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonAssetApplied, hcoKind, hcoName)
+			Expect(applied.EventType).To(Equal(util.EventTypeNormal))
+			corrected := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonDriftCorrected, hcoKind, hcoName)
+			Expect(corrected.EventType).To(Equal(util.EventTypeNormal))
 		})
 
 		It("should emit DriftDetected event when drift is found", func() {
@@ -217,23 +175,13 @@ var _ = Describe("Event Recording Integration", func() {
 
 			// Manually emit drift events to test event recording
 			eventRecorder.DriftDetected(renderCtx.HCO, "ConfigMap", testNs, "drift-test")
-			eventRecorder.DriftCorrected(renderCtx.HCO, "ConfigMap", testNs, "drift-test")
+			eventRecorder.DriftCorrected(renderCtx.HCO, "ConfigMap", testNs, "drift-test", "")
 
 			// Verify DriftDetected event
-			foundDrift := false
-			foundCorrected := false
-			for _, event := range fakeRecorder.Events {
-				if event.Reason == util.EventReasonDriftDetected {
-					foundDrift = true
-					Expect(event.EventType).To(Equal(util.EventTypeWarning))
-				}
-				if event.Reason == util.EventReasonDriftCorrected {
-					foundCorrected = true
-					Expect(event.EventType).To(Equal(util.EventTypeNormal))
-				}
-			}
-			Expect(foundDrift).To(BeTrue(), "Should emit DriftDetected event")
-			Expect(foundCorrected).To(BeTrue(), "Should emit DriftCorrected event")
+			drift := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonDriftDetected, hcoKind, hcoName)
+			Expect(drift.EventType).To(Equal(util.EventTypeWarning))
+			corrected := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonDriftCorrected, hcoKind, hcoName)
+			Expect(corrected.EventType).To(Equal(util.EventTypeNormal))
 		})
 	})
 
@@ -267,15 +215,8 @@ var _ = Describe("Event Recording Integration", func() {
 			eventRecorder.PatchApplied(renderCtx.HCO, "ConfigMap", testNs, "patch-test", 1)
 
 			// Verify PatchApplied event
-			foundPatchApplied := false
-			for _, event := range fakeRecorder.Events {
-				if event.Reason == util.EventReasonPatchApplied {
-					foundPatchApplied = true
-					Expect(event.EventType).To(Equal(util.EventTypeNormal))
-					break
-				}
-			}
-			Expect(foundPatchApplied).To(BeTrue(), "Should emit PatchApplied event")
+			patchApplied := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonPatchApplied, hcoKind, hcoName)
+			Expect(patchApplied.EventType).To(Equal(util.EventTypeNormal))
 		})
 
 		It("should emit InvalidPatch event when JSON patch is invalid", func() {
@@ -307,15 +248,8 @@ var _ = Describe("Event Recording Integration", func() {
 			eventRecorder.InvalidPatch(renderCtx.HCO, "ConfigMap", testNs, "invalid-patch-test", "invalid op: invalid")
 
 			// Verify InvalidPatch event
-			foundInvalidPatch := false
-			for _, event := range fakeRecorder.Events {
-				if event.Reason == util.EventReasonInvalidPatch {
-					foundInvalidPatch = true
-					Expect(event.EventType).To(Equal(util.EventTypeWarning))
-					break
-				}
-			}
-			Expect(foundInvalidPatch).To(BeTrue(), "Should emit InvalidPatch event")
+			invalidPatch := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonInvalidPatch, hcoKind, hcoName)
+			Expect(invalidPatch.EventType).To(Equal(util.EventTypeWarning))
 		})
 	})
 
@@ -349,15 +283,8 @@ var _ = Describe("Event Recording Integration", func() {
 			eventRecorder.UnmanagedMode(renderCtx.HCO, "ConfigMap", testNs, "unmanaged-test")
 
 			// Verify UnmanagedMode event
-			foundUnmanaged := false
-			for _, event := range fakeRecorder.Events {
-				if event.Reason == util.EventReasonUnmanagedMode {
-					foundUnmanaged = true
-					Expect(event.EventType).To(Equal(util.EventTypeNormal))
-					break
-				}
-			}
-			Expect(foundUnmanaged).To(BeTrue(), "Should emit UnmanagedMode event")
+			unmanaged := fakeRecorder.ExpectEvent(GinkgoT(), util.EventReasonUnmanagedMode, hcoKind, hcoName)
+			Expect(unmanaged.EventType).To(Equal(util.EventTypeNormal))
 		})
 	})
 
@@ -317,7 +317,7 @@ var _ = Describe("Metrics Integration", func() {
 
 			By("verifying patch customization metric")
 			expected := `
-				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 				# TYPE kubevirt_autopilot_customization_info gauge
 				kubevirt_autopilot_customization_info{kind="ConfigMap",name="patched-cm",namespace="` + testNs + `",type="patch"} 1
 			`
@@ -338,7 +338,7 @@ var _ = Describe("Metrics Integration", func() {
 
 			By("verifying ignore customization metric")
 			expected := `
-				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 				# TYPE kubevirt_autopilot_customization_info gauge
 				kubevirt_autopilot_customization_info{kind="ConfigMap",name="ignored-cm",namespace="` + testNs + `",type="ignore"} 1
 			`
@@ -359,7 +359,7 @@ var _ = Describe("Metrics Integration", func() {
 
 			By("verifying unmanaged customization metric")
 			expected := `
-				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 				# TYPE kubevirt_autopilot_customization_info gauge
 				kubevirt_autopilot_customization_info{kind="ConfigMap",name="unmanaged-cm",namespace="` + testNs + `",type="unmanaged"} 1
 			`
@@ -378,7 +378,7 @@ var _ = Describe("Metrics Integration", func() {
 
 			By("verifying both customization metrics exist")
 			expected := `
-				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 				# TYPE kubevirt_autopilot_customization_info gauge
 				kubevirt_autopilot_customization_info{kind="ConfigMap",name="multi-custom-cm",namespace="` + testNs + `",type="ignore"} 1
 				kubevirt_autopilot_customization_info{kind="ConfigMap",name="multi-custom-cm",namespace="` + testNs + `",type="patch"} 1
@@ -519,7 +519,7 @@ var _ = Describe("Metrics Integration", func() {
 
 			By("verifying customization metric")
 			expectedCustom := `
-				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged
+				# HELP kubevirt_autopilot_customization_info Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated
 				# TYPE kubevirt_autopilot_customization_info gauge
 				kubevirt_autopilot_customization_info{kind="ConfigMap",name="e2e-cm",namespace="` + testNs + `",type="patch"} 1
 			`
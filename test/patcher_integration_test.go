@@ -171,7 +171,7 @@ var _ = Describe("Patched Baseline Algorithm Integration", func() {
 			}
 
 			// Apply patch
-			patched, err := overrides.ApplyJSONPatch(obj)
+			patched, err := overrides.ApplyPatch(obj)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(patched).To(BeTrue())
 
@@ -490,7 +490,7 @@ var _ = Describe("Patched Baseline Algorithm Integration", func() {
 			}
 
 			// 2. Apply JSON patch
-			patched, err := overrides.ApplyJSONPatch(desiredState)
+			patched, err := overrides.ApplyPatch(desiredState)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(patched).To(BeTrue())
 
@@ -78,7 +78,7 @@ var _ = Describe("Prometheus Alert Rules", Ordered, func() {
 		Expect(criticalGroup["name"]).To(Equal("virt-platform-autopilot.critical"))
 
 		criticalRules := criticalGroup["rules"].([]any)
-		Expect(criticalRules).To(HaveLen(1), "critical group should have 1 alert")
+		Expect(criticalRules).To(HaveLen(2), "critical group should have 2 alerts")
 
 		syncFailedAlert := criticalRules[0].(map[string]any)
 		Expect(syncFailedAlert["alert"]).To(Equal("VirtPlatformSyncFailed"))
@@ -88,12 +88,17 @@ var _ = Describe("Prometheus Alert Rules", Ordered, func() {
 		labels := syncFailedAlert["labels"].(map[string]any)
 		Expect(labels["severity"]).To(Equal("critical"))
 
+		blastRadiusAlert := criticalRules[1].(map[string]any)
+		Expect(blastRadiusAlert["alert"]).To(Equal("VirtPlatformBlastRadiusExceeded"))
+		Expect(blastRadiusAlert["expr"]).To(ContainSubstring("kubevirt_autopilot_blast_radius_held > 0"))
+		Expect(blastRadiusAlert["labels"].(map[string]any)["severity"]).To(Equal("critical"))
+
 		By("verifying warning alert group")
 		warningGroup := groups[1].(map[string]any)
 		Expect(warningGroup["name"]).To(Equal("virt-platform-autopilot.warning"))
 
 		warningRules := warningGroup["rules"].([]any)
-		Expect(warningRules).To(HaveLen(3), "warning group should have 3 alerts")
+		Expect(warningRules).To(HaveLen(4), "warning group should have 4 alerts")
 
 		// Verify thrashing alert
 		thrashingAlert := warningRules[0].(map[string]any)
@@ -111,6 +116,12 @@ var _ = Describe("Prometheus Alert Rules", Ordered, func() {
 		Expect(tombstoneAlert["alert"]).To(Equal("VirtPlatformTombstoneStuck"))
 		Expect(tombstoneAlert["expr"]).To(ContainSubstring("kubevirt_autopilot_tombstone_status < 0"))
 		Expect(tombstoneAlert["for"]).To(Equal("30m"))
+
+		// Verify cache memory alert
+		cacheAlert := warningRules[3].(map[string]any)
+		Expect(cacheAlert["alert"]).To(Equal("VirtPlatformCacheMemoryHigh"))
+		Expect(cacheAlert["expr"]).To(ContainSubstring("kubevirt_autopilot_cache_estimated_bytes > 268435456"))
+		Expect(cacheAlert["for"]).To(Equal("30m"))
 	})
 
 	It("should have proper labels and annotations on all alerts", func() {
@@ -28,13 +28,14 @@ import (
 	"github.com/kubevirt/virt-platform-autopilot/pkg/engine"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/observability"
 	"github.com/kubevirt/virt-platform-autopilot/pkg/util"
+	"github.com/kubevirt/virt-platform-autopilot/pkg/util/eventtest"
 )
 
 var _ = Describe("Tombstone Integration", func() {
 	var (
 		testNs              string
 		tombstoneReconciler *engine.TombstoneReconciler
-		fakeRecorder        *FakeEventRecorder
+		fakeRecorder        *eventtest.Recorder
 		eventRecorder       *util.EventRecorder
 		hco                 *unstructured.Unstructured
 	)
@@ -57,7 +58,7 @@ var _ = Describe("Tombstone Integration", func() {
 		tombstoneReconciler = engine.NewTombstoneReconciler(k8sClient, loader)
 
 		// Use fake recorder to capture events
-		fakeRecorder = &FakeEventRecorder{}
+		fakeRecorder = eventtest.NewRecorder()
 		eventRecorder = util.NewEventRecorder(fakeRecorder)
 		tombstoneReconciler.SetEventRecorder(eventRecorder)
 
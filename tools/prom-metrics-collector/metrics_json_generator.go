@@ -56,7 +56,7 @@ var metricFamilies = []*dto.MetricFamily{
 	},
 	{
 		Name: strPtr("kubevirt_autopilot_customization_info"),
-		Help: strPtr("Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged"),
+		Help: strPtr("Tracks intentional customizations (always 1 when present). Type: patch/ignore/unmanaged/delegated"),
 		Type: typePtr(dto.MetricType_GAUGE),
 	},
 	{